@@ -3,14 +3,39 @@ package main
 import (
 	"fmt"
 	"github.com/EmilGeorgiev/btc-node/common"
+	"github.com/EmilGeorgiev/btc-node/mempool"
 	"math"
 	"net"
+	"path/filepath"
 	"time"
 )
 
 const (
 	mainnet = "mainnet"
 	simnet  = "simnet"
+
+	// the storage backends of the blocks
+	backendBolt   = "bolt"
+	backendMemory = "memory"
+	backendPebble = "pebble"
+
+	// minPruneTarget is the minimum size in MiB of the stored blocks in pruned mode.
+	minPruneTarget = 550
+
+	// defaultBlockDownloadWindow is the number of blocks that can be requested from one peer at the same time.
+	defaultBlockDownloadWindow = 16
+	// defaultBlockDownloadTimeout is the time for which a peer must deliver a requested block.
+	defaultBlockDownloadTimeout = 60 * time.Second
+	// defaultSyncPeerCheckInterval is the interval at which the tips and the throughput of the peers are checked.
+	defaultSyncPeerCheckInterval = time.Minute
+	// defaultMinSyncPeerThroughput is the throughput in bytes per second below which the sync peer is switched.
+	defaultMinSyncPeerThroughput = 20_000
+	// defaultMaxMempool is the maximum size of the mempool in MB.
+	defaultMaxMempool = 300
+	// defaultRPCBind is the address on which the RPC server listens, the same as the mainnet RPC port of bitcoind.
+	defaultRPCBind = "127.0.0.1:8332"
+	// cookieFile is the file in the directory of the database in which the RPC cookie is written.
+	cookieFile = ".cookie"
 )
 
 type Config struct {
@@ -18,6 +43,24 @@ type Config struct {
 	Network                string
 	UserAgent              string
 	DBPath                 string
+	DBBackend              string
+	BlocksDir              string
+	Prune                  uint64
+	TxIndex                bool
+	AddrIndex              bool
+	BlockDownloadWindow    int
+	BlockDownloadTimeout   time.Duration
+	SyncPeerCheckInterval  time.Duration
+	MinSyncPeerThroughput  uint64
+	MaxMempool             uint64
+	MinRelayTxFee          int64
+	MempoolFullRBF         bool
+	Server                 bool
+	REST                   bool
+	RPCBind                string
+	RPCUser                string
+	RPCPassword            string
+	RPCCookieFile          string
 	PingInterval           time.Duration
 	PingTimeout            time.Duration
 	ReadTimeout            time.Duration
@@ -43,5 +86,122 @@ func (c Config) Validate() error {
 		return fmt.Errorf("failed validating config. Network: %s is not valid. Allowed values are [mainnet, simnet]", c.Network)
 	}
 
+	switch c.DBBackend {
+	case "", backendBolt, backendMemory, backendPebble:
+	default:
+		return fmt.Errorf("failed validating config. DBBackend: %s is not valid. Allowed values are [%s, %s, %s]",
+			c.DBBackend, backendBolt, backendMemory, backendPebble)
+	}
+
+	if c.BlocksDir != "" && c.DBBackend != "" && c.DBBackend != backendBolt {
+		return fmt.Errorf("failed validating config. BlocksDir can be used only with the %s dbbackend", backendBolt)
+	}
+
+	if c.Prune != 0 && c.Prune < minPruneTarget {
+		return fmt.Errorf("failed validating config. Prune: %d is not valid, it must be 0 (disabled) or at least %d MiB", c.Prune, minPruneTarget)
+	}
+
+	if c.Prune != 0 && c.BlocksDir == "" {
+		return fmt.Errorf("failed validating config. Prune requires the blocks to be stored in flat files, blocksdir must be set")
+	}
+
+	if c.Prune != 0 && c.TxIndex {
+		return fmt.Errorf("failed validating config. Prune is incompatible with txindex")
+	}
+
+	if c.Prune != 0 && c.AddrIndex {
+		return fmt.Errorf("failed validating config. Prune is incompatible with addrindex")
+	}
+
+	if c.BlockDownloadWindow < 0 {
+		return fmt.Errorf("failed validating config. BlockDownloadWindow: %d is not valid, it must be positive or 0 (default)", c.BlockDownloadWindow)
+	}
+
+	if c.BlockDownloadTimeout < 0 {
+		return fmt.Errorf("failed validating config. BlockDownloadTimeout: %s is not valid, it must be positive or 0 (default)", c.BlockDownloadTimeout)
+	}
+	if c.MinRelayTxFee < 0 {
+		return fmt.Errorf("failed validating config. MinRelayTxFee: %d is not valid, it must be positive or 0 (default)", c.MinRelayTxFee)
+	}
+	if c.SyncPeerCheckInterval < 0 {
+		return fmt.Errorf("failed validating config. SyncPeerCheckInterval: %s is not valid, it must be positive or 0 (default)", c.SyncPeerCheckInterval)
+	}
+	if c.RPCBind != "" {
+		if _, _, err := net.SplitHostPort(c.RPCBind); err != nil {
+			return fmt.Errorf("failed validating config. RPCBind: %s is not valid, it must be host:port", c.RPCBind)
+		}
+	}
+	if (c.RPCUser == "") != (c.RPCPassword == "") {
+		return fmt.Errorf("failed validating config. RPCUser and RPCPassword must be set together")
+	}
+	if c.REST && !c.Server {
+		return fmt.Errorf("failed validating config. REST is served by the RPC server, server must be true")
+	}
+
 	return nil
 }
+
+// blockDownloadWindow returns the configured number of blocks that can be requested from one peer at the same time.
+func (c Config) blockDownloadWindow() int {
+	if c.BlockDownloadWindow == 0 {
+		return defaultBlockDownloadWindow
+	}
+	return c.BlockDownloadWindow
+}
+
+// blockDownloadTimeout returns the configured time for which a peer must deliver a requested block.
+func (c Config) blockDownloadTimeout() time.Duration {
+	if c.BlockDownloadTimeout == 0 {
+		return defaultBlockDownloadTimeout
+	}
+	return c.BlockDownloadTimeout
+}
+
+// syncPeerCheckInterval returns the configured interval at which the sync peer is checked.
+func (c Config) syncPeerCheckInterval() time.Duration {
+	if c.SyncPeerCheckInterval == 0 {
+		return defaultSyncPeerCheckInterval
+	}
+	return c.SyncPeerCheckInterval
+}
+
+// minSyncPeerThroughput returns the configured throughput in bytes per second below which the sync peer is switched.
+func (c Config) minSyncPeerThroughput() uint64 {
+	if c.MinSyncPeerThroughput == 0 {
+		return defaultMinSyncPeerThroughput
+	}
+	return c.MinSyncPeerThroughput
+}
+
+// maxMempool returns the configured maximum size of the mempool in bytes.
+func (c Config) maxMempool() int64 {
+	if c.MaxMempool == 0 {
+		return defaultMaxMempool * 1_000_000
+	}
+	return int64(c.MaxMempool) * 1_000_000
+}
+
+// minRelayTxFee returns the configured minimum fee rate of the relayed transactions in satoshis per 1000 virtual bytes.
+func (c Config) minRelayTxFee() int64 {
+	if c.MinRelayTxFee == 0 {
+		return mempool.DefaultMinRelayFeeRate
+	}
+	return c.MinRelayTxFee
+}
+
+// rpcBind returns the configured address on which the RPC server listens.
+func (c Config) rpcBind() string {
+	if c.RPCBind == "" {
+		return defaultRPCBind
+	}
+	return c.RPCBind
+}
+
+// rpcCookieFile returns the configured path of the RPC cookie file, by default it is in the directory of
+// the database.
+func (c Config) rpcCookieFile() string {
+	if c.RPCCookieFile == "" {
+		return filepath.Join(filepath.Dir(c.DBPath), cookieFile)
+	}
+	return c.RPCCookieFile
+}
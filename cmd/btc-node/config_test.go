@@ -1,7 +1,9 @@
 package main
 
 import (
+	"github.com/EmilGeorgiev/btc-node/common"
 	"testing"
+	"time"
 )
 
 func TestConfigValidate(t *testing.T) {
@@ -13,7 +15,7 @@ func TestConfigValidate(t *testing.T) {
 		{
 			name: "valid configuration mainnet",
 			config: Config{
-				PeerAddrs: []Addr{
+				PeerAddrs: []common.Addr{
 					{IP: "192.168.1.1", Port: 8333},
 					{IP: "10.0.0.1", Port: 18333},
 				},
@@ -24,7 +26,7 @@ func TestConfigValidate(t *testing.T) {
 		{
 			name: "valid configuration simnet",
 			config: Config{
-				PeerAddrs: []Addr{
+				PeerAddrs: []common.Addr{
 					{IP: "192.168.1.1", Port: 8333},
 				},
 				Network: "simnet",
@@ -34,7 +36,7 @@ func TestConfigValidate(t *testing.T) {
 		{
 			name: "invalid IP address",
 			config: Config{
-				PeerAddrs: []Addr{
+				PeerAddrs: []common.Addr{
 					{IP: "invalid_ip", Port: 8333},
 				},
 				Network: "mainnet",
@@ -44,7 +46,7 @@ func TestConfigValidate(t *testing.T) {
 		{
 			name: "invalid port number (too low)",
 			config: Config{
-				PeerAddrs: []Addr{
+				PeerAddrs: []common.Addr{
 					{IP: "192.168.1.1", Port: -1},
 				},
 				Network: "mainnet",
@@ -54,17 +56,156 @@ func TestConfigValidate(t *testing.T) {
 		{
 			name: "invalid port number (too high)",
 			config: Config{
-				PeerAddrs: []Addr{
+				PeerAddrs: []common.Addr{
 					{IP: "192.168.1.1", Port: 70000},
 				},
 				Network: "mainnet",
 			},
 			expectErr: true,
 		},
+		{
+			name: "valid prune target",
+			config: Config{
+				Network:   "mainnet",
+				BlocksDir: "/tmp/blocks",
+				Prune:     550,
+			},
+			expectErr: false,
+		},
+		{
+			name: "prune target too low",
+			config: Config{
+				Network:   "mainnet",
+				BlocksDir: "/tmp/blocks",
+				Prune:     549,
+			},
+			expectErr: true,
+		},
+		{
+			name: "prune without blocks dir",
+			config: Config{
+				Network: "mainnet",
+				Prune:   1000,
+			},
+			expectErr: true,
+		},
+		{
+			name: "prune with txindex",
+			config: Config{
+				Network:   "mainnet",
+				BlocksDir: "/tmp/blocks",
+				Prune:     1000,
+				TxIndex:   true,
+			},
+			expectErr: true,
+		},
+		{
+			name: "prune with addrindex",
+			config: Config{
+				Network:   "mainnet",
+				BlocksDir: "/tmp/blocks",
+				Prune:     1000,
+				AddrIndex: true,
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid pebble dbbackend",
+			config: Config{
+				Network:   "mainnet",
+				DBBackend: "pebble",
+			},
+			expectErr: false,
+		},
+		{
+			name: "negative block download window",
+			config: Config{
+				Network:             "mainnet",
+				BlockDownloadWindow: -1,
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative block download timeout",
+			config: Config{
+				Network:              "mainnet",
+				BlockDownloadTimeout: -time.Second,
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative min relay tx fee",
+			config: Config{
+				Network:       "mainnet",
+				MinRelayTxFee: -1,
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative sync peer check interval",
+			config: Config{
+				Network:               "mainnet",
+				SyncPeerCheckInterval: -time.Second,
+			},
+			expectErr: true,
+		},
+		{
+			name: "valid rpc credentials",
+			config: Config{
+				Network:     "mainnet",
+				Server:      true,
+				REST:        true,
+				RPCBind:     "127.0.0.1:8332",
+				RPCUser:     "user",
+				RPCPassword: "password",
+			},
+			expectErr: false,
+		},
+		{
+			name: "rpc user without password",
+			config: Config{
+				Network: "mainnet",
+				RPCUser: "user",
+			},
+			expectErr: true,
+		},
+		{
+			name: "rest without rpc server",
+			config: Config{
+				Network: "mainnet",
+				REST:    true,
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid rpc bind",
+			config: Config{
+				Network: "mainnet",
+				RPCBind: "127.0.0.1",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid dbbackend",
+			config: Config{
+				Network:   "mainnet",
+				DBBackend: "leveldb",
+			},
+			expectErr: true,
+		},
+		{
+			name: "blocks dir with memory dbbackend",
+			config: Config{
+				Network:   "mainnet",
+				DBBackend: "memory",
+				BlocksDir: "/tmp/blocks",
+			},
+			expectErr: true,
+		},
 		{
 			name: "invalid network",
 			config: Config{
-				PeerAddrs: []Addr{
+				PeerAddrs: []common.Addr{
 					{IP: "192.168.1.1", Port: 8333},
 				},
 				Network: "invalidnet",
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/EmilGeorgiev/btc-node/node"
+)
+
+const (
+	formatBootstrap = "bootstrap"
+	formatNDJSON    = "ndjson"
+)
+
+// RunExport exports the stored blocks from height "from" to height "to" to the file with the given
+// path (or to the standard output when the path is empty) in the bootstrap.dat or the NDJSON format.
+func RunExport(cfg Config, format, path string, from, to int32) error {
+	if format != formatBootstrap && format != formatNDJSON {
+		return fmt.Errorf("unsupported format %s. Allowed values are [%s, %s]", format, formatBootstrap, formatNDJSON)
+	}
+
+	st := openStorage(cfg)
+	defer st.Close()
+
+	exporter, err := node.NewBlockExporter(cfg.Network, st.blockRepo)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the block exporter: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	export := exporter.ExportBootstrap
+	if format == formatNDJSON {
+		export = exporter.ExportNDJSON
+	}
+
+	n, err := export(w, from, to)
+	if err != nil {
+		return fmt.Errorf("export stopped after %d blocks: %w", n, err)
+	}
+
+	log.Printf("exported %d blocks\n", n)
+	return nil
+}
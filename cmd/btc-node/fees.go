@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+)
+
+const (
+	// feeEstimatesFile is the file in the directory of the database in which the fee estimator is saved.
+	feeEstimatesFile = "fee_estimates.dat"
+	// maxFeeEstimatesAge is the age after which the saved fee estimates are too old to be used.
+	maxFeeEstimatesAge = 60 * time.Hour
+)
+
+// feeEstimatesPath returns the path of the saved fee estimator, it is empty when the blocks are kept in
+// memory and nothing is saved.
+func (c Config) feeEstimatesPath() string {
+	if c.DBBackend == backendMemory {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(c.DBPath), feeEstimatesFile)
+}
+
+// loadFeeEstimator reads the fee estimator that is saved at the last stop of the node. A new one is
+// created when there is no saved estimator or it is too old.
+func loadFeeEstimator(cfg Config) *mempool.FeeEstimator {
+	path := cfg.feeEstimatesPath()
+	if path == "" {
+		return mempool.NewFeeEstimator()
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return mempool.NewFeeEstimator()
+	}
+	if err != nil {
+		log.Printf("failed to open the fee estimates: %s\n", err)
+		return mempool.NewFeeEstimator()
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil && time.Since(info.ModTime()) > maxFeeEstimatesAge {
+		log.Printf("the fee estimates are older than %s and are not used\n", maxFeeEstimatesAge)
+		return mempool.NewFeeEstimator()
+	}
+	fe, err := mempool.ReadFeeEstimator(f)
+	if err != nil {
+		log.Printf("failed to read the fee estimates: %s\n", err)
+		return mempool.NewFeeEstimator()
+	}
+	log.Println("loaded the fee estimates from", path)
+	return fe
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoadFeeEstimator(t *testing.T) {
+	cfg := Config{DBPath: filepath.Join(t.TempDir(), "btc-node.db")}
+
+	// every block confirms the transaction of the previous one
+	fe := loadFeeEstimator(cfg)
+	for h := int32(1); h <= 50; h++ {
+		fe.ProcessBlock(h, [][32]byte{{byte(h - 1)}})
+		fe.ProcessTx([32]byte{byte(h)}, h, 5000)
+	}
+	require.NoError(t, filePersister{path: cfg.feeEstimatesPath(), write: fe.Write}.Persist())
+
+	_, err := loadFeeEstimator(cfg).EstimateSmartFee(2, mempool.EstimateEconomical)
+	require.NoError(t, err)
+
+	// the old and the invalid estimates are not used
+	old := time.Now().Add(-61 * time.Hour)
+	require.NoError(t, os.Chtimes(cfg.feeEstimatesPath(), old, old))
+	_, err = loadFeeEstimator(cfg).EstimateSmartFee(2, mempool.EstimateEconomical)
+	require.ErrorIs(t, err, mempool.ErrInsufficientData)
+	require.NoError(t, os.WriteFile(cfg.feeEstimatesPath(), []byte{1}, 0600))
+	_, err = loadFeeEstimator(cfg).EstimateSmartFee(2, mempool.EstimateEconomical)
+	require.ErrorIs(t, err, mempool.ErrInsufficientData)
+
+	require.Empty(t, Config{DBBackend: backendMemory}.feeEstimatesPath())
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/EmilGeorgiev/btc-node/node"
+)
+
+// RunImport imports the blocks from the Bitcoin Core blk*.dat files or bootstrap.dat files into the
+// storage of the node. The blocks go through the same validation as the blocks received from peers.
+func RunImport(cfg Config, paths []string) error {
+	st := openStorage(cfg)
+	defer st.Close()
+
+	processor := node.NewMsgBlockHandler(st.blockRepo, node.NewBlockValidator(st.blockRepo), st.chainState, st.pruner, st.indexers, nil, nil, nil)
+	importer, err := node.NewBlockImporter(cfg.Network, st.chainState, processor)
+	if err != nil {
+		return fmt.Errorf("failed to initialize the block importer: %w", err)
+	}
+
+	n, err := importer.Import(paths)
+	if err != nil {
+		return fmt.Errorf("import stopped after %d blocks: %w", n, err)
+	}
+
+	log.Printf("imported %d blocks\n", n)
+	fmt.Printf("imported %d blocks\n", n)
+	return nil
+}
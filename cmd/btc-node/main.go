@@ -2,21 +2,132 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"gopkg.in/yaml.v3"
 	"log"
 	"os"
+
+	"github.com/EmilGeorgiev/btc-node/node"
 )
 
+const usage = `Usage:
+  btc-node [flags]                 run the node
+  btc-node import [flags] FILE...  import blocks from Bitcoin Core blk*.dat files or bootstrap.dat
+  btc-node export [flags]          export blocks as bootstrap.dat or NDJSON
+  btc-node verifychain [flags]     verify the last blocks and optionally roll back to the last good block
+  btc-node dumptxoutset [flags]    write the UTXO set at the best block to a snapshot file
+  btc-node loadtxoutset FILE       load a UTXO snapshot into a node without blocks
+`
+
 func main() {
-	configPath := flag.String("config", "./example_config.yaml", "path to the configuration file")
-	logsPath := flag.String("logs_path", "/tmp/app.log", "path to the configuration file")
-	flag.Parse()
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		fs := flag.NewFlagSet("import", flag.ExitOnError)
+		cfg, closeLogs := loadConfig(fs, os.Args[2:])
+		defer closeLogs()
+		if fs.NArg() == 0 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+
+		if err := RunImport(cfg, fs.Args()); err != nil {
+			log.Println(err)
+			fmt.Fprintln(os.Stderr, err)
+			closeLogs()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		fs := flag.NewFlagSet("export", flag.ExitOnError)
+		format := fs.String("format", formatBootstrap, "the format of the exported blocks: bootstrap or ndjson")
+		out := fs.String("out", "", "path to the output file, the standard output is used when it is empty")
+		from := fs.Int("from", 1, "the height of the first exported block")
+		to := fs.Int("to", 0, "the height of the last exported block, 0 means the last stored block")
+		cfg, closeLogs := loadConfig(fs, os.Args[2:])
+		defer closeLogs()
+
+		if err := RunExport(cfg, *format, *out, int32(*from), int32(*to)); err != nil {
+			log.Println(err)
+			fmt.Fprintln(os.Stderr, err)
+			closeLogs()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "verifychain" {
+		fs := flag.NewFlagSet("verifychain", flag.ExitOnError)
+		depth := fs.Int("depth", 6, "the number of the verified blocks, 0 means all blocks")
+		level := fs.Int("level", node.VerifyLevelUTXO, "how thorough the verification is: "+
+			"0 - the blocks are stored under their hashes and are linked, 1 - proof of work and merkle root, 2 - UTXO set consistency")
+		rollback := fs.Bool("rollback", false, "roll back the chain to the last good block when a corrupted block is found")
+		cfg, closeLogs := loadConfig(fs, os.Args[2:])
+		defer closeLogs()
+
+		if err := RunVerifyChain(cfg, *depth, *level, *rollback); err != nil {
+			log.Println(err)
+			fmt.Fprintln(os.Stderr, err)
+			closeLogs()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "dumptxoutset" {
+		fs := flag.NewFlagSet("dumptxoutset", flag.ExitOnError)
+		out := fs.String("out", "utxo.dat", "path to the snapshot file")
+		cfg, closeLogs := loadConfig(fs, os.Args[2:])
+		defer closeLogs()
+
+		if err := RunDumpTxOutSet(cfg, *out); err != nil {
+			log.Println(err)
+			fmt.Fprintln(os.Stderr, err)
+			closeLogs()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "loadtxoutset" {
+		fs := flag.NewFlagSet("loadtxoutset", flag.ExitOnError)
+		cfg, closeLogs := loadConfig(fs, os.Args[2:])
+		defer closeLogs()
+		if fs.NArg() != 1 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+
+		if err := RunLoadTxOutSet(cfg, fs.Arg(0)); err != nil {
+			log.Println(err)
+			fmt.Fprintln(os.Stderr, err)
+			closeLogs()
+			os.Exit(1)
+		}
+		return
+	}
+
+	fs := flag.NewFlagSet("btc-node", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	cfg, closeLogs := loadConfig(fs, os.Args[1:])
+	defer closeLogs()
+
+	Run(cfg)
+}
+
+// loadConfig parses the common flags, sets the output of the logs and reads the configuration file.
+func loadConfig(fs *flag.FlagSet, args []string) (Config, func()) {
+	configPath := fs.String("config", "./example_config.yaml", "path to the configuration file")
+	logsPath := fs.String("logs_path", "/tmp/app.log", "path to the configuration file")
+	fs.Parse(args)
 
 	file, err := os.OpenFile(*logsPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
 		log.Fatalf("Failed to open log file: %v", err)
 	}
-	defer file.Close()
 
 	log.SetOutput(file)
 	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
@@ -37,5 +148,5 @@ func main() {
 		log.Fatalf("invalid config file: %s", err)
 	}
 
-	Run(cfg)
+	return cfg, func() { file.Close() }
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+)
+
+// mempoolFile is the file in the directory of the database in which the mempool is dumped.
+const mempoolFile = "mempool.dat"
+
+// mempoolPath returns the path of the dumped mempool, it is empty when the blocks are kept in memory and
+// nothing is saved.
+func (c Config) mempoolPath() string {
+	if c.DBBackend == backendMemory {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(c.DBPath), mempoolFile)
+}
+
+// filePersister persists a state of the node in a file when the node is stopped. The state is written to
+// a temporary file that replaces the saved one, so a failed write doesn't leave a partial state. Nothing
+// is saved when the path is empty.
+type filePersister struct {
+	path  string
+	write func(w io.Writer) error
+}
+
+func (fp filePersister) Persist() error {
+	if fp.path == "" {
+		return nil
+	}
+
+	tmp := fp.path + ".new"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err = errors.Join(fp.write(f), f.Close()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", fp.path, err)
+	}
+	return os.Rename(tmp, fp.path)
+}
+
+// loadMempool adds the transactions that are dumped at the last stop of the node to the mempool. They
+// are validated again, so the transactions that are confirmed or conflict with the chain are dropped.
+func loadMempool(cfg Config, pool *mempool.Mempool) {
+	path := cfg.mempoolPath()
+	if path == "" {
+		return
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Printf("failed to open the dumped mempool: %s\n", err)
+		return
+	}
+	defer f.Close()
+
+	added, dropped, err := pool.Load(f)
+	if err != nil {
+		log.Printf("failed to load the dumped mempool: %s\n", err)
+	}
+	log.Printf("loaded %d transactions from %s, %d are dropped\n", added, path, dropped)
+}
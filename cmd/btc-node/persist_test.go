@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilePersister_KeepsTheSavedStateWhenTheWriteFails(t *testing.T) {
+	cfg := Config{DBPath: filepath.Join(t.TempDir(), "btc-node.db")}
+	write := func(data string, err error) func(w io.Writer) error {
+		return func(w io.Writer) error {
+			_, _ = w.Write([]byte(data))
+			return err
+		}
+	}
+
+	require.NoError(t, filePersister{path: cfg.mempoolPath(), write: write("saved", nil)}.Persist())
+	require.Error(t, filePersister{path: cfg.mempoolPath(), write: write("partial", errors.New("err"))}.Persist())
+
+	data, err := os.ReadFile(cfg.mempoolPath())
+	require.NoError(t, err)
+	require.Equal(t, "saved", string(data))
+	_, err = os.Stat(cfg.mempoolPath() + ".new")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// nothing is saved when the blocks are kept in memory
+	require.Empty(t, Config{DBBackend: backendMemory}.mempoolPath())
+	require.NoError(t, filePersister{write: write("saved", nil)}.Persist())
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"log"
+
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/rpc"
+)
+
+// startRPCServer starts the JSON-RPC server and the REST interface when they are enabled in the config,
+// otherwise it returns nil.
+func startRPCServer(cfg Config, st *storage, peers rpc.Peers, txRelay *node.TxRelay) *rpc.Server {
+	if !cfg.Server {
+		return nil
+	}
+	if st.headerStore == nil {
+		log.Println("the RPC server is not available on a node that is loaded from a UTXO snapshot")
+		return nil
+	}
+
+	s := rpc.NewServer(cfg.Network, cfg.rpcBind(), cfg.RPCUser, cfg.RPCPassword, cfg.rpcCookieFile(), cfg.Prune,
+		cfg.REST, st.blockRepo, st.rawBlocks, st.headerStore, st.chainState, st.utxoSet, peers, txRelay, txRelay)
+	if err := s.Start(); err != nil {
+		log.Fatalf("failed to start the RPC server: %s", err)
+	}
+	return s
+}
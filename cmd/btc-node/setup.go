@@ -3,7 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/hex"
-	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/mempool"
 	"github.com/EmilGeorgiev/btc-node/network"
 	"github.com/EmilGeorgiev/btc-node/network/binary"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 
 	"github.com/EmilGeorgiev/btc-node/node"
@@ -20,13 +21,14 @@ var genesysBlock = "010000000000000000000000000000000000000000000000000000000000
 
 func Run(cfg Config) {
 
-	boltDB, err := db.NewBoltDB(cfg.DBPath)
-	if err != nil {
-		log.Fatalf("can't initialize BoltDB: %s", err)
-	}
-	blockRepo, err := db.NewBlockRepo(boltDB.DB)
-	if err != nil {
-		log.Fatalf("can't initialize Block repository: %s", err)
+	st := openStorage(cfg)
+	defer st.Close()
+	blockRepo := st.blockRepo
+
+	services := uint64(p2p.SrvNodeNetwork)
+	if cfg.Prune > 0 {
+		// a pruned node can serve only the recent blocks (BIP 159)
+		services = p2p.SrvNodeNetworkLimited
 	}
 
 	//storeGenesysBlock(blockRepo)
@@ -46,41 +48,107 @@ func Run(cfg Config) {
 	//PeerSync.Sync  <------------------------------------------------------------- BlockHandler
 	//
 
-	requestHeaders := make(chan sync.RequestedHeaders, 1000)
+	// the transactions that are confirmed by the connected blocks are removed from the mempool, and the
+	// blocks in which they are confirmed are tracked for the fee estimation
+	feeEstimator := loadFeeEstimator(cfg)
+	pool := mempool.New(st.utxoSet, cfg.maxMempool(), cfg.minRelayTxFee(), cfg.MempoolFullRBF, feeEstimator)
+	loadMempool(cfg, pool)
+	indexers := append(slices.Clone(st.indexers), pool)
+	// the transactions of the mempool are relayed between all peers
+	txRelay := node.NewTxRelay(cfg.Network, pool)
+
+	// when the headers are stored, the blocks of the header chain are downloaded from all peers
+	var downloader *node.BlockDownloader
+	if st.headerRepo != nil {
+		blockProcessor := node.NewMsgBlockHandler(blockRepo, node.NewBlockValidator(blockRepo), st.chainState, st.pruner, indexers, nil, nil, nil)
+		// the blocks of a branch that is replaced by the header chain are rolled back
+		rewinder := node.NewChainVerifier(blockRepo, st.utxoSet, indexers)
+		downloader = node.NewBlockDownloader(cfg.Network, st.headerRepo, st.chainTip, blockProcessor, rewinder, cfg.blockDownloadWindow(),
+			cfg.blockDownloadTimeout())
+		downloader.Start()
+		defer downloader.Stop()
+	}
+
 	newServerPeer := func(peer p2p.Peer, err chan node.PeerErr) node.PeerConnectionManager {
+		// the requested headers are per peer, so the headers of the previous sync peer are not received
+		// by the new one when the sync peer is switched
+		requestHeaders := make(chan sync.RequestedHeaders, 1000)
 		chHeaders := make(chan *p2p.MsgHeaders, 1000)
 		chBlock := make(chan *p2p.MsgBlock, 1000)
 		//chProcessedHeaders := make(chan struct{})
-		expectedStartFromHash := make(chan [32]byte, 1000)
+		expectedLocators := make(chan [][32]byte, 1000)
+		chGetData := make(chan *p2p.MsgGetData, 1000)
+		chNotFound := make(chan *p2p.MsgNotFound, 1000)
+		var chGetHeaders chan *p2p.MsgGetHeader
+		var chGetBlocks chan *p2p.MsgGetBlocks
+		chInv := make(chan *p2p.MsgInv, 1000)
+		chTx := make(chan *p2p.MsgTx, 1000)
+		chRelay := make(chan any, 1000)
 		outgoingMsgs := make(chan *p2p.Message, 1000)
+		// the tip of the peer is moved by its validated headers, the sync peer is chosen by its work
+		tip := node.NewPeerTipTracker()
 		//notifyForExpectedBlockHeaders := make(chan []p2p.BlockHeader, 1000)
 
-		blockValidator := node.NewBlockValidator(blockRepo)
-		msgHandlers := []node.StartStop{
-			node.NewMsgHeaderHandler(cfg.Network, outgoingMsgs, chHeaders, expectedStartFromHash, syncCompleted, requestHeaders),
-			node.NewMsgBlockHandler(blockRepo, blockValidator, chBlock, requestHeaders, requestHeaders),
+		// the server peer is created after its handlers, the downloader disconnects it when it stalls
+		var serverPeer *node.ServerPeer
+		disconnect := func(err error) { serverPeer.Disconnect(err) }
+
+		var msgHandlers, overViewMsgHandlers []node.StartStop
+		if downloader != nil {
+			// the header chain is served to the peer, the fork point is found with the block locator
+			chGetHeaders = make(chan *p2p.MsgGetHeader, 1000)
+			chGetBlocks = make(chan *p2p.MsgGetBlocks, 1000)
+			msgHandlers = []node.StartStop{
+				node.NewMsgHeaderHandler(cfg.Network, st.headerRepo, downloader, outgoingMsgs, chHeaders, expectedLocators, syncCompleted, requestHeaders, tip),
+				node.NewMsgGetDataHandler(cfg.Network, blockRepo, pool, chGetData, outgoingMsgs),
+				node.NewMsgGetHeadersHandler(cfg.Network, st.headerRepo, st.chainTip, chGetHeaders, chGetBlocks, outgoingMsgs),
+				node.NewBlockDownloadPeer(peer.Address, downloader, chBlock, chNotFound, outgoingMsgs, disconnect),
+				node.NewTxRelayPeer(peer, txRelay, chInv, chTx, chRelay, outgoingMsgs),
+			}
+			overViewMsgHandlers = msgHandlers[:3]
+		} else {
+			blockValidator := node.NewBlockValidator(blockRepo)
+			msgHandlers = []node.StartStop{
+				node.NewMsgHeaderHandler(cfg.Network, nil, nil, outgoingMsgs, chHeaders, expectedLocators, syncCompleted, requestHeaders, tip),
+				node.NewMsgGetDataHandler(cfg.Network, blockRepo, pool, chGetData, outgoingMsgs),
+				node.NewMsgBlockHandler(blockRepo, blockValidator, st.chainState, st.pruner, indexers, chBlock, requestHeaders, requestHeaders),
+				node.NewTxRelayPeer(peer, txRelay, chInv, chTx, chRelay, outgoingMsgs),
+			}
+			overViewMsgHandlers = msgHandlers[:2]
 		}
-		overViewMsgHandlers := msgHandlers[:1]
 		handlersManager := node.NewMessageHandlersManager(msgHandlers, overViewMsgHandlers)
 
-		headersRequester := sync.NewHeadersRequester(cfg.Network, blockRepo, outgoingMsgs, expectedStartFromHash)
+		headersRequester := sync.NewHeadersRequester(cfg.Network, blockRepo, st.headerRepo, st.chainTip, outgoingMsgs, expectedLocators)
 
 		//processedBlocks := make(chan p2p.MsgBlock)
 		peerSync := sync.NewPeerSync(headersRequester, cfg.SyncWait, requestHeaders)
 		nmrw := network.NewMessageReadWriter(cfg.ReadTimeout, cfg.WriteTimeout)
 		//msgHeaders := make(chan *p2p.MsgHeaders)
 		//msgBlocks := make(chan *p2p.MsgBlock)
-		return node.NewServerPeer(cfg.Network, handlersManager, peerSync, nmrw, peer, outgoingMsgs, err, chHeaders, chBlock)
+		serverPeer = node.NewServerPeer(cfg.Network, handlersManager, peerSync, nmrw, peer, outgoingMsgs, err, chHeaders, chBlock, chGetData, chNotFound, chGetHeaders, chGetBlocks, chInv, chTx, chRelay, tip)
+		return serverPeer
 	}
 
-	hm := p2p.NewHandshakeManager()
+	hm := p2p.NewHandshakeManager(services)
 	peerErr := make(chan node.PeerErr, 1000)
-	n, err := node.New(cfg.Network, cfg.UserAgent, newServerPeer, cfg.PeerAddrs, peerErr, syncCompleted, hm, cfg.GetNextPeerConnMngWait, cfg.ReconnectWait)
+	// the throughput of the sync peer is checked only while blocks are requested from it
+	var inFlight node.InFlightBlocks
+	if downloader != nil {
+		inFlight = downloader
+	}
+	n, err := node.New(cfg.Network, cfg.UserAgent, newServerPeer, cfg.PeerAddrs, peerErr, syncCompleted, hm, cfg.GetNextPeerConnMngWait, cfg.ReconnectWait,
+		cfg.syncPeerCheckInterval(), cfg.minSyncPeerThroughput(), inFlight, []node.Persister{
+			filePersister{path: cfg.mempoolPath(), write: pool.Dump},
+			filePersister{path: cfg.feeEstimatesPath(), write: feeEstimator.Write},
+		})
 	if err != nil {
 		log.Fatalf("failed to initialize the Node: %s", err)
 	}
 
 	n.Start()
+	if rpcServer := startRPCServer(cfg, st, n, txRelay); rpcServer != nil {
+		defer rpcServer.Stop()
+	}
 
 	// Create a signal channel to listen for interrupt or termination signals
 	signalChan := make(chan os.Signal, 1)
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// RunDumpTxOutSet writes the UTXO set at the best block to the file with the given path in the
+// snapshot format of Bitcoin Core.
+func RunDumpTxOutSet(cfg Config, path string) error {
+	st := openStorage(cfg)
+	defer st.Close()
+
+	_, height, err := st.utxoSet.BestBlock()
+	if err != nil {
+		return err
+	}
+
+	// the snapshot is written to a temporary file, so a failed dump doesn't leave a partial snapshot
+	tmp := path + ".incomplete"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	meta, hash, err := st.utxoSet.Dump(f, cfg.Network)
+	if err = errors.Join(err, f.Close()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to dump the UTXO set: %w", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	log.Printf("dumped %d coins at block %x\n", meta.CoinsCount, p2p.Reverse(meta.BaseBlockHash))
+	fmt.Printf("coins_written: %d\nbase_hash: %x\nbase_height: %d\ntxoutset_hash: %x\npath: %s\n",
+		meta.CoinsCount, p2p.Reverse(meta.BaseBlockHash), height, p2p.Reverse(hash), path)
+	return nil
+}
+
+// RunLoadTxOutSet loads the UTXO snapshot from the file with the given path into a node without blocks.
+// When the node is started it syncs from the snapshot height and validates the history in the background.
+func RunLoadTxOutSet(cfg Config, path string) error {
+	st := openStorage(cfg)
+	defer st.Close()
+
+	if _, err := st.blockRepo.GetLast(); !errors.Is(err, sync.ErrNotFound) {
+		return errors.Join(errors.New("a snapshot can be loaded only by a node without blocks"), err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := st.utxoSet.LoadSnapshot(f, cfg.Network)
+	if err != nil {
+		return fmt.Errorf("failed to load the snapshot: %w", err)
+	}
+
+	log.Printf("loaded the UTXO snapshot at block %x\n", p2p.Reverse(data.BlockHash))
+	fmt.Printf("loaded the UTXO snapshot at block %x, height %d\n", p2p.Reverse(data.BlockHash), data.Height)
+	return nil
+}
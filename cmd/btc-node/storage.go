@@ -0,0 +1,176 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/rpc"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// storage holds the databases and the indexes that are used by the node and by the subcommands.
+type storage struct {
+	boltDB    db.BoltDB
+	blockRepo sync.BlockRepository
+	utxoSet   *db.UTXOSet
+	// chainState is the UTXO set, or the SnapshotChainState when the node is loaded from a UTXO snapshot
+	chainState node.ChainState
+	// chainTip is the block after which the blocks are downloaded
+	chainTip sync.ChainTip
+	// headerRepo is nil when the node is loaded from a UTXO snapshot, because the headers before the
+	// snapshot are not downloaded before its blocks
+	headerRepo sync.HeaderRepository
+	// headerStore is the header repository, the RPC server reads the header index from it
+	headerStore *db.HeaderStore
+	// rawBlocks reads the serialized blocks, it is nil when the blocks are not stored in flat files
+	rawBlocks rpc.RawBlockReader
+	pruner    node.BlockPruner
+	indexers  []node.Indexer
+	closers   []func()
+}
+
+// openStorage opens the databases that are configured in the config and starts the indexes.
+func openStorage(cfg Config) *storage {
+	dbPath := cfg.DBPath
+	var closers []func()
+	if cfg.DBBackend == backendMemory {
+		// the UTXO set and the indexes must not outlive the blocks, so they are in a temporary BoltDB
+		dir, err := os.MkdirTemp("", "btc-node")
+		if err != nil {
+			log.Fatalf("can't create a temporary directory: %s", err)
+		}
+		dbPath = filepath.Join(dir, "btc-node.db")
+		closers = append(closers, func() { os.RemoveAll(dir) })
+	}
+
+	boltDB, err := db.NewBoltDB(dbPath)
+	if err != nil {
+		log.Fatalf("can't initialize BoltDB: %s", err)
+	}
+	s := &storage{boltDB: boltDB, closers: append(closers, boltDB.Close)}
+
+	s.utxoSet, err = db.NewUTXOSet(boltDB.DB)
+	if err != nil {
+		log.Fatalf("can't initialize the UTXO set: %s", err)
+	}
+	s.chainState = s.utxoSet
+	s.openSnapshotChainState(cfg, dbPath)
+
+	switch {
+	case cfg.DBBackend == backendMemory:
+		s.blockRepo = db.NewMemoryBlockRepo()
+	case cfg.DBBackend == backendPebble:
+		pebbleRepo, err := db.NewPebbleBlockRepo(cfg.DBPath + ".pebble")
+		if err != nil {
+			log.Fatalf("can't initialize Pebble Block repository: %s", err)
+		}
+		s.closers = append(s.closers, func() { pebbleRepo.Close() })
+		s.blockRepo = pebbleRepo
+	case cfg.BlocksDir != "":
+		// raw blocks are stored in blk*.dat files and BoltDB keeps only their locations
+		flatFileRepo, err := db.NewFlatFileBlockRepo(boltDB.DB, cfg.BlocksDir, cfg.Network)
+		if err != nil {
+			log.Fatalf("can't initialize flat file Block repository: %s", err)
+		}
+		s.closers = append(s.closers, func() { flatFileRepo.Close() })
+		s.blockRepo = flatFileRepo
+		s.rawBlocks = flatFileRepo
+
+		if cfg.Prune > 0 {
+			s.pruner = node.NewPruner(flatFileRepo, s.utxoSet, cfg.Prune)
+		}
+	default:
+		s.blockRepo, err = db.NewBlockRepo(boltDB.DB)
+		if err != nil {
+			log.Fatalf("can't initialize Block repository: %s", err)
+		}
+	}
+
+	if s.chainTip == nil {
+		s.chainTip = sync.NewLastBlockTip(s.blockRepo)
+		s.openHeaderStore(boltDB)
+	}
+
+	if cfg.TxIndex {
+		txIndex, err := db.NewTxIndex(boltDB.DB, s.blockRepo)
+		if err != nil {
+			log.Fatalf("can't initialize the tx index: %s", err)
+		}
+		txIndex.Start()
+		s.closers = append(s.closers, txIndex.Stop)
+		s.indexers = append(s.indexers, txIndex)
+	}
+	if cfg.AddrIndex {
+		addrIndex, err := db.NewAddrIndex(boltDB.DB, s.blockRepo, s.utxoSet)
+		if err != nil {
+			log.Fatalf("can't initialize the address index: %s", err)
+		}
+		addrIndex.Start()
+		s.closers = append(s.closers, addrIndex.Stop)
+		s.indexers = append(s.indexers, addrIndex)
+	}
+
+	return s
+}
+
+// openHeaderStore opens the header store. The headers of the blocks that are stored before the header
+// store existed are added to it.
+func (s *storage) openHeaderStore(boltDB db.BoltDB) {
+	headerStore, err := db.NewHeaderStore(boltDB.DB)
+	if err != nil {
+		log.Fatalf("can't initialize the header store: %s", err)
+	}
+
+	n, err := headerStore.ImportBlocks(s.blockRepo)
+	if err != nil {
+		log.Fatalf("can't add the headers of the stored blocks to the header store: %s", err)
+	}
+	if n > 0 {
+		log.Printf("added the headers of %d stored blocks to the header store\n", n)
+	}
+	s.headerRepo = headerStore
+	s.headerStore = headerStore
+}
+
+// openSnapshotChainState opens the background UTXO set that validates the history of the chain when
+// the node is loaded from a UTXO snapshot.
+func (s *storage) openSnapshotChainState(cfg Config, dbPath string) {
+	base, ok, err := s.utxoSet.SnapshotBase()
+	if err != nil {
+		log.Fatalf("can't read the snapshot data of the UTXO set: %s", err)
+	}
+	if !ok {
+		return
+	}
+	if cfg.TxIndex || cfg.AddrIndex {
+		log.Fatalf("txindex and addrindex are not supported on a node that is loaded from a UTXO snapshot")
+	}
+
+	backgroundDB, err := db.NewBoltDB(dbPath + ".background")
+	if err != nil {
+		log.Fatalf("can't initialize the background BoltDB: %s", err)
+	}
+	s.closers = append(s.closers, backgroundDB.Close)
+
+	background, err := db.NewUTXOSet(backgroundDB.DB)
+	if err != nil {
+		log.Fatalf("can't initialize the background UTXO set: %s", err)
+	}
+
+	snapshotChainState, err := node.NewSnapshotChainState(s.utxoSet, background, base.BlockHash, base.Height, base.HashSerialized)
+	if err != nil {
+		log.Fatalf("can't initialize the snapshot chain state: %s", err)
+	}
+	s.chainState = snapshotChainState
+	s.chainTip = snapshotChainState
+}
+
+// Close stops the indexes and closes the databases in the reverse order of their opening.
+func (s *storage) Close() {
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		s.closers[i]()
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+)
+
+// RunVerifyChain verifies the last "depth" blocks of the stored chain with the given level and prints the
+// result. When a corrupted block is found and rollback is true, the chain is rolled back to the block before it.
+func RunVerifyChain(cfg Config, depth, level int, rollback bool) error {
+	st := openStorage(cfg)
+	defer st.Close()
+
+	verifier := node.NewChainVerifier(st.blockRepo, st.utxoSet, st.indexers)
+	corrupted, err := verifier.Verify(depth, level)
+	if err != nil {
+		return fmt.Errorf("failed to verify the chain: %w", err)
+	}
+
+	if corrupted == nil {
+		fmt.Fprintln(os.Stdout, "the chain is valid")
+		return nil
+	}
+
+	log.Printf("corrupted block %x at height %d: %s\n", p2p.Reverse(corrupted.Hash), corrupted.Height, corrupted.Reason)
+	fmt.Fprintf(os.Stdout, "corrupted block %x at height %d: %s\n", p2p.Reverse(corrupted.Hash), corrupted.Height, corrupted.Reason)
+	if !rollback {
+		return nil
+	}
+
+	if corrupted.PrevBlockHash == [32]byte{} {
+		return fmt.Errorf("can't roll back the chain, the previous block of %x is unknown", p2p.Reverse(corrupted.Hash))
+	}
+	n, err := verifier.Rollback(corrupted.PrevBlockHash)
+	if err != nil {
+		return fmt.Errorf("rollback stopped after %d blocks: %w", n, err)
+	}
+
+	fmt.Fprintf(os.Stdout, "rolled back %d blocks, the new tip is %x\n", n, p2p.Reverse(corrupted.PrevBlockHash))
+	return nil
+}
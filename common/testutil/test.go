@@ -28,3 +28,36 @@ func NewBlockHeader(prevBlockHash [32]byte) p2p.BlockHeader {
 		TxnCount:      p2p.VarInt(1),
 	}
 }
+
+// NewMsgTx returns a legacy transaction that spends the given outpoints and has one output for every value.
+func NewMsgTx(inputs []p2p.OutPoint, values ...int64) p2p.MsgTx {
+	tx := p2p.MsgTx{
+		Version:    1,
+		TxInCount:  p2p.VarInt(len(inputs)),
+		TxOutCount: p2p.VarInt(len(values)),
+	}
+	for _, in := range inputs {
+		tx.TxIn = append(tx.TxIn, p2p.TxInput{
+			PreviousOutput:  in,
+			ScriptLength:    1,
+			SignatureScript: []byte{0x51},
+			Sequence:        0xffffffff,
+		})
+	}
+	for i, v := range values {
+		tx.TxOut = append(tx.TxOut, p2p.TxOutput{
+			Value:          v,
+			PkScriptLength: 2,
+			PkScript:       []byte{0x51, byte(i)},
+		})
+	}
+	return tx
+}
+
+// NewMsgBlockWithTxs returns a block that contains the given transactions.
+func NewMsgBlockWithTxs(prevBlockHash [32]byte, txs ...p2p.MsgTx) p2p.MsgBlock {
+	block := NewMsgBlock(prevBlockHash)
+	block.TxnCount = p2p.VarInt(len(txs))
+	block.Transactions = txs
+	return block
+}
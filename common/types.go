@@ -41,9 +41,15 @@ func AddrFromString(addr string) Addr {
 	}
 }
 
+// ChainOverview is an overview of the peer's chain compared to the local chain. StartHeight is the height
+// of the peer's best block from its version message. NumberOfBlocks and CumulativeWork are the number and
+// the work of the peer's headers after the fork point with the local header chain.
 type ChainOverview struct {
 	Peer           string
+	StartHeight    int32
 	NumberOfBlocks int64
+	// CumulativeWork is the work of the peer's chain from the genesis block when its headers are stored,
+	// otherwise the work of its headers after the fork point with the local chain
 	CumulativeWork *big.Int
 	IsValid        bool
 }
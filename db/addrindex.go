@@ -0,0 +1,275 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	addrIndexBucket      = []byte("AddrIndexBucket")
+	addrIndexUndoBucket  = []byte("AddrIndexUndoBucket")
+	addrIndexStateBucket = []byte("AddrIndexStateBucket")
+)
+
+const (
+	// addrIndexKeyLength is the length of the key: scripthash + height + kind + outpoint.
+	addrIndexKeyLength = 32 + 4 + 1 + outPointLength
+
+	kindFunding  = 0
+	kindSpending = 1
+)
+
+// ScriptHash returns the Electrum-style scripthash of the output script: its SHA256 hash.
+func ScriptHash(pkScript []byte) [32]byte {
+	return sha256.Sum256(pkScript)
+}
+
+// AddrHistoryEntry is a funding or a spending of an output that is locked by a script.
+type AddrHistoryEntry struct {
+	// Height is the height of the block that contains the funding or the spending transaction.
+	Height int32
+	// Spending is true when the entry is for a transaction that spends the output.
+	Spending bool
+	// OutPoint is the funded output.
+	OutPoint p2p.OutPoint
+	// Value is the value of the funded output.
+	Value int64
+	// SpendingTxHash is the txid of the spending transaction. It is set only for spending entries.
+	SpendingTxHash [32]byte
+}
+
+func (e AddrHistoryEntry) key(scriptHash [32]byte) []byte {
+	b := make([]byte, 0, addrIndexKeyLength)
+	b = append(b, scriptHash[:]...)
+	// the height is big endian so the history of a script is iterated in the order of the chain
+	b = binary.BigEndian.AppendUint32(b, uint32(e.Height))
+	if e.Spending {
+		b = append(b, kindSpending)
+	} else {
+		b = append(b, kindFunding)
+	}
+	return append(b, outPointKey(e.OutPoint)...)
+}
+
+func (e AddrHistoryEntry) value() []byte {
+	b := binary.LittleEndian.AppendUint64(make([]byte, 0, 40), uint64(e.Value))
+	if e.Spending {
+		b = append(b, e.SpendingTxHash[:]...)
+	}
+	return b
+}
+
+func decodeAddrHistoryEntry(k, v []byte) (AddrHistoryEntry, error) {
+	if len(k) != addrIndexKeyLength || len(v) < 8 {
+		return AddrHistoryEntry{}, fmt.Errorf("invalid address index entry length: %d, %d", len(k), len(v))
+	}
+
+	op := k[37:]
+	e := AddrHistoryEntry{
+		Height:   int32(binary.BigEndian.Uint32(k[32:36])),
+		Spending: k[36] == kindSpending,
+		OutPoint: p2p.OutPoint{Hash: [32]byte(op[:32]), Index: binary.LittleEndian.Uint32(op[32:])},
+		Value:    int64(binary.LittleEndian.Uint64(v[:8])),
+	}
+	if e.Spending {
+		if len(v) != 40 {
+			return AddrHistoryEntry{}, fmt.Errorf("invalid address index spending entry length: %d", len(v))
+		}
+		e.SpendingTxHash = [32]byte(v[8:])
+	}
+	return e, nil
+}
+
+// AddrIndex keeps for every scripthash the outputs that fund it and the transactions that spend
+// these outputs. The spent outputs are taken from the undo data of the UTXO set, so a block must be
+// connected to the UTXO set before it is connected to the index.
+type AddrIndex struct {
+	*indexSyncer
+	db      *bolt.DB
+	utxoSet *UTXOSet
+}
+
+// NewAddrIndex creates an AddrIndex that is stored in the given BoltDB. The blocks that are saved
+// before the index was enabled are read from the block repository.
+func NewAddrIndex(db *bolt.DB, br sync.BlockRepository, us *UTXOSet) (*AddrIndex, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{addrIndexBucket, addrIndexUndoBucket, addrIndexStateBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	ai := &AddrIndex{db: db, utxoSet: us}
+	ai.indexSyncer = newIndexSyncer("address index", ai, br)
+	return ai, err
+}
+
+// BestBlock returns the hash and the height of the last indexed block.
+func (ai *AddrIndex) BestBlock() ([32]byte, int32, error) {
+	var hash [32]byte
+	var height int32
+	err := ai.db.View(func(tx *bolt.Tx) error {
+		hash, height = indexBestBlock(tx.Bucket(addrIndexStateBucket))
+		return nil
+	})
+	return hash, height, err
+}
+
+// ConnectBlock adds the funding and the spending entries of the block's transactions. The block
+// is skipped when it is already indexed or when the index is still behind the chain.
+func (ai *AddrIndex) ConnectBlock(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	bestHash, _, err := ai.BestBlock()
+	if err != nil || block.PrevBlockHash != bestHash {
+		return err
+	}
+
+	spent, err := ai.utxoSet.SpentOutputs(hash)
+	if err != nil {
+		return fmt.Errorf("failed to get the spent outputs of block %x: %w", p2p.Reverse(hash), err)
+	}
+
+	return ai.db.Update(func(tx *bolt.Tx) error {
+		state := tx.Bucket(addrIndexStateBucket)
+		bestHash, bestHeight := indexBestBlock(state)
+		if block.PrevBlockHash != bestHash {
+			return nil
+		}
+
+		height := bestHeight + 1
+		entries := tx.Bucket(addrIndexBucket)
+		var undo []byte
+		put := func(scriptHash [32]byte, e AddrHistoryEntry) error {
+			k := e.key(scriptHash)
+			undo = append(undo, k...)
+			return entries.Put(k, e.value())
+		}
+
+		// the undo data of the UTXO set keeps the spent outputs in the order of the inputs
+		var spentIndex int
+		for _, t := range block.Transactions {
+			txid := t.TxHash()
+			if !t.IsCoinBase() {
+				for range t.TxIn {
+					if spentIndex >= len(spent) {
+						return fmt.Errorf("missing spent outputs in the undo data of block %x", p2p.Reverse(hash))
+					}
+					so := spent[spentIndex]
+					spentIndex++
+
+					e := AddrHistoryEntry{Height: height, Spending: true, OutPoint: so.OutPoint, Value: so.UTXO.Value, SpendingTxHash: txid}
+					if err := put(ScriptHash(so.UTXO.PkScript), e); err != nil {
+						return err
+					}
+				}
+			}
+
+			for i, out := range t.TxOut {
+				if isUnspendable(out.PkScript) {
+					continue
+				}
+				e := AddrHistoryEntry{Height: height, OutPoint: p2p.OutPoint{Hash: txid, Index: uint32(i)}, Value: out.Value}
+				if err := put(ScriptHash(out.PkScript), e); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Bucket(addrIndexUndoBucket).Put(hash[:], undo); err != nil {
+			return err
+		}
+		return putIndexBestBlock(state, hash, height)
+	})
+}
+
+// DisconnectBlock removes the entries that are added by the block. Only the last indexed block
+// can be disconnected.
+func (ai *AddrIndex) DisconnectBlock(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	return ai.db.Update(func(tx *bolt.Tx) error {
+		state := tx.Bucket(addrIndexStateBucket)
+		bestHash, bestHeight := indexBestBlock(state)
+		if hash != bestHash {
+			return fmt.Errorf("block %x is not the best block of the address index %x", p2p.Reverse(hash), p2p.Reverse(bestHash))
+		}
+
+		undoBucket := tx.Bucket(addrIndexUndoBucket)
+		undo := undoBucket.Get(hash[:])
+		if len(undo)%addrIndexKeyLength != 0 {
+			return fmt.Errorf("invalid address index undo data for block %x", p2p.Reverse(hash))
+		}
+
+		entries := tx.Bucket(addrIndexBucket)
+		for i := 0; i < len(undo); i += addrIndexKeyLength {
+			if err := entries.Delete(undo[i : i+addrIndexKeyLength]); err != nil {
+				return err
+			}
+		}
+
+		if err := undoBucket.Delete(hash[:]); err != nil {
+			return err
+		}
+		return putIndexBestBlock(state, block.PrevBlockHash, bestHeight-1)
+	})
+}
+
+// History returns the funding and the spending entries of the scripthash in the order of the chain.
+func (ai *AddrIndex) History(scriptHash [32]byte) ([]AddrHistoryEntry, error) {
+	var history []AddrHistoryEntry
+	err := ai.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(addrIndexBucket).Cursor()
+		for k, v := c.Seek(scriptHash[:]); k != nil && [32]byte(k[:32]) == scriptHash; k, v = c.Next() {
+			e, err := decodeAddrHistoryEntry(k, v)
+			if err != nil {
+				return err
+			}
+			history = append(history, e)
+		}
+		return nil
+	})
+	return history, err
+}
+
+// Unspent returns the funding entries of the scripthash whose outputs are not spent.
+func (ai *AddrIndex) Unspent(scriptHash [32]byte) ([]AddrHistoryEntry, error) {
+	history, err := ai.History(scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	spent := make(map[p2p.OutPoint]struct{})
+	for _, e := range history {
+		if e.Spending {
+			spent[e.OutPoint] = struct{}{}
+		}
+	}
+
+	var unspent []AddrHistoryEntry
+	for _, e := range history {
+		if _, ok := spent[e.OutPoint]; !ok && !e.Spending {
+			unspent = append(unspent, e)
+		}
+	}
+	return unspent, nil
+}
+
+// Balance returns the sum of the values of the unspent outputs of the scripthash.
+func (ai *AddrIndex) Balance(scriptHash [32]byte) (int64, error) {
+	unspent, err := ai.Unspent(scriptHash)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance int64
+	for _, e := range unspent {
+		balance += e.Value
+	}
+	return balance, nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddrIndex_ConnectAndDisconnectBlocks(t *testing.T) {
+	repo, db := newFlatFileRepo(t, t.TempDir())
+	defer db.Close()
+	defer repo.Close()
+
+	utxoSet, err := NewUTXOSet(db.DB)
+	require.NoError(t, err)
+	index, err := NewAddrIndex(db.DB, repo, utxoSet)
+	require.NoError(t, err)
+
+	connect := func(block p2p.MsgBlock) {
+		require.NoError(t, repo.Save(block))
+		require.NoError(t, utxoSet.ConnectBlock(block))
+		require.NoError(t, index.ConnectBlock(block))
+	}
+
+	coinbase1 := testutil.NewMsgTx(coinbase, 50, 25)
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, coinbase1)
+	connect(block1)
+	mature := maturityBlocks(block1.GetHash())
+	for _, b := range mature {
+		connect(b)
+	}
+
+	spend1 := testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbase1.TxHash(), Index: 0}}, 40)
+	spend2 := testutil.NewMsgTx([]p2p.OutPoint{{Hash: spend1.TxHash(), Index: 0}}, 30)
+	coinbase2 := testutil.NewMsgTx(coinbase, 60)
+	block2 := testutil.NewMsgBlockWithTxs(mature[len(mature)-1].GetHash(), coinbase2, spend1, spend2)
+	connect(block2)
+
+	scriptHash := ScriptHash([]byte{0x51, 0})
+	history, err := index.History(scriptHash)
+	require.NoError(t, err)
+	require.Len(t, history, 6)
+	require.Equal(t, AddrHistoryEntry{Height: 1, OutPoint: p2p.OutPoint{Hash: coinbase1.TxHash()}, Value: 50}, history[0])
+	require.Contains(t, history, AddrHistoryEntry{
+		Height:         coinbaseMaturity + 1,
+		Spending:       true,
+		OutPoint:       p2p.OutPoint{Hash: coinbase1.TxHash()},
+		Value:          50,
+		SpendingTxHash: spend1.TxHash(),
+	})
+
+	unspent, err := index.Unspent(scriptHash)
+	require.NoError(t, err)
+	require.Len(t, unspent, 2)
+
+	balance, err := index.Balance(scriptHash)
+	require.NoError(t, err)
+	require.Equal(t, int64(90), balance)
+
+	balance, err = index.Balance(ScriptHash([]byte{0x51, 1}))
+	require.NoError(t, err)
+	require.Equal(t, int64(25), balance)
+
+	require.NoError(t, index.DisconnectBlock(block2))
+
+	history, err = index.History(scriptHash)
+	require.NoError(t, err)
+	require.Equal(t, []AddrHistoryEntry{{Height: 1, OutPoint: p2p.OutPoint{Hash: coinbase1.TxHash()}, Value: 50}}, history)
+
+	hash, height, err := index.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, mature[len(mature)-1].GetHash(), hash)
+	require.Equal(t, int32(coinbaseMaturity), height)
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/db/dbtest"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocksRepo_Conformance(t *testing.T) {
+	dbtest.RunBlockRepositoryTests(t, func(t *testing.T) sync.BlockRepository {
+		db, err := NewBoltDB(filepath.Join(t.TempDir(), "blocks.db"))
+		require.NoError(t, err)
+		t.Cleanup(db.Close)
+
+		repo, err := NewBlockRepo(db.DB)
+		require.NoError(t, err)
+		return repo
+	})
+}
+
+func TestFlatFileBlockRepo_Conformance(t *testing.T) {
+	dbtest.RunBlockRepositoryTests(t, func(t *testing.T) sync.BlockRepository {
+		repo, db := newFlatFileRepo(t, t.TempDir())
+		t.Cleanup(func() {
+			repo.Close()
+			db.Close()
+		})
+		return repo
+	})
+}
+
+func TestMemoryBlockRepo_Conformance(t *testing.T) {
+	dbtest.RunBlockRepositoryTests(t, func(t *testing.T) sync.BlockRepository {
+		return NewMemoryBlockRepo()
+	})
+}
+
+func TestPebbleBlockRepo_Conformance(t *testing.T) {
+	dbtest.RunBlockRepositoryTests(t, func(t *testing.T) sync.BlockRepository {
+		repo, err := NewPebbleBlockRepo(t.TempDir())
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	})
+}
+
+func TestPebbleBlockRepo_ReopenKeepsTheBlocks(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewPebbleBlockRepo(dir)
+	require.NoError(t, err)
+
+	block := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	require.NoError(t, repo.Save(block))
+	require.NoError(t, repo.Close())
+
+	repo, err = NewPebbleBlockRepo(dir)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	actual, err := repo.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, block, actual)
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
@@ -104,3 +105,44 @@ func (db *BlocksRepo) Get(hash [32]byte) (p2p.MsgBlock, error) {
 	})
 	return block, err
 }
+
+// Delete removes the block with the given hash. When it is the last block, its previous block becomes the last one.
+func (db *BlocksRepo) Delete(hash [32]byte) error {
+	return db.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(blockBucket)
+		data := b.Get(hash[:])
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		var block p2p.MsgBlock
+		if err := json.Unmarshal(data, &block); err != nil {
+			return err
+		}
+
+		if err := b.Delete(hash[:]); err != nil {
+			return err
+		}
+		return unlinkBlock(tx, hash, block.PrevBlockHash)
+	})
+}
+
+// unlinkBlock removes the links of the deleted block to its previous and next blocks. When it is
+// the last block, the previous block becomes the last one.
+func unlinkBlock(tx *bolt.Tx, hash, prevBlockHash [32]byte) error {
+	prevToNext := tx.Bucket(prevToNextBucket)
+	if bytes.Equal(prevToNext.Get(prevBlockHash[:]), hash[:]) {
+		if err := prevToNext.Delete(prevBlockHash[:]); err != nil {
+			return err
+		}
+	}
+	if err := prevToNext.Delete(hash[:]); err != nil {
+		return err
+	}
+
+	lastBlock := tx.Bucket(lastBlockBucket)
+	if bytes.Equal(lastBlock.Get(lastBlockKey), hash[:]) {
+		return lastBlock.Put(lastBlockKey, prevBlockHash[:])
+	}
+	return nil
+}
@@ -21,7 +21,7 @@ func TestBlockRepo_Savek(t *testing.T) {
 	fmt.Println(err)
 	fmt.Printf("prev block hah: %x\n", block.PrevBlockHash)
 	hash := block.GetHash()
-	fmt.Printf("block hash: %x\n", p2p.Reverse(hash[:]))
+	fmt.Printf("block hash: %x\n", p2p.Reverse(hash))
 }
 
 // Test Functions
@@ -139,20 +139,20 @@ func TestBlockRepo_GetLaock(t *testing.T) {
 	require.NoError(t, err)
 
 	h := actual.GetHash()
-	fmt.Printf("1Last block in DB is %x\n", p2p.Reverse(h[:]))
+	fmt.Printf("1Last block in DB is %x\n", p2p.Reverse(h))
 
 	block, err := repo.Get(actual.PrevBlockHash)
 	require.NoError(t, err)
 	h = block.GetHash()
-	fmt.Printf("2 Previous block is: %x\n", p2p.Reverse(h[:]))
+	fmt.Printf("2 Previous block is: %x\n", p2p.Reverse(h))
 
 	block, err = repo.Get(block.PrevBlockHash)
 	require.NoError(t, err)
 	h = block.GetHash()
-	fmt.Printf("3 Previous block is: %x\n", p2p.Reverse(h[:]))
+	fmt.Printf("3 Previous block is: %x\n", p2p.Reverse(h))
 
 	block, err = repo.Get(block.PrevBlockHash)
 	require.NoError(t, err)
 	h = block.GetHash()
-	fmt.Printf("4 Previous block is: %x\n", p2p.Reverse(h[:]))
+	fmt.Printf("4 Previous block is: %x\n", p2p.Reverse(h))
 }
@@ -0,0 +1,183 @@
+// Package dbtest contains the conformance tests that every storage backend must pass.
+package dbtest
+
+import (
+	stdsync "sync"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+)
+
+var coinbase = []p2p.OutPoint{{Index: 0xffffffff}}
+
+// RunBlockRepositoryTests runs the tests that every sync.BlockRepository implementation must pass.
+// newRepo must return a new empty repository for every call.
+func RunBlockRepositoryTests(t *testing.T, newRepo func(t *testing.T) sync.BlockRepository) {
+	t.Run("GetFromEmptyRepository", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.GetLast()
+		require.ErrorIs(t, err, sync.ErrNotFound)
+		_, err = repo.Get([32]byte{1})
+		require.ErrorIs(t, err, sync.ErrNotFound)
+	})
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		chain := newChain(3)
+		for _, block := range chain {
+			require.NoError(t, repo.Save(block))
+		}
+
+		for _, block := range chain {
+			actual, err := repo.Get(block.GetHash())
+			require.NoError(t, err)
+			require.Equal(t, block, actual)
+		}
+		_, err := repo.Get([32]byte{1})
+		require.ErrorIs(t, err, sync.ErrNotFound)
+	})
+
+	t.Run("SaveAndGetSegwitBlock", func(t *testing.T) {
+		repo := newRepo(t)
+		tx := testutil.NewMsgTx([]p2p.OutPoint{{Hash: [32]byte{1}, Index: 2}}, 1000, 2000)
+		tx.Flag = 1
+		tx.TxWitness = []p2p.TxWitnessData{{Count: 2, Witness: []p2p.TxWitness{
+			{Length: 3, Data: []byte{1, 2, 3}},
+			{Length: 1, Data: []byte{4}},
+		}}}
+		block := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 50), tx)
+		require.NoError(t, repo.Save(block))
+
+		actual, err := repo.Get(block.GetHash())
+		require.NoError(t, err)
+		require.Equal(t, block, actual)
+	})
+
+	t.Run("GetLast", func(t *testing.T) {
+		repo := newRepo(t)
+		chain := newChain(4)
+		for i, block := range chain {
+			require.NoError(t, repo.Save(block))
+
+			actual, err := repo.GetLast()
+			require.NoError(t, err)
+			require.Equal(t, chain[i], actual)
+		}
+	})
+
+	t.Run("GetLastWhenTheBlocksAreSavedOutOfOrder", func(t *testing.T) {
+		repo := newRepo(t)
+		chain := newChain(3)
+		require.NoError(t, repo.Save(chain[0]))
+		require.NoError(t, repo.Save(chain[2]))
+		require.NoError(t, repo.Save(chain[1]))
+
+		// the last saved block is followed by the block that was saved before it
+		actual, err := repo.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, chain[2], actual)
+	})
+
+	t.Run("SaveTheSameBlockTwice", func(t *testing.T) {
+		repo := newRepo(t)
+		chain := newChain(2)
+		for _, block := range chain {
+			require.NoError(t, repo.Save(block))
+		}
+		require.NoError(t, repo.Save(chain[1]))
+
+		actual, err := repo.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, chain[1], actual)
+		actual, err = repo.Get(chain[1].GetHash())
+		require.NoError(t, err)
+		require.Equal(t, chain[1], actual)
+	})
+
+	t.Run("DeleteTheLastBlocks", func(t *testing.T) {
+		repo := newRepo(t)
+		chain := newChain(3)
+		for _, block := range chain {
+			require.NoError(t, repo.Save(block))
+		}
+
+		require.NoError(t, repo.Delete(chain[2].GetHash()))
+		_, err := repo.Get(chain[2].GetHash())
+		require.ErrorIs(t, err, sync.ErrNotFound)
+		actual, err := repo.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, chain[1], actual)
+
+		require.NoError(t, repo.Delete(chain[1].GetHash()))
+		require.NoError(t, repo.Delete(chain[0].GetHash()))
+		_, err = repo.GetLast()
+		require.ErrorIs(t, err, sync.ErrNotFound)
+		require.ErrorIs(t, repo.Delete(chain[0].GetHash()), sync.ErrNotFound)
+
+		// the deleted blocks can be saved again
+		require.NoError(t, repo.Save(chain[0]))
+		require.NoError(t, repo.Save(chain[1]))
+		actual, err = repo.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, chain[1], actual)
+	})
+
+	t.Run("ChangingTheReturnedBlockDoesNotChangeTheStoredOne", func(t *testing.T) {
+		repo := newRepo(t)
+		block := newChain(1)[0]
+		require.NoError(t, repo.Save(block))
+
+		actual, err := repo.Get(block.GetHash())
+		require.NoError(t, err)
+		actual.Transactions[0].TxOut[0].Value = 1
+
+		actual, err = repo.Get(block.GetHash())
+		require.NoError(t, err)
+		require.Equal(t, block, actual)
+	})
+
+	t.Run("ConcurrentSaveAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		chain := newChain(20)
+
+		var wg stdsync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, block := range chain {
+				require.NoError(t, repo.Save(block))
+			}
+		}()
+
+		for _, block := range chain {
+			wg.Add(1)
+			go func(hash [32]byte) {
+				defer wg.Done()
+				if _, err := repo.Get(hash); err != nil {
+					require.ErrorIs(t, err, sync.ErrNotFound)
+				}
+			}(block.GetHash())
+		}
+		wg.Wait()
+
+		actual, err := repo.GetLast()
+		require.NoError(t, err)
+		require.Equal(t, chain[len(chain)-1], actual)
+	})
+}
+
+// newChain returns n blocks that are linked to each other and start from the genesis block.
+func newChain(n int) []p2p.MsgBlock {
+	var chain []p2p.MsgBlock
+	prev := sync.GenesisBlockHash
+	for i := 0; i < n; i++ {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, int64(i+1)))
+		chain = append(chain, block)
+		prev = block.GetHash()
+	}
+	return chain
+}
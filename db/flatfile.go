@@ -0,0 +1,818 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	stdsync "sync"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// maxBlockFileSize is the size after which a new blkNNNNN.dat file is started (the same as in Bitcoin Core).
+	maxBlockFileSize = 128 * 1024 * 1024
+
+	// blockRecordHeaderLength is the length of the magic and the size that prefix every block in a blk file.
+	blockRecordHeaderLength = 8
+
+	blockIndexEntryMinLength = 17
+	blockFileInfoLength      = 8
+
+	// statusHaveData is set for the blocks whose raw data is in the blk files (not pruned).
+	statusHaveData = 1
+
+	// unknownHeight is the height of a block that is saved before its previous block, it is set when the
+	// previous block is saved. The files with such blocks are not pruned.
+	unknownHeight = -1
+)
+
+var (
+	blockLocationBucket   = []byte("BlockLocationBucket")
+	blockFileInfoBucket   = []byte("BlockFileInfoBucket")
+	blockFileInfoKey      = []byte("BlockFileInfoKey")
+	blockFilesBucket      = []byte("BlockFilesBucket")
+	blockFileHashesBucket = []byte("BlockFileHashesBucket")
+	// prunedFilesBucket keeps the blk files that are pruned from the index until they are deleted
+	prunedFilesBucket = []byte("PrunedFilesBucket")
+
+	// ErrCorruptedBlockFile is returned when the data in a blk file doesn't match the location index.
+	ErrCorruptedBlockFile = errors.New("corrupted block file")
+	// ErrUnknownBlockFile is returned when the blocks dir has blk files that are not written by this node,
+	// e.g. the blocks dir of another node. They are never deleted.
+	ErrUnknownBlockFile = errors.New("block file is not indexed")
+
+	// errUnknownHeight is returned for the height of a block whose previous block is not saved yet.
+	errUnknownHeight = errors.New("the height of the previous block is unknown")
+)
+
+// blockIndexEntry is the indexed information for a block: its header, height and where the
+// raw block is stored in the blk files.
+type blockIndexEntry struct {
+	Height int32
+	Status byte
+	File   uint32
+	Offset uint32
+	Length uint32
+	Header []byte
+}
+
+func (e blockIndexEntry) encode() []byte {
+	b := make([]byte, 0, blockIndexEntryMinLength+len(e.Header))
+	b = binary.LittleEndian.AppendUint32(b, uint32(e.Height))
+	b = append(b, e.Status)
+	b = binary.LittleEndian.AppendUint32(b, e.File)
+	b = binary.LittleEndian.AppendUint32(b, e.Offset)
+	b = binary.LittleEndian.AppendUint32(b, e.Length)
+	return append(b, e.Header...)
+}
+
+func decodeBlockIndexEntry(b []byte) (blockIndexEntry, error) {
+	if len(b) < blockIndexEntryMinLength {
+		return blockIndexEntry{}, fmt.Errorf("invalid block index entry length: %d", len(b))
+	}
+	return blockIndexEntry{
+		Height: int32(binary.LittleEndian.Uint32(b[0:4])),
+		Status: b[4],
+		File:   binary.LittleEndian.Uint32(b[5:9]),
+		Offset: binary.LittleEndian.Uint32(b[9:13]),
+		Length: binary.LittleEndian.Uint32(b[13:17]),
+		Header: append([]byte{}, b[17:]...),
+	}, nil
+}
+
+// blockFileInfo is the last blk file that was written and the size of its data that is indexed.
+type blockFileInfo struct {
+	File uint32
+	Size uint32
+}
+
+func (fi blockFileInfo) encode() []byte {
+	b := make([]byte, blockFileInfoLength)
+	binary.LittleEndian.PutUint32(b[0:4], fi.File)
+	binary.LittleEndian.PutUint32(b[4:8], fi.Size)
+	return b
+}
+
+// blockFileStats are kept for every blk file that is not pruned.
+type blockFileStats struct {
+	Size      uint32
+	MaxHeight int32
+}
+
+func (fs blockFileStats) encode() []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint32(b[0:4], fs.Size)
+	binary.LittleEndian.PutUint32(b[4:8], uint32(fs.MaxHeight))
+	return b
+}
+
+func fileKey(n uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, n)
+}
+
+// FlatFileBlockRepo stores the raw blocks in rotating blkNNNNN.dat files in the same layout as
+// Bitcoin Core (magic + size + serialized block). BoltDB keeps only the index from block hash to
+// the file, offset and length of the block, together with the block's header and height.
+type FlatFileBlockRepo struct {
+	db          *bolt.DB
+	dir         string
+	magic       p2p.Magic
+	maxFileSize uint32
+
+	mu       stdsync.Mutex
+	fileInfo blockFileInfo
+	files    map[uint32]blockFileStats
+	file     *os.File
+}
+
+// NewFlatFileBlockRepo creates a FlatFileBlockRepo that writes blk files in the dir. Data that was
+// written to the last blk file but was not indexed (partial writes before a crash) is truncated.
+// ErrUnknownBlockFile is returned when the dir has blk files that are not written by the repo.
+func NewFlatFileBlockRepo(db *bolt.DB, dir, network string) (*FlatFileBlockRepo, error) {
+	magic, ok := p2p.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("unsupported network %s", network)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		buckets := [][]byte{blockLocationBucket, blockFileInfoBucket, blockFilesBucket, blockFileHashesBucket,
+			prunedFilesBucket, lastBlockBucket, prevToNextBucket}
+		for _, b := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &FlatFileBlockRepo{
+		db:          db,
+		dir:         dir,
+		magic:       magic,
+		maxFileSize: maxBlockFileSize,
+		files:       map[uint32]blockFileStats{},
+	}
+
+	if err = repo.recover(); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// recover brings the blk files in the state that is described by the index. The file that was written last
+// is truncated to its indexed size and the files that were pruned, but not deleted before a crash, are deleted.
+// Every file is recorded in the index before it is created, so a file that is not in the index is not written
+// by the repo and it is an error. It is never deleted.
+func (db *FlatFileBlockRepo) recover() error {
+	indexed := false
+	var pruned []uint32
+	err := db.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(blockFileInfoBucket).Get(blockFileInfoKey)
+		if len(b) != 0 && len(b) != blockFileInfoLength {
+			return fmt.Errorf("invalid block file info length: %d", len(b))
+		}
+		if len(b) != 0 {
+			indexed = true
+			db.fileInfo = blockFileInfo{
+				File: binary.LittleEndian.Uint32(b[0:4]),
+				Size: binary.LittleEndian.Uint32(b[4:8]),
+			}
+		}
+
+		err := tx.Bucket(prunedFilesBucket).ForEach(func(k, _ []byte) error {
+			pruned = append(pruned, binary.BigEndian.Uint32(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(blockFilesBucket).ForEach(func(k, v []byte) error {
+			db.files[binary.BigEndian.Uint32(k)] = blockFileStats{
+				Size:      binary.LittleEndian.Uint32(v[0:4]),
+				MaxHeight: int32(binary.LittleEndian.Uint32(v[4:8])),
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, n := range pruned {
+		if err = db.deletePrunedFile(n); err != nil {
+			return err
+		}
+	}
+
+	paths, err := filepath.Glob(filepath.Join(db.dir, "blk*.dat"))
+	if err != nil {
+		return err
+	}
+	var unknown []string
+	for _, path := range paths {
+		var n uint32
+		if _, err = fmt.Sscanf(filepath.Base(path), "blk%05d.dat", &n); err != nil {
+			continue
+		}
+		if _, ok := db.files[n]; ok || (indexed && n == db.fileInfo.File) {
+			continue
+		}
+		unknown = append(unknown, path)
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %v are not written by this node, use another blocks dir", ErrUnknownBlockFile, unknown)
+	}
+
+	path := db.filePath(db.fileInfo.File)
+	st, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if db.fileInfo.Size != 0 {
+			return fmt.Errorf("%w: %s is missing", ErrCorruptedBlockFile, path)
+		}
+	case err != nil:
+		return err
+	case st.Size() < int64(db.fileInfo.Size):
+		return fmt.Errorf("%w: %s has %d bytes but %d are indexed", ErrCorruptedBlockFile, path, st.Size(), db.fileInfo.Size)
+	case st.Size() > int64(db.fileInfo.Size):
+		log.Printf("truncate partial write in block file %s from %d to %d bytes\n", path, st.Size(), db.fileInfo.Size)
+		if err = os.Truncate(path, int64(db.fileInfo.Size)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Save appends the block to the current blk file and indexes its location. Blocks that are
+// already stored are not written again. A block can be saved before its previous block, then its
+// height is set when the previous block is saved.
+func (db *FlatFileBlockRepo) Save(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	raw, err := wire.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("failed to serialize block %x: %w", p2p.Reverse(hash), err)
+	}
+	header, err := wire.Marshal(block.BlockHeader)
+	if err != nil {
+		return err
+	}
+
+	// the block is checked under the lock, so the same block that is saved concurrently is written once
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	exists := false
+	err = db.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(blockLocationBucket).Get(hash[:]) != nil
+		return nil
+	})
+	if err != nil || exists {
+		return err
+	}
+
+	height, err := db.nextHeight(block.PrevBlockHash)
+	if errors.Is(err, errUnknownHeight) {
+		height = unknownHeight
+	} else if err != nil {
+		return err
+	}
+
+	entry, err := db.write(raw)
+	if err != nil {
+		return err
+	}
+	entry.Height = height
+	entry.Header = header
+
+	stats := db.files[entry.File]
+	stats.Size = db.fileInfo.Size
+	stats.MaxHeight = max(stats.MaxHeight, prunableHeight(height))
+
+	updated := make(map[uint32]blockFileStats)
+	err = db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(blockLocationBucket).Put(hash[:], entry.encode()); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(blockFileHashesBucket).Put(append(fileKey(entry.File), hash[:]...), []byte{}); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket(blockFilesBucket).Put(fileKey(entry.File), stats.encode()); err != nil {
+			return err
+		}
+
+		// a block of another branch or a block that is saved before its previous block doesn't become the last
+		// block, the blocks after the last block are found by the previous block hashes
+		last := bytes.Clone(lastBlockHash(tx))
+		if err := tx.Bucket(prevToNextBucket).Put(block.PrevBlockHash[:], hash[:]); err != nil {
+			return err
+		}
+		if last == nil || bytes.Equal(last, block.PrevBlockHash[:]) {
+			if err := tx.Bucket(lastBlockBucket).Put(lastBlockKey, hash[:]); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Bucket(blockFileInfoBucket).Put(blockFileInfoKey, db.fileInfo.encode()); err != nil {
+			return err
+		}
+
+		if height == unknownHeight {
+			return nil
+		}
+		return db.setNextHeights(tx, hash, height, updated)
+	})
+	if err != nil {
+		// the written data is not indexed, it is overwritten by the next block
+		db.fileInfo.Size = entry.Offset - blockRecordHeaderLength
+		return err
+	}
+
+	db.files[entry.File] = stats
+	for n, s := range updated {
+		db.files[n] = s
+	}
+	return nil
+}
+
+// setNextHeights sets the heights of the blocks that were saved before the block with the given hash and
+// height and follow it, and returns the updated stats of their files.
+func (db *FlatFileBlockRepo) setNextHeights(tx *bolt.Tx, hash [32]byte, height int32, updated map[uint32]blockFileStats) error {
+	locations := tx.Bucket(blockLocationBucket)
+	files := make(map[uint32]bool)
+	for {
+		next := tx.Bucket(prevToNextBucket).Get(hash[:])
+		if next == nil {
+			break
+		}
+		data := locations.Get(next)
+		if data == nil {
+			break
+		}
+		entry, err := decodeBlockIndexEntry(data)
+		if err != nil {
+			return err
+		}
+		if entry.Height != unknownHeight {
+			break
+		}
+
+		height++
+		entry.Height = height
+		if err = locations.Put(next, entry.encode()); err != nil {
+			return err
+		}
+		if entry.Status&statusHaveData != 0 {
+			files[entry.File] = true
+		}
+		copy(hash[:], next)
+	}
+
+	for n := range files {
+		stats, err := db.fileStats(tx, n)
+		if err != nil {
+			return err
+		}
+		if err = tx.Bucket(blockFilesBucket).Put(fileKey(n), stats.encode()); err != nil {
+			return err
+		}
+		updated[n] = stats
+	}
+	return nil
+}
+
+// fileStats computes the stats of the blk file from the index of its blocks.
+func (db *FlatFileBlockRepo) fileStats(tx *bolt.Tx, n uint32) (blockFileStats, error) {
+	stats := db.files[n]
+	if n == db.fileInfo.File {
+		stats.Size = db.fileInfo.Size
+	}
+	stats.MaxHeight = 0
+
+	prefix := fileKey(n)
+	c := tx.Bucket(blockFileHashesBucket).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		data := tx.Bucket(blockLocationBucket).Get(k[len(prefix):])
+		if data == nil {
+			continue
+		}
+		entry, err := decodeBlockIndexEntry(data)
+		if err != nil {
+			return blockFileStats{}, err
+		}
+		stats.MaxHeight = max(stats.MaxHeight, prunableHeight(entry.Height))
+	}
+	return stats, nil
+}
+
+// prunableHeight returns the height of the block that is compared with the prune height. A block with an
+// unknown height is never pruned.
+func prunableHeight(height int32) int32 {
+	if height == unknownHeight {
+		return math.MaxInt32
+	}
+	return height
+}
+
+// nextHeight returns the height of the block that follows the block with the given hash. errUnknownHeight
+// is returned when the block is not saved or its height is unknown.
+func (db *FlatFileBlockRepo) nextHeight(prevBlockHash [32]byte) (int32, error) {
+	if prevBlockHash == sync.GenesisBlockHash {
+		return 1, nil
+	}
+
+	var height int32
+	err := db.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(blockLocationBucket).Get(prevBlockHash[:])
+		if data == nil {
+			return fmt.Errorf("%w: %x is not saved", errUnknownHeight, p2p.Reverse(prevBlockHash))
+		}
+
+		prev, err := decodeBlockIndexEntry(data)
+		if err != nil {
+			return err
+		}
+		if prev.Height == unknownHeight {
+			return fmt.Errorf("%w: %x is saved before its previous block", errUnknownHeight, p2p.Reverse(prevBlockHash))
+		}
+		height = prev.Height + 1
+		return nil
+	})
+	return height, err
+}
+
+// write appends the raw block to the current blk file and starts a new file when the current one is full.
+func (db *FlatFileBlockRepo) write(raw []byte) (blockIndexEntry, error) {
+	recordLength := uint32(blockRecordHeaderLength + len(raw))
+	if db.fileInfo.Size > 0 && db.fileInfo.Size+recordLength > db.maxFileSize {
+		if db.file != nil {
+			db.file.Close()
+			db.file = nil
+		}
+		db.fileInfo = blockFileInfo{File: db.fileInfo.File + 1}
+	}
+
+	if db.file == nil {
+		// a new file is recorded in the index before it is created, so it is known as written by the repo
+		// when it is not indexed because of a crash
+		if db.fileInfo.Size == 0 {
+			err := db.db.Update(func(tx *bolt.Tx) error {
+				return tx.Bucket(blockFileInfoBucket).Put(blockFileInfoKey, db.fileInfo.encode())
+			})
+			if err != nil {
+				return blockIndexEntry{}, err
+			}
+		}
+		f, err := os.OpenFile(db.filePath(db.fileInfo.File), os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return blockIndexEntry{}, err
+		}
+		db.file = f
+	}
+
+	record := make([]byte, 0, recordLength)
+	record = append(record, db.magic[:]...)
+	record = binary.LittleEndian.AppendUint32(record, uint32(len(raw)))
+	record = append(record, raw...)
+
+	if _, err := db.file.WriteAt(record, int64(db.fileInfo.Size)); err != nil {
+		return blockIndexEntry{}, err
+	}
+
+	// the data must be on the disk before the index points to it.
+	if err := db.file.Sync(); err != nil {
+		return blockIndexEntry{}, err
+	}
+
+	entry := blockIndexEntry{
+		Status: statusHaveData,
+		File:   db.fileInfo.File,
+		Offset: db.fileInfo.Size + blockRecordHeaderLength,
+		Length: uint32(len(raw)),
+	}
+	db.fileInfo.Size += recordLength
+	return entry, nil
+}
+
+// Get returns the block with the given hash. sync.ErrNotFound is returned if the block is not stored
+// and sync.ErrPruned if the block is known but its data was pruned.
+func (db *FlatFileBlockRepo) Get(hash [32]byte) (p2p.MsgBlock, error) {
+	entry, err := db.getEntry(hash)
+	if err != nil {
+		return p2p.MsgBlock{}, err
+	}
+
+	return db.read(entry)
+}
+
+// OpenBlock returns a reader of the serialized block with the given hash and its size, so the block can be
+// copied from the blk file without decoding it. The reader must be closed. The same errors as in Get are
+// returned.
+func (db *FlatFileBlockRepo) OpenBlock(hash [32]byte) (io.ReadCloser, int64, error) {
+	entry, err := db.getEntry(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if entry.Status&statusHaveData == 0 {
+		return nil, 0, sync.ErrPruned
+	}
+
+	f, err := os.Open(db.filePath(entry.File))
+	if err != nil {
+		return nil, 0, err
+	}
+	recordHeader := make([]byte, blockRecordHeaderLength)
+	if _, err = f.ReadAt(recordHeader, int64(entry.Offset-blockRecordHeaderLength)); err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	if err = db.checkRecordHeader(recordHeader, entry); err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+
+	// the open file stays readable when it is pruned while the block is copied
+	section := io.NewSectionReader(f, int64(entry.Offset), int64(entry.Length))
+	return blockReader{Reader: section, Closer: f}, int64(entry.Length), nil
+}
+
+// blockReader reads a block from an open blk file and closes the file.
+type blockReader struct {
+	io.Reader
+	io.Closer
+}
+
+// GetHeader returns the header and the height of the block with the given hash. The headers are
+// kept in the index, so they are available for the pruned blocks too.
+func (db *FlatFileBlockRepo) GetHeader(hash [32]byte) (p2p.BlockHeader, int32, error) {
+	entry, err := db.getEntry(hash)
+	if err != nil {
+		return p2p.BlockHeader{}, 0, err
+	}
+
+	var header p2p.BlockHeader
+	if err = wire.NewDecoder(bytes.NewReader(entry.Header)).Decode(&header); err != nil {
+		return p2p.BlockHeader{}, 0, err
+	}
+	return header, entry.Height, nil
+}
+
+// GetLast returns the last block in the chain that is stored.
+func (db *FlatFileBlockRepo) GetLast() (p2p.MsgBlock, error) {
+	var entry blockIndexEntry
+	err := db.db.View(func(tx *bolt.Tx) error {
+		last := lastBlockHash(tx)
+		if last == nil {
+			return sync.ErrNotFound
+		}
+
+		data := tx.Bucket(blockLocationBucket).Get(last)
+		if len(data) == 0 {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		entry, err = decodeBlockIndexEntry(data)
+		return err
+	})
+	if err != nil {
+		return p2p.MsgBlock{}, err
+	}
+
+	return db.read(entry)
+}
+
+// lastBlockHash returns the hash of the last block in the chain, the last saved block of the chain is followed by
+// the blocks that were saved before their previous blocks. It is nil when no block is saved.
+func lastBlockHash(tx *bolt.Tx) []byte {
+	last := tx.Bucket(lastBlockBucket).Get(lastBlockKey)
+	if len(last) == 0 {
+		return nil
+	}
+
+	prevToNext := tx.Bucket(prevToNextBucket)
+	for {
+		next := prevToNext.Get(last)
+		if len(next) == 0 {
+			return last
+		}
+		last = next
+	}
+}
+
+// Delete removes the block with the given hash from the index. Its data stays in the blk file until
+// the file is pruned. When it is the last block, its previous block becomes the last one.
+func (db *FlatFileBlockRepo) Delete(hash [32]byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.db.Update(func(tx *bolt.Tx) error {
+		locations := tx.Bucket(blockLocationBucket)
+		data := locations.Get(hash[:])
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		entry, err := decodeBlockIndexEntry(data)
+		if err != nil {
+			return err
+		}
+		var header p2p.BlockHeader
+		if err = wire.NewDecoder(bytes.NewReader(entry.Header)).Decode(&header); err != nil {
+			return err
+		}
+
+		if err = locations.Delete(hash[:]); err != nil {
+			return err
+		}
+		if entry.Status&statusHaveData != 0 {
+			if err = tx.Bucket(blockFileHashesBucket).Delete(append(fileKey(entry.File), hash[:]...)); err != nil {
+				return err
+			}
+		}
+		return unlinkBlock(tx, hash, header.PrevBlockHash)
+	})
+}
+
+// Prune deletes the oldest blk files until the size of all files is at most target bytes. Only the files
+// whose blocks are at or below the pruneHeight are deleted, and never the one that is currently written.
+// The index entries of the deleted blocks are kept, but they are marked as pruned.
+func (db *FlatFileBlockRepo) Prune(target uint64, pruneHeight int32) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var total uint64
+	files := make([]uint32, 0, len(db.files))
+	for n, stats := range db.files {
+		total += uint64(stats.Size)
+		files = append(files, n)
+	}
+	if total <= target {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i] < files[j] })
+
+	for _, n := range files {
+		stats := db.files[n]
+		if n == db.fileInfo.File || stats.MaxHeight > pruneHeight {
+			continue
+		}
+
+		if err := db.pruneFile(n); err != nil {
+			return err
+		}
+		log.Printf("pruned block file %s with blocks up to height %d\n", db.filePath(n), stats.MaxHeight)
+
+		total -= uint64(stats.Size)
+		if total <= target {
+			break
+		}
+	}
+	return nil
+}
+
+// pruneFile marks all blocks in the file as pruned and then deletes the file. If the program stops
+// before the file is deleted it is deleted on the next start, it is kept in the pruned files until then.
+func (db *FlatFileBlockRepo) pruneFile(n uint32) error {
+	err := db.db.Update(func(tx *bolt.Tx) error {
+		locations := tx.Bucket(blockLocationBucket)
+		fileHashes := tx.Bucket(blockFileHashesBucket)
+		prefix := fileKey(n)
+
+		var keys [][]byte
+		c := fileHashes.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+		}
+
+		for _, k := range keys {
+			hash := k[len(prefix):]
+			data := locations.Get(hash)
+			if data != nil {
+				entry, err := decodeBlockIndexEntry(data)
+				if err != nil {
+					return err
+				}
+				entry.Status &^= statusHaveData
+				entry.File, entry.Offset, entry.Length = 0, 0, 0
+				if err = locations.Put(hash, entry.encode()); err != nil {
+					return err
+				}
+			}
+			if err := fileHashes.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Bucket(prunedFilesBucket).Put(prefix, []byte{}); err != nil {
+			return err
+		}
+		return tx.Bucket(blockFilesBucket).Delete(prefix)
+	})
+	if err != nil {
+		return err
+	}
+
+	delete(db.files, n)
+	return db.deletePrunedFile(n)
+}
+
+// deletePrunedFile deletes the blk file that is pruned from the index and removes it from the pruned files.
+func (db *FlatFileBlockRepo) deletePrunedFile(n uint32) error {
+	if err := os.Remove(db.filePath(n)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(prunedFilesBucket).Delete(fileKey(n))
+	})
+}
+
+// Close closes the blk file that is currently opened for writing.
+func (db *FlatFileBlockRepo) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.file == nil {
+		return nil
+	}
+	err := db.file.Close()
+	db.file = nil
+	return err
+}
+
+func (db *FlatFileBlockRepo) getEntry(hash [32]byte) (blockIndexEntry, error) {
+	var entry blockIndexEntry
+	err := db.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(blockLocationBucket).Get(hash[:])
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		entry, err = decodeBlockIndexEntry(data)
+		return err
+	})
+	return entry, err
+}
+
+func (db *FlatFileBlockRepo) read(entry blockIndexEntry) (p2p.MsgBlock, error) {
+	if entry.Status&statusHaveData == 0 {
+		return p2p.MsgBlock{}, sync.ErrPruned
+	}
+
+	f, err := os.Open(db.filePath(entry.File))
+	if err != nil {
+		return p2p.MsgBlock{}, err
+	}
+	defer f.Close()
+
+	record := make([]byte, blockRecordHeaderLength+entry.Length)
+	if _, err = f.ReadAt(record, int64(entry.Offset-blockRecordHeaderLength)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return p2p.MsgBlock{}, fmt.Errorf("%w: block at %d:%d is truncated", ErrCorruptedBlockFile, entry.File, entry.Offset)
+		}
+		return p2p.MsgBlock{}, err
+	}
+
+	if err = db.checkRecordHeader(record[:blockRecordHeaderLength], entry); err != nil {
+		return p2p.MsgBlock{}, err
+	}
+
+	var block p2p.MsgBlock
+	if err = wire.NewDecoder(bytes.NewReader(record[blockRecordHeaderLength:])).Decode(&block); err != nil {
+		return p2p.MsgBlock{}, fmt.Errorf("%w: %s", ErrCorruptedBlockFile, err)
+	}
+	return block, nil
+}
+
+// checkRecordHeader checks that the magic and the size before the block match the index entry.
+func (db *FlatFileBlockRepo) checkRecordHeader(recordHeader []byte, entry blockIndexEntry) error {
+	if !bytes.Equal(recordHeader[:4], db.magic[:]) || binary.LittleEndian.Uint32(recordHeader[4:8]) != entry.Length {
+		return fmt.Errorf("%w: invalid record header at %d:%d", ErrCorruptedBlockFile, entry.File, entry.Offset)
+	}
+	return nil
+}
+
+func (db *FlatFileBlockRepo) filePath(n uint32) string {
+	return filepath.Join(db.dir, fmt.Sprintf("blk%05d.dat", n))
+}
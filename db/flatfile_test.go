@@ -0,0 +1,287 @@
+package db
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+var coinbase = []p2p.OutPoint{{Index: 0xffffffff}}
+
+func newFlatFileRepo(t *testing.T, dir string) (*FlatFileBlockRepo, BoltDB) {
+	db, err := NewBoltDB(filepath.Join(dir, "index.db"))
+	require.NoError(t, err)
+
+	repo, err := NewFlatFileBlockRepo(db.DB, filepath.Join(dir, "blocks"), "mainnet")
+	require.NoError(t, err)
+	return repo, db
+}
+
+func TestFlatFileBlockRepo_SaveAndGetBlock(t *testing.T) {
+	repo, db := newFlatFileRepo(t, t.TempDir())
+	defer db.Close()
+	defer repo.Close()
+
+	block := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 5000000000))
+	require.NoError(t, repo.Save(block))
+
+	actual, err := repo.Get(block.GetHash())
+	require.NoError(t, err)
+	require.Equal(t, block, actual)
+
+	// the raw block is read from the blk file as it is
+	r, size, err := repo.OpenBlock(block.GetHash())
+	require.NoError(t, err)
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	expected, err := block.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, expected, raw)
+	require.Equal(t, int64(len(expected)), size)
+
+	_, err = repo.Get([32]byte{1})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+	_, _, err = repo.OpenBlock([32]byte{1})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+}
+
+func TestFlatFileBlockRepo_GetLastAndRotateFiles(t *testing.T) {
+	dir := t.TempDir()
+	repo, db := newFlatFileRepo(t, dir)
+	defer db.Close()
+	defer repo.Close()
+	repo.maxFileSize = 300
+
+	prev := sync.GenesisBlockHash
+	var blocks []p2p.MsgBlock
+	for i := 0; i < 5; i++ {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, int64(i)))
+		require.NoError(t, repo.Save(block))
+		blocks = append(blocks, block)
+		prev = block.GetHash()
+	}
+
+	actual, err := repo.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, blocks[4], actual)
+
+	for _, block := range blocks {
+		actual, err = repo.Get(block.GetHash())
+		require.NoError(t, err)
+		require.Equal(t, block, actual)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "blocks", "blk*.dat"))
+	require.NoError(t, err)
+	require.Greater(t, len(files), 1)
+}
+
+func TestFlatFileBlockRepo_ABlockOfAnotherBranchIsNotTheLastBlock(t *testing.T) {
+	repo, db := newFlatFileRepo(t, t.TempDir())
+	defer db.Close()
+	defer repo.Close()
+
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	block2 := testutil.NewMsgBlockWithTxs(block1.GetHash(), testutil.NewMsgTx(coinbase, 2))
+	fork2 := testutil.NewMsgBlockWithTxs(block1.GetHash(), testutil.NewMsgTx(coinbase, 20))
+	// the headers of the test blocks don't depend on their transactions
+	fork2.Nonce = 1
+	fork3 := testutil.NewMsgBlockWithTxs(fork2.GetHash(), testutil.NewMsgTx(coinbase, 30))
+	for _, block := range []p2p.MsgBlock{block1, block2, fork2, fork3} {
+		require.NoError(t, repo.Save(block))
+	}
+
+	actual, err := repo.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, block2, actual)
+
+	// the block that extends the last block becomes the last block
+	block3 := testutil.NewMsgBlockWithTxs(block2.GetHash(), testutil.NewMsgTx(coinbase, 3))
+	require.NoError(t, repo.Save(block3))
+	actual, err = repo.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, block3, actual)
+}
+
+func TestFlatFileBlockRepo_RecoverPartialWrite(t *testing.T) {
+	dir := t.TempDir()
+	repo, db := newFlatFileRepo(t, dir)
+
+	block := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	require.NoError(t, repo.Save(block))
+	require.NoError(t, repo.Close())
+	db.Close()
+
+	path := filepath.Join(dir, "blocks", "blk00000.dat")
+	st, err := os.Stat(path)
+	require.NoError(t, err)
+	indexedSize := st.Size()
+
+	// simulate a crash in the middle of writing the next block.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0xf9, 0xbe, 0xb4, 0xd9, 0xff, 0x00})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	repo, db = newFlatFileRepo(t, dir)
+	defer db.Close()
+	defer repo.Close()
+
+	st, err = os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, indexedSize, st.Size())
+
+	block2 := testutil.NewMsgBlockWithTxs(block.GetHash(), testutil.NewMsgTx(coinbase, 2))
+	require.NoError(t, repo.Save(block2))
+
+	actual, err := repo.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, block2, actual)
+}
+
+func TestFlatFileBlockRepo_RecoverDoesNotDeleteUnknownFiles(t *testing.T) {
+	dir := t.TempDir()
+	repo, db := newFlatFileRepo(t, dir)
+	block := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	require.NoError(t, repo.Save(block))
+	require.NoError(t, repo.Close())
+	db.Close()
+
+	// a blk file of another node in the same blocks dir
+	unknown := filepath.Join(dir, "blocks", "blk00007.dat")
+	require.NoError(t, os.WriteFile(unknown, []byte{0xf9}, 0600))
+
+	db, err := NewBoltDB(filepath.Join(dir, "index.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = NewFlatFileBlockRepo(db.DB, filepath.Join(dir, "blocks"), "mainnet")
+	require.ErrorIs(t, err, ErrUnknownBlockFile)
+	require.FileExists(t, unknown)
+}
+
+func TestFlatFileBlockRepo_RecoverDoesNotDeleteTheFilesOfAnotherIndex(t *testing.T) {
+	dir := t.TempDir()
+	blocks := filepath.Join(dir, "blocks")
+	require.NoError(t, os.MkdirAll(blocks, 0700))
+	existing := filepath.Join(blocks, "blk00000.dat")
+	require.NoError(t, os.WriteFile(existing, []byte{0xf9, 0xbe, 0xb4, 0xd9}, 0600))
+
+	db, err := NewBoltDB(filepath.Join(dir, "index.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = NewFlatFileBlockRepo(db.DB, blocks, "mainnet")
+	require.ErrorIs(t, err, ErrUnknownBlockFile)
+
+	st, err := os.Stat(existing)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), st.Size())
+}
+
+func TestFlatFileBlockRepo_SetsTheHeightsOfTheBlocksSavedBeforeTheirPreviousBlock(t *testing.T) {
+	repo, db := newFlatFileRepo(t, t.TempDir())
+	defer db.Close()
+	defer repo.Close()
+
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	block2 := testutil.NewMsgBlockWithTxs(block1.GetHash(), testutil.NewMsgTx(coinbase, 2))
+	block3 := testutil.NewMsgBlockWithTxs(block2.GetHash(), testutil.NewMsgTx(coinbase, 3))
+	require.NoError(t, repo.Save(block3))
+	require.NoError(t, repo.Save(block2))
+
+	_, height, err := repo.GetHeader(block3.GetHash())
+	require.NoError(t, err)
+	require.Equal(t, int32(unknownHeight), height)
+	// the file with blocks of unknown height is not pruned
+	require.NoError(t, repo.Prune(0, 100))
+	_, err = repo.Get(block3.GetHash())
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(block1))
+	for i, block := range []p2p.MsgBlock{block1, block2, block3} {
+		_, height, err = repo.GetHeader(block.GetHash())
+		require.NoError(t, err)
+		require.Equal(t, int32(i+1), height)
+	}
+	require.Equal(t, int32(3), repo.files[0].MaxHeight)
+}
+
+func TestFlatFileBlockRepo_SaveRollsBackTheFileWhenTheIndexIsNotUpdated(t *testing.T) {
+	dir := t.TempDir()
+	repo, db := newFlatFileRepo(t, dir)
+	defer repo.Close()
+
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	require.NoError(t, repo.Save(block1))
+	size := repo.fileInfo.Size
+
+	// the index can't be updated after the blk file is written
+	block2 := testutil.NewMsgBlockWithTxs(block1.GetHash(), testutil.NewMsgTx(coinbase, 2))
+	require.NoError(t, db.DB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(lastBlockBucket)
+		if err := b.Delete(lastBlockKey); err != nil {
+			return err
+		}
+		_, err := b.CreateBucket(lastBlockKey)
+		return err
+	}))
+	require.ErrorIs(t, repo.Save(block2), bolt.ErrIncompatibleValue)
+	require.Equal(t, size, repo.fileInfo.Size)
+	require.Equal(t, size, repo.files[0].Size)
+	db.Close()
+}
+
+func TestFlatFileBlockRepo_Prune(t *testing.T) {
+	dir := t.TempDir()
+	repo, db := newFlatFileRepo(t, dir)
+	repo.maxFileSize = 300
+
+	prev := sync.GenesisBlockHash
+	var blocks []p2p.MsgBlock
+	for i := 0; i < 6; i++ {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, int64(i)))
+		require.NoError(t, repo.Save(block))
+		blocks = append(blocks, block)
+		prev = block.GetHash()
+	}
+
+	require.NoError(t, repo.Prune(0, 3))
+
+	for i, block := range blocks {
+		header, height, err := repo.GetHeader(block.GetHash())
+		require.NoError(t, err)
+		require.Equal(t, block.BlockHeader, header)
+		require.Equal(t, int32(i+1), height)
+
+		_, err = repo.Get(block.GetHash())
+		if height <= 3 {
+			require.ErrorIs(t, err, sync.ErrPruned)
+			_, _, err = repo.OpenBlock(block.GetHash())
+			require.ErrorIs(t, err, sync.ErrPruned)
+			continue
+		}
+		require.NoError(t, err)
+	}
+	require.NoFileExists(t, filepath.Join(dir, "blocks", "blk00000.dat"))
+
+	// the pruned state must survive a restart
+	require.NoError(t, repo.Close())
+	db.Close()
+	repo, db = newFlatFileRepo(t, dir)
+	defer db.Close()
+	defer repo.Close()
+
+	_, err := repo.Get(blocks[0].GetHash())
+	require.ErrorIs(t, err, sync.ErrPruned)
+	actual, err := repo.GetLast()
+	require.NoError(t, err)
+	require.Equal(t, blocks[5], actual)
+}
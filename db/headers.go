@@ -0,0 +1,479 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	headerIndexBucket  = []byte("HeaderIndexBucket")
+	headerHeightBucket = []byte("HeaderHeightBucket")
+	headerStateBucket  = []byte("HeaderStateBucket")
+	headerTipBucket    = []byte("HeaderTipBucket") // the headers that are not extended by another header
+	bestHeaderKey      = []byte("BestHeaderKey")
+
+	// ErrHeaderNotConnected is returned when the previous block of a header is not in the header store.
+	ErrHeaderNotConnected = errors.New("the header doesn't connect to a known header")
+)
+
+const (
+	// headerImportBatch is the number of headers that are added in one transaction by ImportBlocks.
+	headerImportBatch = 2000
+	// genesisBits is the difficulty of the genesis block, its work is not included in the stored chain work.
+	genesisBits = 0x1d00ffff
+)
+
+// headerEntry is a header-only block index entry. The chain work is the total work of the chain up to
+// and including the block.
+type headerEntry struct {
+	Height    int32
+	ChainWork *big.Int
+	Header    p2p.BlockHeader
+}
+
+func (e headerEntry) encode() ([]byte, error) {
+	raw, err := wire.Marshal(e.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	work := e.ChainWork.Bytes()
+	b := binary.LittleEndian.AppendUint32(make([]byte, 0, 4+1+len(work)+len(raw)), uint32(e.Height))
+	b = append(b, byte(len(work)))
+	b = append(b, work...)
+	return append(b, raw...), nil
+}
+
+func decodeHeaderEntry(b []byte) (headerEntry, error) {
+	if len(b) < 5 || len(b) < 5+int(b[4]) {
+		return headerEntry{}, fmt.Errorf("invalid header entry length: %d", len(b))
+	}
+
+	workEnd := 5 + int(b[4])
+	var header p2p.BlockHeader
+	if err := wire.NewDecoder(bytes.NewReader(b[workEnd:])).Decode(&header); err != nil {
+		return headerEntry{}, err
+	}
+	return headerEntry{
+		Height:    int32(binary.LittleEndian.Uint32(b[:4])),
+		ChainWork: new(big.Int).SetBytes(b[5:workEnd]),
+		Header:    header,
+	}, nil
+}
+
+// HeaderStore persists the validated headers as header-only block index entries, so the header chain
+// survives restarts and is not downloaded again. The best header is the header with the most chain work,
+// the headers of its chain are indexed by height and the block download follows them.
+type HeaderStore struct {
+	db *bolt.DB
+}
+
+// NewHeaderStore creates a HeaderStore that is stored in the given BoltDB.
+func NewHeaderStore(db *bolt.DB) (*HeaderStore, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{headerIndexBucket, headerHeightBucket, headerStateBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		if tx.Bucket(headerTipBucket) != nil {
+			return nil
+		}
+		// the tips of the headers that are stored before the tips were kept are found once
+		if _, err := tx.CreateBucket(headerTipBucket); err != nil {
+			return err
+		}
+		return indexTips(tx)
+	})
+	return &HeaderStore{db: db}, err
+}
+
+// indexTips adds the stored headers that are not extended by another header to the tips.
+func indexTips(tx *bolt.Tx) error {
+	index := tx.Bucket(headerIndexBucket)
+	extended := make(map[[32]byte]struct{})
+	err := index.ForEach(func(_, v []byte) error {
+		entry, err := decodeHeaderEntry(v)
+		if err != nil {
+			return err
+		}
+		extended[entry.Header.PrevBlockHash] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tips := tx.Bucket(headerTipBucket)
+	return index.ForEach(func(k, _ []byte) error {
+		if _, ok := extended[[32]byte(k)]; ok {
+			return nil
+		}
+		return tips.Put(k, []byte{})
+	})
+}
+
+// AddHeaders stores the headers. The headers must be in the order of the chain and the first one must
+// connect to a stored header or to the genesis block. The headers that are already stored are skipped.
+func (hs *HeaderStore) AddHeaders(headers []p2p.BlockHeader) error {
+	return hs.db.Update(func(tx *bolt.Tx) error {
+		return addHeaders(tx, headers)
+	})
+}
+
+func addHeaders(tx *bolt.Tx, headers []p2p.BlockHeader) error {
+	index := tx.Bucket(headerIndexBucket)
+	state := tx.Bucket(headerStateBucket)
+	tips := tx.Bucket(headerTipBucket)
+	bestHash, best, err := bestHeaderEntry(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, header := range headers {
+		hash := sync.Hash(header)
+		if index.Get(hash[:]) != nil {
+			continue
+		}
+
+		parent, err := headerEntryOf(index, header.PrevBlockHash)
+		if err != nil {
+			return fmt.Errorf("header %x: %w", p2p.Reverse(hash), err)
+		}
+
+		entry := headerEntry{
+			Height:    parent.Height + 1,
+			ChainWork: new(big.Int).Add(parent.ChainWork, headerWork(header.Bits)),
+			Header:    header,
+		}
+		data, err := entry.encode()
+		if err != nil {
+			return err
+		}
+		if err = index.Put(hash[:], data); err != nil {
+			return err
+		}
+		if err = tips.Delete(header.PrevBlockHash[:]); err != nil {
+			return err
+		}
+		// bolt keeps the key until the transaction is committed, so it must not be changed
+		key := hash
+		if err = tips.Put(key[:], []byte{}); err != nil {
+			return err
+		}
+
+		// the headers with the same work as the best header become best only when they extend it
+		cmp := entry.ChainWork.Cmp(best.ChainWork)
+		if cmp < 0 || (cmp == 0 && header.PrevBlockHash != bestHash) {
+			continue
+		}
+		if err = setBestHeader(tx, hash, entry); err != nil {
+			return err
+		}
+		bestHash, best = hash, entry
+	}
+	return state.Put(bestHeaderKey, bestHash[:])
+}
+
+// setBestHeader indexes by height the chain of the new best header. The heights above the fork point
+// with the old best chain are rewritten.
+func setBestHeader(tx *bolt.Tx, hash [32]byte, entry headerEntry) error {
+	heights := tx.Bucket(headerHeightBucket)
+	index := tx.Bucket(headerIndexBucket)
+
+	// the heights above the new best header belong to the old best chain
+	c := heights.Cursor()
+	for k, _ := c.Seek(heightKey(entry.Height + 1)); k != nil; k, _ = c.Seek(heightKey(entry.Height + 1)) {
+		if err := heights.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	for hash != sync.GenesisBlockHash {
+		key := heightKey(entry.Height)
+		if bytes.Equal(heights.Get(key), hash[:]) {
+			return nil
+		}
+		// bolt keeps the value until the transaction is committed, so it must not be changed
+		value := hash
+		if err := heights.Put(key, value[:]); err != nil {
+			return err
+		}
+
+		hash = entry.Header.PrevBlockHash
+		var err error
+		if entry, err = headerEntryOf(index, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BestHeader returns the header with the most chain work and its height. sync.ErrNotFound is returned
+// when no headers are stored.
+func (hs *HeaderStore) BestHeader() (p2p.BlockHeader, int32, error) {
+	var entry headerEntry
+	var hash [32]byte
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		var err error
+		hash, entry, err = bestHeaderEntry(tx)
+		return err
+	})
+	if err != nil {
+		return p2p.BlockHeader{}, 0, err
+	}
+	if hash == sync.GenesisBlockHash {
+		return p2p.BlockHeader{}, 0, sync.ErrNotFound
+	}
+	return entry.Header, entry.Height, nil
+}
+
+// GetHeader returns the stored header with the given hash and its height.
+func (hs *HeaderStore) GetHeader(hash [32]byte) (p2p.BlockHeader, int32, error) {
+	var entry headerEntry
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(headerIndexBucket).Get(hash[:])
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		entry, err = decodeHeaderEntry(data)
+		return err
+	})
+	return entry.Header, entry.Height, err
+}
+
+// HashAt returns the hash of the header at the given height on the best header chain. sync.ErrNotFound
+// is returned when the best header chain is shorter.
+func (hs *HeaderStore) HashAt(height int32) ([32]byte, error) {
+	if height == 0 {
+		return sync.GenesisBlockHash, nil
+	}
+
+	var hash [32]byte
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(headerHeightBucket).Get(heightKey(height))
+		if len(data) != 32 {
+			return sync.ErrNotFound
+		}
+		hash = [32]byte(data)
+		return nil
+	})
+	return hash, err
+}
+
+// ChainWork returns the total work of the chain up to and including the header with the given hash. It
+// includes the work of the genesis block, the same as the chain work in Bitcoin Core.
+func (hs *HeaderStore) ChainWork(hash [32]byte) (*big.Int, error) {
+	var entry headerEntry
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		if hash != sync.GenesisBlockHash && tx.Bucket(headerIndexBucket).Get(hash[:]) == nil {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		entry, err = headerEntryOf(tx.Bucket(headerIndexBucket), hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.ChainWork.Add(entry.ChainWork, headerWork(genesisBits)), nil
+}
+
+// Tips returns the hashes of the stored headers that are not extended by another header, the best
+// header is one of them. The genesis block is returned when no headers are stored.
+func (hs *HeaderStore) Tips() ([][32]byte, error) {
+	var tips [][32]byte
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(headerTipBucket).ForEach(func(k, _ []byte) error {
+			tips = append(tips, [32]byte(k))
+			return nil
+		})
+	})
+	if err == nil && len(tips) == 0 {
+		tips = append(tips, sync.GenesisBlockHash)
+	}
+	return tips, err
+}
+
+// HeadersAfter returns up to max headers of the best header chain after the block with the given hash.
+// When the block is not on the best chain the headers after the fork point are returned.
+func (hs *HeaderStore) HeadersAfter(hash [32]byte, max int) ([]p2p.BlockHeader, error) {
+	var headers []p2p.BlockHeader
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		index, heights := tx.Bucket(headerIndexBucket), tx.Bucket(headerHeightBucket)
+
+		// find the last common block of the block's chain and the best chain
+		entry, err := headerEntryOf(index, hash)
+		if err != nil {
+			return err
+		}
+		for hash != sync.GenesisBlockHash && !bytes.Equal(heights.Get(heightKey(entry.Height)), hash[:]) {
+			hash = entry.Header.PrevBlockHash
+			if entry, err = headerEntryOf(index, hash); err != nil {
+				return err
+			}
+		}
+
+		c := heights.Cursor()
+		for k, v := c.Seek(heightKey(entry.Height + 1)); k != nil && len(headers) < max; k, v = c.Next() {
+			next, err := headerEntryOf(index, [32]byte(v))
+			if err != nil {
+				return err
+			}
+			headers = append(headers, next.Header)
+		}
+		return nil
+	})
+	return headers, err
+}
+
+// BlockLocator returns the block locator of the best header chain. It ends with the genesis block hash.
+func (hs *HeaderStore) BlockLocator() ([][32]byte, error) {
+	var locator [][32]byte
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		_, best, err := bestHeaderEntry(tx)
+		if err != nil {
+			return err
+		}
+
+		heights := tx.Bucket(headerHeightBucket)
+		for _, height := range sync.LocatorHeights(best.Height) {
+			if height == 0 {
+				locator = append(locator, sync.GenesisBlockHash)
+				continue
+			}
+			hash := heights.Get(heightKey(height))
+			if len(hash) != 32 {
+				return fmt.Errorf("missing best header at height %d", height)
+			}
+			locator = append(locator, [32]byte(hash))
+		}
+		return nil
+	})
+	return locator, err
+}
+
+// FindFork returns the first hash of the block locator that is on the best header chain. When none of
+// them is, the chains fork at the genesis block.
+func (hs *HeaderStore) FindFork(locator [][32]byte) ([32]byte, error) {
+	fork := sync.GenesisBlockHash
+	err := hs.db.View(func(tx *bolt.Tx) error {
+		index, heights := tx.Bucket(headerIndexBucket), tx.Bucket(headerHeightBucket)
+		for _, hash := range locator {
+			data := index.Get(hash[:])
+			if data == nil {
+				continue
+			}
+			entry, err := decodeHeaderEntry(data)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(heights.Get(heightKey(entry.Height)), hash[:]) {
+				fork = hash
+				return nil
+			}
+		}
+		return nil
+	})
+	return fork, err
+}
+
+// ImportBlocks adds the headers of the stored blocks that are not in the header store, e.g. when the
+// header store is enabled for a node that already has blocks or after the blocks are imported from files.
+// It returns the number of the added headers.
+func (hs *HeaderStore) ImportBlocks(br sync.BlockRepository) (int, error) {
+	block, err := br.GetLast()
+	if errors.Is(err, sync.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// walk back from the last block to the first block whose header is stored
+	var headers []p2p.BlockHeader
+	for {
+		if _, _, err = hs.GetHeader(block.GetHash()); err == nil {
+			break
+		}
+		if !errors.Is(err, sync.ErrNotFound) {
+			return 0, err
+		}
+
+		headers = append(headers, block.BlockHeader)
+		if block.PrevBlockHash == sync.GenesisBlockHash {
+			break
+		}
+		prev := block.PrevBlockHash
+		if block, err = br.Get(prev); err != nil {
+			return 0, fmt.Errorf("failed to get block %x: %w", p2p.Reverse(prev), err)
+		}
+	}
+
+	for i, j := 0, len(headers)-1; i < j; i, j = i+1, j-1 {
+		headers[i], headers[j] = headers[j], headers[i]
+	}
+	for i := 0; i < len(headers); i += headerImportBatch {
+		if err = hs.AddHeaders(headers[i:min(i+headerImportBatch, len(headers))]); err != nil {
+			return i, err
+		}
+	}
+	return len(headers), nil
+}
+
+// headerEntryOf returns the stored entry of the header with the given hash. The genesis block is not
+// stored, it is at height 0 with no chain work.
+func headerEntryOf(index *bolt.Bucket, hash [32]byte) (headerEntry, error) {
+	if hash == sync.GenesisBlockHash {
+		return headerEntry{ChainWork: new(big.Int)}, nil
+	}
+
+	data := index.Get(hash[:])
+	if data == nil {
+		return headerEntry{}, ErrHeaderNotConnected
+	}
+	return decodeHeaderEntry(data)
+}
+
+func bestHeaderEntry(tx *bolt.Tx) ([32]byte, headerEntry, error) {
+	hash := sync.GenesisBlockHash
+	if data := tx.Bucket(headerStateBucket).Get(bestHeaderKey); len(data) == 32 {
+		hash = [32]byte(data)
+	}
+	entry, err := headerEntryOf(tx.Bucket(headerIndexBucket), hash)
+	return hash, entry, err
+}
+
+func heightKey(height int32) []byte {
+	// big endian, so the cursor iterates the heights in order
+	return binary.BigEndian.AppendUint32(nil, uint32(height))
+}
+
+// headerWork returns the expected number of hashes to mine a block with the given bits, 2^256 / (target + 1).
+func headerWork(bits uint32) *big.Int {
+	exponent := bits >> 24
+	mantissa := int64(bits & 0x007fffff)
+	target := big.NewInt(mantissa)
+	if exponent <= 3 {
+		target.Rsh(target, 8*(3-uint(exponent)))
+	} else {
+		target.Lsh(target, 8*(uint(exponent)-3))
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), 256)
+	if target.Sign() <= 0 || target.Cmp(limit) >= 0 {
+		return new(big.Int)
+	}
+	return limit.Div(limit, target.Add(target, big.NewInt(1)))
+}
@@ -0,0 +1,228 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// newHeader returns a header with the difficulty of the first mainnet blocks.
+func newHeader(prev [32]byte, nonce uint32) p2p.BlockHeader {
+	header := testutil.NewBlockHeader(prev)
+	header.Bits = 0x1d00ffff
+	header.Nonce = nonce
+	return header
+}
+
+func TestHeaderStore_AddHeadersSurvivesRestart(t *testing.T) {
+	path := t.TempDir() + "/headers.db"
+	boltDB, err := NewBoltDB(path)
+	require.NoError(t, err)
+	hs, err := NewHeaderStore(boltDB.DB)
+	require.NoError(t, err)
+
+	_, _, err = hs.BestHeader()
+	require.ErrorIs(t, err, sync.ErrNotFound)
+
+	h1 := newHeader(sync.GenesisBlockHash, 1)
+	h2 := newHeader(sync.Hash(h1), 2)
+	h3 := newHeader(sync.Hash(h2), 3)
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{h1, h2}))
+	// the known headers are skipped
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{h2, h3}))
+	boltDB.Close()
+
+	boltDB, err = NewBoltDB(path)
+	require.NoError(t, err)
+	defer boltDB.Close()
+	hs, err = NewHeaderStore(boltDB.DB)
+	require.NoError(t, err)
+
+	best, height, err := hs.BestHeader()
+	require.NoError(t, err)
+	require.Equal(t, h3, best)
+	require.Equal(t, int32(3), height)
+
+	header, height, err := hs.GetHeader(sync.Hash(h2))
+	require.NoError(t, err)
+	require.Equal(t, h2, header)
+	require.Equal(t, int32(2), height)
+
+	headers, err := hs.HeadersAfter(sync.GenesisBlockHash, 10)
+	require.NoError(t, err)
+	require.Equal(t, []p2p.BlockHeader{h1, h2, h3}, headers)
+
+	headers, err = hs.HeadersAfter(sync.Hash(h1), 1)
+	require.NoError(t, err)
+	require.Equal(t, []p2p.BlockHeader{h2}, headers)
+
+	headers, err = hs.HeadersAfter(sync.Hash(h3), 10)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+}
+
+func TestHeaderStore_TheBestHeaderHasTheMostWork(t *testing.T) {
+	hs, err := NewHeaderStore(newBoltDB(t))
+	require.NoError(t, err)
+
+	h1 := newHeader(sync.GenesisBlockHash, 1)
+	h2 := newHeader(sync.Hash(h1), 2)
+	h3 := newHeader(sync.Hash(h2), 3)
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{h1, h2, h3}))
+
+	// a shorter fork with less work doesn't change the best header
+	f2 := newHeader(sync.Hash(h1), 20)
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{f2}))
+	best, _, err := hs.BestHeader()
+	require.NoError(t, err)
+	require.Equal(t, h3, best)
+
+	// a fork with a higher difficulty has more work than the longer chain
+	f3 := newHeader(sync.Hash(f2), 30)
+	f3.Bits = 0x1c00ffff
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{f3}))
+	best, height, err := hs.BestHeader()
+	require.NoError(t, err)
+	require.Equal(t, f3, best)
+	require.Equal(t, int32(3), height)
+
+	headers, err := hs.HeadersAfter(sync.GenesisBlockHash, 10)
+	require.NoError(t, err)
+	require.Equal(t, []p2p.BlockHeader{h1, f2, f3}, headers)
+
+	// the headers of the best chain after the fork point are returned for a block of the old chain
+	headers, err = hs.HeadersAfter(sync.Hash(h3), 10)
+	require.NoError(t, err)
+	require.Equal(t, []p2p.BlockHeader{f2, f3}, headers)
+}
+
+func TestHeaderStore_TipsHashAtAndChainWork(t *testing.T) {
+	boltDB := newBoltDB(t)
+	hs, err := NewHeaderStore(boltDB)
+	require.NoError(t, err)
+
+	tips, err := hs.Tips()
+	require.NoError(t, err)
+	require.Equal(t, [][32]byte{sync.GenesisBlockHash}, tips)
+
+	h1 := newHeader(sync.GenesisBlockHash, 1)
+	h2 := newHeader(sync.Hash(h1), 2)
+	f2 := newHeader(sync.Hash(h1), 20)
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{h1, h2}))
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{f2}))
+
+	tips, err = hs.Tips()
+	require.NoError(t, err)
+	require.ElementsMatch(t, [][32]byte{sync.Hash(h2), sync.Hash(f2)}, tips)
+
+	hash, err := hs.HashAt(2)
+	require.NoError(t, err)
+	require.Equal(t, sync.Hash(h2), hash)
+	hash, err = hs.HashAt(0)
+	require.NoError(t, err)
+	require.Equal(t, sync.GenesisBlockHash, hash)
+	_, err = hs.HashAt(3)
+	require.ErrorIs(t, err, sync.ErrNotFound)
+
+	// every block with the difficulty of the genesis block adds 0x100010001 work
+	work, err := hs.ChainWork(sync.Hash(h2))
+	require.NoError(t, err)
+	require.Equal(t, "300030003", work.Text(16))
+	_, err = hs.ChainWork([32]byte{1})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+
+	// the tips of a header store without them are found when it is opened
+	require.NoError(t, boltDB.Update(func(tx *bolt.Tx) error { return tx.DeleteBucket(headerTipBucket) }))
+	hs, err = NewHeaderStore(boltDB)
+	require.NoError(t, err)
+	tips, err = hs.Tips()
+	require.NoError(t, err)
+	require.ElementsMatch(t, [][32]byte{sync.Hash(h2), sync.Hash(f2)}, tips)
+}
+
+func TestHeaderStore_AddHeadersThatDontConnect(t *testing.T) {
+	hs, err := NewHeaderStore(newBoltDB(t))
+	require.NoError(t, err)
+
+	h1 := newHeader(sync.GenesisBlockHash, 1)
+	h2 := newHeader(sync.Hash(h1), 2)
+	err = hs.AddHeaders([]p2p.BlockHeader{h2})
+	require.ErrorIs(t, err, ErrHeaderNotConnected)
+
+	_, _, err = hs.BestHeader()
+	require.ErrorIs(t, err, sync.ErrNotFound)
+}
+
+func TestHeaderStore_BlockLocatorAndFindFork(t *testing.T) {
+	hs, err := NewHeaderStore(newBoltDB(t))
+	require.NoError(t, err)
+
+	locator, err := hs.BlockLocator()
+	require.NoError(t, err)
+	require.Equal(t, [][32]byte{sync.GenesisBlockHash}, locator)
+
+	headers := make([]p2p.BlockHeader, 15)
+	prev := sync.GenesisBlockHash
+	for i := range headers {
+		headers[i] = newHeader(prev, uint32(i))
+		prev = sync.Hash(headers[i])
+	}
+	require.NoError(t, hs.AddHeaders(headers))
+
+	locator, err = hs.BlockLocator()
+	require.NoError(t, err)
+	// the heights 15 to 6, then 4 and the genesis block
+	require.Len(t, locator, 12)
+	require.Equal(t, sync.Hash(headers[14]), locator[0])
+	require.Equal(t, sync.Hash(headers[5]), locator[9])
+	require.Equal(t, sync.Hash(headers[3]), locator[10])
+	require.Equal(t, sync.GenesisBlockHash, locator[11])
+
+	// the first hash of the locator that is on the best chain is the fork point
+	fork := newHeader(sync.Hash(headers[3]), 100)
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{fork}))
+	hash, err := hs.FindFork([][32]byte{{1}, sync.Hash(fork), sync.Hash(headers[2]), sync.GenesisBlockHash})
+	require.NoError(t, err)
+	require.Equal(t, sync.Hash(headers[2]), hash)
+
+	hash, err = hs.FindFork([][32]byte{{1}})
+	require.NoError(t, err)
+	require.Equal(t, sync.GenesisBlockHash, hash)
+}
+
+func TestHeaderStore_ImportBlocks(t *testing.T) {
+	hs, err := NewHeaderStore(newBoltDB(t))
+	require.NoError(t, err)
+
+	br := NewMemoryBlockRepo()
+	n, err := hs.ImportBlocks(br)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	b1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx([]p2p.OutPoint{{Index: 0xffffffff}}, 50))
+	b2 := testutil.NewMsgBlockWithTxs(b1.GetHash(), testutil.NewMsgTx([]p2p.OutPoint{{Index: 0xffffffff}}, 25))
+	require.NoError(t, br.Save(b1))
+	require.NoError(t, hs.AddHeaders([]p2p.BlockHeader{b1.BlockHeader}))
+	require.NoError(t, br.Save(b2))
+
+	// only the header of the block that is saved after its parent's header is stored is added
+	n, err = hs.ImportBlocks(br)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	best, height, err := hs.BestHeader()
+	require.NoError(t, err)
+	require.Equal(t, b2.BlockHeader, best)
+	require.Equal(t, int32(2), height)
+}
+
+func newBoltDB(t *testing.T) *bolt.DB {
+	db, err := NewBoltDB(t.TempDir() + "/headers.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db.DB
+}
@@ -0,0 +1,165 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+var errIndexStopped = errors.New("index is stopped")
+
+// chainIndex is an index that is built block by block in the order of the chain.
+type chainIndex interface {
+	BestBlock() ([32]byte, int32, error)
+	ConnectBlock(block p2p.MsgBlock) error
+}
+
+// indexSyncer indexes in the background the blocks that are saved in the block repository before
+// the index was enabled.
+type indexSyncer struct {
+	name            string
+	index           chainIndex
+	blockRepository sync.BlockRepository
+	stop            chan struct{}
+	done            chan struct{}
+	isStarted       atomic.Bool
+}
+
+func newIndexSyncer(name string, index chainIndex, br sync.BlockRepository) *indexSyncer {
+	return &indexSyncer{
+		name:            name,
+		index:           index,
+		blockRepository: br,
+		stop:            make(chan struct{}, 1000),
+		done:            make(chan struct{}, 1000),
+	}
+}
+
+// Start starts indexing the blocks that are saved in the block repository but are not indexed yet.
+func (is *indexSyncer) Start() {
+	if is.isStarted.Load() {
+		log.Printf("%s is already started.\n", is.name)
+		return
+	}
+	is.isStarted.Store(true)
+	go is.backfill()
+	log.Printf("Start %s.\n", is.name)
+}
+
+// Stop stops the background indexing.
+func (is *indexSyncer) Stop() {
+	if !is.isStarted.Load() {
+		log.Printf("%s is not started and can't be stopped.\n", is.name)
+		return
+	}
+	is.isStarted.Store(false)
+	is.stop <- struct{}{}
+	<-is.done
+	log.Printf("Stop %s\n", is.name)
+}
+
+// backfill indexes the blocks that are saved before the index was enabled and waits to be stopped.
+func (is *indexSyncer) backfill() {
+	if err := is.sync(); err != nil {
+		if errors.Is(err, errIndexStopped) {
+			is.done <- struct{}{}
+			return
+		}
+		log.Printf("failed to sync the %s: %s\n", is.name, err)
+	}
+
+	<-is.stop
+	is.done <- struct{}{}
+}
+
+// sync indexes the blocks from the last indexed block to the last block in the repository.
+// It repeats until the index catches up with the repository, because new blocks can be saved
+// while it is running.
+func (is *indexSyncer) sync() error {
+	for {
+		hashes, err := is.notIndexedBlocks()
+		if err != nil {
+			return err
+		}
+
+		if len(hashes) == 0 {
+			log.Printf("%s is synced with the chain\n", is.name)
+			return nil
+		}
+
+		log.Printf("%s: index %d blocks\n", is.name, len(hashes))
+		for _, hash := range hashes {
+			select {
+			case <-is.stop:
+				return errIndexStopped
+			default:
+			}
+
+			block, err := is.blockRepository.Get(hash)
+			if err != nil {
+				return fmt.Errorf("failed to get block %x: %w", p2p.Reverse(hash), err)
+			}
+
+			if err = is.index.ConnectBlock(block); err != nil {
+				return fmt.Errorf("failed to index block %x: %w", p2p.Reverse(hash), err)
+			}
+		}
+	}
+}
+
+// notIndexedBlocks returns the hashes of the blocks after the last indexed block up to the last
+// block in the repository, in the order of the chain.
+func (is *indexSyncer) notIndexedBlocks() ([][32]byte, error) {
+	bestHash, _, err := is.index.BestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := is.blockRepository.GetLast()
+	if errors.Is(err, sync.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes [][32]byte
+	for block.GetHash() != bestHash {
+		hashes = append(hashes, block.GetHash())
+		if block.PrevBlockHash == bestHash {
+			break
+		}
+		if block.PrevBlockHash == sync.GenesisBlockHash {
+			return nil, fmt.Errorf("the last indexed block %x is not in the chain", p2p.Reverse(bestHash))
+		}
+
+		if block, err = is.blockRepository.Get(block.PrevBlockHash); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, j := 0, len(hashes)-1; i < j; i, j = i+1, j-1 {
+		hashes[i], hashes[j] = hashes[j], hashes[i]
+	}
+	return hashes, nil
+}
+
+// indexBestBlock returns the last block that is indexed in the index with the given state bucket.
+func indexBestBlock(state *bolt.Bucket) ([32]byte, int32) {
+	data := state.Get(bestBlockKey)
+	if len(data) != 36 {
+		return sync.GenesisBlockHash, 0
+	}
+	return [32]byte(data[:32]), int32(binary.LittleEndian.Uint32(data[32:]))
+}
+
+func putIndexBestBlock(state *bolt.Bucket, hash [32]byte, height int32) error {
+	data := binary.LittleEndian.AppendUint32(append([]byte{}, hash[:]...), uint32(height))
+	return state.Put(bestBlockKey, data)
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	stdsync "sync"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// MemoryBlockRepo keeps the blocks in memory. The blocks are stored serialized, so the blocks that
+// are returned don't share memory with the stored ones. It is useful for tests and for short-lived nodes.
+type MemoryBlockRepo struct {
+	mu         stdsync.RWMutex
+	blocks     map[[32]byte][]byte
+	prevToNext map[[32]byte][32]byte
+	last       *[32]byte
+}
+
+// NewMemoryBlockRepo creates an empty MemoryBlockRepo.
+func NewMemoryBlockRepo() *MemoryBlockRepo {
+	return &MemoryBlockRepo{
+		blocks:     make(map[[32]byte][]byte),
+		prevToNext: make(map[[32]byte][32]byte),
+	}
+}
+
+// Save stores the block and makes it the last block.
+func (db *MemoryBlockRepo) Save(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	raw, err := wire.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("failed to serialize block %x: %w", p2p.Reverse(hash), err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.blocks[hash] = raw
+	db.prevToNext[block.PrevBlockHash] = hash
+	db.last = &hash
+	return nil
+}
+
+// Get returns the block with the given hash. sync.ErrNotFound is returned if the block is not stored.
+func (db *MemoryBlockRepo) Get(hash [32]byte) (p2p.MsgBlock, error) {
+	db.mu.RLock()
+	raw, ok := db.blocks[hash]
+	db.mu.RUnlock()
+	if !ok {
+		return p2p.MsgBlock{}, sync.ErrNotFound
+	}
+
+	return decodeBlock(raw)
+}
+
+// GetLast returns the last block in the chain that is stored.
+func (db *MemoryBlockRepo) GetLast() (p2p.MsgBlock, error) {
+	db.mu.RLock()
+	if db.last == nil {
+		db.mu.RUnlock()
+		return p2p.MsgBlock{}, sync.ErrNotFound
+	}
+
+	lastBlockHash := *db.last
+	for {
+		next, ok := db.prevToNext[lastBlockHash]
+		if !ok {
+			break
+		}
+		lastBlockHash = next
+	}
+	raw, ok := db.blocks[lastBlockHash]
+	db.mu.RUnlock()
+	if !ok {
+		return p2p.MsgBlock{}, sync.ErrNotFound
+	}
+
+	return decodeBlock(raw)
+}
+
+// Delete removes the block with the given hash. When it is the last block, its previous block becomes the last one.
+func (db *MemoryBlockRepo) Delete(hash [32]byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	raw, ok := db.blocks[hash]
+	if !ok {
+		return sync.ErrNotFound
+	}
+	block, err := decodeBlock(raw)
+	if err != nil {
+		return err
+	}
+
+	delete(db.blocks, hash)
+	if next, ok := db.prevToNext[block.PrevBlockHash]; ok && next == hash {
+		delete(db.prevToNext, block.PrevBlockHash)
+	}
+	delete(db.prevToNext, hash)
+	if *db.last == hash {
+		*db.last = block.PrevBlockHash
+	}
+	return nil
+}
+
+func decodeBlock(raw []byte) (p2p.MsgBlock, error) {
+	var block p2p.MsgBlock
+	if err := wire.NewDecoder(bytes.NewReader(raw)).Decode(&block); err != nil {
+		return p2p.MsgBlock{}, err
+	}
+	return block, nil
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	stdsync "sync"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/cockroachdb/pebble"
+)
+
+// The keys in Pebble are prefixed by the type of the stored value.
+const (
+	pebbleBlockPrefix      = 'b'
+	pebblePrevToNextPrefix = 'n'
+)
+
+var pebbleLastBlockKey = []byte("l")
+
+// PebbleBlockRepo stores the blocks in Pebble (an LSM key-value store), which handles a high
+// write load better than BoltDB. The blocks are stored in the wire format.
+type PebbleBlockRepo struct {
+	db *pebble.DB
+	// mu serializes the writes, so the last block and the links between the blocks are consistent.
+	mu stdsync.Mutex
+}
+
+// NewPebbleBlockRepo opens (or creates) the Pebble database in the dir.
+func NewPebbleBlockRepo(dir string) (*PebbleBlockRepo, error) {
+	db, err := pebble.Open(dir, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pebble db %s: %w", dir, err)
+	}
+	return &PebbleBlockRepo{db: db}, nil
+}
+
+// Save stores the block and makes it the last block.
+func (db *PebbleBlockRepo) Save(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	raw, err := wire.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("failed to serialize block %x: %w", p2p.Reverse(hash), err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	b := db.db.NewBatch()
+	defer b.Close()
+	if err = b.Set(pebbleKey(pebbleBlockPrefix, hash), raw, nil); err != nil {
+		return err
+	}
+	if err = b.Set(pebbleKey(pebblePrevToNextPrefix, block.PrevBlockHash), hash[:], nil); err != nil {
+		return err
+	}
+	if err = b.Set(pebbleLastBlockKey, hash[:], nil); err != nil {
+		return err
+	}
+	return b.Commit(pebble.Sync)
+}
+
+// Get returns the block with the given hash. sync.ErrNotFound is returned if the block is not stored.
+func (db *PebbleBlockRepo) Get(hash [32]byte) (p2p.MsgBlock, error) {
+	raw, err := pebbleGet(db.db, pebbleKey(pebbleBlockPrefix, hash))
+	if err != nil {
+		return p2p.MsgBlock{}, err
+	}
+	return decodeBlock(raw)
+}
+
+// GetLast returns the last block in the chain that is stored.
+func (db *PebbleBlockRepo) GetLast() (p2p.MsgBlock, error) {
+	// a snapshot guarantees that the links between the blocks don't change while they are followed
+	snap := db.db.NewSnapshot()
+	defer snap.Close()
+
+	lastBlockHash, err := pebbleGet(snap, pebbleLastBlockKey)
+	if err != nil {
+		return p2p.MsgBlock{}, err
+	}
+
+	for {
+		next, err := pebbleGet(snap, pebbleKey(pebblePrevToNextPrefix, [32]byte(lastBlockHash)))
+		if errors.Is(err, sync.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return p2p.MsgBlock{}, err
+		}
+		lastBlockHash = next
+	}
+
+	raw, err := pebbleGet(snap, pebbleKey(pebbleBlockPrefix, [32]byte(lastBlockHash)))
+	if err != nil {
+		return p2p.MsgBlock{}, err
+	}
+	return decodeBlock(raw)
+}
+
+// Delete removes the block with the given hash. When it is the last block, its previous block becomes the last one.
+func (db *PebbleBlockRepo) Delete(hash [32]byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	raw, err := pebbleGet(db.db, pebbleKey(pebbleBlockPrefix, hash))
+	if err != nil {
+		return err
+	}
+	block, err := decodeBlock(raw)
+	if err != nil {
+		return err
+	}
+
+	b := db.db.NewBatch()
+	defer b.Close()
+	if err = b.Delete(pebbleKey(pebbleBlockPrefix, hash), nil); err != nil {
+		return err
+	}
+
+	prevKey := pebbleKey(pebblePrevToNextPrefix, block.PrevBlockHash)
+	next, err := pebbleGet(db.db, prevKey)
+	if err == nil && [32]byte(next) == hash {
+		if err = b.Delete(prevKey, nil); err != nil {
+			return err
+		}
+	}
+	if err = b.Delete(pebbleKey(pebblePrevToNextPrefix, hash), nil); err != nil {
+		return err
+	}
+
+	last, err := pebbleGet(db.db, pebbleLastBlockKey)
+	if err == nil && [32]byte(last) == hash {
+		if err = b.Set(pebbleLastBlockKey, block.PrevBlockHash[:], nil); err != nil {
+			return err
+		}
+	}
+	return b.Commit(pebble.Sync)
+}
+
+// Close closes the Pebble database.
+func (db *PebbleBlockRepo) Close() error {
+	return db.db.Close()
+}
+
+// pebbleGet returns a copy of the value of the key, because the returned value from Pebble is
+// valid only until the closer is closed.
+func pebbleGet(r pebble.Reader, key []byte) ([]byte, error) {
+	value, closer, err := r.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, sync.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	return append([]byte{}, value...), nil
+}
+
+func pebbleKey(prefix byte, hash [32]byte) []byte {
+	return append([]byte{prefix}, hash[:]...)
+}
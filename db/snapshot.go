@@ -0,0 +1,651 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"math/big"
+	"sort"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// snapshotVersion is the version of the UTXO snapshot format of Bitcoin Core (dumptxoutset).
+	snapshotVersion = 2
+
+	// snapshotBatchSize is the number of the coins that are loaded in one BoltDB transaction.
+	snapshotBatchSize = 100_000
+
+	// specialScripts is the number of the special script types in the compressed scripts.
+	specialScripts = 6
+)
+
+var (
+	snapshotMagic = []byte{'u', 't', 'x', 'o', 0xff}
+	snapshotKey   = []byte("SnapshotKey")
+
+	// ErrUnknownSnapshot is returned when the base block of the snapshot is not in the AssumeUTXO allowlist.
+	ErrUnknownSnapshot = errors.New("the snapshot base block is not in the assumeutxo allowlist")
+
+	// ErrSnapshotHashMismatch is returned when the hash of the loaded UTXO set differs from the allowlisted one.
+	ErrSnapshotHashMismatch = errors.New("the hash of the snapshot UTXO set doesn't match the assumeutxo hash")
+
+	// ErrInvalidSnapshot is returned when the snapshot file can't be decoded.
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+)
+
+// AssumeUTXOData describes a UTXO snapshot that the node trusts: the UTXO set after the block
+// with the given hash and height must have the given serialized hash (hash_serialized_3).
+type AssumeUTXOData struct {
+	Height         int32
+	BlockHash      [32]byte
+	HashSerialized [32]byte
+	ChainTxCount   uint64
+}
+
+// AssumeUTXO is the allowlist of the snapshots that can be loaded for every network. The hashes are
+// the same as in the chain params of Bitcoin Core.
+var AssumeUTXO = map[string][]AssumeUTXOData{
+	"mainnet": {
+		{
+			Height:         840_000,
+			BlockHash:      rpcHash("0000000000000000000320283a032748cef8227873ff4872689bf23f1cda83a5"),
+			HashSerialized: rpcHash("a2a5521b1b5ab65f67818e5e8eccabb7171a517f9e2382208f77687310768f96"),
+			ChainTxCount:   991_032_194,
+		},
+		{
+			Height:         880_000,
+			BlockHash:      rpcHash("000000000000000000010b17283c3c400507969a9c2afd1dcf2ebc0c3e7c84b9"),
+			HashSerialized: rpcHash("dbd190983eaf433ef7c15f78a278ae42c00ef52e0fd2a54953782175fbadcea9"),
+			ChainTxCount:   1_145_604_538,
+		},
+	},
+}
+
+// rpcHash decodes a hash in the byte order that is used by Bitcoin Core RPC.
+func rpcHash(s string) [32]byte {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 32 {
+		panic("invalid hash " + s)
+	}
+	return [32]byte(p2p.Reverse([32]byte(b)))
+}
+
+func (d AssumeUTXOData) encode() []byte {
+	b := binary.LittleEndian.AppendUint32(make([]byte, 0, 76), uint32(d.Height))
+	b = append(b, d.BlockHash[:]...)
+	b = append(b, d.HashSerialized[:]...)
+	return binary.LittleEndian.AppendUint64(b, d.ChainTxCount)
+}
+
+func decodeAssumeUTXOData(b []byte) (AssumeUTXOData, error) {
+	if len(b) != 76 {
+		return AssumeUTXOData{}, fmt.Errorf("invalid snapshot data length: %d", len(b))
+	}
+	return AssumeUTXOData{
+		Height:         int32(binary.LittleEndian.Uint32(b[:4])),
+		BlockHash:      [32]byte(b[4:36]),
+		HashSerialized: [32]byte(b[36:68]),
+		ChainTxCount:   binary.LittleEndian.Uint64(b[68:]),
+	}, nil
+}
+
+// SnapshotMetadata is the header of a UTXO snapshot file.
+type SnapshotMetadata struct {
+	Magic         p2p.Magic
+	BaseBlockHash [32]byte
+	CoinsCount    uint64
+}
+
+func (m SnapshotMetadata) write(w io.Writer) error {
+	b := append([]byte{}, snapshotMagic...)
+	b = binary.LittleEndian.AppendUint16(b, snapshotVersion)
+	b = append(b, m.Magic[:]...)
+	b = append(b, m.BaseBlockHash[:]...)
+	b = binary.LittleEndian.AppendUint64(b, m.CoinsCount)
+	_, err := w.Write(b)
+	return err
+}
+
+func readSnapshotMetadata(r io.Reader) (SnapshotMetadata, error) {
+	b := make([]byte, len(snapshotMagic)+2+4+32+8)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return SnapshotMetadata{}, fmt.Errorf("%w: failed to read the metadata: %s", ErrInvalidSnapshot, err)
+	}
+	if !bytes.Equal(b[:len(snapshotMagic)], snapshotMagic) {
+		return SnapshotMetadata{}, fmt.Errorf("%w: invalid magic bytes", ErrInvalidSnapshot)
+	}
+	b = b[len(snapshotMagic):]
+	if v := binary.LittleEndian.Uint16(b); v != snapshotVersion {
+		return SnapshotMetadata{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, v)
+	}
+	return SnapshotMetadata{
+		Magic:         p2p.Magic(b[2:6]),
+		BaseBlockHash: [32]byte(b[6:38]),
+		CoinsCount:    binary.LittleEndian.Uint64(b[38:]),
+	}, nil
+}
+
+// Dump writes the UTXO set at its best block to w in the snapshot format of Bitcoin Core. It returns the
+// metadata of the snapshot and the serialized hash of the set, that must be added to the allowlist of
+// the nodes that load the snapshot.
+func (us *UTXOSet) Dump(w io.Writer, network string) (SnapshotMetadata, [32]byte, error) {
+	magic, ok := p2p.Networks[network]
+	if !ok {
+		return SnapshotMetadata{}, [32]byte{}, fmt.Errorf("unsupported network %s", network)
+	}
+
+	var meta SnapshotMetadata
+	var hash [32]byte
+	// the set is read in one transaction, so it doesn't change while it is written
+	err := us.db.View(func(tx *bolt.Tx) error {
+		utxos := tx.Bucket(utxoBucket)
+		meta = SnapshotMetadata{Magic: magic, BaseBlockHash: bestHashOf(tx), CoinsCount: uint64(utxos.Stats().KeyN)}
+
+		bw := bufio.NewWriter(w)
+		if err := meta.write(bw); err != nil {
+			return err
+		}
+
+		hasher := newUTXOHasher()
+		err := forEachTxCoins(utxos, func(txid [32]byte, coins []snapshotCoin) error {
+			if _, err := bw.Write(txid[:]); err != nil {
+				return err
+			}
+			if err := writeCompactSize(bw, uint64(len(coins))); err != nil {
+				return err
+			}
+			for _, c := range coins {
+				if err := writeCompactSize(bw, uint64(c.vout)); err != nil {
+					return err
+				}
+				if err := writeCoin(bw, c.utxo); err != nil {
+					return err
+				}
+				hasher.add(p2p.OutPoint{Hash: txid, Index: c.vout}, c.utxo)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		hash = hasher.sum()
+		return bw.Flush()
+	})
+	return meta, hash, err
+}
+
+// HashSerialized returns the hash of the UTXO set in the same way as hash_serialized_3 of Bitcoin Core.
+func (us *UTXOSet) HashSerialized() ([32]byte, error) {
+	var hash [32]byte
+	err := us.db.View(func(tx *bolt.Tx) error {
+		hasher := newUTXOHasher()
+		err := forEachTxCoins(tx.Bucket(utxoBucket), func(txid [32]byte, coins []snapshotCoin) error {
+			for _, c := range coins {
+				hasher.add(p2p.OutPoint{Hash: txid, Index: c.vout}, c.utxo)
+			}
+			return nil
+		})
+		hash = hasher.sum()
+		return err
+	})
+	return hash, err
+}
+
+// LoadSnapshot loads a UTXO snapshot into the empty set. The base block of the snapshot must be in the
+// AssumeUTXO allowlist of the network and the hash of the loaded set must match the allowlisted one.
+// After the load the base block becomes the best block of the set.
+func (us *UTXOSet) LoadSnapshot(r io.Reader, network string) (AssumeUTXOData, error) {
+	magic, ok := p2p.Networks[network]
+	if !ok {
+		return AssumeUTXOData{}, fmt.Errorf("unsupported network %s", network)
+	}
+
+	br := bufio.NewReader(r)
+	meta, err := readSnapshotMetadata(br)
+	if err != nil {
+		return AssumeUTXOData{}, err
+	}
+	if meta.Magic != magic {
+		return AssumeUTXOData{}, fmt.Errorf("%w: the snapshot is for another network", ErrInvalidSnapshot)
+	}
+
+	data, ok := findAssumeUTXO(network, meta.BaseBlockHash)
+	if !ok {
+		return AssumeUTXOData{}, fmt.Errorf("%w: %x", ErrUnknownSnapshot, p2p.Reverse(meta.BaseBlockHash))
+	}
+
+	bestHash, bestHeight, err := us.BestBlock()
+	if err != nil {
+		return AssumeUTXOData{}, err
+	}
+	if bestHash != sync.GenesisBlockHash || bestHeight != 0 {
+		return AssumeUTXOData{}, errors.New("a snapshot can be loaded only into an empty UTXO set")
+	}
+
+	if err = us.loadCoins(br, meta.CoinsCount); err != nil {
+		return AssumeUTXOData{}, errors.Join(err, us.clear())
+	}
+
+	hash, err := us.HashSerialized()
+	if err != nil {
+		return AssumeUTXOData{}, errors.Join(err, us.clear())
+	}
+	if hash != data.HashSerialized {
+		err = fmt.Errorf("%w: %x, expected %x", ErrSnapshotHashMismatch, p2p.Reverse(hash), p2p.Reverse(data.HashSerialized))
+		return AssumeUTXOData{}, errors.Join(err, us.clear())
+	}
+
+	err = us.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(utxoStateBucket).Put(snapshotKey, data.encode()); err != nil {
+			return err
+		}
+		return putBestBlock(tx, data.BlockHash, data.Height)
+	})
+	return data, err
+}
+
+// SnapshotBase returns the allowlisted data of the snapshot that is loaded into the set. It returns
+// false when the set is not loaded from a snapshot.
+func (us *UTXOSet) SnapshotBase() (AssumeUTXOData, bool, error) {
+	var data AssumeUTXOData
+	var ok bool
+	err := us.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(utxoStateBucket).Get(snapshotKey)
+		if b == nil {
+			return nil
+		}
+
+		var err error
+		data, err = decodeAssumeUTXOData(b)
+		ok = err == nil
+		return err
+	})
+	return data, ok, err
+}
+
+func findAssumeUTXO(network string, blockHash [32]byte) (AssumeUTXOData, bool) {
+	for _, d := range AssumeUTXO[network] {
+		if d.BlockHash == blockHash {
+			return d, true
+		}
+	}
+	return AssumeUTXOData{}, false
+}
+
+// loadCoins reads the coins of the snapshot and puts them into the set in batches.
+func (us *UTXOSet) loadCoins(r *bufio.Reader, count uint64) error {
+	var loaded uint64
+	for loaded < count {
+		err := us.db.Update(func(tx *bolt.Tx) error {
+			utxos := tx.Bucket(utxoBucket)
+			for batch := 0; batch < snapshotBatchSize && loaded < count; {
+				var txid [32]byte
+				if _, err := io.ReadFull(r, txid[:]); err != nil {
+					return fmt.Errorf("%w: failed to read txid: %s", ErrInvalidSnapshot, err)
+				}
+				n, err := readCompactSize(r)
+				if err != nil {
+					return err
+				}
+				if n == 0 || n > count-loaded {
+					return fmt.Errorf("%w: invalid number of coins %d for tx %x", ErrInvalidSnapshot, n, p2p.Reverse(txid))
+				}
+
+				for i := uint64(0); i < n; i++ {
+					vout, err := readCompactSize(r)
+					if err != nil {
+						return err
+					}
+					u, err := readCoin(r)
+					if err != nil {
+						return err
+					}
+					if err = utxos.Put(outPointKey(p2p.OutPoint{Hash: txid, Index: uint32(vout)}), u.encode()); err != nil {
+						return err
+					}
+				}
+				loaded += n
+				batch += int(n)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("loaded %d of %d coins from the snapshot\n", loaded, count)
+	}
+
+	if _, err := r.ReadByte(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: there is data after the last coin", ErrInvalidSnapshot)
+	}
+	return nil
+}
+
+// clear deletes all coins from the set.
+func (us *UTXOSet) clear() error {
+	return us.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(utxoBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(utxoBucket)
+		return err
+	})
+}
+
+func bestHashOf(tx *bolt.Tx) [32]byte {
+	hash, _ := bestBlock(tx)
+	return hash
+}
+
+type snapshotCoin struct {
+	vout uint32
+	utxo UTXO
+}
+
+// forEachTxCoins calls fn for the coins of every transaction in the order of the coins database of
+// Bitcoin Core: by txid and then by the VARINT encoded output index. The keys in the bucket have little
+// endian output indexes, so the coins of every transaction are sorted.
+func forEachTxCoins(utxos *bolt.Bucket, fn func(txid [32]byte, coins []snapshotCoin) error) error {
+	var txid [32]byte
+	var coins []snapshotCoin
+	flush := func() error {
+		if len(coins) == 0 {
+			return nil
+		}
+		sort.Slice(coins, func(i, j int) bool {
+			return bytes.Compare(appendVarInt(nil, uint64(coins[i].vout)), appendVarInt(nil, uint64(coins[j].vout))) < 0
+		})
+		err := fn(txid, coins)
+		coins = coins[:0]
+		return err
+	}
+
+	c := utxos.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if len(k) != outPointLength {
+			return fmt.Errorf("invalid utxo key length: %d", len(k))
+		}
+		if [32]byte(k[:32]) != txid {
+			if err := flush(); err != nil {
+				return err
+			}
+			txid = [32]byte(k[:32])
+		}
+
+		u, err := decodeUTXO(v)
+		if err != nil {
+			return err
+		}
+		coins = append(coins, snapshotCoin{vout: binary.LittleEndian.Uint32(k[32:]), utxo: u})
+	}
+	return flush()
+}
+
+// utxoHasher calculates hash_serialized_3: the double SHA256 of the serialized outpoints and coins.
+type utxoHasher struct {
+	buf []byte
+	h   hash.Hash
+}
+
+func newUTXOHasher() *utxoHasher {
+	return &utxoHasher{h: sha256.New()}
+}
+
+func (uh *utxoHasher) add(op p2p.OutPoint, u UTXO) {
+	b := append(uh.buf[:0], op.Hash[:]...)
+	b = binary.LittleEndian.AppendUint32(b, op.Index)
+	b = binary.LittleEndian.AppendUint32(b, uint32(u.Height)<<1|boolToUint32(u.Coinbase))
+	b = binary.LittleEndian.AppendUint64(b, uint64(u.Value))
+	b = appendCompactSize(b, uint64(len(u.PkScript)))
+	b = append(b, u.PkScript...)
+	uh.h.Write(b)
+	uh.buf = b
+}
+
+func (uh *utxoHasher) sum() [32]byte {
+	return sha256.Sum256(uh.h.Sum(nil))
+}
+
+func boolToUint32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// writeCoin writes the coin in the compressed format of Bitcoin Core: the height and the coinbase flag,
+// the compressed amount and the compressed script.
+func writeCoin(w io.Writer, u UTXO) error {
+	b := appendVarInt(nil, uint64(u.Height)<<1|uint64(boolToUint32(u.Coinbase)))
+	b = appendVarInt(b, compressAmount(uint64(u.Value)))
+	b = append(b, compressScript(u.PkScript)...)
+	_, err := w.Write(b)
+	return err
+}
+
+func readCoin(r *bufio.Reader) (UTXO, error) {
+	code, err := readVarInt(r)
+	if err != nil {
+		return UTXO{}, err
+	}
+	amount, err := readVarInt(r)
+	if err != nil {
+		return UTXO{}, err
+	}
+	script, err := readCompressedScript(r)
+	if err != nil {
+		return UTXO{}, err
+	}
+	return UTXO{Value: int64(decompressAmount(amount)), PkScript: script, Height: int32(code >> 1), Coinbase: code&1 == 1}, nil
+}
+
+// appendVarInt appends the number in the VARINT format of Bitcoin Core: base 128 with the most
+// significant digit first and an offset of 1 for every continuation byte.
+func appendVarInt(b []byte, n uint64) []byte {
+	var tmp [10]byte
+	l := 0
+	for {
+		tmp[l] = byte(n & 0x7f)
+		if l > 0 {
+			tmp[l] |= 0x80
+		}
+		if n <= 0x7f {
+			break
+		}
+		n = (n >> 7) - 1
+		l++
+	}
+	for ; l >= 0; l-- {
+		b = append(b, tmp[l])
+	}
+	return b
+}
+
+func readVarInt(r *bufio.Reader) (uint64, error) {
+	var n uint64
+	for i := 0; i < 10; i++ {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("%w: failed to read varint: %s", ErrInvalidSnapshot, err)
+		}
+		n = n<<7 | uint64(c&0x7f)
+		if c&0x80 == 0 {
+			return n, nil
+		}
+		n++
+	}
+	return 0, fmt.Errorf("%w: varint is too long", ErrInvalidSnapshot)
+}
+
+func appendCompactSize(b []byte, n uint64) []byte {
+	raw, _ := p2p.VarInt(n).MarshalBinary()
+	return append(b, raw...)
+}
+
+func writeCompactSize(w io.Writer, n uint64) error {
+	_, err := w.Write(appendCompactSize(nil, n))
+	return err
+}
+
+func readCompactSize(r io.Reader) (uint64, error) {
+	var v p2p.VarInt
+	if err := v.UnmarshalBinary(r); err != nil {
+		return 0, fmt.Errorf("%w: failed to read compact size: %s", ErrInvalidSnapshot, err)
+	}
+	return uint64(v), nil
+}
+
+// compressAmount compresses the amount in satoshis, the amounts that are multiples of 10 are much shorter.
+func compressAmount(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	e := uint64(0)
+	for n%10 == 0 && e < 9 {
+		n /= 10
+		e++
+	}
+	if e < 9 {
+		d := n % 10
+		n /= 10
+		return 1 + (n*9+d-1)*10 + e
+	}
+	return 1 + (n-1)*10 + 9
+}
+
+func decompressAmount(x uint64) uint64 {
+	if x == 0 {
+		return 0
+	}
+	x--
+	e := x % 10
+	x /= 10
+	var n uint64
+	if e < 9 {
+		d := x%9 + 1
+		x /= 9
+		n = x*10 + d
+	} else {
+		n = x + 1
+	}
+	for ; e > 0; e-- {
+		n *= 10
+	}
+	return n
+}
+
+// compressScript compresses the standard P2PKH, P2SH and P2PK scripts to 21 or 33 bytes. The other
+// scripts are prefixed by their size.
+func compressScript(s []byte) []byte {
+	switch {
+	case len(s) == 25 && s[0] == 0x76 && s[1] == 0xa9 && s[2] == 20 && s[23] == 0x88 && s[24] == 0xac:
+		return append([]byte{0x00}, s[3:23]...)
+	case len(s) == 23 && s[0] == 0xa9 && s[1] == 20 && s[22] == 0x87:
+		return append([]byte{0x01}, s[2:22]...)
+	case len(s) == 35 && s[0] == 33 && s[34] == 0xac && (s[1] == 0x02 || s[1] == 0x03):
+		return append([]byte{s[1]}, s[2:34]...)
+	case len(s) == 67 && s[0] == 65 && s[66] == 0xac && s[1] == 0x04 && isOnCurve(s[2:34], s[34:66]):
+		return append([]byte{0x04 | s[65]&1}, s[2:34]...)
+	}
+	return append(appendVarInt(nil, uint64(len(s)+specialScripts)), s...)
+}
+
+func readCompressedScript(r *bufio.Reader) ([]byte, error) {
+	size, err := readVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if size < specialScripts {
+		l := 20
+		if size > 1 {
+			l = 32
+		}
+		payload := make([]byte, l)
+		if _, err = io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("%w: failed to read script: %s", ErrInvalidSnapshot, err)
+		}
+		return decompressScript(byte(size), payload)
+	}
+
+	size -= specialScripts
+	if size > 10_000 {
+		return nil, fmt.Errorf("%w: script is too long: %d", ErrInvalidSnapshot, size)
+	}
+	script := make([]byte, size)
+	if _, err = io.ReadFull(r, script); err != nil {
+		return nil, fmt.Errorf("%w: failed to read script: %s", ErrInvalidSnapshot, err)
+	}
+	return script, nil
+}
+
+func decompressScript(kind byte, payload []byte) ([]byte, error) {
+	switch kind {
+	case 0x00:
+		return append(append([]byte{0x76, 0xa9, 20}, payload...), 0x88, 0xac), nil
+	case 0x01:
+		return append(append([]byte{0xa9, 20}, payload...), 0x87), nil
+	case 0x02, 0x03:
+		return append(append([]byte{33, kind}, payload...), 0xac), nil
+	}
+
+	// uncompressed P2PK, the type is 4 for even and 5 for odd y
+	y, ok := decompressY(payload, kind&1)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid public key", ErrInvalidSnapshot)
+	}
+	s := append([]byte{65, 0x04}, payload...)
+	s = append(s, y...)
+	return append(s, 0xac), nil
+}
+
+var (
+	secp256k1P, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	secp256k1B    = big.NewInt(7)
+)
+
+// curveY2 returns x^3 + 7 mod p.
+func curveY2(x *big.Int) *big.Int {
+	y2 := new(big.Int).Exp(x, big.NewInt(3), secp256k1P)
+	y2.Add(y2, secp256k1B)
+	return y2.Mod(y2, secp256k1P)
+}
+
+func isOnCurve(xb, yb []byte) bool {
+	x, y := new(big.Int).SetBytes(xb), new(big.Int).SetBytes(yb)
+	if x.Cmp(secp256k1P) >= 0 || y.Cmp(secp256k1P) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(y, big.NewInt(2), secp256k1P).Cmp(curveY2(x)) == 0
+}
+
+// decompressY returns the y coordinate of the secp256k1 point with the given x and parity of y.
+func decompressY(xb []byte, odd byte) ([]byte, bool) {
+	x := new(big.Int).SetBytes(xb)
+	if x.Cmp(secp256k1P) >= 0 {
+		return nil, false
+	}
+
+	// p = 3 mod 4, so the square root is (x^3 + 7)^((p+1)/4)
+	y2 := curveY2(x)
+	exp := new(big.Int).Rsh(new(big.Int).Add(secp256k1P, big.NewInt(1)), 2)
+	y := new(big.Int).Exp(y2, exp, secp256k1P)
+	if new(big.Int).Exp(y, big.NewInt(2), secp256k1P).Cmp(y2) != 0 {
+		return nil, false
+	}
+	if byte(y.Bit(0)) != odd {
+		y.Sub(secp256k1P, y)
+	}
+	return y.FillBytes(make([]byte, 32)), true
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressAmount(t *testing.T) {
+	const coin = 100_000_000
+	tests := []struct {
+		amount     uint64
+		compressed uint64
+	}{
+		{0, 0},
+		{1, 1},
+		{1_000_000, 7},
+		{coin, 9},
+		{50 * coin, 50},
+		{21_000_000 * coin, 21_000_000},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.compressed, compressAmount(tt.amount))
+		require.Equal(t, tt.amount, decompressAmount(tt.compressed))
+	}
+
+	for amount := uint64(0); amount < 100_000; amount++ {
+		require.Equal(t, amount, decompressAmount(compressAmount(amount)))
+	}
+}
+
+func TestVarInt(t *testing.T) {
+	tests := []struct {
+		n       uint64
+		encoded string
+	}{
+		{0, "00"},
+		{0x7f, "7f"},
+		{0x80, "8000"},
+		{0x1234, "a334"},
+		{0xffff, "82fe7f"},
+	}
+	for _, tt := range tests {
+		b := appendVarInt(nil, tt.n)
+		require.Equal(t, tt.encoded, hex.EncodeToString(b))
+
+		n, err := readVarInt(bufio.NewReader(bytes.NewReader(b)))
+		require.NoError(t, err)
+		require.Equal(t, tt.n, n)
+	}
+}
+
+func TestCompressScript(t *testing.T) {
+	// the uncompressed public key of the generator point of secp256k1
+	pubKey, _ := hex.DecodeString("0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	scripts := []struct {
+		script []byte
+		length int
+	}{
+		{append(append([]byte{0x76, 0xa9, 20}, bytes.Repeat([]byte{1}, 20)...), 0x88, 0xac), 21},
+		{append(append([]byte{0xa9, 20}, bytes.Repeat([]byte{2}, 20)...), 0x87), 21},
+		{append(append([]byte{33}, pubKey[:33]...), 0xac), 33},
+		{append(append([]byte{65}, pubKey...), 0xac), 33},
+		{[]byte{0x00, 0x14, 1, 2, 3}, 6},
+	}
+	scripts[2].script[1] = 0x02
+
+	for _, tt := range scripts {
+		compressed := compressScript(tt.script)
+		require.Len(t, compressed, tt.length)
+
+		actual, err := readCompressedScript(bufio.NewReader(bytes.NewReader(compressed)))
+		require.NoError(t, err)
+		require.Equal(t, tt.script, actual)
+	}
+}
+
+func TestUTXOSet_DumpAndLoadSnapshot(t *testing.T) {
+	source := newUTXOSet(t)
+
+	outputs := make([]int64, 300)
+	for i := range outputs {
+		outputs[i] = int64(i + 1)
+	}
+	coinbaseTx := testutil.NewMsgTx(coinbase, outputs...)
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, coinbaseTx)
+	require.NoError(t, source.ConnectBlock(block1))
+	mature := maturityBlocks(block1.GetHash())
+	for _, b := range mature {
+		require.NoError(t, source.ConnectBlock(b))
+	}
+	spend := testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbaseTx.TxHash(), Index: 7}}, 5, 1)
+	block2 := testutil.NewMsgBlockWithTxs(mature[len(mature)-1].GetHash(), testutil.NewMsgTx(coinbase, 50), spend)
+	require.NoError(t, source.ConnectBlock(block2))
+
+	var snapshot bytes.Buffer
+	meta, hash, err := source.Dump(&snapshot, "simnet")
+	require.NoError(t, err)
+	require.Equal(t, block2.GetHash(), meta.BaseBlockHash)
+	require.Equal(t, uint64(299+1+2), meta.CoinsCount)
+
+	sourceHash, err := source.HashSerialized()
+	require.NoError(t, err)
+	require.Equal(t, sourceHash, hash)
+
+	// the snapshot must be in the allowlist
+	_, err = newUTXOSet(t).LoadSnapshot(bytes.NewReader(snapshot.Bytes()), "simnet")
+	require.ErrorIs(t, err, ErrUnknownSnapshot)
+
+	data := AssumeUTXOData{Height: coinbaseMaturity + 1, BlockHash: block2.GetHash(), HashSerialized: hash}
+	AssumeUTXO["simnet"] = []AssumeUTXOData{data}
+	defer delete(AssumeUTXO, "simnet")
+
+	target := newUTXOSet(t)
+	loaded, err := target.LoadSnapshot(bytes.NewReader(snapshot.Bytes()), "simnet")
+	require.NoError(t, err)
+	require.Equal(t, data, loaded)
+
+	bestHash, bestHeight, err := target.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, block2.GetHash(), bestHash)
+	require.Equal(t, int32(coinbaseMaturity+1), bestHeight)
+	base, ok, err := target.SnapshotBase()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, data, base)
+
+	for _, op := range []p2p.OutPoint{{Hash: coinbaseTx.TxHash(), Index: 299}, {Hash: spend.TxHash(), Index: 1}} {
+		expected, err := source.Get(op)
+		require.NoError(t, err)
+		actual, err := target.Get(op)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+	_, err = target.Get(p2p.OutPoint{Hash: coinbaseTx.TxHash(), Index: 7})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+
+	// new blocks are connected on top of the snapshot
+	block3 := testutil.NewMsgBlockWithTxs(block2.GetHash(), testutil.NewMsgTx(coinbase, 50),
+		testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbaseTx.TxHash(), Index: 8}}, 5))
+	require.NoError(t, target.ConnectBlock(block3))
+
+	// the loaded set must match the allowlisted hash
+	AssumeUTXO["simnet"] = []AssumeUTXOData{{Height: coinbaseMaturity + 1, BlockHash: block2.GetHash(), HashSerialized: [32]byte{1}}}
+	other := newUTXOSet(t)
+	_, err = other.LoadSnapshot(bytes.NewReader(snapshot.Bytes()), "simnet")
+	require.ErrorIs(t, err, ErrSnapshotHashMismatch)
+	_, err = other.Get(p2p.OutPoint{Hash: coinbaseTx.TxHash(), Index: 299})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+
+	_, err = other.LoadSnapshot(bytes.NewReader(snapshot.Bytes()), "mainnet")
+	require.ErrorIs(t, err, ErrInvalidSnapshot)
+}
+
+func newUTXOSet(t *testing.T) *UTXOSet {
+	db, err := NewBoltDB(t.TempDir() + "/utxo.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	set, err := NewUTXOSet(db.DB)
+	require.NoError(t, err)
+	return set
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	txIndexBucket      = []byte("TxIndexBucket")
+	wtxIndexBucket     = []byte("WTxIndexBucket")
+	txIndexStateBucket = []byte("TxIndexStateBucket")
+)
+
+const txLocationLength = 40
+
+// TxLocation is the place of a transaction in the chain.
+type TxLocation struct {
+	BlockHash [32]byte
+	Height    int32
+	Position  uint32
+}
+
+func (l TxLocation) encode() []byte {
+	b := make([]byte, 0, txLocationLength)
+	b = append(b, l.BlockHash[:]...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(l.Height))
+	return binary.LittleEndian.AppendUint32(b, l.Position)
+}
+
+func decodeTxLocation(b []byte) (TxLocation, error) {
+	if len(b) != txLocationLength {
+		return TxLocation{}, fmt.Errorf("invalid tx location length: %d", len(b))
+	}
+	return TxLocation{
+		BlockHash: [32]byte(b[:32]),
+		Height:    int32(binary.LittleEndian.Uint32(b[32:36])),
+		Position:  binary.LittleEndian.Uint32(b[36:40]),
+	}, nil
+}
+
+// TxIndex maps every txid and wtxid to the block that contains the transaction and the position
+// of the transaction in the block. The blocks are indexed one by one in the order of the chain.
+// When the index is started it indexes in the background the blocks that are saved before it was
+// enabled, after that it is kept up to date by calling ConnectBlock for every new block.
+type TxIndex struct {
+	*indexSyncer
+	db              *bolt.DB
+	blockRepository sync.BlockRepository
+}
+
+// NewTxIndex creates a TxIndex that is stored in the given BoltDB. The transactions are read
+// from the blocks in the block repository.
+func NewTxIndex(db *bolt.DB, br sync.BlockRepository) (*TxIndex, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{txIndexBucket, wtxIndexBucket, txIndexStateBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	ti := &TxIndex{db: db, blockRepository: br}
+	ti.indexSyncer = newIndexSyncer("tx index", ti, br)
+	return ti, err
+}
+
+// BestBlock returns the hash and the height of the last indexed block.
+func (ti *TxIndex) BestBlock() ([32]byte, int32, error) {
+	var hash [32]byte
+	var height int32
+	err := ti.db.View(func(tx *bolt.Tx) error {
+		hash, height = indexBestBlock(tx.Bucket(txIndexStateBucket))
+		return nil
+	})
+	return hash, height, err
+}
+
+// DisconnectBlock removes the transactions of the block from the index. Only the last indexed
+// block can be disconnected.
+func (ti *TxIndex) DisconnectBlock(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	return ti.db.Update(func(tx *bolt.Tx) error {
+		state := tx.Bucket(txIndexStateBucket)
+		bestHash, bestHeight := indexBestBlock(state)
+		if hash != bestHash {
+			return fmt.Errorf("block %x is not the best block of the tx index %x", p2p.Reverse(hash), p2p.Reverse(bestHash))
+		}
+
+		txs, wtxs := tx.Bucket(txIndexBucket), tx.Bucket(wtxIndexBucket)
+		for _, t := range block.Transactions {
+			txid, wtxid := t.TxHash(), t.WTxHash()
+			if err := txs.Delete(txid[:]); err != nil {
+				return err
+			}
+			if err := wtxs.Delete(wtxid[:]); err != nil {
+				return err
+			}
+		}
+		return putIndexBestBlock(state, block.PrevBlockHash, bestHeight-1)
+	})
+}
+
+// GetTxLocation returns the location of the transaction with the given txid or wtxid.
+// sync.ErrNotFound is returned when the transaction is not indexed.
+func (ti *TxIndex) GetTxLocation(hash [32]byte) (TxLocation, error) {
+	var loc TxLocation
+	err := ti.db.View(func(tx *bolt.Tx) error {
+		txs := tx.Bucket(txIndexBucket)
+		data := txs.Get(hash[:])
+		if data == nil {
+			// it can be a wtxid of a segwit transaction
+			if txid := tx.Bucket(wtxIndexBucket).Get(hash[:]); txid != nil {
+				data = txs.Get(txid)
+			}
+		}
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		loc, err = decodeTxLocation(data)
+		return err
+	})
+	return loc, err
+}
+
+// GetTx returns the transaction with the given txid or wtxid and the height of the block
+// that confirmed it.
+func (ti *TxIndex) GetTx(hash [32]byte) (p2p.MsgTx, int32, error) {
+	loc, err := ti.GetTxLocation(hash)
+	if err != nil {
+		return p2p.MsgTx{}, 0, err
+	}
+
+	block, err := ti.blockRepository.Get(loc.BlockHash)
+	if err != nil {
+		return p2p.MsgTx{}, 0, fmt.Errorf("failed to get block %x of tx %x: %w", p2p.Reverse(loc.BlockHash), p2p.Reverse(hash), err)
+	}
+
+	if int(loc.Position) >= len(block.Transactions) {
+		return p2p.MsgTx{}, 0, fmt.Errorf("tx %x position %d is out of block %x", p2p.Reverse(hash), loc.Position, p2p.Reverse(loc.BlockHash))
+	}
+	return block.Transactions[loc.Position], loc.Height, nil
+}
+
+// ConnectBlock indexes the transactions of the block. The block is skipped when it is already
+// indexed or when the index is still behind the chain, the background indexing will index it later.
+func (ti *TxIndex) ConnectBlock(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	return ti.db.Update(func(tx *bolt.Tx) error {
+		state := tx.Bucket(txIndexStateBucket)
+		bestHash, bestHeight := indexBestBlock(state)
+		if block.PrevBlockHash != bestHash {
+			return nil
+		}
+
+		height := bestHeight + 1
+		txs, wtxs := tx.Bucket(txIndexBucket), tx.Bucket(wtxIndexBucket)
+		for i, t := range block.Transactions {
+			txid, wtxid := t.TxHash(), t.WTxHash()
+			loc := TxLocation{BlockHash: hash, Height: height, Position: uint32(i)}
+			if err := txs.Put(txid[:], loc.encode()); err != nil {
+				return err
+			}
+
+			// the wtxid of the transactions without witness is the same as the txid
+			if wtxid == txid {
+				continue
+			}
+			if err := wtxs.Put(wtxid[:], txid[:]); err != nil {
+				return err
+			}
+		}
+		return putIndexBestBlock(state, hash, height)
+	})
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxIndex_BackfillAndConnectBlocks(t *testing.T) {
+	repo, db := newFlatFileRepo(t, t.TempDir())
+	defer db.Close()
+	defer repo.Close()
+
+	// blocks that are saved before the index is enabled
+	prev := sync.GenesisBlockHash
+	var blocks []p2p.MsgBlock
+	for i := 0; i < 3; i++ {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, int64(i)))
+		require.NoError(t, repo.Save(block))
+		blocks = append(blocks, block)
+		prev = block.GetHash()
+	}
+
+	index, err := NewTxIndex(db.DB, repo)
+	require.NoError(t, err)
+	index.Start()
+	defer index.Stop()
+
+	require.Eventually(t, func() bool {
+		hash, height, err := index.BestBlock()
+		return err == nil && hash == blocks[2].GetHash() && height == 3
+	}, time.Second, 10*time.Millisecond)
+
+	tx, height, err := index.GetTx(blocks[1].Transactions[0].TxHash())
+	require.NoError(t, err)
+	require.Equal(t, blocks[1].Transactions[0], tx)
+	require.Equal(t, int32(2), height)
+
+	// a new block with a segwit transaction is indexed incrementally
+	segwit := testutil.NewMsgTx([]p2p.OutPoint{{Hash: blocks[0].Transactions[0].TxHash()}}, 1)
+	segwit.Flag = 1
+	segwit.TxWitness = []p2p.TxWitnessData{{Count: 1, Witness: []p2p.TxWitness{{Length: 2, Data: []byte{1, 2}}}}}
+	block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, 3), segwit)
+	require.NoError(t, repo.Save(block))
+	require.NoError(t, index.ConnectBlock(block))
+	// connecting the same block twice doesn't change the index
+	require.NoError(t, index.ConnectBlock(block))
+
+	for _, hash := range [][32]byte{segwit.TxHash(), segwit.WTxHash()} {
+		loc, err := index.GetTxLocation(hash)
+		require.NoError(t, err)
+		require.Equal(t, TxLocation{BlockHash: block.GetHash(), Height: 4, Position: 1}, loc)
+
+		tx, height, err = index.GetTx(hash)
+		require.NoError(t, err)
+		require.Equal(t, segwit, tx)
+		require.Equal(t, int32(4), height)
+	}
+
+	require.NoError(t, index.DisconnectBlock(block))
+	_, _, err = index.GetTx(segwit.WTxHash())
+	require.ErrorIs(t, err, sync.ErrNotFound)
+	_, height, err = index.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, int32(3), height)
+}
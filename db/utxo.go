@@ -0,0 +1,390 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	utxoBucket      = []byte("UTXOBucket")
+	utxoUndoBucket  = []byte("UTXOUndoBucket")
+	utxoStateBucket = []byte("UTXOStateBucket")
+	bestBlockKey    = []byte("BestBlockKey")
+
+	// ErrMissingInput is returned when a block spends an output that is not in the UTXO set.
+	ErrMissingInput = errors.New("missing transaction input")
+
+	// ErrNotConnectingBlock is returned when a block doesn't extend the best block of the UTXO set.
+	ErrNotConnectingBlock = errors.New("block doesn't connect to the best block of the UTXO set")
+
+	// ErrImmatureSpend is returned when a block spends a coinbase output that has less than 100 confirmations.
+	ErrImmatureSpend = errors.New("spends an immature coinbase output")
+
+	// ErrOutputsAboveInputs is returned when a transaction of a block creates more value than it spends.
+	ErrOutputsAboveInputs = errors.New("outputs are above the inputs")
+
+	// errRollback is returned from a BoltDB transaction to roll back its changes.
+	errRollback = errors.New("rollback")
+)
+
+const (
+	outPointLength = 36
+	// coinbaseMaturity is the number of blocks after which the outputs of a coinbase can be spent.
+	coinbaseMaturity = 100
+)
+
+// UTXO is an unspent transaction output.
+type UTXO struct {
+	Value    int64
+	PkScript []byte
+	Height   int32
+	Coinbase bool
+}
+
+func (u UTXO) encode() []byte {
+	b := make([]byte, 0, 13+len(u.PkScript))
+	b = binary.LittleEndian.AppendUint32(b, uint32(u.Height))
+	if u.Coinbase {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = binary.LittleEndian.AppendUint64(b, uint64(u.Value))
+	return append(b, u.PkScript...)
+}
+
+func decodeUTXO(b []byte) (UTXO, error) {
+	if len(b) < 13 {
+		return UTXO{}, fmt.Errorf("invalid utxo length: %d", len(b))
+	}
+	return UTXO{
+		Height:   int32(binary.LittleEndian.Uint32(b[0:4])),
+		Coinbase: b[4] == 1,
+		Value:    int64(binary.LittleEndian.Uint64(b[5:13])),
+		PkScript: append([]byte{}, b[13:]...),
+	}, nil
+}
+
+// SpentOutput is an output that was spent by a block. They are kept as undo data, so the block
+// can be disconnected from the UTXO set.
+type SpentOutput struct {
+	OutPoint p2p.OutPoint
+	UTXO     UTXO
+}
+
+// UTXOSet keeps the unspent transaction outputs of the chain in BoltDB. Blocks are connected to
+// the set one by one starting from the genesis block.
+type UTXOSet struct {
+	db *bolt.DB
+}
+
+// NewUTXOSet creates a UTXOSet that is stored in the given BoltDB.
+func NewUTXOSet(db *bolt.DB) (*UTXOSet, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{utxoBucket, utxoUndoBucket, utxoStateBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return &UTXOSet{db: db}, err
+}
+
+// BestBlock returns the hash and the height of the last block that is connected to the set.
+// When no blocks are connected it returns the genesis block hash with height 0.
+func (us *UTXOSet) BestBlock() ([32]byte, int32, error) {
+	var hash [32]byte
+	var height int32
+	err := us.db.View(func(tx *bolt.Tx) error {
+		hash, height = bestBlock(tx)
+		return nil
+	})
+	return hash, height, err
+}
+
+// Get returns the unspent output for the given outpoint. sync.ErrNotFound is returned when
+// the output doesn't exist or is spent.
+func (us *UTXOSet) Get(op p2p.OutPoint) (UTXO, error) {
+	var u UTXO
+	err := us.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(utxoBucket).Get(outPointKey(op))
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		u, err = decodeUTXO(data)
+		return err
+	})
+	return u, err
+}
+
+// ConnectBlock spends the outputs that are used as inputs in the block's transactions and adds
+// the outputs that the transactions create. The block must extend the best block of the set, its
+// transactions must not spend more than their inputs or an immature coinbase output. The spent
+// outputs are kept as undo data.
+func (us *UTXOSet) ConnectBlock(block p2p.MsgBlock) error {
+	var spent []SpentOutput
+	hash := block.GetHash()
+	return us.db.Update(func(tx *bolt.Tx) error {
+		bestHash, bestHeight := bestBlock(tx)
+		if block.PrevBlockHash != bestHash {
+			return fmt.Errorf("%w: block %x, best block %x", ErrNotConnectingBlock, p2p.Reverse(hash), p2p.Reverse(bestHash))
+		}
+		height := bestHeight + 1
+
+		utxos := tx.Bucket(utxoBucket)
+		for _, t := range block.Transactions {
+			txid := t.TxHash()
+			coinbase := t.IsCoinBase()
+			if !coinbase {
+				var inputs int64
+				for _, in := range t.TxIn {
+					key := outPointKey(in.PreviousOutput)
+					data := utxos.Get(key)
+					if data == nil {
+						op := in.PreviousOutput
+						return fmt.Errorf("%w: %x:%d in block %x", ErrMissingInput, p2p.Reverse(op.Hash), op.Index, p2p.Reverse(hash))
+					}
+
+					u, err := decodeUTXO(data)
+					if err != nil {
+						return err
+					}
+					if u.Coinbase && height-u.Height < coinbaseMaturity {
+						op := in.PreviousOutput
+						return fmt.Errorf("%w: %x:%d at height %d in block %x at height %d", ErrImmatureSpend,
+							p2p.Reverse(op.Hash), op.Index, u.Height, p2p.Reverse(hash), height)
+					}
+					inputs += u.Value
+					spent = append(spent, SpentOutput{OutPoint: in.PreviousOutput, UTXO: u})
+					if err = utxos.Delete(key); err != nil {
+						return err
+					}
+				}
+
+				var outputs int64
+				for _, out := range t.TxOut {
+					outputs += out.Value
+				}
+				if outputs > inputs {
+					return fmt.Errorf("%w: transaction %x spends %d and creates %d", ErrOutputsAboveInputs, p2p.Reverse(txid), inputs, outputs)
+				}
+			}
+
+			for i, out := range t.TxOut {
+				if isUnspendable(out.PkScript) {
+					continue
+				}
+				u := UTXO{Value: out.Value, PkScript: out.PkScript, Height: height, Coinbase: coinbase}
+				if err := utxos.Put(outPointKey(p2p.OutPoint{Hash: txid, Index: uint32(i)}), u.encode()); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Bucket(utxoUndoBucket).Put(hash[:], encodeSpentOutputs(spent)); err != nil {
+			return err
+		}
+		return putBestBlock(tx, hash, height)
+	})
+}
+
+// DisconnectBlock reverts the changes that ConnectBlock made for the block. Only the best block
+// of the set can be disconnected.
+func (us *UTXOSet) DisconnectBlock(block p2p.MsgBlock) error {
+	return us.db.Update(func(tx *bolt.Tx) error {
+		return disconnectBlock(tx, block, false)
+	})
+}
+
+// VerifyBlocks checks that the blocks, starting from the best block and going back in the chain,
+// are consistent with the set: every block has undo data and the outputs that it created are
+// unspent with the same values and scripts, unless they are spent by a block that is checked
+// before it. The blocks are disconnected in a transaction that is rolled back, so the set is not
+// changed. It returns the number of the consistent blocks; when it is less than the number of
+// the blocks, the error describes why the next block is not consistent.
+func (us *UTXOSet) VerifyBlocks(blocks []p2p.MsgBlock) (int, error) {
+	var verified int
+	var inconsistency error
+	err := us.db.Update(func(tx *bolt.Tx) error {
+		for _, block := range blocks {
+			if err := disconnectBlock(tx, block, true); err != nil {
+				inconsistency = err
+				break
+			}
+			verified++
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		return 0, err
+	}
+	return verified, inconsistency
+}
+
+// disconnectBlock reverts the changes of the block. When check is true it returns an error if an
+// output that is created by the block is not in the set or differs from the output of the block.
+func disconnectBlock(tx *bolt.Tx, block p2p.MsgBlock, check bool) error {
+	hash := block.GetHash()
+	bestHash, bestHeight := bestBlock(tx)
+	if hash != bestHash {
+		return fmt.Errorf("block %x is not the best block of the UTXO set %x", p2p.Reverse(hash), p2p.Reverse(bestHash))
+	}
+
+	undo := tx.Bucket(utxoUndoBucket)
+	data := undo.Get(hash[:])
+	if data == nil {
+		return fmt.Errorf("missing undo data for block %x", p2p.Reverse(hash))
+	}
+
+	spent, err := decodeSpentOutputs(data)
+	if err != nil {
+		return err
+	}
+
+	// outputs that are created and spent in the same block are not in the set
+	spentInBlock := make(map[p2p.OutPoint]struct{})
+	var inputs int
+	for _, t := range block.Transactions {
+		if !t.IsCoinBase() {
+			inputs += len(t.TxIn)
+		}
+	}
+	if check && inputs != len(spent) {
+		return fmt.Errorf("block %x spends %d outputs, but its undo data has %d", p2p.Reverse(hash), inputs, len(spent))
+	}
+
+	utxos := tx.Bucket(utxoBucket)
+	created := make(map[[32]byte]struct{}, len(block.Transactions))
+	for _, t := range block.Transactions {
+		created[t.TxHash()] = struct{}{}
+	}
+	for _, so := range spent {
+		if _, ok := created[so.OutPoint.Hash]; ok {
+			spentInBlock[so.OutPoint] = struct{}{}
+		}
+	}
+
+	for _, t := range block.Transactions {
+		txid := t.TxHash()
+		for i, out := range t.TxOut {
+			op := p2p.OutPoint{Hash: txid, Index: uint32(i)}
+			key := outPointKey(op)
+			if check && !isUnspendable(out.PkScript) {
+				if _, ok := spentInBlock[op]; !ok {
+					if err = checkUTXO(utxos.Get(key), out, bestHeight, t.IsCoinBase()); err != nil {
+						return fmt.Errorf("output %x:%d of block %x: %w", p2p.Reverse(txid), i, p2p.Reverse(hash), err)
+					}
+				}
+			}
+			if err = utxos.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, so := range spent {
+		if _, ok := spentInBlock[so.OutPoint]; ok {
+			continue
+		}
+		if err = utxos.Put(outPointKey(so.OutPoint), so.UTXO.encode()); err != nil {
+			return err
+		}
+	}
+
+	if err = undo.Delete(hash[:]); err != nil {
+		return err
+	}
+	return putBestBlock(tx, block.PrevBlockHash, bestHeight-1)
+}
+
+// checkUTXO checks that the stored output is the same as the output that is created by a block at the given height.
+func checkUTXO(data []byte, out p2p.TxOutput, height int32, coinbase bool) error {
+	if data == nil {
+		return errors.New("it is not in the UTXO set")
+	}
+
+	u, err := decodeUTXO(data)
+	if err != nil {
+		return err
+	}
+	if u.Value != out.Value || !bytes.Equal(u.PkScript, out.PkScript) || u.Height != height || u.Coinbase != coinbase {
+		return errors.New("it differs from the output in the UTXO set")
+	}
+	return nil
+}
+
+// SpentOutputs returns the outputs that were spent by the connected block with the given hash.
+func (us *UTXOSet) SpentOutputs(hash [32]byte) ([]SpentOutput, error) {
+	var spent []SpentOutput
+	err := us.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(utxoUndoBucket).Get(hash[:])
+		if data == nil {
+			return sync.ErrNotFound
+		}
+
+		var err error
+		spent, err = decodeSpentOutputs(data)
+		return err
+	})
+	return spent, err
+}
+
+func bestBlock(tx *bolt.Tx) ([32]byte, int32) {
+	return indexBestBlock(tx.Bucket(utxoStateBucket))
+}
+
+func putBestBlock(tx *bolt.Tx, hash [32]byte, height int32) error {
+	return putIndexBestBlock(tx.Bucket(utxoStateBucket), hash, height)
+}
+
+func outPointKey(op p2p.OutPoint) []byte {
+	return binary.LittleEndian.AppendUint32(append(make([]byte, 0, outPointLength), op.Hash[:]...), op.Index)
+}
+
+func encodeSpentOutputs(spent []SpentOutput) []byte {
+	var b []byte
+	for _, so := range spent {
+		u := so.UTXO.encode()
+		b = append(b, outPointKey(so.OutPoint)...)
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(u)))
+		b = append(b, u...)
+	}
+	return b
+}
+
+func decodeSpentOutputs(b []byte) ([]SpentOutput, error) {
+	var spent []SpentOutput
+	for len(b) > 0 {
+		if len(b) < outPointLength+4 {
+			return nil, errors.New("invalid undo data")
+		}
+		op := p2p.OutPoint{Hash: [32]byte(b[:32]), Index: binary.LittleEndian.Uint32(b[32:36])}
+		l := int(binary.LittleEndian.Uint32(b[36:40]))
+		b = b[40:]
+		if len(b) < l {
+			return nil, errors.New("invalid undo data")
+		}
+
+		u, err := decodeUTXO(b[:l])
+		if err != nil {
+			return nil, err
+		}
+		spent = append(spent, SpentOutput{OutPoint: op, UTXO: u})
+		b = b[l:]
+	}
+	return spent, nil
+}
+
+// isUnspendable returns true for OP_RETURN outputs, they are never added to the UTXO set.
+func isUnspendable(pkScript []byte) bool {
+	return len(pkScript) > 0 && pkScript[0] == 0x6a
+}
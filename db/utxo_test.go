@@ -0,0 +1,181 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestUTXOSet_ConnectAndDisconnectBlocks(t *testing.T) {
+	db, err := NewBoltDB(t.TempDir() + "/utxo.db")
+	require.NoError(t, err)
+	defer db.Close()
+
+	set, err := NewUTXOSet(db.DB)
+	require.NoError(t, err)
+
+	coinbase1 := testutil.NewMsgTx(coinbase, 50, 25)
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, coinbase1)
+	require.NoError(t, set.ConnectBlock(block1))
+	mature := maturityBlocks(block1.GetHash())
+	for _, b := range mature {
+		require.NoError(t, set.ConnectBlock(b))
+	}
+
+	// the next block spends an output from the first block and an output that is created in the same block.
+	spend1 := testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbase1.TxHash(), Index: 0}}, 40)
+	spend2 := testutil.NewMsgTx([]p2p.OutPoint{{Hash: spend1.TxHash(), Index: 0}}, 30)
+	block2 := testutil.NewMsgBlockWithTxs(mature[len(mature)-1].GetHash(), testutil.NewMsgTx(coinbase, 60), spend1, spend2)
+	require.NoError(t, set.ConnectBlock(block2))
+
+	hash, height, err := set.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, block2.GetHash(), hash)
+	require.Equal(t, int32(coinbaseMaturity+1), height)
+
+	_, err = set.Get(p2p.OutPoint{Hash: coinbase1.TxHash(), Index: 0})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+	_, err = set.Get(p2p.OutPoint{Hash: spend1.TxHash(), Index: 0})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+	u, err := set.Get(p2p.OutPoint{Hash: spend2.TxHash(), Index: 0})
+	require.NoError(t, err)
+	require.Equal(t, UTXO{Value: 30, PkScript: []byte{0x51, 0}, Height: coinbaseMaturity + 1}, u)
+
+	spent, err := set.SpentOutputs(block2.GetHash())
+	require.NoError(t, err)
+	require.Len(t, spent, 2)
+
+	require.NoError(t, set.DisconnectBlock(block2))
+
+	u, err = set.Get(p2p.OutPoint{Hash: coinbase1.TxHash(), Index: 0})
+	require.NoError(t, err)
+	require.Equal(t, UTXO{Value: 50, PkScript: []byte{0x51, 0}, Height: 1, Coinbase: true}, u)
+	_, err = set.Get(p2p.OutPoint{Hash: spend1.TxHash(), Index: 0})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+	_, err = set.Get(p2p.OutPoint{Hash: spend2.TxHash(), Index: 0})
+	require.ErrorIs(t, err, sync.ErrNotFound)
+
+	hash, height, err = set.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, mature[len(mature)-1].GetHash(), hash)
+	require.Equal(t, int32(coinbaseMaturity), height)
+}
+
+func TestUTXOSet_ConnectBlockWithMissingInput(t *testing.T) {
+	db, err := NewBoltDB(t.TempDir() + "/utxo.db")
+	require.NoError(t, err)
+	defer db.Close()
+
+	set, err := NewUTXOSet(db.DB)
+	require.NoError(t, err)
+
+	block := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 50),
+		testutil.NewMsgTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 10))
+	require.ErrorIs(t, set.ConnectBlock(block), ErrMissingInput)
+
+	block = testutil.NewMsgBlockWithTxs([32]byte{2}, testutil.NewMsgTx(coinbase, 50))
+	require.ErrorIs(t, set.ConnectBlock(block), ErrNotConnectingBlock)
+}
+
+func TestUTXOSet_ConnectBlockWithInvalidSpends(t *testing.T) {
+	db, err := NewBoltDB(t.TempDir() + "/utxo.db")
+	require.NoError(t, err)
+	defer db.Close()
+
+	set, err := NewUTXOSet(db.DB)
+	require.NoError(t, err)
+
+	coinbase1 := testutil.NewMsgTx(coinbase, 50)
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, coinbase1)
+	require.NoError(t, set.ConnectBlock(block1))
+	mature := maturityBlocks(block1.GetHash())
+	for _, b := range mature[:len(mature)-1] {
+		require.NoError(t, set.ConnectBlock(b))
+	}
+
+	// the coinbase output has 99 confirmations
+	spend := testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbase1.TxHash()}}, 50)
+	block := testutil.NewMsgBlockWithTxs(mature[len(mature)-2].GetHash(), testutil.NewMsgTx(coinbase, 60), spend)
+	require.ErrorIs(t, set.ConnectBlock(block), ErrImmatureSpend)
+
+	require.NoError(t, set.ConnectBlock(mature[len(mature)-1]))
+	block = testutil.NewMsgBlockWithTxs(mature[len(mature)-1].GetHash(), testutil.NewMsgTx(coinbase, 60),
+		testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbase1.TxHash()}}, 30, 21))
+	require.ErrorIs(t, set.ConnectBlock(block), ErrOutputsAboveInputs)
+
+	// the failed blocks don't change the set
+	_, err = set.Get(p2p.OutPoint{Hash: coinbase1.TxHash()})
+	require.NoError(t, err)
+	block = testutil.NewMsgBlockWithTxs(mature[len(mature)-1].GetHash(), testutil.NewMsgTx(coinbase, 60), spend)
+	require.NoError(t, set.ConnectBlock(block))
+}
+
+func TestUTXOSet_VerifyBlocks(t *testing.T) {
+	db, err := NewBoltDB(t.TempDir() + "/utxo.db")
+	require.NoError(t, err)
+	defer db.Close()
+
+	set, err := NewUTXOSet(db.DB)
+	require.NoError(t, err)
+
+	coinbase1 := testutil.NewMsgTx(coinbase, 50, 25)
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, coinbase1)
+	require.NoError(t, set.ConnectBlock(block1))
+	mature := maturityBlocks(block1.GetHash())
+	for _, b := range mature {
+		require.NoError(t, set.ConnectBlock(b))
+	}
+	spend1 := testutil.NewMsgTx([]p2p.OutPoint{{Hash: coinbase1.TxHash(), Index: 0}}, 40)
+	spend2 := testutil.NewMsgTx([]p2p.OutPoint{{Hash: spend1.TxHash(), Index: 0}}, 30)
+	block2 := testutil.NewMsgBlockWithTxs(mature[len(mature)-1].GetHash(), testutil.NewMsgTx(coinbase, 60), spend1, spend2)
+	require.NoError(t, set.ConnectBlock(block2))
+
+	// the blocks from the best block back to the first block
+	blocks := []p2p.MsgBlock{block2}
+	for i := len(mature) - 1; i >= 0; i-- {
+		blocks = append(blocks, mature[i])
+	}
+	blocks = append(blocks, block1)
+
+	n, err := set.VerifyBlocks(blocks)
+	require.NoError(t, err)
+	require.Equal(t, len(blocks), n)
+
+	// the verification doesn't change the set
+	hash, height, err := set.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, block2.GetHash(), hash)
+	require.Equal(t, int32(coinbaseMaturity+1), height)
+	_, err = set.Get(p2p.OutPoint{Hash: spend2.TxHash(), Index: 0})
+	require.NoError(t, err)
+
+	// an unspent output of the first block is missing
+	err = db.DB.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(utxoBucket).Delete(outPointKey(p2p.OutPoint{Hash: coinbase1.TxHash(), Index: 1}))
+	})
+	require.NoError(t, err)
+
+	n, err = set.VerifyBlocks(blocks)
+	require.Error(t, err)
+	require.Equal(t, len(blocks)-1, n)
+
+	// the blocks must be verified from the best block
+	n, err = set.VerifyBlocks([]p2p.MsgBlock{block1})
+	require.Error(t, err)
+	require.Equal(t, 0, n)
+}
+
+// maturityBlocks returns the empty blocks after prev after which the coinbase outputs of the block prev can be
+// spent by the next block.
+func maturityBlocks(prev [32]byte) []p2p.MsgBlock {
+	blocks := make([]p2p.MsgBlock, coinbaseMaturity-1)
+	for i := range blocks {
+		blocks[i] = testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase))
+		prev = blocks[i].GetHash()
+	}
+	return blocks
+}
@@ -0,0 +1,61 @@
+package mempool
+
+import (
+	"container/heap"
+)
+
+// evictionQueue is a min-heap of the transactions in the mempool ordered by their descendant fee rate, so the
+// transaction that is evicted first when the mempool is full is found without scanning the mempool. The
+// transactions with the same fee rate are ordered by the time they are added, the newest one is evicted first.
+// A transaction must be fixed in the queue every time its descendant package changes.
+type evictionQueue []*TxDesc
+
+func (q evictionQueue) Len() int { return len(q) }
+
+func (q evictionQueue) Less(i, j int) bool {
+	if a, b := q[i].DescendantFeeRate(), q[j].DescendantFeeRate(); a != b {
+		return a < b
+	}
+	return q[i].Added.After(q[j].Added)
+}
+
+func (q evictionQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *evictionQueue) Push(x any) {
+	d := x.(*TxDesc)
+	d.index = len(*q)
+	*q = append(*q, d)
+}
+
+func (q *evictionQueue) Pop() any {
+	old := *q
+	d := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	d.index = -1
+	return d
+}
+
+// add adds the transaction to the queue.
+func (q *evictionQueue) add(d *TxDesc) {
+	heap.Push(q, d)
+}
+
+// remove removes the transaction from the queue.
+func (q *evictionQueue) remove(d *TxDesc) {
+	heap.Remove(q, d.index)
+}
+
+// fix moves the transaction to its place after its descendant fee rate is changed.
+func (q *evictionQueue) fix(d *TxDesc) {
+	heap.Fix(q, d.index)
+}
+
+// worst returns the transaction with the lowest descendant fee rate.
+func (q evictionQueue) worst() *TxDesc {
+	return q[0]
+}
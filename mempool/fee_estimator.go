@@ -0,0 +1,505 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	stdsync "sync"
+)
+
+const (
+	// the fee rates of the buckets in satoshis per 1000 virtual bytes, every bucket is 5% above the previous one
+	minBucketFeeRate = 1000
+	maxBucketFeeRate = 10_000_000
+	feeSpacing       = 1.05
+
+	// the confirmation targets of the short, medium and long horizons are tracked in periods of scale blocks.
+	// The data points decay with every block, so their half-life is 18, 144 and 1008 blocks.
+	shortBlockPeriods = 12
+	shortScale        = 1
+	shortDecay        = .962
+	medBlockPeriods   = 24
+	medScale          = 2
+	medDecay          = .9952
+	longBlockPeriods  = 42
+	longScale         = 24
+	longDecay         = .99931
+
+	// the share of the transactions in a bucket that must be confirmed in the target for the half, the
+	// normal and the double target
+	halfSuccessPct   = .6
+	successPct       = .85
+	doubleSuccessPct = .95
+
+	// the decayed number of transactions per block that a bucket range needs to be estimated
+	sufficientFeeTxs   = .1
+	sufficientTxsShort = .5
+
+	// feeEstimatesVersion is the version of the serialized fee estimator
+	feeEstimatesVersion = 1
+)
+
+var (
+	// ErrInsufficientData is returned when there are not enough confirmed transactions for a fee estimate.
+	ErrInsufficientData = errors.New("insufficient data or no fee rate found")
+	// ErrInvalidFeeEstimates is returned when the serialized fee estimator can't be decoded.
+	ErrInvalidFeeEstimates = errors.New("invalid fee estimates")
+)
+
+// EstimateMode is the mode of the fee estimate, the same as the estimate_mode of estimatesmartfee.
+type EstimateMode int
+
+const (
+	// EstimateEconomical reacts faster to the recent drops of the fee rates.
+	EstimateEconomical EstimateMode = iota
+	// EstimateConservative considers a longer history of the blocks, so the estimate is less likely to be
+	// too low.
+	EstimateConservative
+)
+
+// FeeEstimate is the estimated fee rate in satoshis per 1000 virtual bytes for the confirmation in Blocks
+// blocks. Blocks can be different from the requested target, when there is not enough data for it.
+type FeeEstimate struct {
+	FeeRate int64
+	Blocks  int
+}
+
+// FeeEstimator estimates the fee rate that a transaction needs to be confirmed in a number of blocks, in
+// the same way as Bitcoin Core. The transactions that enter the mempool without unconfirmed parents are
+// tracked in buckets of fee rates, and when they are confirmed the number of blocks that they waited
+// is recorded. The data points decay with every block, in a short, a medium and a long horizon. For a
+// target, the estimate is the median fee rate of the lowest range of buckets whose transactions are
+// confirmed in the target with a high enough probability.
+type FeeEstimator struct {
+	mu      stdsync.Mutex
+	buckets []float64
+	short   *confirmStats
+	medium  *confirmStats
+	long    *confirmStats
+	tracked map[[32]byte]trackedTx
+
+	bestSeenHeight      int32
+	firstRecordedHeight int32
+	// the block span of the estimator from which the state is read
+	historicalFirst int32
+	historicalBest  int32
+}
+
+// trackedTx is a transaction in the mempool whose confirmation is tracked.
+type trackedTx struct {
+	height  int32
+	bucket  int
+	feeRate float64
+}
+
+// NewFeeEstimator creates a FeeEstimator without data.
+func NewFeeEstimator() *FeeEstimator {
+	var buckets []float64
+	for rate := float64(minBucketFeeRate); rate <= maxBucketFeeRate; rate *= feeSpacing {
+		buckets = append(buckets, rate)
+	}
+	buckets = append(buckets, math.Inf(1))
+
+	return &FeeEstimator{
+		buckets: buckets,
+		short:   newConfirmStats(len(buckets), shortBlockPeriods, shortScale, shortDecay),
+		medium:  newConfirmStats(len(buckets), medBlockPeriods, medScale, medDecay),
+		long:    newConfirmStats(len(buckets), longBlockPeriods, longScale, longDecay),
+		tracked: make(map[[32]byte]trackedTx),
+	}
+}
+
+// ProcessTx starts tracking the transaction that entered the mempool at the given height with the given
+// fee rate. The transactions are tracked only when the estimator is at the same height.
+func (fe *FeeEstimator) ProcessTx(txid [32]byte, height int32, feeRate int64) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if _, ok := fe.tracked[txid]; ok || height != fe.bestSeenHeight || fe.bestSeenHeight == 0 {
+		return
+	}
+
+	bucket := fe.bucket(float64(feeRate))
+	fe.tracked[txid] = trackedTx{height: height, bucket: bucket, feeRate: float64(feeRate)}
+	for _, s := range fe.stats() {
+		s.newTx(height, bucket)
+	}
+}
+
+// RemoveTx stops tracking the transaction that is removed from the mempool without a confirmation. It is
+// counted as failed for the targets that it waited for.
+func (fe *FeeEstimator) RemoveTx(txid [32]byte) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	fe.removeTx(txid, false)
+}
+
+func (fe *FeeEstimator) removeTx(txid [32]byte, inBlock bool) {
+	tx, ok := fe.tracked[txid]
+	if !ok {
+		return
+	}
+	for _, s := range fe.stats() {
+		s.removeTx(tx.height, fe.bestSeenHeight, tx.bucket, inBlock)
+	}
+	delete(fe.tracked, txid)
+}
+
+// ProcessBlock records the number of blocks in which the tracked transactions of the block at the given
+// height are confirmed. The data points decay with every block.
+func (fe *FeeEstimator) ProcessBlock(height int32, txids [][32]byte) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if height <= fe.bestSeenHeight {
+		// the blocks of a reorg are not counted twice
+		return
+	}
+	fe.bestSeenHeight = height
+
+	for _, s := range fe.stats() {
+		s.clearCurrent(height)
+		s.updateMovingAverages()
+	}
+
+	var counted int
+	for _, txid := range txids {
+		tx, ok := fe.tracked[txid]
+		if !ok {
+			continue
+		}
+		fe.removeTx(txid, true)
+		blocks := int(height - tx.height)
+		if blocks <= 0 {
+			continue
+		}
+		for _, s := range fe.stats() {
+			s.record(blocks, tx.bucket, tx.feeRate)
+		}
+		counted++
+	}
+	if counted > 0 && fe.firstRecordedHeight == 0 {
+		fe.firstRecordedHeight = height
+	}
+}
+
+// EstimateSmartFee returns the fee rate that a transaction needs to be confirmed in the target number of
+// blocks, with the semantics of estimatesmartfee. The target is reduced to the targets for which there is
+// enough data, and a target of 1 is estimated as 2 blocks.
+func (fe *FeeEstimator) EstimateSmartFee(target int, mode EstimateMode) (FeeEstimate, error) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+
+	maxUsable := fe.maxUsableEstimate()
+	if target <= 0 || maxUsable <= 1 {
+		return FeeEstimate{}, ErrInsufficientData
+	}
+	target = min(target, maxUsable)
+	if target == 1 {
+		target = 2
+	}
+	conservative := mode == EstimateConservative
+
+	// the estimate for the target is at least the estimate with a lower threshold for the half target and
+	// with a higher threshold for the double target
+	median := fe.estimateCombinedFee(target/2, halfSuccessPct, true)
+	median = max(median, fe.estimateCombinedFee(target, successPct, true))
+	median = max(median, fe.estimateCombinedFee(2*target, doubleSuccessPct, !conservative))
+	if conservative || median < 0 {
+		median = max(median, fe.estimateConservativeFee(2*target))
+	}
+	if median < 0 {
+		return FeeEstimate{}, ErrInsufficientData
+	}
+	return FeeEstimate{FeeRate: int64(math.Round(median)), Blocks: target}, nil
+}
+
+// maxUsableEstimate returns the maximum target that can be estimated, it is half of the blocks for which
+// the transactions are tracked.
+func (fe *FeeEstimator) maxUsableEstimate() int {
+	var span, historicalSpan int32
+	if fe.firstRecordedHeight > 0 {
+		span = fe.bestSeenHeight - fe.firstRecordedHeight
+	}
+	if fe.historicalFirst > 0 && fe.historicalBest > fe.historicalFirst {
+		historicalSpan = fe.historicalBest - fe.historicalFirst
+	}
+	return min(fe.long.maxConfirms(), int(max(span, historicalSpan))/2)
+}
+
+// estimateCombinedFee returns the estimate for the target from the shortest horizon that tracks it, or -1.
+// When checkShorterHorizon is true, the lower estimate of the shorter horizons for their maximum targets
+// is used, because a transaction that is confirmed in fewer blocks is confirmed in the target too.
+func (fe *FeeEstimator) estimateCombinedFee(target int, successThreshold float64, checkShorterHorizon bool) float64 {
+	estimate := -1.0
+	if target < 1 || target > fe.long.maxConfirms() {
+		return estimate
+	}
+
+	switch {
+	case target <= fe.short.maxConfirms():
+		estimate = fe.short.estimateMedianVal(target, sufficientTxsShort, successThreshold, fe.bestSeenHeight)
+	case target <= fe.medium.maxConfirms():
+		estimate = fe.medium.estimateMedianVal(target, sufficientFeeTxs, successThreshold, fe.bestSeenHeight)
+	default:
+		estimate = fe.long.estimateMedianVal(target, sufficientFeeTxs, successThreshold, fe.bestSeenHeight)
+	}
+
+	if !checkShorterHorizon {
+		return estimate
+	}
+	for _, s := range []*confirmStats{fe.medium, fe.short} {
+		if target <= s.maxConfirms() {
+			continue
+		}
+		shorter := s.estimateMedianVal(s.maxConfirms(), sufficientFeeTxs, successThreshold, fe.bestSeenHeight)
+		if shorter > 0 && (estimate < 0 || shorter < estimate) {
+			estimate = shorter
+		}
+	}
+	return estimate
+}
+
+// estimateConservativeFee returns the estimate for the double target with the highest threshold from the
+// medium and the long horizons, or -1.
+func (fe *FeeEstimator) estimateConservativeFee(doubleTarget int) float64 {
+	estimate := -1.0
+	if doubleTarget <= fe.short.maxConfirms() {
+		estimate = fe.medium.estimateMedianVal(doubleTarget, sufficientFeeTxs, doubleSuccessPct, fe.bestSeenHeight)
+	}
+	if doubleTarget <= fe.medium.maxConfirms() {
+		estimate = max(estimate, fe.long.estimateMedianVal(doubleTarget, sufficientFeeTxs, doubleSuccessPct, fe.bestSeenHeight))
+	}
+	return estimate
+}
+
+// bucket returns the index of the bucket of the fee rate, it is the first bucket whose fee rate is not
+// below it.
+func (fe *FeeEstimator) bucket(feeRate float64) int {
+	for i, b := range fe.buckets {
+		if feeRate <= b {
+			return i
+		}
+	}
+	return len(fe.buckets) - 1
+}
+
+func (fe *FeeEstimator) stats() []*confirmStats {
+	return []*confirmStats{fe.short, fe.medium, fe.long}
+}
+
+// Write writes the state of the estimator, the tracked transactions are not written because they are not
+// in the mempool when it is read.
+func (fe *FeeEstimator) Write(w io.Writer) error {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+
+	data := []any{uint32(feeEstimatesVersion), fe.firstRecordedHeight, fe.bestSeenHeight, uint32(len(fe.buckets))}
+	for _, s := range fe.stats() {
+		data = append(data, s.txCtAvg, s.feeRateSum)
+		for p := range s.confAvg {
+			data = append(data, s.confAvg[p], s.failAvg[p])
+		}
+	}
+	for _, d := range data {
+		if err := binary.Write(w, binary.LittleEndian, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFeeEstimator reads the state of the estimator that is written by Write. Its block span is used for
+// the maximum target until the new estimator tracks enough blocks.
+func ReadFeeEstimator(r io.Reader) (*FeeEstimator, error) {
+	fe := NewFeeEstimator()
+
+	var version, buckets uint32
+	for _, d := range []any{&version, &fe.historicalFirst, &fe.historicalBest, &buckets} {
+		if err := binary.Read(r, binary.LittleEndian, d); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFeeEstimates, err)
+		}
+	}
+	if version != feeEstimatesVersion || int(buckets) != len(fe.buckets) {
+		return nil, fmt.Errorf("%w: version %d with %d buckets", ErrInvalidFeeEstimates, version, buckets)
+	}
+
+	for _, s := range fe.stats() {
+		data := []any{s.txCtAvg, s.feeRateSum}
+		for p := range s.confAvg {
+			data = append(data, s.confAvg[p], s.failAvg[p])
+		}
+		for _, d := range data {
+			if err := binary.Read(r, binary.LittleEndian, d); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidFeeEstimates, err)
+			}
+		}
+	}
+	return fe, nil
+}
+
+// confirmStats tracks the confirmations of the transactions in every bucket for the targets of a horizon.
+type confirmStats struct {
+	scale int
+	decay float64
+	// confAvg[p][b] is the decayed number of the transactions in bucket b that are confirmed in p+1 periods
+	// or less
+	confAvg [][]float64
+	// failAvg[p][b] is the decayed number of the transactions in bucket b that are removed from the mempool
+	// without a confirmation after p+1 periods or more
+	failAvg [][]float64
+	// txCtAvg[b] is the decayed number of the confirmed transactions in bucket b and feeRateSum[b] is the
+	// decayed sum of their fee rates
+	txCtAvg    []float64
+	feeRateSum []float64
+	// unconfTxs[h][b] is the number of the tracked transactions in bucket b that entered the mempool at a
+	// height h modulo the max confirmations, oldUnconfTxs[b] the number of the older ones
+	unconfTxs    [][]int
+	oldUnconfTxs []int
+}
+
+func newConfirmStats(buckets, periods, scale int, decay float64) *confirmStats {
+	s := &confirmStats{
+		scale:        scale,
+		decay:        decay,
+		confAvg:      make([][]float64, periods),
+		failAvg:      make([][]float64, periods),
+		txCtAvg:      make([]float64, buckets),
+		feeRateSum:   make([]float64, buckets),
+		unconfTxs:    make([][]int, periods*scale),
+		oldUnconfTxs: make([]int, buckets),
+	}
+	for p := range periods {
+		s.confAvg[p] = make([]float64, buckets)
+		s.failAvg[p] = make([]float64, buckets)
+	}
+	for i := range s.unconfTxs {
+		s.unconfTxs[i] = make([]int, buckets)
+	}
+	return s
+}
+
+// maxConfirms returns the maximum target in blocks that is tracked.
+func (s *confirmStats) maxConfirms() int {
+	return len(s.confAvg) * s.scale
+}
+
+func (s *confirmStats) index(height int32) int {
+	n := int32(len(s.unconfTxs))
+	return int((height%n + n) % n)
+}
+
+func (s *confirmStats) newTx(height int32, bucket int) {
+	s.unconfTxs[s.index(height)][bucket]++
+}
+
+func (s *confirmStats) removeTx(entryHeight, bestSeenHeight int32, bucket int, inBlock bool) {
+	blocksAgo := max(int(bestSeenHeight-entryHeight), 0)
+	if blocksAgo >= len(s.unconfTxs) {
+		s.oldUnconfTxs[bucket] = max(s.oldUnconfTxs[bucket]-1, 0)
+	} else {
+		i := s.index(entryHeight)
+		s.unconfTxs[i][bucket] = max(s.unconfTxs[i][bucket]-1, 0)
+	}
+
+	if inBlock || blocksAgo < s.scale {
+		return
+	}
+	for p := 0; p < blocksAgo/s.scale && p < len(s.failAvg); p++ {
+		s.failAvg[p][bucket]++
+	}
+}
+
+// clearCurrent moves the unconfirmed transactions that are older than the max confirmations at the new
+// height to the old ones.
+func (s *confirmStats) clearCurrent(height int32) {
+	i := s.index(height)
+	for b, n := range s.unconfTxs[i] {
+		s.oldUnconfTxs[b] += n
+		s.unconfTxs[i][b] = 0
+	}
+}
+
+func (s *confirmStats) updateMovingAverages() {
+	for b := range s.txCtAvg {
+		s.txCtAvg[b] *= s.decay
+		s.feeRateSum[b] *= s.decay
+		for p := range s.confAvg {
+			s.confAvg[p][b] *= s.decay
+			s.failAvg[p][b] *= s.decay
+		}
+	}
+}
+
+// record records the transaction in the bucket that is confirmed in the given number of blocks.
+func (s *confirmStats) record(blocks int, bucket int, feeRate float64) {
+	periods := (blocks + s.scale - 1) / s.scale
+	for p := periods; p <= len(s.confAvg); p++ {
+		s.confAvg[p-1][bucket]++
+	}
+	s.txCtAvg[bucket]++
+	s.feeRateSum[bucket] += feeRate
+}
+
+// estimateMedianVal returns the median fee rate of the lowest range of buckets in which enough transactions
+// are confirmed in the target, or -1. The buckets are grouped, starting from the highest fee rate, until
+// they have enough data points. A range passes when the share of its transactions that are confirmed in
+// the target, out of all its confirmed, failed and unconfirmed transactions that waited longer than the
+// target, is at least the threshold.
+func (s *confirmStats) estimateMedianVal(target int, sufficientTxs, threshold float64, height int32) float64 {
+	periodTarget := (target + s.scale - 1) / s.scale
+	if periodTarget < 1 || periodTarget > len(s.confAvg) {
+		return -1
+	}
+
+	var confirmed, total, failed, extra float64
+	maxBucket := len(s.txCtAvg) - 1
+	curNear, curFar, bestNear, bestFar := maxBucket, maxBucket, maxBucket, maxBucket
+	found, newRange := false, true
+	for b := maxBucket; b >= 0; b-- {
+		if newRange {
+			curNear = b
+			newRange = false
+		}
+		curFar = b
+		confirmed += s.confAvg[periodTarget-1][b]
+		total += s.txCtAvg[b]
+		failed += s.failAvg[periodTarget-1][b]
+		for c := target; c < s.maxConfirms(); c++ {
+			extra += float64(s.unconfTxs[s.index(height-int32(c))][b])
+		}
+		extra += float64(s.oldUnconfTxs[b])
+
+		if total < sufficientTxs/(1-s.decay) {
+			continue
+		}
+		if confirmed/(total+failed+extra) < threshold {
+			// the lower buckets are added to the failing range, so it never passes again
+			continue
+		}
+		found, newRange = true, true
+		bestNear, bestFar = curNear, curFar
+		confirmed, total, failed, extra = 0, 0, 0, 0
+	}
+	if !found {
+		return -1
+	}
+
+	// the median is the average fee rate of the bucket with the middle transaction of the range
+	var sum float64
+	for b := bestFar; b <= bestNear; b++ {
+		sum += s.txCtAvg[b]
+	}
+	if sum == 0 {
+		return -1
+	}
+	half := sum / 2
+	for b := bestFar; b <= bestNear; b++ {
+		if s.txCtAvg[b] < half {
+			half -= s.txCtAvg[b]
+			continue
+		}
+		return s.feeRateSum[b] / s.txCtAvg[b]
+	}
+	return -1
+}
@@ -0,0 +1,66 @@
+package mempool_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeEstimator_EstimateSmartFee(t *testing.T) {
+	fe := mempool.NewFeeEstimator()
+	_, err := fe.EstimateSmartFee(2, mempool.EstimateEconomical)
+	require.ErrorIs(t, err, mempool.ErrInsufficientData)
+
+	fillFeeEstimator(fe, 50)
+	for _, mode := range []mempool.EstimateMode{mempool.EstimateEconomical, mempool.EstimateConservative} {
+		estimate, err := fe.EstimateSmartFee(1, mode)
+		require.NoError(t, err)
+		require.Equal(t, mempool.FeeEstimate{FeeRate: 20_000, Blocks: 2}, estimate)
+	}
+
+	// the target is reduced to half of the 48 blocks in which the confirmations are recorded
+	estimate, err := fe.EstimateSmartFee(1000, mempool.EstimateEconomical)
+	require.NoError(t, err)
+	require.Equal(t, 24, estimate.Blocks)
+}
+
+func TestFeeEstimator_WriteAndRead(t *testing.T) {
+	fe := mempool.NewFeeEstimator()
+	fillFeeEstimator(fe, 50)
+
+	var buf bytes.Buffer
+	require.NoError(t, fe.Write(&buf))
+	read, err := mempool.ReadFeeEstimator(&buf)
+	require.NoError(t, err)
+
+	// the block span of the written estimator is used before the new one tracks enough blocks
+	estimate, err := read.EstimateSmartFee(1000, mempool.EstimateEconomical)
+	require.NoError(t, err)
+	require.Equal(t, 24, estimate.Blocks)
+	estimate, err = read.EstimateSmartFee(2, mempool.EstimateEconomical)
+	require.NoError(t, err)
+	require.Equal(t, mempool.FeeEstimate{FeeRate: 20_000, Blocks: 2}, estimate)
+
+	_, err = mempool.ReadFeeEstimator(bytes.NewReader([]byte{1, 0, 0, 0, 1}))
+	require.ErrorIs(t, err, mempool.ErrInvalidFeeEstimates)
+}
+
+// fillFeeEstimator tracks 10 transactions with fee rate 20 000 that are confirmed in the next block and 10
+// transactions with fee rate 2000 that are never confirmed in every block up to the given height.
+func fillFeeEstimator(fe *mempool.FeeEstimator, blocks int32) {
+	var next uint16
+	var fast [][32]byte
+	for h := int32(1); h <= blocks; h++ {
+		fe.ProcessBlock(h, fast)
+		fast = nil
+		for i := 0; i < 10; i++ {
+			next++
+			fastTx := [32]byte{byte(next), byte(next >> 8), 1}
+			fe.ProcessTx(fastTx, h, 20_000)
+			fast = append(fast, fastTx)
+			fe.ProcessTx([32]byte{byte(next), byte(next >> 8), 2}, h, 2000)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package mempool
+
+import (
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// UTXOSet is the UTXO set of the best chain against which the transactions are validated. Get returns
+// sync.ErrNotFound when the output doesn't exist or is spent. BestBlock returns the hash and the height
+// of the last block that is connected to the set.
+type UTXOSet interface {
+	Get(op p2p.OutPoint) (db.UTXO, error)
+	BestBlock() ([32]byte, int32, error)
+}
@@ -0,0 +1,860 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	stdsync "sync"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/script"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+const (
+	// incrementalRelayFeeRate is added to the fee rate of the evicted transactions to get the minimum fee
+	// rate of the mempool.
+	incrementalRelayFeeRate = 1000
+	// rollingFeeHalfLife is the time for which the minimum fee rate of the mempool that is raised by the
+	// eviction drops by half.
+	rollingFeeHalfLife = 12 * time.Hour
+	// maxReplacementEvictions is the maximum number of transactions that a replacement evicts, the
+	// conflicting transactions with their descendants.
+	maxReplacementEvictions = 100
+)
+
+var (
+	// ErrAlreadyHave is returned for a transaction that is already in the mempool.
+	ErrAlreadyHave = errors.New("transaction already in the mempool")
+	// ErrMissingInputs is returned when an input of the transaction is neither in the UTXO set nor in the
+	// mempool. The parent may be unknown yet or its output may be spent.
+	ErrMissingInputs = errors.New("missing inputs")
+	// ErrImmatureSpend is returned for a transaction that spends a coinbase before it is mature.
+	ErrImmatureSpend = errors.New("spends an immature coinbase")
+	// ErrConflict is returned for a transaction that spends an output that is spent by another transaction
+	// in the mempool and can't replace it.
+	ErrConflict = errors.New("conflicts with a transaction in the mempool")
+	// ErrTooManyReplacements is returned for a replacement that evicts more than 100 transactions.
+	ErrTooManyReplacements = errors.New("too many replaced transactions")
+	// ErrInsufficientFee is returned when the fee rate of the transaction is below the minimum fee rate of
+	// the mempool.
+	ErrInsufficientFee = errors.New("insufficient fee")
+	// ErrAbsurdFee is returned for a locally submitted transaction whose fee rate is above the maximum.
+	ErrAbsurdFee = errors.New("absurdly high fee")
+	// ErrTooLongChain is returned when the transaction exceeds the limits of the ancestor and descendant
+	// packages.
+	ErrTooLongChain = errors.New("too long chain of unconfirmed transactions")
+	// ErrMempoolFull is returned when the transaction is evicted right after it is added, because its
+	// descendant fee rate is the lowest one in the full mempool.
+	ErrMempoolFull = errors.New("mempool full")
+)
+
+// Mempool keeps the unconfirmed transactions that are received from the peers or submitted locally. The
+// transactions are validated against the UTXO set and the outputs of the other transactions in the mempool,
+// and are relayed only when they follow the policy rules. The total virtual size of the transactions is
+// bounded, when it is exceeded the transactions with the lowest descendant fee rate are evicted with their
+// descendants and the minimum fee rate of the mempool is raised above their fee rate.
+//
+// A transaction that spends the same outputs as transactions in the mempool replaces them when it pays a
+// higher fee rate than each of them and a higher fee than all of them with their descendants (BIP 125).
+// Only the transactions that signal the replacement can be replaced, unless full-RBF is enabled.
+//
+// The transactions from the peers whose parents are unknown are kept in a bounded orphan pool, and they are
+// added to the mempool when their parents are added.
+//
+// It implements node.Indexer, so the transactions that are confirmed by a connected block are removed from
+// it with the transactions that conflict with the block.
+type Mempool struct {
+	utxoSet         UTXOSet
+	maxSize         int64
+	minRelayFeeRate int64
+	fullRBF         bool
+	// the fees are not estimated when the estimator is nil
+	feeEstimator *FeeEstimator
+
+	mu     stdsync.RWMutex
+	txs    map[[32]byte]*TxDesc
+	wtxids map[[32]byte]*TxDesc
+	// spent maps the outputs that are spent by the transactions to them
+	spent map[p2p.OutPoint]*TxDesc
+	size  int64
+	// evictions orders the transactions by their descendant fee rate for the eviction
+	evictions evictionQueue
+	// deltas are the fee deltas of the transactions, they are kept for the transactions that are not in the
+	// mempool yet too
+	deltas map[[32]byte]int64
+
+	// rollingMinFeeRate is the minimum fee rate that is raised by the eviction, it decays with time
+	rollingMinFeeRate float64
+	lastRollingUpdate time.Time
+
+	// the subscribers that are notified for the replaced transactions
+	replacements []chan<- Replacement
+
+	// orphans are the transactions that are received from the peers before their parents
+	orphans *orphanPool
+}
+
+// Replacement is the event for the transactions that are replaced by a transaction, they are the
+// conflicting transactions with their descendants.
+type Replacement struct {
+	Replaced []TxDesc
+	By       TxDesc
+}
+
+// New creates a Mempool whose transactions spend the outputs in the UTXO set. The max size is the maximum
+// total virtual size of the transactions and the min relay fee rate is the minimum fee rate of the
+// accepted transactions in satoshis per 1000 virtual bytes. When full RBF is true, every transaction in the
+// mempool can be replaced, otherwise only the ones that signal it. The fee estimator tracks the confirmations
+// of the transactions, it can be nil.
+func New(us UTXOSet, maxSize int64, minRelayFeeRate int64, fullRBF bool, fe *FeeEstimator) *Mempool {
+	return &Mempool{
+		utxoSet:         us,
+		maxSize:         maxSize,
+		minRelayFeeRate: minRelayFeeRate,
+		fullRBF:         fullRBF,
+		feeEstimator:    fe,
+		txs:             make(map[[32]byte]*TxDesc),
+		wtxids:          make(map[[32]byte]*TxDesc),
+		spent:           make(map[p2p.OutPoint]*TxDesc),
+		deltas:          make(map[[32]byte]int64),
+		orphans:         newOrphanPool(),
+	}
+}
+
+// AcceptTx validates the transaction that is received from a peer and adds it to the mempool.
+func (m *Mempool) AcceptTx(tx p2p.MsgTx) (TxDesc, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	desc, err := m.acceptTx(tx, 0)
+	if err == nil {
+		m.trackFee(desc)
+	}
+	return desc, err
+}
+
+// ProcessTx validates the transaction that is received from the peer and adds it to the mempool with the
+// orphan transactions that spend its outputs, recursively, and returns the added transactions. A transaction
+// whose parents are unknown is kept in the orphan pool until they are received, then it returns the txids of
+// the missing parents, so they can be requested from the peer.
+func (m *Mempool) ProcessTx(tx p2p.MsgTx, peer string) ([]TxDesc, [][32]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	txid := tx.TxHash()
+	if m.orphans.has(txid) {
+		return nil, nil, ErrAlreadyHave
+	}
+	desc, err := m.acceptTx(tx, 0)
+	if errors.Is(err, ErrMissingInputs) {
+		missing := m.missingParents(tx)
+		if len(missing) == 0 {
+			return nil, nil, err
+		}
+		if err = m.orphans.add(tx, peer, time.Now()); err != nil {
+			return nil, nil, err
+		}
+		log.Printf("transaction %x from peer %s is an orphan, %d parents are missing\n", p2p.Reverse(txid), peer, len(missing))
+		return nil, missing, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	m.trackFee(desc)
+	return append([]TxDesc{desc}, m.processOrphans(tx)...), nil, nil
+}
+
+// missingParents returns the txids of the transactions whose outputs the transaction spends and that are
+// neither in the mempool nor in the UTXO set. The parents of an output that is spent are returned too,
+// because the UTXO set doesn't tell a spent output from an unknown one.
+func (m *Mempool) missingParents(tx p2p.MsgTx) [][32]byte {
+	var missing [][32]byte
+	for _, in := range tx.TxIn {
+		op := in.PreviousOutput
+		if _, ok := m.txs[op.Hash]; ok || slices.Contains(missing, op.Hash) {
+			continue
+		}
+		if _, err := m.utxoSet.Get(op); errors.Is(err, sync.ErrNotFound) {
+			missing = append(missing, op.Hash)
+		}
+	}
+	return missing
+}
+
+// processOrphans adds to the mempool the orphans that spend the outputs of the added transaction, then the
+// orphans that spend their outputs. The orphans that still miss parents stay in the orphan pool, the ones
+// that are invalid are removed from it.
+func (m *Mempool) processOrphans(parent p2p.MsgTx) []TxDesc {
+	var added []TxDesc
+	queue := []p2p.MsgTx{parent}
+	for len(queue) > 0 {
+		tx := queue[0]
+		queue = queue[1:]
+		for _, o := range m.orphans.children(tx) {
+			desc, err := m.acceptTx(o.tx, 0)
+			if errors.Is(err, ErrMissingInputs) && len(m.missingParents(o.tx)) > 0 {
+				continue
+			}
+			m.orphans.remove(o.txid)
+			if err != nil {
+				log.Printf("orphan transaction %x from peer %s is rejected: %s\n", p2p.Reverse(o.txid), o.peer, err)
+				continue
+			}
+			m.trackFee(desc)
+			added = append(added, desc)
+			queue = append(queue, o.tx)
+		}
+	}
+	return added
+}
+
+// HaveOrphan returns true when the transaction with the txid or wtxid is in the orphan pool.
+func (m *Mempool) HaveOrphan(hash [32]byte) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.orphans.has(hash)
+}
+
+// RemoveOrphansForPeer removes the orphan transactions that are received from the disconnected peer.
+func (m *Mempool) RemoveOrphansForPeer(peer string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n := m.orphans.removeForPeer(peer); n > 0 {
+		log.Printf("removed %d orphan transactions of peer %s\n", n, peer)
+	}
+}
+
+// SubmitTx validates the transaction that is submitted locally and adds it to the mempool. A transaction
+// whose fee rate is above the max fee rate is rejected, it is usually a mistake. 0 disables the check.
+func (m *Mempool) SubmitTx(tx p2p.MsgTx, maxFeeRate int64) (TxDesc, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	desc, err := m.acceptTx(tx, maxFeeRate)
+	if err == nil {
+		m.trackFee(desc)
+	}
+	return desc, err
+}
+
+// trackFee tracks the confirmation of the transaction for the fee estimation. The transactions with
+// unconfirmed parents are not tracked, because their fee rate is not the one at which they are mined.
+func (m *Mempool) trackFee(desc TxDesc) {
+	if m.feeEstimator != nil && desc.AncestorCount == 1 {
+		m.feeEstimator.ProcessTx(desc.TxID, desc.Height, feeRate(desc.Fee, desc.VSize))
+	}
+}
+
+// EstimateSmartFee returns the fee rate that a transaction needs to be confirmed in the target number of
+// blocks, with the semantics of estimatesmartfee. The fee rate is at least the minimum fee rate of the
+// mempool.
+func (m *Mempool) EstimateSmartFee(target int, mode EstimateMode) (FeeEstimate, error) {
+	if m.feeEstimator == nil {
+		return FeeEstimate{}, ErrInsufficientData
+	}
+	estimate, err := m.feeEstimator.EstimateSmartFee(target, mode)
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+	estimate.FeeRate = max(estimate.FeeRate, m.MinFeeRate())
+	return estimate, nil
+}
+
+// PrioritiseTransaction adds the fee delta to the fee of the transaction, the same as prioritisetransaction.
+// The modified fee is used instead of the fee for the acceptance, the eviction and the replacement of the
+// transaction and for the fees of its packages. The delta is kept for a transaction that is not in the
+// mempool, until it is added or confirmed.
+func (m *Mempool) PrioritiseTransaction(txid [32]byte, delta int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prioritise(txid, delta)
+}
+
+func (m *Mempool) prioritise(txid [32]byte, delta int64) {
+	m.deltas[txid] += delta
+	if m.deltas[txid] == 0 {
+		delete(m.deltas, txid)
+	}
+
+	d, ok := m.txs[txid]
+	if !ok {
+		return
+	}
+	d.FeeDelta += delta
+	d.DescendantFees += delta
+	m.evictions.fix(d)
+	for _, a := range m.ancestors(d.parents) {
+		a.DescendantFees += delta
+		m.evictions.fix(a)
+	}
+	for _, c := range m.descendants(d) {
+		c.AncestorFees += delta
+	}
+}
+
+func (m *Mempool) acceptTx(tx p2p.MsgTx, maxFeeRate int64) (TxDesc, error) {
+	desc, ancestors, replaced, err := m.validateTx(tx, maxFeeRate, true)
+	if err != nil {
+		return TxDesc{}, err
+	}
+
+	m.remove(replaced)
+	m.add(desc, ancestors)
+	m.trimToSize(desc.Added)
+	if _, ok := m.txs[desc.TxID]; !ok {
+		// the replaced transactions stay in the mempool when the replacement doesn't fit in it
+		m.restore(replaced)
+		return TxDesc{}, ErrMempoolFull
+	}
+	if len(replaced) > 0 {
+		m.notifyReplacement(replaced, desc)
+	}
+	return *desc, nil
+}
+
+// restore adds back the transactions that are removed by a replacement that failed, parents first. They keep
+// the time and the height at which they were added. A transaction whose parents are evicted in the meantime
+// is not restored.
+func (m *Mempool) restore(txs map[[32]byte]*TxDesc) {
+	restored := make([]*TxDesc, 0, len(txs))
+	for _, d := range txs {
+		restored = append(restored, d)
+	}
+	slices.SortFunc(restored, func(a, b *TxDesc) int { return a.AncestorCount - b.AncestorCount })
+
+	for _, d := range restored {
+		desc, ancestors, _, err := m.validateTx(d.Tx, 0, false)
+		if err != nil {
+			log.Printf("transaction %x is not restored to the mempool: %s\n", p2p.Reverse(d.TxID), err)
+			continue
+		}
+		desc.Added, desc.Height = d.Added, d.Height
+		m.add(desc, ancestors)
+		m.trackFee(*desc)
+	}
+}
+
+// validateTx validates the transaction against the UTXO set and the mempool, and returns it with its
+// ancestors in the mempool and the transactions that it replaces. The fee is not checked against the
+// minimum fee rate of the mempool when checkFee is false, the transaction of a package is checked with
+// the fee rate of the package.
+func (m *Mempool) validateTx(tx p2p.MsgTx, maxFeeRate int64, checkFee bool) (*TxDesc, map[[32]byte]*TxDesc, map[[32]byte]*TxDesc, error) {
+	txid, wtxid := tx.TxHash(), tx.WTxHash()
+	if _, ok := m.txs[txid]; ok {
+		return nil, nil, nil, ErrAlreadyHave
+	}
+	if _, ok := m.wtxids[wtxid]; ok {
+		return nil, nil, nil, ErrAlreadyHave
+	}
+
+	if err := checkTransaction(tx); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkStandard(tx); err != nil {
+		return nil, nil, nil, err
+	}
+
+	_, height, err := m.utxoSet.BestBlock()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	now := time.Now()
+	if !isFinal(tx, height+1, now) {
+		return nil, nil, nil, fmt.Errorf("%w: lock time %d", ErrNonFinal, tx.LockTime)
+	}
+
+	conflicts := make(map[[32]byte]*TxDesc)
+	for _, in := range tx.TxIn {
+		conflict, ok := m.spent[in.PreviousOutput]
+		if !ok {
+			continue
+		}
+		if !m.fullRBF && !signalsReplacement(conflict.Tx) {
+			return nil, nil, nil, fmt.Errorf("%w: %x spends %x:%d and is not replaceable", ErrConflict, p2p.Reverse(conflict.TxID),
+				p2p.Reverse(in.PreviousOutput.Hash), in.PreviousOutput.Index)
+		}
+		conflicts[conflict.TxID] = conflict
+	}
+
+	parents, prevOuts, err := m.spentOutputs(tx, height+1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var inputs int64
+	for _, out := range prevOuts {
+		inputs += out.Value
+	}
+
+	var outputs int64
+	for _, out := range tx.TxOut {
+		outputs += out.Value
+	}
+	if inputs < outputs {
+		return nil, nil, nil, fmt.Errorf("%w: spends %d, but the inputs are %d", ErrInvalidTx, outputs, inputs)
+	}
+
+	desc := &TxDesc{
+		Tx:       tx,
+		TxID:     txid,
+		WTxID:    wtxid,
+		Fee:      inputs - outputs,
+		FeeDelta: m.deltas[txid],
+		VSize:    tx.VSize(),
+		Height:   height,
+		Added:    now,
+		parents:  parents,
+		children: make(map[[32]byte]*TxDesc),
+	}
+	if minFee := feeAt(m.minFeeRate(now), desc.VSize); checkFee && desc.ModifiedFee() < minFee {
+		return nil, nil, nil, fmt.Errorf("%w: fee %d, the minimum is %d", ErrInsufficientFee, desc.ModifiedFee(), minFee)
+	}
+	if rate := feeRate(desc.Fee, desc.VSize); maxFeeRate > 0 && rate > maxFeeRate {
+		return nil, nil, nil, fmt.Errorf("%w: fee rate %d, the maximum is %d", ErrAbsurdFee, rate, maxFeeRate)
+	}
+
+	ancestors := m.ancestors(parents)
+	var replaced map[[32]byte]*TxDesc
+	if len(conflicts) > 0 {
+		if replaced, err = m.checkReplacement(desc, conflicts, ancestors); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if err = checkPackageLimits(desc, ancestors); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// the scripts are the most expensive check, so they are verified last
+	if err = script.VerifyTx(tx, prevOuts); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidTx, err)
+	}
+	return desc, ancestors, replaced, nil
+}
+
+// spentOutputs returns the transactions in the mempool whose outputs the transaction spends and the outputs
+// that its inputs spend, in the order of the inputs. The coinbase outputs must be mature at the given height.
+func (m *Mempool) spentOutputs(tx p2p.MsgTx, height int32) (map[[32]byte]*TxDesc, []p2p.TxOutput, error) {
+	parents := make(map[[32]byte]*TxDesc)
+	prevOuts := make([]p2p.TxOutput, 0, len(tx.TxIn))
+	for _, in := range tx.TxIn {
+		op := in.PreviousOutput
+		if parent, ok := m.txs[op.Hash]; ok {
+			if int(op.Index) >= len(parent.Tx.TxOut) {
+				return nil, nil, fmt.Errorf("%w: %x:%d", ErrMissingInputs, p2p.Reverse(op.Hash), op.Index)
+			}
+			parents[op.Hash] = parent
+			prevOuts = append(prevOuts, parent.Tx.TxOut[op.Index])
+			continue
+		}
+
+		u, err := m.utxoSet.Get(op)
+		if errors.Is(err, sync.ErrNotFound) {
+			return nil, nil, fmt.Errorf("%w: %x:%d", ErrMissingInputs, p2p.Reverse(op.Hash), op.Index)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if u.Coinbase && height-u.Height < coinbaseMaturity {
+			return nil, nil, fmt.Errorf("%w: %x:%d from height %d", ErrImmatureSpend, p2p.Reverse(op.Hash), op.Index, u.Height)
+		}
+		prevOuts = append(prevOuts, p2p.TxOutput{Value: u.Value, PkScriptLength: p2p.VarInt(len(u.PkScript)), PkScript: u.PkScript})
+	}
+	return parents, prevOuts, nil
+}
+
+// checkReplacement checks that the transaction can replace the transactions in the mempool that it conflicts
+// with (BIP 125), and returns them with their descendants. The replacement:
+//   - doesn't spend the outputs of the transactions that it replaces;
+//   - spends only the unconfirmed outputs that the replaced transactions spend;
+//   - replaces at most 100 transactions;
+//   - has a higher fee rate than each conflicting transaction;
+//   - pays a higher fee than all replaced transactions, by at least the incremental relay fee for its size.
+func (m *Mempool) checkReplacement(desc *TxDesc, conflicts, ancestors map[[32]byte]*TxDesc) (map[[32]byte]*TxDesc, error) {
+	conflictParents := make(map[[32]byte]struct{})
+	for _, c := range conflicts {
+		for _, in := range c.Tx.TxIn {
+			conflictParents[in.PreviousOutput.Hash] = struct{}{}
+		}
+	}
+	for id := range desc.parents {
+		if _, ok := conflictParents[id]; !ok {
+			return nil, fmt.Errorf("%w: the replacement spends the new unconfirmed transaction %x", ErrConflict, p2p.Reverse(id))
+		}
+	}
+
+	replaced := make(map[[32]byte]*TxDesc)
+	for _, c := range conflicts {
+		for id, d := range m.descendants(c) {
+			replaced[id] = d
+		}
+		if len(replaced) > maxReplacementEvictions {
+			return nil, fmt.Errorf("%w: replaces more than %d transactions", ErrTooManyReplacements, maxReplacementEvictions)
+		}
+	}
+	for id := range ancestors {
+		if _, ok := replaced[id]; ok {
+			return nil, fmt.Errorf("%w: spends the outputs of the replaced transaction %x", ErrConflict, p2p.Reverse(id))
+		}
+	}
+
+	for _, c := range conflicts {
+		if desc.FeeRate() <= c.FeeRate() {
+			return nil, fmt.Errorf("%w: fee rate %d, the replaced transaction %x has %d", ErrInsufficientFee,
+				desc.FeeRate(), p2p.Reverse(c.TxID), c.FeeRate())
+		}
+	}
+
+	var fees int64
+	for _, d := range replaced {
+		fees += d.ModifiedFee()
+	}
+	if minFee := fees + feeAt(incrementalRelayFeeRate, desc.VSize); desc.ModifiedFee() < minFee {
+		return nil, fmt.Errorf("%w: fee %d, the replacement must pay at least %d", ErrInsufficientFee, desc.ModifiedFee(), minFee)
+	}
+	return replaced, nil
+}
+
+// NotifyReplacements subscribes the channel for the replaced transactions. The events are dropped when the
+// channel is full, so the mempool is never blocked by a subscriber.
+func (m *Mempool) NotifyReplacements(ch chan<- Replacement) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replacements = append(m.replacements, ch)
+}
+
+func (m *Mempool) notifyReplacement(replaced map[[32]byte]*TxDesc, by *TxDesc) {
+	event := Replacement{By: *by}
+	for _, d := range replaced {
+		event.Replaced = append(event.Replaced, *d)
+	}
+	slices.SortFunc(event.Replaced, func(a, b TxDesc) int { return a.AncestorCount - b.AncestorCount })
+	log.Printf("transaction %x replaced %d transactions of the mempool\n", p2p.Reverse(by.TxID), len(replaced))
+
+	for _, ch := range m.replacements {
+		select {
+		case ch <- event:
+		default:
+			log.Println("drop the replacement event, the subscriber is full")
+		}
+	}
+}
+
+// checkPackageLimits checks that the transaction with its ancestors and every ancestor with its descendants
+// are within the limits of the packages.
+func checkPackageLimits(desc *TxDesc, ancestors map[[32]byte]*TxDesc) error {
+	if len(ancestors)+1 > maxAncestors {
+		return fmt.Errorf("%w: %d ancestors", ErrTooLongChain, len(ancestors))
+	}
+
+	size := desc.VSize
+	for _, a := range ancestors {
+		size += a.VSize
+		if a.DescendantCount+1 > maxDescendants {
+			return fmt.Errorf("%w: %x has %d descendants", ErrTooLongChain, p2p.Reverse(a.TxID), a.DescendantCount)
+		}
+		if a.DescendantSize+desc.VSize > maxDescendantSize {
+			return fmt.Errorf("%w: the descendants of %x are %d vbytes", ErrTooLongChain, p2p.Reverse(a.TxID), a.DescendantSize)
+		}
+	}
+	if size > maxAncestorSize {
+		return fmt.Errorf("%w: the ancestors are %d vbytes", ErrTooLongChain, size)
+	}
+	return nil
+}
+
+// add adds the transaction to the mempool and to the descendant packages of its ancestors.
+func (m *Mempool) add(desc *TxDesc, ancestors map[[32]byte]*TxDesc) {
+	desc.AncestorCount, desc.AncestorSize, desc.AncestorFees = 1, desc.VSize, desc.ModifiedFee()
+	desc.DescendantCount, desc.DescendantSize, desc.DescendantFees = 1, desc.VSize, desc.ModifiedFee()
+	for _, a := range ancestors {
+		desc.AncestorCount++
+		desc.AncestorSize += a.VSize
+		desc.AncestorFees += a.ModifiedFee()
+
+		a.DescendantCount++
+		a.DescendantSize += desc.VSize
+		a.DescendantFees += desc.ModifiedFee()
+		m.evictions.fix(a)
+	}
+	for _, p := range desc.parents {
+		p.children[desc.TxID] = desc
+	}
+
+	m.txs[desc.TxID] = desc
+	m.wtxids[desc.WTxID] = desc
+	for _, in := range desc.Tx.TxIn {
+		m.spent[in.PreviousOutput] = desc
+	}
+	m.size += desc.VSize
+	m.evictions.add(desc)
+}
+
+// ancestors returns the transactions in the mempool that the transactions with the given parents spend from.
+func (m *Mempool) ancestors(parents map[[32]byte]*TxDesc) map[[32]byte]*TxDesc {
+	ancestors := make(map[[32]byte]*TxDesc)
+	queue := make([]*TxDesc, 0, len(parents))
+	for _, p := range parents {
+		queue = append(queue, p)
+	}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if _, ok := ancestors[d.TxID]; ok {
+			continue
+		}
+		ancestors[d.TxID] = d
+		for _, p := range d.parents {
+			queue = append(queue, p)
+		}
+	}
+	return ancestors
+}
+
+// descendants returns the transaction with the transactions in the mempool that spend from it.
+func (m *Mempool) descendants(desc *TxDesc) map[[32]byte]*TxDesc {
+	descendants := make(map[[32]byte]*TxDesc)
+	queue := []*TxDesc{desc}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if _, ok := descendants[d.TxID]; ok {
+			continue
+		}
+		descendants[d.TxID] = d
+		for _, c := range d.children {
+			queue = append(queue, c)
+		}
+	}
+	return descendants
+}
+
+// remove removes the transactions from the mempool. The packages of their ancestors and descendants that
+// stay in the mempool are updated, so a transaction can be removed without its descendants when it is
+// confirmed.
+func (m *Mempool) remove(txs map[[32]byte]*TxDesc) {
+	for _, d := range txs {
+		for id, a := range m.ancestors(d.parents) {
+			if _, ok := txs[id]; !ok {
+				a.DescendantCount--
+				a.DescendantSize -= d.VSize
+				a.DescendantFees -= d.ModifiedFee()
+				m.evictions.fix(a)
+			}
+		}
+		for id, c := range m.descendants(d) {
+			if _, ok := txs[id]; !ok {
+				c.AncestorCount--
+				c.AncestorSize -= d.VSize
+				c.AncestorFees -= d.ModifiedFee()
+			}
+		}
+	}
+
+	for _, d := range txs {
+		for _, p := range d.parents {
+			delete(p.children, d.TxID)
+		}
+		for _, c := range d.children {
+			delete(c.parents, d.TxID)
+		}
+		for _, in := range d.Tx.TxIn {
+			if m.spent[in.PreviousOutput] == d {
+				delete(m.spent, in.PreviousOutput)
+			}
+		}
+		delete(m.txs, d.TxID)
+		delete(m.wtxids, d.WTxID)
+		m.size -= d.VSize
+		m.evictions.remove(d)
+		if m.feeEstimator != nil {
+			m.feeEstimator.RemoveTx(d.TxID)
+		}
+	}
+}
+
+// trimToSize evicts the transactions with the lowest descendant fee rate with their descendants until the
+// mempool is below its max size. The minimum fee rate of the mempool is raised above the evicted fee rate.
+func (m *Mempool) trimToSize(now time.Time) {
+	for m.size > m.maxSize {
+		worst := m.evictions.worst()
+		rate := float64(worst.DescendantFeeRate() + incrementalRelayFeeRate)
+		if rate > m.rollingMinFeeRate {
+			m.rollingMinFeeRate = rate
+		}
+		m.lastRollingUpdate = now
+		evicted := m.descendants(worst)
+		m.remove(evicted)
+		log.Printf("the mempool is full, evicted %d transactions with fee rate %d sat/kvB\n", len(evicted), worst.DescendantFeeRate())
+	}
+}
+
+// MinFeeRate returns the minimum fee rate of the accepted transactions in satoshis per 1000 virtual bytes.
+// It is the min relay fee rate, unless it is raised by the eviction of transactions when the mempool is full.
+func (m *Mempool) MinFeeRate() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.minFeeRate(time.Now())
+}
+
+func (m *Mempool) minFeeRate(now time.Time) int64 {
+	if m.rollingMinFeeRate == 0 {
+		return m.minRelayFeeRate
+	}
+
+	halves := float64(now.Sub(m.lastRollingUpdate)) / float64(rollingFeeHalfLife)
+	m.rollingMinFeeRate /= math.Pow(2, halves)
+	m.lastRollingUpdate = now
+	if m.rollingMinFeeRate < incrementalRelayFeeRate/2 {
+		m.rollingMinFeeRate = 0
+	}
+	return max(m.minRelayFeeRate, int64(math.Round(m.rollingMinFeeRate)))
+}
+
+// Get returns the transaction with the given txid.
+func (m *Mempool) Get(txid [32]byte) (TxDesc, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.txs[txid]
+	if !ok {
+		return TxDesc{}, false
+	}
+	return *d, true
+}
+
+// GetByWTxID returns the transaction with the given wtxid.
+func (m *Mempool) GetByWTxID(wtxid [32]byte) (TxDesc, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.wtxids[wtxid]
+	if !ok {
+		return TxDesc{}, false
+	}
+	return *d, true
+}
+
+// Count returns the number of the transactions in the mempool.
+func (m *Mempool) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.txs)
+}
+
+// Size returns the total virtual size of the transactions in the mempool.
+func (m *Mempool) Size() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// TxsByFeeRate returns the transactions in the mempool ordered by their ancestor fee rate, starting from the
+// highest one. The transactions with the same fee rate are ordered by the time they are added.
+func (m *Mempool) TxsByFeeRate() []TxDesc {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	txs := make([]TxDesc, 0, len(m.txs))
+	for _, d := range m.txs {
+		txs = append(txs, *d)
+	}
+	slices.SortFunc(txs, func(a, b TxDesc) int {
+		if c := b.AncestorFeeRate() - a.AncestorFeeRate(); c != 0 {
+			return int(c)
+		}
+		return a.Added.Compare(b.Added)
+	})
+	return txs
+}
+
+// ConnectBlock removes the transactions that are confirmed by the block and the transactions that spend the
+// same outputs as them, with their descendants.
+func (m *Mempool) ConnectBlock(block p2p.MsgBlock) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the confirmations are recorded before the transactions are removed
+	if m.feeEstimator != nil {
+		_, height, err := m.utxoSet.BestBlock()
+		if err != nil {
+			return err
+		}
+		txids := make([][32]byte, 0, len(block.Transactions))
+		for _, tx := range block.Transactions {
+			txids = append(txids, tx.TxHash())
+		}
+		m.feeEstimator.ProcessBlock(height, txids)
+	}
+
+	m.orphans.removeForBlock(block)
+	var confirmed, conflicts int
+	for _, tx := range block.Transactions {
+		txid := tx.TxHash()
+		delete(m.deltas, txid)
+		if d, ok := m.txs[txid]; ok {
+			m.remove(map[[32]byte]*TxDesc{txid: d})
+			confirmed++
+		}
+		if tx.IsCoinBase() {
+			continue
+		}
+		for _, in := range tx.TxIn {
+			if d, ok := m.spent[in.PreviousOutput]; ok {
+				descendants := m.descendants(d)
+				m.remove(descendants)
+				conflicts += len(descendants)
+			}
+		}
+	}
+	if confirmed > 0 || conflicts > 0 {
+		log.Printf("block %x confirmed %d transactions of the mempool and removed %d conflicting ones\n",
+			p2p.Reverse(block.GetHash()), confirmed, conflicts)
+	}
+	return nil
+}
+
+// DisconnectBlock adds back the transactions of the block that is disconnected from the UTXO set. The
+// transactions in the mempool that spend their outputs are added again after them.
+func (m *Mempool) DisconnectBlock(block p2p.MsgBlock) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spenders := make(map[[32]byte]*TxDesc)
+	for _, tx := range block.Transactions {
+		txid := tx.TxHash()
+		for i := range tx.TxOut {
+			if d, ok := m.spent[p2p.OutPoint{Hash: txid, Index: uint32(i)}]; ok {
+				for id, desc := range m.descendants(d) {
+					spenders[id] = desc
+				}
+			}
+		}
+	}
+	m.remove(spenders)
+
+	// the parents are added before their children
+	readded := make([]*TxDesc, 0, len(spenders))
+	for _, d := range spenders {
+		readded = append(readded, d)
+	}
+	slices.SortFunc(readded, func(a, b *TxDesc) int { return a.AncestorCount - b.AncestorCount })
+
+	for _, tx := range block.Transactions {
+		if tx.IsCoinBase() {
+			continue
+		}
+		if _, err := m.acceptTx(tx, 0); err != nil {
+			log.Printf("transaction %x of the disconnected block is not added to the mempool: %s\n", p2p.Reverse(tx.TxHash()), err)
+		}
+	}
+	for _, d := range readded {
+		if _, err := m.acceptTx(d.Tx, 0); err != nil {
+			log.Printf("transaction %x is removed from the mempool: %s\n", p2p.Reverse(d.TxID), err)
+		}
+	}
+	return nil
+}
+
+// BestBlock returns the best block of the UTXO set, the transactions in the mempool are valid after it.
+func (m *Mempool) BestBlock() ([32]byte, int32, error) {
+	return m.utxoSet.BestBlock()
+}
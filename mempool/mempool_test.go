@@ -0,0 +1,478 @@
+package mempool_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/script"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// txVSize is the virtual size of a transaction with one input and one output that is created by newTx.
+const txVSize = 85
+
+// trueScriptHash is the HASH160 of the OP_TRUE script, the P2SH outputs of the transactions that are created by
+// newTx can be spent without a signature.
+var trueScriptHash, _ = hex.DecodeString("da1745e9b549bd0bfa1a569971c77eba30cd5a4b")
+
+func TestMempool_AcceptTx(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	tx := newTx([]p2p.OutPoint{utxo}, 90_000)
+	desc, err := pool.AcceptTx(tx)
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000), desc.Fee)
+	require.Equal(t, int64(txVSize), desc.VSize)
+	require.Equal(t, int32(200), desc.Height)
+	require.Equal(t, int64(10_000*1000/txVSize), desc.FeeRate())
+
+	require.Equal(t, 1, pool.Count())
+	require.Equal(t, int64(txVSize), pool.Size())
+	actual, ok := pool.Get(tx.TxHash())
+	require.True(t, ok)
+	require.Equal(t, tx, actual.Tx)
+	_, ok = pool.GetByWTxID(tx.WTxHash())
+	require.True(t, ok)
+
+	_, err = pool.AcceptTx(tx)
+	require.ErrorIs(t, err, mempool.ErrAlreadyHave)
+}
+
+func TestMempool_AcceptTxRejectsInvalidTransactions(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	coinbase := p2p.OutPoint{Hash: [32]byte{2}}
+	inPool := p2p.OutPoint{Hash: [32]byte{3}}
+	locked := p2p.OutPoint{Hash: [32]byte{4}}
+	utxos := map[p2p.OutPoint]db.UTXO{
+		utxo:     {Value: 100_000},
+		coinbase: {Value: 100_000, Height: 150, Coinbase: true},
+		inPool:   {Value: 100_000},
+		// the P2SH output of another script
+		locked: {Value: 100_000, PkScript: append(append([]byte{0xa9, 0x14}, make([]byte, 20)...), 0x87)},
+	}
+
+	nonFinal := newTx([]p2p.OutPoint{utxo}, 90_000)
+	nonFinal.LockTime = 300
+	nonFinal.TxIn[0].Sequence = 0
+	twoInputs := newTx([]p2p.OutPoint{utxo, utxo}, 90_000)
+	nonStandard := newTx([]p2p.OutPoint{utxo}, 90_000)
+	nonStandard.TxOut[0].PkScript = []byte{0x51, 0x51}
+
+	tests := []struct {
+		name   string
+		tx     p2p.MsgTx
+		expErr error
+	}{
+		{name: "missing inputs", tx: newTx([]p2p.OutPoint{{Hash: [32]byte{9}}}, 90_000), expErr: mempool.ErrMissingInputs},
+		{name: "immature coinbase", tx: newTx([]p2p.OutPoint{coinbase}, 90_000), expErr: mempool.ErrImmatureSpend},
+		{name: "spends more than the inputs", tx: newTx([]p2p.OutPoint{utxo}, 100_001), expErr: mempool.ErrInvalidTx},
+		{name: "duplicate inputs", tx: twoInputs, expErr: mempool.ErrInvalidTx},
+		{name: "fee below the min relay fee", tx: newTx([]p2p.OutPoint{utxo}, 100_000-txVSize+1), expErr: mempool.ErrInsufficientFee},
+		{name: "dust output", tx: newTx([]p2p.OutPoint{utxo}, 90_000, 100), expErr: mempool.ErrNonStandard},
+		{name: "non-standard output script", tx: nonStandard, expErr: mempool.ErrNonStandard},
+		{name: "lock time after the next block", tx: nonFinal, expErr: mempool.ErrNonFinal},
+		{name: "conflict", tx: newTx([]p2p.OutPoint{inPool}, 80_000), expErr: mempool.ErrConflict},
+		{name: "input script fails", tx: newTx([]p2p.OutPoint{locked}, 90_000), expErr: script.ErrScriptFailed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			pool := mempool.New(newUTXOSet(tt, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+			_, err := pool.AcceptTx(newTx([]p2p.OutPoint{inPool}, 90_000))
+			require.NoError(tt, err)
+
+			_, err = pool.AcceptTx(test.tx)
+			require.ErrorIs(tt, err, test.expErr)
+			require.Equal(tt, 1, pool.Count())
+		})
+	}
+}
+
+func TestMempool_SubmitTxRejectsAnAbsurdFee(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	tx := newTx([]p2p.OutPoint{utxo}, 1_000_000)
+	_, err := pool.SubmitTx(tx, 10_000_000)
+	require.ErrorIs(t, err, mempool.ErrAbsurdFee)
+
+	_, err = pool.SubmitTx(tx, 0)
+	require.NoError(t, err)
+}
+
+func TestMempool_TracksTheAncestorAndDescendantPackages(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	parent := newTx([]p2p.OutPoint{utxo}, 99_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 97_000)
+	grandchild := newTx([]p2p.OutPoint{{Hash: child.TxHash()}}, 94_000)
+	for _, tx := range []p2p.MsgTx{parent, child, grandchild} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+
+	p, _ := pool.Get(parent.TxHash())
+	require.Equal(t, 1, p.AncestorCount)
+	require.Equal(t, 3, p.DescendantCount)
+	require.Equal(t, int64(6_000), p.DescendantFees)
+	require.Equal(t, int64(3*txVSize), p.DescendantSize)
+
+	c, _ := pool.Get(child.TxHash())
+	require.Equal(t, 2, c.AncestorCount)
+	require.Equal(t, int64(3_000), c.AncestorFees)
+	require.Equal(t, 2, c.DescendantCount)
+	require.Equal(t, int64(5_000), c.DescendantFees)
+
+	g, _ := pool.Get(grandchild.TxHash())
+	require.Equal(t, 3, g.AncestorCount)
+	require.Equal(t, int64(6_000), g.AncestorFees)
+	require.Equal(t, int64(6_000*1000/(3*txVSize)), g.AncestorFeeRate())
+}
+
+func TestMempool_RejectsTooLongChains(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 1_000_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	prev, value := utxo, int64(1_000_000)
+	for i := 0; i < 25; i++ {
+		value -= 1000
+		tx := newTx([]p2p.OutPoint{prev}, value)
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+		prev = p2p.OutPoint{Hash: tx.TxHash()}
+	}
+
+	_, err := pool.AcceptTx(newTx([]p2p.OutPoint{prev}, value-1000))
+	require.ErrorIs(t, err, mempool.ErrTooLongChain)
+	require.Equal(t, 25, pool.Count())
+}
+
+func TestMempool_TxsByFeeRate(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{{Hash: [32]byte{1}}: {Value: 100_000}, {Hash: [32]byte{2}}: {Value: 100_000}}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	low := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	high := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 95_000)
+	// the child pays for its parent, their fee rate together is between the two
+	child := newTx([]p2p.OutPoint{{Hash: low.TxHash()}}, 96_000)
+	for _, tx := range []p2p.MsgTx{low, high, child} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+
+	var actual [][32]byte
+	for _, d := range pool.TxsByFeeRate() {
+		actual = append(actual, d.TxID)
+	}
+	require.Equal(t, [][32]byte{high.TxHash(), child.TxHash(), low.TxHash()}, actual)
+}
+
+func TestMempool_EvictsTheLowestDescendantFeeRateWhenItIsFull(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{}
+	for i := byte(1); i <= 4; i++ {
+		utxos[p2p.OutPoint{Hash: [32]byte{i}}] = db.UTXO{Value: 100_000}
+	}
+	pool := mempool.New(newUTXOSet(t, utxos), 2*txVSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	low := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	high := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 95_000)
+	middle := newTx([]p2p.OutPoint{{Hash: [32]byte{3}}}, 97_000)
+	for _, tx := range []p2p.MsgTx{low, high, middle} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, pool.Count())
+	_, ok := pool.Get(low.TxHash())
+	require.False(t, ok)
+	require.Equal(t, int64(1000*1000/txVSize+1000), pool.MinFeeRate())
+
+	// the fee rate of the evicted transaction is not enough anymore
+	_, err := pool.AcceptTx(newTx([]p2p.OutPoint{{Hash: [32]byte{4}}}, 99_000))
+	require.ErrorIs(t, err, mempool.ErrInsufficientFee)
+}
+
+func TestMempool_EvictsByTheModifiedDescendantFeeRate(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{}
+	for i := byte(1); i <= 3; i++ {
+		utxos[p2p.OutPoint{Hash: [32]byte{i}}] = db.UTXO{Value: 100_000}
+	}
+	pool := mempool.New(newUTXOSet(t, utxos), 2*txVSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	low := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	middle := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 97_000)
+	for _, tx := range []p2p.MsgTx{low, middle} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+	pool.PrioritiseTransaction(low.TxHash(), 5000)
+
+	_, err := pool.AcceptTx(newTx([]p2p.OutPoint{{Hash: [32]byte{3}}}, 95_000))
+	require.NoError(t, err)
+	_, ok := pool.Get(low.TxHash())
+	require.True(t, ok)
+	_, ok = pool.Get(middle.TxHash())
+	require.False(t, ok)
+}
+
+func TestMempool_PrioritiseTransaction(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{{Hash: [32]byte{1}}: {Value: 100_000}, {Hash: [32]byte{2}}: {Value: 100_000}}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	parent := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 98_000)
+	for _, tx := range []p2p.MsgTx{parent, child} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+	pool.PrioritiseTransaction(parent.TxHash(), 4_000)
+
+	p, _ := pool.Get(parent.TxHash())
+	require.Equal(t, int64(1_000), p.Fee)
+	require.Equal(t, int64(5_000), p.ModifiedFee())
+	require.Equal(t, int64(6_000), p.DescendantFees)
+	c, _ := pool.Get(child.TxHash())
+	require.Equal(t, int64(6_000), c.AncestorFees)
+
+	// the delta of a transaction that is not in the mempool is applied when it is added
+	zeroFee := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 100_000)
+	_, err := pool.AcceptTx(zeroFee)
+	require.ErrorIs(t, err, mempool.ErrInsufficientFee)
+	pool.PrioritiseTransaction(zeroFee.TxHash(), 1_000)
+	desc, err := pool.AcceptTx(zeroFee)
+	require.NoError(t, err)
+	require.Equal(t, int64(1_000), desc.ModifiedFee())
+}
+
+func TestMempool_ConnectBlockRemovesTheConfirmedAndTheConflictingTransactions(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{{Hash: [32]byte{1}}: {Value: 100_000}, {Hash: [32]byte{2}}: {Value: 100_000}}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	parent := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 98_000)
+	conflict := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 99_000)
+	conflictChild := newTx([]p2p.OutPoint{{Hash: conflict.TxHash()}}, 98_000)
+	for _, tx := range []p2p.MsgTx{parent, child, conflict, conflictChild} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+
+	// the block confirms the parent and another transaction that spends the same output as the conflict
+	block := testutil.NewMsgBlockWithTxs([32]byte{}, parent, newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 90_000))
+	require.NoError(t, pool.ConnectBlock(block))
+
+	require.Equal(t, 1, pool.Count())
+	c, ok := pool.Get(child.TxHash())
+	require.True(t, ok)
+	require.Equal(t, 1, c.AncestorCount)
+	require.Equal(t, int64(1000), c.AncestorFees)
+	require.Equal(t, int64(txVSize), pool.Size())
+}
+
+func TestMempool_DisconnectBlockAddsBackItsTransactions(t *testing.T) {
+	confirmed := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	utxos := map[p2p.OutPoint]db.UTXO{{Hash: confirmed.TxHash()}: {Value: 99_000, Height: 200}}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	child := newTx([]p2p.OutPoint{{Hash: confirmed.TxHash()}}, 98_000)
+	_, err := pool.AcceptTx(child)
+	require.NoError(t, err)
+
+	// the block is disconnected from the UTXO set before the mempool
+	delete(utxos, p2p.OutPoint{Hash: confirmed.TxHash()})
+	utxos[p2p.OutPoint{Hash: [32]byte{1}}] = db.UTXO{Value: 100_000}
+	require.NoError(t, pool.DisconnectBlock(testutil.NewMsgBlockWithTxs([32]byte{}, confirmed)))
+
+	require.Equal(t, 2, pool.Count())
+	c, _ := pool.Get(child.TxHash())
+	require.Equal(t, 2, c.AncestorCount)
+	p, _ := pool.Get(confirmed.TxHash())
+	require.Equal(t, 2, p.DescendantCount)
+}
+
+func TestMempool_ReplaceTheTransactionsThatSignalTheReplacement(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+	replacements := make(chan mempool.Replacement, 1)
+	pool.NotifyReplacements(replacements)
+
+	original := newTx([]p2p.OutPoint{utxo}, 99_000)
+	original.TxIn[0].Sequence = 0xfffffffd
+	child := newTx([]p2p.OutPoint{{Hash: original.TxHash()}}, 98_000)
+	for _, tx := range []p2p.MsgTx{original, child} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+
+	// the replacement must pay the fees of the original and its child and the incremental relay fee
+	_, err := pool.AcceptTx(newTx([]p2p.OutPoint{utxo}, 97_950))
+	require.ErrorIs(t, err, mempool.ErrInsufficientFee)
+
+	replacement := newTx([]p2p.OutPoint{utxo}, 97_900)
+	desc, err := pool.AcceptTx(replacement)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, pool.Count())
+	_, ok := pool.Get(replacement.TxHash())
+	require.True(t, ok)
+	event := <-replacements
+	require.Equal(t, desc, event.By)
+	require.Len(t, event.Replaced, 2)
+	require.Equal(t, original.TxHash(), event.Replaced[0].TxID)
+	require.Equal(t, child.TxHash(), event.Replaced[1].TxID)
+}
+
+func TestMempool_KeepTheReplacedTransactionsWhenTheReplacementIsEvicted(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{}
+	for i := byte(1); i <= 3; i++ {
+		utxos[p2p.OutPoint{Hash: [32]byte{i}}] = db.UTXO{Value: 100_000}
+	}
+	pool := mempool.New(newUTXOSet(t, utxos), 2*txVSize, mempool.DefaultMinRelayFeeRate, false, nil)
+	replacements := make(chan mempool.Replacement, 1)
+	pool.NotifyReplacements(replacements)
+
+	original := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	original.TxIn[0].Sequence = 0xfffffffd
+	high := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 90_000)
+	for _, tx := range []p2p.MsgTx{original, high} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+
+	// the replacement pays more than the original, but it is bigger and has the lowest fee rate in the full mempool
+	replacement := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}, {Hash: [32]byte{3}}}, 197_000)
+	_, err := pool.AcceptTx(replacement)
+	require.ErrorIs(t, err, mempool.ErrMempoolFull)
+
+	require.Equal(t, 2, pool.Count())
+	require.Equal(t, int64(2*txVSize), pool.Size())
+	_, ok := pool.Get(original.TxHash())
+	require.True(t, ok)
+	_, ok = pool.Get(replacement.TxHash())
+	require.False(t, ok)
+	require.Empty(t, replacements)
+}
+
+func TestMempool_ReplaceTheTransactionsThatDontSignalTheReplacementWithFullRBF(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	for _, fullRBF := range []bool{false, true} {
+		pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, fullRBF, nil)
+		_, err := pool.AcceptTx(newTx([]p2p.OutPoint{utxo}, 99_000))
+		require.NoError(t, err)
+
+		_, err = pool.AcceptTx(newTx([]p2p.OutPoint{utxo}, 95_000))
+		if fullRBF {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, mempool.ErrConflict)
+		}
+	}
+}
+
+func TestMempool_RejectTheReplacementsThatBreakTheRules(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{}
+	var inputs []p2p.OutPoint
+	for i := byte(1); i <= 7; i++ {
+		op := p2p.OutPoint{Hash: [32]byte{i}}
+		utxos[op] = db.UTXO{Value: 100_000}
+		inputs = append(inputs, op)
+	}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, true, nil)
+
+	// 5 chains of 21 transactions
+	for _, op := range inputs[:5] {
+		for value := int64(99_000); value >= 79_000; value -= 1000 {
+			tx := newTx([]p2p.OutPoint{op}, value)
+			_, err := pool.AcceptTx(tx)
+			require.NoError(t, err)
+			op = p2p.OutPoint{Hash: tx.TxHash()}
+		}
+	}
+	unconfirmed := newTx([]p2p.OutPoint{inputs[5]}, 99_000)
+	_, err := pool.AcceptTx(unconfirmed)
+	require.NoError(t, err)
+
+	_, err = pool.AcceptTx(newTx(inputs[:5], 100_000))
+	require.ErrorIs(t, err, mempool.ErrTooManyReplacements)
+
+	// the replacement can't spend an unconfirmed output that the replaced transaction doesn't spend
+	_, err = pool.AcceptTx(newTx([]p2p.OutPoint{inputs[0], {Hash: unconfirmed.TxHash()}}, 100_000))
+	require.ErrorIs(t, err, mempool.ErrConflict)
+
+	// the fee is higher, but the fee rate must be higher than the fee rate of the replaced transaction too
+	_, err = pool.AcceptTx(newTx([]p2p.OutPoint{inputs[5], inputs[6]}, 198_700))
+	require.ErrorIs(t, err, mempool.ErrInsufficientFee)
+	require.ErrorContains(t, err, "fee rate")
+}
+
+func TestMempool_EstimateSmartFeeFromTheConfirmedTransactions(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{}
+	height := int32(200)
+	pool := mempool.New(newUTXOSetAt(t, utxos, &height), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, mempool.NewFeeEstimator())
+
+	// every block confirms the 5 transactions that are added after the previous one
+	var pending []p2p.MsgTx
+	for i := 0; i < 30; i++ {
+		height++
+		require.NoError(t, pool.ConnectBlock(testutil.NewMsgBlockWithTxs([32]byte{}, pending...)))
+		pending = nil
+		for j := 0; j < 5; j++ {
+			op := p2p.OutPoint{Hash: [32]byte{byte(i), byte(j), 1}}
+			utxos[op] = db.UTXO{Value: 100_000}
+			tx := newTx([]p2p.OutPoint{op}, 99_000)
+			_, err := pool.AcceptTx(tx)
+			require.NoError(t, err)
+			pending = append(pending, tx)
+		}
+	}
+
+	estimate, err := pool.EstimateSmartFee(2, mempool.EstimateConservative)
+	require.NoError(t, err)
+	require.Equal(t, mempool.FeeEstimate{FeeRate: 1000 * 1000 / txVSize, Blocks: 2}, estimate)
+}
+
+// newTx returns a transaction that spends the outpoints and has a P2SH output of OP_TRUE for every value. Its
+// inputs push the OP_TRUE script, so they spend these outputs and the outputs with an empty script.
+func newTx(inputs []p2p.OutPoint, values ...int64) p2p.MsgTx {
+	tx := testutil.NewMsgTx(inputs, values...)
+	for i := range tx.TxIn {
+		tx.TxIn[i].SignatureScript = []byte{0x01, 0x51}
+		tx.TxIn[i].ScriptLength = 2
+	}
+	for i := range tx.TxOut {
+		tx.TxOut[i].PkScript = append(append([]byte{0xa9, 0x14}, trueScriptHash...), 0x87)
+		tx.TxOut[i].PkScriptLength = 23
+	}
+	return tx
+}
+
+// newUTXOSet returns a UTXO set with the given outputs whose best block is at height 200.
+func newUTXOSet(t *testing.T, utxos map[p2p.OutPoint]db.UTXO) *mempool.MockUTXOSet {
+	height := int32(200)
+	return newUTXOSetAt(t, utxos, &height)
+}
+
+// newUTXOSetAt returns a UTXO set with the given outputs whose best block is at the height that the
+// pointer points to.
+func newUTXOSetAt(t *testing.T, utxos map[p2p.OutPoint]db.UTXO, height *int32) *mempool.MockUTXOSet {
+	utxoSet := mempool.NewMockUTXOSet(gomock.NewController(t))
+	utxoSet.EXPECT().BestBlock().DoAndReturn(func() ([32]byte, int32, error) {
+		return [32]byte{}, *height, nil
+	}).AnyTimes()
+	utxoSet.EXPECT().Get(gomock.Any()).DoAndReturn(func(op p2p.OutPoint) (db.UTXO, error) {
+		u, ok := utxos[op]
+		if !ok {
+			return db.UTXO{}, sync.ErrNotFound
+		}
+		return u, nil
+	}).AnyTimes()
+	return utxoSet
+}
@@ -0,0 +1,3 @@
+package mempool
+
+//go:generate mockgen -source=interfaces.go -destination=mocks_mempool_test.go -package=$GOPACKAGE
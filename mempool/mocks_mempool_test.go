@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package mempool is a generated GoMock package.
+package mempool
+
+import (
+	reflect "reflect"
+
+	db "github.com/EmilGeorgiev/btc-node/db"
+	p2p "github.com/EmilGeorgiev/btc-node/network/p2p"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUTXOSet is a mock of UTXOSet interface.
+type MockUTXOSet struct {
+	ctrl     *gomock.Controller
+	recorder *MockUTXOSetMockRecorder
+}
+
+// MockUTXOSetMockRecorder is the mock recorder for MockUTXOSet.
+type MockUTXOSetMockRecorder struct {
+	mock *MockUTXOSet
+}
+
+// NewMockUTXOSet creates a new mock instance.
+func NewMockUTXOSet(ctrl *gomock.Controller) *MockUTXOSet {
+	mock := &MockUTXOSet{ctrl: ctrl}
+	mock.recorder = &MockUTXOSetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUTXOSet) EXPECT() *MockUTXOSetMockRecorder {
+	return m.recorder
+}
+
+// BestBlock mocks base method.
+func (m *MockUTXOSet) BestBlock() ([32]byte, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlock")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestBlock indicates an expected call of BestBlock.
+func (mr *MockUTXOSetMockRecorder) BestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlock", reflect.TypeOf((*MockUTXOSet)(nil).BestBlock))
+}
+
+// Get mocks base method.
+func (m *MockUTXOSet) Get(op p2p.OutPoint) (db.UTXO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", op)
+	ret0, _ := ret[0].(db.UTXO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUTXOSetMockRecorder) Get(op interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUTXOSet)(nil).Get), op)
+}
@@ -0,0 +1,188 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+const (
+	// maxOrphanTxs is the maximum number of orphan transactions that are kept.
+	maxOrphanTxs = 100
+	// maxOrphanTxSize is the maximum virtual size of an orphan transaction.
+	maxOrphanTxSize = 100_000
+	// orphanTTL is the time after which an orphan transaction whose parents are not received expires.
+	orphanTTL = 20 * time.Minute
+	// orphanExpireInterval is the interval at which the expired orphan transactions are removed.
+	orphanExpireInterval = 5 * time.Minute
+)
+
+// ErrOrphanTooLarge is returned for an orphan transaction that is too large to be kept until its parents are
+// received.
+var ErrOrphanTooLarge = errors.New("orphan transaction too large")
+
+// orphan is a transaction whose parents are unknown, with the peer from which it is received.
+type orphan struct {
+	tx      p2p.MsgTx
+	txid    [32]byte
+	wtxid   [32]byte
+	peer    string
+	expires time.Time
+}
+
+// orphanPool keeps the transactions whose parents are not received yet, so they can be added to the mempool
+// when their parents arrive. The number of orphans is bounded, when it is full an orphan of the peer with
+// the most orphans is evicted, so a peer can't push out the orphans of the others. The orphans expire after
+// 20 minutes and are removed when their peer disconnects.
+type orphanPool struct {
+	orphans map[[32]byte]*orphan
+	wtxids  map[[32]byte]*orphan
+	// spends maps the outputs that the orphans spend to them, so the orphans of a parent are found
+	spends map[p2p.OutPoint]map[[32]byte]*orphan
+	// peers maps the peers to the orphans that are received from them
+	peers      map[string]map[[32]byte]*orphan
+	nextExpire time.Time
+}
+
+func newOrphanPool() *orphanPool {
+	return &orphanPool{
+		orphans: make(map[[32]byte]*orphan),
+		wtxids:  make(map[[32]byte]*orphan),
+		spends:  make(map[p2p.OutPoint]map[[32]byte]*orphan),
+		peers:   make(map[string]map[[32]byte]*orphan),
+	}
+}
+
+// has returns true when the orphan with the txid or wtxid is in the pool.
+func (op *orphanPool) has(hash [32]byte) bool {
+	_, ok := op.orphans[hash]
+	if !ok {
+		_, ok = op.wtxids[hash]
+	}
+	return ok
+}
+
+// add adds the orphan transaction that is received from the peer to the pool.
+func (op *orphanPool) add(tx p2p.MsgTx, peer string, now time.Time) error {
+	txid := tx.TxHash()
+	if _, ok := op.orphans[txid]; ok {
+		return nil
+	}
+	if size := tx.VSize(); size > maxOrphanTxSize {
+		return fmt.Errorf("%w: %d vbytes", ErrOrphanTooLarge, size)
+	}
+
+	op.expire(now)
+	for len(op.orphans) >= maxOrphanTxs {
+		op.evict()
+	}
+
+	o := &orphan{tx: tx, txid: txid, wtxid: tx.WTxHash(), peer: peer, expires: now.Add(orphanTTL)}
+	op.orphans[txid] = o
+	op.wtxids[o.wtxid] = o
+	for _, in := range tx.TxIn {
+		if op.spends[in.PreviousOutput] == nil {
+			op.spends[in.PreviousOutput] = make(map[[32]byte]*orphan)
+		}
+		op.spends[in.PreviousOutput][txid] = o
+	}
+	if op.peers[peer] == nil {
+		op.peers[peer] = make(map[[32]byte]*orphan)
+	}
+	op.peers[peer][txid] = o
+	return nil
+}
+
+// remove removes the orphan with the txid from the pool.
+func (op *orphanPool) remove(txid [32]byte) {
+	o, ok := op.orphans[txid]
+	if !ok {
+		return
+	}
+	for _, in := range o.tx.TxIn {
+		delete(op.spends[in.PreviousOutput], txid)
+		if len(op.spends[in.PreviousOutput]) == 0 {
+			delete(op.spends, in.PreviousOutput)
+		}
+	}
+	delete(op.peers[o.peer], txid)
+	if len(op.peers[o.peer]) == 0 {
+		delete(op.peers, o.peer)
+	}
+	delete(op.orphans, txid)
+	delete(op.wtxids, o.wtxid)
+}
+
+// removeForPeer removes the orphans that are received from the peer and returns their number.
+func (op *orphanPool) removeForPeer(peer string) int {
+	orphans := op.peers[peer]
+	for txid := range orphans {
+		op.remove(txid)
+	}
+	return len(orphans)
+}
+
+// removeForBlock removes the orphans that are confirmed by the block and the ones that spend the same
+// outputs as its transactions.
+func (op *orphanPool) removeForBlock(block p2p.MsgBlock) {
+	for _, tx := range block.Transactions {
+		op.remove(tx.TxHash())
+		for _, in := range tx.TxIn {
+			for txid := range op.spends[in.PreviousOutput] {
+				op.remove(txid)
+			}
+		}
+	}
+}
+
+// children returns the orphans that spend the outputs of the transaction.
+func (op *orphanPool) children(tx p2p.MsgTx) []*orphan {
+	txid := tx.TxHash()
+	var children []*orphan
+	seen := make(map[[32]byte]struct{})
+	for i := range tx.TxOut {
+		for id, o := range op.spends[p2p.OutPoint{Hash: txid, Index: uint32(i)}] {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				children = append(children, o)
+			}
+		}
+	}
+	return children
+}
+
+// expire removes the expired orphans, at most once per orphanExpireInterval.
+func (op *orphanPool) expire(now time.Time) {
+	if now.Before(op.nextExpire) {
+		return
+	}
+	op.nextExpire = now.Add(orphanExpireInterval)
+
+	var expired int
+	for txid, o := range op.orphans {
+		if now.After(o.expires) {
+			op.remove(txid)
+			expired++
+		}
+	}
+	if expired > 0 {
+		log.Printf("removed %d expired orphan transactions\n", expired)
+	}
+}
+
+// evict removes an orphan of the peer with the most orphans. The map iteration picks a random one.
+func (op *orphanPool) evict() {
+	var worst map[[32]byte]*orphan
+	for _, orphans := range op.peers {
+		if len(orphans) > len(worst) {
+			worst = orphans
+		}
+	}
+	for txid := range worst {
+		op.remove(txid)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package mempool_test
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempool_ProcessTxResolvesTheOrphansRecursively(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	parent := newTx([]p2p.OutPoint{utxo}, 99_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 98_000)
+	grandchild := newTx([]p2p.OutPoint{{Hash: child.TxHash()}}, 97_000)
+
+	accepted, missing, err := pool.ProcessTx(grandchild, "a")
+	require.NoError(t, err)
+	require.Empty(t, accepted)
+	require.Equal(t, [][32]byte{child.TxHash()}, missing)
+	require.True(t, pool.HaveOrphan(grandchild.WTxHash()))
+	_, _, err = pool.ProcessTx(grandchild, "a")
+	require.ErrorIs(t, err, mempool.ErrAlreadyHave)
+
+	_, missing, err = pool.ProcessTx(child, "a")
+	require.NoError(t, err)
+	require.Equal(t, [][32]byte{parent.TxHash()}, missing)
+
+	// the parent resolves the child, and the child resolves the grandchild
+	accepted, missing, err = pool.ProcessTx(parent, "b")
+	require.NoError(t, err)
+	require.Empty(t, missing)
+	require.Len(t, accepted, 3)
+	require.Equal(t, parent.TxHash(), accepted[0].TxID)
+	require.Equal(t, child.TxHash(), accepted[1].TxID)
+	require.Equal(t, grandchild.TxHash(), accepted[2].TxID)
+	require.Equal(t, 3, pool.Count())
+	require.False(t, pool.HaveOrphan(child.TxHash()))
+	require.False(t, pool.HaveOrphan(grandchild.TxHash()))
+}
+
+func TestMempool_ProcessTxRemovesTheInvalidOrphans(t *testing.T) {
+	utxo := p2p.OutPoint{Hash: [32]byte{1}}
+	pool := mempool.New(newUTXOSet(t, map[p2p.OutPoint]db.UTXO{utxo: {Value: 100_000}}), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	// the orphan spends more than the output of its parent
+	parent := newTx([]p2p.OutPoint{utxo}, 99_000)
+	invalid := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 99_001)
+	_, _, err := pool.ProcessTx(invalid, "a")
+	require.NoError(t, err)
+
+	accepted, _, err := pool.ProcessTx(parent, "a")
+	require.NoError(t, err)
+	require.Len(t, accepted, 1)
+	require.False(t, pool.HaveOrphan(invalid.TxHash()))
+}
+
+func TestMempool_OrphanPoolIsBoundedAndAccountedPerPeer(t *testing.T) {
+	pool := mempool.New(newUTXOSet(t, nil), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+	orphan := func(i int) p2p.MsgTx {
+		return newTx([]p2p.OutPoint{{Hash: [32]byte{byte(i), byte(i >> 8), 1}}}, 1000)
+	}
+
+	for i := range 100 {
+		_, _, err := pool.ProcessTx(orphan(i), "a")
+		require.NoError(t, err)
+	}
+	// the full pool evicts an orphan of the peer with the most orphans
+	for i := 100; i < 102; i++ {
+		_, _, err := pool.ProcessTx(orphan(i), "b")
+		require.NoError(t, err)
+	}
+	var fromA int
+	for i := range 100 {
+		if pool.HaveOrphan(orphan(i).TxHash()) {
+			fromA++
+		}
+	}
+	require.Equal(t, 98, fromA)
+	require.True(t, pool.HaveOrphan(orphan(100).TxHash()))
+	require.True(t, pool.HaveOrphan(orphan(101).TxHash()))
+
+	// the orphans of a disconnected peer are removed
+	pool.RemoveOrphansForPeer("b")
+	require.False(t, pool.HaveOrphan(orphan(100).TxHash()))
+	require.False(t, pool.HaveOrphan(orphan(101).TxHash()))
+
+	// the orphans that are confirmed or that conflict with a block are removed
+	for i := 200; i < 202; i++ {
+		_, _, err := pool.ProcessTx(orphan(i), "c")
+		require.NoError(t, err)
+		require.True(t, pool.HaveOrphan(orphan(i).TxHash()))
+	}
+	conflict := newTx([]p2p.OutPoint{orphan(201).TxIn[0].PreviousOutput}, 500)
+	require.NoError(t, pool.ConnectBlock(testutil.NewMsgBlockWithTxs([32]byte{}, orphan(200), conflict)))
+	require.False(t, pool.HaveOrphan(orphan(200).TxHash()))
+	require.False(t, pool.HaveOrphan(orphan(201).TxHash()))
+}
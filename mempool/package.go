@@ -0,0 +1,176 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+const (
+	// maxPackageCount is the maximum number of transactions in a package.
+	maxPackageCount = 25
+	// maxPackageSize is the maximum total virtual size of the transactions in a package.
+	maxPackageSize = 101_000
+)
+
+// ErrInvalidPackage is returned for a package that is not a child with its parents, sorted topologically.
+var ErrInvalidPackage = errors.New("invalid package")
+
+// AcceptPackage validates the package of a child with its unconfirmed parents and adds it to the mempool.
+// The parents are sorted topologically and the child is the last transaction. Every transaction that pays
+// the minimum fee rate of the mempool is added on its own, the rest of the package is added together when
+// its package fee rate, the total fee divided by the total size, pays it. So a parent below the minimum fee
+// rate is added with the child that pays for it (CPFP). The transactions of the package can't replace the
+// transactions in the mempool. It returns the added transactions with the orphans that are added after them.
+func (m *Mempool) AcceptPackage(txs []p2p.MsgTx) ([]TxDesc, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := checkPackage(txs); err != nil {
+		return nil, err
+	}
+
+	var accepted []TxDesc
+	var deferred []p2p.MsgTx
+	for _, tx := range txs {
+		if _, ok := m.txs[tx.TxHash()]; ok {
+			continue
+		}
+		// the transactions after one that is deferred spend from it or are evaluated with it
+		if len(deferred) == 0 {
+			desc, err := m.acceptTx(tx, 0)
+			if err == nil {
+				m.trackFee(desc)
+				accepted = append(accepted, desc)
+				continue
+			}
+			if !errors.Is(err, ErrInsufficientFee) && !errors.Is(err, ErrMempoolFull) {
+				return accepted, err
+			}
+		}
+		deferred = append(deferred, tx)
+	}
+
+	if len(deferred) > 0 {
+		descs, err := m.acceptPackage(deferred)
+		if err != nil {
+			return accepted, err
+		}
+		accepted = append(accepted, descs...)
+	}
+
+	for _, desc := range accepted {
+		m.orphans.remove(desc.TxID)
+	}
+	for _, desc := range slices.Clone(accepted) {
+		accepted = append(accepted, m.processOrphans(desc.Tx)...)
+	}
+	return accepted, nil
+}
+
+// acceptPackage adds the transactions to the mempool when their package fee rate pays the minimum fee rate
+// of the mempool, otherwise none of them is added. They are not tracked for the fee estimation, because
+// they are not mined at their own fee rate.
+func (m *Mempool) acceptPackage(txs []p2p.MsgTx) ([]TxDesc, error) {
+	added := make(map[[32]byte]*TxDesc)
+	var descs []*TxDesc
+	var fee, size int64
+	for _, tx := range txs {
+		desc, ancestors, replaced, err := m.validateTx(tx, 0, false)
+		if err == nil && len(replaced) > 0 {
+			err = fmt.Errorf("%w: the transactions of a package can't replace transactions", ErrConflict)
+		}
+		if err != nil {
+			m.remove(added)
+			return nil, err
+		}
+		m.add(desc, ancestors)
+		added[desc.TxID] = desc
+		descs = append(descs, desc)
+		fee += desc.ModifiedFee()
+		size += desc.VSize
+	}
+
+	now := time.Now()
+	if minFee := feeAt(m.minFeeRate(now), size); fee < minFee {
+		m.remove(added)
+		return nil, fmt.Errorf("%w: package fee rate %d, the minimum is %d", ErrInsufficientFee, feeRate(fee, size), m.minFeeRate(now))
+	}
+
+	m.trimToSize(now)
+	result := make([]TxDesc, 0, len(descs))
+	for _, d := range descs {
+		if _, ok := m.txs[d.TxID]; !ok {
+			return nil, ErrMempoolFull
+		}
+		result = append(result, *d)
+	}
+	log.Printf("package of %d transactions with fee rate %d sat/kvB is added to the mempool\n", len(descs), feeRate(fee, size))
+	return result, nil
+}
+
+// AncestorPackage returns the transaction with the wtxid and its ancestors in the mempool, sorted
+// topologically with the transaction last.
+func (m *Mempool) AncestorPackage(wtxid [32]byte) ([]TxDesc, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d, ok := m.wtxids[wtxid]
+	if !ok {
+		return nil, false
+	}
+	pkg := make([]TxDesc, 0, d.AncestorCount)
+	for _, a := range m.ancestors(d.parents) {
+		pkg = append(pkg, *a)
+	}
+	slices.SortFunc(pkg, func(a, b TxDesc) int { return a.AncestorCount - b.AncestorCount })
+	return append(pkg, *d), true
+}
+
+// checkPackage checks that the package is a child with its parents: every transaction before the last one
+// is spent by it, the parents are sorted topologically and the transactions don't spend the same outputs.
+func checkPackage(txs []p2p.MsgTx) error {
+	if len(txs) == 0 || len(txs) > maxPackageCount {
+		return fmt.Errorf("%w: %d transactions", ErrInvalidPackage, len(txs))
+	}
+
+	var size int64
+	later := make(map[[32]byte]struct{})
+	for _, tx := range txs {
+		size += tx.VSize()
+		later[tx.TxHash()] = struct{}{}
+	}
+	if len(later) != len(txs) {
+		return fmt.Errorf("%w: duplicate transactions", ErrInvalidPackage)
+	}
+	if size > maxPackageSize {
+		return fmt.Errorf("%w: %d vbytes", ErrInvalidPackage, size)
+	}
+
+	spent := make(map[p2p.OutPoint]struct{})
+	for _, tx := range txs {
+		delete(later, tx.TxHash())
+		for _, in := range tx.TxIn {
+			if _, ok := later[in.PreviousOutput.Hash]; ok {
+				return fmt.Errorf("%w: not sorted topologically", ErrInvalidPackage)
+			}
+			if _, ok := spent[in.PreviousOutput]; ok {
+				return fmt.Errorf("%w: conflicting transactions", ErrInvalidPackage)
+			}
+			spent[in.PreviousOutput] = struct{}{}
+		}
+	}
+
+	child := txs[len(txs)-1]
+	for _, parent := range txs[:len(txs)-1] {
+		txid := parent.TxHash()
+		if !slices.ContainsFunc(child.TxIn, func(in p2p.TxInput) bool { return in.PreviousOutput.Hash == txid }) {
+			return fmt.Errorf("%w: %x is not a parent of the child", ErrInvalidPackage, p2p.Reverse(txid))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mempool_test
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempool_AcceptPackagePullsInAZeroFeeParentWithItsChild(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{{Hash: [32]byte{1}}: {Value: 100_000}, {Hash: [32]byte{2}}: {Value: 100_000}}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	parent := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 100_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 99_000)
+	_, err := pool.AcceptTx(parent)
+	require.ErrorIs(t, err, mempool.ErrInsufficientFee)
+
+	// the fee of the child pays for the package of both transactions
+	accepted, err := pool.AcceptPackage([]p2p.MsgTx{parent, child})
+	require.NoError(t, err)
+	require.Len(t, accepted, 2)
+	require.Equal(t, parent.TxHash(), accepted[0].TxID)
+	require.Equal(t, child.TxHash(), accepted[1].TxID)
+	c, ok := pool.Get(child.TxHash())
+	require.True(t, ok)
+	require.Equal(t, 2, c.AncestorCount)
+	require.Equal(t, int64(1_000), c.AncestorFees)
+
+	pkg, ok := pool.AncestorPackage(child.WTxHash())
+	require.True(t, ok)
+	require.Len(t, pkg, 2)
+	require.Equal(t, parent.TxHash(), pkg[0].TxID)
+	require.Equal(t, child.TxHash(), pkg[1].TxID)
+
+	// a package whose fee rate is below the minimum is not added
+	lowParent := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 100_000)
+	lowChild := newTx([]p2p.OutPoint{{Hash: lowParent.TxHash()}}, 100_000-txVSize)
+	_, err = pool.AcceptPackage([]p2p.MsgTx{lowParent, lowChild})
+	require.ErrorIs(t, err, mempool.ErrInsufficientFee)
+	require.ErrorContains(t, err, "package fee rate")
+	require.Equal(t, 2, pool.Count())
+}
+
+func TestMempool_AcceptPackageRejectsTheInvalidPackages(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{{Hash: [32]byte{1}}: {Value: 100_000}, {Hash: [32]byte{2}}: {Value: 100_000}}
+	parent := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 100_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 99_000)
+	unrelated := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 99_000)
+	conflict := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}, {Hash: parent.TxHash()}}, 99_000)
+
+	tests := []struct {
+		name string
+		txs  []p2p.MsgTx
+	}{
+		{name: "empty", txs: nil},
+		{name: "not sorted", txs: []p2p.MsgTx{child, parent}},
+		{name: "duplicate transactions", txs: []p2p.MsgTx{parent, parent}},
+		{name: "not a parent of the child", txs: []p2p.MsgTx{unrelated, parent, child}},
+		{name: "conflicting transactions", txs: []p2p.MsgTx{parent, conflict}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			pool := mempool.New(newUTXOSet(tt, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+			_, err := pool.AcceptPackage(test.txs)
+			require.ErrorIs(tt, err, mempool.ErrInvalidPackage)
+			require.Equal(tt, 0, pool.Count())
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// mempoolDumpVersion is the version of the format in which the mempool is dumped.
+const mempoolDumpVersion = 1
+
+// ErrInvalidMempoolDump is returned when the dumped mempool can't be decoded.
+var ErrInvalidMempoolDump = errors.New("invalid mempool dump")
+
+// Dump writes the transactions of the mempool with the time they are added and their fee deltas, in the
+// style of the mempool.dat of Bitcoin Core. The parents are written before their children, so they are
+// loaded in the right order. The fee deltas of the transactions that are not in the mempool are written
+// after them.
+func (m *Mempool) Dump(w io.Writer) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	txs := make([]*TxDesc, 0, len(m.txs))
+	for _, d := range m.txs {
+		txs = append(txs, d)
+	}
+	slices.SortFunc(txs, func(a, b *TxDesc) int {
+		if a.AncestorCount != b.AncestorCount {
+			return a.AncestorCount - b.AncestorCount
+		}
+		return a.Added.Compare(b.Added)
+	})
+
+	if err := writeValues(w, uint64(mempoolDumpVersion), uint64(len(txs))); err != nil {
+		return err
+	}
+	for _, d := range txs {
+		b, err := d.Tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(b); err != nil {
+			return err
+		}
+		if err = writeValues(w, d.Added.Unix(), d.FeeDelta); err != nil {
+			return err
+		}
+	}
+
+	var deltas [][32]byte
+	for txid := range m.deltas {
+		if _, ok := m.txs[txid]; !ok {
+			deltas = append(deltas, txid)
+		}
+	}
+	if err := writeValues(w, uint64(len(deltas))); err != nil {
+		return err
+	}
+	for _, txid := range deltas {
+		if err := writeValues(w, txid, m.deltas[txid]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load adds the transactions that are written by Dump to the mempool. They are validated again against the
+// current UTXO set, so the transactions that are confirmed or conflict with the chain are dropped. It
+// returns the number of the added transactions and of the dropped ones.
+func (m *Mempool) Load(r io.Reader) (int, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var version, count uint64
+	if err := readValues(r, &version, &count); err != nil {
+		return 0, 0, err
+	}
+	if version != mempoolDumpVersion {
+		return 0, 0, fmt.Errorf("%w: version %d", ErrInvalidMempoolDump, version)
+	}
+
+	var added, dropped int
+	for range count {
+		var tx p2p.MsgTx
+		if err := tx.UnmarshalBinary(r); err != nil {
+			return added, dropped, fmt.Errorf("%w: %w", ErrInvalidMempoolDump, err)
+		}
+		var addedAt, delta int64
+		if err := readValues(r, &addedAt, &delta); err != nil {
+			return added, dropped, err
+		}
+
+		txid := tx.TxHash()
+		if delta != 0 {
+			m.prioritise(txid, delta)
+		}
+		if _, err := m.acceptTx(tx, 0); err != nil {
+			log.Printf("the dumped transaction %x is dropped: %s\n", p2p.Reverse(txid), err)
+			dropped++
+			continue
+		}
+		m.txs[txid].Added = time.Unix(addedAt, 0)
+		added++
+	}
+
+	var deltas uint64
+	if err := readValues(r, &deltas); err != nil {
+		return added, dropped, err
+	}
+	for range deltas {
+		var txid [32]byte
+		var delta int64
+		if err := readValues(r, &txid, &delta); err != nil {
+			return added, dropped, err
+		}
+		m.prioritise(txid, delta)
+	}
+	return added, dropped, nil
+}
+
+func writeValues(w io.Writer, values ...any) error {
+	for _, v := range values {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readValues(r io.Reader, values ...any) error {
+	for _, v := range values {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidMempoolDump, err)
+		}
+	}
+	return nil
+}
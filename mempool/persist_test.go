@@ -0,0 +1,65 @@
+package mempool_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempool_DumpAndLoad(t *testing.T) {
+	utxos := map[p2p.OutPoint]db.UTXO{
+		{Hash: [32]byte{1}}: {Value: 100_000},
+		{Hash: [32]byte{2}}: {Value: 100_000},
+		{Hash: [32]byte{3}}: {Value: 100_000},
+	}
+	pool := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	parent := newTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 99_000)
+	child := newTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 98_000)
+	confirmed := newTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 99_000)
+	for _, tx := range []p2p.MsgTx{parent, child, confirmed} {
+		_, err := pool.AcceptTx(tx)
+		require.NoError(t, err)
+	}
+	pool.PrioritiseTransaction(child.TxHash(), 2_000)
+	// the delta of a transaction that is not in the mempool is dumped too
+	zeroFee := newTx([]p2p.OutPoint{{Hash: [32]byte{3}}}, 100_000)
+	pool.PrioritiseTransaction(zeroFee.TxHash(), 1_000)
+
+	var buf bytes.Buffer
+	require.NoError(t, pool.Dump(&buf))
+
+	// the output that is spent by the confirmed transaction is not in the UTXO set of the new chain tip
+	delete(utxos, p2p.OutPoint{Hash: [32]byte{2}})
+	loaded := mempool.New(newUTXOSet(t, utxos), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+	added, dropped, err := loaded.Load(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 2, added)
+	require.Equal(t, 1, dropped)
+
+	expected, _ := pool.Get(child.TxHash())
+	actual, ok := loaded.Get(child.TxHash())
+	require.True(t, ok)
+	require.Equal(t, expected.Added.Truncate(time.Second), actual.Added)
+	require.Equal(t, int64(2_000), actual.FeeDelta)
+	require.Equal(t, 2, actual.AncestorCount)
+	_, ok = loaded.Get(confirmed.TxHash())
+	require.False(t, ok)
+
+	_, err = loaded.AcceptTx(zeroFee)
+	require.NoError(t, err)
+}
+
+func TestMempool_LoadRejectsAnInvalidDump(t *testing.T) {
+	pool := mempool.New(newUTXOSet(t, nil), mempool.DefaultMaxSize, mempool.DefaultMinRelayFeeRate, false, nil)
+
+	_, _, err := pool.Load(bytes.NewReader([]byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}))
+	require.ErrorIs(t, err, mempool.ErrInvalidMempoolDump)
+	_, _, err = pool.Load(bytes.NewReader([]byte{1, 0, 0, 0, 0, 0, 0, 0, 1}))
+	require.ErrorIs(t, err, mempool.ErrInvalidMempoolDump)
+}
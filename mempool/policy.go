@@ -0,0 +1,281 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+const (
+	// MaxMoney is the maximum amount of satoshis, 21 million bitcoins.
+	MaxMoney = 21_000_000 * 100_000_000
+	// DefaultMinRelayFeeRate is the default minimum fee rate in satoshis per 1000 virtual bytes of the
+	// transactions that are accepted.
+	DefaultMinRelayFeeRate = 1000
+	// DefaultMaxSize is the default maximum size in bytes of the transactions in the mempool.
+	DefaultMaxSize = 300_000_000
+
+	// coinbaseMaturity is the number of blocks after which the outputs of a coinbase can be spent.
+	coinbaseMaturity = 100
+	// lockTimeThreshold is the lock time below which it is a block height, above it is a unix time.
+	lockTimeThreshold = 500_000_000
+	// maxTxSequence is the sequence of an input that doesn't enable the lock time.
+	maxTxSequence = 0xffffffff
+	// maxReplaceableSequence is the maximum sequence of an input that signals that the transaction can be
+	// replaced (BIP 125).
+	maxReplaceableSequence = 0xfffffffd
+
+	maxStandardVersion          = 2
+	maxStandardTxWeight         = 400_000
+	minStandardTxNonWitnessSize = 65
+	maxStandardScriptSigSize    = 1650
+	// maxNullDataSize is the maximum size of an OP_RETURN output script.
+	maxNullDataSize = 83
+	// dustRelayFeeRate is the fee rate in satoshis per 1000 virtual bytes that is used to calculate the
+	// dust threshold of the outputs.
+	dustRelayFeeRate = 3000
+
+	// the limits of the in-pool ancestors and descendants of a transaction, including it. The sizes are in
+	// virtual bytes.
+	maxAncestors      = 25
+	maxAncestorSize   = 101_000
+	maxDescendants    = 25
+	maxDescendantSize = 101_000
+)
+
+// the opcodes that are used by the standard scripts
+const (
+	op0             = 0x00
+	opPushData1     = 0x4c
+	opPushData2     = 0x4d
+	opPushData4     = 0x4e
+	op1             = 0x51
+	op3             = 0x53
+	op16            = 0x60
+	opReturn        = 0x6a
+	opDup           = 0x76
+	opEqual         = 0x87
+	opEqualVerify   = 0x88
+	opHash160       = 0xa9
+	opCheckSig      = 0xac
+	opCheckMultiSig = 0xae
+)
+
+var (
+	// ErrInvalidTx is returned for a transaction that is not valid by the consensus rules.
+	ErrInvalidTx = errors.New("invalid transaction")
+	// ErrNonStandard is returned for a transaction that is valid, but is not relayed by the policy rules.
+	ErrNonStandard = errors.New("non-standard transaction")
+	// ErrNonFinal is returned for a transaction whose lock time is not reached by the next block.
+	ErrNonFinal = errors.New("non-final transaction")
+)
+
+// checkTransaction checks the rules that don't depend on the chain: the transaction has inputs and outputs,
+// its outputs values are in the valid range and it doesn't spend the same output twice.
+func checkTransaction(tx p2p.MsgTx) error {
+	if len(tx.TxIn) == 0 {
+		return fmt.Errorf("%w: no inputs", ErrInvalidTx)
+	}
+	if len(tx.TxOut) == 0 {
+		return fmt.Errorf("%w: no outputs", ErrInvalidTx)
+	}
+	if tx.IsCoinBase() {
+		return fmt.Errorf("%w: coinbase", ErrInvalidTx)
+	}
+
+	var total int64
+	for i, out := range tx.TxOut {
+		if out.Value < 0 || out.Value > MaxMoney {
+			return fmt.Errorf("%w: output %d has value %d", ErrInvalidTx, i, out.Value)
+		}
+		total += out.Value
+		if total > MaxMoney {
+			return fmt.Errorf("%w: the outputs value is above the maximum", ErrInvalidTx)
+		}
+	}
+
+	inputs := make(map[p2p.OutPoint]struct{}, len(tx.TxIn))
+	for _, in := range tx.TxIn {
+		if _, ok := inputs[in.PreviousOutput]; ok {
+			return fmt.Errorf("%w: duplicate input %x:%d", ErrInvalidTx, p2p.Reverse(in.PreviousOutput.Hash), in.PreviousOutput.Index)
+		}
+		inputs[in.PreviousOutput] = struct{}{}
+	}
+	return nil
+}
+
+// checkStandard checks the policy rules of the transaction that don't depend on its inputs: its version,
+// weight and size, that its input scripts only push data and that its outputs have standard scripts,
+// at most one of them is OP_RETURN and the others are not dust.
+func checkStandard(tx p2p.MsgTx) error {
+	if tx.Version < 1 || tx.Version > maxStandardVersion {
+		return fmt.Errorf("%w: version %d", ErrNonStandard, tx.Version)
+	}
+	if w := tx.Weight(); w > maxStandardTxWeight {
+		return fmt.Errorf("%w: weight %d", ErrNonStandard, w)
+	}
+	if size := tx.BaseSize(); size < minStandardTxNonWitnessSize {
+		return fmt.Errorf("%w: size without witness %d", ErrNonStandard, size)
+	}
+
+	for i, in := range tx.TxIn {
+		if len(in.SignatureScript) > maxStandardScriptSigSize {
+			return fmt.Errorf("%w: input %d script size %d", ErrNonStandard, i, len(in.SignatureScript))
+		}
+		if !isPushOnly(in.SignatureScript) {
+			return fmt.Errorf("%w: input %d script is not push only", ErrNonStandard, i)
+		}
+	}
+
+	var nullData int
+	for i, out := range tx.TxOut {
+		switch {
+		case isNullData(out.PkScript):
+			nullData++
+		case !isStandardScript(out.PkScript):
+			return fmt.Errorf("%w: output %d script", ErrNonStandard, i)
+		case out.Value < dustThreshold(out):
+			return fmt.Errorf("%w: output %d is dust", ErrNonStandard, i)
+		}
+	}
+	if nullData > 1 {
+		return fmt.Errorf("%w: %d OP_RETURN outputs", ErrNonStandard, nullData)
+	}
+	return nil
+}
+
+// isFinal returns true when the transaction can be included in the block at the given height and time.
+func isFinal(tx p2p.MsgTx, height int32, t time.Time) bool {
+	if tx.LockTime == 0 {
+		return true
+	}
+
+	limit := t.Unix()
+	if tx.LockTime < lockTimeThreshold {
+		limit = int64(height)
+	}
+	if int64(tx.LockTime) < limit {
+		return true
+	}
+
+	// the lock time is disabled when all inputs have the maximum sequence
+	for _, in := range tx.TxIn {
+		if in.Sequence != maxTxSequence {
+			return false
+		}
+	}
+	return true
+}
+
+// signalsReplacement returns true when an input of the transaction signals that it can be replaced (BIP 125).
+// The signal is not inherited from the ancestors of the transaction, the same as in Bitcoin Core.
+func signalsReplacement(tx p2p.MsgTx) bool {
+	for _, in := range tx.TxIn {
+		if in.Sequence <= maxReplaceableSequence {
+			return true
+		}
+	}
+	return false
+}
+
+// dustThreshold returns the minimum value of the output. An output is dust when the fee to spend it is
+// more than a third of its value at the dust relay fee rate.
+func dustThreshold(out p2p.TxOutput) int64 {
+	if isNullData(out.PkScript) {
+		return 0
+	}
+
+	// the size of the output and of the input that spends it
+	b, _ := binary.Marshal(p2p.VarInt(len(out.PkScript)))
+	size := int64(8 + len(b) + len(out.PkScript))
+	if isWitnessProgram(out.PkScript) {
+		size += 32 + 4 + 1 + 107/4 + 4
+	} else {
+		size += 32 + 4 + 1 + 107 + 4
+	}
+	return size * dustRelayFeeRate / 1000
+}
+
+// isStandardScript returns true for the P2PK, P2PKH, P2SH, bare multisig with up to 3 keys and witness
+// program output scripts.
+func isStandardScript(s []byte) bool {
+	switch {
+	case len(s) == 25 && s[0] == opDup && s[1] == opHash160 && s[2] == 20 && s[23] == opEqualVerify && s[24] == opCheckSig:
+		return true
+	case len(s) == 23 && s[0] == opHash160 && s[1] == 20 && s[22] == opEqual:
+		return true
+	case len(s) == 35 && s[0] == 33 && s[34] == opCheckSig, len(s) == 67 && s[0] == 65 && s[66] == opCheckSig:
+		return true
+	}
+	return isWitnessProgram(s) || isMultiSig(s)
+}
+
+// isWitnessProgram returns true when the script is a witness program (BIP 141) of any version.
+func isWitnessProgram(s []byte) bool {
+	if len(s) < 4 || len(s) > 42 || int(s[1]) != len(s)-2 {
+		return false
+	}
+	if s[0] == op0 {
+		// version 0 programs are P2WPKH or P2WSH
+		return len(s) == 22 || len(s) == 34
+	}
+	return s[0] >= op1 && s[0] <= op16
+}
+
+// isMultiSig returns true for a bare m-of-n multisig script with up to 3 compressed or uncompressed keys.
+func isMultiSig(s []byte) bool {
+	if len(s) < 3 || s[len(s)-1] != opCheckMultiSig {
+		return false
+	}
+	m, n := s[0], s[len(s)-2]
+	if m < op1 || n > op3 || m > n {
+		return false
+	}
+
+	keys := s[1 : len(s)-2]
+	for i := op1; i <= int(n); i++ {
+		if len(keys) == 0 || (keys[0] != 33 && keys[0] != 65) || len(keys) < int(keys[0])+1 {
+			return false
+		}
+		keys = keys[keys[0]+1:]
+	}
+	return len(keys) == 0
+}
+
+// isNullData returns true for an OP_RETURN output script that only pushes data, it can't be spent.
+func isNullData(s []byte) bool {
+	return len(s) > 0 && s[0] == opReturn && len(s) <= maxNullDataSize && isPushOnly(s[1:])
+}
+
+// isPushOnly returns true when the script only pushes data on the stack.
+func isPushOnly(s []byte) bool {
+	for len(s) > 0 {
+		op := s[0]
+		s = s[1:]
+
+		var n int
+		switch {
+		case op < opPushData1:
+			n = int(op)
+		case op == opPushData1 && len(s) >= 1:
+			n, s = int(s[0]), s[1:]
+		case op == opPushData2 && len(s) >= 2:
+			n, s = int(s[0])|int(s[1])<<8, s[2:]
+		case op == opPushData4 && len(s) >= 4:
+			n, s = int(s[0])|int(s[1])<<8|int(s[2])<<16|int(s[3])<<24, s[4:]
+		case op <= op16 && op > opPushData4:
+			// OP_1NEGATE, OP_RESERVED and OP_1 - OP_16
+			continue
+		default:
+			return false
+		}
+		if n > len(s) {
+			return false
+		}
+		s = s[n:]
+	}
+	return true
+}
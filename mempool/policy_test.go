@@ -0,0 +1,83 @@
+package mempool
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStandardScript(t *testing.T) {
+	hash20 := bytes.Repeat([]byte{1}, 20)
+	hash32 := bytes.Repeat([]byte{1}, 32)
+	key33 := append([]byte{33, 2}, bytes.Repeat([]byte{1}, 32)...)
+
+	tests := []struct {
+		name     string
+		script   []byte
+		expected bool
+	}{
+		{name: "P2PKH", script: concat([]byte{opDup, opHash160, 20}, hash20, []byte{opEqualVerify, opCheckSig}), expected: true},
+		{name: "P2SH", script: concat([]byte{opHash160, 20}, hash20, []byte{opEqual}), expected: true},
+		{name: "P2PK", script: concat(key33, []byte{opCheckSig}), expected: true},
+		{name: "P2WPKH", script: concat([]byte{op0, 20}, hash20), expected: true},
+		{name: "P2WSH", script: concat([]byte{op0, 32}, hash32), expected: true},
+		{name: "P2TR", script: concat([]byte{op1, 32}, hash32), expected: true},
+		{name: "1-of-2 multisig", script: concat([]byte{op1}, key33, key33, []byte{op1 + 1, opCheckMultiSig}), expected: true},
+		{name: "version 0 program with invalid length", script: concat([]byte{op0, 24}, bytes.Repeat([]byte{1}, 24))},
+		{name: "multisig with missing key", script: concat([]byte{op1}, key33, []byte{op1 + 1, opCheckMultiSig})},
+		{name: "anyone can spend", script: []byte{op1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			require.Equal(tt, test.expected, isStandardScript(test.script))
+		})
+	}
+}
+
+func TestIsPushOnly(t *testing.T) {
+	require.True(t, isPushOnly([]byte{2, 1, 1, op1, opPushData1, 1, 1, opPushData2, 1, 0, 1}))
+	require.False(t, isPushOnly([]byte{3, 1, 1}))
+	require.False(t, isPushOnly([]byte{1, 1, opDup}))
+}
+
+func TestDustThreshold(t *testing.T) {
+	p2pkh := concat([]byte{opDup, opHash160, 20}, bytes.Repeat([]byte{1}, 20), []byte{opEqualVerify, opCheckSig})
+	p2wpkh := concat([]byte{op0, 20}, bytes.Repeat([]byte{1}, 20))
+
+	require.Equal(t, int64(546), dustThreshold(p2p.TxOutput{PkScript: p2pkh}))
+	require.Equal(t, int64(294), dustThreshold(p2p.TxOutput{PkScript: p2wpkh}))
+	require.Equal(t, int64(0), dustThreshold(p2p.TxOutput{PkScript: []byte{opReturn, 1, 1}}))
+}
+
+func TestIsFinal(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tx := p2p.MsgTx{TxIn: []p2p.TxInput{{Sequence: 0}}}
+	require.True(t, isFinal(tx, 100, now))
+
+	tx.LockTime = 99
+	require.True(t, isFinal(tx, 100, now))
+	tx.LockTime = 100
+	require.False(t, isFinal(tx, 100, now))
+	tx.LockTime = uint32(now.Unix())
+	require.False(t, isFinal(tx, 100, now))
+
+	// the lock time is disabled by the sequence
+	tx.TxIn[0].Sequence = maxTxSequence
+	require.True(t, isFinal(tx, 100, now))
+}
+
+func TestSignalsReplacement(t *testing.T) {
+	tx := p2p.MsgTx{TxIn: []p2p.TxInput{{Sequence: maxTxSequence}, {Sequence: maxTxSequence - 1}}}
+	require.False(t, signalsReplacement(tx))
+
+	tx.TxIn[1].Sequence = maxTxSequence - 2
+	require.True(t, signalsReplacement(tx))
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
@@ -0,0 +1,71 @@
+package mempool
+
+import (
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// TxDesc is a transaction in the mempool. Its package of ancestors and its package of descendants are
+// the transactions in the mempool that it spends from, directly or not, and that spend from it. The
+// counts, sizes and fees of the packages include the transaction itself. The sizes are in virtual bytes
+// and the fees in satoshis. The fees of the packages and the fee rates are with the modified fee, the
+// fee plus the fee delta that is set with PrioritiseTransaction.
+type TxDesc struct {
+	Tx       p2p.MsgTx
+	TxID     [32]byte
+	WTxID    [32]byte
+	Fee      int64
+	FeeDelta int64
+	VSize    int64
+	Height   int32
+	Added    time.Time
+
+	AncestorCount   int
+	AncestorSize    int64
+	AncestorFees    int64
+	DescendantCount int
+	DescendantSize  int64
+	DescendantFees  int64
+
+	// the transactions in the mempool that the transaction spends from and that spend from it
+	parents  map[[32]byte]*TxDesc
+	children map[[32]byte]*TxDesc
+	// index is the position of the transaction in the eviction queue of the mempool
+	index int
+}
+
+// ModifiedFee returns the fee of the transaction with its fee delta.
+func (d TxDesc) ModifiedFee() int64 {
+	return d.Fee + d.FeeDelta
+}
+
+// FeeRate returns the modified fee rate of the transaction in satoshis per 1000 virtual bytes.
+func (d TxDesc) FeeRate() int64 {
+	return feeRate(d.ModifiedFee(), d.VSize)
+}
+
+// AncestorFeeRate returns the fee rate of the transaction with its ancestors, it is the fee rate at which
+// a miner includes it in a block.
+func (d TxDesc) AncestorFeeRate() int64 {
+	return feeRate(d.AncestorFees, d.AncestorSize)
+}
+
+// DescendantFeeRate returns the fee rate of the transaction with its descendants, the transactions with
+// the lowest one are evicted first when the mempool is full.
+func (d TxDesc) DescendantFeeRate() int64 {
+	return feeRate(d.DescendantFees, d.DescendantSize)
+}
+
+// feeRate returns the fee rate in satoshis per 1000 virtual bytes.
+func feeRate(fee, vsize int64) int64 {
+	if vsize == 0 {
+		return 0
+	}
+	return fee * 1000 / vsize
+}
+
+// feeAt returns the fee of a transaction with the given virtual size at the fee rate.
+func feeAt(rate, vsize int64) int64 {
+	return rate * vsize / 1000
+}
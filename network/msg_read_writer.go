@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"github.com/EmilGeorgiev/btc-node/network/binary"
@@ -13,9 +14,12 @@ import (
 )
 
 // MessageReadWriter manages reading and writing messages over a network connection with specified timeouts.
+// It counts the bytes of the read and written messages, so every connection should have its own MessageReadWriter.
 type MessageReadWriter struct {
 	readConnTimeout  time.Duration
 	writeConnTimeout time.Duration
+	bytesRead        *atomic.Uint64
+	bytesWritten     *atomic.Uint64
 }
 
 // NewMessageReadWriter creates a new MessageReadWriter with the given read and write timeouts.
@@ -23,9 +27,21 @@ func NewMessageReadWriter(rTimeout, wTimeout time.Duration) MessageReadWriter {
 	return MessageReadWriter{
 		readConnTimeout:  rTimeout,
 		writeConnTimeout: wTimeout,
+		bytesRead:        &atomic.Uint64{},
+		bytesWritten:     &atomic.Uint64{},
 	}
 }
 
+// BytesRead returns the number of bytes of the messages that are read.
+func (ml MessageReadWriter) BytesRead() uint64 {
+	return ml.bytesRead.Load()
+}
+
+// BytesWritten returns the number of bytes of the messages that are written.
+func (ml MessageReadWriter) BytesWritten() uint64 {
+	return ml.bytesWritten.Load()
+}
+
 // ReadMessage reads a message from the given network connection and decode it.
 // The returned interface's type is one of MsgHeaders, MsgPing, MsgBlock and others
 func (ml MessageReadWriter) ReadMessage(conn net.Conn) (interface{}, error) {
@@ -67,6 +83,7 @@ func (ml MessageReadWriter) handleMessage(headerRaw []byte, conn net.Conn) (inte
 		}
 		payload = append(payload, tempBuffer[:num]...)
 	}
+	ml.bytesRead.Add(uint64(len(headerRaw) + len(payload)))
 	if len(payload) != payloadLength {
 		fmt.Printf("Expected to read %d bytes, but only read %d\n", payloadLength, len(payload))
 		return nil, fmt.Errorf("Expected to read %d bytes, but only read %d\n", payloadLength, len(payload))
@@ -109,6 +126,72 @@ func (ml MessageReadWriter) decodeMessage(payload []byte, command string) (inter
 			return nil, err
 		}
 		return &msg, nil
+	case "getdata":
+		msg := p2p.MsgGetData{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "getheaders":
+		msg := p2p.MsgGetHeader{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "getblocks":
+		msg := p2p.MsgGetBlocks{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "notfound":
+		msg := p2p.MsgNotFound{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "inv":
+		msg := p2p.MsgInv{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "tx":
+		msg := p2p.MsgTx{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "feefilter":
+		msg := p2p.MsgFeeFilter{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "sendpackages":
+		msg := p2p.MsgSendPackages{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "ancpkginfo":
+		msg := p2p.MsgAncPkgInfo{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "getpkgtxns":
+		msg := p2p.MsgGetPkgTxns{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
+	case "pkgtxns":
+		msg := p2p.MsgPkgTxns{}
+		if err := binary.NewDecoder(buf).Decode(&msg); err != nil {
+			return nil, err
+		}
+		return &msg, nil
 	default:
 		log.Println("missing logic for message with command: ", command)
 		return &p2p.Unknown{}, nil
@@ -123,6 +206,7 @@ func (ml MessageReadWriter) WriteMessage(msg *p2p.Message, conn net.Conn) error
 	}
 
 	conn.SetWriteDeadline(time.Now().Add(ml.writeConnTimeout))
-	_, err = conn.Write(rawMsg)
+	n, err := conn.Write(rawMsg)
+	ml.bytesWritten.Add(uint64(n))
 	return err
 }
@@ -18,11 +18,13 @@ const minimalSupportedVersion = 70016
 
 // HandshakeManager manages the handshake process for incoming and outgoing connections.
 type HandshakeManager struct {
+	// services that are advertised in the version message
+	services uint64
 }
 
-// NewHandshakeManager creates a new instance of HandshakeManager.
-func NewHandshakeManager() HandshakeManager {
-	return HandshakeManager{}
+// NewHandshakeManager creates a new instance of HandshakeManager that advertises the given services.
+func NewHandshakeManager(services uint64) HandshakeManager {
+	return HandshakeManager{services: services}
 }
 
 // CreateIncomingHandshake handles the handshake process for incoming connections.
@@ -30,7 +32,8 @@ func (hi HandshakeManager) CreateIncomingHandshake(network, userAgent string) (H
 	return Handshake{}, nil
 }
 
-// CreateOutgoingHandshake initiates the handshake process with a remote peer.
+// CreateOutgoingHandshake initiates the handshake process with a remote peer. All messages of the handshake
+// are sent with the magic of the network, and the messages of the peer from another network are rejected.
 func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network, userAgent string) (Handshake, error) {
 	log.Println("Initialize handshake with peer: ", peerAddr.String())
 	conn, err := net.Dial("tcp", peerAddr.String())
@@ -39,7 +42,7 @@ func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network
 		return Handshake{}, errors.NewE(msg, err, true)
 	}
 
-	msg, err := createMsgVersion(peerAddr, network, userAgent)
+	msg, err := createMsgVersion(peerAddr, network, userAgent, hi.services)
 	if err != nil {
 		return Handshake{}, err
 	}
@@ -52,6 +55,7 @@ func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network
 	msgHeader := make([]byte, MsgHeaderLength)
 	versionMsgIsReceived := false
 	wtxidrelayIsReceived := false
+	var packageVersions uint64
 	var handshake Handshake
 	for {
 		n, err := conn.Read(msgHeader)
@@ -71,6 +75,10 @@ func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network
 			m := fmt.Sprintf("Error while validate message header from peer: %s", peerAddr.String())
 			return Handshake{}, errors.NewE(m, err, true)
 		}
+		if header.Magic != Networks[network] {
+			return Handshake{}, errors.NewE(
+				fmt.Sprintf("peer: %s sent a message with magic %x that is not of network %s", peerAddr.String(), header.Magic, network), true)
+		}
 
 		switch header.CommandString() {
 		case "version":
@@ -80,7 +88,7 @@ func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network
 				continue
 			}
 			versionMsgIsReceived = true
-			handshake, err = handleVersion(header, conn)
+			handshake, err = handleVersion(header, conn, network)
 			if err != nil {
 				return Handshake{}, err
 			}
@@ -88,12 +96,22 @@ func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network
 		case "wtxidrelay":
 			wtxidrelayIsReceived = true
 			log.Println("wtxidrelay is received")
+		case "sendpackages":
+			var sp MsgSendPackages
+			if err = binary.NewDecoder(io.LimitReader(conn, int64(header.Length))).Decode(&sp); err != nil {
+				m := fmt.Sprintf("failed to decode sendpackages from peer: %s", peerAddr.String())
+				return Handshake{}, errors.NewE(m, err, true)
+			}
+			packageVersions = sp.Versions
+			log.Println("sendpackages is received")
 		case "verack":
 			log.Println("receive msg verack")
 			if !wtxidrelayIsReceived {
 				log.Println("received verack before wtxidrelay. verack will be discarded")
 				continue
 			}
+			handshake.Peer.WTxIDRelay = true
+			handshake.Peer.PackageRelay = packageVersions&PackageRelayAncestor != 0
 			return handshake, nil
 		default:
 			log.Printf("receive unexpected message: %s. it will be ignored\n", header.CommandString())
@@ -101,8 +119,9 @@ func (hi HandshakeManager) CreateOutgoingHandshake(peerAddr common.Addr, network
 	}
 }
 
-// createMsgVersion creates a "version" message to initiate the handshake with a peer.
-func handleVersion(msgHeader MessageHeader, conn net.Conn) (Handshake, error) {
+// handleVersion reads the version message of the peer and replies with wtxidrelay, sendpackages and verack
+// messages of the network.
+func handleVersion(msgHeader MessageHeader, conn net.Conn, network string) (Handshake, error) {
 	var version MsgVersion
 
 	lr := io.LimitReader(conn, int64(msgHeader.Length))
@@ -112,11 +131,13 @@ func handleVersion(msgHeader MessageHeader, conn net.Conn) (Handshake, error) {
 	}
 
 	peer := Peer{
-		Address:    conn.RemoteAddr().String(),
-		Connection: conn,
-		Services:   version.Services,
-		UserAgent:  version.UserAgent.String,
-		Version:    version.Version,
+		Address:     conn.RemoteAddr().String(),
+		Connection:  conn,
+		Services:    version.Services,
+		UserAgent:   version.UserAgent.String,
+		Version:     version.Version,
+		StartHeight: version.StartHeight,
+		Relay:       version.Relay,
 	}
 
 	if minimalSupportedVersion > version.Version {
@@ -126,7 +147,7 @@ func handleVersion(msgHeader MessageHeader, conn net.Conn) (Handshake, error) {
 	}
 
 	// SEND wtxidrelay
-	wtxidrelay, err := NewMessage("wtxidrelay", "mainnet", []byte{})
+	wtxidrelay, err := NewMessage("wtxidrelay", network, []byte{})
 	if err != nil {
 		fmt.Println("can not initilize wtxidrelay message")
 		return Handshake{}, err
@@ -141,8 +162,21 @@ func handleVersion(msgHeader MessageHeader, conn net.Conn) (Handshake, error) {
 			fmt.Sprintf("failed to send verack message through conn to peer: %s", peer.Address), err, true)
 	}
 
+	// SEND sendpackages, the package relay is negotiated with wtxidrelay
+	sendpackages, err := NewSendPackagesMsg(network, PackageRelayAncestor)
+	if err != nil {
+		return Handshake{}, err
+	}
+	if msg, err = binary.Marshal(sendpackages); err != nil {
+		return Handshake{}, errors.NewE(fmt.Sprintf("failed to marshal sendpackages msg for peer %s", peer.Address), err)
+	}
+	if _, err := conn.Write(msg); err != nil {
+		return Handshake{}, errors.NewE(
+			fmt.Sprintf("failed to send sendpackages message through conn to peer: %s", peer.Address), err, true)
+	}
+
 	// SEND verack
-	verack, err := NewVerackMsg("mainnet")
+	verack, err := NewVerackMsg(network)
 	if err != nil {
 		return Handshake{}, err
 	}
@@ -166,11 +200,11 @@ type Handshake struct {
 	Peer Peer
 }
 
-func createMsgVersion(peerAddr common.Addr, network, userAgent string) ([]byte, error) {
+func createMsgVersion(peerAddr common.Addr, network, userAgent string, services uint64) ([]byte, error) {
 	ip := net.ParseIP(peerAddr.IP)
 	a := IPv4{}
 	copy(a[:], ip.To4())
-	version, err := NewVersionMsg(network, userAgent, a, uint16(peerAddr.Port))
+	version, err := NewVersionMsg(network, userAgent, services, a, uint16(peerAddr.Port))
 	if err != nil {
 		return nil, err
 	}
@@ -1,18 +1,20 @@
 package p2p_test
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/EmilGeorgiev/btc-node/common"
 	"github.com/EmilGeorgiev/btc-node/network/binary"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 	"github.com/stretchr/testify/require"
+	"io"
 	"log"
 	"net"
 	"testing"
 )
 
 func TestCreateHandshake(t *testing.T) {
-	validVersionMsg, _ := p2p.NewVersionMsg("mainnet", "test-agent", [4]byte{0x7F, 0x00, 0x00, 0x01}, 3333)
+	validVersionMsg, _ := p2p.NewVersionMsg("mainnet", "test-agent", p2p.SrvNodeNetwork, [4]byte{0x7F, 0x00, 0x00, 0x01}, 3333)
 	validVerackMsg, _ := p2p.NewVerackMsg("mainnet")
 	pongMsg, _ := p2p.NewPongMsg("mainnet", 11111)
 	tests := []struct {
@@ -75,7 +77,7 @@ func TestCreateHandshake(t *testing.T) {
 			err := tt.peerNode.start(tes)
 			require.NoError(tes, err)
 
-			hm := p2p.NewHandshakeManager()
+			hm := p2p.NewHandshakeManager(p2p.SrvNodeNetwork)
 			actual, err := hm.CreateOutgoingHandshake(tt.peerAddr, "mainnet", "test-agent")
 
 			tt.expected.Peer.Connection = actual.Peer.Connection
@@ -119,3 +121,98 @@ func (dn dummyNode) start(t *testing.T) error {
 
 	return nil
 }
+
+func TestCreateOutgoingHandshake_SendsTheMessagesOfTheNetwork(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	version, err := p2p.NewVersionMsg("simnet", "test-agent", p2p.SrvNodeNetwork, [4]byte{0x7F, 0x00, 0x00, 0x01}, uint16(port))
+	require.NoError(t, err)
+	wtxidrelay, err := p2p.NewMessage(p2p.CmdWtxidrelay, "simnet", []byte{})
+	require.NoError(t, err)
+	verack, err := p2p.NewVerackMsg("simnet")
+	require.NoError(t, err)
+
+	received := make(chan []p2p.MessageHeader, 1)
+	go func() {
+		var headers []p2p.MessageHeader
+		defer func() { received <- headers }()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// the version of the node, then its replies to the version of the peer
+		for _, reply := range [][]p2p.Message{{*version}, nil, nil, {*wtxidrelay, *verack}} {
+			header, err := readMessage(conn)
+			if err != nil {
+				return
+			}
+			headers = append(headers, header)
+			for _, msg := range reply {
+				raw, _ := binary.Marshal(msg)
+				if _, err = conn.Write(raw); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	hm := p2p.NewHandshakeManager(p2p.SrvNodeNetwork)
+	handshake, err := hm.CreateOutgoingHandshake(common.Addr{IP: "127.0.0.1", Port: int64(port)}, "simnet", "test-agent")
+	require.NoError(t, err)
+	require.True(t, handshake.Peer.WTxIDRelay)
+	defer handshake.Peer.Connection.Close()
+
+	headers := <-received
+	var commands []string
+	for _, header := range headers {
+		require.Equal(t, p2p.MagicSimnet, p2p.Magic(header.Magic))
+		commands = append(commands, header.CommandString())
+	}
+	require.Equal(t, []string{"version", "wtxidrelay", "sendpackages", "verack"}, commands)
+}
+
+func TestCreateOutgoingHandshake_RejectsThePeerOfAnotherNetwork(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	version, err := p2p.NewVersionMsg("mainnet", "test-agent", p2p.SrvNodeNetwork, [4]byte{0x7F, 0x00, 0x00, 0x01}, uint16(port))
+	require.NoError(t, err)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, err = readMessage(conn); err != nil {
+			return
+		}
+		raw, _ := binary.Marshal(*version)
+		_, _ = conn.Write(raw)
+		_, _ = readMessage(conn)
+	}()
+
+	hm := p2p.NewHandshakeManager(p2p.SrvNodeNetwork)
+	_, err = hm.CreateOutgoingHandshake(common.Addr{IP: "127.0.0.1", Port: int64(port)}, "simnet", "test-agent")
+	require.Error(t, err)
+}
+
+// readMessage reads a message from the connection and returns its header.
+func readMessage(conn net.Conn) (p2p.MessageHeader, error) {
+	raw := make([]byte, p2p.MsgHeaderLength)
+	if _, err := io.ReadFull(conn, raw); err != nil {
+		return p2p.MessageHeader{}, err
+	}
+	var header p2p.MessageHeader
+	if err := binary.NewDecoder(bytes.NewReader(raw)).Decode(&header); err != nil {
+		return p2p.MessageHeader{}, err
+	}
+	_, err := io.CopyN(io.Discard, conn, int64(header.Length))
+	return header, err
+}
@@ -100,6 +100,24 @@ func (h *MsgHeaders) UnmarshalBinary(r io.Reader) error {
 	return nil
 }
 
+// MarshalBinary implements binary.Marshaler interface.
+func (h MsgHeaders) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	b, err := binary.Marshal(h.Count)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	for _, bh := range h.BlockHeaders {
+		if b, err = binary.Marshal(bh); err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
 // BlockHeader represents the header of a Bitcoin block.
 type BlockHeader struct {
 	// Block Version
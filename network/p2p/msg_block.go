@@ -60,6 +60,18 @@ func (mb *MsgBlock) UnmarshalBinary(r io.Reader) error {
 	return nil
 }
 
+// StripWitness returns a copy of the block in which the transactions are without witness data.
+// It is used when the block is requested by peers that don't support segwit (MSG_BLOCK).
+func (mb MsgBlock) StripWitness() MsgBlock {
+	stripped := MsgBlock{BlockHeader: mb.BlockHeader, Transactions: make([]MsgTx, len(mb.Transactions))}
+	for i, tx := range mb.Transactions {
+		tx.Flag = 0
+		tx.TxWitness = nil
+		stripped.Transactions[i] = tx
+	}
+	return stripped
+}
+
 // GetHash calculates and returns the double SHA-256 hash of the block header.
 func (mb *MsgBlock) GetHash() [32]byte {
 	b, _ := binary.Marshal(mb.BlockHeader)
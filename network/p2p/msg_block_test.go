@@ -50,6 +50,17 @@ func TestMsgBlock_Unmarshal(t *testing.T) {
 	//require.Equal(t, expect, block)
 }
 
+func TestMsgBlock_MarshalBinary(t *testing.T) {
+	b, _ := hex.DecodeString(blockPayload)
+
+	block := p2p.MsgBlock{}
+	require.NoError(t, binary.NewDecoder(bytes.NewBuffer(b)).Decode(&block))
+
+	actual, err := binary.Marshal(block)
+	require.NoError(t, err)
+	require.Equal(t, b, actual)
+}
+
 var validBlock = "f9beb4d9626c6f636b00000000000000d700000000fd963601000000cfc9aa754fb17fc796ea26a5a181572a5293a102c08daa121c09509e0000000026f192a2a1c8da08e191a1fd5ad19542e1934b3af1056c391b9cc805ff9e78b4731f6b49ffff001d0758685d0101000000010000000000000000000000000000000000000000000000000000000000000000ffffffff0704ffff001d0146ffffffff0100f2052a01000000434104bb234bf92a7711339336aa62019853f170a4550286143cba21692a6794094f1d15b0d937f0283c24654e57eca200ab6b5c932eacd3ecbf1e286d011cf1de7988ac00000000"
 
 // f9beb4d9626c6f636b00000000000000d8000000b8d98cb201000000602bd8e84e86ee552bd46300cbc31e1961c03fa92d228b996458a77d0000000033a5654757552457777a3576803d111f7be988899ba94a15ecaf40ad1c7d99c5c9b0254bffff001dc7616f060201000000010000000000000000000000000000000000000000000000000000000000000000ffffffff0704ffff001d0104ffffffff0100f2052a010000004341048a443ad3ad7210741a1e3e5a4d035cefff091af16c2a792b99284b9551361490f5b75b96c0841fdf80d182a1e5ee97932e8ec086942a27f8ec83d2c0761f444dac0000000001000000fd400104e376400599976914ec91db8a12ff229a5c2ea2c82f70217f87add4c392e969000000004a493046022100ea875c8316de4083905a233ddc51fffd10b5cd136623dd57b9c7539ca0dc0986022100dbf79278c96454cab2970cb3f971cddb70c4d83d8d74a87b9216cecfe3d1127601ffffffff01b9c131932bcd45f1d8d0748c2794b37fadbc458e3a34504fde719c913f5543000000004a493046022100fd1bb16d96ad1511e303adeab859f6f9e74efe89e5024373283baef34064541f022100d77269f58a7a2bfb3c8713acc790e3469cb71a881cadc2bec69035c1ddd232b101ffffffff02448b236dac81e9c1d627e472d5793f21634e23ccb3dac3400afd783d11b73300000000494830450221009baee2552686ddde42a193d50fdac4595fb848b17653c0c847c5466a8e70827202201fe713d5efdb19b3e7ee3c92ccf074f5a753f7e5932ed4a38c061ef70d1579a701ffffffff02f896ae5d89a375b8c05894e586b8aceb32c28f2e05e11b66fd614d83a308e4000000004a4930460221009fba8b23f06cef8dd1a66e0dc4082e622664e5c2a0c22191bb24f3ac1d07ec13022100e74465ce93dd5862cdcd10f97483e08702f28fd591284a265dc8b18a3613375b01ffffffff005ece9ff547a17b4506f42f702b5203576a82e8b752c7a1fd56a04989edb36e000000004948304502201c08ac8eb3d94afaac1472e94a3dc395ece3d22dab0905caafaf6011bb52e3680221009c262bc843aacfaf2fb2d120ad4cbd3e27d37b81d63224519b110005b585416701ffffffff007f0f87576321c254beef150f6c5b36f2893ddee449241473375648cb735fca000000004a493046022100e4bd3833d9e1ddaa3cc68a520347235a748332d8a2a602065f3ea0d90f4d1870022100deb81d3a29008097b32afb35fdac3cb0f756240601881983a8b97e814b544b7a01ffffffff04011d29fcb755abdbbefe1169a918c923c364073a44bda55d16c7c6e2c4c9230000000049483045022100a0182512bb98c92039de93e4b7ece2a272fdbb782f0dc123f84a5f517aa8f98a02204fbb25d891ef9b346f840f1da179d94a458c04d938151cd72e4cec6818a5e08f01ffffffff047244506481c126a14262a1fbb369388110e5c6645bc160534d81ebbdf6708200000000494830450221008a1a80813826f97ead087257bec7548ad592dd9a4bfa6e043066bde5c399f5d30220633ed38f292b7590417e30ced5cb3b6f914765abca6b4f0c43a4a3c760cc164601ffffffff062ce60c80d2069fd3da3e94c4f69efea82e09eb1757d89aa246e37cf5924e020000000049483045022100a9adf9328c0e18f56e19d2c6ce8e92079562c00f97986b4a88ec5642267b60590220077711b4dc6e80ce3e7a8dc65f54ba818384e440555026370538f14bc0db4b1801ffffffff06b0020145a599c8602047894c66f46deef867a960513ccf0a2f3151ba48bfa5000000004948304502201b6af37d5028d53601647fcf7043993e4382068dac49ebf4c1c9230b75207739022100e306945e757fdc10774e561390c0f836feeaae599e4b745df41224ac49d3958101ffffffff06b6d0c42289ac43080a013316dbb2dbd521ad54bffb502d1bd1a13e9bace5d9000000004a4930460221008e07b69db8b92adc27fa54906d3577af212ec6f78fd37fd67f4c6216ec039e0c022100f149ff4f5edeb73b6bdc00890e32c2684661d5435697b6b787e75717306ed2cc01ffffffff071dd02d458ed7e65fae97688f364bd417509f9a31e0242085289b2bf9e6bd630000000048473044022004122590791c42859e60bb3d9c41dd903a998ac9369dc3ca910041f56535488302202f4df358c332945cd99c765e9fb4f444de4ff4227adb138ab2e74a19ff6acc7e01ffffffff0730a8f758247d867c9f4ab7e25de95adbf8d3a287a6453cd8b585b8916eb2ab000000004a493046022100e09553b4f5e86ba7c19ff574aba5a5db14660ac71ae1ea6043bd3713cae7bb41022100e9e4d7ee3c10184bc52c02f7a9ff758ef7b1bfe88e57d4581d2783fd4ea3f8db01ffffffff077d51da1c3c8cead891a49eb9f96710ec42b8ab3dce157b8713028176f512570000000049483045022100d1ed5b73b7bb58db7b6f747cf3c2a6130c31ecb393158cc64211391c2577caba02205ee7376ff31428f378fd98cf45ee99a3ae35fc81a2aeb5f142164480d0a1f84401ffffffff08b69c6267362d701e71b191d99e5221d10e77556313071c349c49be8747a085000000004a493046022100f186b5166b840ef90624608afc81331f16e09d450576299308c11876a89a2de30221008ade75f76f55d3e40ec95d6d663638f15f51a1294dce20d5a9763454af1ed2e501ffffffff0913ab7df42e6a2117578a73d474c24df1bd1812eb2b171f6ca59ab4d369d0b400000000494830450220733c6c569b9ea7dddb5ed71a6d5f069cbc66589f4ddb2dcda832242db51b0ec5022100bcdd0374045829c8d7f5d2421dabac79a6f4de428ea7fa3216a317d169e9923801ffffffff093e732077a113dbba6a40e405788b1b25577c1e91258d8d0650ba25f0db21ea0000000049483045022100a926013cd40dbac919fd622230dd85d8bc3dd5f104d0f7de5322c8c7b8f2db0c02201743d8a3b7a471ce8243a2393c4f95941b389e4220c9f47d421eb078c850f10701ffffffff09a7d0cfa0a0a0514f68046745d6a435f4fd39fe7af7745964e7d22ca2287cdc0000000049483045022100f580f5325852c3d80d6f78a21664cdbf765fc273ddd72be9335108ca7718b53802205656b109232bc8859a3d8128e31e2ebc85ebfa6224ba3641c45e2aaea91a480a01ffffffff09b251340d278457fd3c9ba3c7007e068f5680066e5d1e091b0bed6a57a4277000000000484730440220629e4e1a26324c6dc5282a9083b5287fb4fa136f35602a40a5c682688e5b7cd7022019b4d90a2939a5e8e9543bd4004657a8066dd0d43db41dd520ee13a1e8198b3901ffffffff0aba9f4046c797f01ff4cc7f7771d869a886806ddd775551c839e104d513832c000000004948304502201445b5c7a6e3aeb13ffae903caa1b2a82d8b5abf416bfa40e813ce2cb60d7818022100c33d7dd462f75efbc1902be243a85b1481b039402c2986f7f557c98251a8029d01ffffffff0b8da084454bbc6af5e551dfe3df157c683f0b1729e3a8aaef08c9a80e4676a200000000494830450220414ded20ba52fa1cd1600aa730ce4e325219bd41f81bdd59b60d6e236ea188c7022100980514a6a4b7019b3a21846de2e096846d79572a81de83b1851f3122677a3c7e01ffffffff0bd7e195d2b73de0cc1decd079aec387c1e8aab64cedd57e661f77fb8a7755c40000000048473044022017acd915ff7c9253778e09cdf1c62b73a5da96744528a17c1bd04c9dbce8873902205558ea3241ed969d6778b41da3bd206b51d1667be736b7e39b369c9ec6265bc501ffffffff0c0b1f7acbe5b0e36d3a5fbda186bc7f7ab2b5c4f7dec080f39be6edebab95dd0000000049483045022100ef9f56f02595505a5487af4107df7ca43482b711f66a367f3dac623fb07682ac02205d1f58c0b413edb57c694c99a529c1e0c221e972b37e51fcc84269bd6e687e8001ffffffff0dde06145d3ead0638c08c2622da19e126b59bfd44feabb2893475cf23d76b35000000004a493046022100f1fa9f47d4afb46d87e683c0b6e4fd189da7cd5710213a03f75e438d6447e8d9022100a59571356e76a431def133d6b04254e2489f4fe1509e4126c63afa69d9e267cd01ffffffff0e078594414952dfac431b22ddf57291fe9cd9ba8dbeddba3e1d30e891b26235000000004847304402201ea5261a27a8b4c99487bf068b945c3e60a6e4db0e4dd41c81cfd3f016a467910220425489d3e03849676c8c6fe8ca13bbb915ac8daf012cedbf55cebf394e054a6101ffffffff0f3179d4b728a849a9660ef3a88882d626f2b0148d594933d29f89c66d1423e60000000049483045022047141f5b241b3dc2cd10e6194cef69fc5a40d78dc66e6bd930debc8fd94411e9022100aad91a42901b92c5c7ad4a856046937629d1981770a6d1fab5eb21335e16fbba01ffffffff0f63e877a14f6126e47ac55707072e10a0f9558ee6d00a68e782a4ae70b8f34e000000004948304502210093df019b7f85a3e3bf5b2ac3c0496ac538ca78b484ee7f8a0e6492416e04dc350220516bf2de7107d45e13eaeb1e087232a275736854a16ee1e524144ed78e6f1f8701ffffffff0ff50d455adb3ca87896bb2430168cef3a43e48bd1b7880e91964493fa4aed1e00000000494830450220284f57f545e4e834610a991a1c02ebf96b825b9dbbb693391eacb89485956c3b022100d92c2570918235c16dc600cf281a7d49b79a1d5d66248bd7da658c334e6ab0e801ffffffff109c57babaf88c4935661ffbf3c984ce555d819b1bb26c553feb7da45cae22b60000000049483045022100cba664e829c7774790113ea9f9881785c6ea51d383600f0003f92ec24ded5d4902200813581234bce4ce303967a4defcd9a393437a6849f40bbe0f4b125d9d9f27ff01ffffffff111f21e83865abb3559bb5ac4155acfa3de139e4ce398891783a571b2f591ec9000000004a493046022100a6b43ccd70571ff70581eb012301cad22bdd666a502539ba965bc38de049bc65022100a3ae44ceee08a6026084b690d81e9589874606e7932f61638c33ec5a7eb1693001ffffffff113176ebdd7a23ea9427d3541259bdafb51cb6c24ac055cdf7baff7c0838ab5a0000000048473044022027b38917a4914276737e95b7707d44d332c011b098ec30bff74cd94c53ccd647022006c39dafda8d1e522a5f78c423ee59363976a3f97e1d2072f783b05321e636a801ffffffff11f56957ade2e74823918fb6afd7599cf1ea2bd37c8ee6dcfd69344ea884721f000000004948304502206bf6c92ba98c4d2965c77ebb2362192038b77edc1c2dbb534b066397d96bcaac022100f45b1578347549e3ac52cfa3d53ca50be3e35ab6fd81b944b6ced8ec8fa66eba01ffffffff13dad48a036898c6c303a8227fdfffadc0bfc1db55b1c46d3b0ab2a94c606137000000004a493046022100e4975d829d79b2b43c299c67ae9c01e348710fbeb8dd6e8773a30b3a443193eb022100bc73571f7420dc2252a2a06021ca67eaf2f7275100a924216c94756da612008f01ffffffff14c2ef236c3be3e87edc6f9349052f521cadfd2baa98d963b99544b3b0d7aa7300000000494830450221009e0e0b1416cf33eaa4661d10bce06036c408637eb17c70d09b1280b4fec6da1c0220653a4d32ea5ac8ce5e03344766981b5cacdd255c8d1b857cbcd05e4abc66c62801ffffffff15cb342ad4882ad2be80d189a38c8745831a604912fc97dade36064f69a0be8d000000004a493046022100e5c5458019a16ee9141348bd269d9f91738161afddcda2d306f735c0a0e3bb70022100dd6e00ac8b57e261f384440ed95f8ba4213b6254cb1856baf2eb40d081dbc6ab01ffffffff170b1c3b99747ca037d5b91f8229b593f5c86a7cc61cf0bd3fab014d71b5087c0000000049483045022100b0fea0e4d80da0bceba7f724c46fe70cfc7cc2999eda7ffcc3d0f711ac7293ff0220518e50b9be06df5dffddf862bd472b2c17b2da31cb9547cdd633a3cc4cd29e6501ffffffff17be32f2aafb8dee87478d9154154596deb8b5105bc7c96117b8f349e9bc0ca0000000004a493046022100b731ecc3852ecb837178a9e0fbb9a3aea51522500388d76bbfa68724484db825022100c3e3f3e73bf30268aab9b84292c1ba30362d5d4de644048cf28932ce7187f8b101ffffffff17cb465d1d2ba2baaca74c86fa0f066a7931c1ee6e5bed193ed3bd24c7cfeba300000000494830450220219c563b6a44c66e953f43f8cab445ce939be7f94da8d07a4d0907d7da71f5e4022100c0f972d2f809a3fb63b04cc237912eeaf2daa857c38d7c0562814e9f4b773cd601ffffffff17d049c882367bda9ea4f94652f285e2955681c26f2112503b587e80c74fdf100000000049483045022100ec8ef5b3cc00e88bfac22bf3c773509d5cb6df7595f465d083cd0870af7fb8e60220266be45e5db7aa84a70c83c6387f4e991f5b912bdf8028eef2f9d17f626e9a1e01ffffffff197f749ab5269d8c64eb38b424ce2786f7eef2c137ba420472149b5fe4f6c81f000000004948304502203d7ef60224d70c0a50c37259e12bab5d7702a996d5b2d380407cbc18a714dfde022100b25062025dd875f5e2154acf8fe29d34d5af2a502db76d9e4a54adeceb79b18a01ffffffff1a5c2c614068d8c401bad0af3c347aa4ef450697ff40576d91e9e3dc897363f1000000004a4930460221009962e3f31b77c106a43498df7b6efd7003f470481ea9ff8449dac01ab71060460221008640a8fea52a7f9637419a4e5a7f88637672d64b8a39f8b8a89014f80179d93001ffffffff1ac02c366c7442615f3ed64e5045187133d62e25f506203c42705cd1751d7f6c00000000484730440220583333394e52e8dcc46b788bc3cfd0687c85f13cc098581e8c4ca0a82c170f2802206197a715c9fa0a927b8db8f83d234547d499b7da16b4eae464dc29a92998eca701ffffffff1b5055ed70bbc3c5012bc0d0fa69442c8f608d2cddd0b0240d1746dfaf46ef7d0000000049483045022100b9511bc6a594aa9d9a3f03cef92fe55131c1a17775756e0d98bc1cb80d0b68300220647e7cca36a6edbc9a5923f3ad5ccd8b0956628e108c9b70d9b2b313a153b4b901ffffffff1c611a4d89478c27f13de60f254408798178bcfe7cad78bf513a5e6b77b20ab0000000004948304502201b5998d8c3256cfbf04458a17d2e54f4e7cba55bd439440620c81630192142c4022100fc90a7457187d9fba5d82b09935503d443cc204132d9e939fcb619950feda40501ffffffff1c76cdfb610f57dae30dce3a1af158ee5d0bb6c1f6e24e782ac3446e21a55cc20000000049483045022058992c7ccbcad3f2a5ce5ca6bccf2d73b0cabb076ae2234ff3bc2173443ecd79022100bfcf316fb93e5e56bd5953f245f1235f03c1f3a153b6750d112d8cc6e3aba3f101ffffffff1cf35ebee2080007afb90e7c49755a470fcf9848a070795233e9d25dd0a9593e000000004a493046022100e22aca94ca143a584b75777d193bf9bbe6ed1b3385a9e215ae4eab80c50d9220022100d5ed13181e54fdb0b02115a8dec0c21017372f852f91e30d077dce0cc1fde48901ffffffff1ddbc54431fef9d92468344f1c577652be343b01b6a48edb669dc4e0f77a605b0000000049483045022100ff7855751a64bd2f774b901d54baef30e45526382ebf1067abcde59a6da8026c02201238a4dbd1bcc4614513a49173ef5ad8495a3255c49f8012ae05b684eedb93aa01ffffffff1e0860e1d9067d3bf9642b1cb65183bb2304ba65f253a6a675673583320f71ef000000004a493046022100a8a7f871ef8946f3c8de60bc4e7a06e1938f04302cea7159c1b22b8f01a52235022100b779229521ac870bd88e9de53b9ccdcab041c018df8de51c7c529cb7f838a00101ffffffff219119c0360f897a7fc0959a244b693710d42a703ca4c76ce4b38814fa3d9d5d00000000494830450220601e32ca40b406721e39e8b03df94eabaa33a5a69c4f68ec9e3f4f864fa88187022100aa29a74918bd43e019760fc8c883b2f18a8bc3173739947b421327c22d9c58dd01ffffffff21de433bc832d5318bb4936b7dc362c6498b5c26bd3652b6b3a309649fe13bc9000000004847304402205afd84d2ab4c7a9f4d31c10592d27224b91bb1b1163de1a0168f05f79c6a44ab022078b7d574eddd8bd88b0338c9253b679e5bb9c16629999f102e5dd6b5a05c545701ffffffff223d99ff54aedf8ed12fff91c9c4e95c73459b6652549826484145107ac01e010000000049483045022100f81075743f88f5a0ce0d18f3855e90f73257f3e28d152683db5641245714d0970220692c015b3f72393cd6feebc139ad063b2dae552f0aeb6de463caefc966ef1a1e01ffffffff2548c53f1c45df9a00772f473e8cb41b88f77e745a92b796e408bcbf1bfa5890000000004a493046022100f86c9292aa078c3ae5668638f84a82db3c45ad55e2ff6794d92a2906230784b5022100f47bcb1277218a93f5e0549a343b44b0a8ea079e331d0a829e101c30d1cf37a601ffffffff2285810fbef4ac137a875166f523f2bd0355b709f02f24c6375e843cf5640ce300000000494830450220250bf9f742521e8559551c7dcc880f8794f6674fcd4b71cbd4298233d1cbf2b2022100e2b70685f235c5bb6e2952014bfa432b09e1efdc38c115864a3ca1e7ca6ec2fb01ffffffff2377b68c40f026f0f8f9454c23d851d0960efbc910bdfebb934fa93b6671b2d9000000004847304402202db9e91c487e8fed3b47df01659c347323270714865f8bb52468f28770cd0e64022037af43fbca4b3bfe09c33664848a59735be51022580827fc8ebf9cdd63fec04701ffffffff23deb325745bce68db681ce40d9ce7438a46ce7f846fbb9bb7467ea9890a800e0000000048473044022011ad94021217ea257b2b49f8a20bc2688011dc9183d8f57b1702526751d2ba6202202fa5b40c5c2c84985c7b0b7fb654008e9aa709631fc7e3cddfd091a7714f743501ffffffff2410f743a82f4fb9afd822d5b183a7683547909bf1f9b3726b910f434b7bc1ed0000000048473044022045fddd6fccabf46fb72359ea8dfee9eeb6b1c22b3dba920c7a63c597e4ad5b9f02205f3c8d6820e3ef2b16207358da740a8ad141896100de1989d13e238b8442368d01ffffffff2459113829f2d932d34c7dc04e28fe02e299684cecbb69a4863ee5214c169267000000004a493046022100d8ce5296fd3727128a2f7d099687682f464edb9ad3d660ece9e2f156fc347c0f022100d56d14cf7058f6d71f0ac25672989cd026cb269597e58038445a78a3b7a9870d01ffffffff25d4d2df1a58202a3736ab9d59732c91f2c58bd5d5de6301b5bf33da874cd964000000004847304402204b43668522291497fd49b2b84d59a69e0b97e7c5406f0f9eb6f14c542b02c29202206b8e140204528dfc1047dc81b1081f0af9deaa8cc6d69cad5fbd6d4d9218aad301ffffffff28299b976379a91217086e07185fc3d8e8b78f01e4eacb8f2967473011b98d3c000000004847304402202a296a750605204b97bc572a38a247ae49c2758574c026cbc9fa46cefcd9fc1a02200dc819c77cdece5c7c5409961675903bd8a71ea7a9427771800d6e5a42d1c90101ffffffff2935fed7815aee4c1027600dd0c3b07361258cb10e84b977cc745dc23292489e0000000049483045022100d1fc7e8c0a1eabd8cc5faa9e04990b5ddbd35e65619318425066f27e180cca4802204a722630b8af36969fe0b95086c6f1060662d2cd09c5cfa6801f7a3f1a7a3c1c01ffffffff2b615d0c359e4dd0fe62be58e0559186ac293630b6d10075ff9bb781428f1aeb0000000049483045022042bf19cf338e744d73b7a232d4ba4bdfba9ef88c6679c0451f8ec14a7bb6b36b022100afa6778b1f79284cb2d6ea6eb3e5856697552b19f9de578509d530f658eaf82601ffffffff2c08c5ce5c19dfe730300f94ce36c1cba9c8b654a53139327ff7fdb6731fe0d200000000484730440220456be726b91cf1076da13baab2fea1b6e1ef6985bac8da5788880643d7a2265b02203fabc2ecd9fbd313cb70f67560ff3c9068573501a0dc30abc1b22d1febf7433c01ffffffff2d51542eb75ab7d5b7b860d04c02defeefcb8416a79712d604e5b8a0951e2aa90000000049483045022035467e2ea7435ec6ce5fb8f765bbba679af3054cb6439cf5ef31994e26135ecd022100afbc66661356272f3b88706eb2ad987c045e0b189b1dde906e24edf8b29c0cd301ffffffff2e58e49a831d30ce6277da8d272aff40e18f2c9adcd1a137f568ddd1bd8ce5b00000000049483045022100e4ff3395dd6cea71329ae4c7cc89dc9a928241cd0d395292b6269be0b802bf3b02204d25bf41b86b257e3b18da2dd99c5a9a0e6fd2776ecc018912a6460fb77ae43801ffffffff2fff50ad3b5122670b158f99225d88d6132f03d55fbd0e85658b9f8ba521e3cb000000004847304402200a446ab0eb3b2b3d545e5b3732d9640590f69f9dbc8263cab41a134f7f8f5d46022006dd68f100226ca6be75d0720d4d42ae2240ce6e0d23ce1b2801990dec99363701ffffffff30ce9323379ecf2b87c20d873b0725cb4e3866ff36469275dfe307f4a96d6237000000004948304502203f22c3422b83c02f69be11dab0df9759a3334f3b076070ccd389a2cd08e3d0320221009e244cb793fdb12ed5a57600bc85be53f0ca5bebb49439cf97f60b920a1c628a01ffffffff3144c3c30d3768a4508ca831d4654d767789bd8bbc00a18ef3d7c664b0f8c420000000004847304402204674b149c85ccf779353264cf786ba17d08e0380158c0c014aee3a1dfc20a0620220310cc53a1efc9884fb025541f59e68a38567b08fdcea507e0c9d4cebc21a3a4f01ffffffff3146fcfc83c88f7a6799ede0b85a045100df39981ccf03999b94e3eb1f3d4022000000004a4930460221008d79900bb7999a70e3ddd14571eb37619f3592d0e27b3774f6eb0fe7c9449d1702210097c35ca2693b5901ef3660efda5982c7e20d7efd0fac4b01038d693c0dc84cb401ffffffff320fcce1d1093bb222baa555210713124a2557ce2c842a94576801a426493ebe000000004847304402204ab92f79305cb078ac6db4df69e303c5fd468479525962a31c6916b1aa4fd25a022072960a2f467f8b64a3ff626d8dd788cbee6262b6693674d10e458712669ff66b01ffffffff3429d949baea8ee99db576a2ced99b2fc36e8e84786e26fbb566cdb12c5c016f00000000494830450221009e7730b0833919fbba6eb2f47f9eaa41295abe93e3e6089ef45729417f96dd89022064128b1a2b99616a2dcd0e349e139b4a62591437667a9e9655d5720f0d7c713b01ffffffff34b94c40d17e7862e9355b31f07b4b141b377d61fe1704b871a5f887b3ad83b300000000484730440220635a4b0881e97b030112b33d35fc9d881ae4d86f1255924e5b82a2e15a4bb20f0220792d907dc1a9ce8a1e737fc4df4814c341082deccd8788a7c39dbc8cec0758bd01ffffffff34d9a1833b63904d3bb83fc76ecc284107f2234b6084a9889f9516c2617444030000000049483045022100a79d181436b47307ffb0cc670cdb52b8e8ae2ac6ae7de9167855610b720997b102206270b50046ece68f548f0452734d3d66f13e62f166389d341e96d565a6befabc01ffffffff35e231ab85c78ce4702dcfb5cf21ef2f6a83205fa28d7759732bd7af641c903c00000000494830450220633081d9e2d9902757f5315cad8888ef3982ae2d454f76f42b9a49dc1633c745022100db2361b8338cd03b94b25439d13c22d0cc31e08a7d1e85c812202a7db2d98f5001ffffffff374f258b62986d40f1596532151567e3084ab391177e7676ab046e6e2b7cd5940000000049483045022100f02ae9dcda24c38efcec18f5fe4a33d47693950474b0e18fb37691a49a4f79c402200badf3a0c869fd0ebda2c0d8992d36274e80745b21ee4c02ee2398c56e30cb9f01ffffffff378d58c1c9c260669fdbd56339cbbb264c050bca1a6c3a082c64cf9b0159a42b0000000048473044022047768545debb93a1832131eb0319d5b93be4c72faf8ff725af27d322eb102ad502205770eb7aa8143fab0689a5e924925c807d361510d44c9b4576868b1580eb5e9501ffffffff3853778f7a2fdc1eb561f63ad47ac315352cf7f1547ed23c6f47c67d8c7e77f20000000049483045022016f9927fff7dc6e411ffc0f606bcc8ed329831c5e3885d019235393e1a2fa32e0221008a8f69a3b16636927cbe371685423dbb032f1379aa13f856a88fe53b9564e15301ffffffff38559bf90fca05559f52051a816b80059a96e86a742226551e058dd7f7f0ae77000000004a4930460221008e928e54ce0e86957b1079ddd0209e9f68703756b699fc8de62eb2f949272ecf022100a6c728c9f758b65a5d102273388bbebb199bccd83205bbae7504f4686f2db8ed01ffffffff386ab7b603b4d7a7ceab0d081e2837e73bc0d2a7305560d4533d49e1b5a4614b00000000494830450221009cb8a7bcf22bea339ffbefe8117a76d053658ab8eed75d036bdbb2ca0e3ee06a022008513174c29f37535ca606b0824f5facd9c8fb8969b166b70d180436ef33f06401ffffffff3883d6e7ccf75ba8c1ac49530c62cc413607ba2de9ee528663801f6d05ea4de20000000049483045022100c29731e670b2900420f1122b7f6685bbb35b954ce60597e9f921ce9f369a189b02205bcd8bf950da8f9e62f2f1cba01fbc2ed07ae7f823bab970e164306c6c10507c01ffffffff3aec17b58f54406e272ce5074a28fe0cb15e7b76317434173b2bc49780bc9a880000000049483045022100d11515b149fd32555895327e53659ceeb84a2f097df8a2dac497bd0e1d46a1cf02206fe658cc9d8c3cc00c4e3f2dd3a93c3210e0bd58934ad4bb8643572a2a5e0edd01ffffffff3b049b59e1ce12b8feb6464b54de64a95499d7d7cc1709a4b8afe5806b4a6595000000004847304402204a306cddbe7f291c280b5bd5c0da9abb33be43c0bd2a41f777f28738a858a46a02205536ecb196ee09afb4d4a86e0d116889975407cafc1bc18180682acb5346532901ffffffff3b05347eddf62933522fbb0ec0b38c855c3bfc4f901338a771b2b50716b526280000000048473044022024757081b5626b87eea8b88dece2d96b81579135b209598424d030edd56e28b0022079057f2e641f91c02c8c858ec2acc8f215488b14f4a4e2dbdee3d8a00172c64501ffffffff3c773d9e8085ba4feec24cde3809f00285fabf33c526575ca3177d3f70674fcd000000004948304502200ad9ba43d2a914f0121950d82743975bb05f3613c23229d4607e02635414ab43022100d61e2031335d67aba044c7dcf7d7755a2d88986d2f2f8e01c851e70870a853e001ffffffff3ca63a49f677d7f631a476f45924f25af9979e5c43841cc73f9d36717fe17925000000004847304402206cc93fccaf6b56dd152e1ce7b22d6ee16a8c21a2a492701c2679f4453de3b1940220148c95066f024c476aa45eeda921d21c3cc8f048a06d0e7ece5c5069e2323e7e01ffffffff3d9f5f7288a55bfc86a57524597fc7b33b38bc0fabd3d76cdf553720efea64e900000000484730440220477432a990fa40064621f2b08713c5b1351fbcbde4d922c8f558894dfb12eb280220169df9bafe8416c5cf9c413a325bdbc82ed3d4153fd7e55cd9f67e0296cf1aa001ffffffff3f5accce0de9a6abc7a2662fc70ef5d02bb11f8221bd24555df9180b216798fc0000000049483045022100efd12021f2cbbf0486024d29bed5d801c6bace15102a5477c149f1508b08c7fa02202e54c56d85f5e681346255c1e287bdc43e12b7fcb3515f09cad84ea3236f1de701ffffffff40b9d059c5ebd578d5a0936678ad1607644210fb1bda944d5cff81984a25741800000000494830450220014d058ae79efa6319f985d3efd0deead3dd50940b05b4fab44a24b531e9dfd8022100a79d6684ce96a96f3ee8c6eb8d30a09a2dbc9b3c1b433bfa907665bf1ea7d98101ffffffff411a0f78818504b195b6b12ac15cc8538dc2f18ebce281dd947c54657262bd72000000004a4930460221009fde5a67bdcffe0395fdb277458a8f457a2880c1a1b7d5e1bf5b28c43092d328022100ec774faab54d33ba5a03849133694ddb8f691af8a92603d1fb019d5a7214828501ffffffff42a212b18ca54d517b1fb6eba0608383f04cd0e8596b2e6896e5ce760b6729ce0000000049483045022014a30edeb7ed1d5a55f71bb3ea2b3edcec6a02495b1f9e2dff3e4ca99ce41bf8022100e3c203cf34dc48160b47948dd60c804ff5ed7ee26f68692c067bd21627fb983001ffffffff42c5afa16a510f47bdb1c6f576c61dd8cad6eb0b655269805c81b3ffb4821034000000004847304402207aad4748723ef72d416b3701782e37b7f1e29a430c8b2293d7b09613c5c4d75302204ff9dc660c5cf145fdb52f96acf4ccea66c2e74fe577d84719f2b538a862361101ffffffff44a715cb6ff301744aede59490953e1624e74a5493666ccd8a6b41a0396e0644000000004a493046022100f1d6cdb564db11450a63df24829360ee40a59b3005c20da0e703104360b892e5022100e4f16987ad4929445cef41a79770b18fe06dd80551c69a20fe6184d2101e8df001ffffffff46d55f813ae2c85d47691ece94f5e06864fffd1c2f6a57920e120f3ec5a7cdee0000000049483045022100f5121f0e7502bef83ad3ca9f35dfeb762eafbc83b0c9c8adb60ba3d54ab7e7db02206e812d0e3f90325366f2cb6b0c730aebf6abd3aef5ed048653745d8121acb4c701ffffffff47ec2df763ad28de0ae20c8069077a50c9c33dd67409def49182104cdce821080000000049483045022100a0d96e4341a64f15dbc218414c3d39ea891b914645e073135cc3025ba5a1ca8502200689d53ff0b577a69fc853799c0058a5f6dc893b03f36914c54d857fc474755d01ffffffff47fdb84e9cf4f404fa1885efe1a46cd6e6b5e4efafbde99facec76dd8f4440a5000000004948304502201c5b0d80e127dfc36dedea06479776126438ceec32b2f91cefbf63a0b72eeb30022100d1edfc187f5f2e2af00319a6b07af54b3d6e2686e9ac6b668af6f684ecad662701ffffffff4810f17f4729463ee1d972e3ed68ce7e75d98339d15b762d8b5d689a0b88619e000000004847304402202ae40e9a327fd7daee1637ff328328b0740b5f66131db3216c2863d24f30dc7c0220226176b5f9ff24bd5150e965734cf8c24265b54c652f3453a509c7d2e4ae60f801ffffffff481e6afa95e2210aa27ae0a03c7d1dc9abfbedd196a91116dacdfd513e8dd3f00000000048473044022049f2121dd7d1b26860534770a39910c2e1e66757d037787ace9ddf3160c4371f02204b29d34121932a3e545dc3a6ab0c5090950099fd2f5ff151de2b6c031915ec9001ffffffff4aa2115365513bea282f46b9cb8c8f4d03ee3818d79a69e19d6f5f4207913dff0000000049483045022100d0d70b563592ebe1ae4cb7975d27ebf30c5cac3a91f1fac0df6533b4095774ef022002128a44a44f6abb7bcf7e76669cf560556a76eb67a3487d1b723c8a6eae7a7901ffffffff4c19f7eac355d44965745d1a4004966eba039789d057c950a503443946bfac7b000000004a493046022100e5f732cdf9626208ad06c9a3b416607fe464f0b08aa2f0daf439ea8da86ba384022100985541defca2912b50d9d15304ded34508d7ca93578b88bcd906075b804f735801ffffffff4d39bacd6e966ec4d9ddf73db6b6cb92ec465631eb46548a6281ab226176b0500000000049483045022100cfb1869cb70d4c0f72e0c20312c7496ea1687acacc3c5cf2289f217c4a6eff230220459e36a42c1cf58a3a17ea850f1add03d0369c020c9d06724291e08b97b3fa1401ffffffff4ee2b7a2c4445abb510f463e176f52dd6be2da69926703634ae33141da438a3b000000004a493046022100c4c34f84608cbd785b7323c07b246deb33950bbd79c59c925139e6d5637129570221009d151bd2bb270bde5dc3fb0d02731d79a01571908eabf5905f73f524337c6b2401ffffffff50905a88355792d02ee9ba8231050351a8516165b1e454c1f03804429ce993a9000000004a493046022100afff3916d15748e13684c9099f552f890e399d355a71290ea5ae1f71d94ccb68022100a8112e198317a61acabb0745b65bc11cf959717dd13c4562aa8555558f0ec55001ffffffff523e410dcf088cfb53612e71066cb7e26433fd9c668ca6aca65288bfcb2e97c1000000004a493046022100af1a7aba19118b9f27a75d29696dad904df90c41a30adf181b22dc2eec0dc75c022100851ba1ae65ab4b325e379a04a38c9bf6021637edcd8bce34630c46bb55e3647c01ffffffff528620de61f0ce1ce5eb01441acc7f7fc04366dd7ea0f0a0753cb6e9beb0fbeb000000004948304502210089c0f44584cf985e313e92d94336e618768555cf99149b0bfdd3e59fe0790f4e022021d3dd03180764c11acdc22cae48dbb9d90a1ed32b5273b901961f7d44d077c001ffffffff5337e957bb0599b78035031827e66ca0e065f961f635a89568410822144ea615000000004a493046022100b94c97fffdfe31e96cb0a60154febbb8f8720e68d2d178d6576e98e28cade804022100802a5843ab618771a24bbdaa68ada2cfa99f9bc7bc144087dabfb83a6c8c99db01ffffffff535900e57226ff5b43a4cb4e2401b4d6955d4b2d04f2aa1691be7e38b0c8e9690000000049483045022100afd1de544d66099b0d2eae1da32a5de4facb1c0f3a5ebcd5a93e5ce81e69694702203f123c795d74b83c19f7a3e1dfc66da52187e81cafc11917547be3ef85de137301ffffffff547db3034a5f0609acfea9d1c6c036c644507a908f41236d306a8c8bc44fbdde000000004847304402202e8827fe100c29a7f739cbdfc1034588da8d3abe3b3532eb59941709ff9242f3022079ce527c711c4634e100108d2274158728b238644d4c519babf6d6400dabe81201ffffffff5567252ffdfb2fda254e2d3d316a430918f2fc27b72f9213bfe48eff11d9c52d000000004a493046022100915d6a54fd76ca40b143db516e1b450e02f72714ac82c4fb2864c213e498dd89022100d1ecc0c6036499974368973fcceefdccffa6aea3f8667ea9dcdcb1ca71a8e22301ffffffff5572481b8f1412edc74862da66d94bcddf0e39976c9249c4921d7e3cb61f306a0000000049483045022061adff8a0d5d2d9689802b8cdfaeb4ee556e0bf97e944a741e118d88e1385e4b0221008d714027cd61a6f690caf331d4e81e3e432757a3c7c3d8e3b85e5b74bfe9ad8001ffffffff5616338ecb798a6a33c6d2ec0aaef0d2ebb0dedbaae8cf6c87e1620b757b38e90000000048473044022001fedad59099d6283bc172022bb71bedf12a4166c39768086e72fa592f89aa530220100b76af8a44f0a74f3c1456680c04ccfa087bde4c5ceb8e090e28887892fbc101ffffffff564c413e7fcb2d77a3b7ac3afc9b0d90239a2b8bc99fb4e2911a33795c8f9ee3000000004847304402207a11d8e7083cb23968512c4a9b0a4ee0d6c2ce8d38a1c1eb22889d240382473702203be810350862172a53cfc8d1abea75efe0d45bb3097ec9cde3f0445b856d37df01ffffffff57c1c9dfff243b86ed8dd2e191ab6e5aed652286a85c4445c218b86de58c66ec000000004a4930460221009028c54940aef8581887d273e2e6730ffb43dad1a4e50bb2fd8d512b7a3b9a7d022100f2aaad54d84f8627e841bdd38ada0ebf0410869e9d489662b8a89530676ad50701ffffffff58fed57d82c05b8e9d488fe9ac428fc07d9deb9f10fb133b4594573a057e0df1000000004a4930460221009dbd6b994f1e81ec6e716ad6ee6a4a66c365b6325dba06cd4bbf04ef68e7f066022100a3767c2096723a0378216299a6a05eb3a18a06750b7775903c9abee07e430c0501ffffffff5ac1c0a0923c76de161d14daf92a8dc598a74c82c4a669f37ef04eb8f6535e790000000049483045022100d94fc11426b2be900c96698c1a3613b8164ac04fd07d1d815b0cb6be5d99c1bc022064318ff8398d840726203dc82c8395cd6f26e26746f6a14d0fb8af8ae273ce8b01ffffffff5b0b19d44a571728441b07a5281b853126a2bf5cd5a67b4a37ce505e37148d0e000000004a493046022100d5251ad08d95ac5c6494b51825d897cfcd0a0aab56f8cabde201f78e5ec4f915022100d4ab5487d0f727e3bf2bdb66f4d91da32579d0718fea073f4cc885e23bf2775601ffffffff5b380a88e1bd5ebc452b8abc09892faad7a2f630c1ce135f586ff7cdc34d34ca0000000049483045022100c927d731c8fea2f1f0c27c9e6be043f0e50220caae2a76555d38a007b76e6096022030e24af3dedf0a73cf76c270740b22e639b03fa9f0abbdf4a589011c0fbccbe401ffffffff5cd51518e1c5762f3957b56a0624b17fe70b72b58d9072bc83b88c5fa295372a00000000494830450221008901b86ffa53effb84b80e15079d6b3fa8e15fd4a01a2dd0ceadb1314c599b3202207f6de9157655c6e26f3f9e418f18fb774790185c4177ed6d1a4df1eb71ec7a0901ffffffff5d6273d795241a501c887a6d482d3226bf11bcbb0c1357fbb366cbc422a06aae0000000049483045022100f84560bcacedce549084fe5f799889d53de0e19ce0c5468217aab8d302055c64022055753def4c6d1da81382f0e6110aacd4acacd1ff49c475f0ebc15457ae6933d701ffffffff5dd5c24174490e2c9a35b6ed1b29906ecf63ebd078f3df24b7106be35cd2a7420000000049483045022017ccb4b9a7fdeb2e73734fb30297eb1016aafdaf1814371ac979eeac4a04711e0221008e1ea23123102350eceac91c6649ba1001ef70b110153ab760cad2331f5f1d2801ffffffff5e54b42a11795cab6873821a10bc0357a45592490935075448403359aa117e5f000000004a493046022100a9becd71c27dfa166936f6a3ecdbdc2be8b75cc5af91e45c925a13d7426697f0022100ca77427f285b4b7ed3cf60d36dec1af3738808c51d7ad764f7fe2f1559b4f44b01ffffffff5e7579f0f08154c23ca07adb4ead638ceac96ab61ce5e6cf3b17953c6ff521020000000049483045022048ca5f0476a91df151cf8b0df67362e17823bb014e3a7252935f77880c9a34ef022100de66e18aacaff39847e83412ffd5888c67c26dd92e47a3bda83d86cd12411b1501ffffffff5eb262213788a1b55ae60a0d097415dfafbbf91ef01c40ba2d34a3b8a5ca2c1a000000004a493046022100b1baa30275d97fe5c25bdc03b1e52453c50d30b42eb82f232679c25056176a3f022100832352932221e0b02dd62890d1bbf80121fe3890b68b991ba91710ce5d5e0f7501ffffffff5f4ce1476a6b51ce9122ba5210df7603fc09d1367e068f3538a35e0f181010da0000000049483045022073b49c5db4ec64659a6d48205e0835f0af8f5894eab828e280a3ad20e59428a50221008d879ffb07f082d0ad60b23bbbcf58b7a809919c70ecfc350868e7299dddd8a201ffffffff6005a76ea6e1e23ba6c13e74225aef7cd0bd57d1c7c43efc8cfef0afefe2bcef000000004847304402203d83dc6b0182a020606aa7dc1f52cafe06e629f0be545929aa4cf2a83f5036ce02205a5f65d79a1cc3023ea72a5f1ec8994370fe947f3d764931beeb457ca8c23cb801ffffffff614bec9bfd12777195e27e77e23d1bdf2d7edcd98b1db281f80f3cde448edb2100000000484730440221008ddedf4f36aeeaa450b366e87cff95cb28e986f2cc111565c7e47afb39205736021f12cae92806b5053c8cadf8bbf2cd15877fd803b9e486faafbc42ed2021066e01ffffffff618b8c527a187a37be956c46dba6b09ed7b4dd2cb6e13c20077d734e6edd9efd000000004a493046022100b616b3c8574a2212227126cc0cedfbda0496732b1427f450516a888c8b501e5b022100f378de0cee8d96036ca8ed5e6ee593e36f62d65f97a55056e7716919f8bc095e01ffffffff61ae0f276f1dce326a3e7e7309d1695eb7071e9f6a2c71b80e38f1c1b524460c00000000494830450221009a8410cc4705c983cdba7e17dbd25586c31d3bbde94f6ff382e26caaed161b3f02207d1b3a8513c85390e285149999bfbdb141a6420575564c9fe9e4dc14dbc1512c01ffffffff61fb0b176b89637eb6189b434618a03ab9bf183a9334357aa570bd7d87673dc3000000004948304502205793a23ba5e8007699aa90f90f48709e01bd189b5dc3574d8341b2c2dcf8822d02210088a060d4f5459f1e05c2dbcf4ced83de4f627915c2b7e89657814c7c834ad98601ffffffff65a194221059cf948f788e75cd886682e655f6e9cff5ed6ef21eb809525ffeef000000004a4930460221008918dacd51a46d9706bd011ef43c91fa24af6e49118ef870cf0d5396205109f1022100a5668de881759331c6e5e9c32b6cecb10fe82e402d461fca7276e7895712aa1c01ffffffff6648c3e5237e45a2325254ab578a7e4bcd44457008ecd29e21d9b463668ee3b5000000004847304402202b3603312e48b461184c3fc66c95ba0246315e3d0cfed87eb9e6d0006c393ad402205c789c54a8393a0887625a6045085f350b42d3ba0ee5d1205057c0e8855da56101ffffffff67e8ae39d9b9f33c1bfd4ebf1affb4855a278c6d18d62551169f5f00a3e8c46d000000004847304402201b3c6fcb64d23b28abc387cdcbc3508d75a2dbab85b0a4d9bc65d6c34df1b48e02204cec16ea26179765b4bd02a8834c25b4c7af4c7a4478576d811f3952b5fdb92501ffffffff68aed869586c38191378c8cde2a1d42c027a6aba7d30c688fa333eb938c94f4700000000484730440220545db6f7ca869152907d081275dd39de85cd6e914f18d64861dd93ab07386a6102206701beaa9cafc94db98f6a183560f17b49b01fa40bcb43ebfa5a8c1715c910d401ffffffff68fab6cf015f2f88ae71fc072d6e590ca10688bc5affd650a3b299b09be00700000000004a493046022100d4279b2df109d59ed6e1685313e25a1c84579c17697798d5ddcd034f4e9f5b680221008f724062508578765262e1787d0f26357c86b35c42369b027d1a5a4e34a9bec201ffffffff69112eb489f47853e7ea3ede673c1e2ddb9cae06a23b0221fb9bf85da1f844330000000048473044022100a112e75d4956a47de08fdc90e1dbb5de5a8bb50bbe545b7be221ebd08b0535d3021f39e11df258ce1d9e975444214ec5f48fa7cc39b3300d472a2602849cb5e85501ffffffff6ac99023675eaf4780574d932785fc88172c410cc3a983875b6c07b8c3739b9a0000000049483045022028ebcc36c6cf8205a2e2866d670ada71251d23aa1170e04b11f2bb808e418d98022100b6b8ad9106e1544cd1d19ae67d031c4e540a55acb812a6b8f53f2342b8bd03ee01ffffffff6af898070730f0a3161c7f275750d7b39da8038fc451fbb7e201ea8146655cd3000000004948304502200635c160a3dfaa9c6703f6bbd573b8d2ae9c8752a869f860377570b5acdad95c022100d0477fb021b25c4579f5c1f8cd4b4c025993a13c16c14b9760f63fec8a46d6c801ffffffff6b0385725103e2bb0009ba5170d14d1a134b719af97eb645769777cc5187755f000000004948304502203c0b9ba10d12bb8ff0e96d1bfca044c8bdfeed611f691420c6b89e684efb6b82022100cb95a2b2598b540266f6dc0a1902680745ce101758b94fa8f5b46a329c4f79c401ffffffff6b6a7a43e51296636b542db5fe39af97b2458309bfabdf3e23a05277d8d8559d000000004948304502202f7a6c44e9ed089f82905882aaaba1d2f5aa3fb8f698ce4406149d76bdf995370221008447743c2d5426cbc03ed2915dd249ac7fe635195f40c41168c59bba8a21d5be01ffffffff6b84677bdd145ae03a07d9bd9bbea574b495c664c84d03f96e0d15d41c248533000000004a493046022100e4ec32a21eab5da3a1dfcba5a9730a129967cf58a32675610dca168539029b2a022100b412aa609e332ff566dc984a7fccbbdd64f4763c6c7188b36cebec2885955f4201ffffffff6bb2eb9d4aac3498936fa9254ab745e05df05e6d643881bcac3739b8b62af68500000000494830450220319d35a07595033c84233289d7df30c1f30eaa207b0d8b7b71962bff3539c0c602210094b84a16caa8d4547ca8392842462de161374ce9bbc31b94a71c3ae20632844e01ffffffff6c1f3e30a820fe590de95bc44807738bb98f27b5cdb20d17937c6c6f07aee32b000000004a493046022100a47f072fad2e424faa13fe1343524ab35cf3c38277c0f68d63b8266a43c7322d022100d0c6afddba08c8824e8f06533d5d64c132bde59a833d9d9224dfad9cf6ae39b001ffffffff6d6bf955f1750a3559677eb866f61621e4f5a64ac02da77274670dfb4f41b6b00000000049483045022100c62d70af9f414b6de5e898f9cf9ca9ecb626c882852a7e931c604baf35ddc9f00220352b008b54289c0463a0d9a35549ac43417f7a4f9974778d0964780c82f6397c01ffffffff6d76c320b36167a3194786e5589afc9a521574bfdc12857b31a988f6e74bdc76000000004847304402205c24fee84e28de0629f50a5fff584b620218f5ae101a450b172055bf820b7a0402203248972f6684cbcb3a1970af90bc130f55a3a00a1404a3a4c7f6be40fd9a3d3601ffffffff6ec5e0735802311c2d1c4789cac733c6e284b424d2846eab5ec87987a4978cfc000000004847304402206d678b34cd05c0c7026c61ccf9e62e9f2e661cce849785834239fb3adc8921be0220218cceef7936363253b2f07936b6d04b05d8ae7190f1aa2abe5b506934a8ccb601ffffffff6ec97793603b95cfe0702c5496c0ed626491204181d482678e93212d08dce323000000004a493046022100e1e215f05b49c78d9da3b8dfd880aa7c9fd218789a0081420ba2fa3688450513022100faa47cc99944e9691d78ae286bc0a1a8db46cc20a62f9db5a8687b8aac6925f601ffffffff6effce7ea82d79d2db0c10a62aee1ca53512c8ade978bdb839c2dd44b96b379f00000000484730440220498894fe5cd8b6a43da8b6eaccd40cfd1ec721b485deee43995c502c0859e5ff0220744e8751831ae38a8615920a8b99ff1b65098c721379817b6afab49cb383659a01ffffffff6f81f3705e0dd329d6690523c39ce1372fc92a4907031a1d33800bd29d93c4da00000000494830450220686a2cf596c4b05e1a1c1ddfcf5be79f4e424f6d84987c363e265ba5448f24b7022100c05918960dc9d7cfe30235fbe8c049ff990c95b929af0f2f55e26987290b572b01ffffffff72d758776b820b59bf7defb2d527bb66eb4dd14c7d4b7a885d64faba988fa076000000004948304502203bd9597fbf68f5299b30dbc7f75307ee3dee61b4bca52efeb9467f678c22edf50221008e7c75467869f280ce876a8391fed13ad70b3fdc9af7fa35c947d9079e5f4b4d01ffffffff735918d7ce602e6d65db8a93aeb975cc6b427c0f315cf04bfa048366e9b9fb7e000000004948304502201eaaa1ca347f8aec501954cc13f28d2d11fb1edd78fb53b8e1e08ee57f5cc72a02210093d3fc10a5f50716092c5328469fe50ede62e89f215fbc7209089df04075474a01ffffffff74a349c5340b8dc2692a4cba9fd8000c78d1e0572b9bae7a8dd06d10312a2f78000000004847304402200a3eedeff6ac19698807a97ad5ded5f22765f04ec02cb19d2c1bae3775ed3a4702205f9942028e5722c726536c57d82937c30abe95f2fd1e7f3527793471b06d400e01ffffffff74eb232f354e9f8007c9c62ea9ad1961b2b70ab71f3afb9444c4cdcfe4a8e6700000000048473044022056ce78585c984f5e0a3900f53adb075ab9076a34b831fb08a30149e20d7136ea022025e9c05c362ab9d10853d085d3bb8b2d6ae38ca41bdfad4037b51500c5173c6601ffffffff75378dedd597cba72a72e45e516200fd5bed2a0d14f108b29d7ea19fccaca8a20000000049483045022037a46fd5325b8da53d2f09357208b104f3eac5e0feabd7fabd9ef09e3c9a508402210092e4cab8e4a9edcb4b9adc5a3d10e521c977860731886f2777e2616306608a1d01ffffffff7619795eb77515bf562559102b88846f6aac11fe5aa5ee1b5890eb3f02b62ab8000000004948304502206b61aacf30e64d956b7313e8b4151d8b5e9de409e836077ac1e5d38184c38ca1022100f2eef7a5044e7eb4e25ea2733394d527e0209f47a5b0e55acc7b05d1c8718fce01ffffffff7710eaad30c3948fce5afe7453c76294427ea5c319aca1552890dd7d92e603180000000048473044022048c5073df007625bdda3ab473605802d4a5ca9cffa3adac97fb196c5ce5b1155022046def8509250db255316d9bfa151a17513d09412f366d75378d6029f105bdd3a01ffffffff7737da70e78710564a4c47dacd14655d2acec4f4b53c821b2c86094aa315fcde000000004948304502203d79e75c09f3e70164df36f0421c72f18a0811f2f18d58ae28aba593efba6393022100f73ba18c6e5f22bb8e85362dde6be69406fbccdfa20114df31ccb64c044ce3c401ffffffff7756915c6251a3434657efa25900dd5462898c6a68d58ec837e065a6704c31cd0000000049483045022100a5e6a079b655b205e7b5ca54669928a829a55c8c93aacf3bd0d4edf364012ba702204bbd05ac09535279a9349eb2782aadd6b8ba1f974bcb56bd2162d38d1ce5350b01ffffffff776e71e0a0cf2a1872ebda727c562ddc88565336378ba120c3d206a00cfdee570000000049483045022100d71e20f312a452ef0b28ec083c5bfc6ec1c4a328cd3d269c60dfbff3b8e99e3e02206f902d70f9b4bbc74fb7fa14734a9bec84d542f8ebcc8ed3223482ba968939f001ffffffff77893212cfb4a7840f484bbf9bea473306934913cfdfa2e9313ce097dcf49249000000004a493046022100c2d01dd5ec635f5200a452a275b1f46a64acee28ee5721ea6298f5df13035f100221009de6722c59d79b692a5de4546e9291d0e44daf83d0dab392c0c231ea39d30f2c01ffffffff77f1391f7640ad77a45f36110c4ab46c2c0e73243d2d061a8b01a02d77b6cdaa000000004a493046022100c26c580eab500d7ddf032ce44ea1e1edc8f0dde3779995c95e89885f7b0bc3150221008d885b412170511ce009c8334705659ecd3394a4502b52b704336167b412900701ffffffff781ddf8d4c52debb9814bedee3d7ebbbcd4b9598259fd4ae8f06bdf5fe563c10000000004a493046022100a870fb8584ae2f50869a311fd47e12f8af1a5b4b0e0a59ee64ae27481a1c9c300221008f23eabbc055f7a0cb732442b6fd35d957570235a60f63e19310e73d43fd785101ffffffff78942140c5d807a50eccbafd3b44e3b9644dfa234bfd9c080640d0297e90a50000000000484730440220179a09d4991ee73ed47c72d74f198643e9df7572c5449460eb85e3fbca3c3da102201745e29468369f9fda0cc2e7f78822b1b89edf63cac5746486cff980a2a6181601ffffffff7930da8ab07c69b1a41d66ac444df2416a60a3775ea748c13243545563e90b7c0000000049483045022100e15c5d637b4b2d1fc00a4acf39cb99010b9e043d4cc186127b0dec317ba06d1002206e50adc2c16f23d607fbff0214ba077717ed16e335a669b3b10139d14b4f68f901ffffffff79333e40f02acfcce8d35bbf220ebf4f97131c08259f8d4220a2563f20d325fb000000004a49304602210081880cabc6432c5398b3b5a13d133d763ad02bc5814159376ec1044528e19d1a022100f2faa8414b19166e3e48d34607e2384251d653c4214991e8d2ffbff428dbb74c01ffffffff7940e140edeb5d9c59939b724e02a19952394939655eb9f6d3d0b47963b4037100000000484730440220083d9ff2ae87328409f8ddd47d84a174fbd1ffbb5096d281e55b8d93c32725e502201e3a601ea531889684afa65ac96ae55fa3c3add50e9361bc4fc416c24f24fede01ffffffff7964f331cb2e1bb89eec8e2baee3a9532cc2e170d7dfbfb8f93ac7dc5d919c91000000004a4930460221008888f3ee1b60ee6517c4754271749261ddc94646dd825b08724e7b92cf3b5d77022100b1d10ff7065f1c9c5b73e4d92b2e223d8be1b82e755e53e807b56400d94838a001ffffffff7b03d068420a9bd242becb92c86d40ad55de83efb8daab77ca0ec5a7741b7e97000000004a49304602210094a0cd609c52d62d468d808a92783b0daaa867b864b82e67d016d6ab0fe204e2022100aa0f2e73d1984db311fff95fe3c65e0a2dc568977da29e9eee98038c69bdf11401ffffffff7b47e3cef89d67596905dd89dc87641bc89943aca9a0c2133e56ddd6d9a4f75b000000004a493046022100ad5cf33f0ea26f2ef51a2f010f175071d82c96922eac3ebaf20a8babff92b9f0022100ccc2a16760298c449569b08ceb4e49202254f4d94a88dba8c9779404dcbf92a601ffffffff7b779fe3dc4d613eedfdc7fc1e4b3fec85b1de5e7466567fd0daa5e0208084dd000000004a493046022100b0d09b4170ec15ac9f2eba454ffca582b2a6514fcb1860dc802c03f1abf1dd68022100b645981588f0a8253c1ae798c613ffcb05bec4b1218159ec194b7113204f83ef01ffffffff7c428dad903a6e2b6059ff1927653696eac1ec8605da8486ec0a09ed946def590000000049483045022100e09954e7d85571b71a0f2f8ac3815ab479d050996d1d0cafd01bda7edb1a443b02205cf27791973e935e8e47a597d762f7a279209d8e427d1f5a0c4f5ee81409914d01ffffffff7e88502fda8565c48ce4dcb8019bb0ed88afa8ec433ce7708d54475cac55de34000000004a493046022100c731b06b613aec4c6e3b72cca52a00824f2ecb5d568143774b18eaba7e6017e2022100e9fe6bde4264893e527ceb06d608332bbef24d37563305275394d4122654014901ffffffff7e8d6e2d9fbfe2e9137ee0bf44ddd9d52aa802fc2eb23dd033c4281e0d3b2ca7000000004948304502201001e0cd8cd5893af2922e4e995d8b9202c6b138efc6d8bcb6dbb24daff3a49b022100e59ffa9ab38bbb1b004c0589c41804e4f0a69e2c3f8aa272216a8bb5072a3e3c01ffffffff7ee25e1abe7e44021854e7095fdf00b62b2a731f66ec7194ad1119d9d0666ea9000000004847304402202701d292664af6281175a20ce137da73ef21a00006a3e1def07dadf291ea4f2b02204842c59207134e720ea7e170e7674d0e60bb214d20e67bc91c92cf6d237178b201ffffffff7febfffbad8d834a7f5ae4b185b64eef86c9f1044fa5db5e1c64acaf8d7c2019000000004a493046022100b25cf285ced260a2b0ee424c230e671f900500de3d5792499a0391c710cbdd0c022100e8f376b506759c55a2465dc0a589b0c77ae124f14c429e9eaa5c6c5a7d50cb9901ffffffff801a55a9d311c89d55c5aeeceb580c0a6affc7d5635126239e8b70e1bd6479970000000049483045022100e6b9b2634513441f07fc9c57ccda20cd579a778435ff04908400743720a30f46022077ddbb1cc94eb663cb49f90cd19a0351bcc6ee4bb2f97b0d3ebac9c693257a2c01ffffffff818690b0b91bf4d2139bffc074d86004ea09e4405c62852f21fd42c09560f7f40000000048473044022011a3b987ba1d33cac020a3f4a91fefe3927cfd8ada1716b2531194b11324f11c022068b0ee6be306d2faafec47ab7d461c639ee0ad0efb27687894fa7e7fefe27a2201ffffffff8298e8f1781b3f053046d8e95bcca2781e587dc48e0be3959e594357c86a8fcb0000000049483045022100ffa2e134a520242e552bb94fdf00f4a4f82a18ca3f2a01f5e731e674b2e9f5d0022057d77cb313ebbd92a32562a8346119e2aa8006cd8a3d84900375b219ee4d5c0701ffffffff844a9bf86b9cbe49c4feecff2ea4704786fd25789c2fc2a67f5b2024c2be490f0000000049483045022100b34b1d6d890674b47a6fb7e6d9cb7258b425da7b91aca2675c55d5ee9256528a02201f258f20c024fd9e3857ebce5de7f8626ecb4f160ad6846d1877d2e7b803e7a101ffffffff8451c18057a4a5a9466f0386e6960800f6c43cd60c39915b0759f284872edeaf0000000049483045022100f5f58c8ffddbd15180a0c0af1ced928f1eba4e5a505c2f3595968535f2a4b8bd02204c16bb1da86da477e1165dd26231c6c532f6b9b1fae80b89ed4e25d016e2ae5a01ffffffff85573d85e5165d1a6ebb3836134b01a1c4e0034db222c1dd18000bd5cc06ba95000000004a493046022100b7b37da8a59b484cfb12e265fe50c989e1cdf42250318b09585eb6306a23a62d022100bd9533e1d15adb689e3633f1b501a6c0825a1d9c25a3c999fb1ea272a2c0287b01ffffffff862c0b77e9ba4153f67c71b73f4503d5c0ac3c93a26367ea6f0e3e471664465e000000004847304402203716c750873dcbf35bee394a60eea151e4a2b7503e697a99cefc6c67028253a40220633407e0a90cb903b59a2accdfb220c00631d376d88c9e69a5758d35c9ba2dcf01ffffffff88e6be84a51d7541a9c9c70434e44594a8416a0e348cc3622edeb8f0d8cc4f5d0000000049483045022100ae16e133df96ed76694b70ac46aaa2d33e80c229ca3ad06f8853a81eefb2f6ec02203463db7e22a4fb36a163509436d1b195b8f27291c06d0b08d1281f210c4fc52601ffffffff894da141e96ec891010b2b0cbb3aa7e54b0565c87966d718b57dc77795da550f00000000484730440220509c40c3f2601a5d445d25dca07f7082b3d944936f1796473393a6e0063e67dd022022fb210e19fbac7db9738ec9309b1db5c5524bac56e6576cf72dea6ca064ff4601ffffffff89a2c60f941d53356f38bec562f6ac489bdf45946248ca13da190b79290cda77000000004a493046022100a6f65a7d8eccfe4c7bde37e817b6ccb04dbf95b350e6cd89b7ea879a118455400221008d06944f6fcaf3d11d2432ab8ec53836e1ff9116a4a32c5926674c9334e2d1aa01ffffffff89a63240371778e6bf5e35917049a5518a3140e85781c9ddfdc8afe8807b8a990000000049483045022014021ee5904b50cfb9f55c6002c6e698b6c0ed867eb85e77aaf3bb35bbf57a2f022100f846517addb3eb0b52d4ba343a356c0b5075dcdfdc07effbc4bac62ce20a1dd601ffffffff8b1e3ebdccfa2b4f4f0453d4f49c5f6f47d2f190c26b1e364a46db131c37e90d000000004a4930460221008e507d04146bdeead16f76d16124f7bd52653e3e4ce3a28d837a15b148e9777f022100b753b8b1a0d0fc7c26340d82487918330ba732e885fde8de5bc3a8143478df9301ffffffff8b257e790e7489e77ca063b90caace8f045deb28195e0780bcb745031cf0ba60000000004948304502204ab48fe0dfd40ae1a320f8d02ad4ebaa6506644ac8e1feda2f86a3484439a52102210085ef68560924d67e68c7afac1c2b5d1cfa714690d471fd41e5318da64269c00401ffffffff8bab2d4aabc855bf46372ff973889db3f94424b372a0c7d8153698faa060eb0d000000004a493046022100fb926148bd88188f546d85a95273a9f485b25986d6c065c5218f9daeec7c467a022100e1afedbccde8d0ae0e6546940e9ef83acd518f12086c5b9b71e9847f71cce7a101ffffffff8bca12aea34bc965d5a6f255a7fd1898a22a00ca8f15a010c2873564954ed6f70000000049483045022008c9d60755ebcd70b4ef6c6754a7e24042b97275e1abb6fab3b1ff4855d2712a022100f3e1df634620dece234f64ac14706dd3ca5e965a7210568f2964d38998b0ff9801ffffffff8c49996e159faf33a40690da0c7a974ea918f7e3134691d4796ebafbef3046d10000000048473044022022efc8807f972bf9f4318b4298aa43d943b2a68e69bdb11d39c219ed94e3d6460220638f01ebb850b8295bb8fffc5fc9f6786fff631a2a41701d01567ab95d8b0dd801ffffffff8cb9fe5bc1e59ccec6c83196b7ea004ba1efe827c6562cb8c80d7638a870904f0000000048473044022014e5dd40a87774be4cd6d73f685920932f9beaaaa20f5cd25ae1c3da65aa52640220529cb3edf380315b123178b4f5bd4a3e96a0445e34aa2ff51108e1b3251e298001ffffffff8cfb9a0f1caae90956d9497565e0f72b7175911648469cc864266da61f7e1b5a000000004a4930460221009d4b871816db91cff0b00ffce1a8cb7d877b4b23f05cda087ac91d25aa189435022100f75fad399838775deed4dcb2ae5ec8292c08de5918bea246dabadf0abce5a05601ffffffff8d3c26e467f74f25f2695d94f2f6c98c3a98cc28dac6d003a26cb33c190df2e800000000494830450220418286c9a0239550802170d208889816731bc6d221ef57471f98475502c0f014022100aeff00fcd176c6c5fc8f9455d40b61f5f678bf5377721531d80455e23f1abd5101ffffffff8d7af862867073a00a0bb8c39f582724d77af4d8a760190d857d772bb348ffd800000000494830450221009db99c52db1ad4e47b1b607f3497480c1f9f3ab3769c351e59dccf962ac790a102206fd7b0b3f521346c93d97c9164eab339453ee914de24644088b72ae52523028301ffffffff8da37018ba37511c6c4461d1fd3c5001f4df1f989edc16f2fa5c4fc70bff730e000000004847304402205e09444aa1009fab4dfbdf3df9db6ffa49e5fada9cd69abcc13e7305e78ab97002202b2cb319369fdc6d2eaa5364a74abeadbf6a2a7f1e57d18cab1f84510a0f909001ffffffff8e5b11c2f39e426a2d720c0be451bad296917fa81ad093f30742645a48c7e4bf000000004a49304602210083a156c2a7ec089c368147705d813461042fac1d0b0a0075e373257560b2534802210081ab619400685fdd1a4eeaed45d0e04a41450ab3d0baa784b5e23cecd46e3c4a01ffffffff90e31e03ef97a7a5203684fbb07a6f4ec306550145cd9bc6101eced8f4da5623000000004746304302206d6c587aa369da3fd6e75d8d27805fe7aaaa56ffafd801bce491887f618afc0b021f066ab880e1b859e909512746c76dd7f5db773a96022dc13c38bdcb4d04fe8601ffffffff91d230879e4947d8228ccef36aeda6e74ec59fea2713f144e4fe26d81b1cd34c000000004a493046022100ad2b5f9dce7532a534756be5f06c04a5f45b39025a455c838ff85cd102ef7270022100e46b305cabca991bfb5ba22a9fba974b79e314f855b6b43560221d6f05cee90201ffffffff91e6ff33e4d5439590ee754379ba86dcff4de11f2cd059df1178b62c0b2ac78e000000004847304402206dfee2e40d3ddf0793c9c1a2af5037e005c1d94b2ae0588fd83c9229d07a5c900220670ddd065c45ef184398c340161a6914ecf7b8287f87e07d5586fe8a9495910301ffffffff9223197719ff8c7ed28fb79d78f84962888163c3a4d0b111abb521678d1bd32a00000000494830450221008b1aa27bbd8402fa3eb1e6a4aa23a7b22df218faedaf188d81d25041cebecb3002206f158c8509c71e7547b4def691b00ce627dadaa6299d49d2ba134d42e504275c01ffffffff9265f3d32803970b4a0fb7dc7753d205c45e3feb7e951c18c9b7fd828bf58c320000000048473044022044a80d4244e47d3bac8a0fada613e01eb0facb377987ea4157f1a87c13196c9f022024ba161614c5e76b658432ee22e4529c82fa161068627db6524c4f91c33794fa01ffffffff938620903eb8c3667d6e594d7f174270660532eeb98b34b049f57f31e7abb2e40000000049483045022062e601675632b46311c8415c261ebcec699b043f281a67ee64e6c0912c1d4e91022100fe27adde73276d60dc4939a882005d90357d6a492bec7d78d046eadb66f0afce01ffffffff947212d5b44550497c2c745780da7821051759c23655c9a27f8a076e65d1094c0000000048473044022008cff8ffabf4261faba4d703d679aaa6ef51c209c468043348f17062ada86eaf02203e401d773a906b37fd22f0b79d4a54d1d5aae7e286e4915de98e302052dbd04c01ffffffff94791a7e38ebaed89404acb613df663be6f1aa977abeeedb0dd73a480be5b778000000004847304402201d80f8db0a5d6a55c0389e2250952d6abf49612b2ba5fe593f1b55eebe957316022019cf7cb98c77ed2d079b00a63ccefb4f59ba77136180e1ae39b51c24aca5f4d801ffffffff9483ab93c7b0684a90e4f5e87195d415bb3152195f0023dfb5210704003a60240000000048473044022069914e79e91d237a36bb32edf42010fa2446377978cf0f07ddbf8019ae2125750220521961adf3b520dcce961476d441fea053b9513dfcb88dcfecfa48f1b592155201ffffffff95ac66140e19b806afafcda3e8c6bccc02254774df305f5aad7a0b2ea138a5b6000000004847304402206634ba4283bda739647d35b6352432435ab8b40be0f1a71566c34425a67d36f1022077ea07d022166ff4bf3df5db66bb859fd5bc17a4559ca19bdc59d2a06a0ad92001ffffffff96fee98d89318859fc403f8d99b7202b4264a7cbc8a8383b21ebffdaadc2daca000000004a493046022100bb1002f9f9866f3654453f2ace56817fcba733b9239843794e02ee353d5b86c902210086bd6415151ac75cbd1ff4f837eba5faa73992797e7c61af5edcf6c4e243dadf01ffffffff97a66fd7634a130bb319b4e2889cb7e13c6abefa4c289e23cb66f7738e81f23d0000000048473044022010b40104052522137c80a46dad92f5c550d5d57daecad8525cc8de5cb53c66d202205b771cc996e1b70d1bb2f2686339efc9f7e2106e0e93c8674c9a3f04c4b7bedd01ffffffff984c8aa2574d8771f6e548645b06886f284d7f1cc28dcde591a32c986e0e5fc8000000004a493046022100e2eec85dd02f184032bb8cf1dcfd7202b056ade9be59cbc6bd1ce2b757777a6b02210095857ac0f67e33bf19e545806d616dd3bfa498b0d079a2c15e3bb87d99a0d2e601ffffffff996c6a67bf8583825df410b069c6a80edbcde72216ab23e433c755de2ddeada2000000004948304502200ed6ff9b14579f7c6c96f72358e4fecbe87a26e4121d5853be336bfe2f61dee6022100b6ffa6abded1e709d970827df9638001671f3f635d01bfb64c29539627bc55b801ffffffff99f8bebeabd8f92efff4d8dff9006644742f94a131a6219b4aa41cf3ba58a6ab000000004948304502207e4780375e7392a9f9eb37c617a5380242d56635ad459e93c0f36ed90bf99edf022100fe8b23600cbd1a22afc7e2b77a54011602d26aa18503d6fdd310c74c8090187d01ffffffff9b40e29c2bada803abfad905590d602998759f659b344125aa2d9539f319a1130000000049483045022100fbc9aff9990b28ddd56bd0c6d63250f09336edb40628f9b884ca9795c42362a0022003ab75a40fd1a8efd060850b910c5d22572d5febc4b2ae6648eb9647bee3563f01ffffffff9b64b6f5710a3737f2073b2d1c98f18cbf1413753a4bb70c7e1dd079fa2c54420000000049483045022100873435efc79271507b42dbe4cc6af0026cd7538b81ed0e423dbcb8fcdd90725b02200581f3aa0bf0e5f0643a48f83d9a8fa351e4ccb196021d7bb8ce6e4c48730ce101ffffffff9f5edded3a40e29eaa148f54dd5817d6d5bbe90d258cb4a97baffd5e3abc9f9d000000004a493046022100fdc2e54ad0fefa4f49566b5f14f680b8433666053ff33a69589d0271c8843d9c0221009a35b192abd29c56d2ddb02f439f3b37cbbbf2411456449e948ecd92619d97ae01ffffffff9f7d314059025530cc7ae278a775c04f1253fb260f4d5379a9fe74b2e44a27050000000048473044022000c2a8ed15acf636edfdf5ffddb2c48153e7022d92922ce875b72a93c65f03b70220686c719153c935c62f30766d727984e431bafd389099f3f8c47b7bc6fc22066801ffffffffa0277ede350707b54db02848a34a1e0b40a455702921fab1ce244a5eb0e33aae000000004948304502207f4d49a22e5d62758c566ca6fe9be1de1104a4515f006e1ced9841201e52cbad022100835e2d66e977fe3be3c9d336fc3cd9b4826152443f44aaddf701cf054bddd4b601ffffffffa05a3b85eeefc1b93be07decc17dd13c5c679363979b7ce749b65f2ceef1ea910000000049483045022100c7a034ab6d420842bd4a7e06aba6df32d56d502bffe1e6c86caffbdbb665ab6e0220430a142144349421d68863c5ba8e92246122bb4bccf7ed2f32fef1ec60f299a501ffffffffa19d68a9c3e9e82af5ca6a792779f05611ffed2b35d04ad9e0bdd8183a728eb70000000049483045022100adbef1b4aa1bb122147ec24b363f8121a806c10c0ad205917ec66bf8da8a99dd0220209c47677888f657102a1b5c2ba7d1d22110239413672c55e2baf657458a228601ffffffffa2a30b392ca1314648099848d14b968856701771198db3226dc68b344ac47edb00000000484730440220447df15207cb9b7654297fe37662d534cecf665072a22ad173dab519fddac484022017b7ae691a10a60ee93625b9535d9b8eb4763dc7496fc1eb7f1c89f117bd554801ffffffffa2af5ed278529c3bc1fadd29e8f3eed29c69656acf25359b2634f2380f118adb000000004a493046022100994a32596dcbfc3a5d47efb6956b2b0da2a459e1205d857cdb2bc9c09d4a6deb022100d608ff533897cf6e996731c73729b8ae01eb91f0170c70cdc19084a5921e50c401ffffffffa2c19a55afa66d93c9f54595abb72ce73a3e192ca67e3f121e5995444ec643d50000000049483045022100dbfccab011113af2cf0d2d04a97aefebfd37d4564828f7ff31e32bf025d39df2022012e031df19e4042179c2f6052fa7af683e55380c63b8f1219a016acfd88abe4501ffffffffa4c8832c2d57a1d6c74ab8c6aea45d086b5b06fda7c15dd5af82f502081447c20000000049483045022100b7049d91421d2a76ccfc23ccd42bbaf54c3622d39c642e2ae0bcefcffbc6629402207cd579148e3e55c762df02c5d1cfb592ddcded3679addaae0f8336c1da37a9bc01ffffffffa55c17d4a4cfb3644dcc5a52215c499a6655387e9055ec2c89dd3325e52d9f970000000049483045022000dd4eca7932f14767d274b9c8e804d6dd43d334f798bc815bfb53e9160fdd91022100920e659485fee8e00475cb7c2fd34c8ed88b77642b332a2066c70042b097af0001ffffffffa60558de647fbd366a2eb7fc94b518386989c5358892e264eec58e84f816acfa000000004948304502201fab741e5a2869c3bc3a69944c0a7436374a92c50a9bad5a9abf27c19148e27f022100b7b29320c95324852220bed36289b6fd092bb5dd8991682dc4d703e0c051512701ffffffffa69d4b6feed8addd845160d6a68a4e34d500769c6bdfda8f042377c56acc4004000000004847304402204decfd453062a26f4b74b80651fe0009dc723b93b02ee889e46784c8ad18225202205eb7af6851c44938327aa8c2868094fc1f7bca5d23778abd96906c1679d4955001ffffffffa6e129d4f3a904d4e06af9e147413fc8a01694eb1ab03a66c4405c98ceabd680000000004948304502201c7fc7ec32b39baf1d0317bd6b655729ac5127bb440456a273f317bd1d7b61b7022100806373cc455522e9ce69258fefe252ad81022f1af23134c5696be6e3f709602901ffffffffa7fb9083503a6706fbe3fad3f898ce674ac6f4573d308c58bd039277136ccb40000000004a493046022100f34ec6e0cb32bdf897b81b35403c9e840b849183f2be86a5cfb150ee59faf904022100ce832fd66f612592fb0c60e1f916b7b684831a340ce29dc6f6090455b65a2c5201ffffffffa82525efb373ea30637c925b952c1791185d99dd20a9ef2a819bcd3b7b6a4763000000004847304402205f28dd3a1b276228c8e2f402f2b714497f9d586ab3901b4108254f5abc1f2ba0022051fd11a027be386f55bb1285baae7e7997da083aecfa5d1737c761ae0e628a2401ffffffffaa6d55dd695bf8c8ae6315248b95efd4ab7040e52c5b47b5b50eee324e4fc72300000000494830450220523b69ae09a9e712b415c09837ad22ae3cd72fa7ef1502c9ffeafd43484c3cfe022100ec58cdfac6d040a1ad8dba16452482c96cbfa9873bf16dd217212d9df1ac267901ffffffffab244efdfa12495ff190046d4bd337d2c4486dccba7b2cd517585b980184a664000000004a493046022100b9d71d6b6b8d13405926e890e6eb584429f52338559d8d2735752d4cf221da160221009aeda32e93ce4ade7486beb042deb734e39cd3dd2db6a90c6e7570e4873ccc3001ffffffffab6e583b298e91cf4cb2293caeff4fe33170d206552ea624d6f89a5bad6fa50900000000494830450221009cdf1349bbf27e65fa93e7986762847e4db758672dd3f2b061a7cd25b876767f02202e778d3b8cd4ee947e31540938b7aff9afcff9f82ddfca94292f6b9321730c4e01ffffffffad555b62b84ca4166839a3a14c1513e59b32daeb92bf3cf3bdf0886c2b019fe20000000049483045022100b3ebb511568f22b30d66e31ced3b2318dede54b53b395e2a2129d66a866755ac0220372e013cc167c97d8fbf40deadae1ca51c486dfbfff041e029109e7828098fc101ffffffffae5b74e0b8078f409f58965add850fa965ab36c3257e8120cb5cca21274b641f000000004948304502204c9f4dfc5fbd324bd4c68deaf17acf043f620435d61057e76fb1c3defbcb0f9c022100f09865e12b52a8358d66f97c95b1153ca1d030944c5327121f89ee1aebaa4a4701ffffffffaf2d2438a014e300a03eed87354ceae65b9065f3d20d75487c39d3294fbd6e70000000004948304502206cfe4f62801a1c4984362f8b734d79cafe6d7838d5610eaa87760bf9033cf3d1022100e9988769fba9a3aa668d4e4466a8f54416c177af8eae916b25809ede6215132201ffffffffb15da3f35642a8960519af325f4aca95f917ba4148d5215e88d7fd87b0a378de000000004948304502200ab1d2c7779c91f05d44911b68cb7a61f1b911ca3cc286898ab8bdc69a6589e3022100c20344a311b2102385e0cb97d1f0ab6406175250d720b8f56f5b444a372d4b2201ffffffffb2ae8d9508aa71d6f156a29362be64886a412dd7211122b4b34d8b535ee97f360000000049483045022100844e6d71bfeab6c127e09f1059a56cc1d506457867ae0fb47fc8a747ae53f7250220125d285dd10c93bdc63f45788b4c3cf04dfde76860e767607812be49dcfca2ae01ffffffffb2be8882eb9f573b4be72af8ebc406d2bfe8c19074f2abd1a538d1c448630ac30000000049483045022100adb10a2ad2cc1865fdd28dbd18cca1cfb55cb81a4b559aec7f86e66606b3402c022054edc3af229fbbbf13017947db4334b4a8d13e8197b645f3a4a36a5e290c369a01ffffffffb32ad76c87f787a72f1b6c100c61520ad3d464d7704d74b94b4a607d61cde00b000000004a493046022100f76a812aa885e1a1fd30d22b1d2fad395c95f36df93fcba8ed8162b95bf4424f02210092c89c1b0caa16634f3679fe0fd447b2ef2f88b484f248b83f152d91b0fdf38e01ffffffffb35880c41d1588de7dda1fa0155400dab4f7d52b441d8d61a3d0814bf0fc894400000000494830450221009c832e6ed41a157c9a7b366da620c12fcc8980657081692866840762d909f60802204d442e796febc90813bd1fe1862d9bc59644636ce99e2e35344d82b954304c2001ffffffffb429b8010ee14c02178c663020ca508b2853aa609fb0d1a43bee00e8c9e4f97e0000000049483045022100b7ef4274c675f4b1d2c4df19dce5213cc33f06716d7bdbf2204ce828cc1403f20220362b9128767072497a8ccb5af3e299efb2ec22099cf911e3f5f963f4b0e19c1d01ffffffffb523c35b26dedc2a648ba6a5c56741efa91e055523a1e887b6dae4228321191d000000004a493046022100cf6c0a02f676d451a80a202db940c338a7f25e077c84d7886a443e2f75decd17022100e521231aa8927b27c55b521adb420ebb4f668706807cc0b670ace97cb2b2d58d01ffffffffb5773f119e260bf7725c1406ae783d444ea5dd258d820ff0bbf5850eabbb0c1b000000004a493046022100839108ce420eaaca360aa61a5adc81bebd346b2f2d0ab7cfcb27a2fcc8b78ab8022100c04c695bf60257e87bb67d17c18292e0cb3f363df993648d277820818711589b01ffffffffb6b29d525d7da4b5665e0da39ff47d0e28a7b3f9f977caa77f6bd329c2e268a1000000004a493046022100dd0c91383b89f1daefc458f6b55b4e8a06487b964fe39bd30d93dcb55914141c0221009edcecf9904c9409ad5b76a1cb8fc32ade24e4b3f2bce780c4afdac974da9db201ffffffffb874f60a81e55e595851e6f69caeb163f38172509c0d5f506a6a54e6dfd720ca000000004a493046022100e63904b7fef3b59f65d15075707d7eb57d35d1439dbecc52e2705edf83312c1e022100ba3c1edf7cb6b58ebb2ee87558181f488bdb37fee65d05d2b34226cca0a5722801ffffffffb95a0224f8ce137307e39ae30e9f198a02111a031f4c22921057265ba775c561000000004847304402203f96c64baa7bbe96e9689e17215718c0a6dbb796bc2db75005340bd736aa73aa02202646b0f468dc0f73e123072ebc5ae8ab40d5a1f915b413f6711ea6b698b8491e01ffffffffbcab2d43aee2ea434a2a2fb92880754c2e2f909e99866a92cafe9805fea2451700000000494830450221009377409b3e34a163dea103035ec8b9ba8eb5fcafc6605bf9476b455cfc51f87d022070d6cdd3a2261bfcb8d29fb77b002c08abebafebbb82ef80893215c34c16b3a201ffffffffbcb53451311ae5ed88aea07b75f165952632c40091c82e8786d0afb05cd50fcc000000004948304502210096d3c3ef54b9377e3e05ab2cfbbf7efd8bcf4268c2398b2a8b89affb6f49c0a4022056f3fd09a9d2d55d29ddb12063973a0536b846c597bf48f538f8ed085f99f2b001ffffffffbe1083bce551a5de4bae8a6bb097b3fe5288a9be7dcc63d948191c857abcf821000000004948304502205ee86cd90f5daa6e6a5a242670434321f8eee2b2a4aeb09d2a58a836387f39a8022100c71cf637e0906905404afe1cded10a85e21bea3f72329bf6f3fb89778dbc395801ffffffffbeb25c09b197c09ea59c128e1ba55096addb456360a31189dca0cebf3baa6a82000000004847304402207c19b5f7dc2f102a500423ab3981b2a2e5033510e2d19a7811f57cd93796c0e7022044ed9e205956c64db77bb44adf7ed1267bcec8020fcdc729bb43b21025cd2f0301ffffffffc0a2c64bbb1774a43b3d939e822412e9187c4f46df12a6d8928eb9f98a5fc9580000000049483045022017992328712f92e198a57e0f258ad66a2faeb47e4f6a63316ae960e5068b6972022100e1bc9eefa5716f36fe7bc2a91d4a1c0ad398182ff42a709d60c7d050b668e6f401ffffffffc0aca6b9988389a90484781717144018682a1a5bf65df6d43d3470cb291a8bdc000000004a493046022100867a4088ac3e9bd72680d2a82b82177dfc5e450916d788579bf833581f6ac27b02210082f345e26090d69fab1983fd1ec6a7d9afb9d282a3ce2a011b7e18993b251e1001ffffffffc10473e4b281109e0dac6f6c69fd5d5e844fa277dc6af8bd63c16b8026857485000000004948304502202f26bfc3b8f435e4cb813045c9ded4f424308cc70929d38fd534df1938971eec02210098eac1843435c33bdd128190a6474224d7c99af8059f9d158452963cdbbb7d7601ffffffffc120b83f810b5385a6f647f4095845304a89593395117ca59d691ddcd10f65d30000000048473044022070438255035b5fd8f4c9dac51d7d24b09c4542fa2a094f83bdf93121b549b5830220142915a779b7da1bea05f539df78bc214363ce2524baedf9daa93531686780f301ffffffffc1f556b23ea554c701dbee93e755a11cdbedb33cccae4de8cfa47f6b18cc6639000000004a493046022100c3502aa728c911004351b601af479f2acf6e7b8838fcce7be69a69f23df2b6ad0221009ab9351fd1bdd6b826a2097f116de5634963c3f3a01bccfdab26c36af82bad1d01ffffffffc2054c8ca14725cd4358dfa642f9eff2de8edc155b53d83a50fe432ce69376d9000000004a493046022100b1fd6da5f30fb8af6682afad3e30cd54d9c3859c464961ef8cc82cc40ce60f52022100f9171bca6d11edd315e8ec1fe94cdc705617853e61c43a90c57d1aaa6dad68d801ffffffffc2a26015b177f47db9170520c80d587269fb91b28064ea3ccb848a0b76226a92000000004948304502207f5fea416f49604d3bd6b9d80969a3d5057c2d3c191f29f98ecc73e49a3296c7022100fe715be32e441f22616d80ab7e12890ac0cd466a1258b67275e2d5d93474da4701ffffffffc4d346fd609d5b63343bb2c60aa3d79f8234cbc0ec219e5898b11b9580f5a40400000000494830450220734044b13e5398132e828f7fa291a1c5d01578f3e9e70d9c18b866651d1dfe7f022100e49050c1f6768521c07928c05519eb463e4566422f8a7fb7835a89e0d372e55b01ffffffffc54047aef47e0975d89756fd3152dd15561aaf9827a8a76bb3a549e00293cbac000000004a493046022100c311eb814dbd3cb426810dcf6609c6fd83dae065375a6e9b3a2ee424fd1c298802210098f52978d37140097f7488371b1806f847291b0727f37711b94370f0b4f00f6601ffffffffc54de5af22a70b755ce3966fc836040132d0ba645b39d5f1ef500b5e7e5cb6640000000049483045022100beb301008acd5caa5ed5e1ca58aa735da6d3f0b08a440eafa524e9d8568cc26802203a73eac1b32e8b2696d6ef0943246599e93fb01b24a4bb0638c11f456bed257e01ffffffffc579cdbe9ecb7a66725470d2029cd427b684c3571d2f1d054be4518e34a1a4780000000049483045022067bf7b160e28894e36b83ac3a70405606bb3e8d9cd1b792b41e909d8deb62a6a0221009ca94a2596fa2ae846dea6dc5cc6ffa980312cf98a0fc7c8952c6f7d9843e25c01ffffffffc6b38487d389f39aae0e25944fc4ae4f2233c068f4b3570f92403c85517f7b8e0000000049483045022100f13354ee1f0c0e1d63b1f981368a809ea577bebf0bcbe160bd2dbfb96085e6c30220519a3b821d939539d842c1e2cc3f80db4b8e23c7ae3bb1acd19d326953402c8701ffffffffc87cb93e2b749195c93f84a2092dc1bb78c08405bf99a4cce43e957a401278a10000000049483045022100df3d38dbae8b48a492cf430cebdd9653194b285341524131a645dcd7a57c50020220011ff94d78de9cfb5307889b2b9effbb548a3f75a8180fb81fe4a4feee1df91901ffffffffc89e2b7ba74fbc4d796c61c823267427be303890740a3b1d1a4aab2913ed72f30000000048473044022062797bccdfae280f5b25aff43edabc1177fdd4b164651109f96538f8c458e82e0220760252ab883c6d72548a303e8318c01d576d988384f08b24ad0444d40339af2501ffffffffc8f08d8541f0f9effb3ebe18b338b65fdd24a7b214e675abf81b4b84210bb8b50000000049483045022100fbc47aaebf76209b77739e2dfe37fdf2206dc17e78c8c5746ed9a811948979bd0220674932403bfd4df0b4fe1bd5a8575208a236853640fc55845579fa5a5c9be14701ffffffffc90e9ab4da72cda921753351df15181d57f1d0ac388432e8663f78b72fa0ec4700000000494830450220131115ae876c2405d0d43fcc43fcee92589e9eb0940d5f5b3b5302a9255dd60302210089718ff9259393e476a61529a1dc109006f2922ecb35ef8a82e053872ee74bc001ffffffffc9188bd5ceb85e378ab05abbd1f3d6bbb7ef24b0390c79708b6a6b783827c3a0000000004948304502204f97b055503ec315547f3846aeb9a71f31938e6b3fd6dd5e51702b3138ff360f022100cfc1c1a37aac59e24d5e877ac3560ae95fd2d84788336bd0f93da98c26e6e07701ffffffffc9d8a05d6e19993b497b98a5b0f82f5a019f8aa3a4dad98c0326e74b3bb29240000000004948304502210090c69220551d0b6bb16c9d7de39658e8306bfd8dc21a57e8dfd98ddc68827cc5022050791b877a966b0385c0c82219116c90df9e3a316c9d8e537b5a72feb8f4c07901ffffffffca398fb33b7c31563b75130e764f76e8aca8b51bf061e876cdc0409007f5af76000000004948304502206f8641a9c9fdb8434452ba4c97418b04d49f6ec426192e7de50b664409955f1c022100e4eff326d55e6da41f9e19cafbab1b0d39fcc14a0118392c272e8141c09f62c501ffffffffcaa40698b0a1f5b2e60537f284f8cb82b0d6a2b71910249c85de7458b02cfea6000000004847304402206d065823f5cea67752a64202cea5032f7d84fc0566ea6ff02b3bee83282acf010220150f74a438ef9b39ffecb6f1c129c72f70ddf3c75cbd58d5f247b50b90e802f801ffffffffcb2453c33f5b81fe879a2172386c20ceccb152ed6ceba12cc54747960c6615f5000000004847304402207a21929ec25a82a1322b24c670c350560a8dd6e1ade255a94067461428e8d8ba02207a0e2d06015feb5143d69f62ec6c3d63ed8d3f023fc8170a891447259b0be25601ffffffffcb94dd2955d3a7fc3db8ad8c6dde3fc15cf26ac2125e1bb4f96410d904678e080000000048473044022025dcafd6c301149ba0a3f835485e66a9ccb805a5cb075a0e5b724a97a7bd5593022052d50dd971e6e81382b9d7bceb4a5e52af0803845d09ae093bc7daeb291438b601ffffffffcbd7a1dfb90bf54ca5067d49e78771730a593ed0cc72b937d4692001969f8545000000004a493046022100a3cec3a586c391154a5b4c25e5177dd0cf869bfa8206b0334dcb817c147fc13b022100c3dc9e3ca2cbfe47cf11b53b62ca5ba83b03077a2ef3ca96e90a0f3907ec3d2701ffffffffcd21a7330a1c73ebffed5aba8378d0b665180f855f75e81f3f034e3c04493ae10000000048473044022037f29b88a49d8c81d15b7ba40961457bdc55063441ae3a5cdc16f1192c46ed13022009ef59084b7cadddd8a626e9878fe0e8e52dae27d448839dfaa81e0287d0f28701ffffffffcd27333305a9d4c48f6c6cafc21d581b709ba02bf50aa57f53bb72135c6b5ae4000000004948304502206d01833c7e13e7a8b588a9bbef3c0d3ef1c4594289600e18d72e04ae3dcce097022100d2f7c2d3a1cb1aa77e0e2bf541ad2864b15e9562253216a1d84bc3e6ac7f2ab101ffffffffcf047916be763e44757e1698ddd8ed01751b3d01c2f32482c74b03d0a0f6733700000000494830450220587a76224d92b867dfa3b09e7e1684e2817487fa02f6afa6f07954cd4a45446c022100a976d4800f8b384bc582178d2c4e6335cec20a9913e28c930049d94ecceea0ce01ffffffffcfbe4d77dbe8026e7fe7f75111beaacd6a164366af56e55defbb0dc60605a469000000004847304402202cd865ed350e3fa37106e3aa882d3eee840e06941edeb04ea70d2e8c033499d502205d240ac562dafd95244806176bd76f1198305ab6454fc5cac33b472cd384ee5901ffffffffd20744dba647cb4455aecc929a6d0ad974e5118a7dd7cfe9e7ea99470f64311c0000000048473044022061206e5316aec83fe5fe22980cc207e12bf4cfe87a4491728ba99bee730b71f30220421a01c13b64caa3972b89feb0a8002cbef22ef45b53e89cf789e9bf1c0f711a01ffffffffd3a4c099f4c4d77bd6b4267083ccb25ad9356842dc33659d5d69278986fa1615000000004a493046022100b4d50eb00d9be6b537885ea232f1607ff74a3b2bf35934e900ec2a75830c50ea02210084ab4786ebe700c011da961ff14f6028f92a880307f96a0e096baaf1a8a3031a01ffffffffd5506e3f3fbc19ee3d59008e1424aa46c378d92e1b40c60620031cdb25afef95000000004a4930460221008726690d90c503ff03a3676517d433d227f742e5b447be50030c2d6137f5793202210084424955b6a995ad4cb9f0e1e19000c4c876ea21b34c03546383bef5cd2327be01ffffffffd6415dc52a6502206b89e6538b06cae426183ada61eb126f81b92b09f1ab45c3000000004a493046022100de71e86e3b89f74da1e21081b4c8b5123eb2f0c93d94e1dc9487f71cd6b494a3022100ce84259ec141b3c99a9953462e80964b9bc768cdf7d9f59e64f29006e13e8b7301ffffffffd6a77ef419742bfc32611af36b2aafcf1b5223b6c1a6952301091bd58c369298000000004948304502207d8bdc390235f265975cf9e388a41f44b83b641bc7e7a7b63982bc6a3956d38f022100a69086673ad4438971675f2f76c2ba4abb759561a7831e1d44d947fb7f9103ed01ffffffffd7862154ed176da1f047b0aa65ac8af5a455de3fb78ddac57b56534abf076dfc0000000049483045022052e3ebb28efddcb36bb5cd27d9bd60b4639b77f558c8215ea6c796b086bb719f022100e960e37184c4b831e93658cdcb24c1a46f52771be36589ac7de7f4985109e10801ffffffffd7d7f5bb6c110fd6a39d74c7a9a6fda290f3adca18f047c8530804109b1216180000000049483045022100bea8414ac283eed1678c02ae79eb3dffb4cef07b9b2f59933904dd8489ff956602203614e320568799ec6800e6f3e7951d786cbb5e579660bf9c9f6917342e7e0df801ffffffffd7de1ce40721d24b41dac6c807efefbb5cdf78cba3ffb5da7d8d42cfb99dc8710000000048473044022025bb923b9fa5e62108c77f8da1041b53795dd112b2e25470b4536c6760c675db02201238b4065c99b0760df1e8f63478a4a7e78a4f00749f2d5331c40bae60f5a15901ffffffffd8874768ee24479dc3370ab5161e8e4c45be2b1f2d7318b55d013cc63ce51dc6000000004a493046022100cf8b8868d755e7647315656d794cc55e0af140944be97e8c92013c3ee7216c9f022100c5d786e4d1320286b28efc934218c1e6e91bb8c627260a14a6457403cd68ec8c01ffffffffda94cc013b3fb32d8f92cd2c6cc4c8e8bda7ab23880674679ea6cfe8fb4f2c08000000004948304502200c577850802ee7092d6532bee09309c5ef8e7571ad4e1e396f7bac8502a2b5590221009158cb07a12c4495d2f3bd285ab930da15e1be614b32859d77a65d4c50b5f42c01ffffffffdb8204ba27b406943e4769468626b484d46c3b78fa634d68182f4d4b1ca5f4fe0000000048473044022023366aff261f4a597f49a4477a48ece5a20fe401ff97d024e373bdf0def8ac3202206715d81d576e1e91fcf67d16e8ed9b066cc1e3ce18aaf1ed967da079f2441a2101ffffffffdc45243e99ff3bc4e74b6662765f958181e1705efd034d7e0f51d925e4a48dfe000000004a493046022100be093ccd329b3e3c608b2581018a63bee4ff535be1d79cc1cb8b272576d8b91f0221009c0ca1360f850c29b8d127f9ff0686e2015512a89925197be02313f36c43a27401ffffffffdd789ef20fb5473a5a16ac71aee6d72304173f22d2dcf8d13a3781371394d65a0000000049483045022100f69c06cf1e633aac57768151cf1d0accb1fea0cc1909d5eaee8454106bea2c1502202f98bdf5d5cd35104386707166ccdb1586d930c329d45e36b753fe12e780701701ffffffffdeb610058ecb3c678ed4927841ae4c27ca2b1d18739f50475d5f640f885302f10000000049483045022059c4b493df870cbcf5177186b7550a4bb1376fa11677cf98698e53dc6f8f7ac3022100a43b374f73651cc859c3e8eacbaf521e0722741f25d56173fbe162f6e908d17c01ffffffffdee0791d1863bc9cecac3e05945fe486909d6d4420aa08e3aace1cfdce33b8010000000049483045022100ac1800f3235b2d9f1a9c308c4d923c67ec5313106ff7130df8594e7d80e1f98002206ce11da169467f8ccebc3f3c2815be0d537739e24bbf11d5a6934f741333adf301ffffffffdf06d460f788d66febaea9dee6dc67982989b04373254a3c8483848eb571111000000000484730440220115bf33f9da99eae576e34ef77f0595a37e56c668c56f2ba9191791d0a112119022031fca5966f1813ccb142cd7302c223502e54a0fa8fdb46888349c18260930ad601ffffffffdfeb1dace6d8db66a29a6dee746f063243eb02f036b8b3fbb07488d6464407d3000000004a49304602210092a8bc37cda054aa03b9c93dde2e89066b11968feef7b18061dfb51963420059022100bbe4db446fd725dd3eaf22236745f72497d074ce261606b1e831d8a263e7146501ffffffffe001a25edf3883e1c7d678707255f4d6a9a0b41b6b2e2024ec92e4f5de1af6790000000048473044022017d88daeac17f8e959290a84feb9b067dcbaeb5f900fb26e64630c853604ede602206741dc8db28f12c07eb111f3f4c53002b51ece0ac21f2a583868da1894b0e1aa01ffffffffe07b38c227c5a040415857bb980551f549fdbeb04ae2846ab1bfdbbbdfe1f189000000004948304502207a68d9aca24b8282c6b0bb9aaf8c717b7ae830f5a5633154e51f4769af82afd30221009d02cce6ef1aafd0859c05869aaf6e4cbc666fc7e08a76633a7a12f2524b782601ffffffffe15c872bd7a72694376e9586d47ecdbe71b82c58f34415a0db6ac933ad6e3d64000000004948304502207c032a4ec5c114d5c97f677b01d1f6926bdcdcee64446c878f5a3de7b20d6bdf022100fb07d3238fc4ab5bf88f8f808e41dc524e89fcb5a8663551b7988637d0ad18be01ffffffffe2a5c09888b03ffd1de410d90ce9e31eda61d7221c1aace4a00d7df6511075a3000000004948304502200982987de9b57d17466abfc7469a4ff3a84db64b80c25716a70e89e79a10e87c02210099cd5d54f7d518b75067fa91a7c9dceb1208e32d0d1477a65b4f6ad5a19057d501ffffffffe34e0758b593e1e80e760712737d28069c2116db900059122fb8254fb00c220e000000004948304502204fc148bddfd6296355c87d7b7628cfb9ce1e2ea053caae1f327a606c541a80f5022100ce2e1e411c732af61f862c275e250abd6f979ecb37c60e83bec4459f994f2ce901ffffffffe3acf941f65ec07efa641e840d70734e1ee77d1f89ce26dfd2499eb24a6aa24b00000000494830450221009d210b706f301fad511c9463c85963e2f0de8036766d1e66f40d82beb6e4507e02203655950cc8dc8e3c4ef19070c6551925e94ddb7acc4e5fe15e6fbe4e3d48b08401ffffffffe4784746ae0402d8843f563183948e393d0e5bedc423ff5982e0d200d30c015d000000004948304502200664dd10cab39a4032a877aefe6036f214ba85be56d35c5ffffb7a66aef5221f022100969c0f3b10288bce24c06d062fc452b4d9268341c6629d5d1a321fb3a9290c0701ffffffffe4a31a268c9e9e3a3f78925c4bbe08b402d76e8c187677ad3abe1350dd9e4031000000004847304402206ce89a2872f3196f01dbad5b46a5b8efd06754417280c54faaef88ac4e01ed7302202063ea42683f43c594cf7c5b1a0172774688f7ef031d503604279d69036355e001ffffffffe50fd6bc7d99c90885618af250eaf9143abcd7c6e509f0d5c3aa203eb1d56d5a000000004a49304602210092d90eef216b47c2d4304ee8a2dc03508e3748821af492eeec22547f0c3c6175022100a9ff22dd8e51a17737f630eebbff3260caa431af2fd706f83130ca5626685e6e01ffffffffe5b872174de2a4de663789db966e437b63dbc30c891b0c66b55ee803df21c175000000004a493046022100e87dbbd429bd0e7de445a98ac1989e0170891dc49ea38d26277b667a625eda17022100a2e24530f2891c8b58b213858e775b40f531f21fc50f0e32778c23acd5b4eb4d01ffffffffe97a3ba15620d0f1896259d1b97feb932223319b864daf70dafd589a10514c6e000000004847304402206a572899e7728bc0b4bd6f5b88e0d230ea9e7c20c2ca94ba6db861e344d7f97102205217ef8d1bd6eda2cafa814da047df9c4e42f608ea20b5f055d8e9c6eb8ceb6f01ffffffffea2c4df0ebea9e290ea02e77fc07fd7428b0eff86208c57a503145cff82354f8000000004a493046022100a90663070574d05261d7988e0ce33884a37431c35c3179b1d451f69706cbd91a022100b00bcf54bbf0646f4bb354edb0c94fd3b6573646d8e9f35e7b7e6536f086467501ffffffffea55a92b2d28659f6fed455b39eb6504f9852db89531f093e213ec1508bc48a100000000494830450220723bc85ce7b9fb227ab8e924f25dfad95c08957ba0ab154caaf785a15dddc1d6022100a5bc2ddd3f473040a905d8e888bf320957b50d61c7f8a28bb3c2985a30d0a91701ffffffffebadbbcdfa2c906b7289b007b9be950d1e17aac19edf4a9109e4ffc6d937a706000000004847304402205fdfe39db41dc05f668917a53c22f7f6504e38809581e215b7f5fb8049213b330220742e68ee09a0f3d5a09f42cb42121e8c7e6a19b3b6238cbe1e408225d0682c1701ffffffffeeb7fefa1153f7faeec6a2ddb0ecc243384e6847dde6666ef1c1a9bfb20cf0b8000000004847304402201f3019492d5e7e7748e9f4ea3178a402120d414ef50417f14a07415d9fa6f9560220571364d7e9a1a22394ce5dddc42f27dfdb74cbc3dda37af7d5f406c8ab95d4ab01ffffffffeef44e21ef2c617d58662f6a5eb2b1cefaf6fc1a8207746fa89f300c7b360f86000000004a493046022100b7b982cf8ce6eb92fd8fa6d1aad47e5042d0c8ad2367195fb3a9cfe4a7ec82ec022100977df3a879ee7fc21e9c686d61ece2dec485db384fb057dfddf778c5d838265601fffffffff0448878967c7d1bee2aa4562ea31e6d8ee152972cc85fc7e1dae2bec510e9b8000000004a493046022100cefdd4ef4801739b645494f9e34ffb870cbc970f38d96dc9097717b6dfc3c2a8022100d1c72bf682c31db1805a31da22787cb90716d95a376d85bf8dc5f90829cdde6501fffffffff194fd5c9c5e50a8d749513797fb4af968f369f741b812721544ad2616670c6a00000000494830450221009f5c6097b436e2a2e52b7ace9a18489043d05252a5df4512154745081076aaa902203e28d79bee167107645e81b90682142592648490c46b573353897c8f9e3fa63101fffffffff3f2637f2952cda90927b9217d466c308dcbc16d233ac0afae1ba99ecf1a4ad500000000494830450220509c36dff45d1d00595c9370cf58c1895a0bada228954808f49cfa9ff38126b0022100c6a5645eca80b844cdd4a3525a659a152d1c84bef04c77f936e39e6acb51012901fffffffff68eb8ec8313e0ca19b50612b879c888c4700f1a39bafd43130dbe1e84addd070000000048473044022014472297c6afe6b589221980532d1a492cc23c321f13dba4db8789397bcc8045022042de0dc5bb163d1cfdb8cffe36ac8463b5b282f353087c414ca6fd56e1afd23601fffffffff71c6a45bae8f525b5f2be09a322738bc5925a68735455d4c15b1155c22846b8000000004a493046022100a0610b385e477c2a47dab1946e2835f65fd8b6040ce2c5810d0bf26e60bb29c9022100d319918734bc7ea6df5bc66f97bd5bd54a7ee008bc8e44e662ec895b01265d4601fffffffff78edfae9210cb59dc42e232a87970fd0ed5ac225ecb279b38bfc8c21e37908a0000000048473044022034522a174ff36d7e7648330241609fc1bd4bc542ca2419430727f17a26f732de02202330298b80379b49064210c3b463026c5c05d9975f98d541a97da01456aac91a01fffffffff8a0b708cdf688037513dadfd8ca79baa22d5ec43c7551f92a1ba8f1c54dc23d000000004948304502200a932eebbab6b91653a1a831d5af1e2166e7681bf20bf8d9ea383934a3f8a16f0221008275f6cf36a0bc8d6b6ada5abdff57a16e95870ef49552d754fbbf989307ee0601fffffffff9f630220c03b0cc18e8f70361d51c579cd0599ed1c050ae4fe8ef1b9402c35e0000000049483045022100a510474565ef444552c423cd2bc6d635793fc18152b15610b9441e90821fc61c0220639cf3e3605383d92e51d17b881ea0f0a2d1e2507b2df321d1a64598dd922edc01fffffffffa2021437f273dcd49f1fdea594d97e6909136ceb32f9cc0fcba9e248a0bc2ab0000000049483045022100cbee4f9456480a5aa6558a16a62e56e6c5b90157211f03912718385ba849f2f202207e324535a81f57ae64036705730e2e797e036268cefa32954531ef63a2564bd801fffffffffc1a9803438f4c9f5066d16d7079c7d8b8479f10f12ca1173748eda092c2f7d5000000004948304502205ab87edc87df8fbc1ac3215384e5322c7012e78bcaa7bf07b3b268ca046a78000221008ab08bd879eaf941409845cb9795030f32e5afe5fd41b9797f717e3a421f3ddb01fffffffffda6e582dddcfb1ec1d7b4bea9c30fe400f3e072f74272021d8594cba5eb45fb0000000049483045022100fedc2378acbe60d0ed12d16bc7555a716a443362a9cc98d8b8498ddbd11fa35b02205e4415010ad96f31c586b2858f2ba00ee0a1737ef74871b84283f56451699b8a01fffffffffe39ab71e99720230af389dddfeba87b00dda306c7b75aca7aed7a34dd54f87c0000000049483045022100cd09bc648cf425a8f031465fdbf3e2e268d818a7f7ea41faf2eb2a4e66ff7b9d02203c75107dc9a53a919852aec28e5a158ff065ee6cea275e209c4c22613554345a01fffffffffe6ed81fa95f47c43b32c54beb0bf493e08c75325077697e2a5abfddb570d556000000004847304402201b86e70169bfa01d0a2e3ce71b8e3fd498a748a94b6ae363f7e240fa4e71b77002203a545828110a47f52c9ccf1491dc3e462bb62551ecb59bc5cdedb4bcb72f8cdc01ffffffff01c0205677730100001976a914d4af3cdd17410a9968eccaafc88b093110af5d1588ac00000000
@@ -62,3 +73,15 @@ var blockPayload = "01000000602bd8e84e86ee552bd46300cbc31e1961c03fa92d228b996458
 //./bitcoin-27.0/bin/bitcoin-cli decoderawblock f9beb4d9626c6f636b00000000000000858f00002777815801000000602bd8e84e86ee552bd46300cbc31e1961c03fa92d228b996458a77d0000000033a5654757552457777a3576803d111f7be988899ba94a15ecaf40ad1c7d99c5c9b0254bffff001dc7616f060201000000010000000000000000000000000000000000000000000000000000000000000000ffffffff0704ffff001d0104ffffffff0100f2052a010000004341048a443ad3ad7210741a1e3e5a4d035cefff091af16c2a792b99284b9551361490f5b75b96c0841fdf80d182a1e5ee97932e8ec086942a27f8ec83d2c0761f444dac0000000001000000fd400104e376400599976914ec91db8a12ff229a5c2ea2c82f70217f87add4c392e969000000004a493046022100ea875c8316de4083905a233ddc51fffd10b5cd136623dd57b9c7539ca0dc0986022100dbf79278c96454cab2970cb3f971cddb70c4d83d8d74a87b9216cecfe3d1127601ffffffff01b9c131932bcd45f1d8d0748c2794b37fadbc458e3a34504fde719c913f5543000000004a493046022100fd1bb16d96ad1511e303adeab859f6f9e74efe89e5024373283baef34064541f022100d77269f58a7a2bfb3c8713acc790e3469cb71a881cadc2bec69035c1ddd232b101ffffffff02448b236dac81e9c1d627e472d5793f21634e23ccb3dac3400afd783d11b73300000000494830450221009baee2552686ddde42a193d50fdac4595fb848b17653c0c847c5466a8e70827202201fe713d5efdb19b3e7ee3c92ccf074f5a753f7e5932ed4a38c061ef70d1579a701ffffffff02f896ae5d89a375b8c05894e586b8aceb32c28f2e05e11b66fd614d83a308e4000000004a4930460221009fba8b23f06cef8dd1a66e0dc4082e622664e5c2a0c22191bb24f3ac1d07ec13022100e74465ce93dd5862cdcd10f97483e08702f28fd591284a265dc8b18a3613375b01ffffffff005ece9ff547a17b4506f42f702b5203576a82e8b752c7a1fd56a04989edb36e000000004948304502201c08ac8eb3d94afaac1472e94a3dc395ece3d22dab0905caafaf6011bb52e3680221009c262bc843aacfaf2fb2d120ad4cbd3e27d37b81d63224519b110005b585416701ffffffff007f0f87576321c254beef150f6c5b36f2893ddee449241473375648cb735fca000000004a493046022100e4bd3833d9e1ddaa3cc68a520347235a748332d8a2a602065f3ea0d90f4d1870022100deb81d3a29008097b32afb35fdac3cb0f756240601881983a8b97e814b544b7a01ffffffff04011d29fcb755abdbbefe1169a918c923c364073a44bda55d16c7c6e2c4c9230000000049483045022100a0182512bb98c92039de93e4b7ece2a272fdbb782f0dc123f84a5f517aa8f98a02204fbb25d891ef9b346f840f1da179d94a458c04d938151cd72e4cec6818a5e08f01ffffffff047244506481c126a14262a1fbb369388110e5c6645bc160534d81ebbdf6708200000000494830450221008a1a80813826f97ead087257bec7548ad592dd9a4bfa6e043066bde5c399f5d30220633ed38f292b7590417e30ced5cb3b6f914765abca6b4f0c43a4a3c760cc164601ffffffff062ce60c80d2069fd3da3e94c4f69efea82e09eb1757d89aa246e37cf5924e020000000049483045022100a9adf9328c0e18f56e19d2c6ce8e92079562c00f97986b4a88ec5642267b60590220077711b4dc6e80ce3e7a8dc65f54ba818384e440555026370538f14bc0db4b1801ffffffff06b0020145a599c8602047894c66f46deef867a960513ccf0a2f3151ba48bfa5000000004948304502201b6af37d5028d53601647fcf7043993e4382068dac49ebf4c1c9230b75207739022100e306945e757fdc10774e561390c0f836feeaae599e4b745df41224ac49d3958101ffffffff06b6d0c42289ac43080a013316dbb2dbd521ad54bffb502d1bd1a13e9bace5d9000000004a4930460221008e07b69db8b92adc27fa54906d3577af212ec6f78fd37fd67f4c6216ec039e0c022100f149ff4f5edeb73b6bdc00890e32c2684661d5435697b6b787e75717306ed2cc01ffffffff071dd02d458ed7e65fae97688f364bd417509f9a31e0242085289b2bf9e6bd630000000048473044022004122590791c42859e60bb3d9c41dd903a998ac9369dc3ca910041f56535488302202f4df358c332945cd99c765e9fb4f444de4ff4227adb138ab2e74a19ff6acc7e01ffffffff0730a8f758247d867c9f4ab7e25de95adbf8d3a287a6453cd8b585b8916eb2ab000000004a493046022100e09553b4f5e86ba7c19ff574aba5a5db14660ac71ae1ea6043bd3713cae7bb41022100e9e4d7ee3c10184bc52c02f7a9ff758ef7b1bfe88e57d4581d2783fd4ea3f8db01ffffffff077d51da1c3c8cead891a49eb9f96710ec42b8ab3dce157b8713028176f512570000000049483045022100d1ed5b73b7bb58db7b6f747cf3c2a6130c31ecb393158cc64211391c2577caba02205ee7376ff31428f378fd98cf45ee99a3ae35fc81a2aeb5f142164480d0a1f84401ffffffff08b69c6267362d701e71b191d99e5221d10e77556313071c349c49be8747a085000000004a493046022100f186b5166b840ef90624608afc81331f16e09d450576299308c11876a89a2de30221008ade75f76f55d3e40ec95d6d663638f15f51a1294dce20d5a9763454af1ed2e501ffffffff0913ab7df42e6a2117578a73d474c24df1bd1812eb2b171f6ca59ab4d369d0b400000000494830450220733c6c569b9ea7dddb5ed71a6d5f069cbc66589f4ddb2dcda832242db51b0ec5022100bcdd0374045829c8d7f5d2421dabac79a6f4de428ea7fa3216a317d169e9923801ffffffff093e732077a113dbba6a40e405788b1b25577c1e91258d8d0650ba25f0db21ea0000000049483045022100a926013cd40dbac919fd622230dd85d8bc3dd5f104d0f7de5322c8c7b8f2db0c02201743d8a3b7a471ce8243a2393c4f95941b389e4220c9f47d421eb078c850f10701ffffffff09a7d0cfa0a0a0514f68046745d6a435f4fd39fe7af7745964e7d22ca2287cdc0000000049483045022100f580f5325852c3d80d6f78a21664cdbf765fc273ddd72be9335108ca7718b53802205656b109232bc8859a3d8128e31e2ebc85ebfa6224ba3641c45e2aaea91a480a01ffffffff09b251340d278457fd3c9ba3c7007e068f5680066e5d1e091b0bed6a57a4277000000000484730440220629e4e1a26324c6dc5282a9083b5287fb4fa136f35602a40a5c682688e5b7cd7022019b4d90a2939a5e8e9543bd4004657a8066dd0d43db41dd520ee13a1e8198b3901ffffffff0aba9f4046c797f01ff4cc7f7771d869a886806ddd775551c839e104d513832c000000004948304502201445b5c7a6e3aeb13ffae903caa1b2a82d8b5abf416bfa40e813ce2cb60d7818022100c33d7dd462f75efbc1902be243a85b1481b039402c2986f7f557c98251a8029d01ffffffff0b8da084454bbc6af5e551dfe3df157c683f0b1729e3a8aaef08c9a80e4676a200000000494830450220414ded20ba52fa1cd1600aa730ce4e325219bd41f81bdd59b60d6e236ea188c7022100980514a6a4b7019b3a21846de2e096846d79572a81de83b1851f3122677a3c7e01ffffffff0bd7e195d2b73de0cc1decd079aec387c1e8aab64cedd57e661f77fb8a7755c40000000048473044022017acd915ff7c9253778e09cdf1c62b73a5da96744528a17c1bd04c9dbce8873902205558ea3241ed969d6778b41da3bd206b51d1667be736b7e39b369c9ec6265bc501ffffffff0c0b1f7acbe5b0e36d3a5fbda186bc7f7ab2b5c4f7dec080f39be6edebab95dd0000000049483045022100ef9f56f02595505a5487af4107df7ca43482b711f66a367f3dac623fb07682ac02205d1f58c0b413edb57c694c99a529c1e0c221e972b37e51fcc84269bd6e687e8001ffffffff0dde06145d3ead0638c08c2622da19e126b59bfd44feabb2893475cf23d76b35000000004a493046022100f1fa9f47d4afb46d87e683c0b6e4fd189da7cd5710213a03f75e438d6447e8d9022100a59571356e76a431def133d6b04254e2489f4fe1509e4126c63afa69d9e267cd01ffffffff0e078594414952dfac431b22ddf57291fe9cd9ba8dbeddba3e1d30e891b26235000000004847304402201ea5261a27a8b4c99487bf068b945c3e60a6e4db0e4dd41c81cfd3f016a467910220425489d3e03849676c8c6fe8ca13bbb915ac8daf012cedbf55cebf394e054a6101ffffffff0f3179d4b728a849a9660ef3a88882d626f2b0148d594933d29f89c66d1423e60000000049483045022047141f5b241b3dc2cd10e6194cef69fc5a40d78dc66e6bd930debc8fd94411e9022100aad91a42901b92c5c7ad4a856046937629d1981770a6d1fab5eb21335e16fbba01ffffffff0f63e877a14f6126e47ac55707072e10a0f9558ee6d00a68e782a4ae70b8f34e000000004948304502210093df019b7f85a3e3bf5b2ac3c0496ac538ca78b484ee7f8a0e6492416e04dc350220516bf2de7107d45e13eaeb1e087232a275736854a16ee1e524144ed78e6f1f8701ffffffff0ff50d455adb3ca87896bb2430168cef3a43e48bd1b7880e91964493fa4aed1e00000000494830450220284f57f545e4e834610a991a1c02ebf96b825b9dbbb693391eacb89485956c3b022100d92c2570918235c16dc600cf281a7d49b79a1d5d66248bd7da658c334e6ab0e801ffffffff109c57babaf88c4935661ffbf3c984ce555d819b1bb26c553feb7da45cae22b60000000049483045022100cba664e829c7774790113ea9f9881785c6ea51d383600f0003f92ec24ded5d4902200813581234bce4ce303967a4defcd9a393437a6849f40bbe0f4b125d9d9f27ff01ffffffff111f21e83865abb3559bb5ac4155acfa3de139e4ce398891783a571b2f591ec9000000004a493046022100a6b43ccd70571ff70581eb012301cad22bdd666a502539ba965bc38de049bc65022100a3ae44ceee08a6026084b690d81e9589874606e7932f61638c33ec5a7eb1693001ffffffff113176ebdd7a23ea9427d3541259bdafb51cb6c24ac055cdf7baff7c0838ab5a0000000048473044022027b38917a4914276737e95b7707d44d332c011b098ec30bff74cd94c53ccd647022006c39dafda8d1e522a5f78c423ee59363976a3f97e1d2072f783b05321e636a801ffffffff11f56957ade2e74823918fb6afd7599cf1ea2bd37c8ee6dcfd69344ea884721f000000004948304502206bf6c92ba98c4d2965c77ebb2362192038b77edc1c2dbb534b066397d96bcaac022100f45b1578347549e3ac52cfa3d53ca50be3e35ab6fd81b944b6ced8ec8fa66eba01ffffffff13dad48a036898c6c303a8227fdfffadc0bfc1db55b1c46d3b0ab2a94c606137000000004a493046022100e4975d829d79b2b43c299c67ae9c01e348710fbeb8dd6e8773a30b3a443193eb022100bc73571f7420dc2252a2a06021ca67eaf2f7275100a924216c94756da612008f01ffffffff14c2ef236c3be3e87edc6f9349052f521cadfd2baa98d963b99544b3b0d7aa7300000000494830450221009e0e0b1416cf33eaa4661d10bce06036c408637eb17c70d09b1280b4fec6da1c0220653a4d32ea5ac8ce5e03344766981b5cacdd255c8d1b857cbcd05e4abc66c62801ffffffff15cb342ad4882ad2be80d189a38c8745831a604912fc97dade36064f69a0be8d000000004a493046022100e5c5458019a16ee9141348bd269d9f91738161afddcda2d306f735c0a0e3bb70022100dd6e00ac8b57e261f384440ed95f8ba4213b6254cb1856baf2eb40d081dbc6ab01ffffffff170b1c3b99747ca037d5b91f8229b593f5c86a7cc61cf0bd3fab014d71b5087c0000000049483045022100b0fea0e4d80da0bceba7f724c46fe70cfc7cc2999eda7ffcc3d0f711ac7293ff0220518e50b9be06df5dffddf862bd472b2c17b2da31cb9547cdd633a3cc4cd29e6501ffffffff17be32f2aafb8dee87478d9154154596deb8b5105bc7c96117b8f349e9bc0ca0000000004a493046022100b731ecc3852ecb837178a9e0fbb9a3aea51522500388d76bbfa68724484db825022100c3e3f3e73bf30268aab9b84292c1ba30362d5d4de644048cf28932ce7187f8b101ffffffff17cb465d1d2ba2baaca74c86fa0f066a7931c1ee6e5bed193ed3bd24c7cfeba300000000494830450220219c563b6a44c66e953f43f8cab445ce939be7f94da8d07a4d0907d7da71f5e4022100c0f972d2f809a3fb63b04cc237912eeaf2daa857c38d7c0562814e9f4b773cd601ffffffff17d049c882367bda9ea4f94652f285e2955681c26f2112503b587e80c74fdf100000000049483045022100ec8ef5b3cc00e88bfac22bf3c773509d5cb6df7595f465d083cd0870af7fb8e60220266be45e5db7aa84a70c83c6387f4e991f5b912bdf8028eef2f9d17f626e9a1e01ffffffff197f749ab5269d8c64eb38b424ce2786f7eef2c137ba420472149b5fe4f6c81f000000004948304502203d7ef60224d70c0a50c37259e12bab5d7702a996d5b2d380407cbc18a714dfde022100b25062025dd875f5e2154acf8fe29d34d5af2a502db76d9e4a54adeceb79b18a01ffffffff1a5c2c614068d8c401bad0af3c347aa4ef450697ff40576d91e9e3dc897363f1000000004a4930460221009962e3f31b77c106a43498df7b6efd7003f470481ea9ff8449dac01ab71060460221008640a8fea52a7f9637419a4e5a7f88637672d64b8a39f8b8a89014f80179d93001ffffffff1ac02c366c7442615f3ed64e5045187133d62e25f506203c42705cd1751d7f6c00000000484730440220583333394e52e8dcc46b788bc3cfd0687c85f13cc098581e8c4ca0a82c170f2802206197a715c9fa0a927b8db8f83d234547d499b7da16b4eae464dc29a92998eca701ffffffff1b5055ed70bbc3c5012bc0d0fa69442c8f608d2cddd0b0240d1746dfaf46ef7d0000000049483045022100b9511bc6a594aa9d9a3f03cef92fe55131c1a17775756e0d98bc1cb80d0b68300220647e7cca36a6edbc9a5923f3ad5ccd8b0956628e108c9b70d9b2b313a153b4b901ffffffff1c611a4d89478c27f13de60f254408798178bcfe7cad78bf513a5e6b77b20ab0000000004948304502201b5998d8c3256cfbf04458a17d2e54f4e7cba55bd439440620c81630192142c4022100fc90a7457187d9fba5d82b09935503d443cc204132d9e939fcb619950feda40501ffffffff1c76cdfb610f57dae30dce3a1af158ee5d0bb6c1f6e24e782ac3446e21a55cc20000000049483045022058992c7ccbcad3f2a5ce5ca6bccf2d73b0cabb076ae2234ff3bc2173443ecd79022100bfcf316fb93e5e56bd5953f245f1235f03c1f3a153b6750d112d8cc6e3aba3f101ffffffff1cf35ebee2080007afb90e7c49755a470fcf9848a070795233e9d25dd0a9593e000000004a493046022100e22aca94ca143a584b75777d193bf9bbe6ed1b3385a9e215ae4eab80c50d9220022100d5ed13181e54fdb0b02115a8dec0c21017372f852f91e30d077dce0cc1fde48901ffffffff1ddbc54431fef9d92468344f1c577652be343b01b6a48edb669dc4e0f77a605b0000000049483045022100ff7855751a64bd2f774b901d54baef30e45526382ebf1067abcde59a6da8026c02201238a4dbd1bcc4614513a49173ef5ad8495a3255c49f8012ae05b684eedb93aa01ffffffff1e0860e1d9067d3bf9642b1cb65183bb2304ba65f253a6a675673583320f71ef000000004a493046022100a8a7f871ef8946f3c8de60bc4e7a06e1938f04302cea7159c1b22b8f01a52235022100b779229521ac870bd88e9de53b9ccdcab041c018df8de51c7c529cb7f838a00101ffffffff219119c0360f897a7fc0959a244b693710d42a703ca4c76ce4b38814fa3d9d5d00000000494830450220601e32ca40b406721e39e8b03df94eabaa33a5a69c4f68ec9e3f4f864fa88187022100aa29a74918bd43e019760fc8c883b2f18a8bc3173739947b421327c22d9c58dd01ffffffff21de433bc832d5318bb4936b7dc362c6498b5c26bd3652b6b3a309649fe13bc9000000004847304402205afd84d2ab4c7a9f4d31c10592d27224b91bb1b1163de1a0168f05f79c6a44ab022078b7d574eddd8bd88b0338c9253b679e5bb9c16629999f102e5dd6b5a05c545701ffffffff223d99ff54aedf8ed12fff91c9c4e95c73459b6652549826484145107ac01e010000000049483045022100f81075743f88f5a0ce0d18f3855e90f73257f3e28d152683db5641245714d0970220692c015b3f72393cd6feebc139ad063b2dae552f0aeb6de463caefc966ef1a1e01ffffffff2548c53f1c45df9a00772f473e8cb41b88f77e745a92b796e408bcbf1bfa5890000000004a493046022100f86c9292aa078c3ae5668638f84a82db3c45ad55e2ff6794d92a2906230784b5022100f47bcb1277218a93f5e0549a343b44b0a8ea079e331d0a829e101c30d1cf37a601ffffffff2285810fbef4ac137a875166f523f2bd0355b709f02f24c6375e843cf5640ce300000000494830450220250bf9f742521e8559551c7dcc880f8794f6674fcd4b71cbd4298233d1cbf2b2022100e2b70685f235c5bb6e2952014bfa432b09e1efdc38c115864a3ca1e7ca6ec2fb01ffffffff2377b68c40f026f0f8f9454c23d851d0960efbc910bdfebb934fa93b6671b2d9000000004847304402202db9e91c487e8fed3b47df01659c347323270714865f8bb52468f28770cd0e64022037af43fbca4b3bfe09c33664848a59735be51022580827fc8ebf9cdd63fec04701ffffffff23deb325745bce68db681ce40d9ce7438a46ce7f846fbb9bb7467ea9890a800e0000000048473044022011ad94021217ea257b2b49f8a20bc2688011dc9183d8f57b1702526751d2ba6202202fa5b40c5c2c84985c7b0b7fb654008e9aa709631fc7e3cddfd091a7714f743501ffffffff2410f743a82f4fb9afd822d5b183a7683547909bf1f9b3726b910f434b7bc1ed0000000048473044022045fddd6fccabf46fb72359ea8dfee9eeb6b1c22b3dba920c7a63c597e4ad5b9f02205f3c8d6820e3ef2b16207358da740a8ad141896100de1989d13e238b8442368d01ffffffff2459113829f2d932d34c7dc04e28fe02e299684cecbb69a4863ee5214c169267000000004a493046022100d8ce5296fd3727128a2f7d099687682f464edb9ad3d660ece9e2f156fc347c0f022100d56d14cf7058f6d71f0ac25672989cd026cb269597e58038445a78a3b7a9870d01ffffffff25d4d2df1a58202a3736ab9d59732c91f2c58bd5d5de6301b5bf33da874cd964000000004847304402204b43668522291497fd49b2b84d59a69e0b97e7c5406f0f9eb6f14c542b02c29202206b8e140204528dfc1047dc81b1081f0af9deaa8cc6d69cad5fbd6d4d9218aad301ffffffff28299b976379a91217086e07185fc3d8e8b78f01e4eacb8f2967473011b98d3c000000004847304402202a296a750605204b97bc572a38a247ae49c2758574c026cbc9fa46cefcd9fc1a02200dc819c77cdece5c7c5409961675903bd8a71ea7a9427771800d6e5a42d1c90101ffffffff2935fed7815aee4c1027600dd0c3b07361258cb10e84b977cc745dc23292489e0000000049483045022100d1fc7e8c0a1eabd8cc5faa9e04990b5ddbd35e65619318425066f27e180cca4802204a722630b8af36969fe0b95086c6f1060662d2cd09c5cfa6801f7a3f1a7a3c1c01ffffffff2b615d0c359e4dd0fe62be58e0559186ac293630b6d10075ff9bb781428f1aeb0000000049483045022042bf19cf338e744d73b7a232d4ba4bdfba9ef88c6679c0451f8ec14a7bb6b36b022100afa6778b1f79284cb2d6ea6eb3e5856697552b19f9de578509d530f658eaf82601ffffffff2c08c5ce5c19dfe730300f94ce36c1cba9c8b654a53139327ff7fdb6731fe0d200000000484730440220456be726b91cf1076da13baab2fea1b6e1ef6985bac8da5788880643d7a2265b02203fabc2ecd9fbd313cb70f67560ff3c9068573501a0dc30abc1b22d1febf7433c01ffffffff2d51542eb75ab7d5b7b860d04c02defeefcb8416a79712d604e5b8a0951e2aa90000000049483045022035467e2ea7435ec6ce5fb8f765bbba679af3054cb6439cf5ef31994e26135ecd022100afbc66661356272f3b88706eb2ad987c045e0b189b1dde906e24edf8b29c0cd301ffffffff2e58e49a831d30ce6277da8d272aff40e18f2c9adcd1a137f568ddd1bd8ce5b00000000049483045022100e4ff3395dd6cea71329ae4c7cc89dc9a928241cd0d395292b6269be0b802bf3b02204d25bf41b86b257e3b18da2dd99c5a9a0e6fd2776ecc018912a6460fb77ae43801ffffffff2fff50ad3b5122670b158f99225d88d6132f03d55fbd0e85658b9f8ba521e3cb000000004847304402200a446ab0eb3b2b3d545e5b3732d9640590f69f9dbc8263cab41a134f7f8f5d46022006dd68f100226ca6be75d0720d4d42ae2240ce6e0d23ce1b2801990dec99363701ffffffff30ce9323379ecf2b87c20d873b0725cb4e3866ff36469275dfe307f4a96d6237000000004948304502203f22c3422b83c02f69be11dab0df9759a3334f3b076070ccd389a2cd08e3d0320221009e244cb793fdb12ed5a57600bc85be53f0ca5bebb49439cf97f60b920a1c628a01ffffffff3144c3c30d3768a4508ca831d4654d767789bd8bbc00a18ef3d7c664b0f8c420000000004847304402204674b149c85ccf779353264cf786ba17d08e0380158c0c014aee3a1dfc20a0620220310cc53a1efc9884fb025541f59e68a38567b08fdcea507e0c9d4cebc21a3a4f01ffffffff3146fcfc83c88f7a6799ede0b85a045100df39981ccf03999b94e3eb1f3d4022000000004a4930460221008d79900bb7999a70e3ddd14571eb37619f3592d0e27b3774f6eb0fe7c9449d1702210097c35ca2693b5901ef3660efda5982c7e20d7efd0fac4b01038d693c0dc84cb401ffffffff320fcce1d1093bb222baa555210713124a2557ce2c842a94576801a426493ebe000000004847304402204ab92f79305cb078ac6db4df69e303c5fd468479525962a31c6916b1aa4fd25a022072960a2f467f8b64a3ff626d8dd788cbee6262b6693674d10e458712669ff66b01ffffffff3429d949baea8ee99db576a2ced99b2fc36e8e84786e26fbb566cdb12c5c016f00000000494830450221009e7730b0833919fbba6eb2f47f9eaa41295abe93e3e6089ef45729417f96dd89022064128b1a2b99616a2dcd0e349e139b4a62591437667a9e9655d5720f0d7c713b01ffffffff34b94c40d17e7862e9355b31f07b4b141b377d61fe1704b871a5f887b3ad83b300000000484730440220635a4b0881e97b030112b33d35fc9d881ae4d86f1255924e5b82a2e15a4bb20f0220792d907dc1a9ce8a1e737fc4df4814c341082deccd8788a7c39dbc8cec0758bd01ffffffff34d9a1833b63904d3bb83fc76ecc284107f2234b6084a9889f9516c2617444030000000049483045022100a79d181436b47307ffb0cc670cdb52b8e8ae2ac6ae7de9167855610b720997b102206270b50046ece68f548f0452734d3d66f13e62f166389d341e96d565a6befabc01ffffffff35e231ab85c78ce4702dcfb5cf21ef2f6a83205fa28d7759732bd7af641c903c00000000494830450220633081d9e2d9902757f5315cad8888ef3982ae2d454f76f42b9a49dc1633c745022100db2361b8338cd03b94b25439d13c22d0cc31e08a7d1e85c812202a7db2d98f5001ffffffff374f258b62986d40f1596532151567e3084ab391177e7676ab046e6e2b7cd5940000000049483045022100f02ae9dcda24c38efcec18f5fe4a33d47693950474b0e18fb37691a49a4f79c402200badf3a0c869fd0ebda2c0d8992d36274e80745b21ee4c02ee2398c56e30cb9f01ffffffff378d58c1c9c260669fdbd56339cbbb264c050bca1a6c3a082c64cf9b0159a42b0000000048473044022047768545debb93a1832131eb0319d5b93be4c72faf8ff725af27d322eb102ad502205770eb7aa8143fab0689a5e924925c807d361510d44c9b4576868b1580eb5e9501ffffffff3853778f7a2fdc1eb561f63ad47ac315352cf7f1547ed23c6f47c67d8c7e77f20000000049483045022016f9927fff7dc6e411ffc0f606bcc8ed329831c5e3885d019235393e1a2fa32e0221008a8f69a3b16636927cbe371685423dbb032f1379aa13f856a88fe53b9564e15301ffffffff38559bf90fca05559f52051a816b80059a96e86a742226551e058dd7f7f0ae77000000004a4930460221008e928e54ce0e86957b1079ddd0209e9f68703756b699fc8de62eb2f949272ecf022100a6c728c9f758b65a5d102273388bbebb199bccd83205bbae7504f4686f2db8ed01ffffffff386ab7b603b4d7a7ceab0d081e2837e73bc0d2a7305560d4533d49e1b5a4614b00000000494830450221009cb8a7bcf22bea339ffbefe8117a76d053658ab8eed75d036bdbb2ca0e3ee06a022008513174c29f37535ca606b0824f5facd9c8fb8969b166b70d180436ef33f06401ffffffff3883d6e7ccf75ba8c1ac49530c62cc413607ba2de9ee528663801f6d05ea4de20000000049483045022100c29731e670b2900420f1122b7f6685bbb35b954ce60597e9f921ce9f369a189b02205bcd8bf950da8f9e62f2f1cba01fbc2ed07ae7f823bab970e164306c6c10507c01ffffffff3aec17b58f54406e272ce5074a28fe0cb15e7b76317434173b2bc49780bc9a880000000049483045022100d11515b149fd32555895327e53659ceeb84a2f097df8a2dac497bd0e1d46a1cf02206fe658cc9d8c3cc00c4e3f2dd3a93c3210e0bd58934ad4bb8643572a2a5e0edd01ffffffff3b049b59e1ce12b8feb6464b54de64a95499d7d7cc1709a4b8afe5806b4a6595000000004847304402204a306cddbe7f291c280b5bd5c0da9abb33be43c0bd2a41f777f28738a858a46a02205536ecb196ee09afb4d4a86e0d116889975407cafc1bc18180682acb5346532901ffffffff3b05347eddf62933522fbb0ec0b38c855c3bfc4f901338a771b2b50716b526280000000048473044022024757081b5626b87eea8b88dece2d96b81579135b209598424d030edd56e28b0022079057f2e641f91c02c8c858ec2acc8f215488b14f4a4e2dbdee3d8a00172c64501ffffffff3c773d9e8085ba4feec24cde3809f00285fabf33c526575ca3177d3f70674fcd000000004948304502200ad9ba43d2a914f0121950d82743975bb05f3613c23229d4607e02635414ab43022100d61e2031335d67aba044c7dcf7d7755a2d88986d2f2f8e01c851e70870a853e001ffffffff3ca63a49f677d7f631a476f45924f25af9979e5c43841cc73f9d36717fe17925000000004847304402206cc93fccaf6b56dd152e1ce7b22d6ee16a8c21a2a492701c2679f4453de3b1940220148c95066f024c476aa45eeda921d21c3cc8f048a06d0e7ece5c5069e2323e7e01ffffffff3d9f5f7288a55bfc86a57524597fc7b33b38bc0fabd3d76cdf553720efea64e900000000484730440220477432a990fa40064621f2b08713c5b1351fbcbde4d922c8f558894dfb12eb280220169df9bafe8416c5cf9c413a325bdbc82ed3d4153fd7e55cd9f67e0296cf1aa001ffffffff3f5accce0de9a6abc7a2662fc70ef5d02bb11f8221bd24555df9180b216798fc0000000049483045022100efd12021f2cbbf0486024d29bed5d801c6bace15102a5477c149f1508b08c7fa02202e54c56d85f5e681346255c1e287bdc43e12b7fcb3515f09cad84ea3236f1de701ffffffff40b9d059c5ebd578d5a0936678ad1607644210fb1bda944d5cff81984a25741800000000494830450220014d058ae79efa6319f985d3efd0deead3dd50940b05b4fab44a24b531e9dfd8022100a79d6684ce96a96f3ee8c6eb8d30a09a2dbc9b3c1b433bfa907665bf1ea7d98101ffffffff411a0f78818504b195b6b12ac15cc8538dc2f18ebce281dd947c54657262bd72000000004a4930460221009fde5a67bdcffe0395fdb277458a8f457a2880c1a1b7d5e1bf5b28c43092d328022100ec774faab54d33ba5a03849133694ddb8f691af8a92603d1fb019d5a7214828501ffffffff42a212b18ca54d517b1fb6eba0608383f04cd0e8596b2e6896e5ce760b6729ce0000000049483045022014a30edeb7ed1d5a55f71bb3ea2b3edcec6a02495b1f9e2dff3e4ca99ce41bf8022100e3c203cf34dc48160b47948dd60c804ff5ed7ee26f68692c067bd21627fb983001ffffffff42c5afa16a510f47bdb1c6f576c61dd8cad6eb0b655269805c81b3ffb4821034000000004847304402207aad4748723ef72d416b3701782e37b7f1e29a430c8b2293d7b09613c5c4d75302204ff9dc660c5cf145fdb52f96acf4ccea66c2e74fe577d84719f2b538a862361101ffffffff44a715cb6ff301744aede59490953e1624e74a5493666ccd8a6b41a0396e0644000000004a493046022100f1d6cdb564db11450a63df24829360ee40a59b3005c20da0e703104360b892e5022100e4f16987ad4929445cef41a79770b18fe06dd80551c69a20fe6184d2101e8df001ffffffff46d55f813ae2c85d47691ece94f5e06864fffd1c2f6a57920e120f3ec5a7cdee0000000049483045022100f5121f0e7502bef83ad3ca9f35dfeb762eafbc83b0c9c8adb60ba3d54ab7e7db02206e812d0e3f90325366f2cb6b0c730aebf6abd3aef5ed048653745d8121acb4c701ffffffff47ec2df763ad28de0ae20c8069077a50c9c33dd67409def49182104cdce821080000000049483045022100a0d96e4341a64f15dbc218414c3d39ea891b914645e073135cc3025ba5a1ca8502200689d53ff0b577a69fc853799c0058a5f6dc893b03f36914c54d857fc474755d01ffffffff47fdb84e9cf4f404fa1885efe1a46cd6e6b5e4efafbde99facec76dd8f4440a5000000004948304502201c5b0d80e127dfc36dedea06479776126438ceec32b2f91cefbf63a0b72eeb30022100d1edfc187f5f2e2af00319a6b07af54b3d6e2686e9ac6b668af6f684ecad662701ffffffff4810f17f4729463ee1d972e3ed68ce7e75d98339d15b762d8b5d689a0b88619e000000004847304402202ae40e9a327fd7daee1637ff328328b0740b5f66131db3216c2863d24f30dc7c0220226176b5f9ff24bd5150e965734cf8c24265b54c652f3453a509c7d2e4ae60f801ffffffff481e6afa95e2210aa27ae0a03c7d1dc9abfbedd196a91116dacdfd513e8dd3f00000000048473044022049f2121dd7d1b26860534770a39910c2e1e66757d037787ace9ddf3160c4371f02204b29d34121932a3e545dc3a6ab0c5090950099fd2f5ff151de2b6c031915ec9001ffffffff4aa2115365513bea282f46b9cb8c8f4d03ee3818d79a69e19d6f5f4207913dff0000000049483045022100d0d70b563592ebe1ae4cb7975d27ebf30c5cac3a91f1fac0df6533b4095774ef022002128a44a44f6abb7bcf7e76669cf560556a76eb67a3487d1b723c8a6eae7a7901ffffffff4c19f7eac355d44965745d1a4004966eba039789d057c950a503443946bfac7b000000004a493046022100e5f732cdf9626208ad06c9a3b416607fe464f0b08aa2f0daf439ea8da86ba384022100985541defca2912b50d9d15304ded34508d7ca93578b88bcd906075b804f735801ffffffff4d39bacd6e966ec4d9ddf73db6b6cb92ec465631eb46548a6281ab226176b0500000000049483045022100cfb1869cb70d4c0f72e0c20312c7496ea1687acacc3c5cf2289f217c4a6eff230220459e36a42c1cf58a3a17ea850f1add03d0369c020c9d06724291e08b97b3fa1401ffffffff4ee2b7a2c4445abb510f463e176f52dd6be2da69926703634ae33141da438a3b000000004a493046022100c4c34f84608cbd785b7323c07b246deb33950bbd79c59c925139e6d5637129570221009d151bd2bb270bde5dc3fb0d02731d79a01571908eabf5905f73f524337c6b2401ffffffff50905a88355792d02ee9ba8231050351a8516165b1e454c1f03804429ce993a9000000004a493046022100afff3916d15748e13684c9099f552f890e399d355a71290ea5ae1f71d94ccb68022100a8112e198317a61acabb0745b65bc11cf959717dd13c4562aa8555558f0ec55001ffffffff523e410dcf088cfb53612e71066cb7e26433fd9c668ca6aca65288bfcb2e97c1000000004a493046022100af1a7aba19118b9f27a75d29696dad904df90c41a30adf181b22dc2eec0dc75c022100851ba1ae65ab4b325e379a04a38c9bf6021637edcd8bce34630c46bb55e3647c01ffffffff528620de61f0ce1ce5eb01441acc7f7fc04366dd7ea0f0a0753cb6e9beb0fbeb000000004948304502210089c0f44584cf985e313e92d94336e618768555cf99149b0bfdd3e59fe0790f4e022021d3dd03180764c11acdc22cae48dbb9d90a1ed32b5273b901961f7d44d077c001ffffffff5337e957bb0599b78035031827e66ca0e065f961f635a89568410822144ea615000000004a493046022100b94c97fffdfe31e96cb0a60154febbb8f8720e68d2d178d6576e98e28cade804022100802a5843ab618771a24bbdaa68ada2cfa99f9bc7bc144087dabfb83a6c8c99db01ffffffff535900e57226ff5b43a4cb4e2401b4d6955d4b2d04f2aa1691be7e38b0c8e9690000000049483045022100afd1de544d66099b0d2eae1da32a5de4facb1c0f3a5ebcd5a93e5ce81e69694702203f123c795d74b83c19f7a3e1dfc66da52187e81cafc11917547be3ef85de137301ffffffff547db3034a5f0609acfea9d1c6c036c644507a908f41236d306a8c8bc44fbdde000000004847304402202e8827fe100c29a7f739cbdfc1034588da8d3abe3b3532eb59941709ff9242f3022079ce527c711c4634e100108d2274158728b238644d4c519babf6d6400dabe81201ffffffff5567252ffdfb2fda254e2d3d316a430918f2fc27b72f9213bfe48eff11d9c52d000000004a493046022100915d6a54fd76ca40b143db516e1b450e02f72714ac82c4fb2864c213e498dd89022100d1ecc0c6036499974368973fcceefdccffa6aea3f8667ea9dcdcb1ca71a8e22301ffffffff5572481b8f1412edc74862da66d94bcddf0e39976c9249c4921d7e3cb61f306a0000000049483045022061adff8a0d5d2d9689802b8cdfaeb4ee556e0bf97e944a741e118d88e1385e4b0221008d714027cd61a6f690caf331d4e81e3e432757a3c7c3d8e3b85e5b74bfe9ad8001ffffffff5616338ecb798a6a33c6d2ec0aaef0d2ebb0dedbaae8cf6c87e1620b757b38e90000000048473044022001fedad59099d6283bc172022bb71bedf12a4166c39768086e72fa592f89aa530220100b76af8a44f0a74f3c1456680c04ccfa087bde4c5ceb8e090e28887892fbc101ffffffff564c413e7fcb2d77a3b7ac3afc9b0d90239a2b8bc99fb4e2911a33795c8f9ee3000000004847304402207a11d8e7083cb23968512c4a9b0a4ee0d6c2ce8d38a1c1eb22889d240382473702203be810350862172a53cfc8d1abea75efe0d45bb3097ec9cde3f0445b856d37df01ffffffff57c1c9dfff243b86ed8dd2e191ab6e5aed652286a85c4445c218b86de58c66ec000000004a4930460221009028c54940aef8581887d273e2e6730ffb43dad1a4e50bb2fd8d512b7a3b9a7d022100f2aaad54d84f8627e841bdd38ada0ebf0410869e9d489662b8a89530676ad50701ffffffff58fed57d82c05b8e9d488fe9ac428fc07d9deb9f10fb133b4594573a057e0df1000000004a4930460221009dbd6b994f1e81ec6e716ad6ee6a4a66c365b6325dba06cd4bbf04ef68e7f066022100a3767c2096723a0378216299a6a05eb3a18a06750b7775903c9abee07e430c0501ffffffff5ac1c0a0923c76de161d14daf92a8dc598a74c82c4a669f37ef04eb8f6535e790000000049483045022100d94fc11426b2be900c96698c1a3613b8164ac04fd07d1d815b0cb6be5d99c1bc022064318ff8398d840726203dc82c8395cd6f26e26746f6a14d0fb8af8ae273ce8b01ffffffff5b0b19d44a571728441b07a5281b853126a2bf5cd5a67b4a37ce505e37148d0e000000004a493046022100d5251ad08d95ac5c6494b51825d897cfcd0a0aab56f8cabde201f78e5ec4f915022100d4ab5487d0f727e3bf2bdb66f4d91da32579d0718fea073f4cc885e23bf2775601ffffffff5b380a88e1bd5ebc452b8abc09892faad7a2f630c1ce135f586ff7cdc34d34ca0000000049483045022100c927d731c8fea2f1f0c27c9e6be043f0e50220caae2a76555d38a007b76e6096022030e24af3dedf0a73cf76c270740b22e639b03fa9f0abbdf4a589011c0fbccbe401ffffffff5cd51518e1c5762f3957b56a0624b17fe70b72b58d9072bc83b88c5fa295372a00000000494830450221008901b86ffa53effb84b80e15079d6b3fa8e15fd4a01a2dd0ceadb1314c599b3202207f6de9157655c6e26f3f9e418f18fb774790185c4177ed6d1a4df1eb71ec7a0901ffffffff5d6273d795241a501c887a6d482d3226bf11bcbb0c1357fbb366cbc422a06aae0000000049483045022100f84560bcacedce549084fe5f799889d53de0e19ce0c5468217aab8d302055c64022055753def4c6d1da81382f0e6110aacd4acacd1ff49c475f0ebc15457ae6933d701ffffffff5dd5c24174490e2c9a35b6ed1b29906ecf63ebd078f3df24b7106be35cd2a7420000000049483045022017ccb4b9a7fdeb2e73734fb30297eb1016aafdaf1814371ac979eeac4a04711e0221008e1ea23123102350eceac91c6649ba1001ef70b110153ab760cad2331f5f1d2801ffffffff5e54b42a11795cab6873821a10bc0357a45592490935075448403359aa117e5f000000004a493046022100a9becd71c27dfa166936f6a3ecdbdc2be8b75cc5af91e45c925a13d7426697f0022100ca77427f285b4b7ed3cf60d36dec1af3738808c51d7ad764f7fe2f1559b4f44b01ffffffff5e7579f0f08154c23ca07adb4ead638ceac96ab61ce5e6cf3b17953c6ff521020000000049483045022048ca5f0476a91df151cf8b0df67362e17823bb014e3a7252935f77880c9a34ef022100de66e18aacaff39847e83412ffd5888c67c26dd92e47a3bda83d86cd12411b1501ffffffff5eb262213788a1b55ae60a0d097415dfafbbf91ef01c40ba2d34a3b8a5ca2c1a000000004a493046022100b1baa30275d97fe5c25bdc03b1e52453c50d30b42eb82f232679c25056176a3f022100832352932221e0b02dd62890d1bbf80121fe3890b68b991ba91710ce5d5e0f7501ffffffff5f4ce1476a6b51ce9122ba5210df7603fc09d1367e068f3538a35e0f181010da0000000049483045022073b49c5db4ec64659a6d48205e0835f0af8f5894eab828e280a3ad20e59428a50221008d879ffb07f082d0ad60b23bbbcf58b7a809919c70ecfc350868e7299dddd8a201ffffffff6005a76ea6e1e23ba6c13e74225aef7cd0bd57d1c7c43efc8cfef0afefe2bcef000000004847304402203d83dc6b0182a020606aa7dc1f52cafe06e629f0be545929aa4cf2a83f5036ce02205a5f65d79a1cc3023ea72a5f1ec8994370fe947f3d764931beeb457ca8c23cb801ffffffff614bec9bfd12777195e27e77e23d1bdf2d7edcd98b1db281f80f3cde448edb2100000000484730440221008ddedf4f36aeeaa450b366e87cff95cb28e986f2cc111565c7e47afb39205736021f12cae92806b5053c8cadf8bbf2cd15877fd803b9e486faafbc42ed2021066e01ffffffff618b8c527a187a37be956c46dba6b09ed7b4dd2cb6e13c20077d734e6edd9efd000000004a493046022100b616b3c8574a2212227126cc0cedfbda0496732b1427f450516a888c8b501e5b022100f378de0cee8d96036ca8ed5e6ee593e36f62d65f97a55056e7716919f8bc095e01ffffffff61ae0f276f1dce326a3e7e7309d1695eb7071e9f6a2c71b80e38f1c1b524460c00000000494830450221009a8410cc4705c983cdba7e17dbd25586c31d3bbde94f6ff382e26caaed161b3f02207d1b3a8513c85390e285149999bfbdb141a6420575564c9fe9e4dc14dbc1512c01ffffffff61fb0b176b89637eb6189b434618a03ab9bf183a9334357aa570bd7d87673dc3000000004948304502205793a23ba5e8007699aa90f90f48709e01bd189b5dc3574d8341b2c2dcf8822d02210088a060d4f5459f1e05c2dbcf4ced83de4f627915c2b7e89657814c7c834ad98601ffffffff65a194221059cf948f788e75cd886682e655f6e9cff5ed6ef21eb809525ffeef000000004a4930460221008918dacd51a46d9706bd011ef43c91fa24af6e49118ef870cf0d5396205109f1022100a5668de881759331c6e5e9c32b6cecb10fe82e402d461fca7276e7895712aa1c01ffffffff6648c3e5237e45a2325254ab578a7e4bcd44457008ecd29e21d9b463668ee3b5000000004847304402202b3603312e48b461184c3fc66c95ba0246315e3d0cfed87eb9e6d0006c393ad402205c789c54a8393a0887625a6045085f350b42d3ba0ee5d1205057c0e8855da56101ffffffff67e8ae39d9b9f33c1bfd4ebf1affb4855a278c6d18d62551169f5f00a3e8c46d000000004847304402201b3c6fcb64d23b28abc387cdcbc3508d75a2dbab85b0a4d9bc65d6c34df1b48e02204cec16ea26179765b4bd02a8834c25b4c7af4c7a4478576d811f3952b5fdb92501ffffffff68aed869586c38191378c8cde2a1d42c027a6aba7d30c688fa333eb938c94f4700000000484730440220545db6f7ca869152907d081275dd39de85cd6e914f18d64861dd93ab07386a6102206701beaa9cafc94db98f6a183560f17b49b01fa40bcb43ebfa5a8c1715c910d401ffffffff68fab6cf015f2f88ae71fc072d6e590ca10688bc5affd650a3b299b09be00700000000004a493046022100d4279b2df109d59ed6e1685313e25a1c84579c17697798d5ddcd034f4e9f5b680221008f724062508578765262e1787d0f26357c86b35c42369b027d1a5a4e34a9bec201ffffffff69112eb489f47853e7ea3ede673c1e2ddb9cae06a23b0221fb9bf85da1f844330000000048473044022100a112e75d4956a47de08fdc90e1dbb5de5a8bb50bbe545b7be221ebd08b0535d3021f39e11df258ce1d9e975444214ec5f48fa7cc39b3300d472a2602849cb5e85501ffffffff6ac99023675eaf4780574d932785fc88172c410cc3a983875b6c07b8c3739b9a0000000049483045022028ebcc36c6cf8205a2e2866d670ada71251d23aa1170e04b11f2bb808e418d98022100b6b8ad9106e1544cd1d19ae67d031c4e540a55acb812a6b8f53f2342b8bd03ee01ffffffff6af898070730f0a3161c7f275750d7b39da8038fc451fbb7e201ea8146655cd3000000004948304502200635c160a3dfaa9c6703f6bbd573b8d2ae9c8752a869f860377570b5acdad95c022100d0477fb021b25c4579f5c1f8cd4b4c025993a13c16c14b9760f63fec8a46d6c801ffffffff6b0385725103e2bb0009ba5170d14d1a134b719af97eb645769777cc5187755f000000004948304502203c0b9ba10d12bb8ff0e96d1bfca044c8bdfeed611f691420c6b89e684efb6b82022100cb95a2b2598b540266f6dc0a1902680745ce101758b94fa8f5b46a329c4f79c401ffffffff6b6a7a43e51296636b542db5fe39af97b2458309bfabdf3e23a05277d8d8559d000000004948304502202f7a6c44e9ed089f82905882aaaba1d2f5aa3fb8f698ce4406149d76bdf995370221008447743c2d5426cbc03ed2915dd249ac7fe635195f40c41168c59bba8a21d5be01ffffffff6b84677bdd145ae03a07d9bd9bbea574b495c664c84d03f96e0d15d41c248533000000004a493046022100e4ec32a21eab5da3a1dfcba5a9730a129967cf58a32675610dca168539029b2a022100b412aa609e332ff566dc984a7fccbbdd64f4763c6c7188b36cebec2885955f4201ffffffff6bb2eb9d4aac3498936fa9254ab745e05df05e6d643881bcac3739b8b62af68500000000494830450220319d35a07595033c84233289d7df30c1f30eaa207b0d8b7b71962bff3539c0c602210094b84a16caa8d4547ca8392842462de161374ce9bbc31b94a71c3ae20632844e01ffffffff6c1f3e30a820fe590de95bc44807738bb98f27b5cdb20d17937c6c6f07aee32b000000004a493046022100a47f072fad2e424faa13fe1343524ab35cf3c38277c0f68d63b8266a43c7322d022100d0c6afddba08c8824e8f06533d5d64c132bde59a833d9d9224dfad9cf6ae39b001ffffffff6d6bf955f1750a3559677eb866f61621e4f5a64ac02da77274670dfb4f41b6b00000000049483045022100c62d70af9f414b6de5e898f9cf9ca9ecb626c882852a7e931c604baf35ddc9f00220352b008b54289c0463a0d9a35549ac43417f7a4f9974778d0964780c82f6397c01ffffffff6d76c320b36167a3194786e5589afc9a521574bfdc12857b31a988f6e74bdc76000000004847304402205c24fee84e28de0629f50a5fff584b620218f5ae101a450b172055bf820b7a0402203248972f6684cbcb3a1970af90bc130f55a3a00a1404a3a4c7f6be40fd9a3d3601ffffffff6ec5e0735802311c2d1c4789cac733c6e284b424d2846eab5ec87987a4978cfc000000004847304402206d678b34cd05c0c7026c61ccf9e62e9f2e661cce849785834239fb3adc8921be0220218cceef7936363253b2f07936b6d04b05d8ae7190f1aa2abe5b506934a8ccb601ffffffff6ec97793603b95cfe0702c5496c0ed626491204181d482678e93212d08dce323000000004a493046022100e1e215f05b49c78d9da3b8dfd880aa7c9fd218789a0081420ba2fa3688450513022100faa47cc99944e9691d78ae286bc0a1a8db46cc20a62f9db5a8687b8aac6925f601ffffffff6effce7ea82d79d2db0c10a62aee1ca53512c8ade978bdb839c2dd44b96b379f00000000484730440220498894fe5cd8b6a43da8b6eaccd40cfd1ec721b485deee43995c502c0859e5ff0220744e8751831ae38a8615920a8b99ff1b65098c721379817b6afab49cb383659a01ffffffff6f81f3705e0dd329d6690523c39ce1372fc92a4907031a1d33800bd29d93c4da00000000494830450220686a2cf596c4b05e1a1c1ddfcf5be79f4e424f6d84987c363e265ba5448f24b7022100c05918960dc9d7cfe30235fbe8c049ff990c95b929af0f2f55e26987290b572b01ffffffff72d758776b820b59bf7defb2d527bb66eb4dd14c7d4b7a885d64faba988fa076000000004948304502203bd9597fbf68f5299b30dbc7f75307ee3dee61b4bca52efeb9467f678c22edf50221008e7c75467869f280ce876a8391fed13ad70b3fdc9af7fa35c947d9079e5f4b4d01ffffffff735918d7ce602e6d65db8a93aeb975cc6b427c0f315cf04bfa048366e9b9fb7e000000004948304502201eaaa1ca347f8aec501954cc13f28d2d11fb1edd78fb53b8e1e08ee57f5cc72a02210093d3fc10a5f50716092c5328469fe50ede62e89f215fbc7209089df04075474a01ffffffff74a349c5340b8dc2692a4cba9fd8000c78d1e0572b9bae7a8dd06d10312a2f78000000004847304402200a3eedeff6ac19698807a97ad5ded5f22765f04ec02cb19d2c1bae3775ed3a4702205f9942028e5722c726536c57d82937c30abe95f2fd1e7f3527793471b06d400e01ffffffff74eb232f354e9f8007c9c62ea9ad1961b2b70ab71f3afb9444c4cdcfe4a8e6700000000048473044022056ce78585c984f5e0a3900f53adb075ab9076a34b831fb08a30149e20d7136ea022025e9c05c362ab9d10853d085d3bb8b2d6ae38ca41bdfad4037b51500c5173c6601ffffffff75378dedd597cba72a72e45e516200fd5bed2a0d14f108b29d7ea19fccaca8a20000000049483045022037a46fd5325b8da53d2f09357208b104f3eac5e0feabd7fabd9ef09e3c9a508402210092e4cab8e4a9edcb4b9adc5a3d10e521c977860731886f2777e2616306608a1d01ffffffff7619795eb77515bf562559102b88846f6aac11fe5aa5ee1b5890eb3f02b62ab8000000004948304502206b61aacf30e64d956b7313e8b4151d8b5e9de409e836077ac1e5d38184c38ca1022100f2eef7a5044e7eb4e25ea2733394d527e0209f47a5b0e55acc7b05d1c8718fce01ffffffff7710eaad30c3948fce5afe7453c76294427ea5c319aca1552890dd7d92e603180000000048473044022048c5073df007625bdda3ab473605802d4a5ca9cffa3adac97fb196c5ce5b1155022046def8509250db255316d9bfa151a17513d09412f366d75378d6029f105bdd3a01ffffffff7737da70e78710564a4c47dacd14655d2acec4f4b53c821b2c86094aa315fcde000000004948304502203d79e75c09f3e70164df36f0421c72f18a0811f2f18d58ae28aba593efba6393022100f73ba18c6e5f22bb8e85362dde6be69406fbccdfa20114df31ccb64c044ce3c401ffffffff7756915c6251a3434657efa25900dd5462898c6a68d58ec837e065a6704c31cd0000000049483045022100a5e6a079b655b205e7b5ca54669928a829a55c8c93aacf3bd0d4edf364012ba702204bbd05ac09535279a9349eb2782aadd6b8ba1f974bcb56bd2162d38d1ce5350b01ffffffff776e71e0a0cf2a1872ebda727c562ddc88565336378ba120c3d206a00cfdee570000000049483045022100d71e20f312a452ef0b28ec083c5bfc6ec1c4a328cd3d269c60dfbff3b8e99e3e02206f902d70f9b4bbc74fb7fa14734a9bec84d542f8ebcc8ed3223482ba968939f001ffffffff77893212cfb4a7840f484bbf9bea473306934913cfdfa2e9313ce097dcf49249000000004a493046022100c2d01dd5ec635f5200a452a275b1f46a64acee28ee5721ea6298f5df13035f100221009de6722c59d79b692a5de4546e9291d0e44daf83d0dab392c0c231ea39d30f2c01ffffffff77f1391f7640ad77a45f36110c4ab46c2c0e73243d2d061a8b01a02d77b6cdaa000000004a493046022100c26c580eab500d7ddf032ce44ea1e1edc8f0dde3779995c95e89885f7b0bc3150221008d885b412170511ce009c8334705659ecd3394a4502b52b704336167b412900701ffffffff781ddf8d4c52debb9814bedee3d7ebbbcd4b9598259fd4ae8f06bdf5fe563c10000000004a493046022100a870fb8584ae2f50869a311fd47e12f8af1a5b4b0e0a59ee64ae27481a1c9c300221008f23eabbc055f7a0cb732442b6fd35d957570235a60f63e19310e73d43fd785101ffffffff78942140c5d807a50eccbafd3b44e3b9644dfa234bfd9c080640d0297e90a50000000000484730440220179a09d4991ee73ed47c72d74f198643e9df7572c5449460eb85e3fbca3c3da102201745e29468369f9fda0cc2e7f78822b1b89edf63cac5746486cff980a2a6181601ffffffff7930da8ab07c69b1a41d66ac444df2416a60a3775ea748c13243545563e90b7c0000000049483045022100e15c5d637b4b2d1fc00a4acf39cb99010b9e043d4cc186127b0dec317ba06d1002206e50adc2c16f23d607fbff0214ba077717ed16e335a669b3b10139d14b4f68f901ffffffff79333e40f02acfcce8d35bbf220ebf4f97131c08259f8d4220a2563f20d325fb000000004a49304602210081880cabc6432c5398b3b5a13d133d763ad02bc5814159376ec1044528e19d1a022100f2faa8414b19166e3e48d34607e2384251d653c4214991e8d2ffbff428dbb74c01ffffffff7940e140edeb5d9c59939b724e02a19952394939655eb9f6d3d0b47963b4037100000000484730440220083d9ff2ae87328409f8ddd47d84a174fbd1ffbb5096d281e55b8d93c32725e502201e3a601ea531889684afa65ac96ae55fa3c3add50e9361bc4fc416c24f24fede01ffffffff7964f331cb2e1bb89eec8e2baee3a9532cc2e170d7dfbfb8f93ac7dc5d919c91000000004a4930460221008888f3ee1b60ee6517c4754271749261ddc94646dd825b08724e7b92cf3b5d77022100b1d10ff7065f1c9c5b73e4d92b2e223d8be1b82e755e53e807b56400d94838a001ffffffff7b03d068420a9bd242becb92c86d40ad55de83efb8daab77ca0ec5a7741b7e97000000004a49304602210094a0cd609c52d62d468d808a92783b0daaa867b864b82e67d016d6ab0fe204e2022100aa0f2e73d1984db311fff95fe3c65e0a2dc568977da29e9eee98038c69bdf11401ffffffff7b47e3cef89d67596905dd89dc87641bc89943aca9a0c2133e56ddd6d9a4f75b000000004a493046022100ad5cf33f0ea26f2ef51a2f010f175071d82c96922eac3ebaf20a8babff92b9f0022100ccc2a16760298c449569b08ceb4e49202254f4d94a88dba8c9779404dcbf92a601ffffffff7b779fe3dc4d613eedfdc7fc1e4b3fec85b1de5e7466567fd0daa5e0208084dd000000004a493046022100b0d09b4170ec15ac9f2eba454ffca582b2a6514fcb1860dc802c03f1abf1dd68022100b645981588f0a8253c1ae798c613ffcb05bec4b1218159ec194b7113204f83ef01ffffffff7c428dad903a6e2b6059ff1927653696eac1ec8605da8486ec0a09ed946def590000000049483045022100e09954e7d85571b71a0f2f8ac3815ab479d050996d1d0cafd01bda7edb1a443b02205cf27791973e935e8e47a597d762f7a279209d8e427d1f5a0c4f5ee81409914d01ffffffff7e88502fda8565c48ce4dcb8019bb0ed88afa8ec433ce7708d54475cac55de34000000004a493046022100c731b06b613aec4c6e3b72cca52a00824f2ecb5d568143774b18eaba7e6017e2022100e9fe6bde4264893e527ceb06d608332bbef24d37563305275394d4122654014901ffffffff7e8d6e2d9fbfe2e9137ee0bf44ddd9d52aa802fc2eb23dd033c4281e0d3b2ca7000000004948304502201001e0cd8cd5893af2922e4e995d8b9202c6b138efc6d8bcb6dbb24daff3a49b022100e59ffa9ab38bbb1b004c0589c41804e4f0a69e2c3f8aa272216a8bb5072a3e3c01ffffffff7ee25e1abe7e44021854e7095fdf00b62b2a731f66ec7194ad1119d9d0666ea9000000004847304402202701d292664af6281175a20ce137da73ef21a00006a3e1def07dadf291ea4f2b02204842c59207134e720ea7e170e7674d0e60bb214d20e67bc91c92cf6d237178b201ffffffff7febfffbad8d834a7f5ae4b185b64eef86c9f1044fa5db5e1c64acaf8d7c2019000000004a493046022100b25cf285ced260a2b0ee424c230e671f900500de3d5792499a0391c710cbdd0c022100e8f376b506759c55a2465dc0a589b0c77ae124f14c429e9eaa5c6c5a7d50cb9901ffffffff801a55a9d311c89d55c5aeeceb580c0a6affc7d5635126239e8b70e1bd6479970000000049483045022100e6b9b2634513441f07fc9c57ccda20cd579a778435ff04908400743720a30f46022077ddbb1cc94eb663cb49f90cd19a0351bcc6ee4bb2f97b0d3ebac9c693257a2c01ffffffff818690b0b91bf4d2139bffc074d86004ea09e4405c62852f21fd42c09560f7f40000000048473044022011a3b987ba1d33cac020a3f4a91fefe3927cfd8ada1716b2531194b11324f11c022068b0ee6be306d2faafec47ab7d461c639ee0ad0efb27687894fa7e7fefe27a2201ffffffff8298e8f1781b3f053046d8e95bcca2781e587dc48e0be3959e594357c86a8fcb0000000049483045022100ffa2e134a520242e552bb94fdf00f4a4f82a18ca3f2a01f5e731e674b2e9f5d0022057d77cb313ebbd92a32562a8346119e2aa8006cd8a3d84900375b219ee4d5c0701ffffffff844a9bf86b9cbe49c4feecff2ea4704786fd25789c2fc2a67f5b2024c2be490f0000000049483045022100b34b1d6d890674b47a6fb7e6d9cb7258b425da7b91aca2675c55d5ee9256528a02201f258f20c024fd9e3857ebce5de7f8626ecb4f160ad6846d1877d2e7b803e7a101ffffffff8451c18057a4a5a9466f0386e6960800f6c43cd60c39915b0759f284872edeaf0000000049483045022100f5f58c8ffddbd15180a0c0af1ced928f1eba4e5a505c2f3595968535f2a4b8bd02204c16bb1da86da477e1165dd26231c6c532f6b9b1fae80b89ed4e25d016e2ae5a01ffffffff85573d85e5165d1a6ebb3836134b01a1c4e0034db222c1dd18000bd5cc06ba95000000004a493046022100b7b37da8a59b484cfb12e265fe50c989e1cdf42250318b09585eb6306a23a62d022100bd9533e1d15adb689e3633f1b501a6c0825a1d9c25a3c999fb1ea272a2c0287b01ffffffff862c0b77e9ba4153f67c71b73f4503d5c0ac3c93a26367ea6f0e3e471664465e000000004847304402203716c750873dcbf35bee394a60eea151e4a2b7503e697a99cefc6c67028253a40220633407e0a90cb903b59a2accdfb220c00631d376d88c9e69a5758d35c9ba2dcf01ffffffff88e6be84a51d7541a9c9c70434e44594a8416a0e348cc3622edeb8f0d8cc4f5d0000000049483045022100ae16e133df96ed76694b70ac46aaa2d33e80c229ca3ad06f8853a81eefb2f6ec02203463db7e22a4fb36a163509436d1b195b8f27291c06d0b08d1281f210c4fc52601ffffffff894da141e96ec891010b2b0cbb3aa7e54b0565c87966d718b57dc77795da550f00000000484730440220509c40c3f2601a5d445d25dca07f7082b3d944936f1796473393a6e0063e67dd022022fb210e19fbac7db9738ec9309b1db5c5524bac56e6576cf72dea6ca064ff4601ffffffff89a2c60f941d53356f38bec562f6ac489bdf45946248ca13da190b79290cda77000000004a493046022100a6f65a7d8eccfe4c7bde37e817b6ccb04dbf95b350e6cd89b7ea879a118455400221008d06944f6fcaf3d11d2432ab8ec53836e1ff9116a4a32c5926674c9334e2d1aa01ffffffff89a63240371778e6bf5e35917049a5518a3140e85781c9ddfdc8afe8807b8a990000000049483045022014021ee5904b50cfb9f55c6002c6e698b6c0ed867eb85e77aaf3bb35bbf57a2f022100f846517addb3eb0b52d4ba343a356c0b5075dcdfdc07effbc4bac62ce20a1dd601ffffffff8b1e3ebdccfa2b4f4f0453d4f49c5f6f47d2f190c26b1e364a46db131c37e90d000000004a4930460221008e507d04146bdeead16f76d16124f7bd52653e3e4ce3a28d837a15b148e9777f022100b753b8b1a0d0fc7c26340d82487918330ba732e885fde8de5bc3a8143478df9301ffffffff8b257e790e7489e77ca063b90caace8f045deb28195e0780bcb745031cf0ba60000000004948304502204ab48fe0dfd40ae1a320f8d02ad4ebaa6506644ac8e1feda2f86a3484439a52102210085ef68560924d67e68c7afac1c2b5d1cfa714690d471fd41e5318da64269c00401ffffffff8bab2d4aabc855bf46372ff973889db3f94424b372a0c7d8153698faa060eb0d000000004a493046022100fb926148bd88188f546d85a95273a9f485b25986d6c065c5218f9daeec7c467a022100e1afedbccde8d0ae0e6546940e9ef83acd518f12086c5b9b71e9847f71cce7a101ffffffff8bca12aea34bc965d5a6f255a7fd1898a22a00ca8f15a010c2873564954ed6f70000000049483045022008c9d60755ebcd70b4ef6c6754a7e24042b97275e1abb6fab3b1ff4855d2712a022100f3e1df634620dece234f64ac14706dd3ca5e965a7210568f2964d38998b0ff9801ffffffff8c49996e159faf33a40690da0c7a974ea918f7e3134691d4796ebafbef3046d10000000048473044022022efc8807f972bf9f4318b4298aa43d943b2a68e69bdb11d39c219ed94e3d6460220638f01ebb850b8295bb8fffc5fc9f6786fff631a2a41701d01567ab95d8b0dd801ffffffff8cb9fe5bc1e59ccec6c83196b7ea004ba1efe827c6562cb8c80d7638a870904f0000000048473044022014e5dd40a87774be4cd6d73f685920932f9beaaaa20f5cd25ae1c3da65aa52640220529cb3edf380315b123178b4f5bd4a3e96a0445e34aa2ff51108e1b3251e298001ffffffff8cfb9a0f1caae90956d9497565e0f72b7175911648469cc864266da61f7e1b5a000000004a4930460221009d4b871816db91cff0b00ffce1a8cb7d877b4b23f05cda087ac91d25aa189435022100f75fad399838775deed4dcb2ae5ec8292c08de5918bea246dabadf0abce5a05601ffffffff8d3c26e467f74f25f2695d94f2f6c98c3a98cc28dac6d003a26cb33c190df2e800000000494830450220418286c9a0239550802170d208889816731bc6d221ef57471f98475502c0f014022100aeff00fcd176c6c5fc8f9455d40b61f5f678bf5377721531d80455e23f1abd5101ffffffff8d7af862867073a00a0bb8c39f582724d77af4d8a760190d857d772bb348ffd800000000494830450221009db99c52db1ad4e47b1b607f3497480c1f9f3ab3769c351e59dccf962ac790a102206fd7b0b3f521346c93d97c9164eab339453ee914de24644088b72ae52523028301ffffffff8da37018ba37511c6c4461d1fd3c5001f4df1f989edc16f2fa5c4fc70bff730e000000004847304402205e09444aa1009fab4dfbdf3df9db6ffa49e5fada9cd69abcc13e7305e78ab97002202b2cb319369fdc6d2eaa5364a74abeadbf6a2a7f1e57d18cab1f84510a0f909001ffffffff8e5b11c2f39e426a2d720c0be451bad296917fa81ad093f30742645a48c7e4bf000000004a49304602210083a156c2a7ec089c368147705d813461042fac1d0b0a0075e373257560b2534802210081ab619400685fdd1a4eeaed45d0e04a41450ab3d0baa784b5e23cecd46e3c4a01ffffffff90e31e03ef97a7a5203684fbb07a6f4ec306550145cd9bc6101eced8f4da5623000000004746304302206d6c587aa369da3fd6e75d8d27805fe7aaaa56ffafd801bce491887f618afc0b021f066ab880e1b859e909512746c76dd7f5db773a96022dc13c38bdcb4d04fe8601ffffffff91d230879e4947d8228ccef36aeda6e74ec59fea2713f144e4fe26d81b1cd34c000000004a493046022100ad2b5f9dce7532a534756be5f06c04a5f45b39025a455c838ff85cd102ef7270022100e46b305cabca991bfb5ba22a9fba974b79e314f855b6b43560221d6f05cee90201ffffffff91e6ff33e4d5439590ee754379ba86dcff4de11f2cd059df1178b62c0b2ac78e000000004847304402206dfee2e40d3ddf0793c9c1a2af5037e005c1d94b2ae0588fd83c9229d07a5c900220670ddd065c45ef184398c340161a6914ecf7b8287f87e07d5586fe8a9495910301ffffffff9223197719ff8c7ed28fb79d78f84962888163c3a4d0b111abb521678d1bd32a00000000494830450221008b1aa27bbd8402fa3eb1e6a4aa23a7b22df218faedaf188d81d25041cebecb3002206f158c8509c71e7547b4def691b00ce627dadaa6299d49d2ba134d42e504275c01ffffffff9265f3d32803970b4a0fb7dc7753d205c45e3feb7e951c18c9b7fd828bf58c320000000048473044022044a80d4244e47d3bac8a0fada613e01eb0facb377987ea4157f1a87c13196c9f022024ba161614c5e76b658432ee22e4529c82fa161068627db6524c4f91c33794fa01ffffffff938620903eb8c3667d6e594d7f174270660532eeb98b34b049f57f31e7abb2e40000000049483045022062e601675632b46311c8415c261ebcec699b043f281a67ee64e6c0912c1d4e91022100fe27adde73276d60dc4939a882005d90357d6a492bec7d78d046eadb66f0afce01ffffffff947212d5b44550497c2c745780da7821051759c23655c9a27f8a076e65d1094c0000000048473044022008cff8ffabf4261faba4d703d679aaa6ef51c209c468043348f17062ada86eaf02203e401d773a906b37fd22f0b79d4a54d1d5aae7e286e4915de98e302052dbd04c01ffffffff94791a7e38ebaed89404acb613df663be6f1aa977abeeedb0dd73a480be5b778000000004847304402201d80f8db0a5d6a55c0389e2250952d6abf49612b2ba5fe593f1b55eebe957316022019cf7cb98c77ed2d079b00a63ccefb4f59ba77136180e1ae39b51c24aca5f4d801ffffffff9483ab93c7b0684a90e4f5e87195d415bb3152195f0023dfb5210704003a60240000000048473044022069914e79e91d237a36bb32edf42010fa2446377978cf0f07ddbf8019ae2125750220521961adf3b520dcce961476d441fea053b9513dfcb88dcfecfa48f1b592155201ffffffff95ac66140e19b806afafcda3e8c6bccc02254774df305f5aad7a0b2ea138a5b6000000004847304402206634ba4283bda739647d35b6352432435ab8b40be0f1a71566c34425a67d36f1022077ea07d022166ff4bf3df5db66bb859fd5bc17a4559ca19bdc59d2a06a0ad92001ffffffff96fee98d89318859fc403f8d99b7202b4264a7cbc8a8383b21ebffdaadc2daca000000004a493046022100bb1002f9f9866f3654453f2ace56817fcba733b9239843794e02ee353d5b86c902210086bd6415151ac75cbd1ff4f837eba5faa73992797e7c61af5edcf6c4e243dadf01ffffffff97a66fd7634a130bb319b4e2889cb7e13c6abefa4c289e23cb66f7738e81f23d0000000048473044022010b40104052522137c80a46dad92f5c550d5d57daecad8525cc8de5cb53c66d202205b771cc996e1b70d1bb2f2686339efc9f7e2106e0e93c8674c9a3f04c4b7bedd01ffffffff984c8aa2574d8771f6e548645b06886f284d7f1cc28dcde591a32c986e0e5fc8000000004a493046022100e2eec85dd02f184032bb8cf1dcfd7202b056ade9be59cbc6bd1ce2b757777a6b02210095857ac0f67e33bf19e545806d616dd3bfa498b0d079a2c15e3bb87d99a0d2e601ffffffff996c6a67bf8583825df410b069c6a80edbcde72216ab23e433c755de2ddeada2000000004948304502200ed6ff9b14579f7c6c96f72358e4fecbe87a26e4121d5853be336bfe2f61dee6022100b6ffa6abded1e709d970827df9638001671f3f635d01bfb64c29539627bc55b801ffffffff99f8bebeabd8f92efff4d8dff9006644742f94a131a6219b4aa41cf3ba58a6ab000000004948304502207e4780375e7392a9f9eb37c617a5380242d56635ad459e93c0f36ed90bf99edf022100fe8b23600cbd1a22afc7e2b77a54011602d26aa18503d6fdd310c74c8090187d01ffffffff9b40e29c2bada803abfad905590d602998759f659b344125aa2d9539f319a1130000000049483045022100fbc9aff9990b28ddd56bd0c6d63250f09336edb40628f9b884ca9795c42362a0022003ab75a40fd1a8efd060850b910c5d22572d5febc4b2ae6648eb9647bee3563f01ffffffff9b64b6f5710a3737f2073b2d1c98f18cbf1413753a4bb70c7e1dd079fa2c54420000000049483045022100873435efc79271507b42dbe4cc6af0026cd7538b81ed0e423dbcb8fcdd90725b02200581f3aa0bf0e5f0643a48f83d9a8fa351e4ccb196021d7bb8ce6e4c48730ce101ffffffff9f5edded3a40e29eaa148f54dd5817d6d5bbe90d258cb4a97baffd5e3abc9f9d000000004a493046022100fdc2e54ad0fefa4f49566b5f14f680b8433666053ff33a69589d0271c8843d9c0221009a35b192abd29c56d2ddb02f439f3b37cbbbf2411456449e948ecd92619d97ae01ffffffff9f7d314059025530cc7ae278a775c04f1253fb260f4d5379a9fe74b2e44a27050000000048473044022000c2a8ed15acf636edfdf5ffddb2c48153e7022d92922ce875b72a93c65f03b70220686c719153c935c62f30766d727984e431bafd389099f3f8c47b7bc6fc22066801ffffffffa0277ede350707b54db02848a34a1e0b40a455702921fab1ce244a5eb0e33aae000000004948304502207f4d49a22e5d62758c566ca6fe9be1de1104a4515f006e1ced9841201e52cbad022100835e2d66e977fe3be3c9d336fc3cd9b4826152443f44aaddf701cf054bddd4b601ffffffffa05a3b85eeefc1b93be07decc17dd13c5c679363979b7ce749b65f2ceef1ea910000000049483045022100c7a034ab6d420842bd4a7e06aba6df32d56d502bffe1e6c86caffbdbb665ab6e0220430a142144349421d68863c5ba8e92246122bb4bccf7ed2f32fef1ec60f299a501ffffffffa19d68a9c3e9e82af5ca6a792779f05611ffed2b35d04ad9e0bdd8183a728eb70000000049483045022100adbef1b4aa1bb122147ec24b363f8121a806c10c0ad205917ec66bf8da8a99dd0220209c47677888f657102a1b5c2ba7d1d22110239413672c55e2baf657458a228601ffffffffa2a30b392ca1314648099848d14b968856701771198db3226dc68b344ac47edb00000000484730440220447df15207cb9b7654297fe37662d534cecf665072a22ad173dab519fddac484022017b7ae691a10a60ee93625b9535d9b8eb4763dc7496fc1eb7f1c89f117bd554801ffffffffa2af5ed278529c3bc1fadd29e8f3eed29c69656acf25359b2634f2380f118adb000000004a493046022100994a32596dcbfc3a5d47efb6956b2b0da2a459e1205d857cdb2bc9c09d4a6deb022100d608ff533897cf6e996731c73729b8ae01eb91f0170c70cdc19084a5921e50c401ffffffffa2c19a55afa66d93c9f54595abb72ce73a3e192ca67e3f121e5995444ec643d50000000049483045022100dbfccab011113af2cf0d2d04a97aefebfd37d4564828f7ff31e32bf025d39df2022012e031df19e4042179c2f6052fa7af683e55380c63b8f1219a016acfd88abe4501ffffffffa4c8832c2d57a1d6c74ab8c6aea45d086b5b06fda7c15dd5af82f502081447c20000000049483045022100b7049d91421d2a76ccfc23ccd42bbaf54c3622d39c642e2ae0bcefcffbc6629402207cd579148e3e55c762df02c5d1cfb592ddcded3679addaae0f8336c1da37a9bc01ffffffffa55c17d4a4cfb3644dcc5a52215c499a6655387e9055ec2c89dd3325e52d9f970000000049483045022000dd4eca7932f14767d274b9c8e804d6dd43d334f798bc815bfb53e9160fdd91022100920e659485fee8e00475cb7c2fd34c8ed88b77642b332a2066c70042b097af0001ffffffffa60558de647fbd366a2eb7fc94b518386989c5358892e264eec58e84f816acfa000000004948304502201fab741e5a2869c3bc3a69944c0a7436374a92c50a9bad5a9abf27c19148e27f022100b7b29320c95324852220bed36289b6fd092bb5dd8991682dc4d703e0c051512701ffffffffa69d4b6feed8addd845160d6a68a4e34d500769c6bdfda8f042377c56acc4004000000004847304402204decfd453062a26f4b74b80651fe0009dc723b93b02ee889e46784c8ad18225202205eb7af6851c44938327aa8c2868094fc1f7bca5d23778abd96906c1679d4955001ffffffffa6e129d4f3a904d4e06af9e147413fc8a01694eb1ab03a66c4405c98ceabd680000000004948304502201c7fc7ec32b39baf1d0317bd6b655729ac5127bb440456a273f317bd1d7b61b7022100806373cc455522e9ce69258fefe252ad81022f1af23134c5696be6e3f709602901ffffffffa7fb9083503a6706fbe3fad3f898ce674ac6f4573d308c58bd039277136ccb40000000004a493046022100f34ec6e0cb32bdf897b81b35403c9e840b849183f2be86a5cfb150ee59faf904022100ce832fd66f612592fb0c60e1f916b7b684831a340ce29dc6f6090455b65a2c5201ffffffffa82525efb373ea30637c925b952c1791185d99dd20a9ef2a819bcd3b7b6a4763000000004847304402205f28dd3a1b276228c8e2f402f2b714497f9d586ab3901b4108254f5abc1f2ba0022051fd11a027be386f55bb1285baae7e7997da083aecfa5d1737c761ae0e628a2401ffffffffaa6d55dd695bf8c8ae6315248b95efd4ab7040e52c5b47b5b50eee324e4fc72300000000494830450220523b69ae09a9e712b415c09837ad22ae3cd72fa7ef1502c9ffeafd43484c3cfe022100ec58cdfac6d040a1ad8dba16452482c96cbfa9873bf16dd217212d9df1ac267901ffffffffab244efdfa12495ff190046d4bd337d2c4486dccba7b2cd517585b980184a664000000004a493046022100b9d71d6b6b8d13405926e890e6eb584429f52338559d8d2735752d4cf221da160221009aeda32e93ce4ade7486beb042deb734e39cd3dd2db6a90c6e7570e4873ccc3001ffffffffab6e583b298e91cf4cb2293caeff4fe33170d206552ea624d6f89a5bad6fa50900000000494830450221009cdf1349bbf27e65fa93e7986762847e4db758672dd3f2b061a7cd25b876767f02202e778d3b8cd4ee947e31540938b7aff9afcff9f82ddfca94292f6b9321730c4e01ffffffffad555b62b84ca4166839a3a14c1513e59b32daeb92bf3cf3bdf0886c2b019fe20000000049483045022100b3ebb511568f22b30d66e31ced3b2318dede54b53b395e2a2129d66a866755ac0220372e013cc167c97d8fbf40deadae1ca51c486dfbfff041e029109e7828098fc101ffffffffae5b74e0b8078f409f58965add850fa965ab36c3257e8120cb5cca21274b641f000000004948304502204c9f4dfc5fbd324bd4c68deaf17acf043f620435d61057e76fb1c3defbcb0f9c022100f09865e12b52a8358d66f97c95b1153ca1d030944c5327121f89ee1aebaa4a4701ffffffffaf2d2438a014e300a03eed87354ceae65b9065f3d20d75487c39d3294fbd6e70000000004948304502206cfe4f62801a1c4984362f8b734d79cafe6d7838d5610eaa87760bf9033cf3d1022100e9988769fba9a3aa668d4e4466a8f54416c177af8eae916b25809ede6215132201ffffffffb15da3f35642a8960519af325f4aca95f917ba4148d5215e88d7fd87b0a378de000000004948304502200ab1d2c7779c91f05d44911b68cb7a61f1b911ca3cc286898ab8bdc69a6589e3022100c20344a311b2102385e0cb97d1f0ab6406175250d720b8f56f5b444a372d4b2201ffffffffb2ae8d9508aa71d6f156a29362be64886a412dd7211122b4b34d8b535ee97f360000000049483045022100844e6d71bfeab6c127e09f1059a56cc1d506457867ae0fb47fc8a747ae53f7250220125d285dd10c93bdc63f45788b4c3cf04dfde76860e767607812be49dcfca2ae01ffffffffb2be8882eb9f573b4be72af8ebc406d2bfe8c19074f2abd1a538d1c448630ac30000000049483045022100adb10a2ad2cc1865fdd28dbd18cca1cfb55cb81a4b559aec7f86e66606b3402c022054edc3af229fbbbf13017947db4334b4a8d13e8197b645f3a4a36a5e290c369a01ffffffffb32ad76c87f787a72f1b6c100c61520ad3d464d7704d74b94b4a607d61cde00b000000004a493046022100f76a812aa885e1a1fd30d22b1d2fad395c95f36df93fcba8ed8162b95bf4424f02210092c89c1b0caa16634f3679fe0fd447b2ef2f88b484f248b83f152d91b0fdf38e01ffffffffb35880c41d1588de7dda1fa0155400dab4f7d52b441d8d61a3d0814bf0fc894400000000494830450221009c832e6ed41a157c9a7b366da620c12fcc8980657081692866840762d909f60802204d442e796febc90813bd1fe1862d9bc59644636ce99e2e35344d82b954304c2001ffffffffb429b8010ee14c02178c663020ca508b2853aa609fb0d1a43bee00e8c9e4f97e0000000049483045022100b7ef4274c675f4b1d2c4df19dce5213cc33f06716d7bdbf2204ce828cc1403f20220362b9128767072497a8ccb5af3e299efb2ec22099cf911e3f5f963f4b0e19c1d01ffffffffb523c35b26dedc2a648ba6a5c56741efa91e055523a1e887b6dae4228321191d000000004a493046022100cf6c0a02f676d451a80a202db940c338a7f25e077c84d7886a443e2f75decd17022100e521231aa8927b27c55b521adb420ebb4f668706807cc0b670ace97cb2b2d58d01ffffffffb5773f119e260bf7725c1406ae783d444ea5dd258d820ff0bbf5850eabbb0c1b000000004a493046022100839108ce420eaaca360aa61a5adc81bebd346b2f2d0ab7cfcb27a2fcc8b78ab8022100c04c695bf60257e87bb67d17c18292e0cb3f363df993648d277820818711589b01ffffffffb6b29d525d7da4b5665e0da39ff47d0e28a7b3f9f977caa77f6bd329c2e268a1000000004a493046022100dd0c91383b89f1daefc458f6b55b4e8a06487b964fe39bd30d93dcb55914141c0221009edcecf9904c9409ad5b76a1cb8fc32ade24e4b3f2bce780c4afdac974da9db201ffffffffb874f60a81e55e595851e6f69caeb163f38172509c0d5f506a6a54e6dfd720ca000000004a493046022100e63904b7fef3b59f65d15075707d7eb57d35d1439dbecc52e2705edf83312c1e022100ba3c1edf7cb6b58ebb2ee87558181f488bdb37fee65d05d2b34226cca0a5722801ffffffffb95a0224f8ce137307e39ae30e9f198a02111a031f4c22921057265ba775c561000000004847304402203f96c64baa7bbe96e9689e17215718c0a6dbb796bc2db75005340bd736aa73aa02202646b0f468dc0f73e123072ebc5ae8ab40d5a1f915b413f6711ea6b698b8491e01ffffffffbcab2d43aee2ea434a2a2fb92880754c2e2f909e99866a92cafe9805fea2451700000000494830450221009377409b3e34a163dea103035ec8b9ba8eb5fcafc6605bf9476b455cfc51f87d022070d6cdd3a2261bfcb8d29fb77b002c08abebafebbb82ef80893215c34c16b3a201ffffffffbcb53451311ae5ed88aea07b75f165952632c40091c82e8786d0afb05cd50fcc000000004948304502210096d3c3ef54b9377e3e05ab2cfbbf7efd8bcf4268c2398b2a8b89affb6f49c0a4022056f3fd09a9d2d55d29ddb12063973a0536b846c597bf48f538f8ed085f99f2b001ffffffffbe1083bce551a5de4bae8a6bb097b3fe5288a9be7dcc63d948191c857abcf821000000004948304502205ee86cd90f5daa6e6a5a242670434321f8eee2b2a4aeb09d2a58a836387f39a8022100c71cf637e0906905404afe1cded10a85e21bea3f72329bf6f3fb89778dbc395801ffffffffbeb25c09b197c09ea59c128e1ba55096addb456360a31189dca0cebf3baa6a82000000004847304402207c19b5f7dc2f102a500423ab3981b2a2e5033510e2d19a7811f57cd93796c0e7022044ed9e205956c64db77bb44adf7ed1267bcec8020fcdc729bb43b21025cd2f0301ffffffffc0a2c64bbb1774a43b3d939e822412e9187c4f46df12a6d8928eb9f98a5fc9580000000049483045022017992328712f92e198a57e0f258ad66a2faeb47e4f6a63316ae960e5068b6972022100e1bc9eefa5716f36fe7bc2a91d4a1c0ad398182ff42a709d60c7d050b668e6f401ffffffffc0aca6b9988389a90484781717144018682a1a5bf65df6d43d3470cb291a8bdc000000004a493046022100867a4088ac3e9bd72680d2a82b82177dfc5e450916d788579bf833581f6ac27b02210082f345e26090d69fab1983fd1ec6a7d9afb9d282a3ce2a011b7e18993b251e1001ffffffffc10473e4b281109e0dac6f6c69fd5d5e844fa277dc6af8bd63c16b8026857485000000004948304502202f26bfc3b8f435e4cb813045c9ded4f424308cc70929d38fd534df1938971eec02210098eac1843435c33bdd128190a6474224d7c99af8059f9d158452963cdbbb7d7601ffffffffc120b83f810b5385a6f647f4095845304a89593395117ca59d691ddcd10f65d30000000048473044022070438255035b5fd8f4c9dac51d7d24b09c4542fa2a094f83bdf93121b549b5830220142915a779b7da1bea05f539df78bc214363ce2524baedf9daa93531686780f301ffffffffc1f556b23ea554c701dbee93e755a11cdbedb33cccae4de8cfa47f6b18cc6639000000004a493046022100c3502aa728c911004351b601af479f2acf6e7b8838fcce7be69a69f23df2b6ad0221009ab9351fd1bdd6b826a2097f116de5634963c3f3a01bccfdab26c36af82bad1d01ffffffffc2054c8ca14725cd4358dfa642f9eff2de8edc155b53d83a50fe432ce69376d9000000004a493046022100b1fd6da5f30fb8af6682afad3e30cd54d9c3859c464961ef8cc82cc40ce60f52022100f9171bca6d11edd315e8ec1fe94cdc705617853e61c43a90c57d1aaa6dad68d801ffffffffc2a26015b177f47db9170520c80d587269fb91b28064ea3ccb848a0b76226a92000000004948304502207f5fea416f49604d3bd6b9d80969a3d5057c2d3c191f29f98ecc73e49a3296c7022100fe715be32e441f22616d80ab7e12890ac0cd466a1258b67275e2d5d93474da4701ffffffffc4d346fd609d5b63343bb2c60aa3d79f8234cbc0ec219e5898b11b9580f5a40400000000494830450220734044b13e5398132e828f7fa291a1c5d01578f3e9e70d9c18b866651d1dfe7f022100e49050c1f6768521c07928c05519eb463e4566422f8a7fb7835a89e0d372e55b01ffffffffc54047aef47e0975d89756fd3152dd15561aaf9827a8a76bb3a549e00293cbac000000004a493046022100c311eb814dbd3cb426810dcf6609c6fd83dae065375a6e9b3a2ee424fd1c298802210098f52978d37140097f7488371b1806f847291b0727f37711b94370f0b4f00f6601ffffffffc54de5af22a70b755ce3966fc836040132d0ba645b39d5f1ef500b5e7e5cb6640000000049483045022100beb301008acd5caa5ed5e1ca58aa735da6d3f0b08a440eafa524e9d8568cc26802203a73eac1b32e8b2696d6ef0943246599e93fb01b24a4bb0638c11f456bed257e01ffffffffc579cdbe9ecb7a66725470d2029cd427b684c3571d2f1d054be4518e34a1a4780000000049483045022067bf7b160e28894e36b83ac3a70405606bb3e8d9cd1b792b41e909d8deb62a6a0221009ca94a2596fa2ae846dea6dc5cc6ffa980312cf98a0fc7c8952c6f7d9843e25c01ffffffffc6b38487d389f39aae0e25944fc4ae4f2233c068f4b3570f92403c85517f7b8e0000000049483045022100f13354ee1f0c0e1d63b1f981368a809ea577bebf0bcbe160bd2dbfb96085e6c30220519a3b821d939539d842c1e2cc3f80db4b8e23c7ae3bb1acd19d326953402c8701ffffffffc87cb93e2b749195c93f84a2092dc1bb78c08405bf99a4cce43e957a401278a10000000049483045022100df3d38dbae8b48a492cf430cebdd9653194b285341524131a645dcd7a57c50020220011ff94d78de9cfb5307889b2b9effbb548a3f75a8180fb81fe4a4feee1df91901ffffffffc89e2b7ba74fbc4d796c61c823267427be303890740a3b1d1a4aab2913ed72f30000000048473044022062797bccdfae280f5b25aff43edabc1177fdd4b164651109f96538f8c458e82e0220760252ab883c6d72548a303e8318c01d576d988384f08b24ad0444d40339af2501ffffffffc8f08d8541f0f9effb3ebe18b338b65fdd24a7b214e675abf81b4b84210bb8b50000000049483045022100fbc47aaebf76209b77739e2dfe37fdf2206dc17e78c8c5746ed9a811948979bd0220674932403bfd4df0b4fe1bd5a8575208a236853640fc55845579fa5a5c9be14701ffffffffc90e9ab4da72cda921753351df15181d57f1d0ac388432e8663f78b72fa0ec4700000000494830450220131115ae876c2405d0d43fcc43fcee92589e9eb0940d5f5b3b5302a9255dd60302210089718ff9259393e476a61529a1dc109006f2922ecb35ef8a82e053872ee74bc001ffffffffc9188bd5ceb85e378ab05abbd1f3d6bbb7ef24b0390c79708b6a6b783827c3a0000000004948304502204f97b055503ec315547f3846aeb9a71f31938e6b3fd6dd5e51702b3138ff360f022100cfc1c1a37aac59e24d5e877ac3560ae95fd2d84788336bd0f93da98c26e6e07701ffffffffc9d8a05d6e19993b497b98a5b0f82f5a019f8aa3a4dad98c0326e74b3bb29240000000004948304502210090c69220551d0b6bb16c9d7de39658e8306bfd8dc21a57e8dfd98ddc68827cc5022050791b877a966b0385c0c82219116c90df9e3a316c9d8e537b5a72feb8f4c07901ffffffffca398fb33b7c31563b75130e764f76e8aca8b51bf061e876cdc0409007f5af76000000004948304502206f8641a9c9fdb8434452ba4c97418b04d49f6ec426192e7de50b664409955f1c022100e4eff326d55e6da41f9e19cafbab1b0d39fcc14a0118392c272e8141c09f62c501ffffffffcaa40698b0a1f5b2e60537f284f8cb82b0d6a2b71910249c85de7458b02cfea6000000004847304402206d065823f5cea67752a64202cea5032f7d84fc0566ea6ff02b3bee83282acf010220150f74a438ef9b39ffecb6f1c129c72f70ddf3c75cbd58d5f247b50b90e802f801ffffffffcb2453c33f5b81fe879a2172386c20ceccb152ed6ceba12cc54747960c6615f5000000004847304402207a21929ec25a82a1322b24c670c350560a8dd6e1ade255a94067461428e8d8ba02207a0e2d06015feb5143d69f62ec6c3d63ed8d3f023fc8170a891447259b0be25601ffffffffcb94dd2955d3a7fc3db8ad8c6dde3fc15cf26ac2125e1bb4f96410d904678e080000000048473044022025dcafd6c301149ba0a3f835485e66a9ccb805a5cb075a0e5b724a97a7bd5593022052d50dd971e6e81382b9d7bceb4a5e52af0803845d09ae093bc7daeb291438b601ffffffffcbd7a1dfb90bf54ca5067d49e78771730a593ed0cc72b937d4692001969f8545000000004a493046022100a3cec3a586c391154a5b4c25e5177dd0cf869bfa8206b0334dcb817c147fc13b022100c3dc9e3ca2cbfe47cf11b53b62ca5ba83b03077a2ef3ca96e90a0f3907ec3d2701ffffffffcd21a7330a1c73ebffed5aba8378d0b665180f855f75e81f3f034e3c04493ae10000000048473044022037f29b88a49d8c81d15b7ba40961457bdc55063441ae3a5cdc16f1192c46ed13022009ef59084b7cadddd8a626e9878fe0e8e52dae27d448839dfaa81e0287d0f28701ffffffffcd27333305a9d4c48f6c6cafc21d581b709ba02bf50aa57f53bb72135c6b5ae4000000004948304502206d01833c7e13e7a8b588a9bbef3c0d3ef1c4594289600e18d72e04ae3dcce097022100d2f7c2d3a1cb1aa77e0e2bf541ad2864b15e9562253216a1d84bc3e6ac7f2ab101ffffffffcf047916be763e44757e1698ddd8ed01751b3d01c2f32482c74b03d0a0f6733700000000494830450220587a76224d92b867dfa3b09e7e1684e2817487fa02f6afa6f07954cd4a45446c022100a976d4800f8b384bc582178d2c4e6335cec20a9913e28c930049d94ecceea0ce01ffffffffcfbe4d77dbe8026e7fe7f75111beaacd6a164366af56e55defbb0dc60605a469000000004847304402202cd865ed350e3fa37106e3aa882d3eee840e06941edeb04ea70d2e8c033499d502205d240ac562dafd95244806176bd76f1198305ab6454fc5cac33b472cd384ee5901ffffffffd20744dba647cb4455aecc929a6d0ad974e5118a7dd7cfe9e7ea99470f64311c0000000048473044022061206e5316aec83fe5fe22980cc207e12bf4cfe87a4491728ba99bee730b71f30220421a01c13b64caa3972b89feb0a8002cbef22ef45b53e89cf789e9bf1c0f711a01ffffffffd3a4c099f4c4d77bd6b4267083ccb25ad9356842dc33659d5d69278986fa1615000000004a493046022100b4d50eb00d9be6b537885ea232f1607ff74a3b2bf35934e900ec2a75830c50ea02210084ab4786ebe700c011da961ff14f6028f92a880307f96a0e096baaf1a8a3031a01ffffffffd5506e3f3fbc19ee3d59008e1424aa46c378d92e1b40c60620031cdb25afef95000000004a4930460221008726690d90c503ff03a3676517d433d227f742e5b447be50030c2d6137f5793202210084424955b6a995ad4cb9f0e1e19000c4c876ea21b34c03546383bef5cd2327be01ffffffffd6415dc52a6502206b89e6538b06cae426183ada61eb126f81b92b09f1ab45c3000000004a493046022100de71e86e3b89f74da1e21081b4c8b5123eb2f0c93d94e1dc9487f71cd6b494a3022100ce84259ec141b3c99a9953462e80964b9bc768cdf7d9f59e64f29006e13e8b7301ffffffffd6a77ef419742bfc32611af36b2aafcf1b5223b6c1a6952301091bd58c369298000000004948304502207d8bdc390235f265975cf9e388a41f44b83b641bc7e7a7b63982bc6a3956d38f022100a69086673ad4438971675f2f76c2ba4abb759561a7831e1d44d947fb7f9103ed01ffffffffd7862154ed176da1f047b0aa65ac8af5a455de3fb78ddac57b56534abf076dfc0000000049483045022052e3ebb28efddcb36bb5cd27d9bd60b4639b77f558c8215ea6c796b086bb719f022100e960e37184c4b831e93658cdcb24c1a46f52771be36589ac7de7f4985109e10801ffffffffd7d7f5bb6c110fd6a39d74c7a9a6fda290f3adca18f047c8530804109b1216180000000049483045022100bea8414ac283eed1678c02ae79eb3dffb4cef07b9b2f59933904dd8489ff956602203614e320568799ec6800e6f3e7951d786cbb5e579660bf9c9f6917342e7e0df801ffffffffd7de1ce40721d24b41dac6c807efefbb5cdf78cba3ffb5da7d8d42cfb99dc8710000000048473044022025bb923b9fa5e62108c77f8da1041b53795dd112b2e25470b4536c6760c675db02201238b4065c99b0760df1e8f63478a4a7e78a4f00749f2d5331c40bae60f5a15901ffffffffd8874768ee24479dc3370ab5161e8e4c45be2b1f2d7318b55d013cc63ce51dc6000000004a493046022100cf8b8868d755e7647315656d794cc55e0af140944be97e8c92013c3ee7216c9f022100c5d786e4d1320286b28efc934218c1e6e91bb8c627260a14a6457403cd68ec8c01ffffffffda94cc013b3fb32d8f92cd2c6cc4c8e8bda7ab23880674679ea6cfe8fb4f2c08000000004948304502200c577850802ee7092d6532bee09309c5ef8e7571ad4e1e396f7bac8502a2b5590221009158cb07a12c4495d2f3bd285ab930da15e1be614b32859d77a65d4c50b5f42c01ffffffffdb8204ba27b406943e4769468626b484d46c3b78fa634d68182f4d4b1ca5f4fe0000000048473044022023366aff261f4a597f49a4477a48ece5a20fe401ff97d024e373bdf0def8ac3202206715d81d576e1e91fcf67d16e8ed9b066cc1e3ce18aaf1ed967da079f2441a2101ffffffffdc45243e99ff3bc4e74b6662765f958181e1705efd034d7e0f51d925e4a48dfe000000004a493046022100be093ccd329b3e3c608b2581018a63bee4ff535be1d79cc1cb8b272576d8b91f0221009c0ca1360f850c29b8d127f9ff0686e2015512a89925197be02313f36c43a27401ffffffffdd789ef20fb5473a5a16ac71aee6d72304173f22d2dcf8d13a3781371394d65a0000000049483045022100f69c06cf1e633aac57768151cf1d0accb1fea0cc1909d5eaee8454106bea2c1502202f98bdf5d5cd35104386707166ccdb1586d930c329d45e36b753fe12e780701701ffffffffdeb610058ecb3c678ed4927841ae4c27ca2b1d18739f50475d5f640f885302f10000000049483045022059c4b493df870cbcf5177186b7550a4bb1376fa11677cf98698e53dc6f8f7ac3022100a43b374f73651cc859c3e8eacbaf521e0722741f25d56173fbe162f6e908d17c01ffffffffdee0791d1863bc9cecac3e05945fe486909d6d4420aa08e3aace1cfdce33b8010000000049483045022100ac1800f3235b2d9f1a9c308c4d923c67ec5313106ff7130df8594e7d80e1f98002206ce11da169467f8ccebc3f3c2815be0d537739e24bbf11d5a6934f741333adf301ffffffffdf06d460f788d66febaea9dee6dc67982989b04373254a3c8483848eb571111000000000484730440220115bf33f9da99eae576e34ef77f0595a37e56c668c56f2ba9191791d0a112119022031fca5966f1813ccb142cd7302c223502e54a0fa8fdb46888349c18260930ad601ffffffffdfeb1dace6d8db66a29a6dee746f063243eb02f036b8b3fbb07488d6464407d3000000004a49304602210092a8bc37cda054aa03b9c93dde2e89066b11968feef7b18061dfb51963420059022100bbe4db446fd725dd3eaf22236745f72497d074ce261606b1e831d8a263e7146501ffffffffe001a25edf3883e1c7d678707255f4d6a9a0b41b6b2e2024ec92e4f5de1af6790000000048473044022017d88daeac17f8e959290a84feb9b067dcbaeb5f900fb26e64630c853604ede602206741dc8db28f12c07eb111f3f4c53002b51ece0ac21f2a583868da1894b0e1aa01ffffffffe07b38c227c5a040415857bb980551f549fdbeb04ae2846ab1bfdbbbdfe1f189000000004948304502207a68d9aca24b8282c6b0bb9aaf8c717b7ae830f5a5633154e51f4769af82afd30221009d02cce6ef1aafd0859c05869aaf6e4cbc666fc7e08a76633a7a12f2524b782601ffffffffe15c872bd7a72694376e9586d47ecdbe71b82c58f34415a0db6ac933ad6e3d64000000004948304502207c032a4ec5c114d5c97f677b01d1f6926bdcdcee64446c878f5a3de7b20d6bdf022100fb07d3238fc4ab5bf88f8f808e41dc524e89fcb5a8663551b7988637d0ad18be01ffffffffe2a5c09888b03ffd1de410d90ce9e31eda61d7221c1aace4a00d7df6511075a3000000004948304502200982987de9b57d17466abfc7469a4ff3a84db64b80c25716a70e89e79a10e87c02210099cd5d54f7d518b75067fa91a7c9dceb1208e32d0d1477a65b4f6ad5a19057d501ffffffffe34e0758b593e1e80e760712737d28069c2116db900059122fb8254fb00c220e000000004948304502204fc148bddfd6296355c87d7b7628cfb9ce1e2ea053caae1f327a606c541a80f5022100ce2e1e411c732af61f862c275e250abd6f979ecb37c60e83bec4459f994f2ce901ffffffffe3acf941f65ec07efa641e840d70734e1ee77d1f89ce26dfd2499eb24a6aa24b00000000494830450221009d210b706f301fad511c9463c85963e2f0de8036766d1e66f40d82beb6e4507e02203655950cc8dc8e3c4ef19070c6551925e94ddb7acc4e5fe15e6fbe4e3d48b08401ffffffffe4784746ae0402d8843f563183948e393d0e5bedc423ff5982e0d200d30c015d000000004948304502200664dd10cab39a4032a877aefe6036f214ba85be56d35c5ffffb7a66aef5221f022100969c0f3b10288bce24c06d062fc452b4d9268341c6629d5d1a321fb3a9290c0701ffffffffe4a31a268c9e9e3a3f78925c4bbe08b402d76e8c187677ad3abe1350dd9e4031000000004847304402206ce89a2872f3196f01dbad5b46a5b8efd06754417280c54faaef88ac4e01ed7302202063ea42683f43c594cf7c5b1a0172774688f7ef031d503604279d69036355e001ffffffffe50fd6bc7d99c90885618af250eaf9143abcd7c6e509f0d5c3aa203eb1d56d5a000000004a49304602210092d90eef216b47c2d4304ee8a2dc03508e3748821af492eeec22547f0c3c6175022100a9ff22dd8e51a17737f630eebbff3260caa431af2fd706f83130ca5626685e6e01ffffffffe5b872174de2a4de663789db966e437b63dbc30c891b0c66b55ee803df21c175000000004a493046022100e87dbbd429bd0e7de445a98ac1989e0170891dc49ea38d26277b667a625eda17022100a2e24530f2891c8b58b213858e775b40f531f21fc50f0e32778c23acd5b4eb4d01ffffffffe97a3ba15620d0f1896259d1b97feb932223319b864daf70dafd589a10514c6e000000004847304402206a572899e7728bc0b4bd6f5b88e0d230ea9e7c20c2ca94ba6db861e344d7f97102205217ef8d1bd6eda2cafa814da047df9c4e42f608ea20b5f055d8e9c6eb8ceb6f01ffffffffea2c4df0ebea9e290ea02e77fc07fd7428b0eff86208c57a503145cff82354f8000000004a493046022100a90663070574d05261d7988e0ce33884a37431c35c3179b1d451f69706cbd91a022100b00bcf54bbf0646f4bb354edb0c94fd3b6573646d8e9f35e7b7e6536f086467501ffffffffea55a92b2d28659f6fed455b39eb6504f9852db89531f093e213ec1508bc48a100000000494830450220723bc85ce7b9fb227ab8e924f25dfad95c08957ba0ab154caaf785a15dddc1d6022100a5bc2ddd3f473040a905d8e888bf320957b50d61c7f8a28bb3c2985a30d0a91701ffffffffebadbbcdfa2c906b7289b007b9be950d1e17aac19edf4a9109e4ffc6d937a706000000004847304402205fdfe39db41dc05f668917a53c22f7f6504e38809581e215b7f5fb8049213b330220742e68ee09a0f3d5a09f42cb42121e8c7e6a19b3b6238cbe1e408225d0682c1701ffffffffeeb7fefa1153f7faeec6a2ddb0ecc243384e6847dde6666ef1c1a9bfb20cf0b8000000004847304402201f3019492d5e7e7748e9f4ea3178a402120d414ef50417f14a07415d9fa6f9560220571364d7e9a1a22394ce5dddc42f27dfdb74cbc3dda37af7d5f406c8ab95d4ab01ffffffffeef44e21ef2c617d58662f6a5eb2b1cefaf6fc1a8207746fa89f300c7b360f86000000004a493046022100b7b982cf8ce6eb92fd8fa6d1aad47e5042d0c8ad2367195fb3a9cfe4a7ec82ec022100977df3a879ee7fc21e9c686d61ece2dec485db384fb057dfddf778c5d838265601fffffffff0448878967c7d1bee2aa4562ea31e6d8ee152972cc85fc7e1dae2bec510e9b8000000004a493046022100cefdd4ef4801739b645494f9e34ffb870cbc970f38d96dc9097717b6dfc3c2a8022100d1c72bf682c31db1805a31da22787cb90716d95a376d85bf8dc5f90829cdde6501fffffffff194fd5c9c5e50a8d749513797fb4af968f369f741b812721544ad2616670c6a00000000494830450221009f5c6097b436e2a2e52b7ace9a18489043d05252a5df4512154745081076aaa902203e28d79bee167107645e81b90682142592648490c46b573353897c8f9e3fa63101fffffffff3f2637f2952cda90927b9217d466c308dcbc16d233ac0afae1ba99ecf1a4ad500000000494830450220509c36dff45d1d00595c9370cf58c1895a0bada228954808f49cfa9ff38126b0022100c6a5645eca80b844cdd4a3525a659a152d1c84bef04c77f936e39e6acb51012901fffffffff68eb8ec8313e0ca19b50612b879c888c4700f1a39bafd43130dbe1e84addd070000000048473044022014472297c6afe6b589221980532d1a492cc23c321f13dba4db8789397bcc8045022042de0dc5bb163d1cfdb8cffe36ac8463b5b282f353087c414ca6fd56e1afd23601fffffffff71c6a45bae8f525b5f2be09a322738bc5925a68735455d4c15b1155c22846b8000000004a493046022100a0610b385e477c2a47dab1946e2835f65fd8b6040ce2c5810d0bf26e60bb29c9022100d319918734bc7ea6df5bc66f97bd5bd54a7ee008bc8e44e662ec895b01265d4601fffffffff78edfae9210cb59dc42e232a87970fd0ed5ac225ecb279b38bfc8c21e37908a0000000048473044022034522a174ff36d7e7648330241609fc1bd4bc542ca2419430727f17a26f732de02202330298b80379b49064210c3b463026c5c05d9975f98d541a97da01456aac91a01fffffffff8a0b708cdf688037513dadfd8ca79baa22d5ec43c7551f92a1ba8f1c54dc23d000000004948304502200a932eebbab6b91653a1a831d5af1e2166e7681bf20bf8d9ea383934a3f8a16f0221008275f6cf36a0bc8d6b6ada5abdff57a16e95870ef49552d754fbbf989307ee0601fffffffff9f630220c03b0cc18e8f70361d51c579cd0599ed1c050ae4fe8ef1b9402c35e0000000049483045022100a510474565ef444552c423cd2bc6d635793fc18152b15610b9441e90821fc61c0220639cf3e3605383d92e51d17b881ea0f0a2d1e2507b2df321d1a64598dd922edc01fffffffffa2021437f273dcd49f1fdea594d97e6909136ceb32f9cc0fcba9e248a0bc2ab0000000049483045022100cbee4f9456480a5aa6558a16a62e56e6c5b90157211f03912718385ba849f2f202207e324535a81f57ae64036705730e2e797e036268cefa32954531ef63a2564bd801fffffffffc1a9803438f4c9f5066d16d7079c7d8b8479f10f12ca1173748eda092c2f7d5000000004948304502205ab87edc87df8fbc1ac3215384e5322c7012e78bcaa7bf07b3b268ca046a78000221008ab08bd879eaf941409845cb9795030f32e5afe5fd41b9797f717e3a421f3ddb01fffffffffda6e582dddcfb1ec1d7b4bea9c30fe400f3e072f74272021d8594cba5eb45fb0000000049483045022100fedc2378acbe60d0ed12d16bc7555a716a443362a9cc98d8b8498ddbd11fa35b02205e4415010ad96f31c586b2858f2ba00ee0a1737ef74871b84283f56451699b8a01fffffffffe39ab71e99720230af389dddfeba87b00dda306c7b75aca7aed7a34dd54f87c0000000049483045022100cd09bc648cf425a8f031465fdbf3e2e268d818a7f7ea41faf2eb2a4e66ff7b9d02203c75107dc9a53a919852aec28e5a158ff065ee6cea275e209c4c22613554345a01fffffffffe6ed81fa95f47c43b32c54beb0bf493e08c75325077697e2a5abfddb570d556000000004847304402201b86e70169bfa01d0a2e3ce71b8e3fd498a748a94b6ae363f7e240fa4e71b77002203a545828110a47f52c9ccf1491dc3e462bb62551ecb59bc5cdedb4bcb72f8cdc01ffffffff01c0205677730100001976a914d4af3cdd17410a9968eccaafc88b093110af5d1588ac00000000
 
 //./bitcoin-27.0/bin/bitcoin-cli decoderawblock 01000000602bd8e84e86ee552bd46300cbc31e1961c03fa92d228b996458a77d0000000033a5654757552457777a3576803d111f7be988899ba94a15ecaf40ad1c7d99c5c9b0254bffff001dc7616f060201000000010000000000000000000000000000000000000000000000000000000000000000ffffffff0704ffff001d0104ffffffff0100f2052a010000004341048a443ad3ad7210741a1e3e5a4d035cefff091af16c2a792b99284b9551361490f5b75b96c0841fdf80d182a1e5ee97932e8ec086942a27f8ec83d2c0761f444dac0000000001000000fd400104e376400599976914ec91db8a12ff229a5c2ea2c82f70217f87add4c392e969000000004a493046022100ea875c8316de4083905a233ddc51fffd10b5cd136623dd57b9c7539ca0dc0986022100dbf79278c96454cab2970cb3f971cddb70c4d83d8d74a87b9216cecfe3d1127601ffffffff01b9c131932bcd45f1d8d0748c2794b37fadbc458e3a34504fde719c913f5543000000004a493046022100fd1bb16d96ad1511e303adeab859f6f9e74efe89e5024373283baef34064541f022100d77269f58a7a2bfb3c8713acc790e3469cb71a881cadc2bec69035c1ddd232b101ffffffff02448b236dac81e9c1d627e472d5793f21634e23ccb3dac3400afd783d11b73300000000494830450221009baee2552686ddde42a193d50fdac4595fb848b17653c0c847c5466a8e70827202201fe713d5efdb19b3e7ee3c92ccf074f5a753f7e5932ed4a38c061ef70d1579a701ffffffff02f896ae5d89a375b8c05894e586b8aceb32c28f2e05e11b66fd614d83a308e4000000004a4930460221009fba8b23f06cef8dd1a66e0dc4082e622664e5c2a0c22191bb24f3ac1d07ec13022100e74465ce93dd5862cdcd10f97483e08702f28fd591284a265dc8b18a3613375b01ffffffff005ece9ff547a17b4506f42f702b5203576a82e8b752c7a1fd56a04989edb36e000000004948304502201c08ac8eb3d94afaac1472e94a3dc395ece3d22dab0905caafaf6011bb52e3680221009c262bc843aacfaf2fb2d120ad4cbd3e27d37b81d63224519b110005b585416701ffffffff007f0f87576321c254beef150f6c5b36f2893ddee449241473375648cb735fca000000004a493046022100e4bd3833d9e1ddaa3cc68a520347235a748332d8a2a602065f3ea0d90f4d1870022100deb81d3a29008097b32afb35fdac3cb0f756240601881983a8b97e814b544b7a01ffffffff04011d29fcb755abdbbefe1169a918c923c364073a44bda55d16c7c6e2c4c9230000000049483045022100a0182512bb98c92039de93e4b7ece2a272fdbb782f0dc123f84a5f517aa8f98a02204fbb25d891ef9b346f840f1da179d94a458c04d938151cd72e4cec6818a5e08f01ffffffff047244506481c126a14262a1fbb369388110e5c6645bc160534d81ebbdf6708200000000494830450221008a1a80813826f97ead087257bec7548ad592dd9a4bfa6e043066bde5c399f5d30220633ed38f292b7590417e30ced5cb3b6f914765abca6b4f0c43a4a3c760cc164601ffffffff062ce60c80d2069fd3da3e94c4f69efea82e09eb1757d89aa246e37cf5924e020000000049483045022100a9adf9328c0e18f56e19d2c6ce8e92079562c00f97986b4a88ec5642267b60590220077711b4dc6e80ce3e7a8dc65f54ba818384e440555026370538f14bc0db4b1801ffffffff06b0020145a599c8602047894c66f46deef867a960513ccf0a2f3151ba48bfa5000000004948304502201b6af37d5028d53601647fcf7043993e4382068dac49ebf4c1c9230b75207739022100e306945e757fdc10774e561390c0f836feeaae599e4b745df41224ac49d3958101ffffffff06b6d0c42289ac43080a013316dbb2dbd521ad54bffb502d1bd1a13e9bace5d9000000004a4930460221008e07b69db8b92adc27fa54906d3577af212ec6f78fd37fd67f4c6216ec039e0c022100f149ff4f5edeb73b6bdc00890e32c2684661d5435697b6b787e75717306ed2cc01ffffffff071dd02d458ed7e65fae97688f364bd417509f9a31e0242085289b2bf9e6bd630000000048473044022004122590791c42859e60bb3d9c41dd903a998ac9369dc3ca910041f56535488302202f4df358c332945cd99c765e9fb4f444de4ff4227adb138ab2e74a19ff6acc7e01ffffffff0730a8f758247d867c9f4ab7e25de95adbf8d3a287a6453cd8b585b8916eb2ab000000004a493046022100e09553b4f5e86ba7c19ff574aba5a5db14660ac71ae1ea6043bd3713cae7bb41022100e9e4d7ee3c10184bc52c02f7a9ff758ef7b1bfe88e57d4581d2783fd4ea3f8db01ffffffff077d51da1c3c8cead891a49eb9f96710ec42b8ab3dce157b8713028176f512570000000049483045022100d1ed5b73b7bb58db7b6f747cf3c2a6130c31ecb393158cc64211391c2577caba02205ee7376ff31428f378fd98cf45ee99a3ae35fc81a2aeb5f142164480d0a1f84401ffffffff08b69c6267362d701e71b191d99e5221d10e77556313071c349c49be8747a085000000004a493046022100f186b5166b840ef90624608afc81331f16e09d450576299308c11876a89a2de30221008ade75f76f55d3e40ec95d6d663638f15f51a1294dce20d5a9763454af1ed2e501ffffffff0913ab7df42e6a2117578a73d474c24df1bd1812eb2b171f6ca59ab4d369d0b400000000494830450220733c6c569b9ea7dddb5ed71a6d5f069cbc66589f4ddb2dcda832242db51b0ec5022100bcdd0374045829c8d7f5d2421dabac79a6f4de428ea7fa3216a317d169e9923801ffffffff093e732077a113dbba6a40e405788b1b25577c1e91258d8d0650ba25f0db21ea0000000049483045022100a926013cd40dbac919fd622230dd85d8bc3dd5f104d0f7de5322c8c7b8f2db0c02201743d8a3b7a471ce8243a2393c4f95941b389e4220c9f47d421eb078c850f10701ffffffff09a7d0cfa0a0a0514f68046745d6a435f4fd39fe7af7745964e7d22ca2287cdc0000000049483045022100f580f5325852c3d80d6f78a21664cdbf765fc273ddd72be9335108ca7718b53802205656b109232bc8859a3d8128e31e2ebc85ebfa6224ba3641c45e2aaea91a480a01ffffffff09b251340d278457fd3c9ba3c7007e068f5680066e5d1e091b0bed6a57a4277000000000484730440220629e4e1a26324c6dc5282a9083b5287fb4fa136f35602a40a5c682688e5b7cd7022019b4d90a2939a5e8e9543bd4004657a8066dd0d43db41dd520ee13a1e8198b3901ffffffff0aba9f4046c797f01ff4cc7f7771d869a886806ddd775551c839e104d513832c000000004948304502201445b5c7a6e3aeb13ffae903caa1b2a82d8b5abf416bfa40e813ce2cb60d7818022100c33d7dd462f75efbc1902be243a85b1481b039402c2986f7f557c98251a8029d01ffffffff0b8da084454bbc6af5e551dfe3df157c683f0b1729e3a8aaef08c9a80e4676a200000000494830450220414ded20ba52fa1cd1600aa730ce4e325219bd41f81bdd59b60d6e236ea188c7022100980514a6a4b7019b3a21846de2e096846d79572a81de83b1851f3122677a3c7e01ffffffff0bd7e195d2b73de0cc1decd079aec387c1e8aab64cedd57e661f77fb8a7755c40000000048473044022017acd915ff7c9253778e09cdf1c62b73a5da96744528a17c1bd04c9dbce8873902205558ea3241ed969d6778b41da3bd206b51d1667be736b7e39b369c9ec6265bc501ffffffff0c0b1f7acbe5b0e36d3a5fbda186bc7f7ab2b5c4f7dec080f39be6edebab95dd0000000049483045022100ef9f56f02595505a5487af4107df7ca43482b711f66a367f3dac623fb07682ac02205d1f58c0b413edb57c694c99a529c1e0c221e972b37e51fcc84269bd6e687e8001ffffffff0dde06145d3ead0638c08c2622da19e126b59bfd44feabb2893475cf23d76b35000000004a493046022100f1fa9f47d4afb46d87e683c0b6e4fd189da7cd5710213a03f75e438d6447e8d9022100a59571356e76a431def133d6b04254e2489f4fe1509e4126c63afa69d9e267cd01ffffffff0e078594414952dfac431b22ddf57291fe9cd9ba8dbeddba3e1d30e891b26235000000004847304402201ea5261a27a8b4c99487bf068b945c3e60a6e4db0e4dd41c81cfd3f016a467910220425489d3e03849676c8c6fe8ca13bbb915ac8daf012cedbf55cebf394e054a6101ffffffff0f3179d4b728a849a9660ef3a88882d626f2b0148d594933d29f89c66d1423e60000000049483045022047141f5b241b3dc2cd10e6194cef69fc5a40d78dc66e6bd930debc8fd94411e9022100aad91a42901b92c5c7ad4a856046937629d1981770a6d1fab5eb21335e16fbba01ffffffff0f63e877a14f6126e47ac55707072e10a0f9558ee6d00a68e782a4ae70b8f34e000000004948304502210093df019b7f85a3e3bf5b2ac3c0496ac538ca78b484ee7f8a0e6492416e04dc350220516bf2de7107d45e13eaeb1e087232a275736854a16ee1e524144ed78e6f1f8701ffffffff0ff50d455adb3ca87896bb2430168cef3a43e48bd1b7880e91964493fa4aed1e00000000494830450220284f57f545e4e834610a991a1c02ebf96b825b9dbbb693391eacb89485956c3b022100d92c2570918235c16dc600cf281a7d49b79a1d5d66248bd7da658c334e6ab0e801ffffffff109c57babaf88c4935661ffbf3c984ce555d819b1bb26c553feb7da45cae22b60000000049483045022100cba664e829c7774790113ea9f9881785c6ea51d383600f0003f92ec24ded5d4902200813581234bce4ce303967a4defcd9a393437a6849f40bbe0f4b125d9d9f27ff01ffffffff111f21e83865abb3559bb5ac4155acfa3de139e4ce398891783a571b2f591ec9000000004a493046022100a6b43ccd70571ff70581eb012301cad22bdd666a502539ba965bc38de049bc65022100a3ae44ceee08a6026084b690d81e9589874606e7932f61638c33ec5a7eb1693001ffffffff113176ebdd7a23ea9427d3541259bdafb51cb6c24ac055cdf7baff7c0838ab5a0000000048473044022027b38917a4914276737e95b7707d44d332c011b098ec30bff74cd94c53ccd647022006c39dafda8d1e522a5f78c423ee59363976a3f97e1d2072f783b05321e636a801ffffffff11f56957ade2e74823918fb6afd7599cf1ea2bd37c8ee6dcfd69344ea884721f000000004948304502206bf6c92ba98c4d2965c77ebb2362192038b77edc1c2dbb534b066397d96bcaac022100f45b1578347549e3ac52cfa3d53ca50be3e35ab6fd81b944b6ced8ec8fa66eba01ffffffff13dad48a036898c6c303a8227fdfffadc0bfc1db55b1c46d3b0ab2a94c606137000000004a493046022100e4975d829d79b2b43c299c67ae9c01e348710fbeb8dd6e8773a30b3a443193eb022100bc73571f7420dc2252a2a06021ca67eaf2f7275100a924216c94756da612008f01ffffffff14c2ef236c3be3e87edc6f9349052f521cadfd2baa98d963b99544b3b0d7aa7300000000494830450221009e0e0b1416cf33eaa4661d10bce06036c408637eb17c70d09b1280b4fec6da1c0220653a4d32ea5ac8ce5e03344766981b5cacdd255c8d1b857cbcd05e4abc66c62801ffffffff15cb342ad4882ad2be80d189a38c8745831a604912fc97dade36064f69a0be8d000000004a493046022100e5c5458019a16ee9141348bd269d9f91738161afddcda2d306f735c0a0e3bb70022100dd6e00ac8b57e261f384440ed95f8ba4213b6254cb1856baf2eb40d081dbc6ab01ffffffff170b1c3b99747ca037d5b91f8229b593f5c86a7cc61cf0bd3fab014d71b5087c0000000049483045022100b0fea0e4d80da0bceba7f724c46fe70cfc7cc2999eda7ffcc3d0f711ac7293ff0220518e50b9be06df5dffddf862bd472b2c17b2da31cb9547cdd633a3cc4cd29e6501ffffffff17be32f2aafb8dee87478d9154154596deb8b5105bc7c96117b8f349e9bc0ca0000000004a493046022100b731ecc3852ecb837178a9e0fbb9a3aea51522500388d76bbfa68724484db825022100c3e3f3e73bf30268aab9b84292c1ba30362d5d4de644048cf28932ce7187f8b101ffffffff17cb465d1d2ba2baaca74c86fa0f066a7931c1ee6e5bed193ed3bd24c7cfeba300000000494830450220219c563b6a44c66e953f43f8cab445ce939be7f94da8d07a4d0907d7da71f5e4022100c0f972d2f809a3fb63b04cc237912eeaf2daa857c38d7c0562814e9f4b773cd601ffffffff17d049c882367bda9ea4f94652f285e2955681c26f2112503b587e80c74fdf100000000049483045022100ec8ef5b3cc00e88bfac22bf3c773509d5cb6df7595f465d083cd0870af7fb8e60220266be45e5db7aa84a70c83c6387f4e991f5b912bdf8028eef2f9d17f626e9a1e01ffffffff197f749ab5269d8c64eb38b424ce2786f7eef2c137ba420472149b5fe4f6c81f000000004948304502203d7ef60224d70c0a50c37259e12bab5d7702a996d5b2d380407cbc18a714dfde022100b25062025dd875f5e2154acf8fe29d34d5af2a502db76d9e4a54adeceb79b18a01ffffffff1a5c2c614068d8c401bad0af3c347aa4ef450697ff40576d91e9e3dc897363f1000000004a4930460221009962e3f31b77c106a43498df7b6efd7003f470481ea9ff8449dac01ab71060460221008640a8fea52a7f9637419a4e5a7f88637672d64b8a39f8b8a89014f80179d93001ffffffff1ac02c366c7442615f3ed64e5045187133d62e25f506203c42705cd1751d7f6c00000000484730440220583333394e52e8dcc46b788bc3cfd0687c85f13cc098581e8c4ca0a82c170f2802206197a715c9fa0a927b8db8f83d234547d499b7da16b4eae464dc29a92998eca701ffffffff1b5055ed70bbc3c5012bc0d0fa69442c8f608d2cddd0b0240d1746dfaf46ef7d0000000049483045022100b9511bc6a594aa9d9a3f03cef92fe55131c1a17775756e0d98bc1cb80d0b68300220647e7cca36a6edbc9a5923f3ad5ccd8b0956628e108c9b70d9b2b313a153b4b901ffffffff1c611a4d89478c27f13de60f254408798178bcfe7cad78bf513a5e6b77b20ab0000000004948304502201b5998d8c3256cfbf04458a17d2e54f4e7cba55bd439440620c81630192142c4022100fc90a7457187d9fba5d82b09935503d443cc204132d9e939fcb619950feda40501ffffffff1c76cdfb610f57dae30dce3a1af158ee5d0bb6c1f6e24e782ac3446e21a55cc20000000049483045022058992c7ccbcad3f2a5ce5ca6bccf2d73b0cabb076ae2234ff3bc2173443ecd79022100bfcf316fb93e5e56bd5953f245f1235f03c1f3a153b6750d112d8cc6e3aba3f101ffffffff1cf35ebee2080007afb90e7c49755a470fcf9848a070795233e9d25dd0a9593e000000004a493046022100e22aca94ca143a584b75777d193bf9bbe6ed1b3385a9e215ae4eab80c50d9220022100d5ed13181e54fdb0b02115a8dec0c21017372f852f91e30d077dce0cc1fde48901ffffffff1ddbc54431fef9d92468344f1c577652be343b01b6a48edb669dc4e0f77a605b0000000049483045022100ff7855751a64bd2f774b901d54baef30e45526382ebf1067abcde59a6da8026c02201238a4dbd1bcc4614513a49173ef5ad8495a3255c49f8012ae05b684eedb93aa01ffffffff1e0860e1d9067d3bf9642b1cb65183bb2304ba65f253a6a675673583320f71ef000000004a493046022100a8a7f871ef8946f3c8de60bc4e7a06e1938f04302cea7159c1b22b8f01a52235022100b779229521ac870bd88e9de53b9ccdcab041c018df8de51c7c529cb7f838a00101ffffffff219119c0360f897a7fc0959a244b693710d42a703ca4c76ce4b38814fa3d9d5d00000000494830450220601e32ca40b406721e39e8b03df94eabaa33a5a69c4f68ec9e3f4f864fa88187022100aa29a74918bd43e019760fc8c883b2f18a8bc3173739947b421327c22d9c58dd01ffffffff21de433bc832d5318bb4936b7dc362c6498b5c26bd3652b6b3a309649fe13bc9000000004847304402205afd84d2ab4c7a9f4d31c10592d27224b91bb1b1163de1a0168f05f79c6a44ab022078b7d574eddd8bd88b0338c9253b679e5bb9c16629999f102e5dd6b5a05c545701ffffffff223d99ff54aedf8ed12fff91c9c4e95c73459b6652549826484145107ac01e010000000049483045022100f81075743f88f5a0ce0d18f3855e90f73257f3e28d152683db5641245714d0970220692c015b3f72393cd6feebc139ad063b2dae552f0aeb6de463caefc966ef1a1e01ffffffff2548c53f1c45df9a00772f473e8cb41b88f77e745a92b796e408bcbf1bfa5890000000004a493046022100f86c9292aa078c3ae5668638f84a82db3c45ad55e2ff6794d92a2906230784b5022100f47bcb1277218a93f5e0549a343b44b0a8ea079e331d0a829e101c30d1cf37a601ffffffff2285810fbef4ac137a875166f523f2bd0355b709f02f24c6375e843cf5640ce300000000494830450220250bf9f742521e8559551c7dcc880f8794f6674fcd4b71cbd4298233d1cbf2b2022100e2b70685f235c5bb6e2952014bfa432b09e1efdc38c115864a3ca1e7ca6ec2fb01ffffffff2377b68c40f026f0f8f9454c23d851d0960efbc910bdfebb934fa93b6671b2d9000000004847304402202db9e91c487e8fed3b47df01659c347323270714865f8bb52468f28770cd0e64022037af43fbca4b3bfe09c33664848a59735be51022580827fc8ebf9cdd63fec04701ffffffff23deb325745bce68db681ce40d9ce7438a46ce7f846fbb9bb7467ea9890a800e0000000048473044022011ad94021217ea257b2b49f8a20bc2688011dc9183d8f57b1702526751d2ba6202202fa5b40c5c2c84985c7b0b7fb654008e9aa709631fc7e3cddfd091a7714f743501ffffffff2410f743a82f4fb9afd822d5b183a7683547909bf1f9b3726b910f434b7bc1ed0000000048473044022045fddd6fccabf46fb72359ea8dfee9eeb6b1c22b3dba920c7a63c597e4ad5b9f02205f3c8d6820e3ef2b16207358da740a8ad141896100de1989d13e238b8442368d01ffffffff2459113829f2d932d34c7dc04e28fe02e299684cecbb69a4863ee5214c169267000000004a493046022100d8ce5296fd3727128a2f7d099687682f464edb9ad3d660ece9e2f156fc347c0f022100d56d14cf7058f6d71f0ac25672989cd026cb269597e58038445a78a3b7a9870d01ffffffff25d4d2df1a58202a3736ab9d59732c91f2c58bd5d5de6301b5bf33da874cd964000000004847304402204b43668522291497fd49b2b84d59a69e0b97e7c5406f0f9eb6f14c542b02c29202206b8e140204528dfc1047dc81b1081f0af9deaa8cc6d69cad5fbd6d4d9218aad301ffffffff28299b976379a91217086e07185fc3d8e8b78f01e4eacb8f2967473011b98d3c000000004847304402202a296a750605204b97bc572a38a247ae49c2758574c026cbc9fa46cefcd9fc1a02200dc819c77cdece5c7c5409961675903bd8a71ea7a9427771800d6e5a42d1c90101ffffffff2935fed7815aee4c1027600dd0c3b07361258cb10e84b977cc745dc23292489e0000000049483045022100d1fc7e8c0a1eabd8cc5faa9e04990b5ddbd35e65619318425066f27e180cca4802204a722630b8af36969fe0b95086c6f1060662d2cd09c5cfa6801f7a3f1a7a3c1c01ffffffff2b615d0c359e4dd0fe62be58e0559186ac293630b6d10075ff9bb781428f1aeb0000000049483045022042bf19cf338e744d73b7a232d4ba4bdfba9ef88c6679c0451f8ec14a7bb6b36b022100afa6778b1f79284cb2d6ea6eb3e5856697552b19f9de578509d530f658eaf82601ffffffff2c08c5ce5c19dfe730300f94ce36c1cba9c8b654a53139327ff7fdb6731fe0d200000000484730440220456be726b91cf1076da13baab2fea1b6e1ef6985bac8da5788880643d7a2265b02203fabc2ecd9fbd313cb70f67560ff3c9068573501a0dc30abc1b22d1febf7433c01ffffffff2d51542eb75ab7d5b7b860d04c02defeefcb8416a79712d604e5b8a0951e2aa90000000049483045022035467e2ea7435ec6ce5fb8f765bbba679af3054cb6439cf5ef31994e26135ecd022100afbc66661356272f3b88706eb2ad987c045e0b189b1dde906e24edf8b29c0cd301ffffffff2e58e49a831d30ce6277da8d272aff40e18f2c9adcd1a137f568ddd1bd8ce5b00000000049483045022100e4ff3395dd6cea71329ae4c7cc89dc9a928241cd0d395292b6269be0b802bf3b02204d25bf41b86b257e3b18da2dd99c5a9a0e6fd2776ecc018912a6460fb77ae43801ffffffff2fff50ad3b5122670b158f99225d88d6132f03d55fbd0e85658b9f8ba521e3cb000000004847304402200a446ab0eb3b2b3d545e5b3732d9640590f69f9dbc8263cab41a134f7f8f5d46022006dd68f100226ca6be75d0720d4d42ae2240ce6e0d23ce1b2801990dec99363701ffffffff30ce9323379ecf2b87c20d873b0725cb4e3866ff36469275dfe307f4a96d6237000000004948304502203f22c3422b83c02f69be11dab0df9759a3334f3b076070ccd389a2cd08e3d0320221009e244cb793fdb12ed5a57600bc85be53f0ca5bebb49439cf97f60b920a1c628a01ffffffff3144c3c30d3768a4508ca831d4654d767789bd8bbc00a18ef3d7c664b0f8c420000000004847304402204674b149c85ccf779353264cf786ba17d08e0380158c0c014aee3a1dfc20a0620220310cc53a1efc9884fb025541f59e68a38567b08fdcea507e0c9d4cebc21a3a4f01ffffffff3146fcfc83c88f7a6799ede0b85a045100df39981ccf03999b94e3eb1f3d4022000000004a4930460221008d79900bb7999a70e3ddd14571eb37619f3592d0e27b3774f6eb0fe7c9449d1702210097c35ca2693b5901ef3660efda5982c7e20d7efd0fac4b01038d693c0dc84cb401ffffffff320fcce1d1093bb222baa555210713124a2557ce2c842a94576801a426493ebe000000004847304402204ab92f79305cb078ac6db4df69e303c5fd468479525962a31c6916b1aa4fd25a022072960a2f467f8b64a3ff626d8dd788cbee6262b6693674d10e458712669ff66b01ffffffff3429d949baea8ee99db576a2ced99b2fc36e8e84786e26fbb566cdb12c5c016f00000000494830450221009e7730b0833919fbba6eb2f47f9eaa41295abe93e3e6089ef45729417f96dd89022064128b1a2b99616a2dcd0e349e139b4a62591437667a9e9655d5720f0d7c713b01ffffffff34b94c40d17e7862e9355b31f07b4b141b377d61fe1704b871a5f887b3ad83b300000000484730440220635a4b0881e97b030112b33d35fc9d881ae4d86f1255924e5b82a2e15a4bb20f0220792d907dc1a9ce8a1e737fc4df4814c341082deccd8788a7c39dbc8cec0758bd01ffffffff34d9a1833b63904d3bb83fc76ecc284107f2234b6084a9889f9516c2617444030000000049483045022100a79d181436b47307ffb0cc670cdb52b8e8ae2ac6ae7de9167855610b720997b102206270b50046ece68f548f0452734d3d66f13e62f166389d341e96d565a6befabc01ffffffff35e231ab85c78ce4702dcfb5cf21ef2f6a83205fa28d7759732bd7af641c903c00000000494830450220633081d9e2d9902757f5315cad8888ef3982ae2d454f76f42b9a49dc1633c745022100db2361b8338cd03b94b25439d13c22d0cc31e08a7d1e85c812202a7db2d98f5001ffffffff374f258b62986d40f1596532151567e3084ab391177e7676ab046e6e2b7cd5940000000049483045022100f02ae9dcda24c38efcec18f5fe4a33d47693950474b0e18fb37691a49a4f79c402200badf3a0c869fd0ebda2c0d8992d36274e80745b21ee4c02ee2398c56e30cb9f01ffffffff378d58c1c9c260669fdbd56339cbbb264c050bca1a6c3a082c64cf9b0159a42b0000000048473044022047768545debb93a1832131eb0319d5b93be4c72faf8ff725af27d322eb102ad502205770eb7aa8143fab0689a5e924925c807d361510d44c9b4576868b1580eb5e9501ffffffff3853778f7a2fdc1eb561f63ad47ac315352cf7f1547ed23c6f47c67d8c7e77f20000000049483045022016f9927fff7dc6e411ffc0f606bcc8ed329831c5e3885d019235393e1a2fa32e0221008a8f69a3b16636927cbe371685423dbb032f1379aa13f856a88fe53b9564e15301ffffffff38559bf90fca05559f52051a816b80059a96e86a742226551e058dd7f7f0ae77000000004a4930460221008e928e54ce0e86957b1079ddd0209e9f68703756b699fc8de62eb2f949272ecf022100a6c728c9f758b65a5d102273388bbebb199bccd83205bbae7504f4686f2db8ed01ffffffff386ab7b603b4d7a7ceab0d081e2837e73bc0d2a7305560d4533d49e1b5a4614b00000000494830450221009cb8a7bcf22bea339ffbefe8117a76d053658ab8eed75d036bdbb2ca0e3ee06a022008513174c29f37535ca606b0824f5facd9c8fb8969b166b70d180436ef33f06401ffffffff3883d6e7ccf75ba8c1ac49530c62cc413607ba2de9ee528663801f6d05ea4de20000000049483045022100c29731e670b2900420f1122b7f6685bbb35b954ce60597e9f921ce9f369a189b02205bcd8bf950da8f9e62f2f1cba01fbc2ed07ae7f823bab970e164306c6c10507c01ffffffff3aec17b58f54406e272ce5074a28fe0cb15e7b76317434173b2bc49780bc9a880000000049483045022100d11515b149fd32555895327e53659ceeb84a2f097df8a2dac497bd0e1d46a1cf02206fe658cc9d8c3cc00c4e3f2dd3a93c3210e0bd58934ad4bb8643572a2a5e0edd01ffffffff3b049b59e1ce12b8feb6464b54de64a95499d7d7cc1709a4b8afe5806b4a6595000000004847304402204a306cddbe7f291c280b5bd5c0da9abb33be43c0bd2a41f777f28738a858a46a02205536ecb196ee09afb4d4a86e0d116889975407cafc1bc18180682acb5346532901ffffffff3b05347eddf62933522fbb0ec0b38c855c3bfc4f901338a771b2b50716b526280000000048473044022024757081b5626b87eea8b88dece2d96b81579135b209598424d030edd56e28b0022079057f2e641f91c02c8c858ec2acc8f215488b14f4a4e2dbdee3d8a00172c64501ffffffff3c773d9e8085ba4feec24cde3809f00285fabf33c526575ca3177d3f70674fcd000000004948304502200ad9ba43d2a914f0121950d82743975bb05f3613c23229d4607e02635414ab43022100d61e2031335d67aba044c7dcf7d7755a2d88986d2f2f8e01c851e70870a853e001ffffffff3ca63a49f677d7f631a476f45924f25af9979e5c43841cc73f9d36717fe17925000000004847304402206cc93fccaf6b56dd152e1ce7b22d6ee16a8c21a2a492701c2679f4453de3b1940220148c95066f024c476aa45eeda921d21c3cc8f048a06d0e7ece5c5069e2323e7e01ffffffff3d9f5f7288a55bfc86a57524597fc7b33b38bc0fabd3d76cdf553720efea64e900000000484730440220477432a990fa40064621f2b08713c5b1351fbcbde4d922c8f558894dfb12eb280220169df9bafe8416c5cf9c413a325bdbc82ed3d4153fd7e55cd9f67e0296cf1aa001ffffffff3f5accce0de9a6abc7a2662fc70ef5d02bb11f8221bd24555df9180b216798fc0000000049483045022100efd12021f2cbbf0486024d29bed5d801c6bace15102a5477c149f1508b08c7fa02202e54c56d85f5e681346255c1e287bdc43e12b7fcb3515f09cad84ea3236f1de701ffffffff40b9d059c5ebd578d5a0936678ad1607644210fb1bda944d5cff81984a25741800000000494830450220014d058ae79efa6319f985d3efd0deead3dd50940b05b4fab44a24b531e9dfd8022100a79d6684ce96a96f3ee8c6eb8d30a09a2dbc9b3c1b433bfa907665bf1ea7d98101ffffffff411a0f78818504b195b6b12ac15cc8538dc2f18ebce281dd947c54657262bd72000000004a4930460221009fde5a67bdcffe0395fdb277458a8f457a2880c1a1b7d5e1bf5b28c43092d328022100ec774faab54d33ba5a03849133694ddb8f691af8a92603d1fb019d5a7214828501ffffffff42a212b18ca54d517b1fb6eba0608383f04cd0e8596b2e6896e5ce760b6729ce0000000049483045022014a30edeb7ed1d5a55f71bb3ea2b3edcec6a02495b1f9e2dff3e4ca99ce41bf8022100e3c203cf34dc48160b47948dd60c804ff5ed7ee26f68692c067bd21627fb983001ffffffff42c5afa16a510f47bdb1c6f576c61dd8cad6eb0b655269805c81b3ffb4821034000000004847304402207aad4748723ef72d416b3701782e37b7f1e29a430c8b2293d7b09613c5c4d75302204ff9dc660c5cf145fdb52f96acf4ccea66c2e74fe577d84719f2b538a862361101ffffffff44a715cb6ff301744aede59490953e1624e74a5493666ccd8a6b41a0396e0644000000004a493046022100f1d6cdb564db11450a63df24829360ee40a59b3005c20da0e703104360b892e5022100e4f16987ad4929445cef41a79770b18fe06dd80551c69a20fe6184d2101e8df001ffffffff46d55f813ae2c85d47691ece94f5e06864fffd1c2f6a57920e120f3ec5a7cdee0000000049483045022100f5121f0e7502bef83ad3ca9f35dfeb762eafbc83b0c9c8adb60ba3d54ab7e7db02206e812d0e3f90325366f2cb6b0c730aebf6abd3aef5ed048653745d8121acb4c701ffffffff47ec2df763ad28de0ae20c8069077a50c9c33dd67409def49182104cdce821080000000049483045022100a0d96e4341a64f15dbc218414c3d39ea891b914645e073135cc3025ba5a1ca8502200689d53ff0b577a69fc853799c0058a5f6dc893b03f36914c54d857fc474755d01ffffffff47fdb84e9cf4f404fa1885efe1a46cd6e6b5e4efafbde99facec76dd8f4440a5000000004948304502201c5b0d80e127dfc36dedea06479776126438ceec32b2f91cefbf63a0b72eeb30022100d1edfc187f5f2e2af00319a6b07af54b3d6e2686e9ac6b668af6f684ecad662701ffffffff4810f17f4729463ee1d972e3ed68ce7e75d98339d15b762d8b5d689a0b88619e000000004847304402202ae40e9a327fd7daee1637ff328328b0740b5f66131db3216c2863d24f30dc7c0220226176b5f9ff24bd5150e965734cf8c24265b54c652f3453a509c7d2e4ae60f801ffffffff481e6afa95e2210aa27ae0a03c7d1dc9abfbedd196a91116dacdfd513e8dd3f00000000048473044022049f2121dd7d1b26860534770a39910c2e1e66757d037787ace9ddf3160c4371f02204b29d34121932a3e545dc3a6ab0c5090950099fd2f5ff151de2b6c031915ec9001ffffffff4aa2115365513bea282f46b9cb8c8f4d03ee3818d79a69e19d6f5f4207913dff0000000049483045022100d0d70b563592ebe1ae4cb7975d27ebf30c5cac3a91f1fac0df6533b4095774ef022002128a44a44f6abb7bcf7e76669cf560556a76eb67a3487d1b723c8a6eae7a7901ffffffff4c19f7eac355d44965745d1a4004966eba039789d057c950a503443946bfac7b000000004a493046022100e5f732cdf9626208ad06c9a3b416607fe464f0b08aa2f0daf439ea8da86ba384022100985541defca2912b50d9d15304ded34508d7ca93578b88bcd906075b804f735801ffffffff4d39bacd6e966ec4d9ddf73db6b6cb92ec465631eb46548a6281ab226176b0500000000049483045022100cfb1869cb70d4c0f72e0c20312c7496ea1687acacc3c5cf2289f217c4a6eff230220459e36a42c1cf58a3a17ea850f1add03d0369c020c9d06724291e08b97b3fa1401ffffffff4ee2b7a2c4445abb510f463e176f52dd6be2da69926703634ae33141da438a3b000000004a493046022100c4c34f84608cbd785b7323c07b246deb33950bbd79c59c925139e6d5637129570221009d151bd2bb270bde5dc3fb0d02731d79a01571908eabf5905f73f524337c6b2401ffffffff50905a88355792d02ee9ba8231050351a8516165b1e454c1f03804429ce993a9000000004a493046022100afff3916d15748e13684c9099f552f890e399d355a71290ea5ae1f71d94ccb68022100a8112e198317a61acabb0745b65bc11cf959717dd13c4562aa8555558f0ec55001ffffffff523e410dcf088cfb53612e71066cb7e26433fd9c668ca6aca65288bfcb2e97c1000000004a493046022100af1a7aba19118b9f27a75d29696dad904df90c41a30adf181b22dc2eec0dc75c022100851ba1ae65ab4b325e379a04a38c9bf6021637edcd8bce34630c46bb55e3647c01ffffffff528620de61f0ce1ce5eb01441acc7f7fc04366dd7ea0f0a0753cb6e9beb0fbeb000000004948304502210089c0f44584cf985e313e92d94336e618768555cf99149b0bfdd3e59fe0790f4e022021d3dd03180764c11acdc22cae48dbb9d90a1ed32b5273b901961f7d44d077c001ffffffff5337e957bb0599b78035031827e66ca0e065f961f635a89568410822144ea615000000004a493046022100b94c97fffdfe31e96cb0a60154febbb8f8720e68d2d178d6576e98e28cade804022100802a5843ab618771a24bbdaa68ada2cfa99f9bc7bc144087dabfb83a6c8c99db01ffffffff535900e57226ff5b43a4cb4e2401b4d6955d4b2d04f2aa1691be7e38b0c8e9690000000049483045022100afd1de544d66099b0d2eae1da32a5de4facb1c0f3a5ebcd5a93e5ce81e69694702203f123c795d74b83c19f7a3e1dfc66da52187e81cafc11917547be3ef85de137301ffffffff547db3034a5f0609acfea9d1c6c036c644507a908f41236d306a8c8bc44fbdde000000004847304402202e8827fe100c29a7f739cbdfc1034588da8d3abe3b3532eb59941709ff9242f3022079ce527c711c4634e100108d2274158728b238644d4c519babf6d6400dabe81201ffffffff5567252ffdfb2fda254e2d3d316a430918f2fc27b72f9213bfe48eff11d9c52d000000004a493046022100915d6a54fd76ca40b143db516e1b450e02f72714ac82c4fb2864c213e498dd89022100d1ecc0c6036499974368973fcceefdccffa6aea3f8667ea9dcdcb1ca71a8e22301ffffffff5572481b8f1412edc74862da66d94bcddf0e39976c9249c4921d7e3cb61f306a0000000049483045022061adff8a0d5d2d9689802b8cdfaeb4ee556e0bf97e944a741e118d88e1385e4b0221008d714027cd61a6f690caf331d4e81e3e432757a3c7c3d8e3b85e5b74bfe9ad8001ffffffff5616338ecb798a6a33c6d2ec0aaef0d2ebb0dedbaae8cf6c87e1620b757b38e90000000048473044022001fedad59099d6283bc172022bb71bedf12a4166c39768086e72fa592f89aa530220100b76af8a44f0a74f3c1456680c04ccfa087bde4c5ceb8e090e28887892fbc101ffffffff564c413e7fcb2d77a3b7ac3afc9b0d90239a2b8bc99fb4e2911a33795c8f9ee3000000004847304402207a11d8e7083cb23968512c4a9b0a4ee0d6c2ce8d38a1c1eb22889d240382473702203be810350862172a53cfc8d1abea75efe0d45bb3097ec9cde3f0445b856d37df01ffffffff57c1c9dfff243b86ed8dd2e191ab6e5aed652286a85c4445c218b86de58c66ec000000004a4930460221009028c54940aef8581887d273e2e6730ffb43dad1a4e50bb2fd8d512b7a3b9a7d022100f2aaad54d84f8627e841bdd38ada0ebf0410869e9d489662b8a89530676ad50701ffffffff58fed57d82c05b8e9d488fe9ac428fc07d9deb9f10fb133b4594573a057e0df1000000004a4930460221009dbd6b994f1e81ec6e716ad6ee6a4a66c365b6325dba06cd4bbf04ef68e7f066022100a3767c2096723a0378216299a6a05eb3a18a06750b7775903c9abee07e430c0501ffffffff5ac1c0a0923c76de161d14daf92a8dc598a74c82c4a669f37ef04eb8f6535e790000000049483045022100d94fc11426b2be900c96698c1a3613b8164ac04fd07d1d815b0cb6be5d99c1bc022064318ff8398d840726203dc82c8395cd6f26e26746f6a14d0fb8af8ae273ce8b01ffffffff5b0b19d44a571728441b07a5281b853126a2bf5cd5a67b4a37ce505e37148d0e000000004a493046022100d5251ad08d95ac5c6494b51825d897cfcd0a0aab56f8cabde201f78e5ec4f915022100d4ab5487d0f727e3bf2bdb66f4d91da32579d0718fea073f4cc885e23bf2775601ffffffff5b380a88e1bd5ebc452b8abc09892faad7a2f630c1ce135f586ff7cdc34d34ca0000000049483045022100c927d731c8fea2f1f0c27c9e6be043f0e50220caae2a76555d38a007b76e6096022030e24af3dedf0a73cf76c270740b22e639b03fa9f0abbdf4a589011c0fbccbe401ffffffff5cd51518e1c5762f3957b56a0624b17fe70b72b58d9072bc83b88c5fa295372a00000000494830450221008901b86ffa53effb84b80e15079d6b3fa8e15fd4a01a2dd0ceadb1314c599b3202207f6de9157655c6e26f3f9e418f18fb774790185c4177ed6d1a4df1eb71ec7a0901ffffffff5d6273d795241a501c887a6d482d3226bf11bcbb0c1357fbb366cbc422a06aae0000000049483045022100f84560bcacedce549084fe5f799889d53de0e19ce0c5468217aab8d302055c64022055753def4c6d1da81382f0e6110aacd4acacd1ff49c475f0ebc15457ae6933d701ffffffff5dd5c24174490e2c9a35b6ed1b29906ecf63ebd078f3df24b7106be35cd2a7420000000049483045022017ccb4b9a7fdeb2e73734fb30297eb1016aafdaf1814371ac979eeac4a04711e0221008e1ea23123102350eceac91c6649ba1001ef70b110153ab760cad2331f5f1d2801ffffffff5e54b42a11795cab6873821a10bc0357a45592490935075448403359aa117e5f000000004a493046022100a9becd71c27dfa166936f6a3ecdbdc2be8b75cc5af91e45c925a13d7426697f0022100ca77427f285b4b7ed3cf60d36dec1af3738808c51d7ad764f7fe2f1559b4f44b01ffffffff5e7579f0f08154c23ca07adb4ead638ceac96ab61ce5e6cf3b17953c6ff521020000000049483045022048ca5f0476a91df151cf8b0df67362e17823bb014e3a7252935f77880c9a34ef022100de66e18aacaff39847e83412ffd5888c67c26dd92e47a3bda83d86cd12411b1501ffffffff5eb262213788a1b55ae60a0d097415dfafbbf91ef01c40ba2d34a3b8a5ca2c1a000000004a493046022100b1baa30275d97fe5c25bdc03b1e52453c50d30b42eb82f232679c25056176a3f022100832352932221e0b02dd62890d1bbf80121fe3890b68b991ba91710ce5d5e0f7501ffffffff5f4ce1476a6b51ce9122ba5210df7603fc09d1367e068f3538a35e0f181010da0000000049483045022073b49c5db4ec64659a6d48205e0835f0af8f5894eab828e280a3ad20e59428a50221008d879ffb07f082d0ad60b23bbbcf58b7a809919c70ecfc350868e7299dddd8a201ffffffff6005a76ea6e1e23ba6c13e74225aef7cd0bd57d1c7c43efc8cfef0afefe2bcef000000004847304402203d83dc6b0182a020606aa7dc1f52cafe06e629f0be545929aa4cf2a83f5036ce02205a5f65d79a1cc3023ea72a5f1ec8994370fe947f3d764931beeb457ca8c23cb801ffffffff614bec9bfd12777195e27e77e23d1bdf2d7edcd98b1db281f80f3cde448edb2100000000484730440221008ddedf4f36aeeaa450b366e87cff95cb28e986f2cc111565c7e47afb39205736021f12cae92806b5053c8cadf8bbf2cd15877fd803b9e486faafbc42ed2021066e01ffffffff618b8c527a187a37be956c46dba6b09ed7b4dd2cb6e13c20077d734e6edd9efd000000004a493046022100b616b3c8574a2212227126cc0cedfbda0496732b1427f450516a888c8b501e5b022100f378de0cee8d96036ca8ed5e6ee593e36f62d65f97a55056e7716919f8bc095e01ffffffff61ae0f276f1dce326a3e7e7309d1695eb7071e9f6a2c71b80e38f1c1b524460c00000000494830450221009a8410cc4705c983cdba7e17dbd25586c31d3bbde94f6ff382e26caaed161b3f02207d1b3a8513c85390e285149999bfbdb141a6420575564c9fe9e4dc14dbc1512c01ffffffff61fb0b176b89637eb6189b434618a03ab9bf183a9334357aa570bd7d87673dc3000000004948304502205793a23ba5e8007699aa90f90f48709e01bd189b5dc3574d8341b2c2dcf8822d02210088a060d4f5459f1e05c2dbcf4ced83de4f627915c2b7e89657814c7c834ad98601ffffffff65a194221059cf948f788e75cd886682e655f6e9cff5ed6ef21eb809525ffeef000000004a4930460221008918dacd51a46d9706bd011ef43c91fa24af6e49118ef870cf0d5396205109f1022100a5668de881759331c6e5e9c32b6cecb10fe82e402d461fca7276e7895712aa1c01ffffffff6648c3e5237e45a2325254ab578a7e4bcd44457008ecd29e21d9b463668ee3b5000000004847304402202b3603312e48b461184c3fc66c95ba0246315e3d0cfed87eb9e6d0006c393ad402205c789c54a8393a0887625a6045085f350b42d3ba0ee5d1205057c0e8855da56101ffffffff67e8ae39d9b9f33c1bfd4ebf1affb4855a278c6d18d62551169f5f00a3e8c46d000000004847304402201b3c6fcb64d23b28abc387cdcbc3508d75a2dbab85b0a4d9bc65d6c34df1b48e02204cec16ea26179765b4bd02a8834c25b4c7af4c7a4478576d811f3952b5fdb92501ffffffff68aed869586c38191378c8cde2a1d42c027a6aba7d30c688fa333eb938c94f4700000000484730440220545db6f7ca869152907d081275dd39de85cd6e914f18d64861dd93ab07386a6102206701beaa9cafc94db98f6a183560f17b49b01fa40bcb43ebfa5a8c1715c910d401ffffffff68fab6cf015f2f88ae71fc072d6e590ca10688bc5affd650a3b299b09be00700000000004a493046022100d4279b2df109d59ed6e1685313e25a1c84579c17697798d5ddcd034f4e9f5b680221008f724062508578765262e1787d0f26357c86b35c42369b027d1a5a4e34a9bec201ffffffff69112eb489f47853e7ea3ede673c1e2ddb9cae06a23b0221fb9bf85da1f844330000000048473044022100a112e75d4956a47de08fdc90e1dbb5de5a8bb50bbe545b7be221ebd08b0535d3021f39e11df258ce1d9e975444214ec5f48fa7cc39b3300d472a2602849cb5e85501ffffffff6ac99023675eaf4780574d932785fc88172c410cc3a983875b6c07b8c3739b9a0000000049483045022028ebcc36c6cf8205a2e2866d670ada71251d23aa1170e04b11f2bb808e418d98022100b6b8ad9106e1544cd1d19ae67d031c4e540a55acb812a6b8f53f2342b8bd03ee01ffffffff6af898070730f0a3161c7f275750d7b39da8038fc451fbb7e201ea8146655cd3000000004948304502200635c160a3dfaa9c6703f6bbd573b8d2ae9c8752a869f860377570b5acdad95c022100d0477fb021b25c4579f5c1f8cd4b4c025993a13c16c14b9760f63fec8a46d6c801ffffffff6b0385725103e2bb0009ba5170d14d1a134b719af97eb645769777cc5187755f000000004948304502203c0b9ba10d12bb8ff0e96d1bfca044c8bdfeed611f691420c6b89e684efb6b82022100cb95a2b2598b540266f6dc0a1902680745ce101758b94fa8f5b46a329c4f79c401ffffffff6b6a7a43e51296636b542db5fe39af97b2458309bfabdf3e23a05277d8d8559d000000004948304502202f7a6c44e9ed089f82905882aaaba1d2f5aa3fb8f698ce4406149d76bdf995370221008447743c2d5426cbc03ed2915dd249ac7fe635195f40c41168c59bba8a21d5be01ffffffff6b84677bdd145ae03a07d9bd9bbea574b495c664c84d03f96e0d15d41c248533000000004a493046022100e4ec32a21eab5da3a1dfcba5a9730a129967cf58a32675610dca168539029b2a022100b412aa609e332ff566dc984a7fccbbdd64f4763c6c7188b36cebec2885955f4201ffffffff6bb2eb9d4aac3498936fa9254ab745e05df05e6d643881bcac3739b8b62af68500000000494830450220319d35a07595033c84233289d7df30c1f30eaa207b0d8b7b71962bff3539c0c602210094b84a16caa8d4547ca8392842462de161374ce9bbc31b94a71c3ae20632844e01ffffffff6c1f3e30a820fe590de95bc44807738bb98f27b5cdb20d17937c6c6f07aee32b000000004a493046022100a47f072fad2e424faa13fe1343524ab35cf3c38277c0f68d63b8266a43c7322d022100d0c6afddba08c8824e8f06533d5d64c132bde59a833d9d9224dfad9cf6ae39b001ffffffff6d6bf955f1750a3559677eb866f61621e4f5a64ac02da77274670dfb4f41b6b00000000049483045022100c62d70af9f414b6de5e898f9cf9ca9ecb626c882852a7e931c604baf35ddc9f00220352b008b54289c0463a0d9a35549ac43417f7a4f9974778d0964780c82f6397c01ffffffff6d76c320b36167a3194786e5589afc9a521574bfdc12857b31a988f6e74bdc76000000004847304402205c24fee84e28de0629f50a5fff584b620218f5ae101a450b172055bf820b7a0402203248972f6684cbcb3a1970af90bc130f55a3a00a1404a3a4c7f6be40fd9a3d3601ffffffff6ec5e0735802311c2d1c4789cac733c6e284b424d2846eab5ec87987a4978cfc000000004847304402206d678b34cd05c0c7026c61ccf9e62e9f2e661cce849785834239fb3adc8921be0220218cceef7936363253b2f07936b6d04b05d8ae7190f1aa2abe5b506934a8ccb601ffffffff6ec97793603b95cfe0702c5496c0ed626491204181d482678e93212d08dce323000000004a493046022100e1e215f05b49c78d9da3b8dfd880aa7c9fd218789a0081420ba2fa3688450513022100faa47cc99944e9691d78ae286bc0a1a8db46cc20a62f9db5a8687b8aac6925f601ffffffff6effce7ea82d79d2db0c10a62aee1ca53512c8ade978bdb839c2dd44b96b379f00000000484730440220498894fe5cd8b6a43da8b6eaccd40cfd1ec721b485deee43995c502c0859e5ff0220744e8751831ae38a8615920a8b99ff1b65098c721379817b6afab49cb383659a01ffffffff6f81f3705e0dd329d6690523c39ce1372fc92a4907031a1d33800bd29d93c4da00000000494830450220686a2cf596c4b05e1a1c1ddfcf5be79f4e424f6d84987c363e265ba5448f24b7022100c05918960dc9d7cfe30235fbe8c049ff990c95b929af0f2f55e26987290b572b01ffffffff72d758776b820b59bf7defb2d527bb66eb4dd14c7d4b7a885d64faba988fa076000000004948304502203bd9597fbf68f5299b30dbc7f75307ee3dee61b4bca52efeb9467f678c22edf50221008e7c75467869f280ce876a8391fed13ad70b3fdc9af7fa35c947d9079e5f4b4d01ffffffff735918d7ce602e6d65db8a93aeb975cc6b427c0f315cf04bfa048366e9b9fb7e000000004948304502201eaaa1ca347f8aec501954cc13f28d2d11fb1edd78fb53b8e1e08ee57f5cc72a02210093d3fc10a5f50716092c5328469fe50ede62e89f215fbc7209089df04075474a01ffffffff74a349c5340b8dc2692a4cba9fd8000c78d1e0572b9bae7a8dd06d10312a2f78000000004847304402200a3eedeff6ac19698807a97ad5ded5f22765f04ec02cb19d2c1bae3775ed3a4702205f9942028e5722c726536c57d82937c30abe95f2fd1e7f3527793471b06d400e01ffffffff74eb232f354e9f8007c9c62ea9ad1961b2b70ab71f3afb9444c4cdcfe4a8e6700000000048473044022056ce78585c984f5e0a3900f53adb075ab9076a34b831fb08a30149e20d7136ea022025e9c05c362ab9d10853d085d3bb8b2d6ae38ca41bdfad4037b51500c5173c6601ffffffff75378dedd597cba72a72e45e516200fd5bed2a0d14f108b29d7ea19fccaca8a20000000049483045022037a46fd5325b8da53d2f09357208b104f3eac5e0feabd7fabd9ef09e3c9a508402210092e4cab8e4a9edcb4b9adc5a3d10e521c977860731886f2777e2616306608a1d01ffffffff7619795eb77515bf562559102b88846f6aac11fe5aa5ee1b5890eb3f02b62ab8000000004948304502206b61aacf30e64d956b7313e8b4151d8b5e9de409e836077ac1e5d38184c38ca1022100f2eef7a5044e7eb4e25ea2733394d527e0209f47a5b0e55acc7b05d1c8718fce01ffffffff7710eaad30c3948fce5afe7453c76294427ea5c319aca1552890dd7d92e603180000000048473044022048c5073df007625bdda3ab473605802d4a5ca9cffa3adac97fb196c5ce5b1155022046def8509250db255316d9bfa151a17513d09412f366d75378d6029f105bdd3a01ffffffff7737da70e78710564a4c47dacd14655d2acec4f4b53c821b2c86094aa315fcde000000004948304502203d79e75c09f3e70164df36f0421c72f18a0811f2f18d58ae28aba593efba6393022100f73ba18c6e5f22bb8e85362dde6be69406fbccdfa20114df31ccb64c044ce3c401ffffffff7756915c6251a3434657efa25900dd5462898c6a68d58ec837e065a6704c31cd0000000049483045022100a5e6a079b655b205e7b5ca54669928a829a55c8c93aacf3bd0d4edf364012ba702204bbd05ac09535279a9349eb2782aadd6b8ba1f974bcb56bd2162d38d1ce5350b01ffffffff776e71e0a0cf2a1872ebda727c562ddc88565336378ba120c3d206a00cfdee570000000049483045022100d71e20f312a452ef0b28ec083c5bfc6ec1c4a328cd3d269c60dfbff3b8e99e3e02206f902d70f9b4bbc74fb7fa14734a9bec84d542f8ebcc8ed3223482ba968939f001ffffffff77893212cfb4a7840f484bbf9bea473306934913cfdfa2e9313ce097dcf49249000000004a493046022100c2d01dd5ec635f5200a452a275b1f46a64acee28ee5721ea6298f5df13035f100221009de6722c59d79b692a5de4546e9291d0e44daf83d0dab392c0c231ea39d30f2c01ffffffff77f1391f7640ad77a45f36110c4ab46c2c0e73243d2d061a8b01a02d77b6cdaa000000004a493046022100c26c580eab500d7ddf032ce44ea1e1edc8f0dde3779995c95e89885f7b0bc3150221008d885b412170511ce009c8334705659ecd3394a4502b52b704336167b412900701ffffffff781ddf8d4c52debb9814bedee3d7ebbbcd4b9598259fd4ae8f06bdf5fe563c10000000004a493046022100a870fb8584ae2f50869a311fd47e12f8af1a5b4b0e0a59ee64ae27481a1c9c300221008f23eabbc055f7a0cb732442b6fd35d957570235a60f63e19310e73d43fd785101ffffffff78942140c5d807a50eccbafd3b44e3b9644dfa234bfd9c080640d0297e90a50000000000484730440220179a09d4991ee73ed47c72d74f198643e9df7572c5449460eb85e3fbca3c3da102201745e29468369f9fda0cc2e7f78822b1b89edf63cac5746486cff980a2a6181601ffffffff7930da8ab07c69b1a41d66ac444df2416a60a3775ea748c13243545563e90b7c0000000049483045022100e15c5d637b4b2d1fc00a4acf39cb99010b9e043d4cc186127b0dec317ba06d1002206e50adc2c16f23d607fbff0214ba077717ed16e335a669b3b10139d14b4f68f901ffffffff79333e40f02acfcce8d35bbf220ebf4f97131c08259f8d4220a2563f20d325fb000000004a49304602210081880cabc6432c5398b3b5a13d133d763ad02bc5814159376ec1044528e19d1a022100f2faa8414b19166e3e48d34607e2384251d653c4214991e8d2ffbff428dbb74c01ffffffff7940e140edeb5d9c59939b724e02a19952394939655eb9f6d3d0b47963b4037100000000484730440220083d9ff2ae87328409f8ddd47d84a174fbd1ffbb5096d281e55b8d93c32725e502201e3a601ea531889684afa65ac96ae55fa3c3add50e9361bc4fc416c24f24fede01ffffffff7964f331cb2e1bb89eec8e2baee3a9532cc2e170d7dfbfb8f93ac7dc5d919c91000000004a4930460221008888f3ee1b60ee6517c4754271749261ddc94646dd825b08724e7b92cf3b5d77022100b1d10ff7065f1c9c5b73e4d92b2e223d8be1b82e755e53e807b56400d94838a001ffffffff7b03d068420a9bd242becb92c86d40ad55de83efb8daab77ca0ec5a7741b7e97000000004a49304602210094a0cd609c52d62d468d808a92783b0daaa867b864b82e67d016d6ab0fe204e2022100aa0f2e73d1984db311fff95fe3c65e0a2dc568977da29e9eee98038c69bdf11401ffffffff7b47e3cef89d67596905dd89dc87641bc89943aca9a0c2133e56ddd6d9a4f75b000000004a493046022100ad5cf33f0ea26f2ef51a2f010f175071d82c96922eac3ebaf20a8babff92b9f0022100ccc2a16760298c449569b08ceb4e49202254f4d94a88dba8c9779404dcbf92a601ffffffff7b779fe3dc4d613eedfdc7fc1e4b3fec85b1de5e7466567fd0daa5e0208084dd000000004a493046022100b0d09b4170ec15ac9f2eba454ffca582b2a6514fcb1860dc802c03f1abf1dd68022100b645981588f0a8253c1ae798c613ffcb05bec4b1218159ec194b7113204f83ef01ffffffff7c428dad903a6e2b6059ff1927653696eac1ec8605da8486ec0a09ed946def590000000049483045022100e09954e7d85571b71a0f2f8ac3815ab479d050996d1d0cafd01bda7edb1a443b02205cf27791973e935e8e47a597d762f7a279209d8e427d1f5a0c4f5ee81409914d01ffffffff7e88502fda8565c48ce4dcb8019bb0ed88afa8ec433ce7708d54475cac55de34000000004a493046022100c731b06b613aec4c6e3b72cca52a00824f2ecb5d568143774b18eaba7e6017e2022100e9fe6bde4264893e527ceb06d608332bbef24d37563305275394d4122654014901ffffffff7e8d6e2d9fbfe2e9137ee0bf44ddd9d52aa802fc2eb23dd033c4281e0d3b2ca7000000004948304502201001e0cd8cd5893af2922e4e995d8b9202c6b138efc6d8bcb6dbb24daff3a49b022100e59ffa9ab38bbb1b004c0589c41804e4f0a69e2c3f8aa272216a8bb5072a3e3c01ffffffff7ee25e1abe7e44021854e7095fdf00b62b2a731f66ec7194ad1119d9d0666ea9000000004847304402202701d292664af6281175a20ce137da73ef21a00006a3e1def07dadf291ea4f2b02204842c59207134e720ea7e170e7674d0e60bb214d20e67bc91c92cf6d237178b201ffffffff7febfffbad8d834a7f5ae4b185b64eef86c9f1044fa5db5e1c64acaf8d7c2019000000004a493046022100b25cf285ced260a2b0ee424c230e671f900500de3d5792499a0391c710cbdd0c022100e8f376b506759c55a2465dc0a589b0c77ae124f14c429e9eaa5c6c5a7d50cb9901ffffffff801a55a9d311c89d55c5aeeceb580c0a6affc7d5635126239e8b70e1bd6479970000000049483045022100e6b9b2634513441f07fc9c57ccda20cd579a778435ff04908400743720a30f46022077ddbb1cc94eb663cb49f90cd19a0351bcc6ee4bb2f97b0d3ebac9c693257a2c01ffffffff818690b0b91bf4d2139bffc074d86004ea09e4405c62852f21fd42c09560f7f40000000048473044022011a3b987ba1d33cac020a3f4a91fefe3927cfd8ada1716b2531194b11324f11c022068b0ee6be306d2faafec47ab7d461c639ee0ad0efb27687894fa7e7fefe27a2201ffffffff8298e8f1781b3f053046d8e95bcca2781e587dc48e0be3959e594357c86a8fcb0000000049483045022100ffa2e134a520242e552bb94fdf00f4a4f82a18ca3f2a01f5e731e674b2e9f5d0022057d77cb313ebbd92a32562a8346119e2aa8006cd8a3d84900375b219ee4d5c0701ffffffff844a9bf86b9cbe49c4feecff2ea4704786fd25789c2fc2a67f5b2024c2be490f0000000049483045022100b34b1d6d890674b47a6fb7e6d9cb7258b425da7b91aca2675c55d5ee9256528a02201f258f20c024fd9e3857ebce5de7f8626ecb4f160ad6846d1877d2e7b803e7a101ffffffff8451c18057a4a5a9466f0386e6960800f6c43cd60c39915b0759f284872edeaf0000000049483045022100f5f58c8ffddbd15180a0c0af1ced928f1eba4e5a505c2f3595968535f2a4b8bd02204c16bb1da86da477e1165dd26231c6c532f6b9b1fae80b89ed4e25d016e2ae5a01ffffffff85573d85e5165d1a6ebb3836134b01a1c4e0034db222c1dd18000bd5cc06ba95000000004a493046022100b7b37da8a59b484cfb12e265fe50c989e1cdf42250318b09585eb6306a23a62d022100bd9533e1d15adb689e3633f1b501a6c0825a1d9c25a3c999fb1ea272a2c0287b01ffffffff862c0b77e9ba4153f67c71b73f4503d5c0ac3c93a26367ea6f0e3e471664465e000000004847304402203716c750873dcbf35bee394a60eea151e4a2b7503e697a99cefc6c67028253a40220633407e0a90cb903b59a2accdfb220c00631d376d88c9e69a5758d35c9ba2dcf01ffffffff88e6be84a51d7541a9c9c70434e44594a8416a0e348cc3622edeb8f0d8cc4f5d0000000049483045022100ae16e133df96ed76694b70ac46aaa2d33e80c229ca3ad06f8853a81eefb2f6ec02203463db7e22a4fb36a163509436d1b195b8f27291c06d0b08d1281f210c4fc52601ffffffff894da141e96ec891010b2b0cbb3aa7e54b0565c87966d718b57dc77795da550f00000000484730440220509c40c3f2601a5d445d25dca07f7082b3d944936f1796473393a6e0063e67dd022022fb210e19fbac7db9738ec9309b1db5c5524bac56e6576cf72dea6ca064ff4601ffffffff89a2c60f941d53356f38bec562f6ac489bdf45946248ca13da190b79290cda77000000004a493046022100a6f65a7d8eccfe4c7bde37e817b6ccb04dbf95b350e6cd89b7ea879a118455400221008d06944f6fcaf3d11d2432ab8ec53836e1ff9116a4a32c5926674c9334e2d1aa01ffffffff89a63240371778e6bf5e35917049a5518a3140e85781c9ddfdc8afe8807b8a990000000049483045022014021ee5904b50cfb9f55c6002c6e698b6c0ed867eb85e77aaf3bb35bbf57a2f022100f846517addb3eb0b52d4ba343a356c0b5075dcdfdc07effbc4bac62ce20a1dd601ffffffff8b1e3ebdccfa2b4f4f0453d4f49c5f6f47d2f190c26b1e364a46db131c37e90d000000004a4930460221008e507d04146bdeead16f76d16124f7bd52653e3e4ce3a28d837a15b148e9777f022100b753b8b1a0d0fc7c26340d82487918330ba732e885fde8de5bc3a8143478df9301ffffffff8b257e790e7489e77ca063b90caace8f045deb28195e0780bcb745031cf0ba60000000004948304502204ab48fe0dfd40ae1a320f8d02ad4ebaa6506644ac8e1feda2f86a3484439a52102210085ef68560924d67e68c7afac1c2b5d1cfa714690d471fd41e5318da64269c00401ffffffff8bab2d4aabc855bf46372ff973889db3f94424b372a0c7d8153698faa060eb0d000000004a493046022100fb926148bd88188f546d85a95273a9f485b25986d6c065c5218f9daeec7c467a022100e1afedbccde8d0ae0e6546940e9ef83acd518f12086c5b9b71e9847f71cce7a101ffffffff8bca12aea34bc965d5a6f255a7fd1898a22a00ca8f15a010c2873564954ed6f70000000049483045022008c9d60755ebcd70b4ef6c6754a7e24042b97275e1abb6fab3b1ff4855d2712a022100f3e1df634620dece234f64ac14706dd3ca5e965a7210568f2964d38998b0ff9801ffffffff8c49996e159faf33a40690da0c7a974ea918f7e3134691d4796ebafbef3046d10000000048473044022022efc8807f972bf9f4318b4298aa43d943b2a68e69bdb11d39c219ed94e3d6460220638f01ebb850b8295bb8fffc5fc9f6786fff631a2a41701d01567ab95d8b0dd801ffffffff8cb9fe5bc1e59ccec6c83196b7ea004ba1efe827c6562cb8c80d7638a870904f0000000048473044022014e5dd40a87774be4cd6d73f685920932f9beaaaa20f5cd25ae1c3da65aa52640220529cb3edf380315b123178b4f5bd4a3e96a0445e34aa2ff51108e1b3251e298001ffffffff8cfb9a0f1caae90956d9497565e0f72b7175911648469cc864266da61f7e1b5a000000004a4930460221009d4b871816db91cff0b00ffce1a8cb7d877b4b23f05cda087ac91d25aa189435022100f75fad399838775deed4dcb2ae5ec8292c08de5918bea246dabadf0abce5a05601ffffffff8d3c26e467f74f25f2695d94f2f6c98c3a98cc28dac6d003a26cb33c190df2e800000000494830450220418286c9a0239550802170d208889816731bc6d221ef57471f98475502c0f014022100aeff00fcd176c6c5fc8f9455d40b61f5f678bf5377721531d80455e23f1abd5101ffffffff8d7af862867073a00a0bb8c39f582724d77af4d8a760190d857d772bb348ffd800000000494830450221009db99c52db1ad4e47b1b607f3497480c1f9f3ab3769c351e59dccf962ac790a102206fd7b0b3f521346c93d97c9164eab339453ee914de24644088b72ae52523028301ffffffff8da37018ba37511c6c4461d1fd3c5001f4df1f989edc16f2fa5c4fc70bff730e000000004847304402205e09444aa1009fab4dfbdf3df9db6ffa49e5fada9cd69abcc13e7305e78ab97002202b2cb319369fdc6d2eaa5364a74abeadbf6a2a7f1e57d18cab1f84510a0f909001ffffffff8e5b11c2f39e426a2d720c0be451bad296917fa81ad093f30742645a48c7e4bf000000004a49304602210083a156c2a7ec089c368147705d813461042fac1d0b0a0075e373257560b2534802210081ab619400685fdd1a4eeaed45d0e04a41450ab3d0baa784b5e23cecd46e3c4a01ffffffff90e31e03ef97a7a5203684fbb07a6f4ec306550145cd9bc6101eced8f4da5623000000004746304302206d6c587aa369da3fd6e75d8d27805fe7aaaa56ffafd801bce491887f618afc0b021f066ab880e1b859e909512746c76dd7f5db773a96022dc13c38bdcb4d04fe8601ffffffff91d230879e4947d8228ccef36aeda6e74ec59fea2713f144e4fe26d81b1cd34c000000004a493046022100ad2b5f9dce7532a534756be5f06c04a5f45b39025a455c838ff85cd102ef7270022100e46b305cabca991bfb5ba22a9fba974b79e314f855b6b43560221d6f05cee90201ffffffff91e6ff33e4d5439590ee754379ba86dcff4de11f2cd059df1178b62c0b2ac78e000000004847304402206dfee2e40d3ddf0793c9c1a2af5037e005c1d94b2ae0588fd83c9229d07a5c900220670ddd065c45ef184398c340161a6914ecf7b8287f87e07d5586fe8a9495910301ffffffff9223197719ff8c7ed28fb79d78f84962888163c3a4d0b111abb521678d1bd32a00000000494830450221008b1aa27bbd8402fa3eb1e6a4aa23a7b22df218faedaf188d81d25041cebecb3002206f158c8509c71e7547b4def691b00ce627dadaa6299d49d2ba134d42e504275c01ffffffff9265f3d32803970b4a0fb7dc7753d205c45e3feb7e951c18c9b7fd828bf58c320000000048473044022044a80d4244e47d3bac8a0fada613e01eb0facb377987ea4157f1a87c13196c9f022024ba161614c5e76b658432ee22e4529c82fa161068627db6524c4f91c33794fa01ffffffff938620903eb8c3667d6e594d7f174270660532eeb98b34b049f57f31e7abb2e40000000049483045022062e601675632b46311c8415c261ebcec699b043f281a67ee64e6c0912c1d4e91022100fe27adde73276d60dc4939a882005d90357d6a492bec7d78d046eadb66f0afce01ffffffff947212d5b44550497c2c745780da7821051759c23655c9a27f8a076e65d1094c0000000048473044022008cff8ffabf4261faba4d703d679aaa6ef51c209c468043348f17062ada86eaf02203e401d773a906b37fd22f0b79d4a54d1d5aae7e286e4915de98e302052dbd04c01ffffffff94791a7e38ebaed89404acb613df663be6f1aa977abeeedb0dd73a480be5b778000000004847304402201d80f8db0a5d6a55c0389e2250952d6abf49612b2ba5fe593f1b55eebe957316022019cf7cb98c77ed2d079b00a63ccefb4f59ba77136180e1ae39b51c24aca5f4d801ffffffff9483ab93c7b0684a90e4f5e87195d415bb3152195f0023dfb5210704003a60240000000048473044022069914e79e91d237a36bb32edf42010fa2446377978cf0f07ddbf8019ae2125750220521961adf3b520dcce961476d441fea053b9513dfcb88dcfecfa48f1b592155201ffffffff95ac66140e19b806afafcda3e8c6bccc02254774df305f5aad7a0b2ea138a5b6000000004847304402206634ba4283bda739647d35b6352432435ab8b40be0f1a71566c34425a67d36f1022077ea07d022166ff4bf3df5db66bb859fd5bc17a4559ca19bdc59d2a06a0ad92001ffffffff96fee98d89318859fc403f8d99b7202b4264a7cbc8a8383b21ebffdaadc2daca000000004a493046022100bb1002f9f9866f3654453f2ace56817fcba733b9239843794e02ee353d5b86c902210086bd6415151ac75cbd1ff4f837eba5faa73992797e7c61af5edcf6c4e243dadf01ffffffff97a66fd7634a130bb319b4e2889cb7e13c6abefa4c289e23cb66f7738e81f23d0000000048473044022010b40104052522137c80a46dad92f5c550d5d57daecad8525cc8de5cb53c66d202205b771cc996e1b70d1bb2f2686339efc9f7e2106e0e93c8674c9a3f04c4b7bedd01ffffffff984c8aa2574d8771f6e548645b06886f284d7f1cc28dcde591a32c986e0e5fc8000000004a493046022100e2eec85dd02f184032bb8cf1dcfd7202b056ade9be59cbc6bd1ce2b757777a6b02210095857ac0f67e33bf19e545806d616dd3bfa498b0d079a2c15e3bb87d99a0d2e601ffffffff996c6a67bf8583825df410b069c6a80edbcde72216ab23e433c755de2ddeada2000000004948304502200ed6ff9b14579f7c6c96f72358e4fecbe87a26e4121d5853be336bfe2f61dee6022100b6ffa6abded1e709d970827df9638001671f3f635d01bfb64c29539627bc55b801ffffffff99f8bebeabd8f92efff4d8dff9006644742f94a131a6219b4aa41cf3ba58a6ab000000004948304502207e4780375e7392a9f9eb37c617a5380242d56635ad459e93c0f36ed90bf99edf022100fe8b23600cbd1a22afc7e2b77a54011602d26aa18503d6fdd310c74c8090187d01ffffffff9b40e29c2bada803abfad905590d602998759f659b344125aa2d9539f319a1130000000049483045022100fbc9aff9990b28ddd56bd0c6d63250f09336edb40628f9b884ca9795c42362a0022003ab75a40fd1a8efd060850b910c5d22572d5febc4b2ae6648eb9647bee3563f01ffffffff9b64b6f5710a3737f2073b2d1c98f18cbf1413753a4bb70c7e1dd079fa2c54420000000049483045022100873435efc79271507b42dbe4cc6af0026cd7538b81ed0e423dbcb8fcdd90725b02200581f3aa0bf0e5f0643a48f83d9a8fa351e4ccb196021d7bb8ce6e4c48730ce101ffffffff9f5edded3a40e29eaa148f54dd5817d6d5bbe90d258cb4a97baffd5e3abc9f9d000000004a493046022100fdc2e54ad0fefa4f49566b5f14f680b8433666053ff33a69589d0271c8843d9c0221009a35b192abd29c56d2ddb02f439f3b37cbbbf2411456449e948ecd92619d97ae01ffffffff9f7d314059025530cc7ae278a775c04f1253fb260f4d5379a9fe74b2e44a27050000000048473044022000c2a8ed15acf636edfdf5ffddb2c48153e7022d92922ce875b72a93c65f03b70220686c719153c935c62f30766d727984e431bafd389099f3f8c47b7bc6fc22066801ffffffffa0277ede350707b54db02848a34a1e0b40a455702921fab1ce244a5eb0e33aae000000004948304502207f4d49a22e5d62758c566ca6fe9be1de1104a4515f006e1ced9841201e52cbad022100835e2d66e977fe3be3c9d336fc3cd9b4826152443f44aaddf701cf054bddd4b601ffffffffa05a3b85eeefc1b93be07decc17dd13c5c679363979b7ce749b65f2ceef1ea910000000049483045022100c7a034ab6d420842bd4a7e06aba6df32d56d502bffe1e6c86caffbdbb665ab6e0220430a142144349421d68863c5ba8e92246122bb4bccf7ed2f32fef1ec60f299a501ffffffffa19d68a9c3e9e82af5ca6a792779f05611ffed2b35d04ad9e0bdd8183a728eb70000000049483045022100adbef1b4aa1bb122147ec24b363f8121a806c10c0ad205917ec66bf8da8a99dd0220209c47677888f657102a1b5c2ba7d1d22110239413672c55e2baf657458a228601ffffffffa2a30b392ca1314648099848d14b968856701771198db3226dc68b344ac47edb00000000484730440220447df15207cb9b7654297fe37662d534cecf665072a22ad173dab519fddac484022017b7ae691a10a60ee93625b9535d9b8eb4763dc7496fc1eb7f1c89f117bd554801ffffffffa2af5ed278529c3bc1fadd29e8f3eed29c69656acf25359b2634f2380f118adb000000004a493046022100994a32596dcbfc3a5d47efb6956b2b0da2a459e1205d857cdb2bc9c09d4a6deb022100d608ff533897cf6e996731c73729b8ae01eb91f0170c70cdc19084a5921e50c401ffffffffa2c19a55afa66d93c9f54595abb72ce73a3e192ca67e3f121e5995444ec643d50000000049483045022100dbfccab011113af2cf0d2d04a97aefebfd37d4564828f7ff31e32bf025d39df2022012e031df19e4042179c2f6052fa7af683e55380c63b8f1219a016acfd88abe4501ffffffffa4c8832c2d57a1d6c74ab8c6aea45d086b5b06fda7c15dd5af82f502081447c20000000049483045022100b7049d91421d2a76ccfc23ccd42bbaf54c3622d39c642e2ae0bcefcffbc6629402207cd579148e3e55c762df02c5d1cfb592ddcded3679addaae0f8336c1da37a9bc01ffffffffa55c17d4a4cfb3644dcc5a52215c499a6655387e9055ec2c89dd3325e52d9f970000000049483045022000dd4eca7932f14767d274b9c8e804d6dd43d334f798bc815bfb53e9160fdd91022100920e659485fee8e00475cb7c2fd34c8ed88b77642b332a2066c70042b097af0001ffffffffa60558de647fbd366a2eb7fc94b518386989c5358892e264eec58e84f816acfa000000004948304502201fab741e5a2869c3bc3a69944c0a7436374a92c50a9bad5a9abf27c19148e27f022100b7b29320c95324852220bed36289b6fd092bb5dd8991682dc4d703e0c051512701ffffffffa69d4b6feed8addd845160d6a68a4e34d500769c6bdfda8f042377c56acc4004000000004847304402204decfd453062a26f4b74b80651fe0009dc723b93b02ee889e46784c8ad18225202205eb7af6851c44938327aa8c2868094fc1f7bca5d23778abd96906c1679d4955001ffffffffa6e129d4f3a904d4e06af9e147413fc8a01694eb1ab03a66c4405c98ceabd680000000004948304502201c7fc7ec32b39baf1d0317bd6b655729ac5127bb440456a273f317bd1d7b61b7022100806373cc455522e9ce69258fefe252ad81022f1af23134c5696be6e3f709602901ffffffffa7fb9083503a6706fbe3fad3f898ce674ac6f4573d308c58bd039277136ccb40000000004a493046022100f34ec6e0cb32bdf897b81b35403c9e840b849183f2be86a5cfb150ee59faf904022100ce832fd66f612592fb0c60e1f916b7b684831a340ce29dc6f6090455b65a2c5201ffffffffa82525efb373ea30637c925b952c1791185d99dd20a9ef2a819bcd3b7b6a4763000000004847304402205f28dd3a1b276228c8e2f402f2b714497f9d586ab3901b4108254f5abc1f2ba0022051fd11a027be386f55bb1285baae7e7997da083aecfa5d1737c761ae0e628a2401ffffffffaa6d55dd695bf8c8ae6315248b95efd4ab7040e52c5b47b5b50eee324e4fc72300000000494830450220523b69ae09a9e712b415c09837ad22ae3cd72fa7ef1502c9ffeafd43484c3cfe022100ec58cdfac6d040a1ad8dba16452482c96cbfa9873bf16dd217212d9df1ac267901ffffffffab244efdfa12495ff190046d4bd337d2c4486dccba7b2cd517585b980184a664000000004a493046022100b9d71d6b6b8d13405926e890e6eb584429f52338559d8d2735752d4cf221da160221009aeda32e93ce4ade7486beb042deb734e39cd3dd2db6a90c6e7570e4873ccc3001ffffffffab6e583b298e91cf4cb2293caeff4fe33170d206552ea624d6f89a5bad6fa50900000000494830450221009cdf1349bbf27e65fa93e7986762847e4db758672dd3f2b061a7cd25b876767f02202e778d3b8cd4ee947e31540938b7aff9afcff9f82ddfca94292f6b9321730c4e01ffffffffad555b62b84ca4166839a3a14c1513e59b32daeb92bf3cf3bdf0886c2b019fe20000000049483045022100b3ebb511568f22b30d66e31ced3b2318dede54b53b395e2a2129d66a866755ac0220372e013cc167c97d8fbf40deadae1ca51c486dfbfff041e029109e7828098fc101ffffffffae5b74e0b8078f409f58965add850fa965ab36c3257e8120cb5cca21274b641f000000004948304502204c9f4dfc5fbd324bd4c68deaf17acf043f620435d61057e76fb1c3defbcb0f9c022100f09865e12b52a8358d66f97c95b1153ca1d030944c5327121f89ee1aebaa4a4701ffffffffaf2d2438a014e300a03eed87354ceae65b9065f3d20d75487c39d3294fbd6e70000000004948304502206cfe4f62801a1c4984362f8b734d79cafe6d7838d5610eaa87760bf9033cf3d1022100e9988769fba9a3aa668d4e4466a8f54416c177af8eae916b25809ede6215132201ffffffffb15da3f35642a8960519af325f4aca95f917ba4148d5215e88d7fd87b0a378de000000004948304502200ab1d2c7779c91f05d44911b68cb7a61f1b911ca3cc286898ab8bdc69a6589e3022100c20344a311b2102385e0cb97d1f0ab6406175250d720b8f56f5b444a372d4b2201ffffffffb2ae8d9508aa71d6f156a29362be64886a412dd7211122b4b34d8b535ee97f360000000049483045022100844e6d71bfeab6c127e09f1059a56cc1d506457867ae0fb47fc8a747ae53f7250220125d285dd10c93bdc63f45788b4c3cf04dfde76860e767607812be49dcfca2ae01ffffffffb2be8882eb9f573b4be72af8ebc406d2bfe8c19074f2abd1a538d1c448630ac30000000049483045022100adb10a2ad2cc1865fdd28dbd18cca1cfb55cb81a4b559aec7f86e66606b3402c022054edc3af229fbbbf13017947db4334b4a8d13e8197b645f3a4a36a5e290c369a01ffffffffb32ad76c87f787a72f1b6c100c61520ad3d464d7704d74b94b4a607d61cde00b000000004a493046022100f76a812aa885e1a1fd30d22b1d2fad395c95f36df93fcba8ed8162b95bf4424f02210092c89c1b0caa16634f3679fe0fd447b2ef2f88b484f248b83f152d91b0fdf38e01ffffffffb35880c41d1588de7dda1fa0155400dab4f7d52b441d8d61a3d0814bf0fc894400000000494830450221009c832e6ed41a157c9a7b366da620c12fcc8980657081692866840762d909f60802204d442e796febc90813bd1fe1862d9bc59644636ce99e2e35344d82b954304c2001ffffffffb429b8010ee14c02178c663020ca508b2853aa609fb0d1a43bee00e8c9e4f97e0000000049483045022100b7ef4274c675f4b1d2c4df19dce5213cc33f06716d7bdbf2204ce828cc1403f20220362b9128767072497a8ccb5af3e299efb2ec22099cf911e3f5f963f4b0e19c1d01ffffffffb523c35b26dedc2a648ba6a5c56741efa91e055523a1e887b6dae4228321191d000000004a493046022100cf6c0a02f676d451a80a202db940c338a7f25e077c84d7886a443e2f75decd17022100e521231aa8927b27c55b521adb420ebb4f668706807cc0b670ace97cb2b2d58d01ffffffffb5773f119e260bf7725c1406ae783d444ea5dd258d820ff0bbf5850eabbb0c1b000000004a493046022100839108ce420eaaca360aa61a5adc81bebd346b2f2d0ab7cfcb27a2fcc8b78ab8022100c04c695bf60257e87bb67d17c18292e0cb3f363df993648d277820818711589b01ffffffffb6b29d525d7da4b5665e0da39ff47d0e28a7b3f9f977caa77f6bd329c2e268a1000000004a493046022100dd0c91383b89f1daefc458f6b55b4e8a06487b964fe39bd30d93dcb55914141c0221009edcecf9904c9409ad5b76a1cb8fc32ade24e4b3f2bce780c4afdac974da9db201ffffffffb874f60a81e55e595851e6f69caeb163f38172509c0d5f506a6a54e6dfd720ca000000004a493046022100e63904b7fef3b59f65d15075707d7eb57d35d1439dbecc52e2705edf83312c1e022100ba3c1edf7cb6b58ebb2ee87558181f488bdb37fee65d05d2b34226cca0a5722801ffffffffb95a0224f8ce137307e39ae30e9f198a02111a031f4c22921057265ba775c561000000004847304402203f96c64baa7bbe96e9689e17215718c0a6dbb796bc2db75005340bd736aa73aa02202646b0f468dc0f73e123072ebc5ae8ab40d5a1f915b413f6711ea6b698b8491e01ffffffffbcab2d43aee2ea434a2a2fb92880754c2e2f909e99866a92cafe9805fea2451700000000494830450221009377409b3e34a163dea103035ec8b9ba8eb5fcafc6605bf9476b455cfc51f87d022070d6cdd3a2261bfcb8d29fb77b002c08abebafebbb82ef80893215c34c16b3a201ffffffffbcb53451311ae5ed88aea07b75f165952632c40091c82e8786d0afb05cd50fcc000000004948304502210096d3c3ef54b9377e3e05ab2cfbbf7efd8bcf4268c2398b2a8b89affb6f49c0a4022056f3fd09a9d2d55d29ddb12063973a0536b846c597bf48f538f8ed085f99f2b001ffffffffbe1083bce551a5de4bae8a6bb097b3fe5288a9be7dcc63d948191c857abcf821000000004948304502205ee86cd90f5daa6e6a5a242670434321f8eee2b2a4aeb09d2a58a836387f39a8022100c71cf637e0906905404afe1cded10a85e21bea3f72329bf6f3fb89778dbc395801ffffffffbeb25c09b197c09ea59c128e1ba55096addb456360a31189dca0cebf3baa6a82000000004847304402207c19b5f7dc2f102a500423ab3981b2a2e5033510e2d19a7811f57cd93796c0e7022044ed9e205956c64db77bb44adf7ed1267bcec8020fcdc729bb43b21025cd2f0301ffffffffc0a2c64bbb1774a43b3d939e822412e9187c4f46df12a6d8928eb9f98a5fc9580000000049483045022017992328712f92e198a57e0f258ad66a2faeb47e4f6a63316ae960e5068b6972022100e1bc9eefa5716f36fe7bc2a91d4a1c0ad398182ff42a709d60c7d050b668e6f401ffffffffc0aca6b9988389a90484781717144018682a1a5bf65df6d43d3470cb291a8bdc000000004a493046022100867a4088ac3e9bd72680d2a82b82177dfc5e450916d788579bf833581f6ac27b02210082f345e26090d69fab1983fd1ec6a7d9afb9d282a3ce2a011b7e18993b251e1001ffffffffc10473e4b281109e0dac6f6c69fd5d5e844fa277dc6af8bd63c16b8026857485000000004948304502202f26bfc3b8f435e4cb813045c9ded4f424308cc70929d38fd534df1938971eec02210098eac1843435c33bdd128190a6474224d7c99af8059f9d158452963cdbbb7d7601ffffffffc120b83f810b5385a6f647f4095845304a89593395117ca59d691ddcd10f65d30000000048473044022070438255035b5fd8f4c9dac51d7d24b09c4542fa2a094f83bdf93121b549b5830220142915a779b7da1bea05f539df78bc214363ce2524baedf9daa93531686780f301ffffffffc1f556b23ea554c701dbee93e755a11cdbedb33cccae4de8cfa47f6b18cc6639000000004a493046022100c3502aa728c911004351b601af479f2acf6e7b8838fcce7be69a69f23df2b6ad0221009ab9351fd1bdd6b826a2097f116de5634963c3f3a01bccfdab26c36af82bad1d01ffffffffc2054c8ca14725cd4358dfa642f9eff2de8edc155b53d83a50fe432ce69376d9000000004a493046022100b1fd6da5f30fb8af6682afad3e30cd54d9c3859c464961ef8cc82cc40ce60f52022100f9171bca6d11edd315e8ec1fe94cdc705617853e61c43a90c57d1aaa6dad68d801ffffffffc2a26015b177f47db9170520c80d587269fb91b28064ea3ccb848a0b76226a92000000004948304502207f5fea416f49604d3bd6b9d80969a3d5057c2d3c191f29f98ecc73e49a3296c7022100fe715be32e441f22616d80ab7e12890ac0cd466a1258b67275e2d5d93474da4701ffffffffc4d346fd609d5b63343bb2c60aa3d79f8234cbc0ec219e5898b11b9580f5a40400000000494830450220734044b13e5398132e828f7fa291a1c5d01578f3e9e70d9c18b866651d1dfe7f022100e49050c1f6768521c07928c05519eb463e4566422f8a7fb7835a89e0d372e55b01ffffffffc54047aef47e0975d89756fd3152dd15561aaf9827a8a76bb3a549e00293cbac000000004a493046022100c311eb814dbd3cb426810dcf6609c6fd83dae065375a6e9b3a2ee424fd1c298802210098f52978d37140097f7488371b1806f847291b0727f37711b94370f0b4f00f6601ffffffffc54de5af22a70b755ce3966fc836040132d0ba645b39d5f1ef500b5e7e5cb6640000000049483045022100beb301008acd5caa5ed5e1ca58aa735da6d3f0b08a440eafa524e9d8568cc26802203a73eac1b32e8b2696d6ef0943246599e93fb01b24a4bb0638c11f456bed257e01ffffffffc579cdbe9ecb7a66725470d2029cd427b684c3571d2f1d054be4518e34a1a4780000000049483045022067bf7b160e28894e36b83ac3a70405606bb3e8d9cd1b792b41e909d8deb62a6a0221009ca94a2596fa2ae846dea6dc5cc6ffa980312cf98a0fc7c8952c6f7d9843e25c01ffffffffc6b38487d389f39aae0e25944fc4ae4f2233c068f4b3570f92403c85517f7b8e0000000049483045022100f13354ee1f0c0e1d63b1f981368a809ea577bebf0bcbe160bd2dbfb96085e6c30220519a3b821d939539d842c1e2cc3f80db4b8e23c7ae3bb1acd19d326953402c8701ffffffffc87cb93e2b749195c93f84a2092dc1bb78c08405bf99a4cce43e957a401278a10000000049483045022100df3d38dbae8b48a492cf430cebdd9653194b285341524131a645dcd7a57c50020220011ff94d78de9cfb5307889b2b9effbb548a3f75a8180fb81fe4a4feee1df91901ffffffffc89e2b7ba74fbc4d796c61c823267427be303890740a3b1d1a4aab2913ed72f30000000048473044022062797bccdfae280f5b25aff43edabc1177fdd4b164651109f96538f8c458e82e0220760252ab883c6d72548a303e8318c01d576d988384f08b24ad0444d40339af2501ffffffffc8f08d8541f0f9effb3ebe18b338b65fdd24a7b214e675abf81b4b84210bb8b50000000049483045022100fbc47aaebf76209b77739e2dfe37fdf2206dc17e78c8c5746ed9a811948979bd0220674932403bfd4df0b4fe1bd5a8575208a236853640fc55845579fa5a5c9be14701ffffffffc90e9ab4da72cda921753351df15181d57f1d0ac388432e8663f78b72fa0ec4700000000494830450220131115ae876c2405d0d43fcc43fcee92589e9eb0940d5f5b3b5302a9255dd60302210089718ff9259393e476a61529a1dc109006f2922ecb35ef8a82e053872ee74bc001ffffffffc9188bd5ceb85e378ab05abbd1f3d6bbb7ef24b0390c79708b6a6b783827c3a0000000004948304502204f97b055503ec315547f3846aeb9a71f31938e6b3fd6dd5e51702b3138ff360f022100cfc1c1a37aac59e24d5e877ac3560ae95fd2d84788336bd0f93da98c26e6e07701ffffffffc9d8a05d6e19993b497b98a5b0f82f5a019f8aa3a4dad98c0326e74b3bb29240000000004948304502210090c69220551d0b6bb16c9d7de39658e8306bfd8dc21a57e8dfd98ddc68827cc5022050791b877a966b0385c0c82219116c90df9e3a316c9d8e537b5a72feb8f4c07901ffffffffca398fb33b7c31563b75130e764f76e8aca8b51bf061e876cdc0409007f5af76000000004948304502206f8641a9c9fdb8434452ba4c97418b04d49f6ec426192e7de50b664409955f1c022100e4eff326d55e6da41f9e19cafbab1b0d39fcc14a0118392c272e8141c09f62c501ffffffffcaa40698b0a1f5b2e60537f284f8cb82b0d6a2b71910249c85de7458b02cfea6000000004847304402206d065823f5cea67752a64202cea5032f7d84fc0566ea6ff02b3bee83282acf010220150f74a438ef9b39ffecb6f1c129c72f70ddf3c75cbd58d5f247b50b90e802f801ffffffffcb2453c33f5b81fe879a2172386c20ceccb152ed6ceba12cc54747960c6615f5000000004847304402207a21929ec25a82a1322b24c670c350560a8dd6e1ade255a94067461428e8d8ba02207a0e2d06015feb5143d69f62ec6c3d63ed8d3f023fc8170a891447259b0be25601ffffffffcb94dd2955d3a7fc3db8ad8c6dde3fc15cf26ac2125e1bb4f96410d904678e080000000048473044022025dcafd6c301149ba0a3f835485e66a9ccb805a5cb075a0e5b724a97a7bd5593022052d50dd971e6e81382b9d7bceb4a5e52af0803845d09ae093bc7daeb291438b601ffffffffcbd7a1dfb90bf54ca5067d49e78771730a593ed0cc72b937d4692001969f8545000000004a493046022100a3cec3a586c391154a5b4c25e5177dd0cf869bfa8206b0334dcb817c147fc13b022100c3dc9e3ca2cbfe47cf11b53b62ca5ba83b03077a2ef3ca96e90a0f3907ec3d2701ffffffffcd21a7330a1c73ebffed5aba8378d0b665180f855f75e81f3f034e3c04493ae10000000048473044022037f29b88a49d8c81d15b7ba40961457bdc55063441ae3a5cdc16f1192c46ed13022009ef59084b7cadddd8a626e9878fe0e8e52dae27d448839dfaa81e0287d0f28701ffffffffcd27333305a9d4c48f6c6cafc21d581b709ba02bf50aa57f53bb72135c6b5ae4000000004948304502206d01833c7e13e7a8b588a9bbef3c0d3ef1c4594289600e18d72e04ae3dcce097022100d2f7c2d3a1cb1aa77e0e2bf541ad2864b15e9562253216a1d84bc3e6ac7f2ab101ffffffffcf047916be763e44757e1698ddd8ed01751b3d01c2f32482c74b03d0a0f6733700000000494830450220587a76224d92b867dfa3b09e7e1684e2817487fa02f6afa6f07954cd4a45446c022100a976d4800f8b384bc582178d2c4e6335cec20a9913e28c930049d94ecceea0ce01ffffffffcfbe4d77dbe8026e7fe7f75111beaacd6a164366af56e55defbb0dc60605a469000000004847304402202cd865ed350e3fa37106e3aa882d3eee840e06941edeb04ea70d2e8c033499d502205d240ac562dafd95244806176bd76f1198305ab6454fc5cac33b472cd384ee5901ffffffffd20744dba647cb4455aecc929a6d0ad974e5118a7dd7cfe9e7ea99470f64311c0000000048473044022061206e5316aec83fe5fe22980cc207e12bf4cfe87a4491728ba99bee730b71f30220421a01c13b64caa3972b89feb0a8002cbef22ef45b53e89cf789e9bf1c0f711a01ffffffffd3a4c099f4c4d77bd6b4267083ccb25ad9356842dc33659d5d69278986fa1615000000004a493046022100b4d50eb00d9be6b537885ea232f1607ff74a3b2bf35934e900ec2a75830c50ea02210084ab4786ebe700c011da961ff14f6028f92a880307f96a0e096baaf1a8a3031a01ffffffffd5506e3f3fbc19ee3d59008e1424aa46c378d92e1b40c60620031cdb25afef95000000004a4930460221008726690d90c503ff03a3676517d433d227f742e5b447be50030c2d6137f5793202210084424955b6a995ad4cb9f0e1e19000c4c876ea21b34c03546383bef5cd2327be01ffffffffd6415dc52a6502206b89e6538b06cae426183ada61eb126f81b92b09f1ab45c3000000004a493046022100de71e86e3b89f74da1e21081b4c8b5123eb2f0c93d94e1dc9487f71cd6b494a3022100ce84259ec141b3c99a9953462e80964b9bc768cdf7d9f59e64f29006e13e8b7301ffffffffd6a77ef419742bfc32611af36b2aafcf1b5223b6c1a6952301091bd58c369298000000004948304502207d8bdc390235f265975cf9e388a41f44b83b641bc7e7a7b63982bc6a3956d38f022100a69086673ad4438971675f2f76c2ba4abb759561a7831e1d44d947fb7f9103ed01ffffffffd7862154ed176da1f047b0aa65ac8af5a455de3fb78ddac57b56534abf076dfc0000000049483045022052e3ebb28efddcb36bb5cd27d9bd60b4639b77f558c8215ea6c796b086bb719f022100e960e37184c4b831e93658cdcb24c1a46f52771be36589ac7de7f4985109e10801ffffffffd7d7f5bb6c110fd6a39d74c7a9a6fda290f3adca18f047c8530804109b1216180000000049483045022100bea8414ac283eed1678c02ae79eb3dffb4cef07b9b2f59933904dd8489ff956602203614e320568799ec6800e6f3e7951d786cbb5e579660bf9c9f6917342e7e0df801ffffffffd7de1ce40721d24b41dac6c807efefbb5cdf78cba3ffb5da7d8d42cfb99dc8710000000048473044022025bb923b9fa5e62108c77f8da1041b53795dd112b2e25470b4536c6760c675db02201238b4065c99b0760df1e8f63478a4a7e78a4f00749f2d5331c40bae60f5a15901ffffffffd8874768ee24479dc3370ab5161e8e4c45be2b1f2d7318b55d013cc63ce51dc6000000004a493046022100cf8b8868d755e7647315656d794cc55e0af140944be97e8c92013c3ee7216c9f022100c5d786e4d1320286b28efc934218c1e6e91bb8c627260a14a6457403cd68ec8c01ffffffffda94cc013b3fb32d8f92cd2c6cc4c8e8bda7ab23880674679ea6cfe8fb4f2c08000000004948304502200c577850802ee7092d6532bee09309c5ef8e7571ad4e1e396f7bac8502a2b5590221009158cb07a12c4495d2f3bd285ab930da15e1be614b32859d77a65d4c50b5f42c01ffffffffdb8204ba27b406943e4769468626b484d46c3b78fa634d68182f4d4b1ca5f4fe0000000048473044022023366aff261f4a597f49a4477a48ece5a20fe401ff97d024e373bdf0def8ac3202206715d81d576e1e91fcf67d16e8ed9b066cc1e3ce18aaf1ed967da079f2441a2101ffffffffdc45243e99ff3bc4e74b6662765f958181e1705efd034d7e0f51d925e4a48dfe000000004a493046022100be093ccd329b3e3c608b2581018a63bee4ff535be1d79cc1cb8b272576d8b91f0221009c0ca1360f850c29b8d127f9ff0686e2015512a89925197be02313f36c43a27401ffffffffdd789ef20fb5473a5a16ac71aee6d72304173f22d2dcf8d13a3781371394d65a0000000049483045022100f69c06cf1e633aac57768151cf1d0accb1fea0cc1909d5eaee8454106bea2c1502202f98bdf5d5cd35104386707166ccdb1586d930c329d45e36b753fe12e780701701ffffffffdeb610058ecb3c678ed4927841ae4c27ca2b1d18739f50475d5f640f885302f10000000049483045022059c4b493df870cbcf5177186b7550a4bb1376fa11677cf98698e53dc6f8f7ac3022100a43b374f73651cc859c3e8eacbaf521e0722741f25d56173fbe162f6e908d17c01ffffffffdee0791d1863bc9cecac3e05945fe486909d6d4420aa08e3aace1cfdce33b8010000000049483045022100ac1800f3235b2d9f1a9c308c4d923c67ec5313106ff7130df8594e7d80e1f98002206ce11da169467f8ccebc3f3c2815be0d537739e24bbf11d5a6934f741333adf301ffffffffdf06d460f788d66febaea9dee6dc67982989b04373254a3c8483848eb571111000000000484730440220115bf33f9da99eae576e34ef77f0595a37e56c668c56f2ba9191791d0a112119022031fca5966f1813ccb142cd7302c223502e54a0fa8fdb46888349c18260930ad601ffffffffdfeb1dace6d8db66a29a6dee746f063243eb02f036b8b3fbb07488d6464407d3000000004a49304602210092a8bc37cda054aa03b9c93dde2e89066b11968feef7b18061dfb51963420059022100bbe4db446fd725dd3eaf22236745f72497d074ce261606b1e831d8a263e7146501ffffffffe001a25edf3883e1c7d678707255f4d6a9a0b41b6b2e2024ec92e4f5de1af6790000000048473044022017d88daeac17f8e959290a84feb9b067dcbaeb5f900fb26e64630c853604ede602206741dc8db28f12c07eb111f3f4c53002b51ece0ac21f2a583868da1894b0e1aa01ffffffffe07b38c227c5a040415857bb980551f549fdbeb04ae2846ab1bfdbbbdfe1f189000000004948304502207a68d9aca24b8282c6b0bb9aaf8c717b7ae830f5a5633154e51f4769af82afd30221009d02cce6ef1aafd0859c05869aaf6e4cbc666fc7e08a76633a7a12f2524b782601ffffffffe15c872bd7a72694376e9586d47ecdbe71b82c58f34415a0db6ac933ad6e3d64000000004948304502207c032a4ec5c114d5c97f677b01d1f6926bdcdcee64446c878f5a3de7b20d6bdf022100fb07d3238fc4ab5bf88f8f808e41dc524e89fcb5a8663551b7988637d0ad18be01ffffffffe2a5c09888b03ffd1de410d90ce9e31eda61d7221c1aace4a00d7df6511075a3000000004948304502200982987de9b57d17466abfc7469a4ff3a84db64b80c25716a70e89e79a10e87c02210099cd5d54f7d518b75067fa91a7c9dceb1208e32d0d1477a65b4f6ad5a19057d501ffffffffe34e0758b593e1e80e760712737d28069c2116db900059122fb8254fb00c220e000000004948304502204fc148bddfd6296355c87d7b7628cfb9ce1e2ea053caae1f327a606c541a80f5022100ce2e1e411c732af61f862c275e250abd6f979ecb37c60e83bec4459f994f2ce901ffffffffe3acf941f65ec07efa641e840d70734e1ee77d1f89ce26dfd2499eb24a6aa24b00000000494830450221009d210b706f301fad511c9463c85963e2f0de8036766d1e66f40d82beb6e4507e02203655950cc8dc8e3c4ef19070c6551925e94ddb7acc4e5fe15e6fbe4e3d48b08401ffffffffe4784746ae0402d8843f563183948e393d0e5bedc423ff5982e0d200d30c015d000000004948304502200664dd10cab39a4032a877aefe6036f214ba85be56d35c5ffffb7a66aef5221f022100969c0f3b10288bce24c06d062fc452b4d9268341c6629d5d1a321fb3a9290c0701ffffffffe4a31a268c9e9e3a3f78925c4bbe08b402d76e8c187677ad3abe1350dd9e4031000000004847304402206ce89a2872f3196f01dbad5b46a5b8efd06754417280c54faaef88ac4e01ed7302202063ea42683f43c594cf7c5b1a0172774688f7ef031d503604279d69036355e001ffffffffe50fd6bc7d99c90885618af250eaf9143abcd7c6e509f0d5c3aa203eb1d56d5a000000004a49304602210092d90eef216b47c2d4304ee8a2dc03508e3748821af492eeec22547f0c3c6175022100a9ff22dd8e51a17737f630eebbff3260caa431af2fd706f83130ca5626685e6e01ffffffffe5b872174de2a4de663789db966e437b63dbc30c891b0c66b55ee803df21c175000000004a493046022100e87dbbd429bd0e7de445a98ac1989e0170891dc49ea38d26277b667a625eda17022100a2e24530f2891c8b58b213858e775b40f531f21fc50f0e32778c23acd5b4eb4d01ffffffffe97a3ba15620d0f1896259d1b97feb932223319b864daf70dafd589a10514c6e000000004847304402206a572899e7728bc0b4bd6f5b88e0d230ea9e7c20c2ca94ba6db861e344d7f97102205217ef8d1bd6eda2cafa814da047df9c4e42f608ea20b5f055d8e9c6eb8ceb6f01ffffffffea2c4df0ebea9e290ea02e77fc07fd7428b0eff86208c57a503145cff82354f8000000004a493046022100a90663070574d05261d7988e0ce33884a37431c35c3179b1d451f69706cbd91a022100b00bcf54bbf0646f4bb354edb0c94fd3b6573646d8e9f35e7b7e6536f086467501ffffffffea55a92b2d28659f6fed455b39eb6504f9852db89531f093e213ec1508bc48a100000000494830450220723bc85ce7b9fb227ab8e924f25dfad95c08957ba0ab154caaf785a15dddc1d6022100a5bc2ddd3f473040a905d8e888bf320957b50d61c7f8a28bb3c2985a30d0a91701ffffffffebadbbcdfa2c906b7289b007b9be950d1e17aac19edf4a9109e4ffc6d937a706000000004847304402205fdfe39db41dc05f668917a53c22f7f6504e38809581e215b7f5fb8049213b330220742e68ee09a0f3d5a09f42cb42121e8c7e6a19b3b6238cbe1e408225d0682c1701ffffffffeeb7fefa1153f7faeec6a2ddb0ecc243384e6847dde6666ef1c1a9bfb20cf0b8000000004847304402201f3019492d5e7e7748e9f4ea3178a402120d414ef50417f14a07415d9fa6f9560220571364d7e9a1a22394ce5dddc42f27dfdb74cbc3dda37af7d5f406c8ab95d4ab01ffffffffeef44e21ef2c617d58662f6a5eb2b1cefaf6fc1a8207746fa89f300c7b360f86000000004a493046022100b7b982cf8ce6eb92fd8fa6d1aad47e5042d0c8ad2367195fb3a9cfe4a7ec82ec022100977df3a879ee7fc21e9c686d61ece2dec485db384fb057dfddf778c5d838265601fffffffff0448878967c7d1bee2aa4562ea31e6d8ee152972cc85fc7e1dae2bec510e9b8000000004a493046022100cefdd4ef4801739b645494f9e34ffb870cbc970f38d96dc9097717b6dfc3c2a8022100d1c72bf682c31db1805a31da22787cb90716d95a376d85bf8dc5f90829cdde6501fffffffff194fd5c9c5e50a8d749513797fb4af968f369f741b812721544ad2616670c6a00000000494830450221009f5c6097b436e2a2e52b7ace9a18489043d05252a5df4512154745081076aaa902203e28d79bee167107645e81b90682142592648490c46b573353897c8f9e3fa63101fffffffff3f2637f2952cda90927b9217d466c308dcbc16d233ac0afae1ba99ecf1a4ad500000000494830450220509c36dff45d1d00595c9370cf58c1895a0bada228954808f49cfa9ff38126b0022100c6a5645eca80b844cdd4a3525a659a152d1c84bef04c77f936e39e6acb51012901fffffffff68eb8ec8313e0ca19b50612b879c888c4700f1a39bafd43130dbe1e84addd070000000048473044022014472297c6afe6b589221980532d1a492cc23c321f13dba4db8789397bcc8045022042de0dc5bb163d1cfdb8cffe36ac8463b5b282f353087c414ca6fd56e1afd23601fffffffff71c6a45bae8f525b5f2be09a322738bc5925a68735455d4c15b1155c22846b8000000004a493046022100a0610b385e477c2a47dab1946e2835f65fd8b6040ce2c5810d0bf26e60bb29c9022100d319918734bc7ea6df5bc66f97bd5bd54a7ee008bc8e44e662ec895b01265d4601fffffffff78edfae9210cb59dc42e232a87970fd0ed5ac225ecb279b38bfc8c21e37908a0000000048473044022034522a174ff36d7e7648330241609fc1bd4bc542ca2419430727f17a26f732de02202330298b80379b49064210c3b463026c5c05d9975f98d541a97da01456aac91a01fffffffff8a0b708cdf688037513dadfd8ca79baa22d5ec43c7551f92a1ba8f1c54dc23d000000004948304502200a932eebbab6b91653a1a831d5af1e2166e7681bf20bf8d9ea383934a3f8a16f0221008275f6cf36a0bc8d6b6ada5abdff57a16e95870ef49552d754fbbf989307ee0601fffffffff9f630220c03b0cc18e8f70361d51c579cd0599ed1c050ae4fe8ef1b9402c35e0000000049483045022100a510474565ef444552c423cd2bc6d635793fc18152b15610b9441e90821fc61c0220639cf3e3605383d92e51d17b881ea0f0a2d1e2507b2df321d1a64598dd922edc01fffffffffa2021437f273dcd49f1fdea594d97e6909136ceb32f9cc0fcba9e248a0bc2ab0000000049483045022100cbee4f9456480a5aa6558a16a62e56e6c5b90157211f03912718385ba849f2f202207e324535a81f57ae64036705730e2e797e036268cefa32954531ef63a2564bd801fffffffffc1a9803438f4c9f5066d16d7079c7d8b8479f10f12ca1173748eda092c2f7d5000000004948304502205ab87edc87df8fbc1ac3215384e5322c7012e78bcaa7bf07b3b268ca046a78000221008ab08bd879eaf941409845cb9795030f32e5afe5fd41b9797f717e3a421f3ddb01fffffffffda6e582dddcfb1ec1d7b4bea9c30fe400f3e072f74272021d8594cba5eb45fb0000000049483045022100fedc2378acbe60d0ed12d16bc7555a716a443362a9cc98d8b8498ddbd11fa35b02205e4415010ad96f31c586b2858f2ba00ee0a1737ef74871b84283f56451699b8a01fffffffffe39ab71e99720230af389dddfeba87b00dda306c7b75aca7aed7a34dd54f87c0000000049483045022100cd09bc648cf425a8f031465fdbf3e2e268d818a7f7ea41faf2eb2a4e66ff7b9d02203c75107dc9a53a919852aec28e5a158ff065ee6cea275e209c4c22613554345a01fffffffffe6ed81fa95f47c43b32c54beb0bf493e08c75325077697e2a5abfddb570d556000000004847304402201b86e70169bfa01d0a2e3ce71b8e3fd498a748a94b6ae363f7e240fa4e71b77002203a545828110a47f52c9ccf1491dc3e462bb62551ecb59bc5cdedb4bcb72f8cdc01ffffffff01c0205677730100001976a914d4af3cdd17410a9968eccaafc88b093110af5d1588ac00000000
+
+func TestMsgBlock_GenesisCoinbaseTxHash(t *testing.T) {
+	b, _ := hex.DecodeString(genesisBlock)
+
+	block := p2p.MsgBlock{}
+	require.NoError(t, binary.NewDecoder(bytes.NewBuffer(b)).Decode(&block))
+
+	require.True(t, block.Transactions[0].IsCoinBase())
+	txid := block.Transactions[0].TxHash()
+	require.Equal(t, "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", hex.EncodeToString(p2p.Reverse(txid)))
+	require.Equal(t, block.MerkleRoot, txid)
+}
@@ -0,0 +1,12 @@
+package p2p
+
+// MsgFeeFilter represents 'feefilter' message (BIP 133). The peer that sends it asks not to be announced
+// the transactions whose fee rate in satoshis per 1000 virtual bytes is below FeeRate.
+type MsgFeeFilter struct {
+	FeeRate int64
+}
+
+// NewFeeFilterMsg returns a new 'feefilter' message with the fee rate.
+func NewFeeFilterMsg(network string, feeRate int64) (*Message, error) {
+	return NewMessage(CmdFeefilter, network, MsgFeeFilter{FeeRate: feeRate})
+}
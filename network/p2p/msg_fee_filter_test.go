@@ -0,0 +1,19 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgFeeFilter_MarshalAndUnmarshal(t *testing.T) {
+	b, err := binary.Marshal(MsgFeeFilter{FeeRate: 1000})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xe8, 0x03, 0, 0, 0, 0, 0, 0}, b)
+
+	var actual MsgFeeFilter
+	require.NoError(t, binary.NewDecoder(bytes.NewReader(b)).Decode(&actual))
+	require.Equal(t, int64(1000), actual.FeeRate)
+}
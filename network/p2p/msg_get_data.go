@@ -3,13 +3,54 @@ package p2p
 import (
 	"bytes"
 	"github.com/EmilGeorgiev/btc-node/network/binary"
+	"io"
 )
 
+// MsgGetData represents 'getdata' message.
 type MsgGetData struct {
 	Count     VarInt
 	Inventory []InvVector
 }
 
+// MsgNotFound represents 'notfound' message. It is a reply to 'getdata' for the
+// inventory that the node can't provide.
+type MsgNotFound struct {
+	Count     VarInt
+	Inventory []InvVector
+}
+
+// MarshalBinary implements binary.Marshaler interface.
+func (nf MsgNotFound) MarshalBinary() ([]byte, error) {
+	return MsgGetData(nf).MarshalBinary()
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (nf *MsgNotFound) UnmarshalBinary(r io.Reader) error {
+	return (*MsgGetData)(nf).UnmarshalBinary(r)
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (gd *MsgGetData) UnmarshalBinary(r io.Reader) error {
+	d := binary.NewDecoder(r)
+
+	if err := d.Decode(&gd.Count); err != nil {
+		return err
+	}
+
+	gd.Inventory = nil
+	for i := VarInt(0); i < gd.Count; i++ {
+		var v InvVector
+
+		if err := d.Decode(&v); err != nil {
+			return err
+		}
+
+		gd.Inventory = append(gd.Inventory, v)
+	}
+
+	return nil
+}
+
 // MarshalBinary implements binary.Marshaler interface.
 func (gd MsgGetData) MarshalBinary() ([]byte, error) {
 	buf := bytes.NewBuffer([]byte{})
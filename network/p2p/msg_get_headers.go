@@ -1,19 +1,105 @@
 package p2p
 
+import (
+	"bytes"
+	"io"
+
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+)
+
+// MaxBlockLocatorHashes is the maximum number of hashes in a block locator.
+const MaxBlockLocatorHashes = 101
+
+// MsgGetHeader represents 'getheaders' message. The block locator has hashes of blocks from the tip of
+// the sender's chain back to the genesis block, with exponentially bigger steps between them. The peer
+// replies with the headers after the first block in the locator that is on its best chain, up to the
+// stop block (all zeroes to get as many headers as possible).
 type MsgGetHeader struct {
-	Version    uint32
-	HashCount  uint8
-	StartBlock [32]byte
-	StopBlock  [32]byte
+	Version      uint32
+	HashCount    VarInt
+	BlockLocator [][32]byte
+	StopBlock    [32]byte
 }
 
-func NewMsgGetHeader(network string, hashCount uint8, startBlock, stopBlock [32]byte) (*Message, error) {
+// NewMsgGetHeader creates a new 'getheaders' message with the block locator.
+func NewMsgGetHeader(network string, locator [][32]byte, stopBlock [32]byte) (*Message, error) {
 	payload := MsgGetHeader{
-		Version:    Version,
-		HashCount:  hashCount,
-		StartBlock: startBlock,
-		StopBlock:  stopBlock,
+		Version:      Version,
+		HashCount:    VarInt(len(locator)),
+		BlockLocator: locator,
+		StopBlock:    stopBlock,
 	}
 
 	return NewMessage(CmdGetheaders, network, payload)
 }
+
+// MarshalBinary implements binary.Marshaler interface.
+func (gh MsgGetHeader) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+	for _, v := range []interface{}{gh.Version, gh.HashCount} {
+		b, err := binary.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	for _, hash := range gh.BlockLocator {
+		buf.Write(hash[:])
+	}
+	buf.Write(gh.StopBlock[:])
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (gh *MsgGetHeader) UnmarshalBinary(r io.Reader) error {
+	d := binary.NewDecoder(r)
+	if err := d.Decode(&gh.Version); err != nil {
+		return err
+	}
+	if err := d.Decode(&gh.HashCount); err != nil {
+		return err
+	}
+
+	gh.BlockLocator = make([][32]byte, 0, min(gh.HashCount, MaxBlockLocatorHashes))
+	for i := VarInt(0); i < gh.HashCount; i++ {
+		var hash [32]byte
+		if err := d.Decode(&hash); err != nil {
+			return err
+		}
+		gh.BlockLocator = append(gh.BlockLocator, hash)
+	}
+
+	return d.Decode(&gh.StopBlock)
+}
+
+// MsgGetBlocks represents 'getblocks' message. It has the same block locator as 'getheaders', but the
+// peer replies with an 'inv' message with the hashes of the blocks.
+type MsgGetBlocks struct {
+	Version      uint32
+	HashCount    VarInt
+	BlockLocator [][32]byte
+	StopBlock    [32]byte
+}
+
+// NewMsgGetBlocks creates a new 'getblocks' message with the block locator.
+func NewMsgGetBlocks(network string, locator [][32]byte, stopBlock [32]byte) (*Message, error) {
+	payload := MsgGetBlocks{
+		Version:      Version,
+		HashCount:    VarInt(len(locator)),
+		BlockLocator: locator,
+		StopBlock:    stopBlock,
+	}
+
+	return NewMessage(CmdGetblocks, network, payload)
+}
+
+// MarshalBinary implements binary.Marshaler interface.
+func (gb MsgGetBlocks) MarshalBinary() ([]byte, error) {
+	return MsgGetHeader(gb).MarshalBinary()
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (gb *MsgGetBlocks) UnmarshalBinary(r io.Reader) error {
+	return (*MsgGetHeader)(gb).UnmarshalBinary(r)
+}
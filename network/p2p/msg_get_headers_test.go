@@ -0,0 +1,56 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgGetHeader_MarshalAndUnmarshal(t *testing.T) {
+	locator := [][32]byte{{1}, {2}, {3}}
+	msg := MsgGetHeader{Version: Version, HashCount: 3, BlockLocator: locator, StopBlock: [32]byte{9}}
+
+	b, err := binary.Marshal(msg)
+	require.NoError(t, err)
+	// version, the count of the locator hashes, the hashes and the stop hash
+	require.Len(t, b, 4+1+3*32+32)
+	require.Equal(t, byte(3), b[4])
+
+	var actual MsgGetHeader
+	require.NoError(t, binary.NewDecoder(bytes.NewReader(b)).Decode(&actual))
+	require.Equal(t, msg, actual)
+}
+
+func TestMsgGetBlocks_MarshalAndUnmarshal(t *testing.T) {
+	locator := make([][32]byte, 300)
+	for i := range locator {
+		locator[i] = [32]byte{byte(i)}
+	}
+	msg := MsgGetBlocks{Version: Version, HashCount: 300, BlockLocator: locator}
+
+	b, err := binary.Marshal(msg)
+	require.NoError(t, err)
+	// the count is a var int
+	require.Equal(t, []byte{0xFD, 0x2C, 0x01}, b[4:7])
+
+	var actual MsgGetBlocks
+	require.NoError(t, binary.NewDecoder(bytes.NewReader(b)).Decode(&actual))
+	require.Equal(t, msg, actual)
+}
+
+func TestMsgHeaders_MarshalBinary(t *testing.T) {
+	msg := MsgHeaders{Count: 2, BlockHeaders: []BlockHeader{
+		{Version: 1, PrevBlockHash: [32]byte{1}, Bits: 0x1d00ffff},
+		{Version: 1, PrevBlockHash: [32]byte{2}, Bits: 0x1d00ffff},
+	}}
+
+	b, err := binary.Marshal(msg)
+	require.NoError(t, err)
+	require.Len(t, b, 1+2*81)
+
+	var actual MsgHeaders
+	require.NoError(t, actual.UnmarshalBinary(bytes.NewReader(b)))
+	require.Equal(t, msg, actual)
+}
@@ -8,10 +8,15 @@ import (
 
 // MsgInv represents 'inv' message.
 type MsgInv struct {
-	Count     uint8 // TODO: Change to var_int
+	Count     VarInt
 	Inventory []InvVector
 }
 
+// MarshalBinary implements binary.Marshaler interface.
+func (inv MsgInv) MarshalBinary() ([]byte, error) {
+	return MsgGetData(inv).MarshalBinary()
+}
+
 // UnmarshalBinary implements binary.Unmarshaler interface.
 func (inv *MsgInv) UnmarshalBinary(r io.Reader) error {
 	d := binary.NewDecoder(r)
@@ -20,7 +25,8 @@ func (inv *MsgInv) UnmarshalBinary(r io.Reader) error {
 		return err
 	}
 
-	for i := uint8(0); i < inv.Count; i++ {
+	inv.Inventory = nil
+	for i := VarInt(0); i < inv.Count; i++ {
 		var v InvVector
 
 		if err := d.Decode(&v); err != nil {
@@ -33,6 +39,19 @@ func (inv *MsgInv) UnmarshalBinary(r io.Reader) error {
 	return nil
 }
 
+// Inventory vector types.
+const (
+	InvTypeError         = 0
+	InvTypeTx            = 1
+	InvTypeBlock         = 2
+	InvTypeFilteredBlock = 3
+	InvTypeCmpctBlock    = 4
+	InvTypeWtx           = 5
+	InvTypeAncPkgInfo    = 6
+	InvTypeWitnessTx     = 0x40000001
+	InvTypeWitnessBlock  = 0x40000002
+)
+
 // InvVector represents inventory vector.
 type InvVector struct {
 	Type uint32
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+)
+
+// PackageRelayAncestor is the version bit of the ancestor package relay in the sendpackages message (BIP 331).
+const PackageRelayAncestor = 1
+
+// MsgSendPackages represents 'sendpackages' message. It is sent before verack to negotiate the package
+// relay versions that the node supports as a bit field.
+type MsgSendPackages struct {
+	Versions uint64
+}
+
+// NewSendPackagesMsg returns a new 'sendpackages' message for the versions.
+func NewSendPackagesMsg(network string, versions uint64) (*Message, error) {
+	return NewMessage(CmdSendpackages, network, MsgSendPackages{Versions: versions})
+}
+
+// MsgAncPkgInfo represents 'ancpkginfo' message. It is the reply to getdata with MSG_ANCPKGINFO and holds
+// the wtxids of the unconfirmed ancestors of a transaction, sorted topologically, with the transaction
+// itself last.
+type MsgAncPkgInfo struct {
+	Count  VarInt
+	WTxIDs [][32]byte
+}
+
+// MarshalBinary implements binary.Marshaler interface.
+func (ai MsgAncPkgInfo) MarshalBinary() ([]byte, error) {
+	return marshalWTxIDs(ai.Count, ai.WTxIDs)
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (ai *MsgAncPkgInfo) UnmarshalBinary(r io.Reader) error {
+	return unmarshalWTxIDs(r, &ai.Count, &ai.WTxIDs)
+}
+
+// MsgGetPkgTxns represents 'getpkgtxns' message. It requests the transactions of a package by their wtxids.
+type MsgGetPkgTxns struct {
+	Count  VarInt
+	WTxIDs [][32]byte
+}
+
+// MarshalBinary implements binary.Marshaler interface.
+func (gp MsgGetPkgTxns) MarshalBinary() ([]byte, error) {
+	return marshalWTxIDs(gp.Count, gp.WTxIDs)
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (gp *MsgGetPkgTxns) UnmarshalBinary(r io.Reader) error {
+	return unmarshalWTxIDs(r, &gp.Count, &gp.WTxIDs)
+}
+
+// MsgPkgTxns represents 'pkgtxns' message. It is the reply to getpkgtxns with the requested transactions.
+type MsgPkgTxns struct {
+	Count VarInt
+	Txs   []MsgTx
+}
+
+// MarshalBinary implements binary.Marshaler interface.
+func (pt MsgPkgTxns) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+
+	b, err := binary.Marshal(pt.Count)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	for _, tx := range pt.Txs {
+		if b, err = tx.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements binary.Unmarshaler interface.
+func (pt *MsgPkgTxns) UnmarshalBinary(r io.Reader) error {
+	if err := binary.NewDecoder(r).Decode(&pt.Count); err != nil {
+		return err
+	}
+
+	pt.Txs = nil
+	for i := VarInt(0); i < pt.Count; i++ {
+		var tx MsgTx
+		if err := tx.UnmarshalBinary(r); err != nil {
+			return err
+		}
+
+		pt.Txs = append(pt.Txs, tx)
+	}
+
+	return nil
+}
+
+func marshalWTxIDs(count VarInt, wtxids [][32]byte) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+
+	b, err := binary.Marshal(count)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	for _, wtxid := range wtxids {
+		buf.Write(wtxid[:])
+	}
+
+	return buf.Bytes(), nil
+}
+
+func unmarshalWTxIDs(r io.Reader, count *VarInt, wtxids *[][32]byte) error {
+	if err := binary.NewDecoder(r).Decode(count); err != nil {
+		return err
+	}
+
+	*wtxids = nil
+	for i := VarInt(0); i < *count; i++ {
+		var wtxid [32]byte
+		if _, err := io.ReadFull(r, wtxid[:]); err != nil {
+			return err
+		}
+
+		*wtxids = append(*wtxids, wtxid)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgAncPkgInfo_MarshalAndUnmarshal(t *testing.T) {
+	msg := MsgAncPkgInfo{Count: 2, WTxIDs: [][32]byte{{1}, {2}}}
+
+	b, err := binary.Marshal(msg)
+	require.NoError(t, err)
+	// the count of the wtxids and the wtxids
+	require.Len(t, b, 1+2*32)
+
+	var actual MsgAncPkgInfo
+	require.NoError(t, binary.NewDecoder(bytes.NewReader(b)).Decode(&actual))
+	require.Equal(t, msg, actual)
+
+	var getPkg MsgGetPkgTxns
+	require.NoError(t, binary.NewDecoder(bytes.NewReader(b)).Decode(&getPkg))
+	require.Equal(t, MsgGetPkgTxns(msg), getPkg)
+}
+
+func TestMsgPkgTxns_MarshalAndUnmarshal(t *testing.T) {
+	legacy := MsgTx{Version: 1, TxInCount: 1, TxIn: []TxInput{{PreviousOutput: OutPoint{Hash: [32]byte{1}}, Sequence: 0xffffffff}},
+		TxOutCount: 1, TxOut: []TxOutput{{Value: 1000, PkScriptLength: 1, PkScript: []byte{0x51}}}}
+	segwit := legacy
+	segwit.Flag = 1
+	segwit.TxWitness = []TxWitnessData{{Count: 1, Witness: []TxWitness{{Length: 1, Data: []byte{7}}}}}
+	msg := MsgPkgTxns{Count: 2, Txs: []MsgTx{legacy, segwit}}
+
+	b, err := binary.Marshal(msg)
+	require.NoError(t, err)
+
+	var actual MsgPkgTxns
+	require.NoError(t, binary.NewDecoder(bytes.NewReader(b)).Decode(&actual))
+	require.Equal(t, msg, actual)
+}
+
+func TestMsgSendPackages_Marshal(t *testing.T) {
+	b, err := binary.Marshal(MsgSendPackages{Versions: PackageRelayAncestor})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0}, b)
+}
@@ -2,11 +2,14 @@ package p2p
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"github.com/EmilGeorgiev/btc-node/network/binary"
 	"io"
 )
 
+// MsgTx represents a Bitcoin transaction. When Flag is set the transaction is serialized
+// in the segwit format and TxWitness holds one witness stack for every input.
 type MsgTx struct {
 	Version    int32
 	Flag       uint16
@@ -14,10 +17,71 @@ type MsgTx struct {
 	TxIn       []TxInput
 	TxOutCount VarInt
 	TxOut      []TxOutput
-	TxWitness  TxWitnessData
+	TxWitness  []TxWitnessData
 	LockTime   uint32
 }
 
+// MarshalBinary implements binary.Marshaler. The transaction is serialized in the
+// segwit format when the Flag is set, otherwise in the legacy one.
+func (tx MsgTx) MarshalBinary() ([]byte, error) {
+	return tx.serialize(tx.Flag != 0)
+}
+
+func (tx MsgTx) serialize(withWitness bool) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+
+	b, err := binary.Marshal(tx.Version)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	if withWitness {
+		buf.Write([]byte{0x00, byte(tx.Flag)})
+	}
+
+	b, err = binary.Marshal(tx.TxInCount)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	for _, txin := range tx.TxIn {
+		if b, err = binary.Marshal(txin); err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	if b, err = binary.Marshal(tx.TxOutCount); err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	for _, txout := range tx.TxOut {
+		if b, err = binary.Marshal(txout); err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	if withWitness {
+		for _, w := range tx.TxWitness {
+			if b, err = binary.Marshal(w); err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+		}
+	}
+
+	if b, err = binary.Marshal(tx.LockTime); err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	return buf.Bytes(), nil
+}
+
 // UnmarshalBinary implements binary.Unmarshaler
 func (tx *MsgTx) UnmarshalBinary(r io.Reader) error {
 	d := binary.NewDecoder(r)
@@ -32,21 +96,22 @@ func (tx *MsgTx) UnmarshalBinary(r io.Reader) error {
 	}
 
 	if flagPrefix == 0 {
+		// segwit marker, the next byte is the flag
 		var flag byte
 		if err := d.Decode(&flag); err != nil {
 			return err
 		}
-
-		r := bytes.NewBuffer([]byte{flagPrefix, flag})
-		if err := binary.NewDecoder(r).Decode(&tx.Flag); err != nil {
-			return err
-		}
+		tx.Flag = uint16(flag)
 
 		if err := d.Decode(&tx.TxInCount); err != nil {
 			return err
 		}
 	} else {
-		tx.TxInCount = VarInt(flagPrefix)
+		// the byte that was read is the first one of the inputs count
+		prefixed := io.MultiReader(bytes.NewReader([]byte{flagPrefix}), r)
+		if err := binary.NewDecoder(prefixed).Decode(&tx.TxInCount); err != nil {
+			return err
+		}
 	}
 
 	for i := VarInt(0); i < tx.TxInCount; i++ {
@@ -73,9 +138,14 @@ func (tx *MsgTx) UnmarshalBinary(r io.Reader) error {
 		tx.TxOut = append(tx.TxOut, txout)
 	}
 
-	if tx.Flag == 1 {
-		if err := d.Decode(&tx.TxWitness); err != nil {
-			return err
+	if tx.Flag != 0 {
+		for i := VarInt(0); i < tx.TxInCount; i++ {
+			var w TxWitnessData
+			if err := d.Decode(&w); err != nil {
+				return err
+			}
+
+			tx.TxWitness = append(tx.TxWitness, w)
 		}
 	}
 
@@ -101,12 +171,32 @@ type TxOutput struct {
 	PkScript       []byte
 }
 
-// TxWitnessData represents transaction witness data.
+// TxWitnessData represents the witness stack of a single transaction input.
 type TxWitnessData struct {
 	Count   VarInt
 	Witness []TxWitness
 }
 
+// MarshalBinary implements binary.Marshaler interface.
+func (txw TxWitnessData) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{})
+
+	b, err := binary.Marshal(txw.Count)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(b)
+
+	for _, w := range txw.Witness {
+		if b, err = binary.Marshal(w); err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // TxWitness represents a component of transaction witness data.
 type TxWitness struct {
 	Length VarInt
@@ -221,3 +311,48 @@ func (txout *TxOutput) UnmarshalBinary(r io.Reader) error {
 
 	return nil
 }
+
+// TxHash returns the transaction id: the double SHA-256 hash of the transaction serialized without witness data.
+func (tx MsgTx) TxHash() [32]byte {
+	b, _ := tx.serialize(false)
+	return doubleHash(b)
+}
+
+// WTxHash returns the witness transaction id (BIP 141). It is equal to TxHash for transactions without witness.
+func (tx MsgTx) WTxHash() [32]byte {
+	b, _ := tx.serialize(tx.Flag != 0)
+	return doubleHash(b)
+}
+
+// Weight returns the weight of the transaction (BIP 141): the size without the witness data multiplied
+// by 3 plus the size with it.
+func (tx MsgTx) Weight() int64 {
+	total, _ := tx.serialize(tx.Flag != 0)
+	return int64(tx.BaseSize()*3 + len(total))
+}
+
+// BaseSize returns the size of the transaction serialized without the witness data.
+func (tx MsgTx) BaseSize() int {
+	b, _ := tx.serialize(false)
+	return len(b)
+}
+
+// VSize returns the virtual size of the transaction, its weight divided by 4 and rounded up.
+func (tx MsgTx) VSize() int64 {
+	return (tx.Weight() + 3) / 4
+}
+
+// IsCoinBase returns true when the transaction is a coinbase, the first transaction in a block
+// that has a single input that doesn't spend an existing output.
+func (tx MsgTx) IsCoinBase() bool {
+	if len(tx.TxIn) != 1 {
+		return false
+	}
+	prev := tx.TxIn[0].PreviousOutput
+	return prev.Hash == [32]byte{} && prev.Index == 0xffffffff
+}
+
+func doubleHash(b []byte) [32]byte {
+	firstHash := sha256.Sum256(b)
+	return sha256.Sum256(firstHash[:])
+}
@@ -3,11 +3,18 @@ package p2p_test
 import (
 	"bytes"
 	"encoding/hex"
+	"github.com/EmilGeorgiev/btc-node/network/binary"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
 )
 
+var (
+	legacyTx = "0100000001317c144ae5b5a224370bd68c928b9f9e152d9829235ffbecec5ee64113662fc4000000006a47304402203c6ef3cba423365b37c031d235a674a10cf06b14fccda68bb5c35cbda5a2969b02207da3f69ea61c4a98eb488dac9d8a421dda9000e8afdc4a90cc2ebf93fbefb84f012102e248c2b8e9a5b78f2406c60b75ef1c4e88a06c7c36ad31e009db256505e27e79ffffffff0388270c00000000001976a914fe46ec55e937e584005b337495d76464b6b1cdba88ac22020000000000001976a914bdcccc7ce08a732ce55dcc3c1d8890e372bf7c1d88ac0000000000000000166a146f6d6e69000000000000001f0000886c98b7600000000000"
+	segwitTx = "0100000000010145b87f940bc57475403a3928ecf4cb3b86d2ba192039d4d703126edad14487ca0100000000ffffffff0200093d000000000017a91469f375f23b3d5d37bd942f3c31d7ae5a0cb61f5e87c8db030000000000220020701a8d401c84fb13e6baf169d59684e17abd9fa216c8cc5b9fc63d622ff8c58d0400473044022025863cfe71648bc8703f9f0607558cb7e79fcbebadc080ef1f0d7bfdd6ab1afa0220101ffaeb01b70e3360e87d6b3616886e547593e41c8a09d00cf8803601a9cc7901473044022031caba2ba6b079bc0d995e04f3651f977b5fc22dacceab1046a311fa2fb83898022030f5a852b425bdeb156be3a0a3de5bbc764302fbc1b7ca77058740cd511d49a9016952210375e00eb72e29da82b89367947f29ef34afb75e8654f6ea368e0acdfd92976b7c2103a1b26313f430c4b15bb1fdce663207659d8cac749a0e53d70eff01874496feff2103c96d495bfdd5ba4145e3e046fee45e84a8a48ad05bd8dbb395c011a32cf9f88053ae00000000"
+)
+
 func TestMsgTxUnmarshalBinary(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -16,7 +23,7 @@ func TestMsgTxUnmarshalBinary(t *testing.T) {
 		err      error
 	}{
 		{name: "legacy",
-			input: "0100000001317c144ae5b5a224370bd68c928b9f9e152d9829235ffbecec5ee64113662fc4000000006a47304402203c6ef3cba423365b37c031d235a674a10cf06b14fccda68bb5c35cbda5a2969b02207da3f69ea61c4a98eb488dac9d8a421dda9000e8afdc4a90cc2ebf93fbefb84f012102e248c2b8e9a5b78f2406c60b75ef1c4e88a06c7c36ad31e009db256505e27e79ffffffff0388270c00000000001976a914fe46ec55e937e584005b337495d76464b6b1cdba88ac22020000000000001976a914bdcccc7ce08a732ce55dcc3c1d8890e372bf7c1d88ac0000000000000000166a146f6d6e69000000000000001f0000886c98b7600000000000",
+			input: legacyTx,
 			expected: &p2p.MsgTx{
 				Version:   1,
 				Flag:      0,
@@ -60,11 +67,10 @@ func TestMsgTxUnmarshalBinary(t *testing.T) {
 						},
 					},
 				},
-				TxWitness: p2p.TxWitnessData{Count: 0, Witness: nil},
-				LockTime:  0},
+				LockTime: 0},
 			err: nil},
 		{name: "segwit",
-			input: "0100000000010145b87f940bc57475403a3928ecf4cb3b86d2ba192039d4d703126edad14487ca0100000000ffffffff0200093d000000000017a91469f375f23b3d5d37bd942f3c31d7ae5a0cb61f5e87c8db030000000000220020701a8d401c84fb13e6baf169d59684e17abd9fa216c8cc5b9fc63d622ff8c58d0400473044022025863cfe71648bc8703f9f0607558cb7e79fcbebadc080ef1f0d7bfdd6ab1afa0220101ffaeb01b70e3360e87d6b3616886e547593e41c8a09d00cf8803601a9cc7901473044022031caba2ba6b079bc0d995e04f3651f977b5fc22dacceab1046a311fa2fb83898022030f5a852b425bdeb156be3a0a3de5bbc764302fbc1b7ca77058740cd511d49a9016952210375e00eb72e29da82b89367947f29ef34afb75e8654f6ea368e0acdfd92976b7c2103a1b26313f430c4b15bb1fdce663207659d8cac749a0e53d70eff01874496feff2103c96d495bfdd5ba4145e3e046fee45e84a8a48ad05bd8dbb395c011a32cf9f88053ae00000000",
+			input: segwitTx,
 			expected: &p2p.MsgTx{
 				Version:   1,
 				Flag:      1,
@@ -94,40 +100,42 @@ func TestMsgTxUnmarshalBinary(t *testing.T) {
 						},
 					},
 				},
-				TxWitness: p2p.TxWitnessData{
-					Count: 0x04,
-					Witness: []p2p.TxWitness{
-						{},
-						{
-							Length: 0x47,
-							Data: []uint8{
-								0x30, 0x44, 0x02, 0x20, 0x25, 0x86, 0x3c, 0xfe, 0x71, 0x64, 0x8b, 0xc8, 0x70, 0x3f, 0x9f, 0x06,
-								0x07, 0x55, 0x8c, 0xb7, 0xe7, 0x9f, 0xcb, 0xeb, 0xad, 0xc0, 0x80, 0xef, 0x1f, 0x0d, 0x7b, 0xfd,
-								0xd6, 0xab, 0x1a, 0xfa, 0x02, 0x20, 0x10, 0x1f, 0xfa, 0xeb, 0x01, 0xb7, 0x0e, 0x33, 0x60, 0xe8,
-								0x7d, 0x6b, 0x36, 0x16, 0x88, 0x6e, 0x54, 0x75, 0x93, 0xe4, 0x1c, 0x8a, 0x09, 0xd0, 0x0c, 0xf8,
-								0x80, 0x36, 0x01, 0xa9, 0xcc, 0x79, 0x01,
+				TxWitness: []p2p.TxWitnessData{
+					{
+						Count: 0x04,
+						Witness: []p2p.TxWitness{
+							{},
+							{
+								Length: 0x47,
+								Data: []uint8{
+									0x30, 0x44, 0x02, 0x20, 0x25, 0x86, 0x3c, 0xfe, 0x71, 0x64, 0x8b, 0xc8, 0x70, 0x3f, 0x9f, 0x06,
+									0x07, 0x55, 0x8c, 0xb7, 0xe7, 0x9f, 0xcb, 0xeb, 0xad, 0xc0, 0x80, 0xef, 0x1f, 0x0d, 0x7b, 0xfd,
+									0xd6, 0xab, 0x1a, 0xfa, 0x02, 0x20, 0x10, 0x1f, 0xfa, 0xeb, 0x01, 0xb7, 0x0e, 0x33, 0x60, 0xe8,
+									0x7d, 0x6b, 0x36, 0x16, 0x88, 0x6e, 0x54, 0x75, 0x93, 0xe4, 0x1c, 0x8a, 0x09, 0xd0, 0x0c, 0xf8,
+									0x80, 0x36, 0x01, 0xa9, 0xcc, 0x79, 0x01,
+								},
 							},
-						},
-						{
-							Length: 0x47,
-							Data: []byte{
-								0x30, 0x44, 0x02, 0x20, 0x31, 0xca, 0xba, 0x2b, 0xa6, 0xb0, 0x79, 0xbc, 0x0d, 0x99, 0x5e, 0x04,
-								0xf3, 0x65, 0x1f, 0x97, 0x7b, 0x5f, 0xc2, 0x2d, 0xac, 0xce, 0xab, 0x10, 0x46, 0xa3, 0x11, 0xfa,
-								0x2f, 0xb8, 0x38, 0x98, 0x02, 0x20, 0x30, 0xf5, 0xa8, 0x52, 0xb4, 0x25, 0xbd, 0xeb, 0x15, 0x6b,
-								0xe3, 0xa0, 0xa3, 0xde, 0x5b, 0xbc, 0x76, 0x43, 0x02, 0xfb, 0xc1, 0xb7, 0xca, 0x77, 0x05, 0x87,
-								0x40, 0xcd, 0x51, 0x1d, 0x49, 0xa9, 0x01,
+							{
+								Length: 0x47,
+								Data: []byte{
+									0x30, 0x44, 0x02, 0x20, 0x31, 0xca, 0xba, 0x2b, 0xa6, 0xb0, 0x79, 0xbc, 0x0d, 0x99, 0x5e, 0x04,
+									0xf3, 0x65, 0x1f, 0x97, 0x7b, 0x5f, 0xc2, 0x2d, 0xac, 0xce, 0xab, 0x10, 0x46, 0xa3, 0x11, 0xfa,
+									0x2f, 0xb8, 0x38, 0x98, 0x02, 0x20, 0x30, 0xf5, 0xa8, 0x52, 0xb4, 0x25, 0xbd, 0xeb, 0x15, 0x6b,
+									0xe3, 0xa0, 0xa3, 0xde, 0x5b, 0xbc, 0x76, 0x43, 0x02, 0xfb, 0xc1, 0xb7, 0xca, 0x77, 0x05, 0x87,
+									0x40, 0xcd, 0x51, 0x1d, 0x49, 0xa9, 0x01,
+								},
 							},
-						},
-						{
-							Length: 0x69,
-							Data: []uint8{
-								0x52, 0x21, 0x03, 0x75, 0xe0, 0x0e, 0xb7, 0x2e, 0x29, 0xda, 0x82, 0xb8, 0x93, 0x67, 0x94, 0x7f,
-								0x29, 0xef, 0x34, 0xaf, 0xb7, 0x5e, 0x86, 0x54, 0xf6, 0xea, 0x36, 0x8e, 0x0a, 0xcd, 0xfd, 0x92,
-								0x97, 0x6b, 0x7c, 0x21, 0x03, 0xa1, 0xb2, 0x63, 0x13, 0xf4, 0x30, 0xc4, 0xb1, 0x5b, 0xb1, 0xfd,
-								0xce, 0x66, 0x32, 0x07, 0x65, 0x9d, 0x8c, 0xac, 0x74, 0x9a, 0x0e, 0x53, 0xd7, 0x0e, 0xff, 0x01,
-								0x87, 0x44, 0x96, 0xfe, 0xff, 0x21, 0x03, 0xc9, 0x6d, 0x49, 0x5b, 0xfd, 0xd5, 0xba, 0x41, 0x45,
-								0xe3, 0xe0, 0x46, 0xfe, 0xe4, 0x5e, 0x84, 0xa8, 0xa4, 0x8a, 0xd0, 0x5b, 0xd8, 0xdb, 0xb3, 0x95,
-								0xc0, 0x11, 0xa3, 0x2c, 0xf9, 0xf8, 0x80, 0x53, 0xae,
+							{
+								Length: 0x69,
+								Data: []uint8{
+									0x52, 0x21, 0x03, 0x75, 0xe0, 0x0e, 0xb7, 0x2e, 0x29, 0xda, 0x82, 0xb8, 0x93, 0x67, 0x94, 0x7f,
+									0x29, 0xef, 0x34, 0xaf, 0xb7, 0x5e, 0x86, 0x54, 0xf6, 0xea, 0x36, 0x8e, 0x0a, 0xcd, 0xfd, 0x92,
+									0x97, 0x6b, 0x7c, 0x21, 0x03, 0xa1, 0xb2, 0x63, 0x13, 0xf4, 0x30, 0xc4, 0xb1, 0x5b, 0xb1, 0xfd,
+									0xce, 0x66, 0x32, 0x07, 0x65, 0x9d, 0x8c, 0xac, 0x74, 0x9a, 0x0e, 0x53, 0xd7, 0x0e, 0xff, 0x01,
+									0x87, 0x44, 0x96, 0xfe, 0xff, 0x21, 0x03, 0xc9, 0x6d, 0x49, 0x5b, 0xfd, 0xd5, 0xba, 0x41, 0x45,
+									0xe3, 0xe0, 0x46, 0xfe, 0xe4, 0x5e, 0x84, 0xa8, 0xa4, 0x8a, 0xd0, 0x5b, 0xd8, 0xdb, 0xb3, 0x95,
+									0xc0, 0x11, 0xa3, 0x2c, 0xf9, 0xf8, 0x80, 0x53, 0xae,
+								},
 							},
 						},
 					},
@@ -167,3 +175,81 @@ func TestMsgTxUnmarshalBinary(t *testing.T) {
 	}
 
 }
+
+func TestMsgTxMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "legacy", input: legacyTx},
+		{name: "segwit", input: segwitTx},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			input, err := hex.DecodeString(test.input)
+			require.NoError(tt, err)
+
+			tx := p2p.MsgTx{}
+			require.NoError(tt, tx.UnmarshalBinary(bytes.NewBuffer(input)))
+
+			actual, err := binary.Marshal(tx)
+			require.NoError(tt, err)
+			require.Equal(tt, input, actual)
+		})
+	}
+}
+
+func TestMsgTx_TxHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		txid  string
+		wtxid string
+	}{
+		{
+			name:  "segwit",
+			input: segwitTx,
+			txid:  "5e8c9bf29fceeac03d78ea889d31c9e037a0495f32b2119e4e82050d0f314f4c",
+			wtxid: "3a73d57fe08989efbe32f94cf16b991a158ae9ee27ddaff6720190140a269b32",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			input, err := hex.DecodeString(test.input)
+			require.NoError(tt, err)
+
+			tx := p2p.MsgTx{}
+			require.NoError(tt, tx.UnmarshalBinary(bytes.NewBuffer(input)))
+
+			require.Equal(tt, test.txid, hex.EncodeToString(p2p.Reverse(tx.TxHash())))
+			require.Equal(tt, test.wtxid, hex.EncodeToString(p2p.Reverse(tx.WTxHash())))
+		})
+	}
+}
+
+func TestMsgTx_WeightAndVSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		weight int64
+		vsize  int64
+	}{
+		{name: "legacy", input: legacyTx, weight: 4 * 256, vsize: 256},
+		{name: "segwit", input: segwitTx, weight: 758, vsize: 190},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			input, err := hex.DecodeString(test.input)
+			require.NoError(tt, err)
+
+			tx := p2p.MsgTx{}
+			require.NoError(tt, tx.UnmarshalBinary(bytes.NewBuffer(input)))
+
+			require.Equal(tt, test.weight, tx.Weight())
+			require.Equal(tt, test.vsize, tx.VSize())
+		})
+	}
+}
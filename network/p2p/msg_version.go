@@ -18,11 +18,12 @@ type MsgVersion struct {
 	Relay       bool
 }
 
-// NewVersionMsg returns a new MsgVersion.
-func NewVersionMsg(network, userAgent string, peerIP IPv4, peerPort uint16) (*Message, error) {
+// NewVersionMsg returns a new MsgVersion. The services are the bit field of features
+// that the node supports (SrvNodeNetwork, SrvNodeNetworkLimited, ...).
+func NewVersionMsg(network, userAgent string, services uint64, peerIP IPv4, peerPort uint16) (*Message, error) {
 	payload := MsgVersion{
 		Version:   Version,
-		Services:  1,
+		Services:  services,
 		Timestamp: time.Now().UTC().Unix(),
 		AddrRecv: NetAddr{
 			Services: 1,
@@ -30,7 +31,7 @@ func NewVersionMsg(network, userAgent string, peerIP IPv4, peerPort uint16) (*Me
 			Port:     binary.PortNumber(peerPort),
 		},
 		AddrFrom: NetAddr{
-			Services: 1,
+			Services: services,
 			IP:       *NewIPv4(127, 0, 0, 1),
 			Port:     9333,
 		},
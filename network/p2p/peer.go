@@ -12,6 +12,15 @@ type Peer struct {
 	Services   uint64
 	UserAgent  string
 	Version    int32
+	// StartHeight is the height of the peer's best block when the connection is established.
+	StartHeight int32
+	// WTxIDRelay is true when the peer announces the transactions by their wtxid (BIP 339).
+	WTxIDRelay bool
+	// PackageRelay is true when the peer relays the ancestor packages of the transactions (BIP 331).
+	PackageRelay bool
+	// Relay is the relay flag of the peer's version message, it is false when the peer asks not to be sent
+	// transactions (BIP 37).
+	Relay bool
 }
 
 // ID returns peer ID.
@@ -0,0 +1,691 @@
+package node
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+const (
+	// maxQueuedBlocks is the maximum number of blocks of the header chain that are waiting to be requested.
+	maxQueuedBlocks = 2000
+	// blockDownloadWindow is the number of blocks after the tip that can be requested, so the blocks that
+	// wait in the buffer for a slow block before them are limited, like in Bitcoin Core.
+	blockDownloadWindow = 1024
+	// maxPeerStalls is the number of the requested blocks in a row that a peer doesn't deliver in time
+	// before it is disconnected.
+	maxPeerStalls = 3
+	// maxBlockProcessAttempts is the number of times that a block is downloaded and fails to be processed
+	// before the download stops.
+	maxBlockProcessAttempts = 3
+)
+
+// ErrPeerStalling is the reason for disconnecting a peer that doesn't deliver the requested blocks.
+var ErrPeerStalling = errors.New("the peer stalls the block download")
+
+type peerBlock struct {
+	peer  string
+	block *p2p.MsgBlock
+}
+
+type peerNotFound struct {
+	peer   string
+	hashes [][32]byte
+}
+
+type peerEvent struct {
+	peer       string
+	out        chan<- *p2p.Message
+	disconnect func(error)
+	// remove is true when the peer is disconnected
+	remove bool
+}
+
+// downloadPeer is a peer that participates in the block download.
+type downloadPeer struct {
+	out        chan<- *p2p.Message
+	disconnect func(error)
+	inFlight   map[[32]byte]struct{}
+	// stalls is the number of the requested blocks in a row that are not delivered in time, it is reset
+	// when the peer delivers a block
+	stalls int
+}
+
+// blockRequest is a requested block that is not received yet.
+type blockRequest struct {
+	peer string
+	at   time.Time
+}
+
+// BlockDownloader downloads the blocks of the stored header chain from all connected peers. Every peer has
+// at most "window" requested blocks that are not received yet. The blocks can arrive in any order, they are
+// buffered and processed in the order of the chain. A block that is not received before the timeout or that
+// the peer doesn't have (notfound) is requested from another peer. The peers that don't deliver the requested
+// blocks several times in a row are disconnected.
+//
+// The peer that sends an invalid block is disconnected and the block is requested from another peer, because
+// the peer could have changed its transactions. A block that fails to be processed several times is invalid
+// or can't be saved, then the download stops, because the blocks after it can't be processed either. It
+// continues when a block is processed, or when the header chain switches to a branch without the block.
+//
+// When the best header chain switches to a branch that forks below the queued blocks, the blocks of the old
+// branch are dropped and the download continues after the fork. The processed blocks after the fork are
+// rolled back.
+type BlockDownloader struct {
+	network          string
+	headerRepository sync.HeaderRepository
+	chainTip         sync.ChainTip
+	processor        BlockProcessor
+	rewinder         ChainRewinder
+	window           int
+	timeout          time.Duration
+
+	blocks        chan peerBlock
+	notFound      chan peerNotFound
+	peerEvents    chan peerEvent
+	headersStored chan struct{}
+	stop          chan struct{}
+	done          chan struct{}
+	isStarted     atomic.Bool
+
+	// the state below is owned by the download goroutine
+	peers map[string]*downloadPeer
+	// queue has the hashes of the blocks that are not requested yet in the order of the chain
+	queue [][32]byte
+	// order is the position in the chain of every queued or requested block
+	order map[[32]byte]uint64
+	// requested maps the hashes of the requested blocks to the requests
+	requested map[[32]byte]blockRequest
+	// failed has the peers that didn't deliver a block, it is requested from the other peers
+	failed map[[32]byte]map[string]struct{}
+	// buffer has the received blocks that are not processed yet and their peers by the previous block hash
+	buffer map[[32]byte]peerBlock
+	// attempts is the number of times that a block failed to be processed
+	attempts map[[32]byte]int
+	// halted is true when a block fails to be processed too many times
+	halted bool
+	// lastQueued is the hash of the last block that is added to the queue
+	lastQueued [32]byte
+	nextOrder  uint64
+	// tip is the hash of the last processed block
+	tip [32]byte
+	// windowStart is the order of the block after the tip, only the blocks in the blockDownloadWindow after
+	// it are requested
+	windowStart uint64
+
+	// inFlight has the number of the requested blocks of every peer, it is updated by the download goroutine
+	// after every event
+	inFlight atomic.Pointer[map[string]int]
+}
+
+// NewBlockDownloader creates a new BlockDownloader. The download starts after the block that is returned by
+// the chain tip and follows the best header chain of the header repository. A requested block that is not
+// received before the timeout is requested again. The rewinder can be nil, then the download stops when the
+// header chain forks below the tip.
+func NewBlockDownloader(n string, hr sync.HeaderRepository, tip sync.ChainTip, p BlockProcessor, rw ChainRewinder, window int,
+	timeout time.Duration) *BlockDownloader {
+	return &BlockDownloader{
+		network:          n,
+		headerRepository: hr,
+		chainTip:         tip,
+		processor:        p,
+		rewinder:         rw,
+		window:           window,
+		timeout:          timeout,
+		blocks:           make(chan peerBlock, 1000),
+		notFound:         make(chan peerNotFound, 1000),
+		peerEvents:       make(chan peerEvent, 1000),
+		headersStored:    make(chan struct{}, 1),
+		stop:             make(chan struct{}, 1000),
+		done:             make(chan struct{}, 1000),
+		peers:            make(map[string]*downloadPeer),
+		order:            make(map[[32]byte]uint64),
+		requested:        make(map[[32]byte]blockRequest),
+		failed:           make(map[[32]byte]map[string]struct{}),
+		buffer:           make(map[[32]byte]peerBlock),
+		attempts:         make(map[[32]byte]int),
+	}
+}
+
+func (bd *BlockDownloader) Start() {
+	if bd.isStarted.Load() {
+		log.Println("BlockDownloader is already started.")
+		return
+	}
+
+	tip, err := bd.chainTip.SyncFrom()
+	if err != nil {
+		log.Printf("failed to get the chain tip, the download starts from the genesis block: %s\n", err)
+		tip = sync.GenesisBlockHash
+	}
+	bd.tip, bd.lastQueued = tip, tip
+
+	bd.isStarted.Store(true)
+	go bd.download()
+	log.Println("Start BlockDownloader.")
+}
+
+func (bd *BlockDownloader) Stop() {
+	if !bd.isStarted.Load() {
+		log.Println("BlockDownloader is not started and can't be stopped.")
+		return
+	}
+	bd.isStarted.Store(false)
+	bd.stop <- struct{}{}
+	<-bd.done
+	log.Println("Stop BlockDownloader")
+}
+
+// AddPeer adds a peer to the download. The requests for blocks are sent to its outgoing messages. The
+// disconnect function is called when the peer stalls the download, it can be nil.
+func (bd *BlockDownloader) AddPeer(addr string, out chan<- *p2p.Message, disconnect func(error)) {
+	bd.peerEvents <- peerEvent{peer: addr, out: out, disconnect: disconnect}
+}
+
+// RemovePeer removes the peer from the download, the blocks that are requested from it are requested
+// from the other peers.
+func (bd *BlockDownloader) RemovePeer(addr string) {
+	bd.peerEvents <- peerEvent{peer: addr, remove: true}
+}
+
+// ReceiveBlock passes a block that is received from the peer to the download.
+func (bd *BlockDownloader) ReceiveBlock(addr string, block *p2p.MsgBlock) {
+	bd.blocks <- peerBlock{peer: addr, block: block}
+}
+
+// NotFound passes the hashes of the blocks that the peer doesn't have to the download.
+func (bd *BlockDownloader) NotFound(addr string, hashes [][32]byte) {
+	bd.notFound <- peerNotFound{peer: addr, hashes: hashes}
+}
+
+// BlocksInFlight returns the number of the blocks that are requested from the peer and are not received yet.
+func (bd *BlockDownloader) BlocksInFlight(addr string) int {
+	if counts := bd.inFlight.Load(); counts != nil {
+		return (*counts)[addr]
+	}
+	return 0
+}
+
+// HeadersStored notifies the download that new headers are stored.
+func (bd *BlockDownloader) HeadersStored() {
+	select {
+	case bd.headersStored <- struct{}{}:
+	default:
+		// a notification is already waiting
+	}
+}
+
+func (bd *BlockDownloader) download() {
+	ticker := time.NewTicker(bd.timeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-bd.stop:
+			bd.done <- struct{}{}
+			return
+		case e := <-bd.peerEvents:
+			if e.remove {
+				bd.removePeer(e.peer)
+			} else if _, ok := bd.peers[e.peer]; !ok {
+				bd.peers[e.peer] = &downloadPeer{out: e.out, disconnect: e.disconnect, inFlight: make(map[[32]byte]struct{})}
+			}
+		case pb := <-bd.blocks:
+			bd.receiveBlock(pb)
+		case nf := <-bd.notFound:
+			bd.receiveNotFound(nf)
+		case now := <-ticker.C:
+			bd.checkStalls(now)
+		case <-bd.headersStored:
+		}
+		bd.schedule()
+
+		counts := make(map[string]int, len(bd.peers))
+		for addr, p := range bd.peers {
+			counts[addr] = len(p.inFlight)
+		}
+		bd.inFlight.Store(&counts)
+	}
+}
+
+// receiveNotFound requests from the other peers the blocks that the peer doesn't have.
+func (bd *BlockDownloader) receiveNotFound(nf peerNotFound) {
+	var hashes [][32]byte
+	for _, hash := range nf.hashes {
+		if req, ok := bd.requested[hash]; ok && req.peer == nf.peer {
+			bd.cancelRequest(hash, req)
+			hashes = append(hashes, hash)
+		}
+	}
+	if len(hashes) > 0 {
+		log.Printf("peer %s doesn't have %d of the requested blocks\n", nf.peer, len(hashes))
+	}
+	bd.requeue(hashes)
+}
+
+// checkStalls requests from the other peers the blocks that are not received before the timeout. Every block
+// that is not received counts as a stall of its peer, and the peers that stall maxPeerStalls blocks in a row
+// are disconnected.
+func (bd *BlockDownloader) checkStalls(now time.Time) {
+	var hashes [][32]byte
+	stalling := make(map[string]int)
+	for hash, req := range bd.requested {
+		if now.Sub(req.at) < bd.timeout {
+			continue
+		}
+		bd.cancelRequest(hash, req)
+		hashes = append(hashes, hash)
+		stalling[req.peer]++
+	}
+	bd.requeue(hashes)
+
+	for addr, n := range stalling {
+		p, ok := bd.peers[addr]
+		if !ok {
+			continue
+		}
+		p.stalls += n
+		log.Printf("peer %s didn't deliver %d requested blocks in %s\n", addr, n, bd.timeout)
+		if p.stalls < maxPeerStalls {
+			continue
+		}
+
+		log.Printf("disconnect peer %s, it stalls the block download\n", addr)
+		bd.disconnectPeer(addr, ErrPeerStalling)
+	}
+}
+
+// disconnectPeer removes the peer from the download and disconnects it for the reason.
+func (bd *BlockDownloader) disconnectPeer(addr string, reason error) {
+	p, ok := bd.peers[addr]
+	if !ok {
+		return
+	}
+	bd.removePeer(addr)
+	if p.disconnect != nil {
+		// the peer is stopped in another goroutine, because stopping it removes it from the download
+		go p.disconnect(reason)
+	}
+}
+
+// cancelRequest removes the request, so the block can be requested from another peer.
+func (bd *BlockDownloader) cancelRequest(hash [32]byte, req blockRequest) {
+	delete(bd.requested, hash)
+	if p, ok := bd.peers[req.peer]; ok {
+		delete(p.inFlight, hash)
+	}
+
+	if bd.failed[hash] == nil {
+		bd.failed[hash] = make(map[string]struct{})
+	}
+	bd.failed[hash][req.peer] = struct{}{}
+}
+
+func (bd *BlockDownloader) removePeer(addr string) {
+	p, ok := bd.peers[addr]
+	if !ok {
+		return
+	}
+	delete(bd.peers, addr)
+
+	hashes := make([][32]byte, 0, len(p.inFlight))
+	for hash := range p.inFlight {
+		delete(bd.requested, hash)
+		hashes = append(hashes, hash)
+	}
+	bd.requeue(hashes)
+}
+
+// requeue puts the blocks back at the front of the queue, so they are requested before the next blocks.
+func (bd *BlockDownloader) requeue(hashes [][32]byte) {
+	if len(hashes) == 0 {
+		return
+	}
+	bd.queue = append(hashes, bd.queue...)
+	slices.SortFunc(bd.queue, func(a, b [32]byte) int {
+		return cmp.Compare(bd.order[a], bd.order[b])
+	})
+}
+
+func (bd *BlockDownloader) receiveBlock(pb peerBlock) {
+	hash := pb.block.GetHash()
+	if _, ok := bd.order[hash]; !ok {
+		log.Printf("unexpected block %x from peer %s\n", p2p.Reverse(hash), pb.peer)
+		return
+	}
+
+	// the block can be received after it is requested again from another peer or before it is requested
+	if req, ok := bd.requested[hash]; ok {
+		delete(bd.requested, hash)
+		if p, ok := bd.peers[req.peer]; ok {
+			delete(p.inFlight, hash)
+		}
+	}
+	bd.queue = slices.DeleteFunc(bd.queue, func(h [32]byte) bool { return h == hash })
+	if p, ok := bd.peers[pb.peer]; ok {
+		p.stalls = 0
+	}
+	delete(bd.failed, hash)
+
+	bd.buffer[pb.block.PrevBlockHash] = pb
+	bd.processBlocks()
+}
+
+// processBlocks processes the buffered blocks that extend the tip in the order of the chain.
+func (bd *BlockDownloader) processBlocks() {
+	for {
+		pb, ok := bd.buffer[bd.tip]
+		if !ok {
+			return
+		}
+		delete(bd.buffer, bd.tip)
+
+		hash := pb.block.GetHash()
+		if err := bd.processor.ProcessBlock(pb.block); err != nil {
+			bd.processFailed(pb, err)
+			return
+		}
+		bd.windowStart = bd.order[hash] + 1
+		delete(bd.order, hash)
+		delete(bd.attempts, hash)
+		bd.tip = hash
+		bd.halted = false
+	}
+}
+
+// processFailed disconnects the peer that sent an invalid block and requests the block from another peer.
+// The download stops when the block fails to be processed too many times.
+func (bd *BlockDownloader) processFailed(pb peerBlock, err error) {
+	hash := pb.block.GetHash()
+	log.Printf("failed to process block %x from peer %s: %s\n", p2p.Reverse(hash), pb.peer, err)
+	if errors.Is(err, ErrInvalidBlock) {
+		log.Printf("disconnect peer %s, it sent an invalid block\n", pb.peer)
+		bd.disconnectPeer(pb.peer, err)
+	}
+
+	bd.attempts[hash]++
+	if bd.attempts[hash] >= maxBlockProcessAttempts {
+		log.Printf("block %x failed to be processed %d times, stop the block download\n", p2p.Reverse(hash), bd.attempts[hash])
+		bd.halted = true
+		return
+	}
+
+	if bd.failed[hash] == nil {
+		bd.failed[hash] = make(map[string]struct{})
+	}
+	bd.failed[hash][pb.peer] = struct{}{}
+	bd.requeue([][32]byte{hash})
+}
+
+// schedule fills the queue from the stored headers and requests the queued blocks from the peers that
+// have free space in their window.
+func (bd *BlockDownloader) schedule() {
+	bd.fillQueue()
+	if bd.halted || len(bd.queue) == 0 {
+		return
+	}
+
+	addrs := make([]string, 0, len(bd.peers))
+	for addr := range bd.peers {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+
+	now := time.Now()
+	for _, addr := range addrs {
+		p := bd.peers[addr]
+		hashes := bd.takeQueued(addr, bd.window-len(p.inFlight))
+		if len(hashes) == 0 {
+			continue
+		}
+
+		inv := make([]p2p.InvVector, len(hashes))
+		for i, hash := range hashes {
+			inv[i] = p2p.InvVector{Type: p2p.InvTypeBlock, Hash: hash}
+			p.inFlight[hash] = struct{}{}
+			bd.requested[hash] = blockRequest{peer: addr, at: now}
+		}
+
+		msg, err := p2p.NewMessage(p2p.CmdGetdata, bd.network, p2p.MsgGetData{Count: p2p.VarInt(len(inv)), Inventory: inv})
+		if err != nil {
+			log.Printf("failed to create MsgGetData: %s\n", err)
+			continue
+		}
+		select {
+		case p.out <- msg:
+			log.Printf("request %d blocks from peer %s\n", len(inv), addr)
+		default:
+			// the peer doesn't keep up with its outgoing messages, the blocks are requested from the other peers
+			log.Printf("the outgoing messages of peer %s are full, remove it from the download\n", addr)
+			bd.removePeer(addr)
+		}
+	}
+}
+
+// takeQueued removes from the queue and returns up to n blocks in the download window that can be requested
+// from the peer. A block is not requested again from a peer that didn't deliver it, unless all peers didn't
+// deliver it.
+func (bd *BlockDownloader) takeQueued(addr string, n int) [][32]byte {
+	if n <= 0 {
+		return nil
+	}
+
+	var hashes [][32]byte
+	rest := bd.queue[:0]
+	for _, hash := range bd.queue {
+		failed := bd.failed[hash]
+		_, failedByPeer := failed[addr]
+		inWindow := bd.order[hash] < bd.windowStart+blockDownloadWindow
+		if len(hashes) < n && inWindow && (!failedByPeer || len(failed) >= len(bd.peers)) {
+			hashes = append(hashes, hash)
+			continue
+		}
+		rest = append(rest, hash)
+	}
+	bd.queue = rest
+	return hashes
+}
+
+// fillQueue adds the next blocks of the best header chain to the queue. When the header chain switches to
+// another branch, the download is re-anchored at the fork first.
+func (bd *BlockDownloader) fillQueue() {
+	fork, err := bd.findFork()
+	if err != nil {
+		log.Printf("failed to find the fork of the header chain: %s\n", err)
+		return
+	}
+	if fork != bd.lastQueued && !bd.reanchor(fork) {
+		return
+	}
+	if len(bd.queue) >= maxQueuedBlocks/2 {
+		return
+	}
+
+	headers, err := bd.headerRepository.HeadersAfter(bd.lastQueued, maxQueuedBlocks-len(bd.queue))
+	if err != nil {
+		log.Printf("failed to get the stored headers after block %x: %s\n", p2p.Reverse(bd.lastQueued), err)
+		return
+	}
+
+	for _, header := range headers {
+		hash := Hash(header)
+		bd.lastQueued = hash
+		if _, ok := bd.order[hash]; ok {
+			continue
+		}
+		bd.order[hash] = bd.nextOrder
+		bd.nextOrder++
+		bd.queue = append(bd.queue, hash)
+	}
+}
+
+// findFork returns the last queued or processed block that is on the best header chain. It returns the last
+// queued block when the header chain doesn't switch to another branch.
+func (bd *BlockDownloader) findFork() ([32]byte, error) {
+	if fork, err := bd.headerRepository.FindFork([][32]byte{bd.lastQueued}); err != nil || fork == bd.lastQueued {
+		return fork, err
+	}
+
+	locator := make([][32]byte, 0, len(bd.order)+1)
+	for hash := range bd.order {
+		locator = append(locator, hash)
+	}
+	slices.SortFunc(locator, func(a, b [32]byte) int {
+		return cmp.Compare(bd.order[b], bd.order[a])
+	})
+	locator = append(locator, bd.tip)
+
+	fork, err := bd.headerRepository.FindFork(locator)
+	if err != nil || fork == bd.tip || slices.Contains(locator, fork) {
+		return fork, err
+	}
+
+	// the header chain forks below the tip, the processed blocks are walked back to the fork
+	for hash := bd.tip; hash != sync.GenesisBlockHash; {
+		header, _, err := bd.headerRepository.GetHeader(hash)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("failed to get the header of block %x: %w", p2p.Reverse(hash), err)
+		}
+		hash = header.PrevBlockHash
+		if fork, err = bd.headerRepository.FindFork([][32]byte{hash}); err != nil || fork == hash {
+			return fork, err
+		}
+	}
+	return sync.GenesisBlockHash, nil
+}
+
+// reanchor drops the blocks after the fork and continues the download after it. When the fork is below the
+// tip, the processed blocks after it are rolled back. It returns false when they can't be rolled back.
+func (bd *BlockDownloader) reanchor(fork [32]byte) bool {
+	forkOrder, aboveTip := bd.order[fork]
+	if !aboveTip && fork != bd.tip {
+		if bd.rewinder == nil {
+			log.Printf("the header chain forks at block %x below the tip, the blocks can't be rolled back\n", p2p.Reverse(fork))
+			bd.halted = true
+			return false
+		}
+		n, err := bd.rewinder.Rollback(fork)
+		if err != nil {
+			log.Printf("failed to roll back the blocks to the fork %x: %s\n", p2p.Reverse(fork), err)
+			bd.halted = true
+			return false
+		}
+		log.Printf("rolled back %d blocks to the fork %x of the header chain\n", n, p2p.Reverse(fork))
+		bd.tip = fork
+	}
+	log.Printf("the header chain switches to another branch at block %x\n", p2p.Reverse(fork))
+
+	for hash, order := range bd.order {
+		if aboveTip && order <= forkOrder {
+			continue
+		}
+		if req, ok := bd.requested[hash]; ok {
+			if p, ok := bd.peers[req.peer]; ok {
+				delete(p.inFlight, hash)
+			}
+		}
+		delete(bd.order, hash)
+		delete(bd.requested, hash)
+		delete(bd.failed, hash)
+		delete(bd.attempts, hash)
+	}
+	bd.queue = slices.DeleteFunc(bd.queue, func(hash [32]byte) bool {
+		_, ok := bd.order[hash]
+		return !ok
+	})
+	for prev, pb := range bd.buffer {
+		if _, ok := bd.order[pb.block.GetHash()]; !ok {
+			delete(bd.buffer, prev)
+		}
+	}
+
+	bd.lastQueued = fork
+	if !aboveTip {
+		// the block that failed to be processed is not on the header chain anymore
+		bd.windowStart = bd.nextOrder
+		bd.halted = false
+	}
+	return true
+}
+
+// BlockDownloadPeer connects the block download with one peer. It adds the peer to the download when it
+// is started, passes the blocks and the notfound messages from the peer to the download and removes the
+// peer when it is stopped.
+type BlockDownloadPeer struct {
+	addr       string
+	downloader *BlockDownloader
+	blocks     <-chan *p2p.MsgBlock
+	notFound   <-chan *p2p.MsgNotFound
+	out        chan<- *p2p.Message
+	disconnect func(error)
+	stop       chan struct{}
+	done       chan struct{}
+	isStarted  atomic.Bool
+}
+
+// NewBlockDownloadPeer creates a new BlockDownloadPeer for the peer with the given address. The disconnect
+// function is called when the peer stalls the download.
+func NewBlockDownloadPeer(addr string, bd *BlockDownloader, blocks <-chan *p2p.MsgBlock, nf <-chan *p2p.MsgNotFound,
+	out chan<- *p2p.Message, disconnect func(error)) *BlockDownloadPeer {
+	return &BlockDownloadPeer{
+		addr:       addr,
+		downloader: bd,
+		blocks:     blocks,
+		notFound:   nf,
+		out:        out,
+		disconnect: disconnect,
+		stop:       make(chan struct{}, 1000),
+		done:       make(chan struct{}, 1000),
+	}
+}
+
+func (dp *BlockDownloadPeer) Start() {
+	if dp.isStarted.Load() {
+		log.Println("BlockDownloadPeer is already started.")
+		return
+	}
+	dp.isStarted.Store(true)
+	dp.downloader.AddPeer(dp.addr, dp.out, dp.disconnect)
+	go dp.handleBlocks()
+	log.Println("Start BlockDownloadPeer.")
+}
+
+func (dp *BlockDownloadPeer) Stop() {
+	if !dp.isStarted.Load() {
+		log.Println("BlockDownloadPeer is not started and can't be stopped.")
+		return
+	}
+	dp.isStarted.Store(false)
+	dp.stop <- struct{}{}
+	<-dp.done
+	dp.downloader.RemovePeer(dp.addr)
+	log.Println("Stop BlockDownloadPeer")
+}
+
+func (dp *BlockDownloadPeer) handleBlocks() {
+	for {
+		select {
+		case <-dp.stop:
+			dp.done <- struct{}{}
+			return
+		case block := <-dp.blocks:
+			dp.downloader.ReceiveBlock(dp.addr, block)
+		case msg := <-dp.notFound:
+			var hashes [][32]byte
+			for _, inv := range msg.Inventory {
+				if inv.Type == p2p.InvTypeBlock {
+					hashes = append(hashes, inv.Hash)
+				}
+			}
+			if len(hashes) > 0 {
+				dp.downloader.NotFound(dp.addr, hashes)
+			}
+		}
+	}
+}
@@ -0,0 +1,499 @@
+package node_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockDownloader_DownloadsFromAllPeersAndProcessesInOrder(t *testing.T) {
+	blocks := newDownloadChain(4)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[1]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[2]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[3]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, time.Minute)
+	bd.AddPeer("a", outA, nil)
+	bd.AddPeer("b", outB, nil)
+	bd.Start()
+
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1]), <-outA)
+	require.Equal(t, newMsgGetData(t, blocks[2], blocks[3]), <-outB)
+	require.Eventually(t, func() bool { return bd.BlocksInFlight("a") == 2 && bd.BlocksInFlight("b") == 2 },
+		time.Second, time.Millisecond)
+
+	// the blocks of the second peer arrive first, they wait for the blocks before them
+	bd.ReceiveBlock("b", &blocks[3])
+	bd.ReceiveBlock("b", &blocks[2])
+	bd.ReceiveBlock("a", &blocks[1])
+	bd.ReceiveBlock("a", &blocks[0])
+
+	<-processed
+	require.Eventually(t, func() bool { return bd.BlocksInFlight("a") == 0 && bd.BlocksInFlight("b") == 0 },
+		time.Second, time.Millisecond)
+	bd.Stop()
+}
+
+func TestBlockDownloader_RequestsTheBlocksOfARemovedPeerFromTheOtherPeers(t *testing.T) {
+	blocks := newDownloadChain(2)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	processor.EXPECT().ProcessBlock(&blocks[0]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, time.Minute)
+	bd.AddPeer("a", outA, nil)
+	bd.Start()
+
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1]), <-outA)
+
+	bd.AddPeer("b", outB, nil)
+	bd.RemovePeer("a")
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1]), <-outB)
+
+	// a requested block is accepted from any peer
+	bd.ReceiveBlock("a", &blocks[0])
+	<-processed
+	bd.Stop()
+}
+
+func TestBlockDownloader_RequestsTheBlocksThatAreNotReceivedInTimeFromAnotherPeer(t *testing.T) {
+	blocks := newDownloadChain(2)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[1]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, 50*time.Millisecond)
+	bd.AddPeer("a", outA, nil)
+	bd.Start()
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1]), <-outA)
+
+	// the peer "a" doesn't deliver the blocks
+	bd.AddPeer("b", outB, nil)
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1]), <-outB)
+
+	bd.ReceiveBlock("b", &blocks[0])
+	bd.ReceiveBlock("b", &blocks[1])
+	<-processed
+	bd.Stop()
+}
+
+func TestBlockDownloader_DisconnectsAPeerThatKeepsStalling(t *testing.T) {
+	blocks := newDownloadChain(1)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+
+	disconnected := make(chan error, 1)
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, node.NewMockBlockProcessor(ctrl), nil, 2, 20*time.Millisecond)
+	bd.AddPeer("a", out, func(err error) { disconnected <- err })
+	bd.Start()
+
+	// the block is requested again from the same peer, because there is no other peer
+	for i := 0; i < 3; i++ {
+		require.Equal(t, newMsgGetData(t, blocks[0]), <-out)
+	}
+
+	require.ErrorIs(t, <-disconnected, node.ErrPeerStalling)
+	bd.Stop()
+	require.Empty(t, out)
+}
+
+func TestBlockDownloader_DisconnectsAPeerThatStallsSeveralBlocksAtOnce(t *testing.T) {
+	blocks := newDownloadChain(3)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+
+	disconnected := make(chan error, 1)
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, node.NewMockBlockProcessor(ctrl), nil, 3, 20*time.Millisecond)
+	bd.AddPeer("a", out, func(err error) { disconnected <- err })
+	bd.Start()
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1], blocks[2]), <-out)
+
+	// the 3 blocks that are not delivered are 3 stalls
+	require.ErrorIs(t, <-disconnected, node.ErrPeerStalling)
+	bd.Stop()
+	require.Empty(t, out)
+}
+
+func TestBlockDownloader_DoesNotDisconnectAPeerThatDeliversBetweenTheStalls(t *testing.T) {
+	blocks := newDownloadChain(4)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[1]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[2]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[3]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	disconnected := make(chan error, 1)
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 1, 20*time.Millisecond)
+	bd.AddPeer("a", out, func(err error) { disconnected <- err })
+	bd.Start()
+
+	// every block is delivered after it is not delivered in time once, so the peer never stalls 3 blocks
+	// in a row
+	for i := range blocks {
+		require.Equal(t, newMsgGetData(t, blocks[i]), <-out)
+		require.Equal(t, newMsgGetData(t, blocks[i]), <-out)
+		bd.ReceiveBlock("a", &blocks[i])
+	}
+
+	<-processed
+	bd.Stop()
+	require.Empty(t, disconnected)
+}
+
+func TestBlockDownloader_RequestsTheBlocksThatAreNotFoundFromAnotherPeer(t *testing.T) {
+	blocks := newDownloadChain(2)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[1]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, time.Minute)
+	bd.AddPeer("a", outA, nil)
+	bd.AddPeer("b", outB, nil)
+	bd.Start()
+	require.Equal(t, newMsgGetData(t, blocks[0], blocks[1]), <-outA)
+
+	bd.NotFound("a", [][32]byte{blocks[1].GetHash()})
+	require.Equal(t, newMsgGetData(t, blocks[1]), <-outB)
+
+	bd.ReceiveBlock("a", &blocks[0])
+	bd.ReceiveBlock("b", &blocks[1])
+	<-processed
+	bd.Stop()
+}
+
+func TestBlockDownloader_DisconnectsThePeerOfAnInvalidBlockAndRequestsItFromAnotherPeer(t *testing.T) {
+	blocks := newDownloadChain(1)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(fmt.Errorf("%w: bad merkle root", node.ErrInvalidBlock)),
+		processor.EXPECT().ProcessBlock(&blocks[0]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	disconnected := make(chan error, 1)
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, time.Minute)
+	bd.AddPeer("a", outA, func(err error) { disconnected <- err })
+	bd.AddPeer("b", outB, nil)
+	bd.Start()
+	require.Equal(t, newMsgGetData(t, blocks[0]), <-outA)
+
+	bd.ReceiveBlock("a", &blocks[0])
+	require.ErrorIs(t, <-disconnected, node.ErrInvalidBlock)
+	require.Equal(t, newMsgGetData(t, blocks[0]), <-outB)
+
+	bd.ReceiveBlock("b", &blocks[0])
+	<-processed
+	bd.Stop()
+}
+
+func TestBlockDownloader_StopsWhenABlockFailsToBeProcessedTooManyTimes(t *testing.T) {
+	blocks := newDownloadChain(1)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{}, 3)
+	processor := node.NewMockBlockProcessor(ctrl)
+	processor.EXPECT().ProcessBlock(&blocks[0]).Do(func(*p2p.MsgBlock) { processed <- struct{}{} }).
+		Return(errors.New("failed to save block")).Times(3)
+
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, time.Minute)
+	bd.AddPeer("a", out, func(err error) { t.Errorf("the peer is disconnected: %s", err) })
+	bd.Start()
+
+	// the block is requested again from the same peer, because there is no other peer
+	for i := 0; i < 3; i++ {
+		require.Equal(t, newMsgGetData(t, blocks[0]), <-out)
+		bd.ReceiveBlock("a", &blocks[0])
+		<-processed
+	}
+
+	bd.Stop()
+	require.Empty(t, out)
+}
+
+func TestBlockDownloader_RequestsOnlyTheBlocksInTheWindowAfterTheTip(t *testing.T) {
+	blocks := newDownloadChain(1030)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processor := node.NewMockBlockProcessor(ctrl)
+	processor.EXPECT().ProcessBlock(&blocks[0]).Return(nil)
+
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2000, time.Minute)
+	bd.AddPeer("a", out, nil)
+	bd.Start()
+
+	// the peer has space for all blocks, but only the 1024 blocks after the tip are requested
+	require.Equal(t, newMsgGetData(t, blocks[:1024]...), <-out)
+
+	// the window moves when the tip is processed
+	bd.ReceiveBlock("a", &blocks[0])
+	require.Equal(t, newMsgGetData(t, blocks[1024]), <-out)
+
+	bd.Stop()
+}
+
+func TestBlockDownloader_ContinuesAfterTheForkWhenTheHeaderChainSwitchesAboveTheTip(t *testing.T) {
+	old := newDownloadChain(3)
+	branch := append([]p2p.MsgBlock{old[0]}, newBranch(old[0].GetHash(), 2, 1)...)
+
+	ctrl := gomock.NewController(t)
+	var best atomic.Pointer[[]p2p.MsgBlock]
+	best.Store(&old)
+	headerRepo := newSwitchingHeaders(ctrl, &best, append(slices.Clone(old), branch[1:]...)...)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&branch[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&branch[1]).Return(nil),
+		processor.EXPECT().ProcessBlock(&branch[2]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 2, time.Minute)
+	bd.AddPeer("a", out, nil)
+	bd.Start()
+	require.Equal(t, newMsgGetData(t, old[0], old[1]), <-out)
+
+	// the blocks of the old branch after the fork are dropped and the blocks of the new branch are requested
+	best.Store(&branch)
+	bd.HeadersStored()
+	require.Equal(t, newMsgGetData(t, branch[1]), <-out)
+
+	bd.ReceiveBlock("a", &old[1])
+	bd.ReceiveBlock("a", &branch[1])
+	bd.ReceiveBlock("a", &branch[0])
+	require.Equal(t, newMsgGetData(t, branch[2]), <-out)
+	bd.ReceiveBlock("a", &branch[2])
+
+	<-processed
+	bd.Stop()
+}
+
+func TestBlockDownloader_RollsBackTheBlocksAfterTheForkWhenTheHeaderChainSwitchesBelowTheTip(t *testing.T) {
+	old := newDownloadChain(2)
+	branch := append([]p2p.MsgBlock{old[0]}, newBranch(old[0].GetHash(), 2, 1)...)
+
+	ctrl := gomock.NewController(t)
+	var best atomic.Pointer[[]p2p.MsgBlock]
+	best.Store(&old)
+	headerRepo := newSwitchingHeaders(ctrl, &best, append(slices.Clone(old), branch[1:]...)...)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{}, 1)
+	processor := node.NewMockBlockProcessor(ctrl)
+	rewinder := node.NewMockChainRewinder(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&old[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&old[1]).Do(func(*p2p.MsgBlock) { processed <- struct{}{} }).Return(nil),
+		rewinder.EXPECT().Rollback(old[0].GetHash()).Return(1, nil),
+		processor.EXPECT().ProcessBlock(&branch[1]).Return(nil),
+		processor.EXPECT().ProcessBlock(&branch[2]).Do(func(*p2p.MsgBlock) { processed <- struct{}{} }).Return(nil),
+	)
+
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, rewinder, 2, time.Minute)
+	bd.AddPeer("a", out, nil)
+	bd.Start()
+	require.Equal(t, newMsgGetData(t, old[0], old[1]), <-out)
+	bd.ReceiveBlock("a", &old[0])
+	bd.ReceiveBlock("a", &old[1])
+	<-processed
+
+	// the processed block of the old branch is rolled back and the new branch is downloaded after the fork
+	best.Store(&branch)
+	bd.HeadersStored()
+	require.Equal(t, newMsgGetData(t, branch[1], branch[2]), <-out)
+	bd.ReceiveBlock("a", &branch[1])
+	bd.ReceiveBlock("a", &branch[2])
+
+	<-processed
+	bd.Stop()
+}
+
+func TestBlockDownloader_ContinuesWhenTheBlockThatFailedIsProcessed(t *testing.T) {
+	blocks := newDownloadChain(2)
+
+	ctrl := gomock.NewController(t)
+	headerRepo := newStoredHeaders(ctrl, blocks)
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(sync.GenesisBlockHash, nil)
+	processed := make(chan struct{})
+	processor := node.NewMockBlockProcessor(ctrl)
+	gomock.InOrder(
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(errors.New("failed to save block")).Times(3),
+		processor.EXPECT().ProcessBlock(&blocks[0]).Return(nil),
+		processor.EXPECT().ProcessBlock(&blocks[1]).Do(func(*p2p.MsgBlock) { close(processed) }).Return(nil),
+	)
+
+	out := make(chan *p2p.Message, 10)
+	bd := node.NewBlockDownloader("mainnet", headerRepo, chainTip, processor, nil, 1, time.Minute)
+	bd.AddPeer("a", out, nil)
+	bd.Start()
+	for i := 0; i < 3; i++ {
+		require.Equal(t, newMsgGetData(t, blocks[0]), <-out)
+		bd.ReceiveBlock("a", &blocks[0])
+	}
+
+	// the download is stopped until the block arrives again and is processed
+	bd.ReceiveBlock("a", &blocks[0])
+	require.Equal(t, newMsgGetData(t, blocks[1]), <-out)
+	bd.ReceiveBlock("a", &blocks[1])
+
+	<-processed
+	bd.Stop()
+}
+
+// newDownloadChain returns n blocks that extend the genesis block.
+func newDownloadChain(n int) []p2p.MsgBlock {
+	return newBranch(sync.GenesisBlockHash, n, 0)
+}
+
+// newBranch returns n blocks that extend the block prev, the nonce distinguishes the branches.
+func newBranch(prev [32]byte, n int, nonce uint32) []p2p.MsgBlock {
+	blocks := make([]p2p.MsgBlock, n)
+	for i := range blocks {
+		blocks[i] = testutil.NewMsgBlock(prev)
+		blocks[i].Nonce = nonce
+		prev = blocks[i].GetHash()
+	}
+	return blocks
+}
+
+// newStoredHeaders returns a header repository whose best header chain has the headers of the blocks.
+func newStoredHeaders(ctrl *gomock.Controller, blocks []p2p.MsgBlock) *node.MockHeaderRepository {
+	var best atomic.Pointer[[]p2p.MsgBlock]
+	best.Store(&blocks)
+	return newSwitchingHeaders(ctrl, &best, blocks...)
+}
+
+// newSwitchingHeaders returns a header repository whose best header chain has the headers of the best blocks,
+// it switches to another branch when they change. The headers of all blocks are stored.
+func newSwitchingHeaders(ctrl *gomock.Controller, best *atomic.Pointer[[]p2p.MsgBlock], all ...p2p.MsgBlock) *node.MockHeaderRepository {
+	onBest := func(hash [32]byte) bool {
+		return hash == sync.GenesisBlockHash || slices.ContainsFunc(*best.Load(), func(b p2p.MsgBlock) bool { return b.GetHash() == hash })
+	}
+
+	headerRepo := node.NewMockHeaderRepository(ctrl)
+	headerRepo.EXPECT().HeadersAfter(gomock.Any(), gomock.Any()).DoAndReturn(func(hash [32]byte, max int) ([]p2p.BlockHeader, error) {
+		var headers []p2p.BlockHeader
+		found := hash == sync.GenesisBlockHash
+		for _, block := range *best.Load() {
+			if found && len(headers) < max {
+				headers = append(headers, block.BlockHeader)
+			}
+			found = found || block.GetHash() == hash
+		}
+		return headers, nil
+	}).AnyTimes()
+	headerRepo.EXPECT().FindFork(gomock.Any()).DoAndReturn(func(locator [][32]byte) ([32]byte, error) {
+		for _, hash := range locator {
+			if onBest(hash) {
+				return hash, nil
+			}
+		}
+		return sync.GenesisBlockHash, nil
+	}).AnyTimes()
+	headerRepo.EXPECT().GetHeader(gomock.Any()).DoAndReturn(func(hash [32]byte) (p2p.BlockHeader, int32, error) {
+		for i, block := range all {
+			if block.GetHash() == hash {
+				return block.BlockHeader, int32(i), nil
+			}
+		}
+		return p2p.BlockHeader{}, 0, sync.ErrNotFound
+	}).AnyTimes()
+	return headerRepo
+}
+
+func newMsgGetData(t *testing.T, blocks ...p2p.MsgBlock) *p2p.Message {
+	inv := make([]p2p.InvVector, len(blocks))
+	for i, block := range blocks {
+		inv[i] = p2p.InvVector{Type: p2p.InvTypeBlock, Hash: block.GetHash()}
+	}
+
+	msg, err := p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: p2p.VarInt(len(inv)), Inventory: inv})
+	require.NoError(t, err)
+	return msg
+}
@@ -0,0 +1,219 @@
+package node
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// BlockJSON is the decoded block that is exported as one line of NDJSON. The hashes are hex encoded
+// in the byte order that is used by the block explorers and by Bitcoin Core RPC.
+type BlockJSON struct {
+	Hash          string   `json:"hash"`
+	Height        int32    `json:"height"`
+	Version       int32    `json:"version"`
+	PrevBlockHash string   `json:"prev_block_hash"`
+	MerkleRoot    string   `json:"merkle_root"`
+	Time          uint32   `json:"time"`
+	Bits          string   `json:"bits"`
+	Nonce         uint32   `json:"nonce"`
+	TxCount       int      `json:"tx_count"`
+	Txs           []TxJSON `json:"txs"`
+}
+
+// TxJSON is a decoded transaction of an exported block.
+type TxJSON struct {
+	TxID     string         `json:"txid"`
+	WTxID    string         `json:"wtxid"`
+	Version  int32          `json:"version"`
+	LockTime uint32         `json:"locktime"`
+	Inputs   []TxInputJSON  `json:"inputs"`
+	Outputs  []TxOutputJSON `json:"outputs"`
+}
+
+// TxInputJSON is an input of an exported transaction. The coinbase inputs have no previous output.
+type TxInputJSON struct {
+	Coinbase  bool     `json:"coinbase,omitempty"`
+	PrevTxID  string   `json:"prev_txid,omitempty"`
+	PrevVout  uint32   `json:"prev_vout"`
+	ScriptSig string   `json:"script_sig"`
+	Sequence  uint32   `json:"sequence"`
+	Witness   []string `json:"witness,omitempty"`
+}
+
+// TxOutputJSON is an output of an exported transaction. The value is in satoshis.
+type TxOutputJSON struct {
+	N            int    `json:"n"`
+	Value        int64  `json:"value"`
+	ScriptPubKey string `json:"script_pubkey"`
+}
+
+// NewBlockJSON decodes the block at the given height.
+func NewBlockJSON(block p2p.MsgBlock, height int32) BlockJSON {
+	hash := block.GetHash()
+	bj := BlockJSON{
+		Hash:          hex.EncodeToString(p2p.Reverse(hash)),
+		Height:        height,
+		Version:       block.Version,
+		PrevBlockHash: hex.EncodeToString(p2p.Reverse(block.PrevBlockHash)),
+		MerkleRoot:    hex.EncodeToString(p2p.Reverse(block.MerkleRoot)),
+		Time:          block.Timestamp,
+		Bits:          fmt.Sprintf("%08x", block.Bits),
+		Nonce:         block.Nonce,
+		TxCount:       len(block.Transactions),
+		Txs:           make([]TxJSON, len(block.Transactions)),
+	}
+
+	for i, tx := range block.Transactions {
+		txid, wtxid := tx.TxHash(), tx.WTxHash()
+		tj := TxJSON{
+			TxID:     hex.EncodeToString(p2p.Reverse(txid)),
+			WTxID:    hex.EncodeToString(p2p.Reverse(wtxid)),
+			Version:  tx.Version,
+			LockTime: tx.LockTime,
+			Inputs:   make([]TxInputJSON, len(tx.TxIn)),
+			Outputs:  make([]TxOutputJSON, len(tx.TxOut)),
+		}
+
+		coinbase := tx.IsCoinBase()
+		for j, in := range tx.TxIn {
+			ij := TxInputJSON{
+				Coinbase:  coinbase,
+				PrevVout:  in.PreviousOutput.Index,
+				ScriptSig: hex.EncodeToString(in.SignatureScript),
+				Sequence:  in.Sequence,
+			}
+			if !coinbase {
+				ij.PrevTxID = hex.EncodeToString(p2p.Reverse(in.PreviousOutput.Hash))
+			}
+			if j < len(tx.TxWitness) {
+				for _, w := range tx.TxWitness[j].Witness {
+					ij.Witness = append(ij.Witness, hex.EncodeToString(w.Data))
+				}
+			}
+			tj.Inputs[j] = ij
+		}
+
+		for j, out := range tx.TxOut {
+			tj.Outputs[j] = TxOutputJSON{N: j, Value: out.Value, ScriptPubKey: hex.EncodeToString(out.PkScript)}
+		}
+		bj.Txs[i] = tj
+	}
+	return bj
+}
+
+// BlockExporter exports a range of the stored blocks as a linear bootstrap.dat file (the format that
+// Bitcoin Core can import with -loadblock) or as NDJSON.
+type BlockExporter struct {
+	magic           p2p.Magic
+	blockRepository sync.BlockRepository
+}
+
+// NewBlockExporter creates a new BlockExporter.
+func NewBlockExporter(network string, br sync.BlockRepository) (*BlockExporter, error) {
+	magic, ok := p2p.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("unsupported network %s", network)
+	}
+	return &BlockExporter{magic: magic, blockRepository: br}, nil
+}
+
+// ExportBootstrap writes the blocks from height "from" to height "to" (including) prefixed by the
+// network magic and the block size. When "to" is 0 the blocks up to the last block are exported.
+// It returns the number of the exported blocks.
+func (be *BlockExporter) ExportBootstrap(w io.Writer, from, to int32) (int, error) {
+	return be.export(w, from, to, func(bw *bufio.Writer, block p2p.MsgBlock, _ int32) error {
+		raw, err := wire.Marshal(block)
+		if err != nil {
+			return err
+		}
+
+		if _, err = bw.Write(be.magic[:]); err != nil {
+			return err
+		}
+		if err = binary.Write(bw, binary.LittleEndian, uint32(len(raw))); err != nil {
+			return err
+		}
+		_, err = bw.Write(raw)
+		return err
+	})
+}
+
+// ExportNDJSON writes the blocks from height "from" to height "to" (including) as decoded JSON
+// objects, one per line. When "to" is 0 the blocks up to the last block are exported.
+// It returns the number of the exported blocks.
+func (be *BlockExporter) ExportNDJSON(w io.Writer, from, to int32) (int, error) {
+	return be.export(w, from, to, func(bw *bufio.Writer, block p2p.MsgBlock, height int32) error {
+		// the encoder writes a new line after every block
+		return json.NewEncoder(bw).Encode(NewBlockJSON(block, height))
+	})
+}
+
+func (be *BlockExporter) export(w io.Writer, from, to int32, write func(*bufio.Writer, p2p.MsgBlock, int32) error) (int, error) {
+	hashes, err := be.chain(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, hash := range hashes {
+		block, err := be.blockRepository.Get(hash)
+		if err != nil {
+			return i, fmt.Errorf("failed to get block %x: %w", p2p.Reverse(hash), err)
+		}
+
+		if err = write(bw, block, from+int32(i)); err != nil {
+			return i, err
+		}
+	}
+	return len(hashes), bw.Flush()
+}
+
+// chain returns the hashes of the blocks from height "from" to height "to" in the order of the chain.
+// The genesis block (height 0) is not stored, so the first stored block has height 1.
+func (be *BlockExporter) chain(from, to int32) ([][32]byte, error) {
+	if from < 1 || (to != 0 && to < from) {
+		return nil, fmt.Errorf("invalid height range %d - %d", from, to)
+	}
+
+	last, err := be.blockRepository.GetLast()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the last block: %w", err)
+	}
+
+	// walk back from the last block to the genesis block
+	var hashes [][32]byte
+	for block := last; ; {
+		hashes = append(hashes, block.GetHash())
+		prev := block.PrevBlockHash
+		if prev == sync.GenesisBlockHash {
+			break
+		}
+
+		if block, err = be.blockRepository.Get(prev); err != nil {
+			return nil, fmt.Errorf("failed to get block %x: %w", p2p.Reverse(prev), err)
+		}
+	}
+
+	tip := int32(len(hashes))
+	if to == 0 {
+		to = tip
+	}
+	if to > tip || from > to {
+		return nil, fmt.Errorf("height range %d - %d is above the last block height %d", from, to, tip)
+	}
+
+	// hashes[0] is the block at the tip height
+	chain := make([][32]byte, 0, to-from+1)
+	for height := from; height <= to; height++ {
+		chain = append(chain, hashes[tip-height])
+	}
+	return chain, nil
+}
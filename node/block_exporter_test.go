@@ -0,0 +1,89 @@
+package node_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newChain(t *testing.T, ctrl *gomock.Controller, n int) ([]p2p.MsgBlock, *node.MockBlockRepository) {
+	blockRepo := node.NewMockBlockRepository(ctrl)
+	var chain []p2p.MsgBlock
+	prev := sync.GenesisBlockHash
+	for i := 0; i < n; i++ {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, int64(i)))
+		blockRepo.EXPECT().Get(block.GetHash()).Return(block, nil).AnyTimes()
+		chain = append(chain, block)
+		prev = block.GetHash()
+	}
+	blockRepo.EXPECT().GetLast().Return(chain[n-1], nil).AnyTimes()
+	return chain, blockRepo
+}
+
+func TestBlockExporter_ExportBootstrap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain, blockRepo := newChain(t, ctrl, 5)
+
+	exporter, err := node.NewBlockExporter("mainnet", blockRepo)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "bootstrap.dat")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	n, err := exporter.ExportBootstrap(f, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.NoError(t, f.Close())
+
+	var expected bytes.Buffer
+	writeBlockFileTo(t, &expected, chain[:3]...)
+	actual, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected.Bytes(), actual)
+
+	_, err = exporter.ExportBootstrap(io.Discard, 4, 6)
+	require.Error(t, err)
+}
+
+func TestBlockExporter_ExportNDJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain, blockRepo := newChain(t, ctrl, 3)
+
+	exporter, err := node.NewBlockExporter("mainnet", blockRepo)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := exporter.ExportNDJSON(&buf, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []node.BlockJSON
+	for scanner.Scan() {
+		var bj node.BlockJSON
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &bj))
+		lines = append(lines, bj)
+	}
+	require.Len(t, lines, 2)
+
+	hash := chain[1].GetHash()
+	txid := chain[1].Transactions[0].TxHash()
+	require.Equal(t, hex.EncodeToString(p2p.Reverse(hash)), lines[0].Hash)
+	require.Equal(t, int32(2), lines[0].Height)
+	require.Equal(t, hex.EncodeToString(p2p.Reverse(txid)), lines[0].Txs[0].TxID)
+	require.True(t, lines[0].Txs[0].Inputs[0].Coinbase)
+	require.Equal(t, []node.TxOutputJSON{{N: 0, Value: 1, ScriptPubKey: "5100"}}, lines[0].Txs[0].Outputs)
+	require.Equal(t, int32(3), lines[1].Height)
+}
@@ -0,0 +1,254 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// maxImportBlockSize is the maximum size of a block record in the imported files (the max serialized block size).
+const maxImportBlockSize = 4_000_000
+
+// importedBlock is the position of a block in the imported files.
+type importedBlock struct {
+	prevBlockHash [32]byte
+	bits          uint32
+	file          int
+	offset        int64
+	size          uint32
+}
+
+// BlockImporter imports blocks from Bitcoin Core blk*.dat files or from a linear bootstrap.dat.
+// In both formats every block is prefixed by the network magic and the block size. The blocks in the
+// blk files are not in the order of the chain, so the files are scanned first and then the blocks of the
+// chain with the most work that extends the current best block are processed one by one, by the same
+// processor as the blocks that are received from the peers.
+type BlockImporter struct {
+	magic      p2p.Magic
+	chainState ChainState
+	processor  BlockProcessor
+}
+
+// NewBlockImporter creates a new BlockImporter. The imported chain extends the best block of the chain state.
+func NewBlockImporter(network string, cs ChainState, p BlockProcessor) (*BlockImporter, error) {
+	magic, ok := p2p.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("unsupported network %s", network)
+	}
+
+	return &BlockImporter{
+		magic:      magic,
+		chainState: cs,
+		processor:  p,
+	}, nil
+}
+
+// Import imports the blocks from the files and returns the number of the imported blocks.
+func (bi *BlockImporter) Import(paths []string) (int, error) {
+	files := make([]io.ReaderAt, len(paths))
+	for i, path := range paths {
+		f, err := openImportFile(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		files[i] = f
+	}
+
+	blocks := make(map[[32]byte]importedBlock)
+	for i, path := range paths {
+		log.Printf("scan blocks in %s\n", path)
+		if err := bi.scan(files[i], i, blocks); err != nil {
+			return 0, fmt.Errorf("failed to scan %s: %w", path, err)
+		}
+	}
+
+	bestHash, _, err := bi.chainState.BestBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	chain := mostWorkChain(bestHash, blocks)
+	log.Printf("found %d blocks, %d of them extend the best block %x\n", len(blocks), len(chain), p2p.Reverse(bestHash))
+
+	for i, hash := range chain {
+		b := blocks[hash]
+		block, err := bi.readBlock(files[b.file], b)
+		if err != nil {
+			return i, fmt.Errorf("failed to read block %x from %s: %w", p2p.Reverse(hash), paths[b.file], err)
+		}
+
+		if err = bi.processor.ProcessBlock(&block); err != nil {
+			return i, fmt.Errorf("failed to import block %x: %w", p2p.Reverse(hash), err)
+		}
+	}
+
+	return len(chain), nil
+}
+
+// scan reads the headers of the blocks in the file and adds their positions to the blocks.
+func (bi *BlockImporter) scan(f io.ReaderAt, file int, blocks map[[32]byte]importedBlock) error {
+	r := bufio.NewReader(io.NewSectionReader(f, 0, 1<<62))
+	var offset int64
+	for {
+		skipped, err := skipToMagic(r, bi.magic)
+		offset += skipped
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var size uint32
+		if err = binary.Read(r, binary.LittleEndian, &size); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		offset += 4
+
+		if size < 80 || size > maxImportBlockSize {
+			log.Printf("skip block record with invalid size %d at offset %d\n", size, offset)
+			continue
+		}
+
+		data := make([]byte, size)
+		if _, err = io.ReadFull(r, data); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				log.Printf("skip truncated block at offset %d\n", offset)
+				return nil
+			}
+			return err
+		}
+
+		var header p2p.BlockHeader
+		if err = wire.NewDecoder(bytes.NewReader(data)).Decode(&header); err != nil {
+			return fmt.Errorf("invalid block header at offset %d: %w", offset, err)
+		}
+
+		hash := Hash(header)
+		if _, ok := blocks[hash]; !ok {
+			blocks[hash] = importedBlock{prevBlockHash: header.PrevBlockHash, bits: header.Bits, file: file, offset: offset, size: size}
+		}
+		offset += int64(size)
+	}
+}
+
+func (bi *BlockImporter) readBlock(f io.ReaderAt, b importedBlock) (p2p.MsgBlock, error) {
+	data := make([]byte, b.size)
+	if _, err := f.ReadAt(data, b.offset); err != nil {
+		return p2p.MsgBlock{}, err
+	}
+
+	var block p2p.MsgBlock
+	if err := block.UnmarshalBinary(bytes.NewReader(data)); err != nil {
+		return p2p.MsgBlock{}, err
+	}
+	return block, nil
+}
+
+// mostWorkChain returns in the order of the chain the hashes of the chain of blocks with the most work that
+// starts right after the block with the given hash. Of the chains with the same work, the one whose last block
+// is first in the files is returned.
+func mostWorkChain(from [32]byte, blocks map[[32]byte]importedBlock) [][32]byte {
+	children := make(map[[32]byte][][32]byte, len(blocks))
+	for hash, b := range blocks {
+		children[b.prevBlockHash] = append(children[b.prevBlockHash], hash)
+	}
+
+	depth := map[[32]byte]int{from: 0}
+	work := map[[32]byte]*big.Int{from: new(big.Int)}
+	best := from
+	queue := [][32]byte{from}
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+		for _, child := range children[hash] {
+			depth[child] = depth[hash] + 1
+			work[child] = new(big.Int).Add(work[hash], headerWork(blocks[child].bits))
+			c := work[child].Cmp(work[best])
+			if c > 0 || c == 0 && best != from && blocks[child].before(blocks[best]) {
+				best = child
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	chain := make([][32]byte, depth[best])
+	for hash := best; hash != from; hash = blocks[hash].prevBlockHash {
+		chain[depth[hash]-1] = hash
+	}
+	return chain
+}
+
+// before returns true when the block is before the other block in the files.
+func (b importedBlock) before(other importedBlock) bool {
+	return b.file < other.file || b.file == other.file && b.offset < other.offset
+}
+
+// skipToMagic reads from r until the magic is read. It returns the number of the read bytes, including the magic.
+// The blk files of Bitcoin Core are preallocated, so the unused part at their end is filled with zeros.
+func skipToMagic(r *bufio.Reader, magic p2p.Magic) (int64, error) {
+	var window [4]byte
+	var n int64
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		n++
+
+		copy(window[:], window[1:])
+		window[3] = c
+		if n >= 4 && window == magic {
+			return n, nil
+		}
+	}
+}
+
+// importFile is an imported file. The blk files of Bitcoin Core since v28 are obfuscated with the
+// key in the xor.dat file that is in the same directory.
+type importFile struct {
+	*os.File
+	xorKey []byte
+}
+
+func openImportFile(path string) (*importFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := os.ReadFile(filepath.Join(filepath.Dir(path), "xor.dat"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		f.Close()
+		return nil, err
+	}
+	if bytes.Count(key, []byte{0}) == len(key) {
+		key = nil
+	}
+	return &importFile{File: f, xorKey: key}, nil
+}
+
+// ReadAt implements io.ReaderAt interface. It de-obfuscates the read data.
+func (f *importFile) ReadAt(p []byte, off int64) (int, error) {
+	n, err := f.File.ReadAt(p, off)
+	if len(f.xorKey) > 0 {
+		for i := 0; i < n; i++ {
+			p[i] ^= f.xorKey[(off+int64(i))%int64(len(f.xorKey))]
+		}
+	}
+	return n, err
+}
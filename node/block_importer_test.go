@@ -0,0 +1,128 @@
+package node_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var coinbase = []p2p.OutPoint{{Index: 0xffffffff}}
+
+func writeBlockFileTo(t *testing.T, buf *bytes.Buffer, blocks ...p2p.MsgBlock) {
+	for _, block := range blocks {
+		raw, err := wire.Marshal(block)
+		require.NoError(t, err)
+		buf.Write(p2p.MagicMainnet[:])
+		buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(raw))))
+		buf.Write(raw)
+	}
+}
+
+func writeBlockFile(t *testing.T, path string, xorKey []byte, blocks ...p2p.MsgBlock) {
+	var buf bytes.Buffer
+	writeBlockFileTo(t, &buf, blocks...)
+	// the blk files of Bitcoin Core are preallocated with zeros
+	data := append(buf.Bytes(), make([]byte, 64)...)
+
+	for i := range data {
+		if len(xorKey) > 0 {
+			data[i] ^= xorKey[i%len(xorKey)]
+		}
+	}
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+// newImportChain returns a chain of blocks with the given bits after the block with the given hash.
+func newImportChain(prev [32]byte, bits uint32, values ...int64) []p2p.MsgBlock {
+	var chain []p2p.MsgBlock
+	for _, value := range values {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, value))
+		block.Bits = bits
+		chain = append(chain, block)
+		prev = block.GetHash()
+	}
+	return chain
+}
+
+func TestBlockImporter_ImportOutOfOrderBlocks(t *testing.T) {
+	chain := newImportChain(sync.GenesisBlockHash, 0x1d00ffff, 0, 1, 2, 3)
+	// a stale block that forks from the first block
+	stale := newImportChain(chain[0].GetHash(), 0x1d00ffff, 100)[0]
+
+	dir := t.TempDir()
+	xorKey := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "xor.dat"), xorKey, 0600))
+	writeBlockFile(t, filepath.Join(dir, "blk00000.dat"), xorKey, chain[1], stale, chain[0])
+	writeBlockFile(t, filepath.Join(dir, "blk00001.dat"), xorKey, chain[3], chain[2])
+
+	ctrl := gomock.NewController(t)
+	blockRepo := node.NewMockBlockRepository(ctrl)
+	validator := node.NewMockValidator(ctrl)
+	chainState := node.NewMockChainState(ctrl)
+	indexer := node.NewMockIndexer(ctrl)
+
+	chainState.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil).Times(1)
+	var calls []*gomock.Call
+	for i := range chain {
+		block := chain[i]
+		calls = append(calls,
+			validator.EXPECT().Validate(&block).Return(nil),
+			blockRepo.EXPECT().Save(block).Return(nil),
+			chainState.EXPECT().ConnectBlock(block).Return(nil),
+			indexer.EXPECT().ConnectBlock(block).Return(nil),
+		)
+	}
+	gomock.InOrder(calls...)
+
+	processor := node.NewMsgBlockHandler(blockRepo, validator, chainState, nil, []node.Indexer{indexer}, nil, nil, nil)
+	importer, err := node.NewBlockImporter("mainnet", chainState, processor)
+	require.NoError(t, err)
+
+	n, err := importer.Import([]string{filepath.Join(dir, "blk00000.dat"), filepath.Join(dir, "blk00001.dat")})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+}
+
+func TestBlockImporter_ImportTheChainWithTheMostWork(t *testing.T) {
+	// the longer branch has a higher target, so it has less work than the shorter one
+	light := newImportChain(sync.GenesisBlockHash, 0x1d00ffff, 0, 1, 2)
+	heavy := newImportChain(sync.GenesisBlockHash, 0x1c00ffff, 10, 11)
+
+	dir := t.TempDir()
+	writeBlockFile(t, filepath.Join(dir, "blk00000.dat"), nil, append(light, heavy...)...)
+
+	ctrl := gomock.NewController(t)
+	blockRepo := node.NewMockBlockRepository(ctrl)
+	validator := node.NewMockValidator(ctrl)
+	chainState := node.NewMockChainState(ctrl)
+
+	chainState.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil).Times(1)
+	var calls []*gomock.Call
+	for i := range heavy {
+		block := heavy[i]
+		calls = append(calls,
+			validator.EXPECT().Validate(&block).Return(nil),
+			blockRepo.EXPECT().Save(block).Return(nil),
+			chainState.EXPECT().ConnectBlock(block).Return(nil),
+		)
+	}
+	gomock.InOrder(calls...)
+
+	processor := node.NewMsgBlockHandler(blockRepo, validator, chainState, nil, nil, nil, nil, nil)
+	importer, err := node.NewBlockImporter("mainnet", chainState, processor)
+	require.NoError(t, err)
+
+	n, err := importer.Import([]string{filepath.Join(dir, "blk00000.dat")})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
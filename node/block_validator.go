@@ -4,7 +4,6 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
-	"github.com/EmilGeorgiev/btc-node/network/binary"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 	"github.com/EmilGeorgiev/btc-node/sync"
 	"log"
@@ -13,11 +12,11 @@ import (
 
 // BlockValidator is responsible for validating blocks ( headers and transactions).
 type BlockValidator struct {
-	blockRepo BlockRepository
+	blockRepo sync.BlockRepository
 }
 
 // NewBlockValidator creates a new BlockValidator with the given BlockRepository.
-func NewBlockValidator(br BlockRepository) BlockValidator {
+func NewBlockValidator(br sync.BlockRepository) BlockValidator {
 	return BlockValidator{
 		blockRepo: br,
 	}
@@ -43,30 +42,37 @@ func (bv BlockValidator) Validate(bl *p2p.MsgBlock) error {
 
 // ValidateMerkleTree checks if the Merkle tree of the block is valid.
 func (bv BlockValidator) ValidateMerkleTree(bl *p2p.MsgBlock) bool {
-	if bl.TxnCount == 1 {
-		h := HashTx(bl.Transactions[0])
-		return [32]byte(h) == bl.MerkleRoot
+	if len(bl.Transactions) == 0 {
+		return false
 	}
+	return CalcMerkleRoot(bl.Transactions) == bl.MerkleRoot
+}
 
-	txHashes := make([][]byte, bl.TxnCount)
-	for i, tx := range bl.Transactions {
-		txHashes[i] = HashTx(tx)
+// CalcMerkleRoot calculates the merkle root of the transactions. When a level of the tree has an odd
+// number of hashes the last one is duplicated.
+func CalcMerkleRoot(txs []p2p.MsgTx) [32]byte {
+	if len(txs) == 0 {
+		return [32]byte{}
 	}
 
-	if bl.TxnCount%2 != 0 {
-		// duplicate last transaction.
-		txHashes = append(txHashes, txHashes[bl.TxnCount-1])
+	hashes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		hashes[i] = HashTx(tx)
 	}
 
-	for len(txHashes) > 1 {
-		var newLevel [][]byte
-		for i := 0; i < len(txHashes); i += 2 {
-			concatenated := append(txHashes[i], txHashes[i+1]...)
-			newLevel = append(newLevel, DHash(concatenated))
+	for len(hashes) > 1 {
+		if len(hashes)%2 != 0 {
+			hashes = append(hashes, hashes[len(hashes)-1])
+		}
+
+		level := make([][]byte, 0, len(hashes)/2)
+		for i := 0; i < len(hashes); i += 2 {
+			concatenated := append(append(make([]byte, 0, 64), hashes[i]...), hashes[i+1]...)
+			level = append(level, DHash(concatenated))
 		}
-		txHashes = newLevel
+		hashes = level
 	}
-	return bl.MerkleRoot == [32]byte(txHashes[0])
+	return [32]byte(hashes[0])
 }
 
 // DHash performs double SHA-256 hashing on the given byte slice.
@@ -76,11 +82,9 @@ func DHash(b []byte) []byte {
 	return h[:]
 }
 
-// HashTx calculates the double SHA-256 hash of the given transaction.
+// HashTx calculates the double SHA-256 hash of the given transaction without its witness data (the txid).
 func HashTx(tx p2p.MsgTx) []byte {
-	b, _ := binary.Marshal(tx)
-	firstHash := sha256.Sum256(b)
-	h := sha256.Sum256(firstHash[:])
+	h := tx.TxHash()
 	return h[:]
 }
 
@@ -0,0 +1,248 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+const (
+	// VerifyLevelStorage checks that every block is stored under its hash and links to the previous block.
+	VerifyLevelStorage = 0
+	// VerifyLevelBlock checks additionally the proof of work and the merkle root of every block.
+	VerifyLevelBlock = 1
+	// VerifyLevelUTXO checks additionally that the UTXO set is consistent with the blocks.
+	VerifyLevelUTXO = 2
+)
+
+// CorruptedBlock is the first block in the order of the chain that doesn't pass the verification.
+type CorruptedBlock struct {
+	Hash [32]byte
+	// PrevBlockHash is the hash of the last good block. It is zero when the block can't be read.
+	PrevBlockHash [32]byte
+	// Height is the height of the block. It is 0 when the height is unknown.
+	Height int32
+	// Reason describes why the block is corrupted.
+	Reason error
+}
+
+// ChainVerifier verifies the last blocks of the stored chain, like the verifychain RPC of Bitcoin Core,
+// and can roll back the chain to the last good block.
+type ChainVerifier struct {
+	blockRepository sync.BlockRepository
+	chainState      VerifiableChainState
+	indexers        []Indexer
+}
+
+// NewChainVerifier creates a new ChainVerifier.
+func NewChainVerifier(br sync.BlockRepository, cs VerifiableChainState, indexers []Indexer) *ChainVerifier {
+	return &ChainVerifier{blockRepository: br, chainState: cs, indexers: indexers}
+}
+
+// Verify checks the last "depth" blocks (all blocks when depth is 0) with the given level of
+// thoroughness. It returns the first corrupted block in the order of the chain or nil when all
+// checked blocks are good.
+func (cv *ChainVerifier) Verify(depth, level int) (*CorruptedBlock, error) {
+	if level < VerifyLevelStorage || level > VerifyLevelUTXO {
+		return nil, fmt.Errorf("invalid verification level %d", level)
+	}
+	if depth < 0 {
+		return nil, fmt.Errorf("invalid verification depth %d", depth)
+	}
+
+	last, err := cv.blockRepository.GetLast()
+	if errors.Is(err, sync.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the last block: %w", err)
+	}
+
+	// the checked blocks, blocks[0] is the tip
+	var blocks []p2p.MsgBlock
+	var corrupted *CorruptedBlock
+	corruptedIndex := -1
+	markCorrupted := func(i int, hash, prev [32]byte, reason error) {
+		// the blocks are checked from the tip, so the last found is the first in the order of the chain
+		if i > corruptedIndex {
+			corruptedIndex = i
+			corrupted = &CorruptedBlock{Hash: hash, PrevBlockHash: prev, Reason: reason}
+		}
+	}
+
+	hash := last.GetHash()
+	for i := 0; (depth == 0 || i < depth) && hash != sync.GenesisBlockHash; i++ {
+		block, err := cv.blockRepository.Get(hash)
+		if err != nil {
+			// the blocks before it can't be found without its previous block hash
+			markCorrupted(i, hash, [32]byte{}, fmt.Errorf("failed to read the block: %w", err))
+			break
+		}
+
+		if err = verifyBlock(hash, block, level); err != nil {
+			markCorrupted(i, hash, block.PrevBlockHash, err)
+		}
+		blocks = append(blocks, block)
+		hash = block.PrevBlockHash
+	}
+	log.Printf("verified %d blocks at level %d\n", len(blocks), level)
+
+	bestHash, bestHeight, err := cv.chainState.BestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the best block of the UTXO set: %w", err)
+	}
+
+	if level >= VerifyLevelUTXO {
+		cv.verifyUTXO(blocks, bestHash, markCorrupted)
+	}
+
+	if corrupted != nil {
+		corrupted.Height = cv.tipHeight(blocks, hash, bestHash, bestHeight) - int32(corruptedIndex)
+		if corrupted.Height < 1 {
+			corrupted.Height = 0
+		}
+	}
+	return corrupted, nil
+}
+
+// verifyUTXO checks that the blocks are connected to the UTXO set and that the set is consistent with them.
+func (cv *ChainVerifier) verifyUTXO(blocks []p2p.MsgBlock, bestHash [32]byte, markCorrupted func(int, [32]byte, [32]byte, error)) {
+	connected := len(blocks)
+	for i, block := range blocks {
+		if block.GetHash() == bestHash {
+			connected = i
+			break
+		}
+	}
+
+	// the blocks above the best block of the UTXO set are not connected to it
+	if connected > 0 {
+		block := blocks[connected-1]
+		markCorrupted(connected-1, block.GetHash(), block.PrevBlockHash,
+			fmt.Errorf("the block is not connected to the UTXO set, its best block is %x", p2p.Reverse(bestHash)))
+	}
+	if connected == len(blocks) {
+		return
+	}
+
+	verified, err := cv.chainState.VerifyBlocks(blocks[connected:])
+	if err != nil {
+		i := connected + verified
+		markCorrupted(i, blocks[i].GetHash(), blocks[i].PrevBlockHash, fmt.Errorf("the UTXO set is not consistent: %w", err))
+	}
+}
+
+// tipHeight returns the height of the tip. The height is calculated from the best block of the UTXO set
+// when it is one of the checked blocks, otherwise the chain is walked back from the block with the
+// given hash (the previous block of the last checked one). It returns 0 when the height is unknown.
+func (cv *ChainVerifier) tipHeight(blocks []p2p.MsgBlock, hash, bestHash [32]byte, bestHeight int32) int32 {
+	for i, block := range blocks {
+		if block.GetHash() == bestHash {
+			return bestHeight + int32(i)
+		}
+	}
+
+	height := int32(len(blocks))
+	for hash != sync.GenesisBlockHash {
+		if hash == bestHash {
+			return height + bestHeight
+		}
+
+		block, err := cv.blockRepository.Get(hash)
+		if err != nil {
+			return 0
+		}
+		height++
+		hash = block.PrevBlockHash
+	}
+	return height
+}
+
+// verifyBlock checks the stored block with the given level of thoroughness.
+func verifyBlock(hash [32]byte, block p2p.MsgBlock, level int) error {
+	if block.GetHash() != hash {
+		return fmt.Errorf("the block is stored under hash %x, but its hash is %x", p2p.Reverse(hash), p2p.Reverse(block.GetHash()))
+	}
+	if level < VerifyLevelBlock {
+		return nil
+	}
+
+	if !blockHashLessThanTargetDifficulty(&block.BlockHeader) {
+		return errors.New("the block hash is above the target difficulty")
+	}
+	if !(BlockValidator{}).ValidateMerkleTree(&block) {
+		return errors.New("the merkle root doesn't match the transactions")
+	}
+	return nil
+}
+
+// Rollback disconnects from the indexes and the UTXO set and deletes the blocks from the tip down
+// to the block with the given hash (excluding), so it becomes the tip. The deleted blocks are downloaded
+// again when the node syncs. It returns the number of the deleted blocks.
+func (cv *ChainVerifier) Rollback(to [32]byte) (int, error) {
+	last, err := cv.blockRepository.GetLast()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last block: %w", err)
+	}
+
+	// read all blocks before changing anything, so a missing block doesn't leave the chain half rolled back
+	var blocks []p2p.MsgBlock
+	for block := last; block.GetHash() != to; {
+		blocks = append(blocks, block)
+		prev := block.PrevBlockHash
+		if prev == to {
+			break
+		}
+		if prev == sync.GenesisBlockHash {
+			return 0, fmt.Errorf("block %x is not in the chain", p2p.Reverse(to))
+		}
+
+		if block, err = cv.blockRepository.Get(prev); err != nil {
+			return 0, fmt.Errorf("failed to get block %x: %w", p2p.Reverse(prev), err)
+		}
+	}
+
+	for i, block := range blocks {
+		if err = cv.disconnectBlock(block); err != nil {
+			return i, err
+		}
+		log.Printf("rolled back block %x\n", p2p.Reverse(block.GetHash()))
+	}
+	return len(blocks), nil
+}
+
+// disconnectBlock removes the block from the indexes, the UTXO set and the block repository. It is
+// not disconnected from the indexes or the UTXO set that are behind the chain.
+func (cv *ChainVerifier) disconnectBlock(block p2p.MsgBlock) error {
+	hash := block.GetHash()
+	for _, indexer := range cv.indexers {
+		bestHash, _, err := indexer.BestBlock()
+		if err != nil {
+			return err
+		}
+		if bestHash != hash {
+			continue
+		}
+		if err = indexer.DisconnectBlock(block); err != nil {
+			return fmt.Errorf("failed to disconnect block %x from the index: %w", p2p.Reverse(hash), err)
+		}
+	}
+
+	bestHash, _, err := cv.chainState.BestBlock()
+	if err != nil {
+		return err
+	}
+	if bestHash == hash {
+		if err = cv.chainState.DisconnectBlock(block); err != nil {
+			return fmt.Errorf("failed to disconnect block %x from the UTXO set: %w", p2p.Reverse(hash), err)
+		}
+	}
+
+	if err = cv.blockRepository.Delete(hash); err != nil {
+		return fmt.Errorf("failed to delete block %x: %w", p2p.Reverse(hash), err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package node_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// newVerifiedChain returns a chain of n valid blocks and a repository that returns them. The blocks
+// for which corrupt returns true are stored with a changed transaction.
+func newVerifiedChain(ctrl *gomock.Controller, n int, corrupt func(height int) bool) ([]p2p.MsgBlock, *node.MockBlockRepository) {
+	blockRepo := node.NewMockBlockRepository(ctrl)
+	var chain []p2p.MsgBlock
+	prev := sync.GenesisBlockHash
+	for i := 0; i < n; i++ {
+		block := testutil.NewMsgBlockWithTxs(prev, testutil.NewMsgTx(coinbase, int64(i)))
+		block.MerkleRoot = node.CalcMerkleRoot(block.Transactions)
+		chain = append(chain, block)
+		prev = block.GetHash()
+
+		stored := block
+		if corrupt(i + 1) {
+			stored.Transactions = []p2p.MsgTx{testutil.NewMsgTx(coinbase, 1000)}
+		}
+		blockRepo.EXPECT().Get(block.GetHash()).Return(stored, nil).AnyTimes()
+	}
+	blockRepo.EXPECT().GetLast().Return(chain[n-1], nil).AnyTimes()
+	return chain, blockRepo
+}
+
+func reversed(blocks []p2p.MsgBlock) []p2p.MsgBlock {
+	r := make([]p2p.MsgBlock, 0, len(blocks))
+	for i := len(blocks) - 1; i >= 0; i-- {
+		r = append(r, blocks[i])
+	}
+	return r
+}
+
+func TestChainVerifier_VerifyValidChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain, blockRepo := newVerifiedChain(ctrl, 5, func(int) bool { return false })
+	chainState := node.NewMockVerifiableChainState(ctrl)
+	chainState.EXPECT().BestBlock().Return(chain[4].GetHash(), int32(5), nil)
+	chainState.EXPECT().VerifyBlocks(reversed(chain[2:])).Return(3, nil)
+
+	verifier := node.NewChainVerifier(blockRepo, chainState, nil)
+	corrupted, err := verifier.Verify(3, node.VerifyLevelUTXO)
+	require.NoError(t, err)
+	require.Nil(t, corrupted)
+}
+
+func TestChainVerifier_VerifyCorruptedBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain, blockRepo := newVerifiedChain(ctrl, 5, func(height int) bool { return height == 2 || height == 4 })
+	chainState := node.NewMockVerifiableChainState(ctrl)
+	chainState.EXPECT().BestBlock().Return(chain[4].GetHash(), int32(5), nil).AnyTimes()
+	verifier := node.NewChainVerifier(blockRepo, chainState, nil)
+
+	// the transactions are not checked at level 0
+	corrupted, err := verifier.Verify(0, node.VerifyLevelStorage)
+	require.NoError(t, err)
+	require.Nil(t, corrupted)
+
+	// the first corrupted block in the order of the chain is reported
+	corrupted, err = verifier.Verify(0, node.VerifyLevelBlock)
+	require.NoError(t, err)
+	require.NotNil(t, corrupted)
+	require.Equal(t, chain[1].GetHash(), corrupted.Hash)
+	require.Equal(t, chain[0].GetHash(), corrupted.PrevBlockHash)
+	require.Equal(t, int32(2), corrupted.Height)
+
+	// only the last 2 blocks are checked
+	corrupted, err = verifier.Verify(2, node.VerifyLevelBlock)
+	require.NoError(t, err)
+	require.NotNil(t, corrupted)
+	require.Equal(t, chain[3].GetHash(), corrupted.Hash)
+	require.Equal(t, int32(4), corrupted.Height)
+
+	_, err = verifier.Verify(2, 3)
+	require.Error(t, err)
+}
+
+func TestChainVerifier_VerifyUTXOSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain, blockRepo := newVerifiedChain(ctrl, 5, func(int) bool { return false })
+	chainState := node.NewMockVerifiableChainState(ctrl)
+	verifier := node.NewChainVerifier(blockRepo, chainState, nil)
+
+	// the UTXO set is not consistent with the block at height 3
+	chainState.EXPECT().BestBlock().Return(chain[4].GetHash(), int32(5), nil)
+	chainState.EXPECT().VerifyBlocks(reversed(chain)).Return(2, errors.New("missing output"))
+	corrupted, err := verifier.Verify(0, node.VerifyLevelUTXO)
+	require.NoError(t, err)
+	require.NotNil(t, corrupted)
+	require.Equal(t, chain[2].GetHash(), corrupted.Hash)
+	require.Equal(t, int32(3), corrupted.Height)
+
+	// the last 2 blocks are not connected to the UTXO set
+	chainState.EXPECT().BestBlock().Return(chain[2].GetHash(), int32(3), nil)
+	chainState.EXPECT().VerifyBlocks(reversed(chain[1:3])).Return(2, nil)
+	corrupted, err = verifier.Verify(4, node.VerifyLevelUTXO)
+	require.NoError(t, err)
+	require.NotNil(t, corrupted)
+	require.Equal(t, chain[3].GetHash(), corrupted.Hash)
+	require.Equal(t, int32(4), corrupted.Height)
+}
+
+func TestChainVerifier_Rollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain, blockRepo := newVerifiedChain(ctrl, 5, func(int) bool { return false })
+	chainState := node.NewMockVerifiableChainState(ctrl)
+	upToDate, behind := node.NewMockIndexer(ctrl), node.NewMockIndexer(ctrl)
+	verifier := node.NewChainVerifier(blockRepo, chainState, []node.Indexer{upToDate, behind})
+
+	behind.EXPECT().BestBlock().Return(chain[0].GetHash(), int32(1), nil).AnyTimes()
+	var calls []*gomock.Call
+	for i := 4; i >= 3; i-- {
+		hash := chain[i].GetHash()
+		calls = append(calls,
+			upToDate.EXPECT().BestBlock().Return(hash, int32(i+1), nil),
+			upToDate.EXPECT().DisconnectBlock(chain[i]).Return(nil),
+			chainState.EXPECT().BestBlock().Return(hash, int32(i+1), nil),
+			chainState.EXPECT().DisconnectBlock(chain[i]).Return(nil),
+			blockRepo.EXPECT().Delete(hash).Return(nil),
+		)
+	}
+	gomock.InOrder(calls...)
+
+	n, err := verifier.Rollback(chain[2].GetHash())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	_, err = verifier.Rollback([32]byte{1})
+	require.Error(t, err)
+}
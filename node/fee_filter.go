@@ -0,0 +1,55 @@
+package node
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// maxFeeFilter is the highest fee rate in satoshis per 1000 virtual bytes that is sent in a feefilter.
+	maxFeeFilter = 10_000_000
+	// feeFilterSpacing is the ratio between two consecutive fee rates to which the feefilter is rounded.
+	feeFilterSpacing = 1.1
+	// feeFilterInterval is the average interval at which the feefilter is sent to a peer.
+	feeFilterInterval = 10 * time.Minute
+	// maxFeeFilterChangeDelay is the maximum delay after which a feefilter is sent when the minimum fee rate
+	// of the mempool changes significantly.
+	maxFeeFilterChangeDelay = 5 * time.Minute
+)
+
+// feeFilterRounder rounds the fee rates that are sent in feefilter messages, so the minimum fee rate of the
+// mempool can't be used to fingerprint the node. A fee rate is rounded up to the next of the fee rates that
+// are 10% apart, and with 2/3 probability it is moved one fee rate down.
+type feeFilterRounder struct {
+	fees []int64
+}
+
+// newFeeFilterRounder returns a feeFilterRounder whose fee rates start from half of the min fee rate.
+func newFeeFilterRounder(minFeeRate int64) feeFilterRounder {
+	fees := []int64{0}
+	for f := max(1, float64(minFeeRate)/2); f <= maxFeeFilter; f *= feeFilterSpacing {
+		fees = append(fees, int64(math.Round(f)))
+	}
+	return feeFilterRounder{fees: fees}
+}
+
+func (r feeFilterRounder) round(feeRate int64) int64 {
+	i := len(r.fees)
+	for j, f := range r.fees {
+		if f >= feeRate {
+			i = j
+			break
+		}
+	}
+	if i == len(r.fees) || (i > 0 && rand.Intn(3) != 0) {
+		i--
+	}
+	return r.fees[i]
+}
+
+// nextFeeFilterTime returns the time of the next feefilter, it is exponentially distributed with an average
+// of feeFilterInterval, so the peers can't tell when the fee rate is changed.
+func nextFeeFilterTime(now time.Time) time.Time {
+	return now.Add(time.Duration(rand.ExpFloat64() * float64(feeFilterInterval)))
+}
@@ -4,13 +4,91 @@ import (
 	"net"
 
 	"github.com/EmilGeorgiev/btc-node/common"
+	"github.com/EmilGeorgiev/btc-node/mempool"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 )
 
-type BlockRepository interface {
-	Save(block p2p.MsgBlock) error
-	Get(key [32]byte) (p2p.MsgBlock, error)
-	GetLast() (p2p.MsgBlock, error)
+// ChainState keeps the UTXO set in sync with the blocks that are saved.
+type ChainState interface {
+	ConnectBlock(block p2p.MsgBlock) error
+	BestBlock() ([32]byte, int32, error)
+}
+
+// VerifiableChainState is a ChainState whose consistency with the stored blocks can be verified
+// and whose blocks can be disconnected when the chain is rolled back.
+type VerifiableChainState interface {
+	ChainState
+	DisconnectBlock(block p2p.MsgBlock) error
+	VerifyBlocks(blocks []p2p.MsgBlock) (int, error)
+}
+
+// BackgroundChainState is the UTXO set that is built from the history of the chain to validate a snapshot.
+type BackgroundChainState interface {
+	ChainState
+	HashSerialized() ([32]byte, error)
+}
+
+// Indexer is an optional index that is updated with every block that is connected to the chain.
+type Indexer interface {
+	ConnectBlock(block p2p.MsgBlock) error
+	DisconnectBlock(block p2p.MsgBlock) error
+	BestBlock() ([32]byte, int32, error)
+}
+
+// Mempool keeps the unconfirmed transactions that are relayed to the peers. ProcessTx accepts the
+// transactions that are received from the peers with the orphans that spend from them, and returns
+// the missing parents of an orphan. SubmitTx accepts the transactions that are broadcast by the node
+// itself, the fee rate of which is limited by maxFeeRate. AcceptPackage accepts a child with its
+// parents on the fee rate of the package, and AncestorPackage returns the ancestors of a transaction
+// in the mempool that are relayed with it. MinFeeRate is sent to the peers in the feefilter.
+type Mempool interface {
+	ProcessTx(tx p2p.MsgTx, peer string) ([]mempool.TxDesc, [][32]byte, error)
+	AcceptPackage(txs []p2p.MsgTx) ([]mempool.TxDesc, error)
+	AncestorPackage(wtxid [32]byte) ([]mempool.TxDesc, bool)
+	SubmitTx(tx p2p.MsgTx, maxFeeRate int64) (mempool.TxDesc, error)
+	Get(txid [32]byte) (mempool.TxDesc, bool)
+	GetByWTxID(wtxid [32]byte) (mempool.TxDesc, bool)
+	HaveOrphan(hash [32]byte) bool
+	RemoveOrphansForPeer(peer string)
+	MinFeeRate() int64
+}
+
+// Persister saves a state that is kept in memory, so it can be loaded when the node is started again.
+type Persister interface {
+	Persist() error
+}
+
+// BlockPruner deletes the raw data of the old blocks when the node runs in pruned mode.
+type BlockPruner interface {
+	Prune() error
+}
+
+// PrunableBlockRepository is a sync.BlockRepository that can delete the raw data of the blocks
+// up to a given height until the stored data is below the target size in bytes.
+type PrunableBlockRepository interface {
+	Prune(target uint64, pruneHeight int32) error
+}
+
+// BlockProcessor validates and saves a downloaded block and connects it to the chain state.
+type BlockProcessor interface {
+	ProcessBlock(block *p2p.MsgBlock) error
+}
+
+// ChainRewinder rolls the chain back to the block with the given hash, so it becomes the tip. It returns
+// the number of the blocks that are rolled back.
+type ChainRewinder interface {
+	Rollback(to [32]byte) (int, error)
+}
+
+// InFlightBlocks returns the number of the blocks that are requested from the peer with the given address and are
+// not received yet.
+type InFlightBlocks interface {
+	BlocksInFlight(addr string) int
+}
+
+// BlockScheduler is notified when new headers are stored, so it can request their blocks.
+type BlockScheduler interface {
+	HeadersStored()
 }
 
 type HandshakeManager interface {
@@ -35,7 +113,10 @@ type MsgHandlersManager interface {
 type SyncManager interface {
 	StartStop
 
-	StartChainOverview(peerAddr string, cho chan common.ChainOverview)
+	StartChainOverview(peerAddr string, startHeight int32, cho chan common.ChainOverview)
+
+	// BlocksAnnounced requests the headers of the blocks that the peer announced, unless they are synced.
+	BlocksAnnounced()
 }
 
 // PeerConnectionManager defines the interface for managing peer connections in a Bitcoin network.
@@ -45,15 +126,32 @@ type PeerConnectionManager interface {
 	// Sync initializes sync with the node to which the current implementation is connected.
 	Sync()
 
-	// StopSync ...
+	// StopSync stops the sync with the peer when another peer becomes the sync peer.
 	StopSync()
 
+	// DownloadBlocks adds the peer to the block download without syncing the headers from it.
+	DownloadBlocks()
+
 	GetPeerAddr() string
 
+	// BytesReceived returns the number of bytes that are received from the peer, it is used to measure
+	// its throughput.
+	BytesReceived() uint64
+
+	// Tip returns the best header of the peer that is validated and connected to the local header chain.
+	Tip() PeerTip
+
+	// Info returns the information about the peer and its connection.
+	Info() PeerInfo
+
 	GetChainOverview() (<-chan common.ChainOverview, error)
 }
 
 type NetworkMessageHandler interface {
 	ReadMessage(conn net.Conn) (interface{}, error)
 	WriteMessage(msg *p2p.Message, conn net.Conn) error
+	// BytesRead returns the number of bytes of the messages that are read.
+	BytesRead() uint64
+	// BytesWritten returns the number of bytes of the messages that are written.
+	BytesWritten() uint64
 }
@@ -1,3 +1,4 @@
 package node
 
 //go:generate mockgen -source=interfaces.go -destination=mocks_node_test.go -package=$GOPACKAGE
+//go:generate mockgen -destination=mocks_sync_test.go -package=$GOPACKAGE github.com/EmilGeorgiev/btc-node/sync BlockRepository,HeaderRepository,ChainTip
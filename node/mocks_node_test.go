@@ -9,75 +9,691 @@ import (
 	reflect "reflect"
 
 	common "github.com/EmilGeorgiev/btc-node/common"
+	mempool "github.com/EmilGeorgiev/btc-node/mempool"
 	p2p "github.com/EmilGeorgiev/btc-node/network/p2p"
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockBlockRepository is a mock of BlockRepository interface.
-type MockBlockRepository struct {
+// MockChainState is a mock of ChainState interface.
+type MockChainState struct {
 	ctrl     *gomock.Controller
-	recorder *MockBlockRepositoryMockRecorder
+	recorder *MockChainStateMockRecorder
 }
 
-// MockBlockRepositoryMockRecorder is the mock recorder for MockBlockRepository.
-type MockBlockRepositoryMockRecorder struct {
-	mock *MockBlockRepository
+// MockChainStateMockRecorder is the mock recorder for MockChainState.
+type MockChainStateMockRecorder struct {
+	mock *MockChainState
 }
 
-// NewMockBlockRepository creates a new mock instance.
-func NewMockBlockRepository(ctrl *gomock.Controller) *MockBlockRepository {
-	mock := &MockBlockRepository{ctrl: ctrl}
-	mock.recorder = &MockBlockRepositoryMockRecorder{mock}
+// NewMockChainState creates a new mock instance.
+func NewMockChainState(ctrl *gomock.Controller) *MockChainState {
+	mock := &MockChainState{ctrl: ctrl}
+	mock.recorder = &MockChainStateMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockBlockRepository) EXPECT() *MockBlockRepositoryMockRecorder {
+func (m *MockChainState) EXPECT() *MockChainStateMockRecorder {
 	return m.recorder
 }
 
-// Get mocks base method.
-func (m *MockBlockRepository) Get(key [32]byte) (p2p.MsgBlock, error) {
+// BestBlock mocks base method.
+func (m *MockChainState) BestBlock() ([32]byte, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlock")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestBlock indicates an expected call of BestBlock.
+func (mr *MockChainStateMockRecorder) BestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlock", reflect.TypeOf((*MockChainState)(nil).BestBlock))
+}
+
+// ConnectBlock mocks base method.
+func (m *MockChainState) ConnectBlock(block p2p.MsgBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectBlock indicates an expected call of ConnectBlock.
+func (mr *MockChainStateMockRecorder) ConnectBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectBlock", reflect.TypeOf((*MockChainState)(nil).ConnectBlock), block)
+}
+
+// MockVerifiableChainState is a mock of VerifiableChainState interface.
+type MockVerifiableChainState struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerifiableChainStateMockRecorder
+}
+
+// MockVerifiableChainStateMockRecorder is the mock recorder for MockVerifiableChainState.
+type MockVerifiableChainStateMockRecorder struct {
+	mock *MockVerifiableChainState
+}
+
+// NewMockVerifiableChainState creates a new mock instance.
+func NewMockVerifiableChainState(ctrl *gomock.Controller) *MockVerifiableChainState {
+	mock := &MockVerifiableChainState{ctrl: ctrl}
+	mock.recorder = &MockVerifiableChainStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerifiableChainState) EXPECT() *MockVerifiableChainStateMockRecorder {
+	return m.recorder
+}
+
+// BestBlock mocks base method.
+func (m *MockVerifiableChainState) BestBlock() ([32]byte, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlock")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestBlock indicates an expected call of BestBlock.
+func (mr *MockVerifiableChainStateMockRecorder) BestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlock", reflect.TypeOf((*MockVerifiableChainState)(nil).BestBlock))
+}
+
+// ConnectBlock mocks base method.
+func (m *MockVerifiableChainState) ConnectBlock(block p2p.MsgBlock) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", key)
-	ret0, _ := ret[0].(p2p.MsgBlock)
+	ret := m.ctrl.Call(m, "ConnectBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectBlock indicates an expected call of ConnectBlock.
+func (mr *MockVerifiableChainStateMockRecorder) ConnectBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectBlock", reflect.TypeOf((*MockVerifiableChainState)(nil).ConnectBlock), block)
+}
+
+// DisconnectBlock mocks base method.
+func (m *MockVerifiableChainState) DisconnectBlock(block p2p.MsgBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisconnectBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisconnectBlock indicates an expected call of DisconnectBlock.
+func (mr *MockVerifiableChainStateMockRecorder) DisconnectBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectBlock", reflect.TypeOf((*MockVerifiableChainState)(nil).DisconnectBlock), block)
+}
+
+// VerifyBlocks mocks base method.
+func (m *MockVerifiableChainState) VerifyBlocks(blocks []p2p.MsgBlock) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyBlocks", blocks)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// VerifyBlocks indicates an expected call of VerifyBlocks.
+func (mr *MockVerifiableChainStateMockRecorder) VerifyBlocks(blocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlocks", reflect.TypeOf((*MockVerifiableChainState)(nil).VerifyBlocks), blocks)
+}
+
+// MockBackgroundChainState is a mock of BackgroundChainState interface.
+type MockBackgroundChainState struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackgroundChainStateMockRecorder
+}
+
+// MockBackgroundChainStateMockRecorder is the mock recorder for MockBackgroundChainState.
+type MockBackgroundChainStateMockRecorder struct {
+	mock *MockBackgroundChainState
+}
+
+// NewMockBackgroundChainState creates a new mock instance.
+func NewMockBackgroundChainState(ctrl *gomock.Controller) *MockBackgroundChainState {
+	mock := &MockBackgroundChainState{ctrl: ctrl}
+	mock.recorder = &MockBackgroundChainStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackgroundChainState) EXPECT() *MockBackgroundChainStateMockRecorder {
+	return m.recorder
+}
+
+// BestBlock mocks base method.
+func (m *MockBackgroundChainState) BestBlock() ([32]byte, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlock")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestBlock indicates an expected call of BestBlock.
+func (mr *MockBackgroundChainStateMockRecorder) BestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlock", reflect.TypeOf((*MockBackgroundChainState)(nil).BestBlock))
+}
+
+// ConnectBlock mocks base method.
+func (m *MockBackgroundChainState) ConnectBlock(block p2p.MsgBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectBlock indicates an expected call of ConnectBlock.
+func (mr *MockBackgroundChainStateMockRecorder) ConnectBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectBlock", reflect.TypeOf((*MockBackgroundChainState)(nil).ConnectBlock), block)
+}
+
+// HashSerialized mocks base method.
+func (m *MockBackgroundChainState) HashSerialized() ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashSerialized")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HashSerialized indicates an expected call of HashSerialized.
+func (mr *MockBackgroundChainStateMockRecorder) HashSerialized() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashSerialized", reflect.TypeOf((*MockBackgroundChainState)(nil).HashSerialized))
+}
+
+// MockIndexer is a mock of Indexer interface.
+type MockIndexer struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexerMockRecorder
+}
+
+// MockIndexerMockRecorder is the mock recorder for MockIndexer.
+type MockIndexerMockRecorder struct {
+	mock *MockIndexer
+}
+
+// NewMockIndexer creates a new mock instance.
+func NewMockIndexer(ctrl *gomock.Controller) *MockIndexer {
+	mock := &MockIndexer{ctrl: ctrl}
+	mock.recorder = &MockIndexerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexer) EXPECT() *MockIndexerMockRecorder {
+	return m.recorder
+}
+
+// BestBlock mocks base method.
+func (m *MockIndexer) BestBlock() ([32]byte, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlock")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestBlock indicates an expected call of BestBlock.
+func (mr *MockIndexerMockRecorder) BestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlock", reflect.TypeOf((*MockIndexer)(nil).BestBlock))
+}
+
+// ConnectBlock mocks base method.
+func (m *MockIndexer) ConnectBlock(block p2p.MsgBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectBlock indicates an expected call of ConnectBlock.
+func (mr *MockIndexerMockRecorder) ConnectBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectBlock", reflect.TypeOf((*MockIndexer)(nil).ConnectBlock), block)
+}
+
+// DisconnectBlock mocks base method.
+func (m *MockIndexer) DisconnectBlock(block p2p.MsgBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisconnectBlock", block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisconnectBlock indicates an expected call of DisconnectBlock.
+func (mr *MockIndexerMockRecorder) DisconnectBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectBlock", reflect.TypeOf((*MockIndexer)(nil).DisconnectBlock), block)
+}
+
+// MockMempool is a mock of Mempool interface.
+type MockMempool struct {
+	ctrl     *gomock.Controller
+	recorder *MockMempoolMockRecorder
+}
+
+// MockMempoolMockRecorder is the mock recorder for MockMempool.
+type MockMempoolMockRecorder struct {
+	mock *MockMempool
+}
+
+// NewMockMempool creates a new mock instance.
+func NewMockMempool(ctrl *gomock.Controller) *MockMempool {
+	mock := &MockMempool{ctrl: ctrl}
+	mock.recorder = &MockMempoolMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMempool) EXPECT() *MockMempoolMockRecorder {
+	return m.recorder
+}
+
+// AcceptPackage mocks base method.
+func (m *MockMempool) AcceptPackage(txs []p2p.MsgTx) ([]mempool.TxDesc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptPackage", txs)
+	ret0, _ := ret[0].([]mempool.TxDesc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptPackage indicates an expected call of AcceptPackage.
+func (mr *MockMempoolMockRecorder) AcceptPackage(txs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptPackage", reflect.TypeOf((*MockMempool)(nil).AcceptPackage), txs)
+}
+
+// AncestorPackage mocks base method.
+func (m *MockMempool) AncestorPackage(wtxid [32]byte) ([]mempool.TxDesc, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AncestorPackage", wtxid)
+	ret0, _ := ret[0].([]mempool.TxDesc)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// AncestorPackage indicates an expected call of AncestorPackage.
+func (mr *MockMempoolMockRecorder) AncestorPackage(wtxid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AncestorPackage", reflect.TypeOf((*MockMempool)(nil).AncestorPackage), wtxid)
+}
+
+// Get mocks base method.
+func (m *MockMempool) Get(txid [32]byte) (mempool.TxDesc, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", txid)
+	ret0, _ := ret[0].(mempool.TxDesc)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
 // Get indicates an expected call of Get.
-func (mr *MockBlockRepositoryMockRecorder) Get(key interface{}) *gomock.Call {
+func (mr *MockMempoolMockRecorder) Get(txid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMempool)(nil).Get), txid)
+}
+
+// GetByWTxID mocks base method.
+func (m *MockMempool) GetByWTxID(wtxid [32]byte) (mempool.TxDesc, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWTxID", wtxid)
+	ret0, _ := ret[0].(mempool.TxDesc)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetByWTxID indicates an expected call of GetByWTxID.
+func (mr *MockMempoolMockRecorder) GetByWTxID(wtxid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlockRepository)(nil).Get), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWTxID", reflect.TypeOf((*MockMempool)(nil).GetByWTxID), wtxid)
 }
 
-// GetLast mocks base method.
-func (m *MockBlockRepository) GetLast() (p2p.MsgBlock, error) {
+// HaveOrphan mocks base method.
+func (m *MockMempool) HaveOrphan(hash [32]byte) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLast")
-	ret0, _ := ret[0].(p2p.MsgBlock)
+	ret := m.ctrl.Call(m, "HaveOrphan", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HaveOrphan indicates an expected call of HaveOrphan.
+func (mr *MockMempoolMockRecorder) HaveOrphan(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HaveOrphan", reflect.TypeOf((*MockMempool)(nil).HaveOrphan), hash)
+}
+
+// MinFeeRate mocks base method.
+func (m *MockMempool) MinFeeRate() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinFeeRate")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MinFeeRate indicates an expected call of MinFeeRate.
+func (mr *MockMempoolMockRecorder) MinFeeRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinFeeRate", reflect.TypeOf((*MockMempool)(nil).MinFeeRate))
+}
+
+// ProcessTx mocks base method.
+func (m *MockMempool) ProcessTx(tx p2p.MsgTx, peer string) ([]mempool.TxDesc, [][32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessTx", tx, peer)
+	ret0, _ := ret[0].([]mempool.TxDesc)
+	ret1, _ := ret[1].([][32]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ProcessTx indicates an expected call of ProcessTx.
+func (mr *MockMempoolMockRecorder) ProcessTx(tx, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTx", reflect.TypeOf((*MockMempool)(nil).ProcessTx), tx, peer)
+}
+
+// RemoveOrphansForPeer mocks base method.
+func (m *MockMempool) RemoveOrphansForPeer(peer string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveOrphansForPeer", peer)
+}
+
+// RemoveOrphansForPeer indicates an expected call of RemoveOrphansForPeer.
+func (mr *MockMempoolMockRecorder) RemoveOrphansForPeer(peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrphansForPeer", reflect.TypeOf((*MockMempool)(nil).RemoveOrphansForPeer), peer)
+}
+
+// SubmitTx mocks base method.
+func (m *MockMempool) SubmitTx(tx p2p.MsgTx, maxFeeRate int64) (mempool.TxDesc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTx", tx, maxFeeRate)
+	ret0, _ := ret[0].(mempool.TxDesc)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLast indicates an expected call of GetLast.
-func (mr *MockBlockRepositoryMockRecorder) GetLast() *gomock.Call {
+// SubmitTx indicates an expected call of SubmitTx.
+func (mr *MockMempoolMockRecorder) SubmitTx(tx, maxFeeRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTx", reflect.TypeOf((*MockMempool)(nil).SubmitTx), tx, maxFeeRate)
+}
+
+// MockPersister is a mock of Persister interface.
+type MockPersister struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersisterMockRecorder
+}
+
+// MockPersisterMockRecorder is the mock recorder for MockPersister.
+type MockPersisterMockRecorder struct {
+	mock *MockPersister
+}
+
+// NewMockPersister creates a new mock instance.
+func NewMockPersister(ctrl *gomock.Controller) *MockPersister {
+	mock := &MockPersister{ctrl: ctrl}
+	mock.recorder = &MockPersisterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersister) EXPECT() *MockPersisterMockRecorder {
+	return m.recorder
+}
+
+// Persist mocks base method.
+func (m *MockPersister) Persist() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Persist")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Persist indicates an expected call of Persist.
+func (mr *MockPersisterMockRecorder) Persist() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Persist", reflect.TypeOf((*MockPersister)(nil).Persist))
+}
+
+// MockBlockPruner is a mock of BlockPruner interface.
+type MockBlockPruner struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockPrunerMockRecorder
+}
+
+// MockBlockPrunerMockRecorder is the mock recorder for MockBlockPruner.
+type MockBlockPrunerMockRecorder struct {
+	mock *MockBlockPruner
+}
+
+// NewMockBlockPruner creates a new mock instance.
+func NewMockBlockPruner(ctrl *gomock.Controller) *MockBlockPruner {
+	mock := &MockBlockPruner{ctrl: ctrl}
+	mock.recorder = &MockBlockPrunerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockPruner) EXPECT() *MockBlockPrunerMockRecorder {
+	return m.recorder
+}
+
+// Prune mocks base method.
+func (m *MockBlockPruner) Prune() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockBlockPrunerMockRecorder) Prune() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockBlockPruner)(nil).Prune))
+}
+
+// MockPrunableBlockRepository is a mock of PrunableBlockRepository interface.
+type MockPrunableBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrunableBlockRepositoryMockRecorder
+}
+
+// MockPrunableBlockRepositoryMockRecorder is the mock recorder for MockPrunableBlockRepository.
+type MockPrunableBlockRepositoryMockRecorder struct {
+	mock *MockPrunableBlockRepository
+}
+
+// NewMockPrunableBlockRepository creates a new mock instance.
+func NewMockPrunableBlockRepository(ctrl *gomock.Controller) *MockPrunableBlockRepository {
+	mock := &MockPrunableBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockPrunableBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrunableBlockRepository) EXPECT() *MockPrunableBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Prune mocks base method.
+func (m *MockPrunableBlockRepository) Prune(target uint64, pruneHeight int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", target, pruneHeight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockPrunableBlockRepositoryMockRecorder) Prune(target, pruneHeight interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLast", reflect.TypeOf((*MockBlockRepository)(nil).GetLast))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockPrunableBlockRepository)(nil).Prune), target, pruneHeight)
 }
 
-// Save mocks base method.
-func (m *MockBlockRepository) Save(block p2p.MsgBlock) error {
+// MockBlockProcessor is a mock of BlockProcessor interface.
+type MockBlockProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockProcessorMockRecorder
+}
+
+// MockBlockProcessorMockRecorder is the mock recorder for MockBlockProcessor.
+type MockBlockProcessorMockRecorder struct {
+	mock *MockBlockProcessor
+}
+
+// NewMockBlockProcessor creates a new mock instance.
+func NewMockBlockProcessor(ctrl *gomock.Controller) *MockBlockProcessor {
+	mock := &MockBlockProcessor{ctrl: ctrl}
+	mock.recorder = &MockBlockProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockProcessor) EXPECT() *MockBlockProcessorMockRecorder {
+	return m.recorder
+}
+
+// ProcessBlock mocks base method.
+func (m *MockBlockProcessor) ProcessBlock(block *p2p.MsgBlock) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", block)
+	ret := m.ctrl.Call(m, "ProcessBlock", block)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Save indicates an expected call of Save.
-func (mr *MockBlockRepositoryMockRecorder) Save(block interface{}) *gomock.Call {
+// ProcessBlock indicates an expected call of ProcessBlock.
+func (mr *MockBlockProcessorMockRecorder) ProcessBlock(block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlock", reflect.TypeOf((*MockBlockProcessor)(nil).ProcessBlock), block)
+}
+
+// MockChainRewinder is a mock of ChainRewinder interface.
+type MockChainRewinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainRewinderMockRecorder
+}
+
+// MockChainRewinderMockRecorder is the mock recorder for MockChainRewinder.
+type MockChainRewinderMockRecorder struct {
+	mock *MockChainRewinder
+}
+
+// NewMockChainRewinder creates a new mock instance.
+func NewMockChainRewinder(ctrl *gomock.Controller) *MockChainRewinder {
+	mock := &MockChainRewinder{ctrl: ctrl}
+	mock.recorder = &MockChainRewinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainRewinder) EXPECT() *MockChainRewinderMockRecorder {
+	return m.recorder
+}
+
+// Rollback mocks base method.
+func (m *MockChainRewinder) Rollback(to [32]byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockChainRewinderMockRecorder) Rollback(to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockChainRewinder)(nil).Rollback), to)
+}
+
+// MockInFlightBlocks is a mock of InFlightBlocks interface.
+type MockInFlightBlocks struct {
+	ctrl     *gomock.Controller
+	recorder *MockInFlightBlocksMockRecorder
+}
+
+// MockInFlightBlocksMockRecorder is the mock recorder for MockInFlightBlocks.
+type MockInFlightBlocksMockRecorder struct {
+	mock *MockInFlightBlocks
+}
+
+// NewMockInFlightBlocks creates a new mock instance.
+func NewMockInFlightBlocks(ctrl *gomock.Controller) *MockInFlightBlocks {
+	mock := &MockInFlightBlocks{ctrl: ctrl}
+	mock.recorder = &MockInFlightBlocksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInFlightBlocks) EXPECT() *MockInFlightBlocksMockRecorder {
+	return m.recorder
+}
+
+// BlocksInFlight mocks base method.
+func (m *MockInFlightBlocks) BlocksInFlight(addr string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlocksInFlight", addr)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// BlocksInFlight indicates an expected call of BlocksInFlight.
+func (mr *MockInFlightBlocksMockRecorder) BlocksInFlight(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlocksInFlight", reflect.TypeOf((*MockInFlightBlocks)(nil).BlocksInFlight), addr)
+}
+
+// MockBlockScheduler is a mock of BlockScheduler interface.
+type MockBlockScheduler struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockSchedulerMockRecorder
+}
+
+// MockBlockSchedulerMockRecorder is the mock recorder for MockBlockScheduler.
+type MockBlockSchedulerMockRecorder struct {
+	mock *MockBlockScheduler
+}
+
+// NewMockBlockScheduler creates a new mock instance.
+func NewMockBlockScheduler(ctrl *gomock.Controller) *MockBlockScheduler {
+	mock := &MockBlockScheduler{ctrl: ctrl}
+	mock.recorder = &MockBlockSchedulerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockScheduler) EXPECT() *MockBlockSchedulerMockRecorder {
+	return m.recorder
+}
+
+// HeadersStored mocks base method.
+func (m *MockBlockScheduler) HeadersStored() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HeadersStored")
+}
+
+// HeadersStored indicates an expected call of HeadersStored.
+func (mr *MockBlockSchedulerMockRecorder) HeadersStored() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBlockRepository)(nil).Save), block)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadersStored", reflect.TypeOf((*MockBlockScheduler)(nil).HeadersStored))
 }
 
 // MockHandshakeManager is a mock of HandshakeManager interface.
@@ -299,6 +915,18 @@ func (m *MockSyncManager) EXPECT() *MockSyncManagerMockRecorder {
 	return m.recorder
 }
 
+// BlocksAnnounced mocks base method.
+func (m *MockSyncManager) BlocksAnnounced() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BlocksAnnounced")
+}
+
+// BlocksAnnounced indicates an expected call of BlocksAnnounced.
+func (mr *MockSyncManagerMockRecorder) BlocksAnnounced() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlocksAnnounced", reflect.TypeOf((*MockSyncManager)(nil).BlocksAnnounced))
+}
+
 // Start mocks base method.
 func (m *MockSyncManager) Start() {
 	m.ctrl.T.Helper()
@@ -312,15 +940,15 @@ func (mr *MockSyncManagerMockRecorder) Start() *gomock.Call {
 }
 
 // StartChainOverview mocks base method.
-func (m *MockSyncManager) StartChainOverview(peerAddr string, cho chan common.ChainOverview) {
+func (m *MockSyncManager) StartChainOverview(peerAddr string, startHeight int32, cho chan common.ChainOverview) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "StartChainOverview", peerAddr, cho)
+	m.ctrl.Call(m, "StartChainOverview", peerAddr, startHeight, cho)
 }
 
 // StartChainOverview indicates an expected call of StartChainOverview.
-func (mr *MockSyncManagerMockRecorder) StartChainOverview(peerAddr, cho interface{}) *gomock.Call {
+func (mr *MockSyncManagerMockRecorder) StartChainOverview(peerAddr, startHeight, cho interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartChainOverview", reflect.TypeOf((*MockSyncManager)(nil).StartChainOverview), peerAddr, cho)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartChainOverview", reflect.TypeOf((*MockSyncManager)(nil).StartChainOverview), peerAddr, startHeight, cho)
 }
 
 // Stop mocks base method.
@@ -358,6 +986,32 @@ func (m *MockPeerConnectionManager) EXPECT() *MockPeerConnectionManagerMockRecor
 	return m.recorder
 }
 
+// BytesReceived mocks base method.
+func (m *MockPeerConnectionManager) BytesReceived() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesReceived")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// BytesReceived indicates an expected call of BytesReceived.
+func (mr *MockPeerConnectionManagerMockRecorder) BytesReceived() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesReceived", reflect.TypeOf((*MockPeerConnectionManager)(nil).BytesReceived))
+}
+
+// DownloadBlocks mocks base method.
+func (m *MockPeerConnectionManager) DownloadBlocks() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DownloadBlocks")
+}
+
+// DownloadBlocks indicates an expected call of DownloadBlocks.
+func (mr *MockPeerConnectionManagerMockRecorder) DownloadBlocks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadBlocks", reflect.TypeOf((*MockPeerConnectionManager)(nil).DownloadBlocks))
+}
+
 // GetChainOverview mocks base method.
 func (m *MockPeerConnectionManager) GetChainOverview() (<-chan common.ChainOverview, error) {
 	m.ctrl.T.Helper()
@@ -387,6 +1041,20 @@ func (mr *MockPeerConnectionManagerMockRecorder) GetPeerAddr() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeerAddr", reflect.TypeOf((*MockPeerConnectionManager)(nil).GetPeerAddr))
 }
 
+// Info mocks base method.
+func (m *MockPeerConnectionManager) Info() PeerInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info")
+	ret0, _ := ret[0].(PeerInfo)
+	return ret0
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockPeerConnectionManagerMockRecorder) Info() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockPeerConnectionManager)(nil).Info))
+}
+
 // Start mocks base method.
 func (m *MockPeerConnectionManager) Start() {
 	m.ctrl.T.Helper()
@@ -435,6 +1103,20 @@ func (mr *MockPeerConnectionManagerMockRecorder) Sync() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockPeerConnectionManager)(nil).Sync))
 }
 
+// Tip mocks base method.
+func (m *MockPeerConnectionManager) Tip() PeerTip {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tip")
+	ret0, _ := ret[0].(PeerTip)
+	return ret0
+}
+
+// Tip indicates an expected call of Tip.
+func (mr *MockPeerConnectionManagerMockRecorder) Tip() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tip", reflect.TypeOf((*MockPeerConnectionManager)(nil).Tip))
+}
+
 // MockNetworkMessageHandler is a mock of NetworkMessageHandler interface.
 type MockNetworkMessageHandler struct {
 	ctrl     *gomock.Controller
@@ -458,6 +1140,34 @@ func (m *MockNetworkMessageHandler) EXPECT() *MockNetworkMessageHandlerMockRecor
 	return m.recorder
 }
 
+// BytesRead mocks base method.
+func (m *MockNetworkMessageHandler) BytesRead() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesRead")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// BytesRead indicates an expected call of BytesRead.
+func (mr *MockNetworkMessageHandlerMockRecorder) BytesRead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesRead", reflect.TypeOf((*MockNetworkMessageHandler)(nil).BytesRead))
+}
+
+// BytesWritten mocks base method.
+func (m *MockNetworkMessageHandler) BytesWritten() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesWritten")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// BytesWritten indicates an expected call of BytesWritten.
+func (mr *MockNetworkMessageHandlerMockRecorder) BytesWritten() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesWritten", reflect.TypeOf((*MockNetworkMessageHandler)(nil).BytesWritten))
+}
+
 // ReadMessage mocks base method.
 func (m *MockNetworkMessageHandler) ReadMessage(conn net.Conn) (interface{}, error) {
 	m.ctrl.T.Helper()
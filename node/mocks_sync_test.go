@@ -0,0 +1,261 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/EmilGeorgiev/btc-node/sync (interfaces: BlockRepository,HeaderRepository,ChainTip)
+
+// Package node is a generated GoMock package.
+package node
+
+import (
+	big "math/big"
+	reflect "reflect"
+
+	p2p "github.com/EmilGeorgiev/btc-node/network/p2p"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlockRepository is a mock of BlockRepository interface.
+type MockBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockRepositoryMockRecorder
+}
+
+// MockBlockRepositoryMockRecorder is the mock recorder for MockBlockRepository.
+type MockBlockRepositoryMockRecorder struct {
+	mock *MockBlockRepository
+}
+
+// NewMockBlockRepository creates a new mock instance.
+func NewMockBlockRepository(ctrl *gomock.Controller) *MockBlockRepository {
+	mock := &MockBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockRepository) EXPECT() *MockBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlockRepository) Delete(arg0 [32]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlockRepositoryMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlockRepository)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockBlockRepository) Get(arg0 [32]byte) (p2p.MsgBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(p2p.MsgBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlockRepositoryMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlockRepository)(nil).Get), arg0)
+}
+
+// GetLast mocks base method.
+func (m *MockBlockRepository) GetLast() (p2p.MsgBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLast")
+	ret0, _ := ret[0].(p2p.MsgBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLast indicates an expected call of GetLast.
+func (mr *MockBlockRepositoryMockRecorder) GetLast() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLast", reflect.TypeOf((*MockBlockRepository)(nil).GetLast))
+}
+
+// Save mocks base method.
+func (m *MockBlockRepository) Save(arg0 p2p.MsgBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBlockRepositoryMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBlockRepository)(nil).Save), arg0)
+}
+
+// MockHeaderRepository is a mock of HeaderRepository interface.
+type MockHeaderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeaderRepositoryMockRecorder
+}
+
+// MockHeaderRepositoryMockRecorder is the mock recorder for MockHeaderRepository.
+type MockHeaderRepositoryMockRecorder struct {
+	mock *MockHeaderRepository
+}
+
+// NewMockHeaderRepository creates a new mock instance.
+func NewMockHeaderRepository(ctrl *gomock.Controller) *MockHeaderRepository {
+	mock := &MockHeaderRepository{ctrl: ctrl}
+	mock.recorder = &MockHeaderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeaderRepository) EXPECT() *MockHeaderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddHeaders mocks base method.
+func (m *MockHeaderRepository) AddHeaders(arg0 []p2p.BlockHeader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHeaders", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddHeaders indicates an expected call of AddHeaders.
+func (mr *MockHeaderRepositoryMockRecorder) AddHeaders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHeaders", reflect.TypeOf((*MockHeaderRepository)(nil).AddHeaders), arg0)
+}
+
+// BestHeader mocks base method.
+func (m *MockHeaderRepository) BestHeader() (p2p.BlockHeader, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestHeader")
+	ret0, _ := ret[0].(p2p.BlockHeader)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestHeader indicates an expected call of BestHeader.
+func (mr *MockHeaderRepositoryMockRecorder) BestHeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestHeader", reflect.TypeOf((*MockHeaderRepository)(nil).BestHeader))
+}
+
+// BlockLocator mocks base method.
+func (m *MockHeaderRepository) BlockLocator() ([][32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockLocator")
+	ret0, _ := ret[0].([][32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockLocator indicates an expected call of BlockLocator.
+func (mr *MockHeaderRepositoryMockRecorder) BlockLocator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockLocator", reflect.TypeOf((*MockHeaderRepository)(nil).BlockLocator))
+}
+
+// ChainWork mocks base method.
+func (m *MockHeaderRepository) ChainWork(arg0 [32]byte) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainWork", arg0)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainWork indicates an expected call of ChainWork.
+func (mr *MockHeaderRepositoryMockRecorder) ChainWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainWork", reflect.TypeOf((*MockHeaderRepository)(nil).ChainWork), arg0)
+}
+
+// FindFork mocks base method.
+func (m *MockHeaderRepository) FindFork(arg0 [][32]byte) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFork", arg0)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFork indicates an expected call of FindFork.
+func (mr *MockHeaderRepositoryMockRecorder) FindFork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFork", reflect.TypeOf((*MockHeaderRepository)(nil).FindFork), arg0)
+}
+
+// GetHeader mocks base method.
+func (m *MockHeaderRepository) GetHeader(arg0 [32]byte) (p2p.BlockHeader, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", arg0)
+	ret0, _ := ret[0].(p2p.BlockHeader)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockHeaderRepositoryMockRecorder) GetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockHeaderRepository)(nil).GetHeader), arg0)
+}
+
+// HeadersAfter mocks base method.
+func (m *MockHeaderRepository) HeadersAfter(arg0 [32]byte, arg1 int) ([]p2p.BlockHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeadersAfter", arg0, arg1)
+	ret0, _ := ret[0].([]p2p.BlockHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadersAfter indicates an expected call of HeadersAfter.
+func (mr *MockHeaderRepositoryMockRecorder) HeadersAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadersAfter", reflect.TypeOf((*MockHeaderRepository)(nil).HeadersAfter), arg0, arg1)
+}
+
+// MockChainTip is a mock of ChainTip interface.
+type MockChainTip struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainTipMockRecorder
+}
+
+// MockChainTipMockRecorder is the mock recorder for MockChainTip.
+type MockChainTipMockRecorder struct {
+	mock *MockChainTip
+}
+
+// NewMockChainTip creates a new mock instance.
+func NewMockChainTip(ctrl *gomock.Controller) *MockChainTip {
+	mock := &MockChainTip{ctrl: ctrl}
+	mock.recorder = &MockChainTipMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainTip) EXPECT() *MockChainTipMockRecorder {
+	return m.recorder
+}
+
+// SyncFrom mocks base method.
+func (m *MockChainTip) SyncFrom() ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncFrom")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncFrom indicates an expected call of SyncFrom.
+func (mr *MockChainTipMockRecorder) SyncFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncFrom", reflect.TypeOf((*MockChainTip)(nil).SyncFrom))
+}
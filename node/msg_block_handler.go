@@ -1,6 +1,9 @@
 package node
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/EmilGeorgiev/btc-node/sync"
 	"log"
 	"sync/atomic"
@@ -8,9 +11,15 @@ import (
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 )
 
+// ErrInvalidBlock is returned by ProcessBlock for a block that doesn't pass the validation.
+var ErrInvalidBlock = errors.New("invalid block")
+
 type MsgBlockHandler struct {
 	blockRepository        sync.BlockRepository
 	blockValidator         sync.BlockValidator
+	chainState             ChainState
+	pruner                 BlockPruner
+	indexers               []Indexer
 	stop                   chan struct{}
 	blocks                 <-chan *p2p.MsgBlock
 	notifyProcessedHeaders chan<- sync.RequestedHeaders
@@ -19,11 +28,16 @@ type MsgBlockHandler struct {
 	expectedBlockHeaders   <-chan sync.RequestedHeaders
 }
 
-func NewMsgBlockHandler(br sync.BlockRepository, bv sync.BlockValidator, blocks <-chan *p2p.MsgBlock,
+// NewMsgBlockHandler creates a new MsgBlockHandler. The pruner can be nil when the node is not pruned.
+// The indexers are updated with every block that is connected to the UTXO set.
+func NewMsgBlockHandler(br sync.BlockRepository, bv sync.BlockValidator, cs ChainState, p BlockPruner, indexers []Indexer, blocks <-chan *p2p.MsgBlock,
 	processed chan<- sync.RequestedHeaders, expBlockHeaders <-chan sync.RequestedHeaders) *MsgBlockHandler {
 	return &MsgBlockHandler{
 		blockRepository:        br,
 		blockValidator:         bv,
+		chainState:             cs,
+		pruner:                 p,
+		indexers:               indexers,
 		blocks:                 blocks,
 		notifyProcessedHeaders: processed,
 
@@ -83,15 +97,8 @@ func (mh *MsgBlockHandler) handleMsgBlock() {
 				nextBlockHeader = expectedHeaders.BlockHeaders[currentBlockIndex]
 			}
 
-			log.Println("validate block")
-			if err := mh.blockValidator.Validate(block); err != nil {
-				log.Printf("block is not valid: %s ", err)
-				continue
-			}
-
-			log.Printf("save block: %x\n", p2p.Reverse(block.GetHash()))
-			if err := mh.blockRepository.Save(*block); err != nil {
-				log.Println("failed to save block: ", err)
+			if err := mh.ProcessBlock(block); err != nil {
+				log.Println(err)
 				continue
 			}
 
@@ -106,3 +113,46 @@ func (mh *MsgBlockHandler) handleMsgBlock() {
 		}
 	}
 }
+
+// ProcessBlock validates and saves the block and connects it to the UTXO set and to the indexes. A block
+// that can't be connected to the UTXO set is deleted, so only the connected blocks stay saved.
+func (mh *MsgBlockHandler) ProcessBlock(block *p2p.MsgBlock) error {
+	log.Println("validate block")
+	if err := mh.blockValidator.Validate(block); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBlock, err)
+	}
+
+	hash := block.GetHash()
+	log.Printf("save block: %x\n", p2p.Reverse(hash))
+	if err := mh.blockRepository.Save(*block); err != nil {
+		return fmt.Errorf("failed to save block: %w", err)
+	}
+
+	if err := mh.chainState.ConnectBlock(*block); err != nil {
+		if delErr := mh.blockRepository.Delete(hash); delErr != nil {
+			log.Printf("failed to delete block %x that is not connected: %s\n", p2p.Reverse(hash), delErr)
+		}
+		return fmt.Errorf("%w: failed to connect block %x to the UTXO set: %w", ErrInvalidBlock, p2p.Reverse(hash), err)
+	}
+
+	mh.connectBlock(block)
+	return nil
+}
+
+// connectBlock connects the block that is connected to the UTXO set to the indexes. After that the data
+// of the old blocks can be pruned.
+func (mh *MsgBlockHandler) connectBlock(block *p2p.MsgBlock) {
+	for _, indexer := range mh.indexers {
+		if err := indexer.ConnectBlock(*block); err != nil {
+			log.Printf("failed to index block %x: %s\n", p2p.Reverse(block.GetHash()), err)
+		}
+	}
+
+	if mh.pruner == nil {
+		return
+	}
+
+	if err := mh.pruner.Prune(); err != nil {
+		log.Println("failed to prune blocks:", err)
+	}
+}
@@ -1,7 +1,9 @@
 package node_test
 
 import (
+	"errors"
 	"fmt"
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
 	"github.com/EmilGeorgiev/btc-node/node"
 	"github.com/EmilGeorgiev/btc-node/sync"
 	"testing"
@@ -15,8 +17,8 @@ func TestHandleMsgBlocks_HappyPath(t *testing.T) {
 	blockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F,
 		0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 
-	bl1 := newMsgBlock(blockHash)
-	bl2 := newMsgBlock(bl1.GetHash())
+	bl1 := testutil.NewMsgBlock(blockHash)
+	bl2 := testutil.NewMsgBlock(bl1.GetHash())
 
 	fmt.Printf("1: %x\n", p2p.Reverse(bl1.GetHash()))
 	fmt.Printf("2: %x\n", p2p.Reverse(bl2.GetHash()))
@@ -28,11 +30,19 @@ func TestHandleMsgBlocks_HappyPath(t *testing.T) {
 	blockRepo := node.NewMockBlockRepository(ctrl)
 	blockRepo.EXPECT().Save(bl1).Return(nil).Times(1)
 	blockRepo.EXPECT().Save(bl2).Return(nil).Times(1)
+	chainState := node.NewMockChainState(ctrl)
+	chainState.EXPECT().ConnectBlock(bl1).Return(nil).Times(1)
+	chainState.EXPECT().ConnectBlock(bl2).Return(nil).Times(1)
+	indexer := node.NewMockIndexer(ctrl)
+	indexer.EXPECT().ConnectBlock(bl1).Return(nil).Times(1)
+	indexer.EXPECT().ConnectBlock(bl2).Return(nil).Times(1)
+	pruner := node.NewMockBlockPruner(ctrl)
+	pruner.EXPECT().Prune().Return(nil).Times(2)
 
 	blocks := make(chan *p2p.MsgBlock)
 	expectedHeaders := make(chan sync.RequestedHeaders)
 	processed := make(chan sync.RequestedHeaders)
-	msgBlockHandle := node.NewMsgBlockHandler(blockRepo, blockValidator, blocks, processed, expectedHeaders)
+	msgBlockHandle := node.NewMsgBlockHandler(blockRepo, blockValidator, chainState, pruner, []node.Indexer{indexer}, blocks, processed, expectedHeaders)
 	msgBlockHandle.Start()
 
 	expHead := sync.RequestedHeaders{BlockHeaders: []p2p.BlockHeader{bl1.BlockHeader, bl2.BlockHeader}}
@@ -46,3 +56,25 @@ func TestHandleMsgBlocks_HappyPath(t *testing.T) {
 
 	msgBlockHandle.Stop()
 }
+
+func TestMsgBlockHandler_ProcessBlockDeletesTheBlockThatIsNotConnected(t *testing.T) {
+	block := testutil.NewMsgBlock([32]byte{1})
+	connectErr := errors.New("missing transaction input")
+
+	ctrl := gomock.NewController(t)
+	blockValidator := node.NewMockValidator(ctrl)
+	blockValidator.EXPECT().Validate(&block).Return(nil).Times(1)
+	blockRepo := node.NewMockBlockRepository(ctrl)
+	blockRepo.EXPECT().Save(block).Return(nil).Times(1)
+	blockRepo.EXPECT().Delete(block.GetHash()).Return(nil).Times(1)
+	chainState := node.NewMockChainState(ctrl)
+	chainState.EXPECT().ConnectBlock(block).Return(connectErr).Times(1)
+	// the block is not indexed and nothing is pruned
+	indexer := node.NewMockIndexer(ctrl)
+	pruner := node.NewMockBlockPruner(ctrl)
+
+	handler := node.NewMsgBlockHandler(blockRepo, blockValidator, chainState, pruner, []node.Indexer{indexer}, nil, nil, nil)
+	err := handler.ProcessBlock(&block)
+	require.ErrorIs(t, err, node.ErrInvalidBlock)
+	require.ErrorIs(t, err, connectErr)
+}
@@ -0,0 +1,168 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"sync/atomic"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// MsgGetDataHandler handles the getdata messages that are received from the peer. It replies with the
+// requested blocks, with the transactions and the ancestor package infos of the mempool, and with a
+// notfound message for the blocks that are not stored or are pruned and for the transactions that are
+// not in the mempool.
+type MsgGetDataHandler struct {
+	network         string
+	blockRepository sync.BlockRepository
+	// the transactions are not served when the mempool is nil
+	mempool      Mempool
+	getData      <-chan *p2p.MsgGetData
+	outgoingMsgs chan<- *p2p.Message
+	stop         chan struct{}
+	done         chan struct{}
+	isStarted    atomic.Bool
+}
+
+// NewMsgGetDataHandler creates a new MsgGetDataHandler.
+func NewMsgGetDataHandler(n string, br sync.BlockRepository, mp Mempool, gd <-chan *p2p.MsgGetData, out chan<- *p2p.Message) *MsgGetDataHandler {
+	return &MsgGetDataHandler{
+		network:         n,
+		blockRepository: br,
+		mempool:         mp,
+		getData:         gd,
+		outgoingMsgs:    out,
+		stop:            make(chan struct{}, 1000),
+		done:            make(chan struct{}, 1000),
+	}
+}
+
+func (mh *MsgGetDataHandler) Start() {
+	if mh.isStarted.Load() {
+		log.Println("MsgGetDataHandler is already started.")
+		return
+	}
+	mh.isStarted.Store(true)
+	go mh.handleGetData()
+	log.Println("Start MsgGetDataHandler.")
+}
+
+func (mh *MsgGetDataHandler) Stop() {
+	if !mh.isStarted.Load() {
+		log.Println("MsgGetDataHandler is not started and can't be stopped.")
+		return
+	}
+	mh.isStarted.Store(false)
+	mh.stop <- struct{}{}
+	<-mh.done
+	log.Println("Stop MsgGetDataHandler")
+}
+
+func (mh *MsgGetDataHandler) handleGetData() {
+	for {
+		select {
+		case <-mh.stop:
+			mh.done <- struct{}{}
+			return
+		case gd := <-mh.getData:
+			var notFound []p2p.InvVector
+			for _, inv := range gd.Inventory {
+				var msg *p2p.Message
+				var err error
+				switch inv.Type {
+				case p2p.InvTypeTx, p2p.InvTypeWtx, p2p.InvTypeWitnessTx:
+					msg, err = mh.getTxMsg(inv)
+				case p2p.InvTypeAncPkgInfo:
+					msg, err = mh.getAncPkgInfoMsg(inv)
+				default:
+					msg, err = mh.getBlockMsg(inv)
+				}
+				if err != nil {
+					log.Printf("can't provide inventory %d %x: %s\n", inv.Type, p2p.Reverse(inv.Hash), err)
+					notFound = append(notFound, inv)
+					continue
+				}
+				mh.outgoingMsgs <- msg
+			}
+
+			if len(notFound) == 0 {
+				continue
+			}
+
+			msgNotFound := p2p.MsgNotFound{Count: p2p.VarInt(len(notFound)), Inventory: notFound}
+			msg, err := p2p.NewMessage(p2p.CmdNotfound, mh.network, msgNotFound)
+			if err != nil {
+				log.Println("failed to create notfound message:", err)
+				continue
+			}
+			mh.outgoingMsgs <- msg
+		}
+	}
+}
+
+// getBlockMsg returns the block message for the inventory. Only blocks are served, and the blocks
+// that are pruned are refused.
+func (mh *MsgGetDataHandler) getBlockMsg(inv p2p.InvVector) (*p2p.Message, error) {
+	if inv.Type != p2p.InvTypeBlock && inv.Type != p2p.InvTypeWitnessBlock {
+		return nil, errors.New("unsupported inventory type")
+	}
+
+	block, err := mh.blockRepository.Get(inv.Hash)
+	if err != nil {
+		if errors.Is(err, sync.ErrPruned) {
+			log.Printf("refuse getdata for pruned block: %x\n", p2p.Reverse(inv.Hash))
+		}
+		return nil, err
+	}
+
+	if inv.Type == p2p.InvTypeBlock {
+		block = block.StripWitness()
+	}
+	return p2p.NewMessage(p2p.CmdBlock, mh.network, block)
+}
+
+// getTxMsg returns the transaction message for the inventory. The transaction is looked up by its wtxid
+// for MSG_WTX and by its txid for the others, it is sent without the witness for MSG_TX.
+func (mh *MsgGetDataHandler) getTxMsg(inv p2p.InvVector) (*p2p.Message, error) {
+	if mh.mempool == nil {
+		return nil, errors.New("the mempool is disabled")
+	}
+
+	var desc mempool.TxDesc
+	var ok bool
+	if inv.Type == p2p.InvTypeWtx {
+		desc, ok = mh.mempool.GetByWTxID(inv.Hash)
+	} else {
+		desc, ok = mh.mempool.Get(inv.Hash)
+	}
+	if !ok {
+		return nil, errors.New("the transaction is not in the mempool")
+	}
+
+	tx := desc.Tx
+	if inv.Type == p2p.InvTypeTx {
+		tx.Flag = 0
+		tx.TxWitness = nil
+	}
+	return p2p.NewMessage(p2p.CmdTx, mh.network, tx)
+}
+
+// getAncPkgInfoMsg returns the ancpkginfo message with the wtxids of the transaction and its ancestors in
+// the mempool, the transaction is looked up by its wtxid.
+func (mh *MsgGetDataHandler) getAncPkgInfoMsg(inv p2p.InvVector) (*p2p.Message, error) {
+	if mh.mempool == nil {
+		return nil, errors.New("the mempool is disabled")
+	}
+
+	pkg, ok := mh.mempool.AncestorPackage(inv.Hash)
+	if !ok {
+		return nil, errors.New("the transaction is not in the mempool")
+	}
+	wtxids := make([][32]byte, 0, len(pkg))
+	for _, desc := range pkg {
+		wtxids = append(wtxids, desc.WTxID)
+	}
+	return p2p.NewMessage(p2p.CmdAncpkginfo, mh.network, p2p.MsgAncPkgInfo{Count: p2p.VarInt(len(wtxids)), WTxIDs: wtxids})
+}
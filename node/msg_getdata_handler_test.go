@@ -0,0 +1,109 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgGetDataHandler_ServeBlocksAndRefusePruned(t *testing.T) {
+	block := testutil.NewMsgBlock(sync.GenesisBlockHash)
+	pruned := [32]byte{1}
+
+	ctrl := gomock.NewController(t)
+	blockRepo := node.NewMockBlockRepository(ctrl)
+	blockRepo.EXPECT().Get(block.GetHash()).Return(block, nil).Times(1)
+	blockRepo.EXPECT().Get(pruned).Return(p2p.MsgBlock{}, sync.ErrPruned).Times(1)
+
+	getData := make(chan *p2p.MsgGetData)
+	outgoingMsgs := make(chan *p2p.Message, 2)
+	handler := node.NewMsgGetDataHandler("mainnet", blockRepo, nil, getData, outgoingMsgs)
+	handler.Start()
+
+	getData <- &p2p.MsgGetData{Count: 2, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeBlock, Hash: block.GetHash()},
+		{Type: p2p.InvTypeBlock, Hash: pruned},
+	}}
+
+	expBlock, err := p2p.NewMessage(p2p.CmdBlock, "mainnet", block)
+	require.NoError(t, err)
+	require.Equal(t, expBlock, <-outgoingMsgs)
+
+	expNotFound, err := p2p.NewMessage(p2p.CmdNotfound, "mainnet", p2p.MsgNotFound{
+		Count:     1,
+		Inventory: []p2p.InvVector{{Type: p2p.InvTypeBlock, Hash: pruned}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expNotFound, <-outgoingMsgs)
+
+	handler.Stop()
+}
+
+func TestMsgGetDataHandler_ServeTxsFromTheMempool(t *testing.T) {
+	tx := newSegwitTx()
+	desc := mempool.TxDesc{Tx: tx, TxID: tx.TxHash(), WTxID: tx.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().GetByWTxID(desc.WTxID).Return(desc, true).Times(1)
+	pool.EXPECT().Get(desc.TxID).Return(desc, true).Times(1)
+	pool.EXPECT().Get([32]byte{1}).Return(mempool.TxDesc{}, false).Times(1)
+
+	getData := make(chan *p2p.MsgGetData)
+	outgoingMsgs := make(chan *p2p.Message, 3)
+	handler := node.NewMsgGetDataHandler("mainnet", nil, pool, getData, outgoingMsgs)
+	handler.Start()
+
+	getData <- &p2p.MsgGetData{Count: 3, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeWtx, Hash: desc.WTxID},
+		{Type: p2p.InvTypeTx, Hash: desc.TxID},
+		{Type: p2p.InvTypeTx, Hash: [32]byte{1}},
+	}}
+
+	// the transaction is sent without the witness for MSG_TX
+	stripped := tx
+	stripped.Flag = 0
+	stripped.TxWitness = nil
+	expWitness, err := p2p.NewMessage(p2p.CmdTx, "mainnet", tx)
+	require.NoError(t, err)
+	expStripped, err := p2p.NewMessage(p2p.CmdTx, "mainnet", stripped)
+	require.NoError(t, err)
+	expNotFound, err := p2p.NewMessage(p2p.CmdNotfound, "mainnet", p2p.MsgNotFound{
+		Count:     1,
+		Inventory: []p2p.InvVector{{Type: p2p.InvTypeTx, Hash: [32]byte{1}}},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, expWitness, <-outgoingMsgs)
+	require.Equal(t, expStripped, <-outgoingMsgs)
+	require.Equal(t, expNotFound, <-outgoingMsgs)
+	handler.Stop()
+}
+
+func TestMsgGetDataHandler_ServeTheAncestorPackageInfo(t *testing.T) {
+	parent := newSegwitTx()
+	child := testutil.NewMsgTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 500)
+	pkg := []mempool.TxDesc{{Tx: parent, WTxID: parent.WTxHash()}, {Tx: child, WTxID: child.WTxHash()}}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().AncestorPackage(child.WTxHash()).Return(pkg, true).Times(1)
+
+	getData := make(chan *p2p.MsgGetData)
+	outgoingMsgs := make(chan *p2p.Message, 1)
+	handler := node.NewMsgGetDataHandler("mainnet", nil, pool, getData, outgoingMsgs)
+	handler.Start()
+
+	getData <- &p2p.MsgGetData{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeAncPkgInfo, Hash: child.WTxHash()}}}
+
+	expected, err := p2p.NewMessage(p2p.CmdAncpkginfo, "mainnet", p2p.MsgAncPkgInfo{Count: 2, WTxIDs: [][32]byte{parent.WTxHash(), child.WTxHash()}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outgoingMsgs)
+	handler.Stop()
+}
@@ -0,0 +1,161 @@
+package node
+
+import (
+	"log"
+	"sync/atomic"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+const (
+	// maxHeadersPerMsg is the maximum number of headers in a reply to getheaders.
+	maxHeadersPerMsg = 2000
+	// maxBlocksPerInv is the maximum number of block hashes in a reply to getblocks.
+	maxBlocksPerInv = 500
+)
+
+// MsgGetHeadersHandler handles the getheaders and getblocks messages that are received from the peer. The
+// fork point of the peer's chain is the first block of the block locator that is on the best header chain.
+// It replies to getheaders with the headers after the fork point and to getblocks with an inv message with
+// the hashes of the stored blocks after it.
+type MsgGetHeadersHandler struct {
+	network          string
+	headerRepository sync.HeaderRepository
+	chainTip         sync.ChainTip
+	getHeaders       <-chan *p2p.MsgGetHeader
+	getBlocks        <-chan *p2p.MsgGetBlocks
+	outgoingMsgs     chan<- *p2p.Message
+	stop             chan struct{}
+	done             chan struct{}
+	isStarted        atomic.Bool
+}
+
+// NewMsgGetHeadersHandler creates a new MsgGetHeadersHandler. The chain tip is the last stored block, the
+// blocks after it are not announced in the replies to getblocks.
+func NewMsgGetHeadersHandler(n string, hr sync.HeaderRepository, tip sync.ChainTip, gh <-chan *p2p.MsgGetHeader,
+	gb <-chan *p2p.MsgGetBlocks, out chan<- *p2p.Message) *MsgGetHeadersHandler {
+	return &MsgGetHeadersHandler{
+		network:          n,
+		headerRepository: hr,
+		chainTip:         tip,
+		getHeaders:       gh,
+		getBlocks:        gb,
+		outgoingMsgs:     out,
+		stop:             make(chan struct{}, 1000),
+		done:             make(chan struct{}, 1000),
+	}
+}
+
+func (mh *MsgGetHeadersHandler) Start() {
+	if mh.isStarted.Load() {
+		log.Println("MsgGetHeadersHandler is already started.")
+		return
+	}
+	mh.isStarted.Store(true)
+	go mh.handleGetHeaders()
+	log.Println("Start MsgGetHeadersHandler.")
+}
+
+func (mh *MsgGetHeadersHandler) Stop() {
+	if !mh.isStarted.Load() {
+		log.Println("MsgGetHeadersHandler is not started and can't be stopped.")
+		return
+	}
+	mh.isStarted.Store(false)
+	mh.stop <- struct{}{}
+	<-mh.done
+	log.Println("Stop MsgGetHeadersHandler")
+}
+
+func (mh *MsgGetHeadersHandler) handleGetHeaders() {
+	for {
+		select {
+		case <-mh.stop:
+			mh.done <- struct{}{}
+			return
+		case gh := <-mh.getHeaders:
+			headers, _, err := mh.headersAfterFork(gh.BlockLocator, gh.StopBlock, maxHeadersPerMsg)
+			if err != nil {
+				log.Println("failed to get the requested headers:", err)
+				continue
+			}
+
+			msg, err := p2p.NewMessage(p2p.CmdHeaders, mh.network, p2p.MsgHeaders{Count: p2p.VarInt(len(headers)), BlockHeaders: headers})
+			if err != nil {
+				log.Println("failed to create headers message:", err)
+				continue
+			}
+			mh.outgoingMsgs <- msg
+		case gb := <-mh.getBlocks:
+			msg, err := mh.blocksInv(gb)
+			if err != nil {
+				log.Println("failed to get the requested blocks:", err)
+				continue
+			}
+			if msg != nil {
+				mh.outgoingMsgs <- msg
+			}
+		}
+	}
+}
+
+// headersAfterFork returns up to max headers of the best header chain after the fork point with the block
+// locator and the height of the fork point. The stop block is the last returned header.
+func (mh *MsgGetHeadersHandler) headersAfterFork(locator [][32]byte, stop [32]byte, max int) ([]p2p.BlockHeader, int32, error) {
+	fork, err := mh.headerRepository.FindFork(locator)
+	if err != nil {
+		return nil, 0, err
+	}
+	height, err := mh.heightOf(fork)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	headers, err := mh.headerRepository.HeadersAfter(fork, max)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, header := range headers {
+		if Hash(header) == stop {
+			return headers[:i+1], height, nil
+		}
+	}
+	return headers, height, nil
+}
+
+// blocksInv returns an inv message with the hashes of the stored blocks after the fork point with the
+// block locator, or nil when there are no such blocks.
+func (mh *MsgGetHeadersHandler) blocksInv(gb *p2p.MsgGetBlocks) (*p2p.Message, error) {
+	headers, forkHeight, err := mh.headersAfterFork(gb.BlockLocator, gb.StopBlock, maxBlocksPerInv)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := mh.chainTip.SyncFrom()
+	if err != nil {
+		return nil, err
+	}
+	tipHeight, err := mh.heightOf(tip)
+	if err != nil {
+		return nil, err
+	}
+
+	// the headers above the last stored block don't have blocks yet
+	n := min(len(headers), int(tipHeight-forkHeight))
+	if n <= 0 {
+		return nil, nil
+	}
+	inv := make([]p2p.InvVector, n)
+	for i, header := range headers[:n] {
+		inv[i] = p2p.InvVector{Type: p2p.InvTypeBlock, Hash: Hash(header)}
+	}
+	return p2p.NewMessage(p2p.CmdInv, mh.network, p2p.MsgInv{Count: p2p.VarInt(n), Inventory: inv})
+}
+
+func (mh *MsgGetHeadersHandler) heightOf(hash [32]byte) (int32, error) {
+	if hash == sync.GenesisBlockHash {
+		return 0, nil
+	}
+	_, height, err := mh.headerRepository.GetHeader(hash)
+	return height, err
+}
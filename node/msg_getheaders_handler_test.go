@@ -0,0 +1,69 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgGetHeadersHandler_ServesTheHeadersAfterTheForkPoint(t *testing.T) {
+	bh1 := testutil.NewBlockHeader(sync.GenesisBlockHash)
+	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+	bh3 := testutil.NewBlockHeader(node.Hash(bh2))
+	locator := [][32]byte{{1}, node.Hash(bh1), sync.GenesisBlockHash}
+
+	ctrl := gomock.NewController(t)
+	headerRepo := node.NewMockHeaderRepository(ctrl)
+	headerRepo.EXPECT().FindFork(locator).Return(node.Hash(bh1), nil)
+	headerRepo.EXPECT().GetHeader(node.Hash(bh1)).Return(bh1, int32(1), nil)
+	headerRepo.EXPECT().HeadersAfter(node.Hash(bh1), 2000).Return([]p2p.BlockHeader{bh2, bh3}, nil)
+
+	getHeaders := make(chan *p2p.MsgGetHeader)
+	out := make(chan *p2p.Message, 1)
+	handler := node.NewMsgGetHeadersHandler("mainnet", headerRepo, node.NewMockChainTip(ctrl), getHeaders, nil, out)
+	handler.Start()
+
+	// the headers after the stop block are not sent
+	getHeaders <- &p2p.MsgGetHeader{HashCount: 3, BlockLocator: locator, StopBlock: node.Hash(bh2)}
+
+	expected, err := p2p.NewMessage(p2p.CmdHeaders, "mainnet", p2p.MsgHeaders{Count: 1, BlockHeaders: []p2p.BlockHeader{bh2}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-out)
+	handler.Stop()
+}
+
+func TestMsgGetHeadersHandler_AnnouncesTheStoredBlocksAfterTheForkPoint(t *testing.T) {
+	bh1 := testutil.NewBlockHeader(sync.GenesisBlockHash)
+	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+	bh3 := testutil.NewBlockHeader(node.Hash(bh2))
+	locator := [][32]byte{sync.GenesisBlockHash}
+
+	ctrl := gomock.NewController(t)
+	headerRepo := node.NewMockHeaderRepository(ctrl)
+	headerRepo.EXPECT().FindFork(locator).Return(sync.GenesisBlockHash, nil)
+	headerRepo.EXPECT().HeadersAfter(sync.GenesisBlockHash, 500).Return([]p2p.BlockHeader{bh1, bh2, bh3}, nil)
+	headerRepo.EXPECT().GetHeader(node.Hash(bh2)).Return(bh2, int32(2), nil)
+	// the block of the last header is not stored yet
+	chainTip := node.NewMockChainTip(ctrl)
+	chainTip.EXPECT().SyncFrom().Return(node.Hash(bh2), nil)
+
+	getBlocks := make(chan *p2p.MsgGetBlocks)
+	out := make(chan *p2p.Message, 1)
+	handler := node.NewMsgGetHeadersHandler("mainnet", headerRepo, chainTip, nil, getBlocks, out)
+	handler.Start()
+
+	getBlocks <- &p2p.MsgGetBlocks{HashCount: 1, BlockLocator: locator}
+
+	expected, err := p2p.NewMessage(p2p.CmdInv, "mainnet", p2p.MsgInv{Count: 2, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeBlock, Hash: node.Hash(bh1)},
+		{Type: p2p.InvTypeBlock, Hash: node.Hash(bh2)},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-out)
+	handler.Stop()
+}
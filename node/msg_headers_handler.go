@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"slices"
 	"sync/atomic"
 
 	"github.com/EmilGeorgiev/btc-node/network/binary"
@@ -13,28 +14,40 @@ import (
 )
 
 type MsgHeadersHandler struct {
-	network               string
-	outgoingMsgs          chan<- *p2p.Message
-	headers               <-chan *p2p.MsgHeaders
-	expectedStartFromHash <-chan [32]byte
-	syncCompleted         chan<- struct{}
-	stop                  chan struct{}
-	done                  chan struct{}
-	isStarted             atomic.Bool
-	headersOverviews      chan<- sync.RequestedHeaders
+	network string
+	// headerRepository is nil when the headers are not stored, then the blocks of the received headers
+	// are requested directly.
+	headerRepository sync.HeaderRepository
+	blockScheduler   BlockScheduler
+	outgoingMsgs     chan<- *p2p.Message
+	headers          <-chan *p2p.MsgHeaders
+	expectedLocators <-chan [][32]byte
+	syncCompleted    chan<- struct{}
+	stop             chan struct{}
+	done             chan struct{}
+	isStarted        atomic.Bool
+	headersOverviews chan<- sync.RequestedHeaders
+	// tip is updated with the last stored header of the peer, it can be nil
+	tip *PeerTipTracker
 }
 
-func NewMsgHeaderHandler(n string, out chan<- *p2p.Message, h <-chan *p2p.MsgHeaders,
-	expectedStartFromHash <-chan [32]byte, syncCompl chan struct{}, headersOverviews chan<- sync.RequestedHeaders) *MsgHeadersHandler {
+// NewMsgHeaderHandler creates a new MsgHeadersHandler. When the header repository is set, the validated
+// headers are stored, the block scheduler is notified to download their blocks and the tip of the peer is
+// moved to the last of them. The header repository, the block scheduler and the tip can be nil.
+func NewMsgHeaderHandler(n string, hr sync.HeaderRepository, bs BlockScheduler, out chan<- *p2p.Message, h <-chan *p2p.MsgHeaders,
+	expectedLocators <-chan [][32]byte, syncCompl chan struct{}, headersOverviews chan<- sync.RequestedHeaders, tip *PeerTipTracker) *MsgHeadersHandler {
 	return &MsgHeadersHandler{
-		network:               n,
-		outgoingMsgs:          out,
-		headers:               h,
-		expectedStartFromHash: expectedStartFromHash,
-		syncCompleted:         syncCompl,
-		stop:                  make(chan struct{}, 1000),
-		done:                  make(chan struct{}, 1000),
-		headersOverviews:      headersOverviews,
+		network:          n,
+		headerRepository: hr,
+		blockScheduler:   bs,
+		outgoingMsgs:     out,
+		headers:          h,
+		expectedLocators: expectedLocators,
+		syncCompleted:    syncCompl,
+		stop:             make(chan struct{}, 1000),
+		done:             make(chan struct{}, 1000),
+		headersOverviews: headersOverviews,
+		tip:              tip,
 	}
 }
 
@@ -65,14 +78,14 @@ type HeadersFromPeer struct {
 
 func (mh *MsgHeadersHandler) handleHeaders() {
 	fmt.Println("START HEADERS HANDLER")
-	expPrevBlockHash := sync.GenesisBlockHash
+	expLocator := [][32]byte{sync.GenesisBlockHash}
 	for {
 		select {
 		case <-mh.stop:
 			mh.done <- struct{}{}
 			return
-		case expPrevBlockHash = <-mh.expectedStartFromHash:
-			log.Printf("set expPrevBlockhash: %x\n", p2p.Reverse(expPrevBlockHash))
+		case expLocator = <-mh.expectedLocators:
+			log.Printf("set expected block locator: %x\n", p2p.Reverse(expLocator[0]))
 		case msgH := <-mh.headers: // handle MsgHeaders
 			headers := msgH.BlockHeaders
 			if len(headers) == 0 {
@@ -82,9 +95,10 @@ func (mh *MsgHeadersHandler) handleHeaders() {
 				continue
 			}
 
-			if expPrevBlockHash != headers[0].PrevBlockHash {
+			// the peer replies with the headers after the first block of the locator that is on its chain
+			if !slices.Contains(expLocator, headers[0].PrevBlockHash) {
 				log.Println("The current Headers are not requested and will be scipped")
-				log.Printf("expected prev block hash: %x\n", p2p.Reverse(expPrevBlockHash))
+				log.Printf("expected prev block hash: %x\n", p2p.Reverse(expLocator[0]))
 				log.Printf("actual prev block hash: %x\n", p2p.Reverse(headers[0].PrevBlockHash))
 				continue
 			}
@@ -100,25 +114,65 @@ func (mh *MsgHeadersHandler) handleHeaders() {
 				continue
 			}
 
-			inv := make([]p2p.InvVector, len(headers))
-			for i := 0; i < len(msgH.BlockHeaders); i++ {
-				inv[i] = p2p.InvVector{Type: 2, Hash: Hash(headers[i])}
+			if mh.headerRepository != nil {
+				if err := mh.headerRepository.AddHeaders(headers); err != nil {
+					log.Printf("failed to store the headers: %s\n", err)
+					mh.headersOverviews <- sync.RequestedHeaders{CumulativePoW: cumulPoW, IsValid: false}
+					continue
+				}
+				if mh.blockScheduler != nil {
+					mh.blockScheduler.HeadersStored()
+				}
+				work := mh.updateTip(headers[len(headers)-1])
+				// the blocks are downloaded by the block scheduler, continue with the next headers
+				mh.headersOverviews <- sync.RequestedHeaders{BlockHeaders: headers, CumulativePoW: cumulPoW, ChainWork: work, IsValid: true}
+				continue
 			}
 
-			msgGetdata := p2p.MsgGetData{Count: p2p.VarInt(len(headers)), Inventory: inv}
-			msg, _ := p2p.NewMessage(p2p.CmdGetdata, mh.network, msgGetdata)
-			log.Println("Send Get Data With ", msgGetdata.Count)
 			mh.headersOverviews <- sync.RequestedHeaders{
 				BlockHeaders:  headers,
 				CumulativePoW: cumulPoW,
 				IsValid:       true,
 			}
 			// notify block handlers what to expect
-			mh.outgoingMsgs <- msg
+			mh.outgoingMsgs <- mh.newMsgGetData(headers)
 		}
 	}
 }
 
+// updateTip moves the tip of the peer to the stored header, with the chain work of the header chain, and
+// returns the chain work. It returns nil when the chain work is not known.
+func (mh *MsgHeadersHandler) updateTip(header p2p.BlockHeader) *big.Int {
+	hash := Hash(header)
+	work, err := mh.headerRepository.ChainWork(hash)
+	if err != nil {
+		log.Printf("failed to get the chain work of the header %x: %s\n", p2p.Reverse(hash), err)
+		return nil
+	}
+	if mh.tip == nil {
+		return work
+	}
+	_, height, err := mh.headerRepository.GetHeader(hash)
+	if err != nil {
+		log.Printf("failed to get the stored header %x: %s\n", p2p.Reverse(hash), err)
+		return work
+	}
+	mh.tip.Update(header, height, work)
+	return work
+}
+
+func (mh *MsgHeadersHandler) newMsgGetData(headers []p2p.BlockHeader) *p2p.Message {
+	inv := make([]p2p.InvVector, len(headers))
+	for i := 0; i < len(headers); i++ {
+		inv[i] = p2p.InvVector{Type: p2p.InvTypeBlock, Hash: Hash(headers[i])}
+	}
+
+	msgGetdata := p2p.MsgGetData{Count: p2p.VarInt(len(headers)), Inventory: inv}
+	msg, _ := p2p.NewMessage(p2p.CmdGetdata, mh.network, msgGetdata)
+	log.Println("Send Get Data With ", msgGetdata.Count)
+	return msg
+}
+
 func Hash(bh p2p.BlockHeader) [32]byte {
 	b, _ := binary.Marshal(bh)
 	firstHash := sha256.Sum256(b[:80])
@@ -1,6 +1,8 @@
 package node_test
 
 import (
+	"errors"
+
 	"github.com/EmilGeorgiev/btc-node/common/testutil"
 	"math/big"
 	"testing"
@@ -8,6 +10,7 @@ import (
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 	"github.com/EmilGeorgiev/btc-node/node"
 	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,9 +26,9 @@ func TestHandleMsgHeaders_HappyPath(t *testing.T) {
 	msggetdata := p2p.MsgGetData{
 		Count: 3,
 		Inventory: []p2p.InvVector{
-			{2, node.Hash(bh1)},
-			{2, node.Hash(bh2)},
-			{2, node.Hash(bh3)},
+			{Type: p2p.InvTypeBlock, Hash: node.Hash(bh1)},
+			{Type: p2p.InvTypeBlock, Hash: node.Hash(bh2)},
+			{Type: p2p.InvTypeBlock, Hash: node.Hash(bh3)},
 		},
 	}
 
@@ -34,12 +37,12 @@ func TestHandleMsgHeaders_HappyPath(t *testing.T) {
 	out := make(chan *p2p.Message)
 	headers := make(chan *p2p.MsgHeaders)
 	syncComplete := make(chan struct{})
-	expectedBlockHashes := make(chan [32]byte)
+	expectedLocators := make(chan [][32]byte)
 	requestedHeaders := make(chan sync.RequestedHeaders)
-	headersHandler := node.NewMsgHeaderHandler("mainnet", out, headers, expectedBlockHashes, syncComplete, requestedHeaders)
+	headersHandler := node.NewMsgHeaderHandler("mainnet", nil, nil, out, headers, expectedLocators, syncComplete, requestedHeaders, nil)
 	headersHandler.Start()
 
-	expectedBlockHashes <- prevBlockHash
+	expectedLocators <- [][32]byte{prevBlockHash}
 	headers <- msgHeaders
 
 	actualRH := <-requestedHeaders
@@ -57,6 +60,92 @@ func TestHandleMsgHeaders_HappyPath(t *testing.T) {
 	headersHandler.Stop()
 }
 
+func TestHandleMsgHeaders_StoresTheHeadersAndNotifiesTheBlockScheduler(t *testing.T) {
+	bh1 := testutil.NewBlockHeader(sync.GenesisBlockHash)
+	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+	bh3 := testutil.NewBlockHeader(node.Hash(bh2))
+	blockHeaders := []p2p.BlockHeader{bh1, bh2, bh3}
+
+	ctrl := gomock.NewController(t)
+	headerRepo := node.NewMockHeaderRepository(ctrl)
+	blockScheduler := node.NewMockBlockScheduler(ctrl)
+	gomock.InOrder(
+		headerRepo.EXPECT().AddHeaders(blockHeaders).Return(nil),
+		blockScheduler.EXPECT().HeadersStored(),
+	)
+	headerRepo.EXPECT().GetHeader(node.Hash(bh3)).Return(bh3, int32(3), nil)
+	headerRepo.EXPECT().ChainWork(node.Hash(bh3)).Return(big.NewInt(4000), nil)
+	tip := node.NewPeerTipTracker()
+
+	out := make(chan *p2p.Message, 1)
+	headers := make(chan *p2p.MsgHeaders)
+	requestedHeaders := make(chan sync.RequestedHeaders, 1)
+	headersHandler := node.NewMsgHeaderHandler("mainnet", headerRepo, blockScheduler, out, headers, make(chan [][32]byte), nil, requestedHeaders, tip)
+	headersHandler.Start()
+
+	headers <- &p2p.MsgHeaders{Count: 3, BlockHeaders: blockHeaders}
+
+	// the chain work of the stored headers is sent with them
+	require.Equal(t, sync.RequestedHeaders{BlockHeaders: blockHeaders, CumulativePoW: big.NewInt(0), ChainWork: big.NewInt(4000), IsValid: true},
+		<-requestedHeaders)
+	headersHandler.Stop()
+	// the blocks are requested by the block scheduler
+	require.Equal(t, 0, len(out))
+	// the tip of the peer is the last stored header
+	require.Equal(t, int32(3), tip.Tip().Height)
+	require.Equal(t, big.NewInt(4000), tip.Tip().Work)
+}
+
+func TestHandleMsgHeaders_WhenTheHeadersCantBeStored(t *testing.T) {
+	bh1 := testutil.NewBlockHeader(sync.GenesisBlockHash)
+	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+
+	ctrl := gomock.NewController(t)
+	headerRepo := node.NewMockHeaderRepository(ctrl)
+	headerRepo.EXPECT().AddHeaders([]p2p.BlockHeader{bh1, bh2}).Return(errors.New("err"))
+
+	out := make(chan *p2p.Message, 1)
+	headers := make(chan *p2p.MsgHeaders)
+	requestedHeaders := make(chan sync.RequestedHeaders, 1)
+	headersHandler := node.NewMsgHeaderHandler("mainnet", headerRepo, node.NewMockBlockScheduler(ctrl), out, headers, make(chan [][32]byte), nil, requestedHeaders, nil)
+	headersHandler.Start()
+
+	headers <- &p2p.MsgHeaders{Count: 2, BlockHeaders: []p2p.BlockHeader{bh1, bh2}}
+
+	require.Equal(t, sync.RequestedHeaders{CumulativePoW: big.NewInt(0), IsValid: false}, <-requestedHeaders)
+	headersHandler.Stop()
+	require.Equal(t, 0, len(out))
+}
+
+func TestHandleMsgHeaders_AcceptsTheHeadersAfterAnyBlockOfTheLocator(t *testing.T) {
+	bh1 := testutil.NewBlockHeader(sync.GenesisBlockHash)
+	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+	fork := testutil.NewBlockHeader(node.Hash(bh1))
+	fork.Nonce = 1
+
+	ctrl := gomock.NewController(t)
+	headerRepo := node.NewMockHeaderRepository(ctrl)
+	headerRepo.EXPECT().AddHeaders([]p2p.BlockHeader{fork}).Return(nil)
+	headerRepo.EXPECT().ChainWork(node.Hash(fork)).Return(big.NewInt(3000), nil)
+
+	out := make(chan *p2p.Message, 1)
+	headers := make(chan *p2p.MsgHeaders)
+	expectedLocators := make(chan [][32]byte)
+	requestedHeaders := make(chan sync.RequestedHeaders, 1)
+	headersHandler := node.NewMsgHeaderHandler("mainnet", headerRepo, nil, out, headers, expectedLocators, nil, requestedHeaders, nil)
+	headersHandler.Start()
+
+	expectedLocators <- [][32]byte{node.Hash(bh2), node.Hash(bh1), sync.GenesisBlockHash}
+	// the headers are not requested
+	headers <- &p2p.MsgHeaders{Count: 1, BlockHeaders: []p2p.BlockHeader{testutil.NewBlockHeader([32]byte{1})}}
+	// the peer's chain forks after bh1
+	headers <- &p2p.MsgHeaders{Count: 1, BlockHeaders: []p2p.BlockHeader{fork}}
+
+	require.Equal(t, sync.RequestedHeaders{BlockHeaders: []p2p.BlockHeader{fork}, CumulativePoW: big.NewInt(0), ChainWork: big.NewInt(3000), IsValid: true},
+		<-requestedHeaders)
+	headersHandler.Stop()
+}
+
 //func TestHandleMsgHeaders_WhenMsgHeadersHasZeroBlockHeaders(t *testing.T) {
 //	prevBlockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F, 0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 //
@@ -69,7 +158,7 @@ func TestHandleMsgHeaders_HappyPath(t *testing.T) {
 //	headersHandler := node.NewMsgHeaderHandler("mainnet", nil, headers, expectedBlockHashes, syncComplete)
 //	headersHandler.Start()
 //
-//	expectedBlockHashes <- prevBlockHash
+//	expectedLocators <- [][32]byte{prevBlockHash}
 //	headers <- msgHeaders
 //
 //	<-syncComplete // expect a signal that sync is completed
@@ -80,19 +169,19 @@ func TestHandleMsgHeaders_HappyPath(t *testing.T) {
 //func TestHandleMsgHeaders_WhenMsgHeadersContainsUnwantedBlockHeaders(t *testing.T) {
 //	prevBlockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F, 0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 //
-//	bh1 := newBlockHeader([32]byte{0})
-//	bh2 := newBlockHeader(node.Hash(bh1))
-//	bh3 := newBlockHeader(node.Hash(bh2))
+//	bh1 := testutil.NewBlockHeader([32]byte{0})
+//	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+//	bh3 := testutil.NewBlockHeader(node.Hash(bh2))
 //	blockHeaders := []p2p.BlockHeader{bh1, bh2, bh3}
 //	msgHeaders := &p2p.MsgHeaders{Count: 3, BlockHeaders: blockHeaders}
 //
 //	headers := make(chan *p2p.MsgHeaders)
 //	out := make(chan *p2p.Message)
 //	expectedBlockHashes := make(chan [32]byte)
-//	headersHandler := node.NewMsgHeaderHandler("mainnet", out, headers, expectedBlockHashes, nil)
+//	headersHandler := node.NewMsgHeaderHandler("mainnet", nil, nil, out, headers, expectedBlockHashes, nil)
 //	headersHandler.Start()
 //
-//	expectedBlockHashes <- prevBlockHash
+//	expectedLocators <- [][32]byte{prevBlockHash}
 //	headers <- msgHeaders
 //
 //	require.Equal(t, 0, len(out))
@@ -3,13 +3,21 @@ package node
 import (
 	"fmt"
 	"log"
+	"math/big"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/EmilGeorgiev/btc-node/common"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 )
 
+// syncPeerSwitchBlocks is the number of blocks, at the work of its tip, by which the chain of another peer
+// must have more work than the chain of the sync peer to replace it. The peers that announce the same blocks
+// at slightly different times don't take the sync from each other.
+const syncPeerSwitchBlocks = 2
+
 // Node is a central part in the program that hold reference to all Peer and manage communication with them.
 type Node struct {
 	newServerPeer          func(p2p.Peer, chan PeerErr) PeerConnectionManager
@@ -26,11 +34,36 @@ type Node struct {
 	stop               chan struct{}
 	wg                 *sync.WaitGroup
 	notifySyncForError chan PeerErr
+
+	// syncPeer is the peer from which the headers are synced, it is nil until the first chain overview
+	// of a peer with a valid chain is done. syncView is the overview of its chain.
+	mu       sync.Mutex
+	syncPeer PeerConnectionManager
+	syncView *common.ChainOverview
+
+	// the sync peer is switched when its throughput in bytes per second drops below minThroughput and
+	// another peer is faster. The throughput is checked every checkInterval, 0 disables the check.
+	checkInterval time.Duration
+	minThroughput uint64
+	// syncBytes is the number of bytes that are received from the sync peer at syncBytesAt, when it became
+	// the sync peer or at the last check of its throughput
+	syncBytes   uint64
+	syncBytesAt time.Time
+	// inFlight returns the blocks that are requested from the peers, the throughput of the sync peer is not
+	// checked while no blocks are requested from it. It is nil when the blocks are not downloaded in parallel.
+	inFlight InFlightBlocks
+
+	// persisters save the state that is kept in memory, like the mempool, when the node is stopped.
+	persisters []Persister
+
+	// lastPeerID is the ID of the last connected peer, every connection gets a new ID
+	lastPeerID atomic.Int64
 }
 
 // New initialize and return a new Node.
 func New(network, userAgent string, newServerPeer func(p2p.Peer, chan PeerErr) PeerConnectionManager,
-	peerAddr []common.Addr, err chan PeerErr, sf chan struct{}, hm HandshakeManager, w time.Duration, recWait time.Duration) (*Node, error) {
+	peerAddr []common.Addr, err chan PeerErr, sf chan struct{}, hm HandshakeManager, w time.Duration, recWait time.Duration,
+	checkInterval time.Duration, minThroughput uint64, inFlight InFlightBlocks, persisters []Persister) (*Node, error) {
 	_, ok := p2p.Networks[network]
 	if !ok {
 		return nil, fmt.Errorf("unsupported network %s", network)
@@ -50,6 +83,10 @@ func New(network, userAgent string, newServerPeer func(p2p.Peer, chan PeerErr) P
 		notifySyncForError:     make(chan PeerErr, 1000),
 		wg:                     &sync.WaitGroup{},
 		reconnectWait:          recWait,
+		checkInterval:          checkInterval,
+		minThroughput:          minThroughput,
+		inFlight:               inFlight,
+		persisters:             persisters,
 	}, nil
 }
 
@@ -63,6 +100,10 @@ func (n *Node) Start() {
 	}
 	n.wg.Add(1)
 	go n.listenForPeerErrors()
+	if n.checkInterval > 0 {
+		n.wg.Add(1)
+		go n.monitorSyncPeer()
+	}
 	for _, peerAddr := range n.peerAddrs {
 		err := n.connectToPeer(peerAddr)
 		if err == nil {
@@ -94,46 +135,109 @@ func (n *Node) getChainOverview(pch PeerChain) {
 			pch.view = &view
 			log.Printf("Overview: %#v\n", pch.view)
 			n.peerChain.Store(pch.peer.GetPeerAddr(), pch)
-			n.selectBestPeerChainForSync()
+			n.selectBestPeerChainForSync(pch)
 			return
 		}
 	}
 }
 
 type PeerChain struct {
+	id   int
 	peer PeerConnectionManager
 	view *common.ChainOverview
 }
 
-func (n *Node) selectBestPeerChainForSync() {
-	chainsOverviewsCompleted := true
-	var bestChain PeerChain
+// PeerInfo returns the information about the connected peers sorted by their IDs.
+func (n *Node) PeerInfo() []PeerInfo {
+	var peers []PeerInfo
 	n.peerChain.Range(func(key, value any) bool {
 		pch := value.(PeerChain)
-		if pch.view == nil {
-			chainsOverviewsCompleted = false
-			return false
+		// the peers to which the node failed to connect have no connection
+		if pch.peer == nil {
+			return true
 		}
+		info := pch.peer.Info()
+		info.ID = pch.id
+		peers = append(peers, info)
+		return true
+	})
+	slices.SortFunc(peers, func(a, b PeerInfo) int { return a.ID - b.ID })
+	return peers
+}
 
-		if bestChain.view == nil {
-			bestChain = pch
+// selectBestPeerChainForSync is called when the chain overview of a peer is done. The sync doesn't wait
+// for the overviews of all peers: when there is no sync peer, the peer with the best chain of the completed
+// overviews becomes the sync peer. The other peers with a valid chain take part in the block download,
+// unless they advertise more work than the sync peer, then they become the sync peer.
+func (n *Node) selectBestPeerChainForSync(pch PeerChain) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.syncPeer == nil {
+		n.startSync()
+		return
+	}
+	if !pch.view.IsValid {
+		return
+	}
+	if betterChain(currentView(pch.peer, *pch.view), currentView(n.syncPeer, *n.syncView)) {
+		log.Printf("peer %s advertises more work than the sync peer\n", pch.peer.GetPeerAddr())
+		n.switchSyncPeer(pch)
+		return
+	}
+	pch.peer.DownloadBlocks()
+}
+
+// startSync starts the sync with the peer with the best chain of the completed overviews and adds the other
+// peers with a valid chain to the block download. n.mu must be held.
+func (n *Node) startSync() {
+	var bestChain PeerChain
+	n.peerChain.Range(func(key, value any) bool {
+		pch := value.(PeerChain)
+		if pch.view == nil || !pch.view.IsValid {
 			return true
 		}
-
-		if bestChain.view.CumulativeWork.Cmp(pch.view.CumulativeWork) == -1 {
+		if bestChain.view == nil || betterChain(currentView(pch.peer, *pch.view), currentView(bestChain.peer, *bestChain.view)) {
 			bestChain = pch
 		}
-
 		return true
 	})
 
-	if !chainsOverviewsCompleted {
-		log.Println("overview is nit completed")
+	if bestChain.view == nil {
+		log.Println("there is no peer with a valid chain to sync with")
 		return
 	}
 
 	log.Printf("THE BEST CHAIN is from PEER: %#v\n", bestChain.peer.GetPeerAddr())
 	bestChain.peer.Sync()
+	n.setSyncPeer(bestChain)
+
+	// the blocks are downloaded from all peers with a valid chain
+	n.peerChain.Range(func(key, value any) bool {
+		pch := value.(PeerChain)
+		if pch.peer != bestChain.peer && pch.view != nil && pch.view.IsValid {
+			pch.peer.DownloadBlocks()
+		}
+		return true
+	})
+}
+
+// currentView returns the overview of the peer's chain with the work of the peer's validated tip, when the
+// peer announced blocks after its overview was taken. The overviews of all peers are compared at the same time.
+func currentView(p PeerConnectionManager, view common.ChainOverview) common.ChainOverview {
+	if tip := p.Tip(); tip.Work.Cmp(view.CumulativeWork) > 0 {
+		view.CumulativeWork = tip.Work
+	}
+	return view
+}
+
+// betterChain returns true when the chain a has more work than the chain b. When the work is the same, the
+// chain of the peer that announced a higher best block is better.
+func betterChain(a, b common.ChainOverview) bool {
+	if c := a.CumulativeWork.Cmp(b.CumulativeWork); c != 0 {
+		return c > 0
+	}
+	return a.StartHeight > b.StartHeight
 }
 
 func (n *Node) Stop() {
@@ -146,6 +250,11 @@ func (n *Node) Stop() {
 		return true
 	})
 	log.Println("all goroutines are stopped")
+	for _, p := range n.persisters {
+		if err := p.Persist(); err != nil {
+			log.Println("failed to persist the state of the node:", err)
+		}
+	}
 }
 
 func (n *Node) reconnectToPeer(addr common.Addr) {
@@ -180,7 +289,7 @@ func (n *Node) connectToPeer(addr common.Addr) error {
 	}
 
 	pcm := n.newServerPeer(handshake.Peer, n.errors)
-	pch := PeerChain{peer: pcm}
+	pch := PeerChain{id: int(n.lastPeerID.Add(1)), peer: pcm}
 	n.peerChain.Store(addr.String(), pch)
 	pcm.Start()
 
@@ -207,6 +316,7 @@ func (n *Node) listenForPeerErrors() {
 			}
 
 			n.peerChain.Delete(addr)
+			n.syncPeerDisconnected(addr)
 			address := common.AddrFromString(addr)
 
 			n.wg.Add(1)
@@ -214,3 +324,127 @@ func (n *Node) listenForPeerErrors() {
 		}
 	}
 }
+
+// syncPeerDisconnected starts the sync with another peer when the sync peer is disconnected.
+func (n *Node) syncPeerDisconnected(addr string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.syncPeer == nil || n.syncPeer.GetPeerAddr() != addr {
+		return
+	}
+
+	log.Println("the sync peer is disconnected:", addr)
+	n.syncPeer, n.syncView = nil, nil
+	n.startSync()
+}
+
+// switchSyncPeer stops the sync with the current sync peer, it continues to take part in the block
+// download, and starts the sync with the new one. n.mu must be held.
+func (n *Node) switchSyncPeer(pch PeerChain) {
+	log.Printf("switch the sync peer from %s to %s\n", n.syncPeer.GetPeerAddr(), pch.peer.GetPeerAddr())
+	n.syncPeer.StopSync()
+	pch.peer.Sync()
+	n.setSyncPeer(pch)
+}
+
+// setSyncPeer makes the peer the sync peer, its throughput is measured from the bytes that are received from
+// it until now. n.mu must be held.
+func (n *Node) setSyncPeer(pch PeerChain) {
+	n.syncPeer, n.syncView = pch.peer, pch.view
+	if n.checkInterval > 0 {
+		n.syncBytes, n.syncBytesAt = pch.peer.BytesReceived(), time.Now()
+	}
+}
+
+// monitorSyncPeer checks the validated tips of the peers and their throughput every check
+// interval and switches the sync peer when another peer has a better chain or the sync peer is too slow.
+func (n *Node) monitorSyncPeer() {
+	defer n.wg.Done()
+	ticker := time.NewTicker(n.checkInterval)
+	defer ticker.Stop()
+
+	// the number of bytes that are received from every peer until the last check
+	received := make(map[PeerConnectionManager]uint64)
+	for {
+		select {
+		case <-n.stop:
+			return
+		case <-ticker.C:
+			n.checkSyncPeer(received)
+		}
+	}
+}
+
+// checkSyncPeer switches the sync peer when the validated tip of another peer with a valid chain has more work
+// than the tip of the sync peer, by at least the work of syncPeerSwitchBlocks blocks. Otherwise
+// the sync peer is switched when its throughput is below the minimum and another peer has a throughput above
+// it, the fastest of them becomes the sync peer. The throughput of the sync peer is measured from the time it
+// became the sync peer, and it is not checked while no blocks are requested from it, because then it has
+// nothing to send. The throughput of the other peers is measured from the previous check.
+func (n *Node) checkSyncPeer(received map[PeerConnectionManager]uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	bytes := make(map[PeerConnectionManager]uint64)
+	throughput := make(map[PeerConnectionManager]uint64)
+	var best, fastest PeerChain
+	var bestTip PeerTip
+	n.peerChain.Range(func(key, value any) bool {
+		pch := value.(PeerChain)
+		if pch.view == nil || !pch.view.IsValid {
+			return true
+		}
+
+		if pch.peer == n.syncPeer {
+			return true
+		}
+		bytes[pch.peer] = pch.peer.BytesReceived()
+		// a new connection has no throughput until the next check
+		if prev, ok := received[pch.peer]; ok {
+			throughput[pch.peer] = (bytes[pch.peer] - min(prev, bytes[pch.peer])) * uint64(time.Second) / uint64(n.checkInterval)
+		}
+		if tip := pch.peer.Tip(); best.peer == nil || tip.Work.Cmp(bestTip.Work) > 0 {
+			best, bestTip = pch, tip
+		}
+		if fastest.peer == nil || throughput[pch.peer] > throughput[fastest.peer] {
+			fastest = pch
+		}
+		return true
+	})
+
+	// the disconnected peers are removed
+	clear(received)
+	for p, b := range bytes {
+		received[p] = b
+	}
+
+	if n.syncPeer == nil || best.peer == nil {
+		return
+	}
+	syncTip := n.syncPeer.Tip()
+	margin := new(big.Int).Mul(bestTip.BlockWork, big.NewInt(syncPeerSwitchBlocks))
+	if bestTip.Work.Cmp(margin.Add(margin, syncTip.Work)) > 0 {
+		log.Printf("the tip of peer %s at height %d has more work than the tip of the sync peer at height %d\n",
+			best.peer.GetPeerAddr(), bestTip.Height, syncTip.Height)
+		n.switchSyncPeer(best)
+		return
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(n.syncBytesAt)
+	if elapsed < n.checkInterval/2 {
+		// the peer has just become the sync peer
+		return
+	}
+	syncBytes := n.syncPeer.BytesReceived()
+	syncThroughput := (syncBytes - min(n.syncBytes, syncBytes)) * uint64(time.Second) / uint64(elapsed)
+	n.syncBytes, n.syncBytesAt = syncBytes, now
+	if n.inFlight != nil && n.inFlight.BlocksInFlight(n.syncPeer.GetPeerAddr()) == 0 {
+		return
+	}
+	if syncThroughput >= n.minThroughput || throughput[fastest.peer] < n.minThroughput {
+		return
+	}
+	log.Printf("the throughput of the sync peer is %d B/s, below the minimum %d B/s\n", syncThroughput, n.minThroughput)
+	n.switchSyncPeer(fastest)
+}
@@ -2,6 +2,7 @@ package node
 
 import (
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -42,15 +43,25 @@ func TestNode_StartAndSelectTheBestChainForSync(t *testing.T) {
 	peerConnMng1.EXPECT().Start().Times(1)
 	peerConnMng1.EXPECT().GetChainOverview().Return(chOverveiw1, nil)
 	peerConnMng1.EXPECT().GetPeerAddr().Return("127.0.0.1:5555").AnyTimes()
-	peerConnMng1.EXPECT().Sync()
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(-1, 0, 0)).AnyTimes()
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
 	peerConnMng1.EXPECT().Stop().Times(1)
 
+	downloading := make(chan struct{})
 	peerConnMng2.EXPECT().Start().Times(1)
 	peerConnMng2.EXPECT().GetChainOverview().Return(chOverveiw2, nil)
 	peerConnMng2.EXPECT().GetPeerAddr().Return("127.0.0.2:6666").AnyTimes()
+	peerConnMng2.EXPECT().Tip().Return(newPeerTip(-1, 0, 0)).AnyTimes()
+	peerConnMng2.EXPECT().DownloadBlocks().Do(func() { close(downloading) })
 	peerConnMng2.EXPECT().Stop().Times(1)
 
-	n, err := New("mainnet", "test-agent", newPeerConnMng, addrs, peerErrors, syncCompleted, handshakeManager, 10*time.Millisecond, 10*time.Millisecond)
+	// the state in memory is persisted after the peers are stopped
+	persister := NewMockPersister(ctrl)
+	persister.EXPECT().Persist().Return(nil).Times(1)
+
+	n, err := New("mainnet", "test-agent", newPeerConnMng, addrs, peerErrors, syncCompleted, handshakeManager, 10*time.Millisecond, 10*time.Millisecond, 0, 0, nil,
+		[]Persister{persister})
 	require.NoError(t, err)
 
 	n.Start()
@@ -61,12 +72,25 @@ func TestNode_StartAndSelectTheBestChainForSync(t *testing.T) {
 		CumulativeWork: big.NewInt(9999),
 		IsValid:        true,
 	}
+	// the sync doesn't wait for the overview of the second peer
+	<-synced
+
+	// the peer that completes its overview later takes part in the block download
 	chOverveiw2 <- common.ChainOverview{
 		Peer:           "127.0.0.1:6666",
 		NumberOfBlocks: 129,
 		CumulativeWork: big.NewInt(8888),
 		IsValid:        true,
 	}
+	<-downloading
+
+	// the peers get their IDs in the order of the connections
+	peerConnMng1.EXPECT().Info().Return(PeerInfo{Addr: "127.0.0.1:5555"}).Times(1)
+	peerConnMng2.EXPECT().Info().Return(PeerInfo{Addr: "127.0.0.2:6666"}).Times(1)
+	require.Equal(t, []PeerInfo{
+		{ID: 1, Addr: "127.0.0.1:5555"},
+		{ID: 2, Addr: "127.0.0.2:6666"},
+	}, n.PeerInfo())
 
 	n.Stop()
 }
@@ -89,10 +113,12 @@ func TestNode_WhenNodeHasOneConnectedPeerAndReceivePeerError(t *testing.T) {
 	peerConnMng1.EXPECT().Start().Times(2)
 	peerConnMng1.EXPECT().GetChainOverview().Return(chOverveiw1, nil).Times(2)
 	peerConnMng1.EXPECT().GetPeerAddr().Return("127.0.0.1:5555").AnyTimes()
-	peerConnMng1.EXPECT().Sync().Times(2)
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(-1, 0, 0)).AnyTimes()
+	synced := make(chan struct{}, 2)
+	peerConnMng1.EXPECT().Sync().Do(func() { synced <- struct{}{} }).Times(2)
 	peerConnMng1.EXPECT().Stop().Times(1)
 
-	n, err := New("mainnet", "test-agent", newPeerConnMng, addrs, peerErrors, syncCompleted, handshakeManager, 10*time.Millisecond, 10*time.Millisecond)
+	n, err := New("mainnet", "test-agent", newPeerConnMng, addrs, peerErrors, syncCompleted, handshakeManager, 10*time.Millisecond, 10*time.Millisecond, 0, 0, nil, nil)
 	require.NoError(t, err)
 
 	n.Start()
@@ -105,10 +131,241 @@ func TestNode_WhenNodeHasOneConnectedPeerAndReceivePeerError(t *testing.T) {
 	}
 
 	chOverveiw1 <- chO
+	<-synced
 	peerErrors <- PeerErr{Peer: p2p.Peer{Address: "127.0.0.1:5555"}}
+	// the peer is reconnected and becomes the sync peer again
 	chOverveiw1 <- chO
+	<-synced
 
 	//time.Sleep(5 * time.Second)
 
 	n.Stop()
 }
+
+func TestNode_SwitchesTheSyncPeerWhenAnotherPeerAdvertisesMoreWork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	peerConnMng1, peerConnMng2, n, chOverview1, chOverview2 := newNodeWithTwoPeers(t, ctrl, 0, 0, nil)
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(-1, 0, 0)).AnyTimes()
+	peerConnMng2.EXPECT().Tip().Return(newPeerTip(-1, 0, 0)).AnyTimes()
+
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
+	switched := make(chan struct{})
+	gomock.InOrder(
+		peerConnMng1.EXPECT().StopSync(),
+		peerConnMng2.EXPECT().Sync().Do(func() { close(switched) }),
+	)
+
+	n.Start()
+	chOverview1 <- common.ChainOverview{Peer: "127.0.0.1:5555", CumulativeWork: big.NewInt(8888), IsValid: true}
+	<-synced
+
+	// the chain of the second peer has more work, the first peer still downloads blocks
+	chOverview2 <- common.ChainOverview{Peer: "127.0.0.2:6666", CumulativeWork: big.NewInt(9999), IsValid: true}
+	<-switched
+
+	n.Stop()
+}
+
+func TestNode_ComparesTheOverviewOfAPeerWithTheCurrentTipOfTheSyncPeer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	peerConnMng1, peerConnMng2, n, chOverview1, chOverview2 := newNodeWithTwoPeers(t, ctrl, 0, 0, nil)
+	// the sync peer announced blocks after its overview, its tip has more work than the overview of the
+	// second peer that is taken later
+	var tip1 atomic.Pointer[PeerTip]
+	tip1.Store(&PeerTip{Height: -1, Work: big.NewInt(0), BlockWork: big.NewInt(0)})
+	peerConnMng1.EXPECT().Tip().DoAndReturn(func() PeerTip { return *tip1.Load() }).AnyTimes()
+	peerConnMng2.EXPECT().Tip().Return(newPeerTip(-1, 0, 0)).AnyTimes()
+
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
+	downloads := make(chan struct{})
+	peerConnMng2.EXPECT().DownloadBlocks().Do(func() { close(downloads) })
+
+	n.Start()
+	chOverview1 <- common.ChainOverview{Peer: "127.0.0.1:5555", CumulativeWork: big.NewInt(8888), IsValid: true}
+	<-synced
+	tip1.Store(&PeerTip{Height: 1000, Work: big.NewInt(10000), BlockWork: big.NewInt(10)})
+	chOverview2 <- common.ChainOverview{Peer: "127.0.0.2:6666", CumulativeWork: big.NewInt(9999), IsValid: true}
+	<-downloads
+
+	n.Stop()
+}
+
+func TestNode_SwitchesTheSyncPeerWhenTheTipOfAnotherPeerHasMoreWork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	peerConnMng1, peerConnMng2, n, chOverview1, chOverview2 := newNodeWithTwoPeers(t, ctrl, 10*time.Millisecond, 1000, nil)
+	peerConnMng1.EXPECT().BytesReceived().Return(uint64(0)).AnyTimes()
+	peerConnMng2.EXPECT().BytesReceived().Return(uint64(0)).AnyTimes()
+	// the second peer is more than 2 blocks ahead
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(100, 1000, 10)).AnyTimes()
+	peerConnMng2.EXPECT().Tip().Return(newPeerTip(103, 1030, 10)).AnyTimes()
+
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
+	peerConnMng2.EXPECT().DownloadBlocks()
+	switched := make(chan struct{})
+	gomock.InOrder(
+		peerConnMng1.EXPECT().StopSync(),
+		peerConnMng2.EXPECT().Sync().Do(func() { close(switched) }),
+	)
+
+	n.Start()
+	chOverview1 <- common.ChainOverview{Peer: "127.0.0.1:5555", CumulativeWork: big.NewInt(9999), IsValid: true}
+	<-synced
+	chOverview2 <- common.ChainOverview{Peer: "127.0.0.2:6666", CumulativeWork: big.NewInt(8888), IsValid: true}
+	<-switched
+
+	n.Stop()
+}
+
+func TestNode_KeepsTheSyncPeerWhenAnotherPeerIsAheadByLessThanTheMargin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	peerConnMng1, peerConnMng2, n, chOverview1, chOverview2 := newNodeWithTwoPeers(t, ctrl, 10*time.Millisecond, 0, nil)
+	peerConnMng1.EXPECT().BytesReceived().Return(uint64(0)).AnyTimes()
+	peerConnMng2.EXPECT().BytesReceived().Return(uint64(0)).AnyTimes()
+	// the peers announce the same blocks at different times, the second peer is 2 blocks ahead at most
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(100, 1000, 10)).AnyTimes()
+	checked := make(chan struct{}, 100)
+	peerConnMng2.EXPECT().Tip().DoAndReturn(func() PeerTip {
+		checked <- struct{}{}
+		return newPeerTip(102, 1020, 10)
+	}).AnyTimes()
+
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
+	downloads := make(chan struct{})
+	peerConnMng2.EXPECT().DownloadBlocks().Do(func() { close(downloads) })
+
+	n.Start()
+	chOverview1 <- common.ChainOverview{Peer: "127.0.0.1:5555", CumulativeWork: big.NewInt(9999), IsValid: true}
+	<-synced
+	chOverview2 <- common.ChainOverview{Peer: "127.0.0.2:6666", CumulativeWork: big.NewInt(8888), IsValid: true}
+	<-downloads
+	for i := 0; i < 3; i++ {
+		<-checked
+	}
+
+	n.Stop()
+}
+
+func TestNode_SwitchesTheSyncPeerWhenItsThroughputIsTooLow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inFlight := NewMockInFlightBlocks(ctrl)
+	inFlight.EXPECT().BlocksInFlight("127.0.0.1:5555").Return(16).AnyTimes()
+	peerConnMng1, peerConnMng2, n, chOverview1, chOverview2 := newNodeWithTwoPeers(t, ctrl, 10*time.Millisecond, 1000, inFlight)
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(100, 1000, 10)).AnyTimes()
+	peerConnMng2.EXPECT().Tip().Return(newPeerTip(100, 1000, 10)).AnyTimes()
+	// the sync peer doesn't send anything after the overview, the other peer sends 1000 bytes every 10 milliseconds
+	peerConnMng1.EXPECT().BytesReceived().Return(uint64(1_000_000)).AnyTimes()
+	var received uint64
+	peerConnMng2.EXPECT().BytesReceived().DoAndReturn(func() uint64 {
+		received += 1000
+		return received
+	}).AnyTimes()
+
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
+	peerConnMng2.EXPECT().DownloadBlocks()
+	switched := make(chan struct{})
+	gomock.InOrder(
+		peerConnMng1.EXPECT().StopSync(),
+		peerConnMng2.EXPECT().Sync().Do(func() { close(switched) }),
+	)
+
+	n.Start()
+	chOverview1 <- common.ChainOverview{Peer: "127.0.0.1:5555", CumulativeWork: big.NewInt(9999), IsValid: true}
+	<-synced
+	chOverview2 <- common.ChainOverview{Peer: "127.0.0.2:6666", CumulativeWork: big.NewInt(8888), IsValid: true}
+	<-switched
+
+	n.Stop()
+}
+
+func TestNode_KeepsTheSyncPeerWhenNoBlocksAreRequestedFromIt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inFlight := NewMockInFlightBlocks(ctrl)
+	checked := make(chan struct{}, 100)
+	inFlight.EXPECT().BlocksInFlight("127.0.0.1:5555").DoAndReturn(func(string) int {
+		checked <- struct{}{}
+		return 0
+	}).AnyTimes()
+	peerConnMng1, peerConnMng2, n, chOverview1, chOverview2 := newNodeWithTwoPeers(t, ctrl, 10*time.Millisecond, 1000, inFlight)
+	peerConnMng1.EXPECT().Tip().Return(newPeerTip(100, 1000, 10)).AnyTimes()
+	peerConnMng2.EXPECT().Tip().Return(newPeerTip(100, 1000, 10)).AnyTimes()
+	// the sync peer has nothing to send, the other peer sends 1000 bytes every 10 milliseconds
+	peerConnMng1.EXPECT().BytesReceived().Return(uint64(0)).AnyTimes()
+	var received atomic.Uint64
+	peerConnMng2.EXPECT().BytesReceived().DoAndReturn(func() uint64 {
+		return received.Add(1000)
+	}).AnyTimes()
+
+	synced := make(chan struct{})
+	peerConnMng1.EXPECT().Sync().Do(func() { close(synced) })
+	downloads := make(chan struct{})
+	peerConnMng2.EXPECT().DownloadBlocks().Do(func() { close(downloads) })
+
+	n.Start()
+	chOverview1 <- common.ChainOverview{Peer: "127.0.0.1:5555", CumulativeWork: big.NewInt(9999), IsValid: true}
+	<-synced
+	chOverview2 <- common.ChainOverview{Peer: "127.0.0.2:6666", CumulativeWork: big.NewInt(8888), IsValid: true}
+	<-downloads
+	for i := 0; i < 3; i++ {
+		<-checked
+	}
+
+	n.Stop()
+}
+
+// newNodeWithTwoPeers returns a Node that connects to two peers and the channels of their chain overviews. The
+// inFlight can be nil.
+func newNodeWithTwoPeers(t *testing.T, ctrl *gomock.Controller, checkInterval time.Duration, minThroughput uint64,
+	inFlight InFlightBlocks) (
+	*MockPeerConnectionManager, *MockPeerConnectionManager, *Node, chan common.ChainOverview, chan common.ChainOverview) {
+	peerConnMng1 := NewMockPeerConnectionManager(ctrl)
+	peerConnMng2 := NewMockPeerConnectionManager(ctrl)
+	peers := map[string]PeerConnectionManager{"127.0.0.1": peerConnMng1, "127.0.0.2": peerConnMng2}
+	newPeerConnMng := func(p p2p.Peer, _ chan PeerErr) PeerConnectionManager {
+		return peers[p.Address]
+	}
+	addrs := []common.Addr{
+		{IP: "127.0.0.1", Port: 5555},
+		{IP: "127.0.0.2", Port: 6666},
+	}
+
+	chOverview1 := make(chan common.ChainOverview)
+	chOverview2 := make(chan common.ChainOverview)
+	handshakeManager := NewMockHandshakeManager(ctrl)
+	handshakeManager.EXPECT().CreateOutgoingHandshake(addrs[0], "mainnet", "test-agent").Return(p2p.Handshake{Peer: p2p.Peer{Address: "127.0.0.1"}}, nil)
+	handshakeManager.EXPECT().CreateOutgoingHandshake(addrs[1], "mainnet", "test-agent").Return(p2p.Handshake{Peer: p2p.Peer{Address: "127.0.0.2"}}, nil)
+	peerConnMng1.EXPECT().Start()
+	peerConnMng1.EXPECT().GetChainOverview().Return(chOverview1, nil)
+	peerConnMng1.EXPECT().GetPeerAddr().Return("127.0.0.1:5555").AnyTimes()
+	peerConnMng1.EXPECT().Stop()
+	peerConnMng2.EXPECT().Start()
+	peerConnMng2.EXPECT().GetChainOverview().Return(chOverview2, nil)
+	peerConnMng2.EXPECT().GetPeerAddr().Return("127.0.0.2:6666").AnyTimes()
+	peerConnMng2.EXPECT().Stop()
+
+	n, err := New("mainnet", "test-agent", newPeerConnMng, addrs, make(chan PeerErr), make(chan struct{}), handshakeManager,
+		10*time.Millisecond, 10*time.Millisecond, checkInterval, minThroughput, inFlight, nil)
+	require.NoError(t, err)
+	return peerConnMng1, peerConnMng2, n, chOverview1, chOverview2
+}
+
+// newPeerTip returns the tip of a peer at the given height with the chain work and the work of the tip block.
+func newPeerTip(height int32, work, blockWork int64) PeerTip {
+	return PeerTip{Height: height, Work: big.NewInt(work), BlockWork: big.NewInt(blockWork)}
+}
+
+func TestBetterChain(t *testing.T) {
+	a := common.ChainOverview{CumulativeWork: big.NewInt(100), StartHeight: 10}
+	b := common.ChainOverview{CumulativeWork: big.NewInt(50), StartHeight: 20}
+	require.True(t, betterChain(a, b))
+	require.False(t, betterChain(b, a))
+
+	// with the same work the peer with the higher best block is better
+	b.CumulativeWork = big.NewInt(100)
+	require.True(t, betterChain(b, a))
+	require.False(t, betterChain(a, a))
+}
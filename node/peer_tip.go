@@ -0,0 +1,57 @@
+package node
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// PeerTip is the best header that a peer sent which is validated and connected to the local header chain.
+// Work is the total work of the chain up to it and BlockWork is the work of the header itself. The height is
+// -1 and the work is 0 until the first headers of the peer are stored.
+type PeerTip struct {
+	Height    int32
+	Work      *big.Int
+	BlockWork *big.Int
+}
+
+// PeerTipTracker keeps the tip of a peer. It is updated by the headers handler of the peer when its headers
+// are stored, so the blocks that the peer only announces don't move its tip.
+type PeerTipTracker struct {
+	mu  sync.Mutex
+	tip PeerTip
+}
+
+// NewPeerTipTracker creates a PeerTipTracker of a peer whose tip is not known yet.
+func NewPeerTipTracker() *PeerTipTracker {
+	return &PeerTipTracker{tip: PeerTip{Height: -1, Work: new(big.Int), BlockWork: new(big.Int)}}
+}
+
+// Update sets the tip of the peer to the stored header with the given height and chain work, unless the
+// current tip has at least the same work.
+func (t *PeerTipTracker) Update(header p2p.BlockHeader, height int32, work *big.Int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if work.Cmp(t.tip.Work) <= 0 {
+		return
+	}
+	t.tip = PeerTip{Height: height, Work: new(big.Int).Set(work), BlockWork: headerWork(header.Bits)}
+}
+
+// Tip returns the tip of the peer.
+func (t *PeerTipTracker) Tip() PeerTip {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return PeerTip{Height: t.tip.Height, Work: new(big.Int).Set(t.tip.Work), BlockWork: new(big.Int).Set(t.tip.BlockWork)}
+}
+
+// headerWork returns the work of a header with the given bits, 2^256 / target.
+func headerWork(bits uint32) *big.Int {
+	target := BitsToTarget(bits)
+	if target.Sign() <= 0 {
+		return new(big.Int)
+	}
+	work := new(big.Int).Lsh(big.NewInt(1), 256)
+	return work.Div(work, target)
+}
@@ -0,0 +1,38 @@
+package node
+
+// MinBlocksToKeep is the number of the last blocks whose data is never pruned. They are needed to handle
+// reorganizations and to serve the recent blocks to the peers (BIP 159 requires at least 288 blocks).
+const MinBlocksToKeep = 288
+
+// Pruner deletes the raw data of the blocks that are deep enough in the chain and are already
+// connected to the UTXO set, so that the stored blocks stay below the target size.
+type Pruner struct {
+	blockRepository PrunableBlockRepository
+	chainState      ChainState
+	target          uint64
+}
+
+// NewPruner creates a Pruner that keeps the stored blocks below targetMiB mebibytes.
+func NewPruner(br PrunableBlockRepository, cs ChainState, targetMiB uint64) Pruner {
+	return Pruner{
+		blockRepository: br,
+		chainState:      cs,
+		target:          targetMiB * 1024 * 1024,
+	}
+}
+
+// Prune deletes the data of the blocks that are at least MinBlocksToKeep blocks below the
+// best block of the UTXO set.
+func (p Pruner) Prune() error {
+	_, height, err := p.chainState.BestBlock()
+	if err != nil {
+		return err
+	}
+
+	pruneHeight := height - MinBlocksToKeep
+	if pruneHeight <= 0 {
+		return nil
+	}
+
+	return p.blockRepository.Prune(p.target, pruneHeight)
+}
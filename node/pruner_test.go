@@ -0,0 +1,25 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruner_KeepsTheLastBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chainState := node.NewMockChainState(ctrl)
+	blockRepo := node.NewMockPrunableBlockRepository(ctrl)
+
+	pruner := node.NewPruner(blockRepo, chainState, 550)
+
+	// nothing is pruned until the chain is deeper than MinBlocksToKeep
+	chainState.EXPECT().BestBlock().Return([32]byte{}, int32(node.MinBlocksToKeep), nil).Times(1)
+	require.NoError(t, pruner.Prune())
+
+	chainState.EXPECT().BestBlock().Return([32]byte{}, int32(1000), nil).Times(1)
+	blockRepo.EXPECT().Prune(uint64(550*1024*1024), int32(1000-node.MinBlocksToKeep)).Return(nil).Times(1)
+	require.NoError(t, pruner.Prune())
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type Mode int
@@ -30,8 +31,23 @@ type ServerPeer struct {
 	mode       atomic.Int64
 	msgHeaders chan<- *p2p.MsgHeaders
 
-	msgBlocks chan<- *p2p.MsgBlock
-	stop      chan struct{}
+	msgBlocks   chan<- *p2p.MsgBlock
+	msgGetData  chan<- *p2p.MsgGetData
+	msgNotFound chan<- *p2p.MsgNotFound
+	// the getheaders and getblocks messages are not handled when the channels are nil
+	msgGetHeaders chan<- *p2p.MsgGetHeader
+	msgGetBlocks  chan<- *p2p.MsgGetBlocks
+	// the transactions and their announcements are not handled when the channels are nil
+	msgInv chan<- *p2p.MsgInv
+	msgTx  chan<- *p2p.MsgTx
+	// the package relay and feefilter messages are not handled when the channel is nil
+	msgRelay chan<- any
+	stop     chan struct{}
+
+	// tip is the best header of the peer that is validated and stored, the tip is unknown when it is nil
+	tip *PeerTipTracker
+	// connTime is the time when the connection with the peer is established
+	connTime time.Time
 
 	wg sync.WaitGroup
 
@@ -41,7 +57,9 @@ type ServerPeer struct {
 }
 
 func NewServerPeer(network string, mhm MsgHandlersManager, ps SyncManager, nmh NetworkMessageHandler, p p2p.Peer,
-	out chan *p2p.Message, e chan<- PeerErr, h chan<- *p2p.MsgHeaders, b chan<- *p2p.MsgBlock) *ServerPeer {
+	out chan *p2p.Message, e chan<- PeerErr, h chan<- *p2p.MsgHeaders, b chan<- *p2p.MsgBlock, gd chan<- *p2p.MsgGetData,
+	nf chan<- *p2p.MsgNotFound, gh chan<- *p2p.MsgGetHeader, gb chan<- *p2p.MsgGetBlocks, inv chan<- *p2p.MsgInv,
+	tx chan<- *p2p.MsgTx, relay chan<- any, tip *PeerTipTracker) *ServerPeer {
 	sp := &ServerPeer{
 		network:               network,
 		msgHandlersManager:    mhm,
@@ -52,7 +70,16 @@ func NewServerPeer(network string, mhm MsgHandlersManager, ps SyncManager, nmh N
 		errors:                e,
 		msgHeaders:            h,
 		msgBlocks:             b,
+		msgGetData:            gd,
+		msgNotFound:           nf,
+		msgGetHeaders:         gh,
+		msgGetBlocks:          gb,
+		msgInv:                inv,
+		msgTx:                 tx,
+		msgRelay:              relay,
+		tip:                   tip,
 		stop:                  make(chan struct{}, 1),
+		connTime:              time.Now(),
 	}
 	sp.mode.Store(int64(Overview))
 	return sp
@@ -78,7 +105,7 @@ func (sp *ServerPeer) GetPeerAddr() string {
 func (sp *ServerPeer) GetChainOverview() (<-chan common.ChainOverview, error) {
 	ch := make(chan common.ChainOverview, 1)
 	sp.mode.Store(int64(Overview))
-	sp.peerSync.StartChainOverview(sp.peer.Address, ch)
+	sp.peerSync.StartChainOverview(sp.peer.Address, sp.peer.StartHeight, ch)
 	return ch, nil
 }
 
@@ -92,10 +119,52 @@ func (sp *ServerPeer) Sync() {
 	sp.peerSync.Start()
 }
 
+// DownloadBlocks starts all message handlers, so the peer takes part in the block download, but the
+// headers are not synced from it.
+func (sp *ServerPeer) DownloadBlocks() {
+	if !sp.isStarted.Load() {
+		log.Println("Can't download blocks because serverPeer is not started.")
+		return
+	}
+	sp.msgHandlersManager.Start()
+	sp.mode.Store(int64(Standard))
+}
+
+// StopSync stops syncing the headers from the peer, it still takes part in the block download.
 func (sp *ServerPeer) StopSync() {
 	sp.peerSync.Stop()
 }
 
+// BytesReceived returns the number of bytes that are received from the peer.
+func (sp *ServerPeer) BytesReceived() uint64 {
+	return sp.networkMessageHandler.BytesRead()
+}
+
+// Tip returns the best header of the peer that is validated and connected to the local header chain.
+func (sp *ServerPeer) Tip() PeerTip {
+	if sp.tip == nil {
+		return NewPeerTipTracker().Tip()
+	}
+	return sp.tip.Tip()
+}
+
+// Info returns the information about the peer and its connection.
+func (sp *ServerPeer) Info() PeerInfo {
+	return PeerInfo{
+		Addr:          sp.peer.Address,
+		Services:      sp.peer.Services,
+		Version:       sp.peer.Version,
+		UserAgent:     sp.peer.UserAgent,
+		StartHeight:   sp.peer.StartHeight,
+		TipHeight:     sp.Tip().Height,
+		BytesSent:     sp.networkMessageHandler.BytesWritten(),
+		BytesReceived: sp.BytesReceived(),
+		ConnTime:      sp.connTime,
+		WTxIDRelay:    sp.peer.WTxIDRelay,
+		RelayTxes:     sp.peer.Relay,
+	}
+}
+
 func (sp *ServerPeer) Stop() {
 	if !sp.isStarted.Load() {
 		log.Println("Can't stop ServerPeer because it is not started.")
@@ -110,6 +179,33 @@ func (sp *ServerPeer) Stop() {
 	log.Println("Stop ServerPeer:", err)
 }
 
+// Disconnect reports the error to the Node and stops the ServerPeer, e.g. when the peer stalls the
+// block download.
+func (sp *ServerPeer) Disconnect(err error) {
+	if !sp.isStarted.Load() {
+		return
+	}
+	sp.errors <- PeerErr{Peer: sp.peer, Err: err}
+	go sp.Stop()
+}
+
+// PeerInfo is the information about a connected peer. The ID is assigned by the Node when it connects to
+// the peer. TipHeight is the height of the peer's best header that is validated, -1 when it is unknown.
+type PeerInfo struct {
+	ID            int
+	Addr          string
+	Services      uint64
+	Version       int32
+	UserAgent     string
+	StartHeight   int32
+	TipHeight     int32
+	BytesSent     uint64
+	BytesReceived uint64
+	ConnTime      time.Time
+	WTxIDRelay    bool
+	RelayTxes     bool
+}
+
 type PeerErr struct {
 	Peer p2p.Peer
 	Err  error
@@ -158,7 +254,7 @@ func (sp *ServerPeer) handOutgoingMsgs(wg *sync.WaitGroup) {
 			return
 		case msg := <-sp.outgoingMsgs:
 
-			if sp.mode.Load() == int64(Overview) && msg.CommandString() != p2p.CmdGetheaders && msg.CommandString() != p2p.CmdPong {
+			if sp.mode.Load() == int64(Overview) && !allowedInOverview(msg.CommandString()) {
 				continue
 			}
 			log.Println("send outgoin message:", msg.MessageHeader.CommandString())
@@ -184,9 +280,14 @@ func (sp *ServerPeer) handleMessage(msg interface{}) {
 	case *p2p.MsgVersion:
 	case *p2p.MsgVerack:
 	case *p2p.MsgWtxidrelay:
+	case *p2p.MsgSendPackages:
 	case *p2p.MsgPing:
 		pp := msg.(*p2p.MsgPing)
-		pong, _ := p2p.NewPongMsg("mainnet", pp.Nonce)
+		pong, err := p2p.NewPongMsg(sp.network, pp.Nonce)
+		if err != nil {
+			log.Println("failed to create pong message:", err)
+			return
+		}
 		sp.outgoingMsgs <- pong
 	case *p2p.MsgHeaders:
 		sp.msgHeaders <- msg.(*p2p.MsgHeaders)
@@ -195,7 +296,82 @@ func (sp *ServerPeer) handleMessage(msg interface{}) {
 			return
 		}
 		sp.msgBlocks <- msg.(*p2p.MsgBlock)
+	case *p2p.MsgGetData:
+		sp.msgGetData <- msg.(*p2p.MsgGetData)
+	case *p2p.MsgNotFound:
+		if sp.msgNotFound == nil || sp.mode.Load() == int64(Overview) {
+			return
+		}
+		sp.msgNotFound <- msg.(*p2p.MsgNotFound)
+	case *p2p.MsgInv:
+		inv := msg.(*p2p.MsgInv)
+		if sp.mode.Load() == int64(Standard) && hasBlockInventory(inv) {
+			sp.peerSync.BlocksAnnounced()
+		}
+		if sp.msgInv != nil && sp.mode.Load() == int64(Standard) && hasTxInventory(inv) {
+			sp.msgInv <- inv
+		}
+	case *p2p.MsgTx:
+		if sp.msgTx == nil || sp.mode.Load() == int64(Overview) {
+			return
+		}
+		sp.msgTx <- msg.(*p2p.MsgTx)
+	case *p2p.MsgAncPkgInfo, *p2p.MsgGetPkgTxns, *p2p.MsgPkgTxns:
+		if sp.msgRelay == nil || sp.mode.Load() == int64(Overview) {
+			return
+		}
+		sp.msgRelay <- msg
+	case *p2p.MsgFeeFilter:
+		// the peers send the feefilter right after the handshake, so it is kept until the transactions are
+		// relayed, but it never blocks the peer
+		if sp.msgRelay == nil {
+			return
+		}
+		select {
+		case sp.msgRelay <- msg:
+		default:
+		}
+	case *p2p.MsgGetHeader:
+		if sp.msgGetHeaders != nil {
+			sp.msgGetHeaders <- msg.(*p2p.MsgGetHeader)
+		}
+	case *p2p.MsgGetBlocks:
+		if sp.msgGetBlocks != nil {
+			sp.msgGetBlocks <- msg.(*p2p.MsgGetBlocks)
+		}
 	default:
 		//log.Printf("missing handler for msg: %#v\n", msg)
 	}
 }
+
+// hasBlockInventory returns true when the inv message announces blocks. The announced blocks are not
+// trusted, the peer's tip moves only when their headers are received and validated.
+func hasBlockInventory(inv *p2p.MsgInv) bool {
+	for _, v := range inv.Inventory {
+		if v.Type == p2p.InvTypeBlock {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTxInventory returns true when the inv message announces transactions.
+func hasTxInventory(inv *p2p.MsgInv) bool {
+	for _, v := range inv.Inventory {
+		if v.Type == p2p.InvTypeTx || v.Type == p2p.InvTypeWtx {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedInOverview returns true for the outgoing messages that can be sent while the peer's chain
+// overview is running. Replies to the peer's requests are always sent.
+func allowedInOverview(cmd string) bool {
+	switch cmd {
+	case p2p.CmdGetheaders, p2p.CmdPong, p2p.CmdBlock, p2p.CmdTx, p2p.CmdNotfound, p2p.CmdHeaders, p2p.CmdInv,
+		p2p.CmdAncpkginfo, p2p.CmdFeefilter:
+		return true
+	}
+	return false
+}
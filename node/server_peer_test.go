@@ -3,6 +3,7 @@ package node_test
 import (
 	"errors"
 	"fmt"
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
 	"github.com/EmilGeorgiev/btc-node/network/binary"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 	"github.com/EmilGeorgiev/btc-node/node"
@@ -16,12 +17,12 @@ import (
 func TestServerPeer_StartHandleIncomingMsgsHeadersAndBlocks(t *testing.T) {
 	prevBlockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F, 0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 
-	bh1 := newBlockHeader(prevBlockHash)
-	bh2 := newBlockHeader(node.Hash(bh1))
-	bh3 := newBlockHeader(node.Hash(bh2))
+	bh1 := testutil.NewBlockHeader(prevBlockHash)
+	bh2 := testutil.NewBlockHeader(node.Hash(bh1))
+	bh3 := testutil.NewBlockHeader(node.Hash(bh2))
 	blockHeaders := []p2p.BlockHeader{bh1, bh2, bh3}
 	msgHeaders := &p2p.MsgHeaders{Count: 3, BlockHeaders: blockHeaders}
-	block := newMsgBlock(prevBlockHash)
+	block := testutil.NewMsgBlock(prevBlockHash)
 
 	ctrl := gomock.NewController(t)
 
@@ -44,7 +45,7 @@ func TestServerPeer_StartHandleIncomingMsgsHeadersAndBlocks(t *testing.T) {
 	msgBlocksCh := make(chan *p2p.MsgBlock)
 
 	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
-		networkMessageHandler, peer, outgoingMsgs, errors, msgHeadersCh, msgBlocksCh)
+		networkMessageHandler, peer, outgoingMsgs, errors, msgHeadersCh, msgBlocksCh, nil, nil, nil, nil, nil, nil, nil, nil)
 	sp.Start()
 	sp.Sync()
 
@@ -57,11 +58,37 @@ func TestServerPeer_StartHandleIncomingMsgsHeadersAndBlocks(t *testing.T) {
 	require.True(t, fConn.IsClosed)
 }
 
+func TestServerPeer_RepliesToPingWithPongOfItsNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fConn := &FakeConn{}
+	msgHandlersManager := node.NewMockMsgHandlersManager(ctrl)
+	msgHandlersManager.EXPECT().StartOverviewHandlers().Times(1)
+	msgHandlersManager.EXPECT().Stop().Times(1)
+	peerSync := node.NewMockSyncManager(ctrl)
+	peerSync.EXPECT().Stop()
+	networkMessageHandler := node.NewMockNetworkMessageHandler(ctrl)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).Return(&p2p.MsgPing{Nonce: 42}, nil).Times(1)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).Return(&p2p.Message{}, &timeoutError{}).AnyTimes()
+
+	pong, err := p2p.NewPongMsg("simnet", 42)
+	require.NoError(t, err)
+	written := make(chan struct{})
+	networkMessageHandler.EXPECT().WriteMessage(pong, fConn).Do(func(*p2p.Message, net.Conn) { close(written) }).Return(nil)
+
+	peer := p2p.Peer{Connection: fConn, Address: "127.0.0.1:5555"}
+	sp := node.NewServerPeer("simnet", msgHandlersManager, peerSync, networkMessageHandler, peer, make(chan *p2p.Message, 1),
+		make(chan node.PeerErr), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	sp.Start()
+
+	<-written
+	sp.Stop()
+}
+
 func TestServerPeer_StartHandleOutgoingMsgsHeadersAndBlocks(t *testing.T) {
 	prevBlockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F, 0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 
-	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", 1, prevBlockHash, [32]byte{})
-	b, _ := binary.Marshal(newMsgBlock(prevBlockHash))
+	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", [][32]byte{prevBlockHash}, [32]byte{})
+	b, _ := binary.Marshal(testutil.NewMsgBlock(prevBlockHash))
 	blockMsg, _ := p2p.NewMessage(p2p.CmdPong, "mainnet", b)
 
 	ctrl := gomock.NewController(t)
@@ -84,7 +111,7 @@ func TestServerPeer_StartHandleOutgoingMsgsHeadersAndBlocks(t *testing.T) {
 	msgBlocksCh := make(chan *p2p.MsgBlock)
 
 	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
-		networkMessageHandler, peer, outgoingMsgs, errorsCh, msgHeadersCh, msgBlocksCh)
+		networkMessageHandler, peer, outgoingMsgs, errorsCh, msgHeadersCh, msgBlocksCh, nil, nil, nil, nil, nil, nil, nil, nil)
 	sp.Start()
 
 	outgoingMsgs <- msgGetHeaders
@@ -98,7 +125,7 @@ func TestServerPeer_StartHandleOutgoingMsgsHeadersAndBlocks(t *testing.T) {
 func TestServerPeer_WhenReadAndWriteTimeout(t *testing.T) {
 	prevBlockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F, 0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 
-	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", 1, prevBlockHash, [32]byte{})
+	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", [][32]byte{prevBlockHash}, [32]byte{})
 
 	ctrl := gomock.NewController(t)
 	msgHandlersManager := node.NewMockMsgHandlersManager(ctrl)
@@ -119,7 +146,7 @@ func TestServerPeer_WhenReadAndWriteTimeout(t *testing.T) {
 	msgBlocksCh := make(chan *p2p.MsgBlock)
 
 	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
-		networkMessageHandler, peer, outgoingMsgs, errors, msgHeadersCh, msgBlocksCh)
+		networkMessageHandler, peer, outgoingMsgs, errors, msgHeadersCh, msgBlocksCh, nil, nil, nil, nil, nil, nil, nil, nil)
 	sp.Start()
 
 	outgoingMsgs <- msgGetHeaders
@@ -130,6 +157,82 @@ func TestServerPeer_WhenReadAndWriteTimeout(t *testing.T) {
 	require.True(t, fConn.IsClosed)
 }
 
+func TestServerPeer_RequestsTheHeadersOfTheAnnouncedBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	msgHandlersManager := node.NewMockMsgHandlersManager(ctrl)
+	msgHandlersManager.EXPECT().StartOverviewHandlers().Times(1)
+	msgHandlersManager.EXPECT().Start().Times(1)
+	msgHandlersManager.EXPECT().Stop().Times(1)
+	peerSync := node.NewMockSyncManager(ctrl)
+	peerSync.EXPECT().Stop()
+	// the inv with transactions only doesn't move the tip
+	announced := make(chan struct{})
+	peerSync.EXPECT().BlocksAnnounced().Do(func() { close(announced) }).Times(1)
+
+	downloading := make(chan struct{})
+	txInv := &p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeTx, Hash: [32]byte{2}}}}
+	blockInv := &p2p.MsgInv{Count: 2, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeBlock, Hash: [32]byte{1}},
+		{Type: p2p.InvTypeBlock, Hash: [32]byte{3}},
+	}}
+	fConn := &FakeConn{}
+	networkMessageHandler := node.NewMockNetworkMessageHandler(ctrl)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).DoAndReturn(func(net.Conn) (interface{}, error) {
+		<-downloading
+		return txInv, nil
+	}).Times(1)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).Return(blockInv, nil).Times(1)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).Return(&p2p.Message{}, &timeoutError{}).AnyTimes()
+
+	peer := p2p.Peer{Connection: fConn, Address: "127.0.0.1:5555", StartHeight: 100}
+	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
+		networkMessageHandler, peer, make(chan *p2p.Message), make(chan node.PeerErr), nil, nil, nil, nil, nil, nil, nil, nil, nil, node.NewPeerTipTracker())
+	sp.Start()
+	sp.DownloadBlocks()
+	close(downloading)
+
+	<-announced
+	// the announced blocks are not trusted until their headers are stored
+	require.Equal(t, int32(-1), sp.Tip().Height)
+	sp.Stop()
+}
+
+func TestServerPeer_PassTheTxsAndTheirAnnouncementsWhenTheBlocksAreDownloaded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	msgHandlersManager := node.NewMockMsgHandlersManager(ctrl)
+	msgHandlersManager.EXPECT().StartOverviewHandlers().Times(1)
+	msgHandlersManager.EXPECT().Start().Times(1)
+	msgHandlersManager.EXPECT().Stop().Times(1)
+	peerSync := node.NewMockSyncManager(ctrl)
+	peerSync.EXPECT().Stop()
+
+	// the messages are read after the peer takes part in the block download
+	downloading := make(chan struct{})
+	inv := &p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeWtx, Hash: [32]byte{1}}}}
+	tx := testutil.NewMsgTx([]p2p.OutPoint{{Hash: [32]byte{2}}}, 1000)
+	fConn := &FakeConn{}
+	networkMessageHandler := node.NewMockNetworkMessageHandler(ctrl)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).DoAndReturn(func(net.Conn) (interface{}, error) {
+		<-downloading
+		return inv, nil
+	}).Times(1)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).Return(&tx, nil).Times(1)
+	networkMessageHandler.EXPECT().ReadMessage(fConn).Return(&p2p.Message{}, &timeoutError{}).AnyTimes()
+
+	peer := p2p.Peer{Connection: fConn, Address: "127.0.0.1:5555"}
+	invCh := make(chan *p2p.MsgInv)
+	txCh := make(chan *p2p.MsgTx)
+	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
+		networkMessageHandler, peer, make(chan *p2p.Message), make(chan node.PeerErr), nil, nil, nil, nil, nil, nil, invCh, txCh, nil, nil)
+	sp.Start()
+	sp.DownloadBlocks()
+	close(downloading)
+
+	require.Equal(t, inv, <-invCh)
+	require.Equal(t, &tx, <-txCh)
+	sp.Stop()
+}
+
 func TestServerPeer_WhenReadMsgFail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	msgHandlersManager := node.NewMockMsgHandlersManager(ctrl)
@@ -149,7 +252,7 @@ func TestServerPeer_WhenReadMsgFail(t *testing.T) {
 	msgBlocksCh := make(chan *p2p.MsgBlock)
 
 	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
-		networkMessageHandler, peer, outgoingMsgs, errorsCh, msgHeadersCh, msgBlocksCh)
+		networkMessageHandler, peer, outgoingMsgs, errorsCh, msgHeadersCh, msgBlocksCh, nil, nil, nil, nil, nil, nil, nil, nil)
 	sp.Start()
 
 	actual := <-errorsCh
@@ -164,7 +267,7 @@ func TestServerPeer_WhenReadMsgFail(t *testing.T) {
 func TestServerPeer_WhenWriteMsgFail(t *testing.T) {
 	prevBlockHash := [32]byte{0x3B, 0xA3, 0xED, 0xFD, 0x7A, 0x7B, 0x12, 0xB2, 0x7A, 0xC7, 0x2C, 0x3E, 0x67, 0x76, 0x8F, 0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 
-	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", 1, prevBlockHash, [32]byte{})
+	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", [][32]byte{prevBlockHash}, [32]byte{})
 
 	ctrl := gomock.NewController(t)
 	msgHandlersManager := node.NewMockMsgHandlersManager(ctrl)
@@ -185,7 +288,7 @@ func TestServerPeer_WhenWriteMsgFail(t *testing.T) {
 	msgBlocksCh := make(chan *p2p.MsgBlock)
 
 	sp := node.NewServerPeer("mainnet", msgHandlersManager, peerSync,
-		networkMessageHandler, peer, outgoingMsgs, errorsCh, msgHeadersCh, msgBlocksCh)
+		networkMessageHandler, peer, outgoingMsgs, errorsCh, msgHeadersCh, msgBlocksCh, nil, nil, nil, nil, nil, nil, nil, nil)
 	sp.Start()
 
 	outgoingMsgs <- msgGetHeaders
@@ -0,0 +1,138 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// ErrSnapshotInvalid is returned for every new block after the background validation found that the
+// UTXO snapshot, that the node is loaded from, doesn't match the history of the chain.
+var ErrSnapshotInvalid = errors.New("the UTXO snapshot doesn't match the history of the chain")
+
+// SnapshotChainState is the ChainState of a node that is loaded from a UTXO snapshot. The new blocks are
+// connected to the snapshot UTXO set, so the node syncs from the snapshot height at once. The blocks from
+// the genesis block to the snapshot base block are connected in the background to a second UTXO set and
+// when it reaches the base block its hash is compared with the hash of the snapshot.
+type SnapshotChainState struct {
+	active     ChainState
+	background BackgroundChainState
+	baseHash   [32]byte
+	baseHeight int32
+	baseUTXO   [32]byte
+
+	mu        sync.Mutex
+	validated bool
+	invalid   bool
+	// history is toggled on every SyncFrom call, so the sync alternates between the tip and the history
+	history bool
+}
+
+// NewSnapshotChainState creates a new SnapshotChainState for the snapshot with the given base block and
+// UTXO set hash. When the background UTXO set is already at the base block, the snapshot is validated.
+func NewSnapshotChainState(active ChainState, background BackgroundChainState, baseHash [32]byte, baseHeight int32,
+	baseUTXOHash [32]byte) (*SnapshotChainState, error) {
+	cs := &SnapshotChainState{
+		active:     active,
+		background: background,
+		baseHash:   baseHash,
+		baseHeight: baseHeight,
+		baseUTXO:   baseUTXOHash,
+	}
+
+	_, height, err := background.BestBlock()
+	if err != nil {
+		return nil, err
+	}
+	if height >= baseHeight {
+		if err = cs.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return cs, nil
+}
+
+// ConnectBlock connects the block to the background UTXO set when it is the next block of the history
+// before the snapshot, otherwise to the snapshot UTXO set.
+func (cs *SnapshotChainState) ConnectBlock(block p2p.MsgBlock) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.invalid {
+		return ErrSnapshotInvalid
+	}
+	if cs.validated {
+		return cs.active.ConnectBlock(block)
+	}
+
+	bgHash, bgHeight, err := cs.background.BestBlock()
+	if err != nil {
+		return err
+	}
+	if block.PrevBlockHash != bgHash || bgHeight >= cs.baseHeight {
+		return cs.active.ConnectBlock(block)
+	}
+
+	if err = cs.background.ConnectBlock(block); err != nil {
+		return fmt.Errorf("failed to connect block %x to the background UTXO set: %w", p2p.Reverse(block.GetHash()), err)
+	}
+	if bgHeight+1 < cs.baseHeight {
+		return nil
+	}
+	return cs.validate()
+}
+
+// validate compares the background UTXO set at the snapshot height with the snapshot.
+func (cs *SnapshotChainState) validate() error {
+	bgHash, _, err := cs.background.BestBlock()
+	if err != nil {
+		return err
+	}
+
+	utxoHash, err := cs.background.HashSerialized()
+	if err != nil {
+		return fmt.Errorf("failed to hash the background UTXO set: %w", err)
+	}
+
+	if bgHash != cs.baseHash || utxoHash != cs.baseUTXO {
+		cs.invalid = true
+		log.Printf("the background validation reached block %x with UTXO set hash %x, but the snapshot is at block %x with hash %x\n",
+			p2p.Reverse(bgHash), p2p.Reverse(utxoHash), p2p.Reverse(cs.baseHash), p2p.Reverse(cs.baseUTXO))
+		return ErrSnapshotInvalid
+	}
+
+	cs.validated = true
+	log.Printf("the UTXO snapshot at height %d is validated by the background chain\n", cs.baseHeight)
+	return nil
+}
+
+// BestBlock returns the best block of the snapshot UTXO set.
+func (cs *SnapshotChainState) BestBlock() ([32]byte, int32, error) {
+	return cs.active.BestBlock()
+}
+
+// IsValidated returns true when the background validation confirmed the snapshot.
+func (cs *SnapshotChainState) IsValidated() bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.validated
+}
+
+// SyncFrom implements sync.ChainTip. Until the snapshot is validated, the sync alternates between the
+// best block of the snapshot UTXO set and the best block of the background UTXO set.
+func (cs *SnapshotChainState) SyncFrom() ([32]byte, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.history = !cs.history
+	if cs.history && !cs.validated && !cs.invalid {
+		hash, _, err := cs.background.BestBlock()
+		return hash, err
+	}
+
+	hash, _, err := cs.active.BestBlock()
+	return hash, err
+}
@@ -0,0 +1,86 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotChainState_ValidatesTheHistoryInTheBackground(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	active := node.NewMockChainState(ctrl)
+	background := node.NewMockBackgroundChainState(ctrl)
+
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+	block2 := testutil.NewMsgBlockWithTxs(block1.GetHash(), testutil.NewMsgTx(coinbase, 2))
+	block3 := testutil.NewMsgBlockWithTxs(block2.GetHash(), testutil.NewMsgTx(coinbase, 3))
+	utxoHash := [32]byte{9}
+
+	// the snapshot is at block 2
+	background.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil)
+	cs, err := node.NewSnapshotChainState(active, background, block2.GetHash(), 2, utxoHash)
+	require.NoError(t, err)
+
+	// the sync alternates between the history and the tip
+	active.EXPECT().BestBlock().Return(block2.GetHash(), int32(2), nil).Times(2)
+	background.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil)
+	from, err := cs.SyncFrom()
+	require.NoError(t, err)
+	require.Equal(t, sync.GenesisBlockHash, from)
+	from, err = cs.SyncFrom()
+	require.NoError(t, err)
+	require.Equal(t, block2.GetHash(), from)
+	hash, height, err := cs.BestBlock()
+	require.NoError(t, err)
+	require.Equal(t, block2.GetHash(), hash)
+	require.Equal(t, int32(2), height)
+
+	// the new block at the tip is connected to the snapshot UTXO set
+	background.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil)
+	active.EXPECT().ConnectBlock(block3).Return(nil)
+	require.NoError(t, cs.ConnectBlock(block3))
+
+	// the history blocks are connected to the background UTXO set
+	gomock.InOrder(
+		background.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil),
+		background.EXPECT().ConnectBlock(block1).Return(nil),
+		background.EXPECT().BestBlock().Return(block1.GetHash(), int32(1), nil),
+		background.EXPECT().ConnectBlock(block2).Return(nil),
+		background.EXPECT().BestBlock().Return(block2.GetHash(), int32(2), nil),
+		background.EXPECT().HashSerialized().Return(utxoHash, nil),
+	)
+	require.NoError(t, cs.ConnectBlock(block1))
+	require.False(t, cs.IsValidated())
+	require.NoError(t, cs.ConnectBlock(block2))
+	require.True(t, cs.IsValidated())
+}
+
+func TestSnapshotChainState_InvalidSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	active := node.NewMockChainState(ctrl)
+	background := node.NewMockBackgroundChainState(ctrl)
+	block1 := testutil.NewMsgBlockWithTxs(sync.GenesisBlockHash, testutil.NewMsgTx(coinbase, 1))
+
+	background.EXPECT().BestBlock().Return(sync.GenesisBlockHash, int32(0), nil).Times(2)
+	cs, err := node.NewSnapshotChainState(active, background, block1.GetHash(), 1, [32]byte{9})
+	require.NoError(t, err)
+
+	background.EXPECT().ConnectBlock(block1).Return(nil)
+	background.EXPECT().BestBlock().Return(block1.GetHash(), int32(1), nil)
+	background.EXPECT().HashSerialized().Return([32]byte{8}, nil)
+	require.ErrorIs(t, cs.ConnectBlock(block1), node.ErrSnapshotInvalid)
+
+	// no more blocks are connected to the chain of the invalid snapshot
+	require.ErrorIs(t, cs.ConnectBlock(p2p.MsgBlock{}), node.ErrSnapshotInvalid)
+
+	// the node can't start with the invalid snapshot
+	background.EXPECT().BestBlock().Return(block1.GetHash(), int32(1), nil).Times(2)
+	background.EXPECT().HashSerialized().Return([32]byte{8}, nil)
+	_, err = node.NewSnapshotChainState(active, background, block1.GetHash(), 1, [32]byte{9})
+	require.ErrorIs(t, err, node.ErrSnapshotInvalid)
+}
@@ -0,0 +1,650 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	stdsync "sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+const (
+	// maxKnownInventory is the maximum number of transactions that are remembered per peer as known by it.
+	maxKnownInventory = 50_000
+	// txRequestTimeout is the time after which a requested transaction that is not received can be
+	// requested from another peer that announces it.
+	txRequestTimeout = time.Minute
+	// feeFilterCheckInterval is the interval at which it is checked whether a feefilter is sent to a peer.
+	feeFilterCheckInterval = 5 * time.Second
+	// maxPackageTxs is the maximum number of transactions in a package that is requested from a peer.
+	maxPackageTxs = 25
+	// maxPeerTxRequestInFlight is the maximum number of transactions that are requested from a peer and are
+	// not received yet, the other announced transactions wait until the requests are received or expire.
+	maxPeerTxRequestInFlight = 100
+	// maxPeerTxAnnouncements is the maximum number of the transactions that are announced by a peer and are
+	// requested or wait to be requested, the peer's announcements above it are ignored.
+	maxPeerTxAnnouncements = 5000
+)
+
+// errUnrequestedPackage is returned for a package whose transactions are not requested from the peer.
+var errUnrequestedPackage = errors.New("unrequested package transactions")
+
+// knownInventory is a bounded set of the transaction hashes that a peer knows, the oldest ones are
+// forgotten first.
+type knownInventory struct {
+	hashes map[[32]byte]struct{}
+	order  [][32]byte
+	next   int
+}
+
+func newKnownInventory() *knownInventory {
+	return &knownInventory{hashes: make(map[[32]byte]struct{})}
+}
+
+func (ki *knownInventory) has(hash [32]byte) bool {
+	_, ok := ki.hashes[hash]
+	return ok
+}
+
+func (ki *knownInventory) add(hash [32]byte) {
+	if ki.has(hash) {
+		return
+	}
+	if len(ki.order) < maxKnownInventory {
+		ki.order = append(ki.order, hash)
+	} else {
+		delete(ki.hashes, ki.order[ki.next])
+		ki.order[ki.next] = hash
+		ki.next = (ki.next + 1) % maxKnownInventory
+	}
+	ki.hashes[hash] = struct{}{}
+}
+
+// txRequest is a transaction that is requested from a peer at the given time.
+type txRequest struct {
+	peer string
+	at   time.Time
+}
+
+// relayPeer is a peer to which the transactions are relayed.
+type relayPeer struct {
+	out          chan<- *p2p.Message
+	wtxidRelay   bool
+	packageRelay bool
+	known        *knownInventory
+	// inFlight are the hashes that are requested from the peer and are not received yet, and announced are
+	// the announced transactions that wait to be requested when the peer has too many requests in flight
+	inFlight  map[[32]byte]struct{}
+	announced []p2p.InvVector
+	// feeFilter is the minimum fee rate of the transactions that are announced to the peer (BIP 133)
+	feeFilter int64
+	// sentFeeFilter is the last fee rate that is sent to the peer in a feefilter, and nextFeeFilter is the
+	// time when the next one is sent
+	sentFeeFilter int64
+	nextFeeFilter time.Time
+}
+
+// TxRelay relays the transactions of the mempool between the peers. The transactions that the peers
+// announce with inv messages are requested with getdata when they are not in the mempool, and the
+// transactions that are accepted to the mempool are announced to the other peers. The transactions are
+// announced by their wtxid to the peers that negotiated it (BIP 339) and by their txid to the others.
+// A transaction is announced once to a peer, and it is never announced to the peer from which it is
+// received.
+//
+// The missing parents of an orphan transaction from a peer that negotiated the package relay (BIP 331)
+// are requested as the ancestor package of the orphan: its ancpkginfo is requested first, then the
+// transactions of the package that are not in the mempool with getpkgtxns, and the received package is
+// added to the mempool on its package fee rate, so a parent below the minimum fee rate is added with its
+// child.
+//
+// The transactions below the fee rate of the peer's feefilter are not announced to it, and the minimum fee
+// rate of the mempool is sent to the peers in feefilter messages when it changes. The sent fee rate is
+// rounded and the time when it is sent is random, so the peers can't use it to fingerprint the node.
+//
+// A peer has at most maxPeerTxRequestInFlight requested transactions that are not received, like in Bitcoin
+// Core. The requests that are not received in txRequestTimeout expire, so the transactions can be requested
+// from another peer.
+type TxRelay struct {
+	network string
+	mempool Mempool
+	rounder feeFilterRounder
+
+	mu    stdsync.Mutex
+	peers map[string]*relayPeer
+	// the requested transactions by their announced hash
+	requested map[[32]byte]txRequest
+}
+
+// NewTxRelay creates a new TxRelay for the mempool.
+func NewTxRelay(n string, mp Mempool) *TxRelay {
+	return &TxRelay{
+		network:   n,
+		mempool:   mp,
+		rounder:   newFeeFilterRounder(mempool.DefaultMinRelayFeeRate),
+		peers:     make(map[string]*relayPeer),
+		requested: make(map[[32]byte]txRequest),
+	}
+}
+
+// AddPeer adds the peer to the relay, the messages to it are sent to out.
+func (tr *TxRelay) AddPeer(addr string, out chan<- *p2p.Message, wtxidRelay, packageRelay bool) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.peers[addr] = &relayPeer{out: out, wtxidRelay: wtxidRelay, packageRelay: packageRelay, known: newKnownInventory(),
+		inFlight: make(map[[32]byte]struct{})}
+}
+
+// RemovePeer removes the disconnected peer from the relay with the orphan transactions that are received
+// from it. The transactions that are requested from it can be requested from the other peers.
+func (tr *TxRelay) RemovePeer(addr string) {
+	tr.mu.Lock()
+	if p, ok := tr.peers[addr]; ok {
+		for hash := range p.inFlight {
+			delete(tr.requested, hash)
+		}
+		delete(tr.peers, addr)
+	}
+	tr.mu.Unlock()
+	tr.mempool.RemoveOrphansForPeer(addr)
+}
+
+// ReceiveInv requests from the peer the announced transactions that are not in the mempool and that
+// are not requested from another peer already. When the peer has too many requests in flight the
+// transactions are requested later.
+func (tr *TxRelay) ReceiveInv(addr string, inv *p2p.MsgInv) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	p, ok := tr.peers[addr]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var request []p2p.InvVector
+	for _, v := range inv.Inventory {
+		var inPool bool
+		switch v.Type {
+		case p2p.InvTypeTx:
+			_, inPool = tr.mempool.Get(v.Hash)
+			// the transaction is requested with its witness
+			v.Type = p2p.InvTypeWitnessTx
+		case p2p.InvTypeWtx:
+			_, inPool = tr.mempool.GetByWTxID(v.Hash)
+		default:
+			continue
+		}
+		if !inPool {
+			inPool = tr.mempool.HaveOrphan(v.Hash)
+		}
+
+		p.known.add(v.Hash)
+		if inPool {
+			continue
+		}
+		if tr.request(addr, p, v, now) {
+			request = append(request, v)
+		}
+	}
+	tr.sendGetData(addr, p, request)
+}
+
+// request records the request of the transaction from the peer and reports whether it can be sent now. The
+// transaction is not requested when it is requested already, and it waits in the peer's announcements when
+// the peer has too many requests in flight.
+func (tr *TxRelay) request(addr string, p *relayPeer, v p2p.InvVector, now time.Time) bool {
+	if _, ok := tr.requested[v.Hash]; ok {
+		return false
+	}
+	if len(p.inFlight) >= maxPeerTxRequestInFlight {
+		if len(p.inFlight)+len(p.announced) < maxPeerTxAnnouncements {
+			p.announced = append(p.announced, v)
+		}
+		return false
+	}
+	tr.requested[v.Hash] = txRequest{peer: addr, at: now}
+	p.inFlight[v.Hash] = struct{}{}
+	return true
+}
+
+// received removes the request of the transaction with the given hash, and requests the announced
+// transactions from the peer from which it was requested.
+func (tr *TxRelay) received(hash [32]byte) {
+	req, ok := tr.requested[hash]
+	if !ok {
+		return
+	}
+	delete(tr.requested, hash)
+	if p, ok := tr.peers[req.peer]; ok {
+		delete(p.inFlight, hash)
+		tr.requestAnnounced(req.peer, p, time.Now())
+	}
+}
+
+// requestAnnounced requests the transactions that wait in the peer's announcements while the peer has room
+// for requests in flight.
+func (tr *TxRelay) requestAnnounced(addr string, p *relayPeer, now time.Time) {
+	var request []p2p.InvVector
+	for len(p.announced) > 0 && len(p.inFlight) < maxPeerTxRequestInFlight {
+		v := p.announced[0]
+		p.announced = p.announced[1:]
+		if tr.have(v) {
+			continue
+		}
+		if tr.request(addr, p, v, now) {
+			request = append(request, v)
+		}
+	}
+	tr.sendGetData(addr, p, request)
+}
+
+// have reports whether the announced transaction is in the mempool or in the orphans.
+func (tr *TxRelay) have(v p2p.InvVector) bool {
+	switch v.Type {
+	case p2p.InvTypeWtx:
+		if _, ok := tr.mempool.GetByWTxID(v.Hash); ok {
+			return true
+		}
+	case p2p.InvTypeWitnessTx:
+		if _, ok := tr.mempool.Get(v.Hash); ok {
+			return true
+		}
+	}
+	return tr.mempool.HaveOrphan(v.Hash)
+}
+
+// ExpireRequests removes the requests to the peer that are not received in txRequestTimeout, so the
+// transactions can be requested from another peer, and requests the announced transactions instead.
+func (tr *TxRelay) ExpireRequests(addr string, now time.Time) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	p, ok := tr.peers[addr]
+	if !ok {
+		return
+	}
+	for hash := range p.inFlight {
+		if req, ok := tr.requested[hash]; ok && now.Sub(req.at) < txRequestTimeout {
+			continue
+		}
+		delete(tr.requested, hash)
+		delete(p.inFlight, hash)
+	}
+	tr.requestAnnounced(addr, p, now)
+}
+
+// sendGetData sends the getdata message with the requested transactions to the peer.
+func (tr *TxRelay) sendGetData(addr string, p *relayPeer, request []p2p.InvVector) {
+	if len(request) == 0 {
+		return
+	}
+	msg, err := p2p.NewMessage(p2p.CmdGetdata, tr.network, p2p.MsgGetData{Count: p2p.VarInt(len(request)), Inventory: request})
+	if err != nil {
+		log.Println("failed to create getdata message:", err)
+		return
+	}
+	tr.send(addr, p, msg)
+}
+
+// ReceiveTx adds the transaction that is received from the peer to the mempool and announces it to
+// the other peers when it is accepted, together with the orphan transactions that are accepted after
+// it. The missing parents of an orphan transaction are requested from the peer.
+func (tr *TxRelay) ReceiveTx(addr string, tx *p2p.MsgTx) error {
+	txid, wtxid := tx.TxHash(), tx.WTxHash()
+
+	tr.mu.Lock()
+	tr.received(txid)
+	tr.received(wtxid)
+	if p, ok := tr.peers[addr]; ok {
+		p.known.add(txid)
+		p.known.add(wtxid)
+	}
+	tr.mu.Unlock()
+
+	accepted, missing, err := tr.mempool.ProcessTx(*tx, addr)
+	if err != nil {
+		if !errors.Is(err, mempool.ErrAlreadyHave) {
+			log.Printf("transaction %x from peer %s is rejected: %s\n", p2p.Reverse(txid), addr, err)
+		}
+		return err
+	}
+	if len(missing) > 0 {
+		tr.requestParents(addr, wtxid, missing)
+	}
+	for _, desc := range accepted {
+		tr.Announce(desc)
+	}
+	return nil
+}
+
+// requestParents requests the missing parents of an orphan transaction from the peer that sent it. Only
+// their txids are known, so they are requested by txid with their witness. The ancestor package of the
+// orphan is requested instead from a peer that relays packages.
+func (tr *TxRelay) requestParents(addr string, orphan [32]byte, parents [][32]byte) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	p, ok := tr.peers[addr]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var request []p2p.InvVector
+	if p.packageRelay {
+		parents = [][32]byte{orphan}
+	}
+	for _, hash := range parents {
+		v := p2p.InvVector{Type: p2p.InvTypeWitnessTx, Hash: hash}
+		if p.packageRelay {
+			v = p2p.InvVector{Type: p2p.InvTypeAncPkgInfo, Hash: hash}
+		}
+		if tr.request(addr, p, v, now) {
+			request = append(request, v)
+		}
+	}
+	tr.sendGetData(addr, p, request)
+}
+
+// ReceiveAncPkgInfo requests from the peer the transactions of the ancestor package that are not in the
+// mempool. The package is requested only when its ancpkginfo is requested from the peer.
+func (tr *TxRelay) ReceiveAncPkgInfo(addr string, info *p2p.MsgAncPkgInfo) {
+	if len(info.WTxIDs) == 0 || len(info.WTxIDs) > maxPackageTxs {
+		log.Printf("peer %s sent ancpkginfo with %d transactions\n", addr, len(info.WTxIDs))
+		return
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	p, ok := tr.peers[addr]
+	if !ok {
+		return
+	}
+	child := info.WTxIDs[len(info.WTxIDs)-1]
+	if req, ok := tr.requested[child]; !ok || req.peer != addr {
+		log.Printf("peer %s sent ancpkginfo of %x that is not requested\n", addr, p2p.Reverse(child))
+		return
+	}
+	delete(tr.requested, child)
+	delete(p.inFlight, child)
+
+	// the package is requested in one message, so its transactions are in flight even when the peer has
+	// too many requests in flight
+	now := time.Now()
+	var request [][32]byte
+	for _, wtxid := range info.WTxIDs {
+		if _, inPool := tr.mempool.GetByWTxID(wtxid); inPool {
+			continue
+		}
+		tr.requested[wtxid] = txRequest{peer: addr, at: now}
+		p.inFlight[wtxid] = struct{}{}
+		request = append(request, wtxid)
+	}
+
+	if len(request) == 0 {
+		return
+	}
+	msg, err := p2p.NewMessage(p2p.CmdGetpkgtxns, tr.network, p2p.MsgGetPkgTxns{Count: p2p.VarInt(len(request)), WTxIDs: request})
+	if err != nil {
+		log.Println("failed to create getpkgtxns message:", err)
+		return
+	}
+	tr.send(addr, p, msg)
+}
+
+// ReceivePkgTxns adds the package that is received from the peer to the mempool and announces its accepted
+// transactions to the other peers. Only the transactions that are requested from the peer are accepted.
+func (tr *TxRelay) ReceivePkgTxns(addr string, pkg *p2p.MsgPkgTxns) error {
+	tr.mu.Lock()
+	for _, tx := range pkg.Txs {
+		wtxid := tx.WTxHash()
+		if req, ok := tr.requested[wtxid]; !ok || req.peer != addr {
+			tr.mu.Unlock()
+			log.Printf("peer %s sent the package transaction %x that is not requested\n", addr, p2p.Reverse(wtxid))
+			return errUnrequestedPackage
+		}
+	}
+	p, ok := tr.peers[addr]
+	for _, tx := range pkg.Txs {
+		tr.received(tx.WTxHash())
+		if ok {
+			p.known.add(tx.TxHash())
+			p.known.add(tx.WTxHash())
+		}
+	}
+	tr.mu.Unlock()
+
+	accepted, err := tr.mempool.AcceptPackage(pkg.Txs)
+	for _, desc := range accepted {
+		tr.Announce(desc)
+	}
+	if err != nil {
+		log.Printf("package of %d transactions from peer %s is rejected: %s\n", len(pkg.Txs), addr, err)
+	}
+	return err
+}
+
+// ServeGetPkgTxns replies to the peer with the requested transactions of the mempool, or with a notfound
+// message for the ones that are not in the mempool.
+func (tr *TxRelay) ServeGetPkgTxns(addr string, req *p2p.MsgGetPkgTxns) {
+	var txs []p2p.MsgTx
+	var notFound []p2p.InvVector
+	for _, wtxid := range req.WTxIDs {
+		desc, ok := tr.mempool.GetByWTxID(wtxid)
+		if !ok {
+			notFound = append(notFound, p2p.InvVector{Type: p2p.InvTypeWtx, Hash: wtxid})
+			continue
+		}
+		txs = append(txs, desc.Tx)
+	}
+
+	var msg *p2p.Message
+	var err error
+	if len(notFound) > 0 {
+		msg, err = p2p.NewMessage(p2p.CmdNotfound, tr.network, p2p.MsgNotFound{Count: p2p.VarInt(len(notFound)), Inventory: notFound})
+	} else {
+		msg, err = p2p.NewMessage(p2p.CmdPkgtxns, tr.network, p2p.MsgPkgTxns{Count: p2p.VarInt(len(txs)), Txs: txs})
+	}
+	if err != nil {
+		log.Println("failed to create the reply to getpkgtxns:", err)
+		return
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if p, ok := tr.peers[addr]; ok {
+		tr.send(addr, p, msg)
+	}
+}
+
+// ReceiveFeeFilter stores the minimum fee rate of the transactions that are announced to the peer.
+func (tr *TxRelay) ReceiveFeeFilter(addr string, ff *p2p.MsgFeeFilter) {
+	if ff.FeeRate < 0 || ff.FeeRate > mempool.MaxMoney {
+		log.Printf("peer %s sent an invalid feefilter %d\n", addr, ff.FeeRate)
+		return
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if p, ok := tr.peers[addr]; ok {
+		p.feeFilter = ff.FeeRate
+	}
+}
+
+// FeeFilter returns the fee rate of the peer's feefilter, 0 when the peer didn't send one.
+func (tr *TxRelay) FeeFilter(addr string) int64 {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if p, ok := tr.peers[addr]; ok {
+		return p.feeFilter
+	}
+	return 0
+}
+
+// SendFeeFilter sends the rounded minimum fee rate of the mempool to the peer when it is time for the next
+// feefilter and the fee rate is changed since the last one. The next feefilter is sent earlier when the
+// minimum fee rate changes by more than a third.
+func (tr *TxRelay) SendFeeFilter(addr string, now time.Time) {
+	minFeeRate := tr.mempool.MinFeeRate()
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	p, ok := tr.peers[addr]
+	if !ok {
+		return
+	}
+
+	if now.Before(p.nextFeeFilter) {
+		changed := minFeeRate*4 < p.sentFeeFilter*3 || minFeeRate*3 > p.sentFeeFilter*4
+		if changed && p.nextFeeFilter.Sub(now) > maxFeeFilterChangeDelay {
+			p.nextFeeFilter = now.Add(time.Duration(rand.Int63n(int64(maxFeeFilterChangeDelay))))
+		}
+		return
+	}
+
+	p.nextFeeFilter = nextFeeFilterTime(now)
+	feeRate := tr.rounder.round(minFeeRate)
+	if feeRate == p.sentFeeFilter {
+		return
+	}
+	msg, err := p2p.NewFeeFilterMsg(tr.network, feeRate)
+	if err != nil {
+		log.Println("failed to create feefilter message:", err)
+		return
+	}
+	p.sentFeeFilter = feeRate
+	tr.send(addr, p, msg)
+}
+
+// BroadcastTx adds the transaction that is created by the node to the mempool and announces it to all
+// peers. The transaction is rejected when its fee rate is above maxFeeRate, 0 disables the check.
+func (tr *TxRelay) BroadcastTx(tx p2p.MsgTx, maxFeeRate int64) (mempool.TxDesc, error) {
+	desc, err := tr.mempool.SubmitTx(tx, maxFeeRate)
+	if err != nil {
+		return mempool.TxDesc{}, err
+	}
+	tr.Announce(desc)
+	return desc, nil
+}
+
+// Announce sends an inv message with the transaction to the peers that don't know it.
+func (tr *TxRelay) Announce(desc mempool.TxDesc) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	for addr, p := range tr.peers {
+		v := p2p.InvVector{Type: p2p.InvTypeTx, Hash: desc.TxID}
+		if p.wtxidRelay {
+			v = p2p.InvVector{Type: p2p.InvTypeWtx, Hash: desc.WTxID}
+		}
+		if p.known.has(desc.TxID) || p.known.has(desc.WTxID) {
+			continue
+		}
+		if p.feeFilter > 0 && desc.Fee*1000 < p.feeFilter*desc.VSize {
+			continue
+		}
+		p.known.add(v.Hash)
+
+		msg, err := p2p.NewMessage(p2p.CmdInv, tr.network, p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{v}})
+		if err != nil {
+			log.Println("failed to create inv message:", err)
+			return
+		}
+		tr.send(addr, p, msg)
+	}
+}
+
+// send sends the message to the peer without blocking the relay, the message is dropped when the
+// outgoing messages of the peer are not sent fast enough.
+func (tr *TxRelay) send(addr string, p *relayPeer, msg *p2p.Message) {
+	select {
+	case p.out <- msg:
+	default:
+		log.Printf("drop %s message to peer %s, its outgoing messages are full\n", msg.CommandString(), addr)
+	}
+}
+
+// TxRelayPeer connects the transaction relay with one peer. It adds the peer to the relay when it is
+// started, passes the inv, tx, package relay and feefilter messages from the peer to the relay, sends the
+// feefilter to the peer and removes the peer when it is stopped.
+type TxRelayPeer struct {
+	peer  p2p.Peer
+	relay *TxRelay
+	invs  <-chan *p2p.MsgInv
+	txs   <-chan *p2p.MsgTx
+	// msgs are the ancpkginfo, getpkgtxns, pkgtxns and feefilter messages
+	msgs      <-chan any
+	out       chan<- *p2p.Message
+	stop      chan struct{}
+	done      chan struct{}
+	isStarted atomic.Bool
+}
+
+// NewTxRelayPeer creates a new TxRelayPeer for the peer.
+func NewTxRelayPeer(p p2p.Peer, tr *TxRelay, invs <-chan *p2p.MsgInv, txs <-chan *p2p.MsgTx, msgs <-chan any,
+	out chan<- *p2p.Message) *TxRelayPeer {
+	return &TxRelayPeer{
+		peer:  p,
+		relay: tr,
+		invs:  invs,
+		txs:   txs,
+		msgs:  msgs,
+		out:   out,
+		stop:  make(chan struct{}, 1000),
+		done:  make(chan struct{}, 1000),
+	}
+}
+
+func (rp *TxRelayPeer) Start() {
+	if rp.isStarted.Load() {
+		log.Println("TxRelayPeer is already started.")
+		return
+	}
+	rp.isStarted.Store(true)
+	rp.relay.AddPeer(rp.peer.Address, rp.out, rp.peer.WTxIDRelay, rp.peer.PackageRelay)
+	go rp.handleTxs()
+	log.Println("Start TxRelayPeer.")
+}
+
+func (rp *TxRelayPeer) Stop() {
+	if !rp.isStarted.Load() {
+		log.Println("TxRelayPeer is not started and can't be stopped.")
+		return
+	}
+	rp.isStarted.Store(false)
+	rp.stop <- struct{}{}
+	<-rp.done
+	rp.relay.RemovePeer(rp.peer.Address)
+	log.Println("Stop TxRelayPeer")
+}
+
+func (rp *TxRelayPeer) handleTxs() {
+	ticker := time.NewTicker(feeFilterCheckInterval)
+	defer ticker.Stop()
+	rp.relay.SendFeeFilter(rp.peer.Address, time.Now())
+	for {
+		select {
+		case <-rp.stop:
+			rp.done <- struct{}{}
+			return
+		case now := <-ticker.C:
+			rp.relay.SendFeeFilter(rp.peer.Address, now)
+			rp.relay.ExpireRequests(rp.peer.Address, now)
+		case inv := <-rp.invs:
+			rp.relay.ReceiveInv(rp.peer.Address, inv)
+		case tx := <-rp.txs:
+			_ = rp.relay.ReceiveTx(rp.peer.Address, tx)
+		case msg := <-rp.msgs:
+			switch m := msg.(type) {
+			case *p2p.MsgFeeFilter:
+				rp.relay.ReceiveFeeFilter(rp.peer.Address, m)
+			case *p2p.MsgAncPkgInfo:
+				rp.relay.ReceiveAncPkgInfo(rp.peer.Address, m)
+			case *p2p.MsgGetPkgTxns:
+				rp.relay.ServeGetPkgTxns(rp.peer.Address, m)
+			case *p2p.MsgPkgTxns:
+				_ = rp.relay.ReceivePkgTxns(rp.peer.Address, m)
+			}
+		}
+	}
+}
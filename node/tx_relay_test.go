@@ -0,0 +1,362 @@
+package node_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxRelay_AnnounceTheAcceptedTxToTheOtherPeers(t *testing.T) {
+	tx := newSegwitTx()
+	desc := mempool.TxDesc{Tx: tx, TxID: tx.TxHash(), WTxID: tx.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().ProcessTx(tx, "a").Return([]mempool.TxDesc{desc}, nil, nil).Times(1)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	outC := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", outA, true, false)
+	relay.AddPeer("b", outB, true, false)
+	relay.AddPeer("c", outC, false, false)
+
+	require.NoError(t, relay.ReceiveTx("a", &tx))
+
+	// the peers that negotiated wtxidrelay receive the wtxid, the others the txid
+	expWtx, err := p2p.NewMessage(p2p.CmdInv, "mainnet", p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeWtx, Hash: desc.WTxID}}})
+	require.NoError(t, err)
+	expTx, err := p2p.NewMessage(p2p.CmdInv, "mainnet", p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeTx, Hash: desc.TxID}}})
+	require.NoError(t, err)
+	require.Equal(t, expWtx, <-outB)
+	require.Equal(t, expTx, <-outC)
+	require.Len(t, outA, 0)
+
+	// the transaction is not announced again to the peers that know it
+	relay.Announce(desc)
+	require.Len(t, outA, 0)
+	require.Len(t, outB, 0)
+	require.Len(t, outC, 0)
+}
+
+func TestTxRelay_RequestTheAnnouncedTxsThatAreNotInTheMempoolOnce(t *testing.T) {
+	inPool, missing, legacy := [32]byte{1}, [32]byte{2}, [32]byte{3}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().GetByWTxID(inPool).Return(mempool.TxDesc{}, true).AnyTimes()
+	pool.EXPECT().GetByWTxID(missing).Return(mempool.TxDesc{}, false).AnyTimes()
+	pool.EXPECT().Get(legacy).Return(mempool.TxDesc{}, false).AnyTimes()
+	pool.EXPECT().HaveOrphan(gomock.Any()).Return(false).AnyTimes()
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", outA, true, false)
+	relay.AddPeer("b", outB, true, false)
+
+	relay.ReceiveInv("a", &p2p.MsgInv{Count: 4, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeWtx, Hash: inPool},
+		{Type: p2p.InvTypeWtx, Hash: missing},
+		{Type: p2p.InvTypeTx, Hash: legacy},
+		{Type: p2p.InvTypeBlock, Hash: [32]byte{4}},
+	}})
+
+	// the transactions that are announced by txid are requested with their witness
+	expected, err := p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: 2, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeWtx, Hash: missing},
+		{Type: p2p.InvTypeWitnessTx, Hash: legacy},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outA)
+
+	// the transactions that are already requested are not requested from another peer
+	relay.ReceiveInv("b", &p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeWtx, Hash: missing}}})
+	require.Len(t, outB, 0)
+}
+
+func TestTxRelay_LimitTheRequestsInFlightToAPeer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().GetByWTxID(gomock.Any()).Return(mempool.TxDesc{}, false).AnyTimes()
+	pool.EXPECT().HaveOrphan(gomock.Any()).Return(false).AnyTimes()
+
+	outA := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", outA, true, false)
+
+	var inv []p2p.InvVector
+	for i := 0; i < 150; i++ {
+		inv = append(inv, p2p.InvVector{Type: p2p.InvTypeWtx, Hash: [32]byte{byte(i), byte(i >> 8), 1}})
+	}
+	now := time.Now()
+	relay.ReceiveInv("a", &p2p.MsgInv{Count: p2p.VarInt(len(inv)), Inventory: inv})
+
+	// only 100 transactions are in flight, the others are requested when the requests expire
+	expected, err := p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: 100, Inventory: inv[:100]})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outA)
+	require.Len(t, outA, 0)
+
+	relay.ExpireRequests("a", now.Add(30*time.Second))
+	require.Len(t, outA, 0)
+
+	relay.ExpireRequests("a", now.Add(time.Minute+time.Second))
+	expected, err = p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: 50, Inventory: inv[100:]})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outA)
+}
+
+func TestTxRelay_RequestTheTxFromAnotherPeerWhenTheRequestExpires(t *testing.T) {
+	missing := [32]byte{2}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().GetByWTxID(missing).Return(mempool.TxDesc{}, false).AnyTimes()
+	pool.EXPECT().HaveOrphan(gomock.Any()).Return(false).AnyTimes()
+	pool.EXPECT().RemoveOrphansForPeer("b").Times(1)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", outA, true, false)
+	relay.AddPeer("b", outB, true, false)
+
+	inv := &p2p.MsgInv{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeWtx, Hash: missing}}}
+	expected, err := p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: 1, Inventory: inv.Inventory})
+	require.NoError(t, err)
+
+	now := time.Now()
+	relay.ReceiveInv("a", inv)
+	require.Equal(t, expected, <-outA)
+
+	// the expired request is removed, so the transaction is requested from the next peer that announces it
+	relay.ExpireRequests("a", now.Add(time.Minute+time.Second))
+	relay.ReceiveInv("b", inv)
+	require.Equal(t, expected, <-outB)
+
+	// the requests to a removed peer are removed too
+	relay.RemovePeer("b")
+	relay.ReceiveInv("a", inv)
+	require.Equal(t, expected, <-outA)
+}
+
+func TestTxRelay_RequestTheParentsOfAnOrphanAndAnnounceItWhenTheyArrive(t *testing.T) {
+	parent := newSegwitTx()
+	orphan := testutil.NewMsgTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 500)
+	parentDesc := mempool.TxDesc{Tx: parent, TxID: parent.TxHash(), WTxID: parent.WTxHash()}
+	orphanDesc := mempool.TxDesc{Tx: orphan, TxID: orphan.TxHash(), WTxID: orphan.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().ProcessTx(orphan, "a").Return(nil, [][32]byte{parent.TxHash()}, nil).Times(1)
+	pool.EXPECT().ProcessTx(parent, "a").Return([]mempool.TxDesc{parentDesc, orphanDesc}, nil, nil).Times(1)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", outA, true, false)
+	relay.AddPeer("b", outB, true, false)
+
+	// the missing parent is requested from the peer that sent the orphan
+	require.NoError(t, relay.ReceiveTx("a", &orphan))
+	expected, err := p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: 1, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeWitnessTx, Hash: parent.TxHash()},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outA)
+	require.Len(t, outB, 0)
+
+	// the parent and the orphan that is accepted after it are announced to the other peers
+	require.NoError(t, relay.ReceiveTx("a", &parent))
+	require.Equal(t, p2p.CmdInv, (<-outB).CommandString())
+	require.Equal(t, p2p.CmdInv, (<-outB).CommandString())
+	require.Len(t, outA, 0)
+}
+
+func TestTxRelay_RequestTheAncestorPackageOfAnOrphanFromAPackageRelayPeer(t *testing.T) {
+	parent := newSegwitTx()
+	child := testutil.NewMsgTx([]p2p.OutPoint{{Hash: parent.TxHash()}}, 500)
+	parentDesc := mempool.TxDesc{Tx: parent, TxID: parent.TxHash(), WTxID: parent.WTxHash()}
+	childDesc := mempool.TxDesc{Tx: child, TxID: child.TxHash(), WTxID: child.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().ProcessTx(child, "a").Return(nil, [][32]byte{parent.TxHash()}, nil).Times(1)
+	pool.EXPECT().GetByWTxID(gomock.Any()).Return(mempool.TxDesc{}, false).Times(2)
+	pool.EXPECT().AcceptPackage([]p2p.MsgTx{parent, child}).Return([]mempool.TxDesc{parentDesc, childDesc}, nil).Times(1)
+
+	outA := make(chan *p2p.Message, 10)
+	outB := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", outA, true, true)
+	relay.AddPeer("b", outB, true, false)
+
+	// the ancestor package info of the orphan is requested instead of its parents
+	require.NoError(t, relay.ReceiveTx("a", &child))
+	expected, err := p2p.NewMessage(p2p.CmdGetdata, "mainnet", p2p.MsgGetData{Count: 1, Inventory: []p2p.InvVector{
+		{Type: p2p.InvTypeAncPkgInfo, Hash: child.WTxHash()},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outA)
+
+	// the transactions of the package that are not in the mempool are requested
+	relay.ReceiveAncPkgInfo("a", &p2p.MsgAncPkgInfo{Count: 2, WTxIDs: [][32]byte{parent.WTxHash(), child.WTxHash()}})
+	expected, err = p2p.NewMessage(p2p.CmdGetpkgtxns, "mainnet", p2p.MsgGetPkgTxns{Count: 2, WTxIDs: [][32]byte{parent.WTxHash(), child.WTxHash()}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-outA)
+
+	// the package is accepted only from the peer from which it is requested
+	require.Error(t, relay.ReceivePkgTxns("b", &p2p.MsgPkgTxns{Count: 2, Txs: []p2p.MsgTx{parent, child}}))
+
+	// the package is accepted and its transactions are announced to the other peers
+	require.NoError(t, relay.ReceivePkgTxns("a", &p2p.MsgPkgTxns{Count: 2, Txs: []p2p.MsgTx{parent, child}}))
+	require.Len(t, outB, 2)
+	require.Len(t, outA, 0)
+
+	// the transactions that are not requested are not accepted
+	require.Error(t, relay.ReceivePkgTxns("a", &p2p.MsgPkgTxns{Count: 2, Txs: []p2p.MsgTx{parent, child}}))
+	relay.ReceiveAncPkgInfo("a", &p2p.MsgAncPkgInfo{Count: 1, WTxIDs: [][32]byte{{9}}})
+	require.Len(t, outA, 0)
+}
+
+func TestTxRelay_ServeGetPkgTxns(t *testing.T) {
+	tx := newSegwitTx()
+	desc := mempool.TxDesc{Tx: tx, TxID: tx.TxHash(), WTxID: tx.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().GetByWTxID(desc.WTxID).Return(desc, true).Times(2)
+	pool.EXPECT().GetByWTxID([32]byte{1}).Return(mempool.TxDesc{}, false).Times(1)
+
+	out := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", out, true, true)
+
+	relay.ServeGetPkgTxns("a", &p2p.MsgGetPkgTxns{Count: 1, WTxIDs: [][32]byte{desc.WTxID}})
+	expected, err := p2p.NewMessage(p2p.CmdPkgtxns, "mainnet", p2p.MsgPkgTxns{Count: 1, Txs: []p2p.MsgTx{tx}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-out)
+
+	// the package is not sent when a transaction is missing
+	relay.ServeGetPkgTxns("a", &p2p.MsgGetPkgTxns{Count: 2, WTxIDs: [][32]byte{desc.WTxID, {1}}})
+	expected, err = p2p.NewMessage(p2p.CmdNotfound, "mainnet", p2p.MsgNotFound{Count: 1, Inventory: []p2p.InvVector{{Type: p2p.InvTypeWtx, Hash: [32]byte{1}}}})
+	require.NoError(t, err)
+	require.Equal(t, expected, <-out)
+}
+
+func TestTxRelay_DontAnnounceTheTxsBelowTheFeeFilterOfThePeer(t *testing.T) {
+	tx := newSegwitTx()
+	cheap := mempool.TxDesc{Tx: tx, TxID: [32]byte{1}, WTxID: [32]byte{1}, Fee: 100, VSize: 100}
+	expensive := mempool.TxDesc{Tx: tx, TxID: [32]byte{2}, WTxID: [32]byte{2}, Fee: 1000, VSize: 100}
+
+	out := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", node.NewMockMempool(gomock.NewController(t)))
+	relay.AddPeer("a", out, true, false)
+
+	// the invalid fee filters are ignored
+	relay.ReceiveFeeFilter("a", &p2p.MsgFeeFilter{FeeRate: 5000})
+	relay.ReceiveFeeFilter("a", &p2p.MsgFeeFilter{FeeRate: -1})
+	require.Equal(t, int64(5000), relay.FeeFilter("a"))
+
+	relay.Announce(cheap)
+	require.Len(t, out, 0)
+	relay.Announce(expensive)
+	require.Len(t, out, 1)
+}
+
+func TestTxRelay_SendTheRoundedMinFeeRateOfTheMempoolWhenItChanges(t *testing.T) {
+	minFeeRate := int64(1000)
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().MinFeeRate().DoAndReturn(func() int64 { return minFeeRate }).AnyTimes()
+
+	out := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", out, true, false)
+	feeFilter := func() int64 {
+		msg := <-out
+		require.Equal(t, p2p.CmdFeefilter, msg.CommandString())
+		var ff p2p.MsgFeeFilter
+		require.NoError(t, binary.NewDecoder(bytes.NewReader(msg.Payload)).Decode(&ff))
+		return ff.FeeRate
+	}
+
+	// the fee rate is rounded to one of the fee rates that are 10% apart
+	now := time.Now()
+	relay.SendFeeFilter("a", now)
+	require.InDelta(t, 1000, feeFilter(), 100)
+	relay.SendFeeFilter("a", now)
+	require.Len(t, out, 0)
+
+	// a significant change of the min fee rate is sent in at most 5 minutes
+	minFeeRate = 5000
+	relay.SendFeeFilter("a", now)
+	relay.SendFeeFilter("a", now.Add(5*time.Minute+time.Second))
+	require.InDelta(t, 5000, feeFilter(), 500)
+}
+
+func TestTxRelay_BroadcastTx(t *testing.T) {
+	tx := newSegwitTx()
+	desc := mempool.TxDesc{Tx: tx, TxID: tx.TxHash(), WTxID: tx.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().SubmitTx(tx, int64(100_000)).Return(desc, nil).Times(1)
+	pool.EXPECT().SubmitTx(tx, int64(100_000)).Return(mempool.TxDesc{}, mempool.ErrAlreadyHave).Times(1)
+
+	out := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("a", out, true, false)
+
+	actual, err := relay.BroadcastTx(tx, 100_000)
+	require.NoError(t, err)
+	require.Equal(t, desc, actual)
+	require.Len(t, out, 1)
+
+	_, err = relay.BroadcastTx(tx, 100_000)
+	require.ErrorIs(t, err, mempool.ErrAlreadyHave)
+	require.Len(t, out, 1)
+}
+
+func TestTxRelayPeer_PassTheMessagesOfThePeerToTheRelay(t *testing.T) {
+	tx := newSegwitTx()
+	desc := mempool.TxDesc{Tx: tx, TxID: tx.TxHash(), WTxID: tx.WTxHash()}
+
+	ctrl := gomock.NewController(t)
+	pool := node.NewMockMempool(ctrl)
+	pool.EXPECT().ProcessTx(tx, "a").Return([]mempool.TxDesc{desc}, nil, nil).Times(1)
+	pool.EXPECT().RemoveOrphansForPeer("a").Times(1)
+	pool.EXPECT().MinFeeRate().Return(int64(1000)).AnyTimes()
+
+	other := make(chan *p2p.Message, 10)
+	relay := node.NewTxRelay("mainnet", pool)
+	relay.AddPeer("b", other, true, false)
+
+	txs := make(chan *p2p.MsgTx)
+	peer := p2p.Peer{Address: "a", WTxIDRelay: true}
+	rp := node.NewTxRelayPeer(peer, relay, make(chan *p2p.MsgInv), txs, make(chan any), make(chan *p2p.Message, 10))
+	rp.Start()
+	txs <- &tx
+
+	msg := <-other
+	require.Equal(t, p2p.CmdInv, msg.CommandString())
+	rp.Stop()
+}
+
+// newSegwitTx returns a transaction with a witness, so its txid and wtxid are different.
+func newSegwitTx() p2p.MsgTx {
+	tx := testutil.NewMsgTx([]p2p.OutPoint{{Hash: [32]byte{7}}}, 1000)
+	tx.Flag = 1
+	tx.TxWitness = []p2p.TxWitnessData{{Count: 1, Witness: []p2p.TxWitness{{Length: 2, Data: []byte{1, 2}}}}}
+	return tx
+}
@@ -0,0 +1,258 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strings"
+)
+
+const (
+	op0             = 0x00
+	opPushData1     = 0x4c
+	opPushData2     = 0x4d
+	opPushData4     = 0x4e
+	op1             = 0x51
+	op16            = 0x60
+	opReturn        = 0x6a
+	opDup           = 0x76
+	opEqual         = 0x87
+	opEqualVerify   = 0x88
+	opHash160       = 0xa9
+	opCheckSig      = 0xac
+	opCheckMultiSig = 0xae
+
+	// bech32mConst is the checksum constant of the witness programs of version 1 and above (BIP 350).
+	bech32mConst = 0x2bc830a3
+)
+
+// addressParams are the prefixes of the addresses of a network.
+type addressParams struct {
+	pubKeyHash byte
+	scriptHash byte
+	hrp        string
+}
+
+var networkAddressParams = map[string]addressParams{
+	"mainnet": {pubKeyHash: 0x00, scriptHash: 0x05, hrp: "bc"},
+	"simnet":  {pubKeyHash: 0x3f, scriptHash: 0x7b, hrp: "sb"},
+}
+
+// scriptType returns the type of the output script with the names that Bitcoin Core uses.
+func scriptType(s []byte) string {
+	switch {
+	case len(s) == 25 && s[0] == opDup && s[1] == opHash160 && s[2] == 20 && s[23] == opEqualVerify && s[24] == opCheckSig:
+		return "pubkeyhash"
+	case len(s) == 23 && s[0] == opHash160 && s[1] == 20 && s[22] == opEqual:
+		return "scripthash"
+	case len(s) == 35 && s[0] == 33 && s[34] == opCheckSig, len(s) == 67 && s[0] == 65 && s[66] == opCheckSig:
+		return "pubkey"
+	case len(s) > 0 && s[0] == opReturn && isPushOnly(s[1:]):
+		return "nulldata"
+	}
+
+	version, program, ok := witnessProgram(s)
+	switch {
+	case !ok:
+		if isMultiSig(s) {
+			return "multisig"
+		}
+		return "nonstandard"
+	case version == 0 && len(program) == 20:
+		return "witness_v0_keyhash"
+	case version == 0 && len(program) == 32:
+		return "witness_v0_scripthash"
+	case version == 0:
+		return "nonstandard"
+	case version == 1 && len(program) == 32:
+		return "witness_v1_taproot"
+	case version == 1 && len(program) == 2 && program[0] == 0x4e && program[1] == 0x73:
+		return "anchor"
+	}
+	return "witness_unknown"
+}
+
+// scriptAddress returns the address of the output script, it is empty for the scripts without an address.
+func scriptAddress(s []byte, network string) string {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return ""
+	}
+
+	switch scriptType(s) {
+	case "pubkeyhash":
+		return base58Check(params.pubKeyHash, s[3:23])
+	case "scripthash":
+		return base58Check(params.scriptHash, s[2:22])
+	case "witness_v0_keyhash", "witness_v0_scripthash", "witness_v1_taproot", "anchor", "witness_unknown":
+		version, program, _ := witnessProgram(s)
+		return segwitAddress(params.hrp, version, program)
+	}
+	return ""
+}
+
+// witnessProgram returns the version and the program of a witness program output script (BIP 141).
+func witnessProgram(s []byte) (int, []byte, bool) {
+	if len(s) < 4 || len(s) > 42 || int(s[1]) != len(s)-2 {
+		return 0, nil, false
+	}
+	switch {
+	case s[0] == op0:
+		return 0, s[2:], true
+	case s[0] >= op1 && s[0] <= op16:
+		return int(s[0]-op1) + 1, s[2:], true
+	}
+	return 0, nil, false
+}
+
+// isMultiSig returns true for a bare m-of-n multisig script with compressed or uncompressed keys.
+func isMultiSig(s []byte) bool {
+	if len(s) < 3 || s[len(s)-1] != opCheckMultiSig {
+		return false
+	}
+	m, n := s[0], s[len(s)-2]
+	if m < op1 || n > op16 || m > n {
+		return false
+	}
+
+	keys := s[1 : len(s)-2]
+	for i := op1; i <= int(n); i++ {
+		if len(keys) == 0 || (keys[0] != 33 && keys[0] != 65) || len(keys) < int(keys[0])+1 {
+			return false
+		}
+		keys = keys[keys[0]+1:]
+	}
+	return len(keys) == 0
+}
+
+// isPushOnly returns true when the script only pushes data on the stack.
+func isPushOnly(s []byte) bool {
+	for len(s) > 0 {
+		op := s[0]
+		s = s[1:]
+
+		var n int
+		switch {
+		case op < opPushData1:
+			n = int(op)
+		case op == opPushData1 && len(s) >= 1:
+			n, s = int(s[0]), s[1:]
+		case op == opPushData2 && len(s) >= 2:
+			n, s = int(s[0])|int(s[1])<<8, s[2:]
+		case op == opPushData4 && len(s) >= 4:
+			n, s = int(s[0])|int(s[1])<<8|int(s[2])<<16|int(s[3])<<24, s[4:]
+		case op <= op16 && op > opPushData4:
+			// OP_1NEGATE, OP_RESERVED and OP_1 - OP_16
+			continue
+		default:
+			return false
+		}
+		if n > len(s) {
+			return false
+		}
+		s = s[n:]
+	}
+	return true
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Check encodes the payload with the version byte and a checksum of 4 bytes in base58.
+func base58Check(version byte, payload []byte) string {
+	b := append([]byte{version}, payload...)
+	first := sha256.Sum256(b)
+	checksum := sha256.Sum256(first[:])
+	b = append(b, checksum[:4]...)
+
+	var sb strings.Builder
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		sb.WriteByte(base58Alphabet[0])
+	}
+
+	var digits []byte
+	n := new(big.Int).SetBytes(b)
+	radix, mod := big.NewInt(58), new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		digits = append(digits, base58Alphabet[mod.Int64()])
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		sb.WriteByte(digits[i])
+	}
+	return sb.String()
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// segwitAddress encodes the witness program in bech32 for version 0 (BIP 173) and in bech32m for the
+// later versions (BIP 350).
+func segwitAddress(hrp string, version int, program []byte) string {
+	data := append([]byte{byte(version)}, convertBits(program, 8, 5)...)
+
+	constant := uint32(1)
+	if version > 0 {
+		constant = bech32mConst
+	}
+	values := append(hrpExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ constant
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+func hrpExpand(hrp string) []byte {
+	b := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		b = append(b, hrp[i]>>5)
+	}
+	b = append(b, 0)
+	for i := 0; i < len(hrp); i++ {
+		b = append(b, hrp[i]&31)
+	}
+	return b
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// convertBits regroups the bits of the data from groups of "from" bits to groups of "to" bits, the last
+// group is padded with zeros.
+func convertBits(data []byte, from, to uint) []byte {
+	var acc, bits uint
+	var out []byte
+	maxv := uint(1)<<to - 1
+	for _, b := range data {
+		acc = acc<<from | uint(b)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte((acc>>bits)&maxv))
+		}
+	}
+	if bits > 0 {
+		out = append(out, byte((acc<<(to-bits))&maxv))
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptTypeAndAddress(t *testing.T) {
+	tests := []struct {
+		script  string
+		typ     string
+		address string
+	}{
+		// BIP 84 and BIP 86 test vectors
+		{"0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2", "witness_v0_keyhash", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", "witness_v1_taproot", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{"76a914000000000000000000000000000000000000000088ac", "pubkeyhash", "1111111111111111111114oLvT2"},
+		{"a914748284390f9e263a4b766a75d0633c50426eb87587", "scripthash", "3CK4fEwbMP7heJarmU4eqA3sMbVJyEnU3V"},
+		{"6a0568656c6c6f", "nulldata", ""},
+		{"51024e73", "anchor", "bc1pfeessrawgf"},
+		{"512102020202020202020202020202020202020202020202020202020202020202020251ae", "multisig", ""},
+		{"0015000000000000000000000000000000000000000000", "nonstandard", ""},
+	}
+
+	for _, tt := range tests {
+		script, err := hex.DecodeString(tt.script)
+		require.NoError(t, err)
+		require.Equal(t, tt.typ, scriptType(script), tt.script)
+		require.Equal(t, tt.address, scriptAddress(script, "mainnet"), tt.script)
+	}
+}
+
+func TestScriptDescriptor(t *testing.T) {
+	// the checksum of the BIP 380 test vector
+	require.Equal(t, "89f8spxm", descriptorChecksum("raw(deadbeef)"))
+
+	tests := []struct {
+		script string
+		desc   string
+	}{
+		{"0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2", "addr(bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu)#lpewvaaa"},
+		{"76a914000000000000000000000000000000000000000088ac", "addr(1111111111111111111114oLvT2)#ula7cdg0"},
+		{"5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c",
+			"rawtr(a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c)#h9nmpf4q"},
+		{"6a0568656c6c6f", "raw(6a0568656c6c6f)#yktqjuxu"},
+		{"21020202020202020202020202020202020202020202020202020202020202020202ac",
+			"pk(020202020202020202020202020202020202020202020202020202020202020202)#ldku6hfz"},
+		{"512102020202020202020202020202020202020202020202020202020202020202020251ae",
+			"multi(1,020202020202020202020202020202020202020202020202020202020202020202)#dk3zpmcy"},
+		// the key is not a point of the curve
+		{"2102000000000000000000000000000000000000000000000000000000000000000005ac",
+			"raw(2102000000000000000000000000000000000000000000000000000000000000000005ac)#qn9nu6qp"},
+	}
+	for _, tt := range tests {
+		script, err := hex.DecodeString(tt.script)
+		require.NoError(t, err)
+		require.Equal(t, tt.desc, scriptDescriptor(script, "mainnet"), tt.script)
+	}
+}
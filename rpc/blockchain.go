@@ -0,0 +1,503 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/script"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// maxTipAge is the age of the tip after which the node is in the initial block download.
+const maxTipAge = 24 * time.Hour
+
+// chainNames are the names of the networks that are returned by getblockchaininfo.
+var chainNames = map[string]string{"mainnet": "main", "simnet": "simnet"}
+
+// amount is a value in satoshis that is encoded in JSON in bitcoins with 8 decimals, as in Bitcoin Core.
+type amount int64
+
+func (a amount) MarshalJSON() ([]byte, error) {
+	sign, v := "", int64(a)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return []byte(fmt.Sprintf("%s%d.%08d", sign, v/100_000_000, v%100_000_000)), nil
+}
+
+type blockchainInfoResult struct {
+	Chain                string  `json:"chain"`
+	Blocks               int32   `json:"blocks"`
+	Headers              int32   `json:"headers"`
+	BestBlockHash        string  `json:"bestblockhash"`
+	Difficulty           float64 `json:"difficulty"`
+	Time                 uint32  `json:"time"`
+	MedianTime           uint32  `json:"mediantime"`
+	VerificationProgress float64 `json:"verificationprogress"`
+	InitialBlockDownload bool    `json:"initialblockdownload"`
+	ChainWork            string  `json:"chainwork"`
+	Pruned               bool    `json:"pruned"`
+	AutomaticPruning     *bool   `json:"automatic_pruning,omitempty"`
+	PruneTargetSize      uint64  `json:"prune_target_size,omitempty"`
+	Warnings             string  `json:"warnings"`
+}
+
+// blockHeaderResult is the verbose result of getblockheader. The number of transactions is set only by
+// getblock, because the header index doesn't keep it.
+type blockHeaderResult struct {
+	Hash              string  `json:"hash"`
+	Confirmations     int32   `json:"confirmations"`
+	Height            int32   `json:"height"`
+	Version           int32   `json:"version"`
+	VersionHex        string  `json:"versionHex"`
+	MerkleRoot        string  `json:"merkleroot"`
+	Time              uint32  `json:"time"`
+	MedianTime        uint32  `json:"mediantime"`
+	Nonce             uint32  `json:"nonce"`
+	Bits              string  `json:"bits"`
+	Difficulty        float64 `json:"difficulty"`
+	ChainWork         string  `json:"chainwork"`
+	NTx               int     `json:"nTx,omitempty"`
+	PreviousBlockHash string  `json:"previousblockhash,omitempty"`
+	NextBlockHash     string  `json:"nextblockhash,omitempty"`
+}
+
+// blockResult is the result of getblock with verbosity 1, the transactions are their txids, and with
+// verbosity 2, the transactions are decoded.
+type blockResult struct {
+	blockHeaderResult
+	StrippedSize int `json:"strippedsize"`
+	Size         int `json:"size"`
+	Weight       int `json:"weight"`
+	Tx           any `json:"tx"`
+}
+
+type txResult struct {
+	TxID     string       `json:"txid"`
+	Hash     string       `json:"hash"`
+	Version  int32        `json:"version"`
+	Size     int          `json:"size"`
+	VSize    int64        `json:"vsize"`
+	Weight   int64        `json:"weight"`
+	LockTime uint32       `json:"locktime"`
+	Vin      []vinResult  `json:"vin"`
+	Vout     []voutResult `json:"vout"`
+	Fee      *amount      `json:"fee,omitempty"`
+	Hex      string       `json:"hex"`
+}
+
+type vinResult struct {
+	Coinbase    string           `json:"coinbase,omitempty"`
+	TxID        string           `json:"txid,omitempty"`
+	Vout        *uint32          `json:"vout,omitempty"`
+	ScriptSig   *scriptSigResult `json:"scriptSig,omitempty"`
+	TxInWitness []string         `json:"txinwitness,omitempty"`
+	Sequence    uint32           `json:"sequence"`
+}
+
+type scriptSigResult struct {
+	Asm string `json:"asm"`
+	Hex string `json:"hex"`
+}
+
+type voutResult struct {
+	Value        amount             `json:"value"`
+	N            int                `json:"n"`
+	ScriptPubKey scriptPubKeyResult `json:"scriptPubKey"`
+}
+
+type scriptPubKeyResult struct {
+	Asm     string `json:"asm"`
+	Desc    string `json:"desc"`
+	Hex     string `json:"hex"`
+	Address string `json:"address,omitempty"`
+	Type    string `json:"type"`
+}
+
+type chainTipResult struct {
+	Height    int32  `json:"height"`
+	Hash      string `json:"hash"`
+	BranchLen int32  `json:"branchlen"`
+	Status    string `json:"status"`
+}
+
+func (s *Server) getBlockchainInfo(_ []json.RawMessage) (any, error) {
+	tipHash, tipHeight, err := s.chain.tip()
+	if err != nil {
+		return nil, err
+	}
+	tip, _, err := s.chain.header(tipHash)
+	if err != nil {
+		return nil, err
+	}
+	medianTime, err := s.chain.medianTime(tip, tipHeight)
+	if err != nil {
+		return nil, err
+	}
+	work, err := s.chain.headers.ChainWork(tipHash)
+	if err != nil {
+		return nil, err
+	}
+	_, headers, err := s.chain.headers.BestHeader()
+	if err != nil && !errors.Is(err, sync.ErrNotFound) {
+		return nil, err
+	}
+
+	// the progress is estimated by the number of the blocks, not by the number of the transactions
+	progress := 1.0
+	if headers > tipHeight {
+		progress = float64(tipHeight) / float64(headers)
+	}
+	result := blockchainInfoResult{
+		Chain:                chainNames[s.network],
+		Blocks:               tipHeight,
+		Headers:              max(headers, tipHeight),
+		BestBlockHash:        hashString(tipHash),
+		Difficulty:           difficulty(tip.Bits),
+		Time:                 tip.Timestamp,
+		MedianTime:           medianTime,
+		VerificationProgress: progress,
+		InitialBlockDownload: s.initialBlockDownload(tip, tipHeight, headers),
+		ChainWork:            chainWorkString(work),
+		Pruned:               s.pruneTarget > 0,
+	}
+	if result.Pruned {
+		automatic := true
+		result.AutomaticPruning = &automatic
+		result.PruneTargetSize = s.pruneTarget * 1024 * 1024
+	}
+	return result, nil
+}
+
+// initialBlockDownload returns true while the tip of the active chain is older than maxTipAge or the best header
+// chain is ahead of it. Like in Bitcoin Core, the node doesn't return to the initial block download after it
+// leaves it.
+func (s *Server) initialBlockDownload(tip p2p.BlockHeader, tipHeight, headers int32) bool {
+	if s.ibdDone.Load() {
+		return false
+	}
+	if headers > tipHeight || time.Since(time.Unix(int64(tip.Timestamp), 0)) > maxTipAge {
+		return true
+	}
+	s.ibdDone.Store(true)
+	return false
+}
+
+func (s *Server) getBlockCount(_ []json.RawMessage) (any, error) {
+	_, height, err := s.chain.tip()
+	return height, err
+}
+
+func (s *Server) getBestBlockHash(_ []json.RawMessage) (any, error) {
+	hash, _, err := s.chain.tip()
+	return hashString(hash), err
+}
+
+func (s *Server) getBlockHash(params []json.RawMessage) (any, error) {
+	var height int32
+	if err := requiredParam(params, 0, "height", "number", &height); err != nil {
+		return nil, err
+	}
+	hash, err := s.chain.hashAt(height)
+	if errors.Is(err, errHeightOutOfRange) {
+		return nil, newError(ErrCodeInvalidParameter, "Block height out of range")
+	}
+	return hashString(hash), err
+}
+
+func (s *Server) getBlockHeader(params []json.RawMessage) (any, error) {
+	hash, err := hashParam(params, 0, "blockhash")
+	if err != nil {
+		return nil, err
+	}
+	verbose := true
+	if err = optionalParam(params, 1, "bool", &verbose); err != nil {
+		return nil, err
+	}
+
+	header, height, err := s.chain.header(hash)
+	if errors.Is(err, sync.ErrNotFound) {
+		return nil, newError(ErrCodeInvalidAddressKey, "Block not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !verbose {
+		raw, err := wire.Marshal(header)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(raw[:80]), nil
+	}
+	return s.headerResult(hash, header, height)
+}
+
+func (s *Server) getBlock(params []json.RawMessage) (any, error) {
+	hash, err := hashParam(params, 0, "blockhash")
+	if err != nil {
+		return nil, err
+	}
+	verbosity, err := verbosityParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	header, height, err := s.chain.header(hash)
+	if errors.Is(err, sync.ErrNotFound) {
+		return nil, newError(ErrCodeInvalidAddressKey, "Block not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.chain.block(hash)
+	switch {
+	case errors.Is(err, sync.ErrPruned):
+		return nil, newError(ErrCodeMisc, "Block not available (pruned data)")
+	case errors.Is(err, sync.ErrNotFound):
+		return nil, newError(ErrCodeMisc, "Block not found on disk")
+	case err != nil:
+		return nil, err
+	}
+
+	raw, err := wire.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	if verbosity <= 0 {
+		return hex.EncodeToString(raw), nil
+	}
+	return s.blockResult(hash, header, height, block, len(raw), verbosity)
+}
+
+// blockResult returns the result of getblock with verbosity 1 or 2 for the block with the given hash,
+// height and serialized size.
+func (s *Server) blockResult(hash [32]byte, header p2p.BlockHeader, height int32, block p2p.MsgBlock, size int,
+	verbosity int) (blockResult, error) {
+	hr, err := s.headerResult(hash, header, height)
+	if err != nil {
+		return blockResult{}, err
+	}
+	hr.NTx = len(block.Transactions)
+	stripped, err := wire.Marshal(block.StripWitness())
+	if err != nil {
+		return blockResult{}, err
+	}
+	result := blockResult{
+		blockHeaderResult: hr,
+		StrippedSize:      len(stripped),
+		Size:              size,
+		Weight:            len(stripped)*3 + size,
+	}
+
+	if verbosity == 1 {
+		txids := make([]string, len(block.Transactions))
+		for i, tx := range block.Transactions {
+			txids[i] = hashString(tx.TxHash())
+		}
+		result.Tx = txids
+		return result, nil
+	}
+
+	// the fees are calculated from the undo data, which is kept only for the connected blocks
+	spent, err := s.undo.SpentOutputs(hash)
+	if err != nil && !errors.Is(err, sync.ErrNotFound) {
+		return blockResult{}, err
+	}
+	txs := make([]txResult, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		if txs[i], err = s.txResult(tx); err != nil {
+			return blockResult{}, err
+		}
+		if tx.IsCoinBase() || len(spent) < len(tx.TxIn) {
+			continue
+		}
+		fee := amount(0)
+		for _, so := range spent[:len(tx.TxIn)] {
+			fee += amount(so.UTXO.Value)
+		}
+		for _, out := range tx.TxOut {
+			fee -= amount(out.Value)
+		}
+		spent = spent[len(tx.TxIn):]
+		txs[i].Fee = &fee
+	}
+	result.Tx = txs
+	return result, nil
+}
+
+func (s *Server) getChainTips(_ []json.RawMessage) (any, error) {
+	tipHash, tipHeight, err := s.chain.tip()
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := s.chain.headers.Tips()
+	if err != nil {
+		return nil, err
+	}
+
+	// the tip of the active chain is extended by the headers of the blocks that are not connected yet
+	tips := []chainTipResult{{Height: tipHeight, Hash: hashString(tipHash), Status: "active"}}
+	for _, hash := range hashes {
+		if hash == tipHash {
+			continue
+		}
+		_, height, err := s.chain.header(hash)
+		if err != nil {
+			return nil, err
+		}
+		fork, err := s.chain.forkHeight(hash, height)
+		if err != nil {
+			return nil, err
+		}
+
+		// a block is stored only when it is validated and connected to the UTXO set, so a branch whose blocks
+		// are all stored is fully validated
+		status := "valid-fork"
+		stored, err := s.chain.hasBlocks(hash, height-fork)
+		if err != nil {
+			return nil, err
+		}
+		if !stored {
+			status = "headers-only"
+		}
+		tips = append(tips, chainTipResult{Height: height, Hash: hashString(hash), BranchLen: height - fork, Status: status})
+	}
+	slices.SortStableFunc(tips, func(a, b chainTipResult) int { return int(b.Height - a.Height) })
+	return tips, nil
+}
+
+// headerResult returns the verbose header of the block with the given hash and height.
+func (s *Server) headerResult(hash [32]byte, header p2p.BlockHeader, height int32) (blockHeaderResult, error) {
+	_, tipHeight, err := s.chain.tip()
+	if err != nil {
+		return blockHeaderResult{}, err
+	}
+	medianTime, err := s.chain.medianTime(header, height)
+	if err != nil {
+		return blockHeaderResult{}, err
+	}
+	work, err := s.chain.headers.ChainWork(hash)
+	if err != nil {
+		return blockHeaderResult{}, err
+	}
+
+	hr := blockHeaderResult{
+		Hash:          hashString(hash),
+		Confirmations: -1,
+		Height:        height,
+		Version:       header.Version,
+		VersionHex:    fmt.Sprintf("%08x", uint32(header.Version)),
+		MerkleRoot:    hashString(header.MerkleRoot),
+		Time:          header.Timestamp,
+		MedianTime:    medianTime,
+		Nonce:         header.Nonce,
+		Bits:          fmt.Sprintf("%08x", header.Bits),
+		Difficulty:    difficulty(header.Bits),
+		ChainWork:     chainWorkString(work),
+	}
+	if height > 0 {
+		hr.PreviousBlockHash = hashString(header.PrevBlockHash)
+	}
+
+	active, err := s.chain.isActive(hash, height)
+	if err != nil || !active {
+		return hr, err
+	}
+	// only the blocks of the active chain have confirmations and the next block
+	hr.Confirmations = tipHeight - height + 1
+	if height < tipHeight {
+		next, err := s.chain.hashAt(height + 1)
+		if err != nil {
+			return blockHeaderResult{}, err
+		}
+		hr.NextBlockHash = hashString(next)
+	}
+	return hr, nil
+}
+
+// txResult returns the decoded transaction, its fee is set by the caller.
+func (s *Server) txResult(tx p2p.MsgTx) (txResult, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return txResult{}, err
+	}
+	result := txResult{
+		TxID:     hashString(tx.TxHash()),
+		Hash:     hashString(tx.WTxHash()),
+		Version:  tx.Version,
+		Size:     len(raw),
+		VSize:    tx.VSize(),
+		Weight:   tx.Weight(),
+		LockTime: tx.LockTime,
+		Vin:      make([]vinResult, len(tx.TxIn)),
+		Vout:     make([]voutResult, len(tx.TxOut)),
+		Hex:      hex.EncodeToString(raw),
+	}
+
+	coinbase := tx.IsCoinBase()
+	for i, in := range tx.TxIn {
+		vin := vinResult{Sequence: in.Sequence}
+		if coinbase {
+			vin.Coinbase = hex.EncodeToString(in.SignatureScript)
+		} else {
+			index := in.PreviousOutput.Index
+			vin.TxID = hashString(in.PreviousOutput.Hash)
+			vin.Vout = &index
+			vin.ScriptSig = &scriptSigResult{
+				Asm: script.Disassemble(in.SignatureScript, true),
+				Hex: hex.EncodeToString(in.SignatureScript),
+			}
+		}
+		if i < len(tx.TxWitness) {
+			for _, w := range tx.TxWitness[i].Witness {
+				vin.TxInWitness = append(vin.TxInWitness, hex.EncodeToString(w.Data))
+			}
+		}
+		result.Vin[i] = vin
+	}
+
+	for i, out := range tx.TxOut {
+		result.Vout[i] = voutResult{
+			Value: amount(out.Value),
+			N:     i,
+			ScriptPubKey: scriptPubKeyResult{
+				Asm:     script.Disassemble(out.PkScript, false),
+				Desc:    scriptDescriptor(out.PkScript, s.network),
+				Hex:     hex.EncodeToString(out.PkScript),
+				Address: scriptAddress(out.PkScript, s.network),
+				Type:    scriptType(out.PkScript),
+			},
+		}
+	}
+	return result, nil
+}
+
+// difficulty returns how many times the target of the bits is harder than the target of the genesis block.
+func difficulty(bits uint32) float64 {
+	shift := (bits >> 24) & 0xff
+	diff := float64(0x0000ffff) / float64(bits&0x00ffffff)
+	for ; shift < 29; shift++ {
+		diff *= 256
+	}
+	for ; shift > 29; shift-- {
+		diff /= 256
+	}
+	return diff
+}
+
+// hashString returns the hash in the byte order that is used by Bitcoin Core RPC.
+func hashString(hash [32]byte) string {
+	return hex.EncodeToString(p2p.Reverse(hash))
+}
+
+// chainWorkString returns the chain work as 64 hex digits.
+func chainWorkString(work *big.Int) string {
+	return fmt.Sprintf("%064x", work)
+}
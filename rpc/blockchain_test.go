@@ -0,0 +1,251 @@
+package rpc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/db"
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/rpc"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain is the chain genesis - b1 - b2 - b3 with the fork b1 - f2. b2 is the tip of the active chain,
+// the block b3 is not downloaded yet and the block f2 is stored.
+type testChain struct {
+	server         *rpc.Server
+	b1, b2, b3, f2 p2p.MsgBlock
+	rawBlocks      *rpc.MockRawBlockReader
+	undo           *rpc.MockUndoStore
+	peers          *rpc.MockPeers
+	feeFilters     *rpc.MockFeeFilters
+	broadcaster    *rpc.MockTxBroadcaster
+}
+
+func newTestChain(t *testing.T) testChain {
+	coinbase := testutil.NewMsgTx([]p2p.OutPoint{{Index: 0xffffffff}}, 50_0000_0000)
+	spend := testutil.NewMsgTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 9_000)
+	// P2WPKH output of the BIP 84 test vector
+	spend.TxOut[0].PkScript, _ = hex.DecodeString("0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2")
+	spend.TxOut[0].PkScriptLength = 22
+
+	tc := testChain{
+		b1: newBlock(sync.GenesisBlockHash, 1, coinbase),
+	}
+	tc.b2 = newBlock(tc.b1.GetHash(), 2, coinbase, spend)
+	tc.b3 = newBlock(tc.b2.GetHash(), 3, coinbase)
+	tc.f2 = newBlock(tc.b1.GetHash(), 20, coinbase)
+
+	boltDB, err := db.NewBoltDB(t.TempDir() + "/rpc.db")
+	require.NoError(t, err)
+	t.Cleanup(boltDB.Close)
+	headers, err := db.NewHeaderStore(boltDB.DB)
+	require.NoError(t, err)
+	require.NoError(t, headers.AddHeaders([]p2p.BlockHeader{tc.b1.BlockHeader, tc.b2.BlockHeader, tc.b3.BlockHeader}))
+	require.NoError(t, headers.AddHeaders([]p2p.BlockHeader{tc.f2.BlockHeader}))
+
+	blocks := db.NewMemoryBlockRepo()
+	for _, b := range []p2p.MsgBlock{tc.b1, tc.f2, tc.b2} {
+		require.NoError(t, blocks.Save(b))
+	}
+
+	ctrl := gomock.NewController(t)
+	state := rpc.NewMockChainState(ctrl)
+	state.EXPECT().BestBlock().Return(tc.b2.GetHash(), int32(2), nil).AnyTimes()
+	tc.rawBlocks = rpc.NewMockRawBlockReader(ctrl)
+	tc.undo = rpc.NewMockUndoStore(ctrl)
+	tc.peers = rpc.NewMockPeers(ctrl)
+	tc.feeFilters = rpc.NewMockFeeFilters(ctrl)
+	tc.broadcaster = rpc.NewMockTxBroadcaster(ctrl)
+
+	tc.server = rpc.NewServer("mainnet", "127.0.0.1:0", "user", "pass", "", 0, true, blocks, tc.rawBlocks, headers, state,
+		tc.undo, tc.peers, tc.feeFilters, tc.broadcaster)
+	return tc
+}
+
+func newBlock(prev [32]byte, nonce uint32, txs ...p2p.MsgTx) p2p.MsgBlock {
+	block := testutil.NewMsgBlockWithTxs(prev, txs...)
+	block.Bits = 0x1d00ffff
+	block.Nonce = nonce
+	return block
+}
+
+// call calls the method with the credentials of the test server and returns the result or the error.
+func call(t *testing.T, s *rpc.Server, method string, params ...any) (json.RawMessage, *rpc.Error) {
+	body, err := json.Marshal(map[string]any{"method": method, "params": params, "id": 1})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.SetBasicAuth("user", "pass")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	var resp struct {
+		Result json.RawMessage
+		Error  *rpc.Error
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Result, resp.Error
+}
+
+func hashHex(hash [32]byte) string {
+	return hex.EncodeToString(p2p.Reverse(hash))
+}
+
+func TestServer_ActiveChain(t *testing.T) {
+	tc := newTestChain(t)
+
+	result, rpcErr := call(t, tc.server, "getblockcount")
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `2`, string(result))
+
+	result, rpcErr = call(t, tc.server, "getbestblockhash")
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `"`+hashHex(tc.b2.GetHash())+`"`, string(result))
+
+	result, rpcErr = call(t, tc.server, "getblockhash", 1)
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `"`+hashHex(tc.b1.GetHash())+`"`, string(result))
+
+	result, rpcErr = call(t, tc.server, "getblockhash", 0)
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `"000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"`, string(result))
+
+	// the header b3 is known, but its block is not connected
+	_, rpcErr = call(t, tc.server, "getblockhash", 3)
+	require.Equal(t, &rpc.Error{Code: rpc.ErrCodeInvalidParameter, Message: "Block height out of range"}, rpcErr)
+
+	result, rpcErr = call(t, tc.server, "getblockchaininfo")
+	require.Nil(t, rpcErr)
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(result, &info))
+	require.Equal(t, "main", info["chain"])
+	require.Equal(t, float64(2), info["blocks"])
+	require.Equal(t, float64(3), info["headers"])
+	require.Equal(t, hashHex(tc.b2.GetHash()), info["bestblockhash"])
+	require.Equal(t, float64(1), info["difficulty"])
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000300030003", info["chainwork"])
+	require.Equal(t, false, info["pruned"])
+	// the header of b3 is ahead of the tip
+	require.Equal(t, true, info["initialblockdownload"])
+}
+
+func TestServer_GetBlockHeader(t *testing.T) {
+	tc := newTestChain(t)
+
+	result, rpcErr := call(t, tc.server, "getblockheader", hashHex(tc.b1.GetHash()))
+	require.Nil(t, rpcErr)
+	var header map[string]any
+	require.NoError(t, json.Unmarshal(result, &header))
+	require.Equal(t, float64(2), header["confirmations"])
+	require.Equal(t, float64(1), header["height"])
+	require.Equal(t, "1d00ffff", header["bits"])
+	require.Equal(t, "00000001", header["versionHex"])
+	require.Equal(t, hashHex(sync.GenesisBlockHash), header["previousblockhash"])
+	require.Equal(t, hashHex(tc.b2.GetHash()), header["nextblockhash"])
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000200020002", header["chainwork"])
+
+	// the blocks that are not on the active chain have no confirmations and no next block
+	result, rpcErr = call(t, tc.server, "getblockheader", hashHex(tc.f2.GetHash()))
+	require.Nil(t, rpcErr)
+	header = nil
+	require.NoError(t, json.Unmarshal(result, &header))
+	require.Equal(t, float64(-1), header["confirmations"])
+	require.NotContains(t, header, "nextblockhash")
+
+	raw, err := wire.Marshal(tc.b3.BlockHeader)
+	require.NoError(t, err)
+	result, rpcErr = call(t, tc.server, "getblockheader", hashHex(tc.b3.GetHash()), false)
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `"`+hex.EncodeToString(raw[:80])+`"`, string(result))
+
+	_, rpcErr = call(t, tc.server, "getblockheader", hashHex([32]byte{1}))
+	require.Equal(t, &rpc.Error{Code: rpc.ErrCodeInvalidAddressKey, Message: "Block not found"}, rpcErr)
+
+	_, rpcErr = call(t, tc.server, "getblockheader", "00ff")
+	require.Equal(t, &rpc.Error{Code: rpc.ErrCodeInvalidParameter, Message: "blockhash must be of length 64 (not 4, for '00ff')"}, rpcErr)
+
+	_, rpcErr = call(t, tc.server, "getblockheader", hashHex(tc.b1.GetHash()), "yes")
+	require.Equal(t, &rpc.Error{Code: rpc.ErrCodeType, Message: "JSON value of type string is not of expected type bool"}, rpcErr)
+}
+
+func TestServer_GetBlock(t *testing.T) {
+	tc := newTestChain(t)
+	hash := hashHex(tc.b2.GetHash())
+
+	raw, err := wire.Marshal(tc.b2)
+	require.NoError(t, err)
+	result, rpcErr := call(t, tc.server, "getblock", hash, 0)
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `"`+hex.EncodeToString(raw)+`"`, string(result))
+
+	result, rpcErr = call(t, tc.server, "getblock", hash)
+	require.Nil(t, rpcErr)
+	var block map[string]any
+	require.NoError(t, json.Unmarshal(result, &block))
+	require.Equal(t, float64(2), block["nTx"])
+	require.Equal(t, float64(len(raw)), block["size"])
+	require.Equal(t, float64(len(raw)*4), block["weight"])
+	require.Equal(t, []any{hashHex(tc.b2.Transactions[0].TxHash()), hashHex(tc.b2.Transactions[1].TxHash())}, block["tx"])
+
+	// the fee is calculated from the spent outputs of the undo data
+	tc.undo.EXPECT().SpentOutputs(tc.b2.GetHash()).Return([]db.SpentOutput{{UTXO: db.UTXO{Value: 10_000}}}, nil).Times(1)
+	result, rpcErr = call(t, tc.server, "getblock", hash, 2)
+	require.Nil(t, rpcErr)
+	var verbose struct {
+		Tx []struct {
+			TxID string
+			Fee  json.Number
+			Vin  []map[string]any
+			Vout []struct {
+				Value        json.Number
+				ScriptPubKey map[string]any
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(result, &verbose))
+	require.Len(t, verbose.Tx, 2)
+	require.Contains(t, verbose.Tx[0].Vin[0], "coinbase")
+	require.Equal(t, json.Number("50.00000000"), verbose.Tx[0].Vout[0].Value)
+	require.Empty(t, verbose.Tx[0].Fee)
+	require.Equal(t, json.Number("0.00001000"), verbose.Tx[1].Fee)
+	require.Equal(t, map[string]any{"asm": "1", "hex": "51"}, verbose.Tx[1].Vin[0]["scriptSig"])
+	require.Equal(t, map[string]any{
+		"asm":     "0 c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2",
+		"desc":    "addr(bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu)#lpewvaaa",
+		"hex":     "0014c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2",
+		"address": "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		"type":    "witness_v0_keyhash",
+	}, verbose.Tx[1].Vout[0].ScriptPubKey)
+
+	// the header of b3 is known, but the block is not downloaded
+	_, rpcErr = call(t, tc.server, "getblock", hashHex(tc.b3.GetHash()))
+	require.Equal(t, &rpc.Error{Code: rpc.ErrCodeMisc, Message: "Block not found on disk"}, rpcErr)
+
+	// the genesis block is not stored
+	result, rpcErr = call(t, tc.server, "getblock", hashHex(sync.GenesisBlockHash), true)
+	require.Nil(t, rpcErr)
+	block = nil
+	require.NoError(t, json.Unmarshal(result, &block))
+	require.Equal(t, []any{"4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"}, block["tx"])
+	require.Equal(t, float64(3), block["confirmations"])
+}
+
+func TestServer_GetChainTips(t *testing.T) {
+	tc := newTestChain(t)
+
+	result, rpcErr := call(t, tc.server, "getchaintips")
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, `[
+		{"height": 3, "hash": "`+hashHex(tc.b3.GetHash())+`", "branchlen": 1, "status": "headers-only"},
+		{"height": 2, "hash": "`+hashHex(tc.b2.GetHash())+`", "branchlen": 0, "status": "active"},
+		{"height": 2, "hash": "`+hashHex(tc.f2.GetHash())+`", "branchlen": 1, "status": "valid-fork"}
+	]`, string(result))
+}
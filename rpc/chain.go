@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+// medianTimeSpan is the number of blocks whose median timestamp is the median time past of a block.
+const medianTimeSpan = 11
+
+// genesisBlockHex is the serialized genesis block of the mainnet. It is not stored in the block repository,
+// because the chain starts from it.
+const genesisBlockHex = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e6776" +
+	"8f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c010100000001000000000000000000000000000000000000000000" +
+	"0000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f7220" +
+	"6f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe" +
+	"5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf1" +
+	"1d5fac00000000"
+
+// errHeightOutOfRange is returned for a height above the tip of the active chain.
+var errHeightOutOfRange = errors.New("block height out of range")
+
+var genesisBlock = func() p2p.MsgBlock {
+	raw, _ := hex.DecodeString(genesisBlockHex)
+	var block p2p.MsgBlock
+	if err := block.UnmarshalBinary(bytes.NewReader(raw)); err != nil {
+		panic(err)
+	}
+	return block
+}()
+
+// chain reads the active chain, the header index and the stored blocks. The active chain ends with the
+// last block that is connected to the UTXO set, the best header chain can be longer while its blocks are
+// downloaded.
+type chain struct {
+	blocks  sync.BlockRepository
+	headers HeaderIndex
+	state   ChainState
+}
+
+// tip returns the hash and the height of the tip of the active chain.
+func (c chain) tip() ([32]byte, int32, error) {
+	return c.state.BestBlock()
+}
+
+// header returns the header with the given hash and its height, sync.ErrNotFound is returned for an
+// unknown header.
+func (c chain) header(hash [32]byte) (p2p.BlockHeader, int32, error) {
+	if hash == sync.GenesisBlockHash {
+		return genesisBlock.BlockHeader, 0, nil
+	}
+	return c.headers.GetHeader(hash)
+}
+
+// block returns the stored block with the given hash.
+func (c chain) block(hash [32]byte) (p2p.MsgBlock, error) {
+	if hash == sync.GenesisBlockHash {
+		return genesisBlock, nil
+	}
+	return c.blocks.Get(hash)
+}
+
+// hasBlocks returns true when the block with the given hash and the n-1 blocks before it are stored and not
+// pruned.
+func (c chain) hasBlocks(hash [32]byte, n int32) (bool, error) {
+	for ; n > 0; n-- {
+		block, err := c.block(hash)
+		if errors.Is(err, sync.ErrNotFound) || errors.Is(err, sync.ErrPruned) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		hash = block.PrevBlockHash
+	}
+	return true, nil
+}
+
+// hashAt returns the hash of the block at the given height on the active chain. errHeightOutOfRange is
+// returned for a height above its tip.
+func (c chain) hashAt(height int32) ([32]byte, error) {
+	tipHash, tipHeight, err := c.tip()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if height < 0 || height > tipHeight {
+		return [32]byte{}, errHeightOutOfRange
+	}
+
+	onBest, err := c.onBestHeaderChain(tipHash, tipHeight)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if onBest {
+		return c.headers.HashAt(height)
+	}
+
+	// the blocks of a better header chain are not connected yet, the active chain is walked back
+	hash := tipHash
+	for h := tipHeight; h > height; h-- {
+		header, _, err := c.header(hash)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		hash = header.PrevBlockHash
+	}
+	return hash, nil
+}
+
+// isActive returns true when the block with the given hash and height is on the active chain.
+func (c chain) isActive(hash [32]byte, height int32) (bool, error) {
+	active, err := c.hashAt(height)
+	if errors.Is(err, errHeightOutOfRange) {
+		return false, nil
+	}
+	return active == hash, err
+}
+
+// forkHeight returns the height of the last block of the branch that ends with the given block that is on
+// the active chain.
+func (c chain) forkHeight(hash [32]byte, height int32) (int32, error) {
+	tipHash, tipHeight, err := c.tip()
+	if err != nil {
+		return 0, err
+	}
+
+	// the best header chain usually extends the active chain, so its blocks after the tip are not walked
+	if height > tipHeight {
+		onBest, err := c.onBestHeaderChain(hash, height)
+		if err != nil {
+			return 0, err
+		}
+		tipOnBest, err := c.onBestHeaderChain(tipHash, tipHeight)
+		if err != nil {
+			return 0, err
+		}
+		if onBest && tipOnBest {
+			return tipHeight, nil
+		}
+	}
+
+	for {
+		active, err := c.isActive(hash, height)
+		if err != nil || active {
+			return height, err
+		}
+		header, _, err := c.header(hash)
+		if err != nil {
+			return 0, err
+		}
+		hash, height = header.PrevBlockHash, height-1
+	}
+}
+
+// onBestHeaderChain returns true when the block with the given hash and height is on the best header chain.
+func (c chain) onBestHeaderChain(hash [32]byte, height int32) (bool, error) {
+	best, err := c.headers.HashAt(height)
+	if errors.Is(err, sync.ErrNotFound) {
+		return false, nil
+	}
+	return best == hash, err
+}
+
+// medianTime returns the median timestamp of the block and the 10 blocks before it.
+func (c chain) medianTime(header p2p.BlockHeader, height int32) (uint32, error) {
+	times := []uint32{header.Timestamp}
+	for i := 1; i < medianTimeSpan && height-int32(i) >= 0; i++ {
+		prev, _, err := c.header(header.PrevBlockHash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get header %x: %w", p2p.Reverse(header.PrevBlockHash), err)
+		}
+		header = prev
+		times = append(times, header.Timestamp)
+	}
+	slices.Sort(times)
+	return times[len(times)/2], nil
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/EmilGeorgiev/btc-node/script"
+)
+
+const (
+	// descriptorInputCharset are the characters of the descriptors, the checksum is computed from their positions.
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	// descriptorChecksumCharset are the characters of the checksum of the descriptors.
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// scriptDescriptor returns the output descriptor with its checksum that Bitcoin Core infers for the output script
+// without knowing its keys (BIP 380). The scripts with a public key have a key descriptor, the other scripts with an
+// address have an addr() descriptor and the rest a raw() descriptor.
+func scriptDescriptor(s []byte, network string) string {
+	var desc string
+	switch scriptType(s) {
+	case "pubkey":
+		if key := s[1 : len(s)-1]; script.IsValidPubKey(key) {
+			desc = fmt.Sprintf("pk(%x)", key)
+		}
+	case "multisig":
+		desc = multiSigDescriptor(s)
+	case "witness_v1_taproot":
+		// the x-only key is valid when the key with an even y coordinate is
+		if script.IsValidPubKey(append([]byte{0x02}, s[2:]...)) {
+			desc = fmt.Sprintf("rawtr(%x)", s[2:])
+		}
+	}
+	if desc == "" {
+		if address := scriptAddress(s, network); address != "" {
+			desc = "addr(" + address + ")"
+		} else {
+			desc = "raw(" + hex.EncodeToString(s) + ")"
+		}
+	}
+	return desc + "#" + descriptorChecksum(desc)
+}
+
+// multiSigDescriptor returns the multi() descriptor of a bare multisig script, it is empty when a key is not valid.
+func multiSigDescriptor(s []byte) string {
+	parts := []string{fmt.Sprint(int(s[0]-op1) + 1)}
+	for keys := s[1 : len(s)-2]; len(keys) > 0; keys = keys[keys[0]+1:] {
+		key := keys[1 : keys[0]+1]
+		if !script.IsValidPubKey(key) {
+			return ""
+		}
+		parts = append(parts, hex.EncodeToString(key))
+	}
+	return "multi(" + strings.Join(parts, ",") + ")"
+}
+
+// descriptorChecksum returns the checksum of the descriptor (BIP 380).
+func descriptorChecksum(desc string) string {
+	var symbols []uint64
+	var groups []uint64
+	for _, c := range desc {
+		v := uint64(strings.IndexRune(descriptorInputCharset, c))
+		symbols = append(symbols, v&31)
+		groups = append(groups, v>>5)
+		if len(groups) == 3 {
+			symbols = append(symbols, groups[0]*9+groups[1]*3+groups[2])
+			groups = groups[:0]
+		}
+	}
+	switch len(groups) {
+	case 1:
+		symbols = append(symbols, groups[0])
+	case 2:
+		symbols = append(symbols, groups[0]*3+groups[1])
+	}
+
+	checksum := descriptorPolymod(append(symbols, 0, 0, 0, 0, 0, 0, 0, 0)) ^ 1
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = descriptorChecksumCharset[(checksum>>(5*(7-i)))&31]
+	}
+	return string(b)
+}
+
+func descriptorPolymod(symbols []uint64) uint64 {
+	generator := [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+	chk := uint64(1)
+	for _, v := range symbols {
+		top := chk >> 35
+		chk = (chk&0x7ffffffff)<<5 ^ v
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/node"
+)
+
+// ChainState returns the tip of the active chain, the last block that is connected to the UTXO set.
+type ChainState interface {
+	BestBlock() ([32]byte, int32, error)
+}
+
+// HeaderIndex is the index of the validated headers. GetHeader returns sync.ErrNotFound for an unknown
+// header and HashAt returns the hash at the given height of the best header chain. ChainWork includes the
+// work of the genesis block. Tips returns the headers that are not extended by another header.
+type HeaderIndex interface {
+	BestHeader() (p2p.BlockHeader, int32, error)
+	GetHeader(hash [32]byte) (p2p.BlockHeader, int32, error)
+	HashAt(height int32) ([32]byte, error)
+	ChainWork(hash [32]byte) (*big.Int, error)
+	Tips() ([][32]byte, error)
+}
+
+// RawBlockReader reads the serialized blocks from the disk, the REST interface streams them without decoding.
+type RawBlockReader interface {
+	OpenBlock(hash [32]byte) (io.ReadCloser, int64, error)
+}
+
+// UndoStore returns the outputs that are spent by a connected block, the fees of its transactions are
+// calculated from them.
+type UndoStore interface {
+	SpentOutputs(hash [32]byte) ([]db.SpentOutput, error)
+}
+
+// Peers returns the connected peers.
+type Peers interface {
+	PeerInfo() []node.PeerInfo
+}
+
+// FeeFilters returns the feefilter that a peer sent, in satoshis per 1000 virtual bytes.
+type FeeFilters interface {
+	FeeFilter(addr string) int64
+}
+
+// TxBroadcaster adds the transactions that are submitted with sendrawtransaction to the mempool and announces
+// them to the peers. The max fee rate is in satoshis per 1000 virtual bytes, 0 disables the check.
+type TxBroadcaster interface {
+	BroadcastTx(tx p2p.MsgTx, maxFeeRate int64) (mempool.TxDesc, error)
+}
@@ -0,0 +1,3 @@
+package rpc
+
+//go:generate mockgen -source=interfaces.go -destination=mocks_rpc_test.go -package=$GOPACKAGE
@@ -0,0 +1,345 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+
+// Package rpc is a generated GoMock package.
+package rpc
+
+import (
+	io "io"
+	big "math/big"
+	reflect "reflect"
+
+	db "github.com/EmilGeorgiev/btc-node/db"
+	mempool "github.com/EmilGeorgiev/btc-node/mempool"
+	p2p "github.com/EmilGeorgiev/btc-node/network/p2p"
+	node "github.com/EmilGeorgiev/btc-node/node"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChainState is a mock of ChainState interface.
+type MockChainState struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainStateMockRecorder
+}
+
+// MockChainStateMockRecorder is the mock recorder for MockChainState.
+type MockChainStateMockRecorder struct {
+	mock *MockChainState
+}
+
+// NewMockChainState creates a new mock instance.
+func NewMockChainState(ctrl *gomock.Controller) *MockChainState {
+	mock := &MockChainState{ctrl: ctrl}
+	mock.recorder = &MockChainStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainState) EXPECT() *MockChainStateMockRecorder {
+	return m.recorder
+}
+
+// BestBlock mocks base method.
+func (m *MockChainState) BestBlock() ([32]byte, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestBlock")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestBlock indicates an expected call of BestBlock.
+func (mr *MockChainStateMockRecorder) BestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlock", reflect.TypeOf((*MockChainState)(nil).BestBlock))
+}
+
+// MockHeaderIndex is a mock of HeaderIndex interface.
+type MockHeaderIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeaderIndexMockRecorder
+}
+
+// MockHeaderIndexMockRecorder is the mock recorder for MockHeaderIndex.
+type MockHeaderIndexMockRecorder struct {
+	mock *MockHeaderIndex
+}
+
+// NewMockHeaderIndex creates a new mock instance.
+func NewMockHeaderIndex(ctrl *gomock.Controller) *MockHeaderIndex {
+	mock := &MockHeaderIndex{ctrl: ctrl}
+	mock.recorder = &MockHeaderIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeaderIndex) EXPECT() *MockHeaderIndexMockRecorder {
+	return m.recorder
+}
+
+// BestHeader mocks base method.
+func (m *MockHeaderIndex) BestHeader() (p2p.BlockHeader, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestHeader")
+	ret0, _ := ret[0].(p2p.BlockHeader)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestHeader indicates an expected call of BestHeader.
+func (mr *MockHeaderIndexMockRecorder) BestHeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestHeader", reflect.TypeOf((*MockHeaderIndex)(nil).BestHeader))
+}
+
+// ChainWork mocks base method.
+func (m *MockHeaderIndex) ChainWork(hash [32]byte) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainWork", hash)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainWork indicates an expected call of ChainWork.
+func (mr *MockHeaderIndexMockRecorder) ChainWork(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainWork", reflect.TypeOf((*MockHeaderIndex)(nil).ChainWork), hash)
+}
+
+// GetHeader mocks base method.
+func (m *MockHeaderIndex) GetHeader(hash [32]byte) (p2p.BlockHeader, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", hash)
+	ret0, _ := ret[0].(p2p.BlockHeader)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockHeaderIndexMockRecorder) GetHeader(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockHeaderIndex)(nil).GetHeader), hash)
+}
+
+// HashAt mocks base method.
+func (m *MockHeaderIndex) HashAt(height int32) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashAt", height)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HashAt indicates an expected call of HashAt.
+func (mr *MockHeaderIndexMockRecorder) HashAt(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashAt", reflect.TypeOf((*MockHeaderIndex)(nil).HashAt), height)
+}
+
+// Tips mocks base method.
+func (m *MockHeaderIndex) Tips() ([][32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tips")
+	ret0, _ := ret[0].([][32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tips indicates an expected call of Tips.
+func (mr *MockHeaderIndexMockRecorder) Tips() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tips", reflect.TypeOf((*MockHeaderIndex)(nil).Tips))
+}
+
+// MockRawBlockReader is a mock of RawBlockReader interface.
+type MockRawBlockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockRawBlockReaderMockRecorder
+}
+
+// MockRawBlockReaderMockRecorder is the mock recorder for MockRawBlockReader.
+type MockRawBlockReaderMockRecorder struct {
+	mock *MockRawBlockReader
+}
+
+// NewMockRawBlockReader creates a new mock instance.
+func NewMockRawBlockReader(ctrl *gomock.Controller) *MockRawBlockReader {
+	mock := &MockRawBlockReader{ctrl: ctrl}
+	mock.recorder = &MockRawBlockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRawBlockReader) EXPECT() *MockRawBlockReaderMockRecorder {
+	return m.recorder
+}
+
+// OpenBlock mocks base method.
+func (m *MockRawBlockReader) OpenBlock(hash [32]byte) (io.ReadCloser, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenBlock", hash)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenBlock indicates an expected call of OpenBlock.
+func (mr *MockRawBlockReaderMockRecorder) OpenBlock(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenBlock", reflect.TypeOf((*MockRawBlockReader)(nil).OpenBlock), hash)
+}
+
+// MockUndoStore is a mock of UndoStore interface.
+type MockUndoStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoStoreMockRecorder
+}
+
+// MockUndoStoreMockRecorder is the mock recorder for MockUndoStore.
+type MockUndoStoreMockRecorder struct {
+	mock *MockUndoStore
+}
+
+// NewMockUndoStore creates a new mock instance.
+func NewMockUndoStore(ctrl *gomock.Controller) *MockUndoStore {
+	mock := &MockUndoStore{ctrl: ctrl}
+	mock.recorder = &MockUndoStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndoStore) EXPECT() *MockUndoStoreMockRecorder {
+	return m.recorder
+}
+
+// SpentOutputs mocks base method.
+func (m *MockUndoStore) SpentOutputs(hash [32]byte) ([]db.SpentOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpentOutputs", hash)
+	ret0, _ := ret[0].([]db.SpentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpentOutputs indicates an expected call of SpentOutputs.
+func (mr *MockUndoStoreMockRecorder) SpentOutputs(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpentOutputs", reflect.TypeOf((*MockUndoStore)(nil).SpentOutputs), hash)
+}
+
+// MockPeers is a mock of Peers interface.
+type MockPeers struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeersMockRecorder
+}
+
+// MockPeersMockRecorder is the mock recorder for MockPeers.
+type MockPeersMockRecorder struct {
+	mock *MockPeers
+}
+
+// NewMockPeers creates a new mock instance.
+func NewMockPeers(ctrl *gomock.Controller) *MockPeers {
+	mock := &MockPeers{ctrl: ctrl}
+	mock.recorder = &MockPeersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeers) EXPECT() *MockPeersMockRecorder {
+	return m.recorder
+}
+
+// PeerInfo mocks base method.
+func (m *MockPeers) PeerInfo() []node.PeerInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerInfo")
+	ret0, _ := ret[0].([]node.PeerInfo)
+	return ret0
+}
+
+// PeerInfo indicates an expected call of PeerInfo.
+func (mr *MockPeersMockRecorder) PeerInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerInfo", reflect.TypeOf((*MockPeers)(nil).PeerInfo))
+}
+
+// MockFeeFilters is a mock of FeeFilters interface.
+type MockFeeFilters struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeFiltersMockRecorder
+}
+
+// MockFeeFiltersMockRecorder is the mock recorder for MockFeeFilters.
+type MockFeeFiltersMockRecorder struct {
+	mock *MockFeeFilters
+}
+
+// NewMockFeeFilters creates a new mock instance.
+func NewMockFeeFilters(ctrl *gomock.Controller) *MockFeeFilters {
+	mock := &MockFeeFilters{ctrl: ctrl}
+	mock.recorder = &MockFeeFiltersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeFilters) EXPECT() *MockFeeFiltersMockRecorder {
+	return m.recorder
+}
+
+// FeeFilter mocks base method.
+func (m *MockFeeFilters) FeeFilter(addr string) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeFilter", addr)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// FeeFilter indicates an expected call of FeeFilter.
+func (mr *MockFeeFiltersMockRecorder) FeeFilter(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeFilter", reflect.TypeOf((*MockFeeFilters)(nil).FeeFilter), addr)
+}
+
+// MockTxBroadcaster is a mock of TxBroadcaster interface.
+type MockTxBroadcaster struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxBroadcasterMockRecorder
+}
+
+// MockTxBroadcasterMockRecorder is the mock recorder for MockTxBroadcaster.
+type MockTxBroadcasterMockRecorder struct {
+	mock *MockTxBroadcaster
+}
+
+// NewMockTxBroadcaster creates a new mock instance.
+func NewMockTxBroadcaster(ctrl *gomock.Controller) *MockTxBroadcaster {
+	mock := &MockTxBroadcaster{ctrl: ctrl}
+	mock.recorder = &MockTxBroadcasterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxBroadcaster) EXPECT() *MockTxBroadcasterMockRecorder {
+	return m.recorder
+}
+
+// BroadcastTx mocks base method.
+func (m *MockTxBroadcaster) BroadcastTx(tx p2p.MsgTx, maxFeeRate int64) (mempool.TxDesc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BroadcastTx", tx, maxFeeRate)
+	ret0, _ := ret[0].(mempool.TxDesc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BroadcastTx indicates an expected call of BroadcastTx.
+func (mr *MockTxBroadcasterMockRecorder) BroadcastTx(tx, maxFeeRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastTx", reflect.TypeOf((*MockTxBroadcaster)(nil).BroadcastTx), tx, maxFeeRate)
+}
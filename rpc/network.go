@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// serviceNames are the names of the service bits in getpeerinfo.
+var serviceNames = []struct {
+	bit  uint64
+	name string
+}{
+	{1, "NETWORK"},
+	{2, "GETUTXO"},
+	{4, "BLOOM"},
+	{8, "WITNESS"},
+	{64, "COMPACT_FILTERS"},
+	{1024, "NETWORK_LIMITED"},
+	{2048, "P2P_V2"},
+}
+
+type peerInfoResult struct {
+	ID                    int      `json:"id"`
+	Addr                  string   `json:"addr"`
+	Network               string   `json:"network"`
+	Services              string   `json:"services"`
+	ServicesNames         []string `json:"servicesnames"`
+	RelayTxes             bool     `json:"relaytxes"`
+	BytesSent             uint64   `json:"bytessent"`
+	BytesRecv             uint64   `json:"bytesrecv"`
+	ConnTime              int64    `json:"conntime"`
+	Version               int32    `json:"version"`
+	SubVer                string   `json:"subver"`
+	Inbound               bool     `json:"inbound"`
+	StartingHeight        int32    `json:"startingheight"`
+	SyncedHeaders         int32    `json:"synced_headers"`
+	SyncedBlocks          int32    `json:"synced_blocks"`
+	MinFeeFilter          amount   `json:"minfeefilter"`
+	ConnectionType        string   `json:"connection_type"`
+	TransportProtocolType string   `json:"transport_protocol_type"`
+}
+
+// getPeerInfo returns the connected peers. The node connects only to the configured peers, so all of them
+// are outbound full relay connections. The synced headers are the height of the best header of the peer that
+// is validated and stored, -1 when it is unknown, and the synced blocks are the part of it that is connected.
+func (s *Server) getPeerInfo(_ []json.RawMessage) (any, error) {
+	_, tipHeight, err := s.chain.tip()
+	if err != nil {
+		return nil, err
+	}
+	peers := s.peers.PeerInfo()
+	result := make([]peerInfoResult, 0, len(peers))
+	for _, p := range peers {
+		pr := peerInfoResult{
+			ID:                    p.ID,
+			Addr:                  p.Addr,
+			Network:               addrNetwork(p.Addr),
+			Services:              fmt.Sprintf("%016x", p.Services),
+			ServicesNames:         []string{},
+			RelayTxes:             p.RelayTxes,
+			BytesSent:             p.BytesSent,
+			BytesRecv:             p.BytesReceived,
+			ConnTime:              p.ConnTime.Unix(),
+			Version:               p.Version,
+			SubVer:                p.UserAgent,
+			StartingHeight:        p.StartHeight,
+			SyncedHeaders:         p.TipHeight,
+			SyncedBlocks:          min(p.TipHeight, tipHeight),
+			MinFeeFilter:          amount(s.feeFilters.FeeFilter(p.Addr)),
+			ConnectionType:        "outbound-full-relay",
+			TransportProtocolType: "v1",
+		}
+		for _, sn := range serviceNames {
+			if p.Services&sn.bit != 0 {
+				pr.ServicesNames = append(pr.ServicesNames, sn.name)
+			}
+		}
+		result = append(result, pr)
+	}
+	return result, nil
+}
+
+// addrNetwork returns the network of the address of a peer.
+func addrNetwork(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return "not_publicly_routable"
+	case ip.To4() != nil:
+		return "ipv4"
+	}
+	return "ipv6"
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// requiredParam decodes the parameter at index i into v, an error is returned when it is missing.
+func requiredParam(params []json.RawMessage, i int, name, typ string, v any) error {
+	if missing(params, i) {
+		return newError(ErrCodeMisc, "missing required parameter %s", name)
+	}
+	return optionalParam(params, i, typ, v)
+}
+
+// optionalParam decodes the parameter at index i into v, v keeps its default value when it is missing.
+func optionalParam(params []json.RawMessage, i int, typ string, v any) error {
+	if missing(params, i) {
+		return nil
+	}
+	if jsonType(params[i]) != typ || json.Unmarshal(params[i], v) != nil {
+		return newError(ErrCodeType, "JSON value of type %s is not of expected type %s", jsonType(params[i]), typ)
+	}
+	return nil
+}
+
+// hashParam decodes the block hash at index i, it is hex encoded in the byte order of Bitcoin Core RPC.
+func hashParam(params []json.RawMessage, i int, name string) ([32]byte, error) {
+	var s string
+	if err := requiredParam(params, i, name, "string", &s); err != nil {
+		return [32]byte{}, err
+	}
+	return parseHash(s, name)
+}
+
+func parseHash(s, name string) ([32]byte, error) {
+	if len(s) != 64 {
+		return [32]byte{}, newError(ErrCodeInvalidParameter, "%s must be of length 64 (not %d, for '%s')", name, len(s), s)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return [32]byte{}, newError(ErrCodeInvalidParameter, "%s must be hexadecimal string (not '%s')", name, s)
+	}
+
+	var hash [32]byte
+	for j := range b {
+		hash[31-j] = b[j]
+	}
+	return hash, nil
+}
+
+// verbosityParam decodes the verbosity at index i, it is a number or a boolean. The default is 1.
+func verbosityParam(params []json.RawMessage, i int) (int, error) {
+	if !missing(params, i) && jsonType(params[i]) == "bool" {
+		var verbose bool
+		err := optionalParam(params, i, "bool", &verbose)
+		if verbose {
+			return 1, err
+		}
+		return 0, err
+	}
+
+	verbosity := 1
+	err := optionalParam(params, i, "number", &verbosity)
+	return verbosity, err
+}
+
+func missing(params []json.RawMessage, i int) bool {
+	return i >= len(params) || params[i] == nil || bytes.Equal(bytes.TrimSpace(params[i]), []byte("null"))
+}
+
+// jsonType returns the type of the JSON value with the names that Bitcoin Core uses in its errors.
+func jsonType(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return "null"
+	}
+	switch raw[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// defaultMaxFeeRate is the default maxfeerate of sendrawtransaction, 0.10 BTC/kvB in satoshis per 1000 virtual
+// bytes.
+const defaultMaxFeeRate = 10_000_000
+
+// sendRawTransaction adds the transaction to the mempool and announces it to the peers. A transaction that
+// is already in the mempool is not an error, its txid is returned like for a new one. maxfeerate is in
+// BTC/kvB, 0 accepts any fee rate.
+func (s *Server) sendRawTransaction(params []json.RawMessage) (any, error) {
+	var hexTx string
+	if err := requiredParam(params, 0, "hexstring", "string", &hexTx); err != nil {
+		return nil, err
+	}
+	maxFeeRate := float64(defaultMaxFeeRate) / 1e8
+	if err := optionalParam(params, 1, "number", &maxFeeRate); err != nil {
+		return nil, err
+	}
+	if maxFeeRate < 0 || maxFeeRate > 21_000_000 {
+		return nil, newError(ErrCodeType, "Amount out of range")
+	}
+
+	tx, err := decodeTx(hexTx)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := s.broadcaster.BroadcastTx(tx, int64(math.Round(maxFeeRate*1e8)))
+	switch {
+	case err == nil:
+		return hashString(desc.TxID), nil
+	case errors.Is(err, mempool.ErrAlreadyHave):
+		return hashString(tx.TxHash()), nil
+	case errors.Is(err, mempool.ErrMissingInputs):
+		return nil, newError(ErrCodeTransaction, "Missing inputs")
+	case errors.Is(err, mempool.ErrAbsurdFee):
+		return nil, newError(ErrCodeTransaction, "Fee exceeds maximum configured by user (e.g. -maxtxfee, maxfeerate)")
+	}
+	return nil, newError(ErrCodeTransactionRejected, "%s", err)
+}
+
+// decodeTx decodes the hex encoded transaction, the data after the transaction is an error.
+func decodeTx(s string) (p2p.MsgTx, error) {
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return p2p.MsgTx{}, newError(ErrCodeDeserialization, "TX decode failed. Make sure the tx has at least one input.")
+	}
+	var tx p2p.MsgTx
+	r := bytes.NewReader(raw)
+	if err = tx.UnmarshalBinary(r); err != nil || r.Len() != 0 || len(tx.TxIn) == 0 {
+		return p2p.MsgTx{}, newError(ErrCodeDeserialization, "TX decode failed. Make sure the tx has at least one input.")
+	}
+	return tx, nil
+}
@@ -0,0 +1,56 @@
+package rpc_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/common/testutil"
+	"github.com/EmilGeorgiev/btc-node/mempool"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_SendRawTransaction(t *testing.T) {
+	tc := newTestChain(t)
+	tx := testutil.NewMsgTx([]p2p.OutPoint{{Hash: [32]byte{1}}}, 9_000)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	txHex := hex.EncodeToString(raw)
+
+	tc.broadcaster.EXPECT().BroadcastTx(tx, int64(10_000_000)).Return(mempool.TxDesc{TxID: tx.TxHash()}, nil).Times(1)
+	result, rpcErr := call(t, tc.server, "sendrawtransaction", txHex)
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, fmt.Sprintf("%q", hashHex(tx.TxHash())), string(result))
+
+	// a transaction that is already in the mempool is not an error
+	tc.broadcaster.EXPECT().BroadcastTx(tx, int64(0)).Return(mempool.TxDesc{}, mempool.ErrAlreadyHave).Times(1)
+	result, rpcErr = call(t, tc.server, "sendrawtransaction", txHex, 0)
+	require.Nil(t, rpcErr)
+	require.JSONEq(t, fmt.Sprintf("%q", hashHex(tx.TxHash())), string(result))
+
+	tests := []struct {
+		name   string
+		params []any
+		err    error
+		code   int
+	}{
+		{name: "not hex", params: []any{"zz"}, code: rpc.ErrCodeDeserialization},
+		{name: "trailing data", params: []any{txHex + "00"}, code: rpc.ErrCodeDeserialization},
+		{name: "negative max fee rate", params: []any{txHex, -1}, code: rpc.ErrCodeType},
+		{name: "missing inputs", params: []any{txHex, 0.5}, err: mempool.ErrMissingInputs, code: rpc.ErrCodeTransaction},
+		{name: "absurd fee", params: []any{txHex, 0.5}, err: mempool.ErrAbsurdFee, code: rpc.ErrCodeTransaction},
+		{name: "rejected", params: []any{txHex, 0.5}, err: mempool.ErrInsufficientFee, code: rpc.ErrCodeTransactionRejected},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err != nil {
+				tc.broadcaster.EXPECT().BroadcastTx(tx, int64(50_000_000)).Return(mempool.TxDesc{}, test.err).Times(1)
+			}
+			_, rpcErr := call(t, tc.server, "sendrawtransaction", test.params...)
+			require.NotNil(t, rpcErr)
+			require.Equal(t, test.code, rpcErr.Code)
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+const (
+	// maxRESTHeaders is the maximum count of /rest/headers, the same as in Bitcoin Core.
+	maxRESTHeaders = 2000
+	// defaultRESTHeaders is the count of /rest/headers when the count query parameter is missing.
+	defaultRESTHeaders = "5"
+	// immutableCacheControl is sent with the responses that never change, the serialized blocks by their hash.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// tipCacheControl is sent with the responses that change when a new block is connected or in a reorg.
+	tipCacheControl = "public, max-age=10"
+)
+
+// The output formats of the REST interface, they are the extension of the last segment of the path.
+const (
+	formatBin  = "bin"
+	formatHex  = "hex"
+	formatJSON = "json"
+)
+
+// contentTypes are the content types of the output formats.
+var contentTypes = map[string]string{
+	formatBin:  "application/octet-stream",
+	formatHex:  "text/plain",
+	formatJSON: "application/json",
+}
+
+// registerREST registers the endpoints of the REST interface of Bitcoin Core. They are read only and are not
+// authenticated, so they can be used behind a CDN.
+func (s *Server) registerREST() {
+	s.mux.HandleFunc("GET /rest/block/{file}", s.restBlock)
+	s.mux.HandleFunc("GET /rest/headers/{file}", s.restHeaders)
+	s.mux.HandleFunc("GET /rest/headers/{count}/{file}", s.restHeaders)
+	s.mux.HandleFunc("GET /rest/blockhashbyheight/{file}", s.restBlockHashByHeight)
+	s.mux.HandleFunc("GET /rest/chaininfo.json", s.restChainInfo)
+	s.mux.HandleFunc("/rest/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			restError(w, http.StatusMethodNotAllowed, "REST interface handles only GET requests")
+			return
+		}
+		restError(w, http.StatusNotFound, "not found")
+	})
+}
+
+// restBlock returns the block with the given hash, the JSON output is the result of getblock with verbosity 2.
+func (s *Server) restBlock(w http.ResponseWriter, r *http.Request) {
+	hashStr, format, ok := restFile(w, r.PathValue("file"))
+	if !ok {
+		return
+	}
+	hash, err := parseHash(hashStr, "hash")
+	if err != nil {
+		restError(w, http.StatusBadRequest, "Invalid hash: "+hashStr)
+		return
+	}
+	header, height, err := s.chain.header(hash)
+	if errors.Is(err, sync.ErrNotFound) {
+		restError(w, http.StatusNotFound, hashStr+" not found")
+		return
+	}
+	if err != nil {
+		restInternalError(w, err)
+		return
+	}
+
+	if format != formatJSON {
+		s.writeRawBlock(w, r, hash, hashStr, format)
+		return
+	}
+
+	block, err := s.chain.block(hash)
+	if err != nil {
+		restBlockError(w, hashStr, err)
+		return
+	}
+	raw, err := wire.Marshal(block)
+	if err != nil {
+		restInternalError(w, err)
+		return
+	}
+	result, err := s.blockResult(hash, header, height, block, len(raw), 2)
+	if err != nil {
+		restInternalError(w, err)
+		return
+	}
+	// the confirmations and the next block change with the tip
+	w.Header().Set("Cache-Control", tipCacheControl)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// writeRawBlock streams the serialized block in binary or in hex. The block never changes, so it has the
+// hash as ETag and can be cached forever. The block is opened before the ETag is checked, so a block that is
+// not downloaded yet or is pruned is not reported as not modified.
+func (s *Server) writeRawBlock(w http.ResponseWriter, r *http.Request, hash [32]byte, hashStr, format string) {
+	block, size, err := s.openBlock(hash)
+	if err != nil {
+		restBlockError(w, hashStr, err)
+		return
+	}
+	defer block.Close()
+
+	etag := `"` + hashStr + `"`
+	if ifNoneMatch(r, etag) {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", immutableCacheControl)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	length := size
+	if format == formatHex {
+		length = 2*size + 1
+	}
+	w.Header().Set("Content-Type", contentTypes[format])
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", immutableCacheControl)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if format == formatBin {
+		_, err = io.Copy(w, block)
+	} else if _, err = io.Copy(hex.NewEncoder(w), block); err == nil {
+		_, err = w.Write([]byte("\n"))
+	}
+	if err != nil {
+		// the status is already sent, the client sees a truncated body
+		log.Printf("failed to send block %s: %s\n", hashStr, err)
+	}
+}
+
+// openBlock returns a reader of the serialized block and its size. The block is read from the disk when
+// the blocks are stored in flat files, otherwise it is encoded in memory.
+func (s *Server) openBlock(hash [32]byte) (io.ReadCloser, int64, error) {
+	if s.rawBlocks != nil && hash != sync.GenesisBlockHash {
+		return s.rawBlocks.OpenBlock(hash)
+	}
+	block, err := s.chain.block(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	raw, err := wire.Marshal(block)
+	if err != nil {
+		return nil, 0, err
+	}
+	return io.NopCloser(bytes.NewReader(raw)), int64(len(raw)), nil
+}
+
+// restHeaders returns up to count headers of the active chain, starting with the header with the given
+// hash. The count is in the path (/rest/headers/<count>/<hash>.<ext>) or in the count query parameter
+// (/rest/headers/<hash>.<ext>?count=<count>). No headers are returned when the block is not on the active
+// chain.
+func (s *Server) restHeaders(w http.ResponseWriter, r *http.Request) {
+	hashStr, format, ok := restFile(w, r.PathValue("file"))
+	if !ok {
+		return
+	}
+	countStr := r.PathValue("count")
+	if countStr == "" {
+		countStr = r.URL.Query().Get("count")
+		if countStr == "" {
+			countStr = defaultRESTHeaders
+		}
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count < 1 || count > maxRESTHeaders {
+		restError(w, http.StatusBadRequest, "Header count is invalid or out of acceptable range (1-2000): "+countStr)
+		return
+	}
+	hash, err := parseHash(hashStr, "hash")
+	if err != nil {
+		restError(w, http.StatusBadRequest, "Invalid hash: "+hashStr)
+		return
+	}
+
+	hashes, headers, height, err := s.activeHeaders(hash, count)
+	if err != nil {
+		restInternalError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", tipCacheControl)
+	switch format {
+	case formatJSON:
+		results := make([]blockHeaderResult, len(headers))
+		for i, header := range headers {
+			if results[i], err = s.headerResult(hashes[i], header, height+int32(i)); err != nil {
+				restInternalError(w, err)
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, results)
+	default:
+		raw := make([]byte, 0, len(headers)*80)
+		for _, header := range headers {
+			b, err := wire.Marshal(header)
+			if err != nil {
+				restInternalError(w, err)
+				return
+			}
+			raw = append(raw, b[:80]...)
+		}
+		writeRaw(w, format, raw)
+	}
+}
+
+// activeHeaders returns the hashes and the headers of up to count blocks of the active chain that start
+// with the block with the given hash, and the height of the first one. The chain is walked back from the
+// last block, because the active chain can be behind a better header chain.
+func (s *Server) activeHeaders(hash [32]byte, count int) ([][32]byte, []p2p.BlockHeader, int32, error) {
+	_, height, err := s.chain.header(hash)
+	if errors.Is(err, sync.ErrNotFound) {
+		return nil, nil, 0, nil
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	active, err := s.chain.isActive(hash, height)
+	if err != nil || !active {
+		return nil, nil, 0, err
+	}
+
+	_, tipHeight, err := s.chain.tip()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	last := min(height+int32(count)-1, tipHeight)
+	if hash, err = s.chain.hashAt(last); err != nil {
+		return nil, nil, 0, err
+	}
+
+	hashes := make([][32]byte, last-height+1)
+	headers := make([]p2p.BlockHeader, len(hashes))
+	for i := len(hashes) - 1; i >= 0; i-- {
+		header, _, err := s.chain.header(hash)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		hashes[i], headers[i] = hash, header
+		hash = header.PrevBlockHash
+	}
+	return hashes, headers, height, nil
+}
+
+// restBlockHashByHeight returns the hash of the block at the given height of the active chain.
+func (s *Server) restBlockHashByHeight(w http.ResponseWriter, r *http.Request) {
+	heightStr, format, ok := restFile(w, r.PathValue("file"))
+	if !ok {
+		return
+	}
+	height, err := strconv.ParseInt(heightStr, 10, 32)
+	if err != nil || height < 0 {
+		restError(w, http.StatusBadRequest, "Invalid height: "+heightStr)
+		return
+	}
+	hash, err := s.chain.hashAt(int32(height))
+	if errors.Is(err, errHeightOutOfRange) {
+		restError(w, http.StatusNotFound, "Block height out of range")
+		return
+	}
+	if err != nil {
+		restInternalError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", tipCacheControl)
+	switch format {
+	case formatJSON:
+		writeJSON(w, http.StatusOK, map[string]string{"blockhash": hashString(hash)})
+	case formatHex:
+		// the hex is in the byte order of the RPC, the binary hash is in the internal byte order
+		writeRaw(w, format, p2p.Reverse(hash))
+	default:
+		writeRaw(w, format, hash[:])
+	}
+}
+
+// restChainInfo returns the result of getblockchaininfo.
+func (s *Server) restChainInfo(w http.ResponseWriter, _ *http.Request) {
+	result, err := s.getBlockchainInfo(nil)
+	if err != nil {
+		restInternalError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", tipCacheControl)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// restFile splits the last segment of the path into the parameter and the output format, which is after
+// the last dot. It writes the error and returns false when the format is not supported.
+func restFile(w http.ResponseWriter, file string) (string, string, bool) {
+	i := strings.LastIndexByte(file, '.')
+	param, format := file, ""
+	if i >= 0 {
+		param, format = file[:i], file[i+1:]
+	}
+	if _, ok := contentTypes[format]; !ok {
+		restError(w, http.StatusNotFound, "output format not found (available: .bin, .hex, .json)")
+		return "", "", false
+	}
+	return param, format, true
+}
+
+// writeRaw writes the data in binary or in hex, the hex is followed by a new line as in Bitcoin Core.
+func writeRaw(w http.ResponseWriter, format string, data []byte) {
+	if format == formatHex {
+		data = append([]byte(hex.EncodeToString(data)), '\n')
+	}
+	w.Header().Set("Content-Type", contentTypes[format])
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ifNoneMatch returns true when the request has the ETag in If-None-Match, so the cached response is valid.
+func ifNoneMatch(r *http.Request, etag string) bool {
+	for _, tag := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func restBlockError(w http.ResponseWriter, hashStr string, err error) {
+	switch {
+	case errors.Is(err, sync.ErrPruned):
+		restError(w, http.StatusNotFound, hashStr+" not available (pruned data)")
+	case errors.Is(err, sync.ErrNotFound):
+		restError(w, http.StatusNotFound, hashStr+" not found")
+	default:
+		restInternalError(w, err)
+	}
+}
+
+func restInternalError(w http.ResponseWriter, err error) {
+	log.Println("REST request failed:", err)
+	restError(w, http.StatusInternalServerError, "Internal error")
+}
+
+// restError writes the error as text, the errors are not cached because the missing blocks can be
+// downloaded later.
+func restError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	w.Write([]byte(msg + "\r\n"))
+}
@@ -0,0 +1,213 @@
+package rpc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/db"
+	wire "github.com/EmilGeorgiev/btc-node/network/binary"
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/EmilGeorgiev/btc-node/sync"
+	"github.com/stretchr/testify/require"
+)
+
+// genesisHeaderHex is the serialized header of the mainnet genesis block.
+const genesisHeaderHex = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f61" +
+	"7fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+
+func get(s http.Handler, path string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func rawHeaders(t *testing.T, blocks ...p2p.MsgBlock) []byte {
+	var raw []byte
+	for _, b := range blocks {
+		header, err := wire.Marshal(b.BlockHeader)
+		require.NoError(t, err)
+		raw = append(raw, header[:80]...)
+	}
+	return raw
+}
+
+func TestREST_Block(t *testing.T) {
+	tc := newTestChain(t)
+	hash := hashHex(tc.b2.GetHash())
+	raw, err := wire.Marshal(tc.b2)
+	require.NoError(t, err)
+
+	// the block is streamed from the disk without authentication
+	tc.rawBlocks.EXPECT().OpenBlock(tc.b2.GetHash()).Return(io.NopCloser(bytes.NewReader(raw)), int64(len(raw)), nil).Times(1)
+	rec := get(tc.server, "/rest/block/"+hash+".bin")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	require.Equal(t, strconv.Itoa(len(raw)), rec.Header().Get("Content-Length"))
+	require.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"))
+	require.Equal(t, `"`+hash+`"`, rec.Header().Get("ETag"))
+	require.Equal(t, raw, rec.Body.Bytes())
+
+	tc.rawBlocks.EXPECT().OpenBlock(tc.b2.GetHash()).Return(io.NopCloser(bytes.NewReader(raw)), int64(len(raw)), nil).Times(1)
+	rec = get(tc.server, "/rest/block/"+hash+".hex")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+	require.Equal(t, hex.EncodeToString(raw)+"\n", rec.Body.String())
+	require.Equal(t, strconv.Itoa(rec.Body.Len()), rec.Header().Get("Content-Length"))
+
+	// the cached block is still valid
+	tc.rawBlocks.EXPECT().OpenBlock(tc.b2.GetHash()).Return(io.NopCloser(bytes.NewReader(raw)), int64(len(raw)), nil).Times(1)
+	rec = get(tc.server, "/rest/block/"+hash+".bin", "If-None-Match", `"`+hash+`"`)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.Bytes())
+
+	tc.undo.EXPECT().SpentOutputs(tc.b2.GetHash()).Return([]db.SpentOutput{{UTXO: db.UTXO{Value: 10_000}}}, nil).Times(1)
+	rec = get(tc.server, "/rest/block/"+hash+".json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, "public, max-age=10", rec.Header().Get("Cache-Control"))
+	var block struct {
+		Hash          string
+		Confirmations int
+		Tx            []struct{ Fee json.Number }
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &block))
+	require.Equal(t, hash, block.Hash)
+	require.Equal(t, 1, block.Confirmations)
+	require.Len(t, block.Tx, 2)
+	require.Equal(t, json.Number("0.00001000"), block.Tx[1].Fee)
+
+	// the genesis block is not stored
+	rec = get(tc.server, "/rest/block/"+hashHex(sync.GenesisBlockHash)+".hex")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "04ffff001d0104455468652054696d6573")
+}
+
+func TestREST_BlockErrors(t *testing.T) {
+	tc := newTestChain(t)
+	b3 := hashHex(tc.b3.GetHash())
+	f2 := hashHex(tc.f2.GetHash())
+	tc.rawBlocks.EXPECT().OpenBlock(tc.b3.GetHash()).Return(nil, int64(0), sync.ErrNotFound).Times(1)
+	tc.rawBlocks.EXPECT().OpenBlock(tc.f2.GetHash()).Return(nil, int64(0), sync.ErrPruned).Times(1)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		body   string
+	}{
+		{"invalid hash", "/rest/block/00ff.bin", http.StatusBadRequest, "Invalid hash: 00ff\r\n"},
+		{"unknown block", "/rest/block/" + hashHex([32]byte{1}) + ".bin", http.StatusNotFound, hashHex([32]byte{1}) + " not found\r\n"},
+		{"not downloaded block", "/rest/block/" + b3 + ".bin", http.StatusNotFound, b3 + " not found\r\n"},
+		{"pruned block", "/rest/block/" + f2 + ".hex", http.StatusNotFound, f2 + " not available (pruned data)\r\n"},
+		{"not downloaded block in json", "/rest/block/" + b3 + ".json", http.StatusNotFound, b3 + " not found\r\n"},
+		{"unknown format", "/rest/block/" + b3 + ".txt", http.StatusNotFound, "output format not found (available: .bin, .hex, .json)\r\n"},
+		{"missing format", "/rest/block/" + b3, http.StatusNotFound, "output format not found (available: .bin, .hex, .json)\r\n"},
+		{"unknown endpoint", "/rest/tx/" + b3 + ".bin", http.StatusNotFound, "not found\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(tc.server, tt.path)
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, tt.body, rec.Body.String())
+			require.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+			require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		})
+	}
+
+	// a cached block that is pruned since then is not reported as not modified
+	tc.rawBlocks.EXPECT().OpenBlock(tc.f2.GetHash()).Return(nil, int64(0), sync.ErrPruned).Times(1)
+	rec := get(tc.server, "/rest/block/"+f2+".bin", "If-None-Match", `"`+f2+`"`)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, f2+" not available (pruned data)\r\n", rec.Body.String())
+
+	// the REST interface is read only, the other requests are sent to the JSON-RPC handler only on "/"
+	req := httptest.NewRequest(http.MethodPost, "/rest/block/"+b3+".bin", nil)
+	rec = httptest.NewRecorder()
+	tc.server.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+}
+
+func TestREST_Headers(t *testing.T) {
+	tc := newTestChain(t)
+	b1 := hashHex(tc.b1.GetHash())
+
+	rec := get(tc.server, "/rest/headers/5/"+b1+".bin")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	require.Equal(t, "public, max-age=10", rec.Header().Get("Cache-Control"))
+	// the header b3 is not returned, because its block is not connected
+	require.Equal(t, rawHeaders(t, tc.b1, tc.b2), rec.Body.Bytes())
+
+	rec = get(tc.server, "/rest/headers/"+hashHex(sync.GenesisBlockHash)+".hex?count=2")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, genesisHeaderHex+hex.EncodeToString(rawHeaders(t, tc.b1))+"\n", rec.Body.String())
+
+	rec = get(tc.server, "/rest/headers/1/"+b1+".json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var headers []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &headers))
+	require.Len(t, headers, 1)
+	require.Equal(t, b1, headers[0]["hash"])
+	require.Equal(t, float64(2), headers[0]["confirmations"])
+
+	// the blocks that are not on the active chain have no headers
+	rec = get(tc.server, "/rest/headers/5/"+hashHex(tc.f2.GetHash())+".json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `[]`, rec.Body.String())
+	rec = get(tc.server, "/rest/headers/5/"+hashHex([32]byte{1})+".bin")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Body.Bytes())
+
+	for _, count := range []string{"0", "2001", "x"} {
+		rec = get(tc.server, "/rest/headers/"+count+"/"+b1+".bin")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, "Header count is invalid or out of acceptable range (1-2000): "+count+"\r\n", rec.Body.String())
+	}
+}
+
+func TestREST_BlockHashByHeightAndChainInfo(t *testing.T) {
+	tc := newTestChain(t)
+	hash := tc.b1.GetHash()
+
+	rec := get(tc.server, "/rest/blockhashbyheight/1.bin")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "public, max-age=10", rec.Header().Get("Cache-Control"))
+	require.Equal(t, hash[:], rec.Body.Bytes())
+
+	rec = get(tc.server, "/rest/blockhashbyheight/1.hex")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, hashHex(hash)+"\n", rec.Body.String())
+
+	rec = get(tc.server, "/rest/blockhashbyheight/1.json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"blockhash":"`+hashHex(hash)+`"}`, rec.Body.String())
+
+	rec = get(tc.server, "/rest/blockhashbyheight/3.json")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "Block height out of range\r\n", rec.Body.String())
+
+	rec = get(tc.server, "/rest/blockhashbyheight/-1.json")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "Invalid height: -1\r\n", rec.Body.String())
+
+	rec = get(tc.server, "/rest/chaininfo.json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	require.Equal(t, "main", info["chain"])
+	require.Equal(t, float64(2), info["blocks"])
+	require.Equal(t, hashHex(tc.b2.GetHash()), info["bestblockhash"])
+}
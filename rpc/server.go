@@ -0,0 +1,412 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/sync"
+)
+
+const (
+	// cookieUser is the user name of the cookie authentication, the password is generated on every start.
+	cookieUser = "__cookie__"
+	// authFailureDelay slows down the guessing of the password.
+	authFailureDelay = 250 * time.Millisecond
+	// maxRequestSize is the maximum size of the body of a request.
+	maxRequestSize = 32 << 20
+)
+
+// The error codes of Bitcoin Core, the clients of bitcoind depend on them.
+const (
+	ErrCodeInvalidRequest      = -32600
+	ErrCodeMethodNotFound      = -32601
+	ErrCodeInternal            = -32603
+	ErrCodeParse               = -32700
+	ErrCodeMisc                = -1
+	ErrCodeType                = -3
+	ErrCodeInvalidAddressKey   = -5
+	ErrCodeInvalidParameter    = -8
+	ErrCodeDeserialization     = -22
+	ErrCodeTransaction         = -25
+	ErrCodeTransactionRejected = -26
+)
+
+// Error is a JSON-RPC error with the code that Bitcoin Core returns for it.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+func newError(code int, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// request is a JSON-RPC 1.0 or 2.0 request. The parameters are an array or an object with named parameters.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// response is the reply to a JSON-RPC 1.0 request, the result and the error are always present.
+type response struct {
+	Result any             `json:"result"`
+	Error  *Error          `json:"error"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// responseV2 is the reply to a JSON-RPC 2.0 request, it has either a result or an error.
+type responseV2 struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// method is an RPC method with the names of its parameters, the named parameters are passed in this order.
+type method struct {
+	params  []string
+	handler func(s *Server, params []json.RawMessage) (any, error)
+}
+
+// methods are the supported RPC methods by their names.
+var methods = map[string]method{
+	"getblockchaininfo":  {handler: (*Server).getBlockchainInfo},
+	"getblockcount":      {handler: (*Server).getBlockCount},
+	"getbestblockhash":   {handler: (*Server).getBestBlockHash},
+	"getblockhash":       {params: []string{"height"}, handler: (*Server).getBlockHash},
+	"getblock":           {params: []string{"blockhash", "verbosity"}, handler: (*Server).getBlock},
+	"getblockheader":     {params: []string{"blockhash", "verbose"}, handler: (*Server).getBlockHeader},
+	"getchaintips":       {handler: (*Server).getChainTips},
+	"getpeerinfo":        {handler: (*Server).getPeerInfo},
+	"sendrawtransaction": {params: []string{"hexstring", "maxfeerate"}, handler: (*Server).sendRawTransaction},
+}
+
+// Server is an HTTP JSON-RPC server that is compatible with the RPC interface of Bitcoin Core, so the
+// tools and the libraries that are written for bitcoind can be used with the node. The requests are
+// authenticated with HTTP basic auth with the configured user and password, or with the cookie that is
+// written in the cookie file when no password is configured.
+type Server struct {
+	network  string
+	addr     string
+	user     string
+	password string
+	// cookiePath is the file in which the cookie is written, cookie is its password
+	cookiePath string
+	cookie     string
+	// pruneTarget is the size in MiB of the stored blocks in pruned mode, 0 when the node is not pruned
+	pruneTarget uint64
+	// ibdDone is true after the node leaves the initial block download
+	ibdDone atomic.Bool
+
+	chain chain
+	// rawBlocks is nil when the blocks are not stored in flat files
+	rawBlocks   RawBlockReader
+	undo        UndoStore
+	peers       Peers
+	feeFilters  FeeFilters
+	broadcaster TxBroadcaster
+
+	mux        *http.ServeMux
+	httpServer *http.Server
+	isStarted  atomic.Bool
+}
+
+// NewServer creates a Server that listens on addr. The cookie is used when the password is empty. When rest
+// is true the REST interface is served too, rb can be nil.
+func NewServer(network, addr, user, password, cookiePath string, pruneTarget uint64, rest bool, br sync.BlockRepository,
+	rb RawBlockReader, hi HeaderIndex, cs ChainState, us UndoStore, peers Peers, ff FeeFilters, tb TxBroadcaster) *Server {
+	s := &Server{
+		network:     network,
+		addr:        addr,
+		user:        user,
+		password:    password,
+		cookiePath:  cookiePath,
+		pruneTarget: pruneTarget,
+		chain:       chain{blocks: br, headers: hi, state: cs},
+		rawBlocks:   rb,
+		undo:        us,
+		peers:       peers,
+		feeFilters:  ff,
+		broadcaster: tb,
+		mux:         http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/", s.handleRPC)
+	if rest {
+		s.registerREST()
+	}
+	return s
+}
+
+// Start writes the cookie file when no password is configured and starts listening for requests.
+func (s *Server) Start() error {
+	if s.isStarted.Load() {
+		log.Println("RPC server is already started.")
+		return nil
+	}
+
+	if s.password == "" {
+		if err := s.writeCookie(); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		s.removeCookie()
+		return fmt.Errorf("failed to listen for RPC requests on %s: %w", s.addr, err)
+	}
+	s.httpServer = &http.Server{Handler: s, ReadHeaderTimeout: 30 * time.Second}
+	s.isStarted.Store(true)
+	go func() {
+		if err := s.httpServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Println("RPC server stopped:", err)
+		}
+	}()
+	log.Printf("Start RPC server on %s.\n", ln.Addr())
+	return nil
+}
+
+// Stop closes the listener and the connections and deletes the cookie file.
+func (s *Server) Stop() {
+	if !s.isStarted.Load() {
+		log.Println("Can't stop RPC server because it is not started.")
+		return
+	}
+	s.isStarted.Store(false)
+	if err := s.httpServer.Close(); err != nil {
+		log.Println("failed to close the RPC server:", err)
+	}
+	s.removeCookie()
+	log.Println("Stop RPC server.")
+}
+
+// ServeHTTP implements http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// writeCookie generates a random password and writes it in the cookie file as "__cookie__:password". Only
+// the owner can read the file.
+func (s *Server) writeCookie() error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	s.cookie = hex.EncodeToString(b)
+
+	tmp := s.cookiePath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(cookieUser+":"+s.cookie), 0600); err != nil {
+		return fmt.Errorf("failed to write the RPC cookie file: %w", err)
+	}
+	return os.Rename(tmp, s.cookiePath)
+}
+
+func (s *Server) removeCookie() {
+	if s.cookie == "" {
+		return
+	}
+	if err := os.Remove(s.cookiePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Println("failed to delete the RPC cookie file:", err)
+	}
+}
+
+// authorized returns true when the request has the credentials of the configured user or the cookie.
+func (s *Server) authorized(r *http.Request) bool {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Basic ")
+	if !ok {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(auth))
+	if err != nil {
+		return false
+	}
+
+	if s.password != "" && secureEqual(string(decoded), s.user+":"+s.password) {
+		return true
+	}
+	return s.cookie != "" && secureEqual(string(decoded), cookieUser+":"+s.cookie)
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// handleRPC handles a single request or a batch of requests. The HTTP status codes of the errors of the
+// JSON-RPC 1.0 requests are the same as in Bitcoin Core, the JSON-RPC 2.0 errors are returned with 200.
+func (s *Server) handleRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "JSONRPC server handles only POST requests", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		log.Printf("incorrect RPC password from %s\n", r.RemoteAddr)
+		time.Sleep(authFailureDelay)
+		w.Header().Set("WWW-Authenticate", `Basic realm="jsonrpc"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, response{Error: newError(ErrCodeParse, "Parse error")})
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err = json.Unmarshal(body, &batch); err != nil {
+			writeJSON(w, http.StatusInternalServerError, response{Error: newError(ErrCodeParse, "Parse error")})
+			return
+		}
+		replies := make([]any, 0, len(batch))
+		for _, raw := range batch {
+			if reply, _, notification := s.handleRequest(raw); !notification {
+				replies = append(replies, reply)
+			}
+		}
+		writeJSON(w, http.StatusOK, replies)
+		return
+	}
+
+	reply, status, notification := s.handleRequest(body)
+	if notification {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, status, reply)
+}
+
+// handleRequest calls the method of the request and returns the reply with its HTTP status. It returns true
+// for a JSON-RPC 2.0 notification, which has no reply.
+func (s *Server) handleRequest(raw json.RawMessage) (any, int, bool) {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return response{Error: newError(ErrCodeParse, "Parse error")}, http.StatusInternalServerError, false
+	}
+
+	result, err := s.call(req)
+	rpcErr := toError(err)
+	if req.JSONRPC == "2.0" {
+		if req.ID == nil {
+			return nil, http.StatusNoContent, true
+		}
+		return responseV2{JSONRPC: "2.0", Result: result, Error: rpcErr, ID: req.ID}, http.StatusOK, false
+	}
+
+	status := http.StatusOK
+	switch {
+	case rpcErr == nil:
+	case rpcErr.Code == ErrCodeInvalidRequest:
+		status = http.StatusBadRequest
+	case rpcErr.Code == ErrCodeMethodNotFound:
+		status = http.StatusNotFound
+	default:
+		status = http.StatusInternalServerError
+	}
+	return response{Result: result, Error: rpcErr, ID: req.ID}, status, false
+}
+
+// call validates the request and calls its method with the positional parameters.
+func (s *Server) call(req request) (any, error) {
+	if req.Method == "" {
+		return nil, newError(ErrCodeInvalidRequest, "Method must be a string")
+	}
+	m, ok := methods[req.Method]
+	if !ok {
+		return nil, newError(ErrCodeMethodNotFound, "Method not found")
+	}
+
+	params, err := positionalParams(m, req.Params)
+	if err != nil {
+		return nil, err
+	}
+	return m.handler(s, params)
+}
+
+// positionalParams returns the parameters of the request in the order of the method's parameters. The
+// missing parameters are nil.
+func positionalParams(m method, raw json.RawMessage) ([]json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	if raw[0] == '{' {
+		var named map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &named); err != nil {
+			return nil, newError(ErrCodeInvalidRequest, "Params must be an array or object")
+		}
+		params := make([]json.RawMessage, len(m.params))
+		for name, value := range named {
+			i := indexOf(m.params, name)
+			if i < 0 {
+				return nil, newError(ErrCodeInvalidParameter, "Unknown named parameter %s", name)
+			}
+			params[i] = value
+		}
+		return params, nil
+	}
+
+	var params []json.RawMessage
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, newError(ErrCodeInvalidRequest, "Params must be an array or object")
+	}
+	if len(params) > len(m.params) {
+		return nil, newError(ErrCodeMisc, "too many parameters, the method takes at most %d", len(m.params))
+	}
+	return params, nil
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// toError converts the error of a method to a JSON-RPC error, the unexpected errors are internal errors.
+func toError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	return newError(ErrCodeInternal, "%s", err)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Println("failed to encode the RPC reply:", err)
+		status = http.StatusInternalServerError
+		b, _ = json.Marshal(response{Error: newError(ErrCodeInternal, "%s", err)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(b, '\n'))
+}
@@ -0,0 +1,203 @@
+package rpc_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EmilGeorgiev/btc-node/db"
+	"github.com/EmilGeorgiev/btc-node/node"
+	"github.com/EmilGeorgiev/btc-node/rpc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func post(s http.Handler, body string, user, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Authentication(t *testing.T) {
+	tc := newTestChain(t)
+	body := `{"method":"getblockcount","id":1}`
+
+	rec := post(tc.server, body, "", "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, `Basic realm="jsonrpc"`, rec.Header().Get("WWW-Authenticate"))
+
+	rec = post(tc.server, body, "user", "wrong")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = post(tc.server, body, "user", "pass")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"result":2,"error":null,"id":1}`, rec.Body.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("user", "pass")
+	rec = httptest.NewRecorder()
+	tc.server.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServer_Cookie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cookiePath := filepath.Join(t.TempDir(), ".cookie")
+	state := rpc.NewMockChainState(ctrl)
+	state.EXPECT().BestBlock().Return([32]byte{}, int32(0), nil).AnyTimes()
+	s := rpc.NewServer("mainnet", "127.0.0.1:0", "", "", cookiePath, 0, false, db.NewMemoryBlockRepo(),
+		nil, rpc.NewMockHeaderIndex(ctrl), state, rpc.NewMockUndoStore(ctrl), rpc.NewMockPeers(ctrl), rpc.NewMockFeeFilters(ctrl),
+		rpc.NewMockTxBroadcaster(ctrl))
+
+	require.NoError(t, s.Start())
+	cookie, err := os.ReadFile(cookiePath)
+	require.NoError(t, err)
+	info, err := os.Stat(cookiePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	user, password, ok := strings.Cut(string(cookie), ":")
+	require.True(t, ok)
+	require.Equal(t, "__cookie__", user)
+	require.Len(t, password, 64)
+
+	rec := post(s, `{"method":"getblockcount","id":1}`, user, password)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = post(s, `{"method":"getblockcount","id":1}`, "", ":")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	s.Stop()
+	require.NoFileExists(t, cookiePath)
+}
+
+func TestServer_Protocol(t *testing.T) {
+	tc := newTestChain(t)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		reply  string
+	}{
+		{
+			name:   "method not found",
+			body:   `{"method":"getnothing","id":"a"}`,
+			status: http.StatusNotFound,
+			reply:  `{"result":null,"error":{"code":-32601,"message":"Method not found"},"id":"a"}`,
+		},
+		{
+			name:   "parse error",
+			body:   `{"method":`,
+			status: http.StatusInternalServerError,
+			reply:  `{"result":null,"error":{"code":-32700,"message":"Parse error"},"id":null}`,
+		},
+		{
+			name:   "error of a method",
+			body:   `{"method":"getblockhash","params":[-1],"id":1}`,
+			status: http.StatusInternalServerError,
+			reply:  `{"result":null,"error":{"code":-8,"message":"Block height out of range"},"id":1}`,
+		},
+		{
+			name:   "missing parameter",
+			body:   `{"method":"getblockhash","id":1}`,
+			status: http.StatusInternalServerError,
+			reply:  `{"result":null,"error":{"code":-1,"message":"missing required parameter height"},"id":1}`,
+		},
+		{
+			name:   "named parameters",
+			body:   `{"method":"getblockhash","params":{"height":0},"id":1}`,
+			status: http.StatusOK,
+			reply:  `{"result":"000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f","error":null,"id":1}`,
+		},
+		{
+			name:   "unknown named parameter",
+			body:   `{"method":"getblockhash","params":{"hight":0},"id":1}`,
+			status: http.StatusInternalServerError,
+			reply:  `{"result":null,"error":{"code":-8,"message":"Unknown named parameter hight"},"id":1}`,
+		},
+		{
+			name:   "JSON-RPC 2.0 error",
+			body:   `{"jsonrpc":"2.0","method":"getnothing","id":1}`,
+			status: http.StatusOK,
+			reply:  `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`,
+		},
+		{
+			name:   "JSON-RPC 2.0 result",
+			body:   `{"jsonrpc":"2.0","method":"getblockcount","id":1}`,
+			status: http.StatusOK,
+			reply:  `{"jsonrpc":"2.0","result":2,"id":1}`,
+		},
+		{
+			name:   "batch",
+			body:   `[{"method":"getblockcount","id":1},{"jsonrpc":"2.0","method":"getblockcount"},{"method":"getnothing","id":2}]`,
+			status: http.StatusOK,
+			reply: `[{"result":2,"error":null,"id":1},
+				{"result":null,"error":{"code":-32601,"message":"Method not found"},"id":2}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(tc.server, tt.body, "user", "pass")
+			require.Equal(t, tt.status, rec.Code)
+			require.JSONEq(t, tt.reply, rec.Body.String())
+		})
+	}
+
+	rec := post(tc.server, `{"jsonrpc":"2.0","method":"getblockcount"}`, "user", "pass")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Empty(t, rec.Body.String())
+}
+
+func TestServer_GetPeerInfo(t *testing.T) {
+	tc := newTestChain(t)
+	connTime := time.Unix(1_700_000_000, 0)
+	tc.peers.EXPECT().PeerInfo().Return([]node.PeerInfo{
+		{
+			ID:            1,
+			Addr:          "203.0.113.5:8333",
+			Services:      1 | 8 | 1024,
+			Version:       70016,
+			UserAgent:     "/Satoshi:27.0.0/",
+			StartHeight:   1,
+			TipHeight:     3,
+			BytesSent:     100,
+			BytesReceived: 200,
+			ConnTime:      connTime,
+			RelayTxes:     true,
+		},
+	}).Times(1)
+	tc.feeFilters.EXPECT().FeeFilter("203.0.113.5:8333").Return(int64(1000)).Times(1)
+
+	rec := post(tc.server, `{"method":"getpeerinfo","id":1}`, "user", "pass")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"result":[{
+		"id": 1,
+		"addr": "203.0.113.5:8333",
+		"network": "ipv4",
+		"services": "0000000000000409",
+		"servicesnames": ["NETWORK", "WITNESS", "NETWORK_LIMITED"],
+		"relaytxes": true,
+		"bytessent": 100,
+		"bytesrecv": 200,
+		"conntime": 1700000000,
+		"version": 70016,
+		"subver": "/Satoshi:27.0.0/",
+		"inbound": false,
+		"startingheight": 1,
+		"synced_headers": 3,
+		"synced_blocks": 2,
+		"minfeefilter": 0.00001000,
+		"connection_type": "outbound-full-relay",
+		"transport_protocol_type": "v1"
+	}],"error":null,"id":1}`, rec.Body.String())
+}
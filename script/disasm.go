@@ -0,0 +1,102 @@
+package script
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// opNames are the names of the opcodes after OP_16 in the order of their values, as in Bitcoin Core.
+var opNames = []string{
+	"OP_NOP", "OP_VER", "OP_IF", "OP_NOTIF", "OP_VERIF", "OP_VERNOTIF", "OP_ELSE", "OP_ENDIF", "OP_VERIFY", "OP_RETURN",
+	"OP_TOALTSTACK", "OP_FROMALTSTACK", "OP_2DROP", "OP_2DUP", "OP_3DUP", "OP_2OVER", "OP_2ROT", "OP_2SWAP", "OP_IFDUP",
+	"OP_DEPTH", "OP_DROP", "OP_DUP", "OP_NIP", "OP_OVER", "OP_PICK", "OP_ROLL", "OP_ROT", "OP_SWAP", "OP_TUCK", "OP_CAT",
+	"OP_SUBSTR", "OP_LEFT", "OP_RIGHT", "OP_SIZE", "OP_INVERT", "OP_AND", "OP_OR", "OP_XOR", "OP_EQUAL", "OP_EQUALVERIFY",
+	"OP_RESERVED1", "OP_RESERVED2", "OP_1ADD", "OP_1SUB", "OP_2MUL", "OP_2DIV", "OP_NEGATE", "OP_ABS", "OP_NOT",
+	"OP_0NOTEQUAL", "OP_ADD", "OP_SUB", "OP_MUL", "OP_DIV", "OP_MOD", "OP_LSHIFT", "OP_RSHIFT", "OP_BOOLAND", "OP_BOOLOR",
+	"OP_NUMEQUAL", "OP_NUMEQUALVERIFY", "OP_NUMNOTEQUAL", "OP_LESSTHAN", "OP_GREATERTHAN", "OP_LESSTHANOREQUAL",
+	"OP_GREATERTHANOREQUAL", "OP_MIN", "OP_MAX", "OP_WITHIN", "OP_RIPEMD160", "OP_SHA1", "OP_SHA256", "OP_HASH160",
+	"OP_HASH256", "OP_CODESEPARATOR", "OP_CHECKSIG", "OP_CHECKSIGVERIFY", "OP_CHECKMULTISIG", "OP_CHECKMULTISIGVERIFY",
+	"OP_NOP1", "OP_CHECKLOCKTIMEVERIFY", "OP_CHECKSEQUENCEVERIFY", "OP_NOP4", "OP_NOP5", "OP_NOP6", "OP_NOP7", "OP_NOP8",
+	"OP_NOP9", "OP_NOP10", "OP_CHECKSIGADD",
+}
+
+// sigHashNames are the names of the hash types that are shown after the signatures of the input scripts.
+var sigHashNames = map[byte]string{
+	0x01: "ALL",
+	0x02: "NONE",
+	0x03: "SINGLE",
+	0x81: "ALL|ANYONECANPAY",
+	0x82: "NONE|ANYONECANPAY",
+	0x83: "SINGLE|ANYONECANPAY",
+}
+
+// Disassemble returns the script in the asm format of Bitcoin Core: the pushes of up to 4 bytes are numbers, the
+// longer pushes are hex and the other opcodes are their names. When decodeSigHash is true the hash type of the
+// pushed signatures is shown by its name, like in the input scripts. A push that exceeds the script ends with
+// "[error]".
+func Disassemble(script []byte, decodeSigHash bool) string {
+	var sb strings.Builder
+	unspendable := len(script) > 0 && script[0] == opReturn
+	for pc := 0; pc < len(script); {
+		if pc > 0 {
+			sb.WriteByte(' ')
+		}
+		op, data, next, ok := parseOp(script, pc)
+		if !ok {
+			sb.WriteString("[error]")
+			break
+		}
+		pc = next
+
+		switch {
+		case op <= opPushData4 && len(data) <= 4:
+			sb.WriteString(strconv.FormatInt(scriptNum(data), 10))
+		case op <= opPushData4:
+			sb.WriteString(pushString(data, decodeSigHash && !unspendable))
+		case op == op1Negate:
+			sb.WriteString("-1")
+		case op == opReserved:
+			sb.WriteString("OP_RESERVED")
+		case op >= op1 && op <= op16:
+			sb.WriteString(strconv.Itoa(int(op-op1) + 1))
+		case int(op-opNop) < len(opNames):
+			sb.WriteString(opNames[op-opNop])
+		default:
+			sb.WriteString("OP_UNKNOWN")
+		}
+	}
+	return sb.String()
+}
+
+// pushString returns the pushed data in hex. A signature is shown without its hash type, whose name follows it
+// in brackets.
+func pushString(data []byte, decodeSigHash bool) string {
+	if decodeSigHash && isValidSignatureEncoding(data) {
+		if name, ok := sigHashNames[data[len(data)-1]]; ok {
+			return hex.EncodeToString(data[:len(data)-1]) + "[" + name + "]"
+		}
+	}
+	return hex.EncodeToString(data)
+}
+
+// scriptNum decodes the little endian number with a sign bit, the encoding doesn't have to be minimal.
+func scriptNum(b []byte) int64 {
+	if len(b) == 0 {
+		return 0
+	}
+	var n int64
+	for i, v := range b {
+		n |= int64(v) << (8 * i)
+	}
+	if b[len(b)-1]&0x80 != 0 {
+		return -(n &^ (int64(0x80) << (8 * (len(b) - 1))))
+	}
+	return n
+}
+
+// IsValidPubKey returns true for a compressed or uncompressed public key that is a point of the curve.
+func IsValidPubKey(b []byte) bool {
+	_, ok := parsePubKey(b)
+	return ok
+}
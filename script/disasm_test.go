@@ -0,0 +1,47 @@
+package script
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	require.Len(t, opNames, opCheckSigAdd-opNop+1)
+
+	raw, err := hex.DecodeString(legacyTx)
+	require.NoError(t, err)
+	var tx p2p.MsgTx
+	require.NoError(t, tx.UnmarshalBinary(bytes.NewReader(raw)))
+
+	// the hash type of the signature is decoded only in the input scripts
+	require.Equal(t, "304402203c6ef3cba423365b37c031d235a674a10cf06b14fccda68bb5c35cbda5a2969b02207da3f69ea61c4a98eb488dac9d8a"+
+		"421dda9000e8afdc4a90cc2ebf93fbefb84f[ALL] 02e248c2b8e9a5b78f2406c60b75ef1c4e88a06c7c36ad31e009db256505e27e79",
+		Disassemble(tx.TxIn[0].SignatureScript, true))
+	require.Equal(t, "304402203c6ef3cba423365b37c031d235a674a10cf06b14fccda68bb5c35cbda5a2969b02207da3f69ea61c4a98eb488dac9d8a"+
+		"421dda9000e8afdc4a90cc2ebf93fbefb84f01 02e248c2b8e9a5b78f2406c60b75ef1c4e88a06c7c36ad31e009db256505e27e79",
+		Disassemble(tx.TxIn[0].SignatureScript, false))
+	require.Equal(t, "OP_DUP OP_HASH160 fe46ec55e937e584005b337495d76464b6b1cdba OP_EQUALVERIFY OP_CHECKSIG",
+		Disassemble(tx.TxOut[0].PkScript, false))
+	require.Equal(t, "OP_RETURN 6f6d6e69000000000000001f0000886c98b76000", Disassemble(tx.TxOut[2].PkScript, true))
+
+	tests := []struct {
+		script string
+		asm    string
+	}{
+		{script: "", asm: ""},
+		{script: "00", asm: "0"},
+		{script: "4f516160", asm: "-1 1 OP_NOP 16"},
+		{script: "02e803" + "0181" + "4c0100", asm: "1000 -1 0"},
+		{script: "50b1b2baff", asm: "OP_RESERVED OP_CHECKLOCKTIMEVERIFY OP_CHECKSEQUENCEVERIFY OP_CHECKSIGADD OP_UNKNOWN"},
+		{script: "6a0501", asm: "OP_RETURN [error]"},
+	}
+	for _, test := range tests {
+		s, err := hex.DecodeString(test.script)
+		require.NoError(t, err)
+		require.Equal(t, test.asm, Disassemble(s, false), test.script)
+	}
+}
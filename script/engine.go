@@ -0,0 +1,913 @@
+package script
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	maxScriptSize         = 10_000
+	maxScriptElementSize  = 520
+	maxOpsPerScript       = 201
+	maxStackSize          = 1000
+	maxPubKeysPerMultiSig = 20
+	// maxNumSize is the maximum size of the numbers of the arithmetic opcodes, the lock time opcodes accept 5
+	// bytes numbers.
+	maxNumSize         = 4
+	maxLockTimeNumSize = 5
+
+	// validationWeightPerSigOp is the weight of a signature check in tapscript, a script has a budget of the
+	// size of its witness plus validationWeightOffset (BIP 342).
+	validationWeightPerSigOp = 50
+	validationWeightOffset   = 50
+
+	lockTimeThreshold = 500_000_000
+	// the flags of the relative lock time in the input sequence (BIP 68)
+	sequenceDisableFlag = 1 << 31
+	sequenceTypeFlag    = 1 << 22
+	sequenceMask        = 0x0000ffff
+)
+
+// ErrScriptFailed is returned when the script of an input fails.
+var ErrScriptFailed = errors.New("script failed")
+
+func scriptError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrScriptFailed, fmt.Sprintf(format, args...))
+}
+
+// sigVersion is the version of the script that is executed, it defines the signature hash and the rules
+// of the signature checks.
+type sigVersion int
+
+const (
+	sigVersionBase sigVersion = iota
+	sigVersionWitnessV0
+	sigVersionTapscript
+)
+
+// engine executes the scripts of a transaction input. The scripts are executed with the standard rules of
+// Bitcoin Core, which are stricter than the consensus rules: the encoding of the signatures, the public keys,
+// the pushes and the numbers must be canonical, the failed signatures must be empty and the opcodes and the
+// witness versions that are reserved for the upgrades fail.
+type engine struct {
+	tx  *txContext
+	idx int
+
+	version sigVersion
+	stack   [][]byte
+	alt     [][]byte
+
+	// the tapscript hash, the position of the last executed OP_CODESEPARATOR and the budget of the
+	// signature checks
+	leafHash   [32]byte
+	codeSepPos uint32
+	weightLeft int64
+}
+
+// eval executes the script on the stack of the engine.
+func (e *engine) eval(script []byte) error {
+	if e.version != sigVersionTapscript && len(script) > maxScriptSize {
+		return scriptError("script size %d", len(script))
+	}
+
+	var conds []bool
+	var ops int
+	// the script code that is signed starts after the last executed OP_CODESEPARATOR
+	codeStart := 0
+	e.alt = nil
+	e.codeSepPos = 0xffffffff
+
+	for pc, opIdx := 0, uint32(0); pc < len(script); opIdx++ {
+		op, data, next, ok := parseOp(script, pc)
+		if !ok {
+			return scriptError("bad opcode at %d", pc)
+		}
+		if len(data) > maxScriptElementSize {
+			return scriptError("push size %d", len(data))
+		}
+		if e.version != sigVersionTapscript && op > op16 {
+			if ops++; ops > maxOpsPerScript {
+				return scriptError("too many opcodes")
+			}
+		}
+		if isDisabled(op) {
+			return scriptError("disabled opcode %#x", op)
+		}
+		if op == opCodeSeparator && e.version == sigVersionBase {
+			return scriptError("OP_CODESEPARATOR in a legacy script")
+		}
+
+		executing := !slices.Contains(conds, false)
+		switch {
+		case executing && op <= opPushData4:
+			if !isMinimalPush(op, data) {
+				return scriptError("non-minimal push at %d", pc)
+			}
+			e.push(data)
+		case executing || (op >= opIf && op <= opEndIf):
+			var err error
+			if conds, err = e.execute(op, script, next, opIdx, conds, &ops, &codeStart); err != nil {
+				return err
+			}
+		}
+
+		if len(e.stack)+len(e.alt) > maxStackSize {
+			return scriptError("stack size")
+		}
+		pc = next
+	}
+	if len(conds) != 0 {
+		return scriptError("unbalanced conditional")
+	}
+	return nil
+}
+
+// execute executes the opcode that is not a data push. Next is the position of the next opcode in the script.
+func (e *engine) execute(op byte, script []byte, next int, opIdx uint32, conds []bool, ops *int, codeStart *int) ([]bool, error) {
+	executing := !slices.Contains(conds, false)
+
+	switch {
+	case op == op1Negate || (op >= op1 && op <= op16):
+		e.push(encodeNum(int64(op) - op1 + 1))
+		return conds, nil
+	case op == opCheckLockTimeVerify:
+		return conds, e.checkLockTime()
+	case op == opCheckSequenceVerify:
+		return conds, e.checkSequence()
+	case op == opNop1 || (op >= opNop4 && op <= opNop10):
+		return conds, scriptError("upgradable NOP %#x", op)
+	}
+
+	switch op {
+	case opNop:
+	case opIf, opNotIf:
+		value := false
+		if executing {
+			top, err := e.pop()
+			if err != nil {
+				return nil, err
+			}
+			if e.version != sigVersionBase && (len(top) > 1 || (len(top) == 1 && top[0] != 1)) {
+				return nil, scriptError("OP_IF argument is not minimal")
+			}
+			value = castToBool(top) == (op == opIf)
+		}
+		return append(conds, value), nil
+	case opElse:
+		if len(conds) == 0 {
+			return nil, scriptError("unbalanced conditional")
+		}
+		conds[len(conds)-1] = !conds[len(conds)-1]
+	case opEndIf:
+		if len(conds) == 0 {
+			return nil, scriptError("unbalanced conditional")
+		}
+		return conds[:len(conds)-1], nil
+	case opVerify:
+		return conds, e.verify()
+	case opReturn:
+		return nil, scriptError("OP_RETURN")
+
+	case opToAltStack:
+		v, err := e.pop()
+		if err != nil {
+			return nil, err
+		}
+		e.alt = append(e.alt, v)
+	case opFromAltStack:
+		if len(e.alt) == 0 {
+			return nil, scriptError("empty alt stack")
+		}
+		e.push(e.alt[len(e.alt)-1])
+		e.alt = e.alt[:len(e.alt)-1]
+	case op2Drop:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		e.stack = e.stack[:len(e.stack)-2]
+	case op2Dup:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		e.push(e.top(2))
+		e.push(e.top(2))
+	case op3Dup:
+		if err := e.need(3); err != nil {
+			return nil, err
+		}
+		e.push(e.top(3))
+		e.push(e.top(3))
+		e.push(e.top(3))
+	case op2Over:
+		if err := e.need(4); err != nil {
+			return nil, err
+		}
+		e.push(e.top(4))
+		e.push(e.top(4))
+	case op2Rot:
+		if err := e.need(6); err != nil {
+			return nil, err
+		}
+		n := len(e.stack)
+		a, b := e.stack[n-6], e.stack[n-5]
+		e.stack = append(e.stack[:n-6], e.stack[n-4:]...)
+		e.push(a)
+		e.push(b)
+	case op2Swap:
+		if err := e.need(4); err != nil {
+			return nil, err
+		}
+		n := len(e.stack)
+		e.stack[n-4], e.stack[n-3], e.stack[n-2], e.stack[n-1] = e.stack[n-2], e.stack[n-1], e.stack[n-4], e.stack[n-3]
+	case opIfDup:
+		if err := e.need(1); err != nil {
+			return nil, err
+		}
+		if castToBool(e.top(1)) {
+			e.push(e.top(1))
+		}
+	case opDepth:
+		e.push(encodeNum(int64(len(e.stack))))
+	case opDrop:
+		if _, err := e.pop(); err != nil {
+			return nil, err
+		}
+	case opDup:
+		if err := e.need(1); err != nil {
+			return nil, err
+		}
+		e.push(e.top(1))
+	case opNip:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		n := len(e.stack)
+		e.stack = append(e.stack[:n-2], e.stack[n-1])
+	case opOver:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		e.push(e.top(2))
+	case opPick, opRoll:
+		n, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n >= int64(len(e.stack)) {
+			return nil, scriptError("invalid stack operation")
+		}
+		i := len(e.stack) - 1 - int(n)
+		v := e.stack[i]
+		if op == opRoll {
+			e.stack = append(e.stack[:i], e.stack[i+1:]...)
+		}
+		e.push(v)
+	case opRot:
+		if err := e.need(3); err != nil {
+			return nil, err
+		}
+		n := len(e.stack)
+		e.stack[n-3], e.stack[n-2], e.stack[n-1] = e.stack[n-2], e.stack[n-1], e.stack[n-3]
+	case opSwap:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		n := len(e.stack)
+		e.stack[n-2], e.stack[n-1] = e.stack[n-1], e.stack[n-2]
+	case opTuck:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		n := len(e.stack)
+		top := e.stack[n-1]
+		e.stack = append(e.stack[:n-2], top, e.stack[n-2], top)
+	case opSize:
+		if err := e.need(1); err != nil {
+			return nil, err
+		}
+		e.push(encodeNum(int64(len(e.top(1)))))
+
+	case opEqual, opEqualVerify:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		b, _ := e.pop()
+		a, _ := e.pop()
+		e.push(encodeBool(bytes.Equal(a, b)))
+		if op == opEqualVerify {
+			return conds, e.verify()
+		}
+
+	case op1Add, op1Sub, opNegate, opAbs, opNot, op0NotEqual:
+		n, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case op1Add:
+			n++
+		case op1Sub:
+			n--
+		case opNegate:
+			n = -n
+		case opAbs:
+			n = max(n, -n)
+		case opNot:
+			n = boolNum(n == 0)
+		case op0NotEqual:
+			n = boolNum(n != 0)
+		}
+		e.push(encodeNum(n))
+	case opAdd, opSub, opBoolAnd, opBoolOr, opNumEqual, opNumEqualVerify, opNumNotEqual, opLessThan, opGreaterThan,
+		opLessThanOrEqual, opGreaterThanOrEqual, opMin, opMax:
+		b, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		a, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		var n int64
+		switch op {
+		case opAdd:
+			n = a + b
+		case opSub:
+			n = a - b
+		case opBoolAnd:
+			n = boolNum(a != 0 && b != 0)
+		case opBoolOr:
+			n = boolNum(a != 0 || b != 0)
+		case opNumEqual, opNumEqualVerify:
+			n = boolNum(a == b)
+		case opNumNotEqual:
+			n = boolNum(a != b)
+		case opLessThan:
+			n = boolNum(a < b)
+		case opGreaterThan:
+			n = boolNum(a > b)
+		case opLessThanOrEqual:
+			n = boolNum(a <= b)
+		case opGreaterThanOrEqual:
+			n = boolNum(a >= b)
+		case opMin:
+			n = min(a, b)
+		case opMax:
+			n = max(a, b)
+		}
+		e.push(encodeNum(n))
+		if op == opNumEqualVerify {
+			return conds, e.verify()
+		}
+	case opWithin:
+		hi, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		lo, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		x, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		e.push(encodeBool(lo <= x && x < hi))
+
+	case opRipemd160, opSha1, opSha256, opHash160, opHash256:
+		v, err := e.pop()
+		if err != nil {
+			return nil, err
+		}
+		var h []byte
+		switch op {
+		case opRipemd160:
+			sum := ripemd160(v)
+			h = sum[:]
+		case opSha1:
+			sum := sha1.Sum(v)
+			h = sum[:]
+		case opSha256:
+			sum := sha256.Sum256(v)
+			h = sum[:]
+		case opHash160:
+			sum := hash160(v)
+			h = sum[:]
+		case opHash256:
+			sum := doubleSHA256(v)
+			h = sum[:]
+		}
+		e.push(h)
+	case opCodeSeparator:
+		*codeStart = next
+		e.codeSepPos = opIdx
+
+	case opCheckSig, opCheckSigVerify:
+		if err := e.need(2); err != nil {
+			return nil, err
+		}
+		pubKey, _ := e.pop()
+		sig, _ := e.pop()
+		ok, err := e.checkSig(sig, pubKey, script[*codeStart:])
+		if err != nil {
+			return nil, err
+		}
+		e.push(encodeBool(ok))
+		if op == opCheckSigVerify {
+			return conds, e.verify()
+		}
+	case opCheckSigAdd:
+		if e.version != sigVersionTapscript {
+			return nil, scriptError("bad opcode %#x", op)
+		}
+		if err := e.need(3); err != nil {
+			return nil, err
+		}
+		pubKey, _ := e.pop()
+		n, err := e.popNum(maxNumSize)
+		if err != nil {
+			return nil, err
+		}
+		sig, _ := e.pop()
+		ok, err := e.checkSig(sig, pubKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		e.push(encodeNum(n + boolNum(ok)))
+	case opCheckMultiSig, opCheckMultiSigVerify:
+		if e.version == sigVersionTapscript {
+			return nil, scriptError("OP_CHECKMULTISIG in tapscript")
+		}
+		ok, err := e.checkMultiSig(script[*codeStart:], ops)
+		if err != nil {
+			return nil, err
+		}
+		e.push(encodeBool(ok))
+		if op == opCheckMultiSigVerify {
+			return conds, e.verify()
+		}
+
+	default:
+		return nil, scriptError("bad opcode %#x", op)
+	}
+	return conds, nil
+}
+
+// checkSig checks the signature of the public key with the signature hash of the script version. It returns
+// false for an empty signature, an invalid signature that is not empty fails the script.
+func (e *engine) checkSig(sig, pubKey, scriptCode []byte) (bool, error) {
+	if e.version == sigVersionTapscript {
+		if len(sig) > 0 {
+			if e.weightLeft -= validationWeightPerSigOp; e.weightLeft < 0 {
+				return false, scriptError("tapscript validation weight")
+			}
+		}
+		if len(pubKey) != 32 {
+			// the empty keys fail and the other sizes are reserved for the upgrades
+			return false, scriptError("public key type")
+		}
+		if len(sig) > 0 && !e.checkSchnorr(sig, pubKey, &e.leafHash) {
+			return false, scriptError("schnorr signature")
+		}
+		return len(sig) > 0, nil
+	}
+
+	if e.version == sigVersionBase && containsPush(scriptCode, sig) {
+		return false, scriptError("the signature is in the script code")
+	}
+	if err := e.checkEncoding(sig, pubKey); err != nil {
+		return false, err
+	}
+	ok := e.checkECDSA(sig, pubKey, scriptCode)
+	if !ok && len(sig) > 0 {
+		return false, scriptError("signature doesn't match")
+	}
+	return ok, nil
+}
+
+// checkMultiSig executes OP_CHECKMULTISIG: the signatures are checked with the public keys in order and every
+// signature must match one of the keys after the key of the previous signature.
+func (e *engine) checkMultiSig(scriptCode []byte, ops *int) (bool, error) {
+	i := 1
+	if err := e.need(i); err != nil {
+		return false, err
+	}
+	keys, err := decodeNum(e.top(i), maxNumSize)
+	if err != nil {
+		return false, err
+	}
+	if keys < 0 || keys > maxPubKeysPerMultiSig {
+		return false, scriptError("public keys count %d", keys)
+	}
+	if *ops += int(keys); *ops > maxOpsPerScript {
+		return false, scriptError("too many opcodes")
+	}
+	i++
+	key := i
+	i += int(keys)
+	if err = e.need(i); err != nil {
+		return false, err
+	}
+	sigs, err := decodeNum(e.top(i), maxNumSize)
+	if err != nil {
+		return false, err
+	}
+	if sigs < 0 || sigs > keys {
+		return false, scriptError("signatures count %d", sigs)
+	}
+	i++
+	sig, firstSig, sigsCount := i, i, int(sigs)
+	i += int(sigs)
+	if err = e.need(i); err != nil {
+		return false, err
+	}
+
+	if e.version == sigVersionBase {
+		for k := 0; k < int(sigs); k++ {
+			if containsPush(scriptCode, e.top(sig+k)) {
+				return false, scriptError("the signature is in the script code")
+			}
+		}
+	}
+
+	success := true
+	for success && sigs > 0 {
+		s, k := e.top(sig), e.top(key)
+		if err = e.checkEncoding(s, k); err != nil {
+			return false, err
+		}
+		if e.checkECDSA(s, k, scriptCode) {
+			sig++
+			sigs--
+		}
+		key++
+		keys--
+		// there are more signatures left than keys
+		if sigs > keys {
+			success = false
+		}
+	}
+
+	// the signatures must be empty when the check fails
+	if !success {
+		for k := firstSig; k < firstSig+sigsCount; k++ {
+			if len(e.top(k)) > 0 {
+				return false, scriptError("signature doesn't match")
+			}
+		}
+	}
+	e.stack = e.stack[:len(e.stack)-(i-1)]
+
+	// the extra element that is popped because of a bug in the original client must be empty
+	dummy, err := e.pop()
+	if err != nil {
+		return false, err
+	}
+	if len(dummy) > 0 {
+		return false, scriptError("OP_CHECKMULTISIG dummy is not empty")
+	}
+	return success, nil
+}
+
+// checkEncoding checks that the signature is strictly DER encoded with a low S and a defined hash type, and
+// that the public key is compressed or uncompressed, only compressed keys are used by segwit.
+func (e *engine) checkEncoding(sig, pubKey []byte) error {
+	if len(sig) > 0 {
+		if !isValidSignatureEncoding(sig) {
+			return scriptError("signature encoding")
+		}
+		if _, s, _ := parseDER(sig[:len(sig)-1]); s.Cmp(halfN) > 0 {
+			return scriptError("signature S is not low")
+		}
+		if ht := sig[len(sig)-1] &^ sigHashAnyoneCanPay; ht < sigHashAll || ht > sigHashSingle {
+			return scriptError("signature hash type %#x", sig[len(sig)-1])
+		}
+	}
+
+	switch {
+	case len(pubKey) == 33 && (pubKey[0] == 0x02 || pubKey[0] == 0x03):
+	case len(pubKey) == 65 && pubKey[0] == 0x04 && e.version == sigVersionBase:
+	default:
+		return scriptError("public key encoding")
+	}
+	return nil
+}
+
+func (e *engine) checkECDSA(sig, pubKey, scriptCode []byte) bool {
+	if len(sig) == 0 {
+		return false
+	}
+	hashType := uint32(sig[len(sig)-1])
+	var hash [32]byte
+	if e.version == sigVersionBase {
+		hash = e.tx.legacySigHash(e.idx, scriptCode, hashType)
+	} else {
+		hash = e.tx.witnessV0SigHash(e.idx, scriptCode, hashType)
+	}
+	return verifyECDSA(pubKey, sig[:len(sig)-1], hash)
+}
+
+// checkSchnorr checks the BIP 340 signature of a taproot input, the 64 bytes signatures use SIGHASH_DEFAULT and
+// the 65 bytes ones have an explicit hash type. The leaf hash is nil for the key path spending.
+func (e *engine) checkSchnorr(sig, pubKey []byte, leafHash *[32]byte) bool {
+	hashType := byte(sigHashDefault)
+	switch len(sig) {
+	case 64:
+	case 65:
+		if hashType = sig[64]; hashType == sigHashDefault {
+			return false
+		}
+		sig = sig[:64]
+	default:
+		return false
+	}
+	hash, ok := e.tx.taprootSigHash(e.idx, hashType, leafHash, e.codeSepPos)
+	return ok && verifySchnorr(pubKey, sig, hash)
+}
+
+func (e *engine) checkLockTime() error {
+	if err := e.need(1); err != nil {
+		return err
+	}
+	n, err := decodeNum(e.top(1), maxLockTimeNumSize)
+	if err != nil {
+		return err
+	}
+	lockTime := int64(e.tx.tx.LockTime)
+	switch {
+	case n < 0:
+		return scriptError("negative lock time")
+	case (n < lockTimeThreshold) != (lockTime < lockTimeThreshold):
+		return scriptError("lock time type")
+	case n > lockTime:
+		return scriptError("lock time %d is not reached", n)
+	case e.tx.tx.TxIn[e.idx].Sequence == 0xffffffff:
+		return scriptError("the lock time is disabled by the input sequence")
+	}
+	return nil
+}
+
+func (e *engine) checkSequence() error {
+	if err := e.need(1); err != nil {
+		return err
+	}
+	n, err := decodeNum(e.top(1), maxLockTimeNumSize)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return scriptError("negative sequence")
+	}
+	if n&sequenceDisableFlag != 0 {
+		return nil
+	}
+
+	seq := int64(e.tx.tx.TxIn[e.idx].Sequence)
+	switch {
+	case e.tx.tx.Version < 2:
+		return scriptError("relative lock time with transaction version %d", e.tx.tx.Version)
+	case seq&sequenceDisableFlag != 0:
+		return scriptError("the relative lock time is disabled by the input sequence")
+	case (n & sequenceTypeFlag) != (seq & sequenceTypeFlag):
+		return scriptError("relative lock time type")
+	case n&(sequenceTypeFlag|sequenceMask) > seq&(sequenceTypeFlag|sequenceMask):
+		return scriptError("relative lock time %d is not reached", n)
+	}
+	return nil
+}
+
+func (e *engine) push(v []byte) {
+	e.stack = append(e.stack, v)
+}
+
+func (e *engine) pop() ([]byte, error) {
+	if err := e.need(1); err != nil {
+		return nil, err
+	}
+	v := e.stack[len(e.stack)-1]
+	e.stack = e.stack[:len(e.stack)-1]
+	return v, nil
+}
+
+func (e *engine) popNum(maxSize int) (int64, error) {
+	v, err := e.pop()
+	if err != nil {
+		return 0, err
+	}
+	return decodeNum(v, maxSize)
+}
+
+// top returns the i-th element from the top of the stack, the top one is 1.
+func (e *engine) top(i int) []byte {
+	return e.stack[len(e.stack)-i]
+}
+
+func (e *engine) need(n int) error {
+	if len(e.stack) < n {
+		return scriptError("invalid stack operation")
+	}
+	return nil
+}
+
+func (e *engine) verify() error {
+	v, err := e.pop()
+	if err != nil {
+		return err
+	}
+	if !castToBool(v) {
+		return scriptError("verify failed")
+	}
+	return nil
+}
+
+// parseOp returns the opcode at the position and the data that it pushes, with the position of the next opcode.
+func parseOp(script []byte, pc int) (byte, []byte, int, bool) {
+	op := script[pc]
+	pc++
+
+	var n int
+	switch {
+	case op < opPushData1:
+		n = int(op)
+	case op == opPushData1:
+		if pc+1 > len(script) {
+			return 0, nil, 0, false
+		}
+		n, pc = int(script[pc]), pc+1
+	case op == opPushData2:
+		if pc+2 > len(script) {
+			return 0, nil, 0, false
+		}
+		n, pc = int(script[pc])|int(script[pc+1])<<8, pc+2
+	case op == opPushData4:
+		if pc+4 > len(script) {
+			return 0, nil, 0, false
+		}
+		n, pc = int(script[pc])|int(script[pc+1])<<8|int(script[pc+2])<<16|int(script[pc+3])<<24, pc+4
+	default:
+		return op, nil, pc, true
+	}
+	if n < 0 || pc+n > len(script) {
+		return 0, nil, 0, false
+	}
+	return op, script[pc : pc+n], pc + n, true
+}
+
+// isMinimalPush returns true when the data is pushed by the shortest opcode.
+func isMinimalPush(op byte, data []byte) bool {
+	switch {
+	case len(data) == 0:
+		return op == op0
+	case len(data) == 1 && data[0] >= 1 && data[0] <= 16:
+		return false
+	case len(data) == 1 && data[0] == 0x81:
+		return false
+	case len(data) <= 75:
+		return int(op) == len(data)
+	case len(data) <= 255:
+		return op == opPushData1
+	case len(data) <= 65535:
+		return op == opPushData2
+	}
+	return true
+}
+
+// pushData returns the script that pushes the data with the shortest push opcode that is not a number.
+func pushData(data []byte) []byte {
+	var s []byte
+	switch n := len(data); {
+	case n < opPushData1:
+		s = append(s, byte(n))
+	case n <= 0xff:
+		s = append(s, opPushData1, byte(n))
+	case n <= 0xffff:
+		s = append(s, opPushData2, byte(n), byte(n>>8))
+	default:
+		s = append(s, opPushData4, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+	}
+	return append(s, data...)
+}
+
+// containsPush returns true when the script pushes the data with pushData. The legacy signature hash removes
+// the signatures from the script code, the standard scripts must not contain them.
+func containsPush(script, data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	p := pushData(data)
+	for pc := 0; pc < len(script); {
+		if bytes.HasPrefix(script[pc:], p) {
+			return true
+		}
+		_, _, next, ok := parseOp(script, pc)
+		if !ok {
+			return false
+		}
+		pc = next
+	}
+	return false
+}
+
+// isValidSignatureEncoding returns true for a strictly DER encoded signature followed by the hash type (BIP 66).
+func isValidSignatureEncoding(sig []byte) bool {
+	if len(sig) < 9 || len(sig) > 73 || sig[0] != 0x30 || int(sig[1]) != len(sig)-3 {
+		return false
+	}
+	rLen := int(sig[3])
+	if 5+rLen >= len(sig) {
+		return false
+	}
+	sLen := int(sig[5+rLen])
+	if rLen+sLen+7 != len(sig) {
+		return false
+	}
+
+	if sig[2] != 0x02 || rLen == 0 || sig[4]&0x80 != 0 || (rLen > 1 && sig[4] == 0 && sig[5]&0x80 == 0) {
+		return false
+	}
+	if sig[rLen+4] != 0x02 || sLen == 0 || sig[rLen+6]&0x80 != 0 || (sLen > 1 && sig[rLen+6] == 0 && sig[rLen+7]&0x80 == 0) {
+		return false
+	}
+	return true
+}
+
+// decodeNum decodes the little endian number with a sign bit whose encoding must be minimal.
+func decodeNum(b []byte, maxSize int) (int64, error) {
+	if len(b) > maxSize {
+		return 0, scriptError("number size %d", len(b))
+	}
+	if len(b) > 0 && b[len(b)-1]&0x7f == 0 && (len(b) == 1 || b[len(b)-2]&0x80 == 0) {
+		return 0, scriptError("number is not minimally encoded")
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	var n int64
+	for i, v := range b {
+		n |= int64(v) << (8 * i)
+	}
+	if b[len(b)-1]&0x80 != 0 {
+		return -(n &^ (int64(0x80) << (8 * (len(b) - 1)))), nil
+	}
+	return n, nil
+}
+
+func encodeNum(n int64) []byte {
+	if n == 0 {
+		return nil
+	}
+	neg := n < 0
+	abs := uint64(n)
+	if neg {
+		abs = uint64(-n)
+	}
+
+	var b []byte
+	for ; abs > 0; abs >>= 8 {
+		b = append(b, byte(abs))
+	}
+	switch {
+	case b[len(b)-1]&0x80 != 0 && neg:
+		b = append(b, 0x80)
+	case b[len(b)-1]&0x80 != 0:
+		b = append(b, 0)
+	case neg:
+		b[len(b)-1] |= 0x80
+	}
+	return b
+}
+
+func encodeBool(v bool) []byte {
+	if v {
+		return []byte{1}
+	}
+	return nil
+}
+
+func boolNum(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// castToBool returns false for the zero and the negative zero numbers.
+func castToBool(v []byte) bool {
+	for i, b := range v {
+		if b != 0 {
+			// the negative zero
+			return i != len(v)-1 || b != 0x80
+		}
+	}
+	return false
+}
+
+func hash160(b []byte) [20]byte {
+	h := sha256.Sum256(b)
+	return ripemd160(h[:])
+}
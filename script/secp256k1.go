@@ -0,0 +1,275 @@
+package script
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// the parameters of the secp256k1 curve y² = x³ + 7 over the field of the prime p, whose group of order n is
+// generated by G
+var (
+	curveP  = mustHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	curveN  = mustHex("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	curveGx = mustHex("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	curveGy = mustHex("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+
+	curveG     = affinePoint{x: curveGx, y: curveGy}
+	halfN      = new(big.Int).Rsh(curveN, 1)
+	sqrtExp    = new(big.Int).Rsh(new(big.Int).Add(curveP, big.NewInt(1)), 2)
+	curveSeven = big.NewInt(7)
+)
+
+func mustHex(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex number " + s)
+	}
+	return v
+}
+
+// affinePoint is a point of the curve, nil coordinates are the point at infinity.
+type affinePoint struct {
+	x, y *big.Int
+}
+
+func (a affinePoint) infinity() bool {
+	return a.x == nil
+}
+
+// jacobianPoint is a point in Jacobian coordinates (X/Z², Y/Z³), it is the point at infinity when Z is 0.
+type jacobianPoint struct {
+	x, y, z *big.Int
+}
+
+func toJacobian(a affinePoint) jacobianPoint {
+	if a.infinity() {
+		return jacobianPoint{x: big.NewInt(1), y: big.NewInt(1), z: new(big.Int)}
+	}
+	return jacobianPoint{x: new(big.Int).Set(a.x), y: new(big.Int).Set(a.y), z: big.NewInt(1)}
+}
+
+func (j jacobianPoint) affine() affinePoint {
+	if j.z.Sign() == 0 {
+		return affinePoint{}
+	}
+	zInv := new(big.Int).ModInverse(j.z, curveP)
+	zInv2 := fieldMul(zInv, zInv)
+	return affinePoint{x: fieldMul(j.x, zInv2), y: fieldMul(j.y, fieldMul(zInv2, zInv))}
+}
+
+func fieldMul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Mod(r, curveP)
+}
+
+func fieldSub(a, b *big.Int) *big.Int {
+	r := new(big.Int).Sub(a, b)
+	return r.Mod(r, curveP)
+}
+
+func fieldAdd(a, b *big.Int) *big.Int {
+	r := new(big.Int).Add(a, b)
+	return r.Mod(r, curveP)
+}
+
+func (j jacobianPoint) double() jacobianPoint {
+	if j.z.Sign() == 0 || j.y.Sign() == 0 {
+		return jacobianPoint{x: big.NewInt(1), y: big.NewInt(1), z: new(big.Int)}
+	}
+	a := fieldMul(j.x, j.x)
+	b := fieldMul(j.y, j.y)
+	c := fieldMul(b, b)
+	t := fieldAdd(j.x, b)
+	d := fieldSub(fieldSub(fieldMul(t, t), a), c)
+	d = fieldAdd(d, d)
+	e := fieldAdd(fieldAdd(a, a), a)
+	f := fieldMul(e, e)
+
+	x := fieldSub(f, fieldAdd(d, d))
+	c8 := new(big.Int).Lsh(c, 3)
+	y := fieldSub(fieldMul(e, fieldSub(d, x)), c8)
+	z := fieldMul(j.y, j.z)
+	return jacobianPoint{x: x, y: y, z: fieldAdd(z, z)}
+}
+
+func (j jacobianPoint) add(o jacobianPoint) jacobianPoint {
+	if j.z.Sign() == 0 {
+		return o
+	}
+	if o.z.Sign() == 0 {
+		return j
+	}
+	z1z1 := fieldMul(j.z, j.z)
+	z2z2 := fieldMul(o.z, o.z)
+	u1 := fieldMul(j.x, z2z2)
+	u2 := fieldMul(o.x, z1z1)
+	s1 := fieldMul(j.y, fieldMul(o.z, z2z2))
+	s2 := fieldMul(o.y, fieldMul(j.z, z1z1))
+	if u1.Cmp(u2) == 0 {
+		if s1.Cmp(s2) == 0 {
+			return j.double()
+		}
+		return jacobianPoint{x: big.NewInt(1), y: big.NewInt(1), z: new(big.Int)}
+	}
+
+	h := fieldSub(u2, u1)
+	r := fieldSub(s2, s1)
+	h2 := fieldMul(h, h)
+	h3 := fieldMul(h2, h)
+	v := fieldMul(u1, h2)
+	x := fieldSub(fieldSub(fieldMul(r, r), h3), fieldAdd(v, v))
+	y := fieldSub(fieldMul(r, fieldSub(v, x)), fieldMul(s1, h3))
+	z := fieldMul(h, fieldMul(j.z, o.z))
+	return jacobianPoint{x: x, y: y, z: z}
+}
+
+// doubleScalarMult returns k1·P1 + k2·P2, the points are added together bit by bit (Shamir's trick).
+func doubleScalarMult(k1 *big.Int, p1 affinePoint, k2 *big.Int, p2 affinePoint) affinePoint {
+	j1, j2 := toJacobian(p1), toJacobian(p2)
+	both := j1.add(j2)
+
+	r := toJacobian(affinePoint{})
+	for i := max(k1.BitLen(), k2.BitLen()) - 1; i >= 0; i-- {
+		r = r.double()
+		switch b1, b2 := k1.Bit(i), k2.Bit(i); {
+		case b1 == 1 && b2 == 1:
+			r = r.add(both)
+		case b1 == 1:
+			r = r.add(j1)
+		case b2 == 1:
+			r = r.add(j2)
+		}
+	}
+	return r.affine()
+}
+
+// liftX returns the point with the x coordinate and an even or odd y coordinate. It returns false when x is
+// not the coordinate of a point of the curve.
+func liftX(x *big.Int, odd bool) (affinePoint, bool) {
+	if x.Cmp(curveP) >= 0 {
+		return affinePoint{}, false
+	}
+	c := new(big.Int).Exp(x, big.NewInt(3), curveP)
+	c = fieldAdd(c, curveSeven)
+	y := new(big.Int).Exp(c, sqrtExp, curveP)
+	if fieldMul(y, y).Cmp(c) != 0 {
+		return affinePoint{}, false
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(curveP, y)
+	}
+	return affinePoint{x: new(big.Int).Set(x), y: y}, true
+}
+
+// parsePubKey parses a compressed or uncompressed public key (SEC 1).
+func parsePubKey(b []byte) (affinePoint, bool) {
+	switch {
+	case len(b) == 33 && (b[0] == 0x02 || b[0] == 0x03):
+		return liftX(new(big.Int).SetBytes(b[1:]), b[0] == 0x03)
+	case len(b) == 65 && b[0] == 0x04:
+		x, y := new(big.Int).SetBytes(b[1:33]), new(big.Int).SetBytes(b[33:])
+		if x.Cmp(curveP) >= 0 || y.Cmp(curveP) >= 0 {
+			return affinePoint{}, false
+		}
+		c := fieldAdd(new(big.Int).Exp(x, big.NewInt(3), curveP), curveSeven)
+		if fieldMul(y, y).Cmp(c) != 0 {
+			return affinePoint{}, false
+		}
+		return affinePoint{x: x, y: y}, true
+	}
+	return affinePoint{}, false
+}
+
+// verifyECDSA verifies the DER encoded signature of the hash with the public key. The signature encoding is
+// checked by the engine.
+func verifyECDSA(pubKey []byte, der []byte, hash [32]byte) bool {
+	q, ok := parsePubKey(pubKey)
+	if !ok {
+		return false
+	}
+	r, s, ok := parseDER(der)
+	if !ok || r.Sign() == 0 || s.Sign() == 0 || r.Cmp(curveN) >= 0 || s.Cmp(curveN) >= 0 {
+		return false
+	}
+
+	w := new(big.Int).ModInverse(s, curveN)
+	u1 := new(big.Int).SetBytes(hash[:])
+	u1.Mul(u1, w).Mod(u1, curveN)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, curveN)
+
+	p := doubleScalarMult(u1, curveG, u2, q)
+	if p.infinity() {
+		return false
+	}
+	return new(big.Int).Mod(p.x, curveN).Cmp(r) == 0
+}
+
+// parseDER returns R and S of a signature that is strictly DER encoded (BIP 66), without the hash type.
+func parseDER(sig []byte) (*big.Int, *big.Int, bool) {
+	if len(sig) < 8 || sig[0] != 0x30 || int(sig[1]) != len(sig)-2 || sig[2] != 0x02 {
+		return nil, nil, false
+	}
+	rLen := int(sig[3])
+	if 5+rLen >= len(sig) || sig[4+rLen] != 0x02 {
+		return nil, nil, false
+	}
+	sLen := int(sig[5+rLen])
+	if 6+rLen+sLen != len(sig) {
+		return nil, nil, false
+	}
+	return new(big.Int).SetBytes(sig[4 : 4+rLen]), new(big.Int).SetBytes(sig[6+rLen:]), true
+}
+
+// verifySchnorr verifies the BIP 340 signature of the message with the x-only public key.
+func verifySchnorr(pubKey []byte, sig []byte, msg [32]byte) bool {
+	if len(pubKey) != 32 || len(sig) != 64 {
+		return false
+	}
+	p, ok := liftX(new(big.Int).SetBytes(pubKey), false)
+	if !ok {
+		return false
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(curveP) >= 0 || s.Cmp(curveN) >= 0 {
+		return false
+	}
+
+	h := taggedHash("BIP0340/challenge", sig[:32], pubKey, msg[:])
+	e := new(big.Int).SetBytes(h[:])
+	e.Mod(e, curveN)
+	// R = s·G - e·P
+	e.Sub(curveN, e)
+	rp := doubleScalarMult(s, curveG, e, p)
+	return !rp.infinity() && rp.y.Bit(0) == 0 && rp.x.Cmp(r) == 0
+}
+
+// tweakPubKey returns the point P + t·G of the x-only public key P, it is used to commit to the script tree of
+// a taproot output (BIP 341). It returns false when the key is not valid or the tweak overflows.
+func tweakPubKey(internal []byte, tweak [32]byte) (affinePoint, bool) {
+	p, ok := liftX(new(big.Int).SetBytes(internal), false)
+	if !ok {
+		return affinePoint{}, false
+	}
+	t := new(big.Int).SetBytes(tweak[:])
+	if t.Cmp(curveN) >= 0 {
+		return affinePoint{}, false
+	}
+	q := doubleScalarMult(t, curveG, big.NewInt(1), p)
+	return q, !q.infinity()
+}
+
+// taggedHash returns the hash of the data with the tag (BIP 340): SHA256(SHA256(tag) || SHA256(tag) || data).
+func taggedHash(tag string, data ...[]byte) [32]byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(t[:])
+	h.Write(t[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	var sum [32]byte
+	h.Sum(sum[:0])
+	return sum
+}
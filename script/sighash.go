@@ -0,0 +1,251 @@
+package script
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+// the signature hash types, they select the inputs and the outputs of the transaction that are signed
+const (
+	sigHashDefault      = 0x00
+	sigHashAll          = 0x01
+	sigHashNone         = 0x02
+	sigHashSingle       = 0x03
+	sigHashAnyoneCanPay = 0x80
+)
+
+// txContext is the transaction whose inputs are verified with the outputs that they spend, in the order of the
+// inputs. It keeps the hashes of the inputs and the outputs that are signed by every input.
+type txContext struct {
+	tx       p2p.MsgTx
+	prevOuts []p2p.TxOutput
+
+	// the double SHA-256 hashes of BIP 143
+	hashPrevOuts, hashSequences, hashOutputs [32]byte
+	// the single SHA-256 hashes of BIP 341
+	shaPrevOuts, shaAmounts, shaPkScripts, shaSequences, shaOutputs [32]byte
+}
+
+func newTxContext(tx p2p.MsgTx, prevOuts []p2p.TxOutput) *txContext {
+	c := &txContext{tx: tx, prevOuts: prevOuts}
+
+	var prev, amounts, pkScripts, sequences, outputs bytes.Buffer
+	for i, in := range tx.TxIn {
+		writeOutPoint(&prev, in.PreviousOutput)
+		writeUint32(&sequences, in.Sequence)
+		writeUint64(&amounts, uint64(prevOuts[i].Value))
+		writeBytes(&pkScripts, prevOuts[i].PkScript)
+	}
+	for _, out := range tx.TxOut {
+		writeOutput(&outputs, out)
+	}
+
+	c.shaPrevOuts = sha256.Sum256(prev.Bytes())
+	c.shaAmounts = sha256.Sum256(amounts.Bytes())
+	c.shaPkScripts = sha256.Sum256(pkScripts.Bytes())
+	c.shaSequences = sha256.Sum256(sequences.Bytes())
+	c.shaOutputs = sha256.Sum256(outputs.Bytes())
+	c.hashPrevOuts = sha256.Sum256(c.shaPrevOuts[:])
+	c.hashSequences = sha256.Sum256(c.shaSequences[:])
+	c.hashOutputs = sha256.Sum256(c.shaOutputs[:])
+	return c
+}
+
+// legacySigHash returns the hash that is signed by the input of a transaction that is not segwit. The script
+// code is the script that is executed by the input.
+func (c *txContext) legacySigHash(idx int, scriptCode []byte, hashType uint32) [32]byte {
+	base := hashType & 0x1f
+	if base == sigHashSingle && idx >= len(c.tx.TxOut) {
+		// the hash of the inputs without a matching output is 1, the same as in the original client
+		return [32]byte{1}
+	}
+
+	var buf bytes.Buffer
+	writeUint32(&buf, uint32(c.tx.Version))
+
+	anyoneCanPay := hashType&sigHashAnyoneCanPay != 0
+	if anyoneCanPay {
+		writeVarInt(&buf, 1)
+	} else {
+		writeVarInt(&buf, uint64(len(c.tx.TxIn)))
+	}
+	for i, in := range c.tx.TxIn {
+		if anyoneCanPay && i != idx {
+			continue
+		}
+		writeOutPoint(&buf, in.PreviousOutput)
+		if i == idx {
+			writeBytes(&buf, scriptCode)
+		} else {
+			writeBytes(&buf, nil)
+		}
+		if i != idx && (base == sigHashNone || base == sigHashSingle) {
+			// the other inputs can be updated
+			writeUint32(&buf, 0)
+		} else {
+			writeUint32(&buf, in.Sequence)
+		}
+	}
+
+	switch base {
+	case sigHashNone:
+		writeVarInt(&buf, 0)
+	case sigHashSingle:
+		writeVarInt(&buf, uint64(idx+1))
+		for i := 0; i < idx; i++ {
+			writeOutput(&buf, p2p.TxOutput{Value: -1})
+		}
+		writeOutput(&buf, c.tx.TxOut[idx])
+	default:
+		writeVarInt(&buf, uint64(len(c.tx.TxOut)))
+		for _, out := range c.tx.TxOut {
+			writeOutput(&buf, out)
+		}
+	}
+
+	writeUint32(&buf, c.tx.LockTime)
+	writeUint32(&buf, hashType)
+	return doubleSHA256(buf.Bytes())
+}
+
+// witnessV0SigHash returns the hash that is signed by a segwit version 0 input (BIP 143).
+func (c *txContext) witnessV0SigHash(idx int, scriptCode []byte, hashType uint32) [32]byte {
+	base := hashType & 0x1f
+	anyoneCanPay := hashType&sigHashAnyoneCanPay != 0
+
+	var prevOuts, sequences, outputs [32]byte
+	if !anyoneCanPay {
+		prevOuts = c.hashPrevOuts
+	}
+	if !anyoneCanPay && base != sigHashSingle && base != sigHashNone {
+		sequences = c.hashSequences
+	}
+	switch {
+	case base != sigHashSingle && base != sigHashNone:
+		outputs = c.hashOutputs
+	case base == sigHashSingle && idx < len(c.tx.TxOut):
+		var out bytes.Buffer
+		writeOutput(&out, c.tx.TxOut[idx])
+		outputs = doubleSHA256(out.Bytes())
+	}
+
+	in := c.tx.TxIn[idx]
+	var buf bytes.Buffer
+	writeUint32(&buf, uint32(c.tx.Version))
+	buf.Write(prevOuts[:])
+	buf.Write(sequences[:])
+	writeOutPoint(&buf, in.PreviousOutput)
+	writeBytes(&buf, scriptCode)
+	writeUint64(&buf, uint64(c.prevOuts[idx].Value))
+	writeUint32(&buf, in.Sequence)
+	buf.Write(outputs[:])
+	writeUint32(&buf, c.tx.LockTime)
+	writeUint32(&buf, hashType)
+	return doubleSHA256(buf.Bytes())
+}
+
+// taprootSigHash returns the hash that is signed by a taproot input (BIP 341). The leaf hash is nil for the key
+// path spending, otherwise it is the hash of the executed tapscript and the code separator position is the
+// position of the last executed OP_CODESEPARATOR (BIP 342). It returns false for an undefined hash type and for
+// SIGHASH_SINGLE without a matching output.
+func (c *txContext) taprootSigHash(idx int, hashType byte, leafHash *[32]byte, codeSepPos uint32) ([32]byte, bool) {
+	base := hashType & 0x03
+	anyoneCanPay := hashType&sigHashAnyoneCanPay != 0
+	if !(hashType <= sigHashSingle || (hashType >= 0x81 && hashType <= 0x83)) {
+		return [32]byte{}, false
+	}
+	if base == sigHashSingle && idx >= len(c.tx.TxOut) {
+		return [32]byte{}, false
+	}
+
+	var buf bytes.Buffer
+	// the epoch
+	buf.WriteByte(0)
+	buf.WriteByte(hashType)
+	writeUint32(&buf, uint32(c.tx.Version))
+	writeUint32(&buf, c.tx.LockTime)
+	if !anyoneCanPay {
+		buf.Write(c.shaPrevOuts[:])
+		buf.Write(c.shaAmounts[:])
+		buf.Write(c.shaPkScripts[:])
+		buf.Write(c.shaSequences[:])
+	}
+	if base != sigHashNone && base != sigHashSingle {
+		buf.Write(c.shaOutputs[:])
+	}
+
+	// the annex is not standard, so it is never committed
+	var spendType byte
+	if leafHash != nil {
+		spendType = 2
+	}
+	buf.WriteByte(spendType)
+	if anyoneCanPay {
+		writeOutPoint(&buf, c.tx.TxIn[idx].PreviousOutput)
+		writeOutput(&buf, c.prevOuts[idx])
+		writeUint32(&buf, c.tx.TxIn[idx].Sequence)
+	} else {
+		writeUint32(&buf, uint32(idx))
+	}
+	if base == sigHashSingle {
+		var out bytes.Buffer
+		writeOutput(&out, c.tx.TxOut[idx])
+		sum := sha256.Sum256(out.Bytes())
+		buf.Write(sum[:])
+	}
+
+	if leafHash != nil {
+		buf.Write(leafHash[:])
+		// the key version
+		buf.WriteByte(0)
+		writeUint32(&buf, codeSepPos)
+	}
+	return taggedHash("TapSighash", buf.Bytes()), true
+}
+
+func doubleSHA256(b []byte) [32]byte {
+	h := sha256.Sum256(b)
+	return sha256.Sum256(h[:])
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	buf.Write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	buf.Write(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+func writeVarInt(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n < 0xfd:
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xfd)
+		buf.Write(binary.LittleEndian.AppendUint16(nil, uint16(n)))
+	case n <= 0xffffffff:
+		buf.WriteByte(0xfe)
+		writeUint32(buf, uint32(n))
+	default:
+		buf.WriteByte(0xff)
+		writeUint64(buf, n)
+	}
+}
+
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	writeVarInt(buf, uint64(len(b)))
+	buf.Write(b)
+}
+
+func writeOutPoint(buf *bytes.Buffer, op p2p.OutPoint) {
+	buf.Write(op.Hash[:])
+	writeUint32(buf, op.Index)
+}
+
+func writeOutput(buf *bytes.Buffer, out p2p.TxOutput) {
+	writeUint64(buf, uint64(out.Value))
+	writeBytes(buf, out.PkScript)
+}
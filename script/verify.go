@@ -0,0 +1,270 @@
+// Package script verifies the scripts of the transaction inputs: the legacy scripts with P2SH (BIP 16), the
+// segwit version 0 programs (BIP 141, BIP 143) and the taproot outputs with their tapscripts (BIP 341, BIP 342).
+// The scripts are executed with the standard rules of the mempool, a transaction that fails them may still be
+// valid in a block.
+package script
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
+
+const (
+	// taprootLeafVersion is the leaf version of tapscript, the other versions are reserved for the upgrades.
+	taprootLeafVersion = 0xc0
+	// taprootAnnexTag is the first byte of the annex, the optional last element of a taproot witness.
+	taprootAnnexTag         = 0x50
+	taprootControlBaseSize  = 33
+	taprootControlNodeSize  = 32
+	taprootControlMaxNodes  = 128
+	witnessV0KeyHashSize    = 20
+	witnessV0ScriptHashSize = 32
+	witnessV1TaprootSize    = 32
+)
+
+// VerifyTx verifies the scripts of all inputs of the transaction. The previous outputs are the outputs that the
+// inputs spend, in the order of the inputs.
+func VerifyTx(tx p2p.MsgTx, prevOuts []p2p.TxOutput) error {
+	if len(prevOuts) != len(tx.TxIn) {
+		return fmt.Errorf("%d previous outputs for %d inputs", len(prevOuts), len(tx.TxIn))
+	}
+
+	c := newTxContext(tx, prevOuts)
+	for i := range tx.TxIn {
+		if err := c.verifyInput(i); err != nil {
+			return fmt.Errorf("input %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *txContext) verifyInput(idx int) error {
+	sigScript := c.tx.TxIn[idx].SignatureScript
+	pkScript := c.prevOuts[idx].PkScript
+	var witness [][]byte
+	if c.tx.Flag != 0 && idx < len(c.tx.TxWitness) {
+		for _, w := range c.tx.TxWitness[idx].Witness {
+			witness = append(witness, w.Data)
+		}
+	}
+
+	if !isPushOnly(sigScript) {
+		return scriptError("the input script is not push only")
+	}
+	e := &engine{tx: c, idx: idx, version: sigVersionBase}
+	if err := e.eval(sigScript); err != nil {
+		return err
+	}
+	sigStack := slices.Clone(e.stack)
+	if err := e.eval(pkScript); err != nil {
+		return err
+	}
+	if len(e.stack) == 0 || !castToBool(e.top(1)) {
+		return scriptError("the output script returns false")
+	}
+
+	hasWitness := false
+	if version, program, ok := witnessProgram(pkScript); ok {
+		hasWitness = true
+		if len(sigScript) != 0 {
+			return scriptError("the input script of a witness program is not empty")
+		}
+		if err := c.verifyWitnessProgram(idx, witness, version, program, false); err != nil {
+			return err
+		}
+		// the witness program leaves a single element on the stack
+		e.stack = e.stack[:1]
+	}
+
+	if isP2SH(pkScript) {
+		e.stack = sigStack
+		redeem, err := e.pop()
+		if err != nil {
+			return err
+		}
+		if err = e.eval(redeem); err != nil {
+			return err
+		}
+		if len(e.stack) == 0 || !castToBool(e.top(1)) {
+			return scriptError("the redeem script returns false")
+		}
+
+		if version, program, ok := witnessProgram(redeem); ok {
+			hasWitness = true
+			if !bytes.Equal(sigScript, pushData(redeem)) {
+				return scriptError("the input script of a nested witness program is not a single push")
+			}
+			if err = c.verifyWitnessProgram(idx, witness, version, program, true); err != nil {
+				return err
+			}
+			e.stack = e.stack[:1]
+		}
+	}
+
+	if len(e.stack) != 1 {
+		return scriptError("%d elements are left on the stack", len(e.stack))
+	}
+	if !hasWitness && len(witness) > 0 {
+		return scriptError("unexpected witness")
+	}
+	return nil
+}
+
+// verifyWitnessProgram verifies the witness of the P2WPKH, P2WSH and P2TR programs, the other versions are
+// reserved for the upgrades. The taproot programs that are nested in P2SH are not taproot outputs.
+func (c *txContext) verifyWitnessProgram(idx int, witness [][]byte, version byte, program []byte, nested bool) error {
+	switch {
+	case version == 0 && len(program) == witnessV0ScriptHashSize:
+		if len(witness) == 0 {
+			return scriptError("empty witness")
+		}
+		script := witness[len(witness)-1]
+		if sum := sha256.Sum256(script); !bytes.Equal(sum[:], program) {
+			return scriptError("the witness script doesn't match the program")
+		}
+		return c.execWitnessScript(idx, witness[:len(witness)-1], script, sigVersionWitnessV0, witness, [32]byte{})
+	case version == 0 && len(program) == witnessV0KeyHashSize:
+		if len(witness) != 2 {
+			return scriptError("%d witness elements of a P2WPKH program", len(witness))
+		}
+		script := append([]byte{opDup, opHash160, witnessV0KeyHashSize}, program...)
+		script = append(script, opEqualVerify, opCheckSig)
+		return c.execWitnessScript(idx, witness, script, sigVersionWitnessV0, witness, [32]byte{})
+	case version == 0:
+		return scriptError("witness program size %d", len(program))
+	case version == 1 && len(program) == witnessV1TaprootSize && !nested:
+		return c.verifyTaproot(idx, witness, program)
+	}
+	return scriptError("witness version %d is reserved for the upgrades", version)
+}
+
+// verifyTaproot verifies the key path spending with a signature or the script path spending with a tapscript
+// that is committed to by the output key.
+func (c *txContext) verifyTaproot(idx int, witness [][]byte, outputKey []byte) error {
+	if len(witness) == 0 {
+		return scriptError("empty witness")
+	}
+	if last := witness[len(witness)-1]; len(witness) >= 2 && len(last) > 0 && last[0] == taprootAnnexTag {
+		return scriptError("the annex is not standard")
+	}
+
+	if len(witness) == 1 {
+		e := &engine{tx: c, idx: idx, version: sigVersionTapscript, codeSepPos: 0xffffffff}
+		if !e.checkSchnorr(witness[0], outputKey, nil) {
+			return scriptError("schnorr signature")
+		}
+		return nil
+	}
+
+	control := witness[len(witness)-1]
+	script := witness[len(witness)-2]
+	nodes := (len(control) - taprootControlBaseSize) / taprootControlNodeSize
+	if len(control) < taprootControlBaseSize || nodes > taprootControlMaxNodes ||
+		(len(control)-taprootControlBaseSize)%taprootControlNodeSize != 0 {
+		return scriptError("control block size %d", len(control))
+	}
+
+	leafVersion := control[0] &^ 1
+	var size bytes.Buffer
+	writeVarInt(&size, uint64(len(script)))
+	leafHash := taggedHash("TapLeaf", []byte{leafVersion}, size.Bytes(), script)
+
+	k := leafHash
+	for i := 0; i < nodes; i++ {
+		node := control[taprootControlBaseSize+i*taprootControlNodeSize:][:taprootControlNodeSize]
+		if bytes.Compare(k[:], node) < 0 {
+			k = taggedHash("TapBranch", k[:], node)
+		} else {
+			k = taggedHash("TapBranch", node, k[:])
+		}
+	}
+	internalKey := control[1:taprootControlBaseSize]
+	q, ok := tweakPubKey(internalKey, taggedHash("TapTweak", internalKey, k[:]))
+	if !ok || !bytes.Equal(q.x.FillBytes(make([]byte, 32)), outputKey) || q.y.Bit(0) != uint(control[0]&1) {
+		return scriptError("the script is not committed to by the output key")
+	}
+
+	if leafVersion != taprootLeafVersion {
+		return scriptError("leaf version %#x is reserved for the upgrades", leafVersion)
+	}
+	return c.execWitnessScript(idx, witness[:len(witness)-2], script, sigVersionTapscript, witness, leafHash)
+}
+
+// execWitnessScript executes the witness script on the stack, it must leave a single true element on it.
+func (c *txContext) execWitnessScript(idx int, stack [][]byte, script []byte, version sigVersion, witness [][]byte, leafHash [32]byte) error {
+	e := &engine{tx: c, idx: idx, version: version, stack: slices.Clone(stack), leafHash: leafHash}
+
+	if version == sigVersionTapscript {
+		for pc := 0; pc < len(script); {
+			op, _, next, ok := parseOp(script, pc)
+			if !ok {
+				return scriptError("bad opcode at %d", pc)
+			}
+			if isSuccess(op) {
+				return scriptError("OP_SUCCESS %#x is reserved for the upgrades", op)
+			}
+			pc = next
+		}
+
+		var w bytes.Buffer
+		writeVarInt(&w, uint64(len(witness)))
+		for _, item := range witness {
+			writeBytes(&w, item)
+		}
+		e.weightLeft = int64(w.Len()) + validationWeightOffset
+	}
+
+	if len(e.stack) > maxStackSize {
+		return scriptError("stack size")
+	}
+	for _, item := range e.stack {
+		if len(item) > maxScriptElementSize {
+			return scriptError("push size %d", len(item))
+		}
+	}
+
+	if err := e.eval(script); err != nil {
+		return err
+	}
+	if len(e.stack) != 1 {
+		return scriptError("%d elements are left on the stack", len(e.stack))
+	}
+	if !castToBool(e.top(1)) {
+		return scriptError("the witness script returns false")
+	}
+	return nil
+}
+
+// witnessProgram returns the version and the program of a witness program output script (BIP 141).
+func witnessProgram(s []byte) (byte, []byte, bool) {
+	if len(s) < 4 || len(s) > 42 || int(s[1]) != len(s)-2 {
+		return 0, nil, false
+	}
+	switch {
+	case s[0] == op0:
+		return 0, s[2:], true
+	case s[0] >= op1 && s[0] <= op16:
+		return s[0] - op1 + 1, s[2:], true
+	}
+	return 0, nil, false
+}
+
+func isP2SH(s []byte) bool {
+	return len(s) == 23 && s[0] == opHash160 && s[1] == 20 && s[22] == opEqual
+}
+
+// isPushOnly returns true when the script only pushes data on the stack.
+func isPushOnly(s []byte) bool {
+	for pc := 0; pc < len(s); {
+		op, _, next, ok := parseOp(s, pc)
+		if !ok || op > op16 {
+			return false
+		}
+		pc = next
+	}
+	return true
+}
@@ -0,0 +1,509 @@
+package script
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+	"github.com/stretchr/testify/require"
+)
+
+// a mainnet P2PKH spend, its previous output is the P2PKH script of the key in the input script
+const legacyTx = "0100000001317c144ae5b5a224370bd68c928b9f9e152d9829235ffbecec5ee64113662fc4000000006a47304402203c6ef3cba42336" +
+	"5b37c031d235a674a10cf06b14fccda68bb5c35cbda5a2969b02207da3f69ea61c4a98eb488dac9d8a421dda9000e8afdc4a90cc2ebf93fbe" +
+	"fb84f012102e248c2b8e9a5b78f2406c60b75ef1c4e88a06c7c36ad31e009db256505e27e79ffffffff0388270c00000000001976a914fe46" +
+	"ec55e937e584005b337495d76464b6b1cdba88ac22020000000000001976a914bdcccc7ce08a732ce55dcc3c1d8890e372bf7c1d88ac0000" +
+	"000000000000166a146f6d6e69000000000000001f0000886c98b7600000000000"
+
+// a mainnet P2WSH 2-of-3 multisig spend of 4342872 satoshis
+const segwitTx = "0100000000010145b87f940bc57475403a3928ecf4cb3b86d2ba192039d4d703126edad14487ca0100000000ffffffff0200093d00" +
+	"0000000017a91469f375f23b3d5d37bd942f3c31d7ae5a0cb61f5e87c8db030000000000220020701a8d401c84fb13e6baf169d59684e17ab" +
+	"d9fa216c8cc5b9fc63d622ff8c58d0400473044022025863cfe71648bc8703f9f0607558cb7e79fcbebadc080ef1f0d7bfdd6ab1afa022010" +
+	"1ffaeb01b70e3360e87d6b3616886e547593e41c8a09d00cf8803601a9cc7901473044022031caba2ba6b079bc0d995e04f3651f977b5fc22d" +
+	"acceab1046a311fa2fb83898022030f5a852b425bdeb156be3a0a3de5bbc764302fbc1b7ca77058740cd511d49a9016952210375e00eb72e29" +
+	"da82b89367947f29ef34afb75e8654f6ea368e0acdfd92976b7c2103a1b26313f430c4b15bb1fdce663207659d8cac749a0e53d70eff018744" +
+	"96feff2103c96d495bfdd5ba4145e3e046fee45e84a8a48ad05bd8dbb395c011a32cf9f88053ae00000000"
+
+func TestRipemd160(t *testing.T) {
+	tests := map[string]string{
+		"":               "9c1185a5c5e9fc54612808977ee8f548b2258d31",
+		"abc":            "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc",
+		"message digest": "5d0689ef49d2fae572b881b123a85ffa21595f36",
+	}
+	for input, expected := range tests {
+		sum := ripemd160([]byte(input))
+		require.Equal(t, expected, hex.EncodeToString(sum[:]), input)
+	}
+}
+
+func TestVerifySchnorr(t *testing.T) {
+	// the test vectors of BIP 340
+	tests := []struct {
+		key, pubKey, aux, msg, sig string
+	}{
+		{
+			key:    "0000000000000000000000000000000000000000000000000000000000000003",
+			pubKey: "f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			aux:    "0000000000000000000000000000000000000000000000000000000000000000",
+			msg:    "0000000000000000000000000000000000000000000000000000000000000000",
+			sig: "e907831f80848d1069a5371b402410364bdf1c5f8307b0084c55f1ce2dca8215" +
+				"25f66a4a85ea8b71e482a74f382d2ce5ebeee8fdb2172f477df4900d310536c0",
+		},
+		{
+			key:    "b7e151628aed2a6abf7158809cf4f3c762e7160f38b4da56a784d9045190cfef",
+			pubKey: "dff1d77f2a671c5f36183726db2341be58feae1da2deced843240f7b502ba659",
+			aux:    "0000000000000000000000000000000000000000000000000000000000000001",
+			msg:    "243f6a8885a308d313198a2e03707344a4093822299f31d0082efa98ec4e6c89",
+			sig: "6896bd60eeae296db48a229ff71dfe071bde413e6d43f917dc8dcf8c78de3341" +
+				"8906d11ac976abccb20b091292bff4ea897efcb639ea871cfa95f6de339e4b0a",
+		},
+	}
+
+	for _, test := range tests {
+		pubKey, sig := unhex(t, test.pubKey), unhex(t, test.sig)
+		msg := [32]byte(unhex(t, test.msg))
+		require.True(t, verifySchnorr(pubKey, sig, msg))
+		require.Equal(t, sig, signSchnorr(new(big.Int).SetBytes(unhex(t, test.key)), msg, [32]byte(unhex(t, test.aux))))
+
+		msg[0] ^= 1
+		require.False(t, verifySchnorr(pubKey, sig, msg))
+	}
+}
+
+func TestVerifyTx_MainnetTransactions(t *testing.T) {
+	tx := decodeTx(t, legacyTx)
+	sigScript := tx.TxIn[0].SignatureScript
+	prevOuts := []p2p.TxOutput{{Value: 1_000_000, PkScript: p2pkh(sigScript[len(sigScript)-33:])}}
+	require.NoError(t, VerifyTx(tx, prevOuts))
+
+	tx.TxOut[0].Value--
+	require.ErrorIs(t, VerifyTx(tx, prevOuts), ErrScriptFailed)
+
+	tx = decodeTx(t, segwitTx)
+	witness := tx.TxWitness[0].Witness
+	prevOuts = []p2p.TxOutput{{Value: 4342872, PkScript: p2wsh(witness[len(witness)-1].Data)}}
+	require.NoError(t, VerifyTx(tx, prevOuts))
+
+	// the segwit signatures commit to the spent amount
+	prevOuts[0].Value--
+	require.ErrorIs(t, VerifyTx(tx, prevOuts), ErrScriptFailed)
+}
+
+func TestVerifyTx_SpendsTheStandardOutputs(t *testing.T) {
+	key1, key2 := testKey(1), testKey(2)
+
+	tests := []struct {
+		name string
+		// spend returns the previous output and sets the input script and the witness of the transaction
+		spend func(tx *p2p.MsgTx) p2p.TxOutput
+	}{
+		{
+			name: "P2PKH",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2pkh(pubKey(key1))}
+				sig := signLegacy(*tx, prev, prev.PkScript, key1)
+				setInput(tx, append(pushData(sig), pushData(pubKey(key1))...), nil)
+				return prev
+			},
+		},
+		{
+			name: "P2SH multisig",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				redeem := multiSig(pubKey(key1), pubKey(key2))
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2sh(redeem)}
+				sig := signLegacy(*tx, prev, redeem, key2)
+				setInput(tx, append(append([]byte{op0}, pushData(sig)...), pushData(redeem)...), nil)
+				return prev
+			},
+		},
+		{
+			name: "P2WPKH",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				h := hash160(pubKey(key1))
+				prev := p2p.TxOutput{Value: 100_000, PkScript: append([]byte{op0, 20}, h[:]...)}
+				sig := signWitnessV0(*tx, prev, p2pkh(pubKey(key1)), key1)
+				setInput(tx, nil, [][]byte{sig, pubKey(key1)})
+				return prev
+			},
+		},
+		{
+			name: "P2SH-P2WPKH",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				h := hash160(pubKey(key1))
+				redeem := append([]byte{op0, 20}, h[:]...)
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2sh(redeem)}
+				sig := signWitnessV0(*tx, prev, p2pkh(pubKey(key1)), key1)
+				setInput(tx, pushData(redeem), [][]byte{sig, pubKey(key1)})
+				return prev
+			},
+		},
+		{
+			name: "P2WSH multisig",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				ws := multiSig(pubKey(key1), pubKey(key2))
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2wsh(ws)}
+				sig := signWitnessV0(*tx, prev, ws, key1)
+				setInput(tx, nil, [][]byte{nil, sig, ws})
+				return prev
+			},
+		},
+		{
+			name: "P2TR key path",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				outputKey, _, tweak := taprootOutput(key1, nil)
+				prev := p2p.TxOutput{Value: 100_000, PkScript: append([]byte{op1, 32}, outputKey...)}
+				tweaked := new(big.Int).Add(evenKey(key1), tweak)
+				sig := signTaproot(*tx, prev, tweaked.Mod(tweaked, curveN), nil)
+				setInput(tx, nil, [][]byte{sig})
+				return prev
+			},
+		},
+		{
+			name: "P2TR script path",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				leaf := append(pushData(xOnlyPubKey(key2)), opCheckSig)
+				outputKey, control, _ := taprootOutput(key1, leaf)
+				prev := p2p.TxOutput{Value: 100_000, PkScript: append([]byte{op1, 32}, outputKey...)}
+				sig := signTaproot(*tx, prev, key2, leaf)
+				setInput(tx, nil, [][]byte{sig, leaf, control})
+				return prev
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			tx := unsignedTx()
+			prev := test.spend(&tx)
+			require.NoError(tt, VerifyTx(tx, []p2p.TxOutput{prev}))
+
+			// the signature commits to the outputs
+			tx.TxOut[0].Value--
+			require.ErrorIs(tt, VerifyTx(tx, []p2p.TxOutput{prev}), ErrScriptFailed)
+		})
+	}
+}
+
+func TestVerifyTx_RejectsTheNonStandardSpends(t *testing.T) {
+	key1, key2 := testKey(1), testKey(2)
+
+	tests := []struct {
+		name  string
+		spend func(tx *p2p.MsgTx) p2p.TxOutput
+	}{
+		{
+			name: "the signature of another key",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2pkh(pubKey(key1))}
+				sig := signLegacy(*tx, prev, prev.PkScript, key2)
+				setInput(tx, append(pushData(sig), pushData(pubKey(key1))...), nil)
+				return prev
+			},
+		},
+		{
+			name: "a signature with a high S",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2pkh(pubKey(key1))}
+				hash := newTxContext(*tx, []p2p.TxOutput{prev}).legacySigHash(0, prev.PkScript, sigHashAll)
+				r, s := signECDSA(key1, hash)
+				sig := append(encodeDER(r, new(big.Int).Sub(curveN, s)), sigHashAll)
+				setInput(tx, append(pushData(sig), pushData(pubKey(key1))...), nil)
+				return prev
+			},
+		},
+		{
+			name: "a failed signature that is not empty",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				// the script succeeds when the signature doesn't match, but the signature must be empty
+				script := append(append(pushData(pubKey(key1)), opCheckSig), opNot)
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2wsh(script)}
+				sig := signWitnessV0(*tx, prev, script, key2)
+				setInput(tx, nil, [][]byte{sig, script})
+				return prev
+			},
+		},
+		{
+			name: "an uncompressed key in a witness script",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				script := append(pushData(uncompressedPubKey(key1)), opCheckSig)
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2wsh(script)}
+				sig := signWitnessV0(*tx, prev, script, key1)
+				setInput(tx, nil, [][]byte{sig, script})
+				return prev
+			},
+		},
+		{
+			name: "a non-minimal push",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2pkh(pubKey(key1))}
+				sig := signLegacy(*tx, prev, prev.PkScript, key1)
+				setInput(tx, append(append([]byte{opPushData1, byte(len(sig))}, sig...), pushData(pubKey(key1))...), nil)
+				return prev
+			},
+		},
+		{
+			name: "a witness version that is reserved for the upgrades",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				prev := p2p.TxOutput{Value: 100_000, PkScript: append([]byte{op16, 2}, 1, 2)}
+				setInput(tx, nil, [][]byte{{1}})
+				return prev
+			},
+		},
+		{
+			name: "an OP_SUCCESS in a tapscript",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				leaf := []byte{op1, 0x50}
+				outputKey, control, _ := taprootOutput(key1, leaf)
+				prev := p2p.TxOutput{Value: 100_000, PkScript: append([]byte{op1, 32}, outputKey...)}
+				setInput(tx, nil, [][]byte{leaf, control})
+				return prev
+			},
+		},
+		{
+			name: "a witness for an output that is not a witness program",
+			spend: func(tx *p2p.MsgTx) p2p.TxOutput {
+				prev := p2p.TxOutput{Value: 100_000, PkScript: p2pkh(pubKey(key1))}
+				sig := signLegacy(*tx, prev, prev.PkScript, key1)
+				setInput(tx, append(pushData(sig), pushData(pubKey(key1))...), [][]byte{{1}})
+				return prev
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			tx := unsignedTx()
+			prev := test.spend(&tx)
+			require.ErrorIs(tt, VerifyTx(tx, []p2p.TxOutput{prev}), ErrScriptFailed)
+		})
+	}
+}
+
+func TestVerifyTx_WitnessV0SignatureCommitsToTheAmount(t *testing.T) {
+	key := testKey(1)
+	h := hash160(pubKey(key))
+	prev := p2p.TxOutput{Value: 100_000, PkScript: append([]byte{op0, 20}, h[:]...)}
+	tx := unsignedTx()
+	setInput(&tx, nil, [][]byte{signWitnessV0(tx, prev, p2pkh(pubKey(key)), key), pubKey(key)})
+	require.NoError(t, VerifyTx(tx, []p2p.TxOutput{prev}))
+
+	prev.Value++
+	require.ErrorIs(t, VerifyTx(tx, []p2p.TxOutput{prev}), ErrScriptFailed)
+}
+
+func TestVerifyTx_CheckLockTimeVerify(t *testing.T) {
+	key := testKey(1)
+	script := append(append([]byte{0x02, 0xe8, 0x03, opCheckLockTimeVerify, opDrop}, pushData(pubKey(key))...), opCheckSig)
+	prev := p2p.TxOutput{Value: 100_000, PkScript: p2wsh(script)}
+
+	for lockTime, valid := range map[uint32]bool{999: false, 1000: true} {
+		tx := unsignedTx()
+		tx.LockTime = lockTime
+		tx.TxIn[0].Sequence = 0xfffffffe
+		setInput(&tx, nil, [][]byte{signWitnessV0(tx, prev, script, key), script})
+
+		err := VerifyTx(tx, []p2p.TxOutput{prev})
+		if valid {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, ErrScriptFailed)
+		}
+	}
+}
+
+func unsignedTx() p2p.MsgTx {
+	return p2p.MsgTx{
+		Version:    2,
+		TxInCount:  1,
+		TxIn:       []p2p.TxInput{{PreviousOutput: p2p.OutPoint{Hash: [32]byte{1}}, Sequence: 0xffffffff}},
+		TxOutCount: 1,
+		TxOut:      []p2p.TxOutput{{Value: 90_000, PkScriptLength: 22, PkScript: append([]byte{op0, 20}, make([]byte, 20)...)}},
+	}
+}
+
+func setInput(tx *p2p.MsgTx, sigScript []byte, witness [][]byte) {
+	tx.TxIn[0].SignatureScript = sigScript
+	tx.TxIn[0].ScriptLength = p2p.VarInt(len(sigScript))
+	if witness == nil {
+		return
+	}
+	tx.Flag = 1
+	w := p2p.TxWitnessData{Count: p2p.VarInt(len(witness))}
+	for _, item := range witness {
+		w.Witness = append(w.Witness, p2p.TxWitness{Length: p2p.VarInt(len(item)), Data: item})
+	}
+	tx.TxWitness = []p2p.TxWitnessData{w}
+}
+
+func decodeTx(t *testing.T, s string) p2p.MsgTx {
+	var tx p2p.MsgTx
+	require.NoError(t, tx.UnmarshalBinary(bytes.NewReader(unhex(t, s))))
+	return tx
+}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func p2pkh(pubKey []byte) []byte {
+	h := hash160(pubKey)
+	return append(append([]byte{opDup, opHash160, 20}, h[:]...), opEqualVerify, opCheckSig)
+}
+
+func p2sh(redeem []byte) []byte {
+	h := hash160(redeem)
+	return append(append([]byte{opHash160, 20}, h[:]...), opEqual)
+}
+
+func p2wsh(script []byte) []byte {
+	h := sha256.Sum256(script)
+	return append([]byte{op0, 32}, h[:]...)
+}
+
+// multiSig returns the 1-of-n multisig script of the keys.
+func multiSig(pubKeys ...[]byte) []byte {
+	s := []byte{op1}
+	for _, k := range pubKeys {
+		s = append(s, pushData(k)...)
+	}
+	return append(s, op1+byte(len(pubKeys))-1, opCheckMultiSig)
+}
+
+// taprootOutput returns the output key that commits to the leaf script, the control block of the leaf and the
+// tweak of the internal key. There is no script tree when the leaf is nil.
+func taprootOutput(internal *big.Int, leaf []byte) ([]byte, []byte, *big.Int) {
+	internalKey := xOnlyPubKey(internal)
+	var root []byte
+	if leaf != nil {
+		var size bytes.Buffer
+		writeVarInt(&size, uint64(len(leaf)))
+		h := taggedHash("TapLeaf", []byte{taprootLeafVersion}, size.Bytes(), leaf)
+		root = h[:]
+	}
+	tweak := taggedHash("TapTweak", internalKey, root)
+	q, _ := tweakPubKey(internalKey, tweak)
+	control := append([]byte{taprootLeafVersion | byte(q.y.Bit(0))}, internalKey...)
+	return q.x.FillBytes(make([]byte, 32)), control, new(big.Int).SetBytes(tweak[:])
+}
+
+func testKey(seed byte) *big.Int {
+	h := sha256.Sum256([]byte{seed})
+	return new(big.Int).SetBytes(h[:])
+}
+
+func publicPoint(d *big.Int) affinePoint {
+	return doubleScalarMult(d, curveG, new(big.Int), curveG)
+}
+
+func pubKey(d *big.Int) []byte {
+	p := publicPoint(d)
+	return append([]byte{0x02 + byte(p.y.Bit(0))}, p.x.FillBytes(make([]byte, 32))...)
+}
+
+func uncompressedPubKey(d *big.Int) []byte {
+	p := publicPoint(d)
+	return append(append([]byte{0x04}, p.x.FillBytes(make([]byte, 32))...), p.y.FillBytes(make([]byte, 32))...)
+}
+
+func xOnlyPubKey(d *big.Int) []byte {
+	return publicPoint(d).x.FillBytes(make([]byte, 32))
+}
+
+// evenKey returns the private key of the x-only public key, whose y coordinate is even.
+func evenKey(d *big.Int) *big.Int {
+	if publicPoint(d).y.Bit(0) == 1 {
+		return new(big.Int).Sub(curveN, d)
+	}
+	return d
+}
+
+func signLegacy(tx p2p.MsgTx, prev p2p.TxOutput, scriptCode []byte, d *big.Int) []byte {
+	hash := newTxContext(tx, []p2p.TxOutput{prev}).legacySigHash(0, scriptCode, sigHashAll)
+	return append(encodeDER(signECDSA(d, hash)), sigHashAll)
+}
+
+func signWitnessV0(tx p2p.MsgTx, prev p2p.TxOutput, scriptCode []byte, d *big.Int) []byte {
+	hash := newTxContext(tx, []p2p.TxOutput{prev}).witnessV0SigHash(0, scriptCode, sigHashAll)
+	return append(encodeDER(signECDSA(d, hash)), sigHashAll)
+}
+
+// signTaproot signs the key path spending when the leaf is nil, otherwise the script path spending of the leaf.
+func signTaproot(tx p2p.MsgTx, prev p2p.TxOutput, d *big.Int, leaf []byte) []byte {
+	var leafHash *[32]byte
+	if leaf != nil {
+		var size bytes.Buffer
+		writeVarInt(&size, uint64(len(leaf)))
+		h := taggedHash("TapLeaf", []byte{taprootLeafVersion}, size.Bytes(), leaf)
+		leafHash = &h
+	}
+	hash, _ := newTxContext(tx, []p2p.TxOutput{prev}).taprootSigHash(0, sigHashDefault, leafHash, 0xffffffff)
+	return signSchnorr(d, hash, [32]byte{})
+}
+
+// signECDSA returns the signature with a low S, the nonce is derived from the key and the hash.
+func signECDSA(d *big.Int, hash [32]byte) (*big.Int, *big.Int) {
+	z := new(big.Int).SetBytes(hash[:])
+	for i := byte(0); ; i++ {
+		nonce := sha256.Sum256(append(append(d.FillBytes(make([]byte, 32)), hash[:]...), i))
+		k := new(big.Int).SetBytes(nonce[:])
+		k.Mod(k, curveN)
+		if k.Sign() == 0 {
+			continue
+		}
+		r := new(big.Int).Mod(publicPoint(k).x, curveN)
+		s := new(big.Int).Mul(r, d)
+		s.Add(s, z).Mul(s, new(big.Int).ModInverse(k, curveN)).Mod(s, curveN)
+		if r.Sign() == 0 || s.Sign() == 0 {
+			continue
+		}
+		if s.Cmp(halfN) > 0 {
+			s.Sub(curveN, s)
+		}
+		return r, s
+	}
+}
+
+func encodeDER(r, s *big.Int) []byte {
+	encode := func(v *big.Int) []byte {
+		b := v.Bytes()
+		if b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return append([]byte{0x02, byte(len(b))}, b...)
+	}
+	body := append(encode(r), encode(s)...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
+
+// signSchnorr returns the BIP 340 signature of the message with the auxiliary random data.
+func signSchnorr(d *big.Int, msg [32]byte, aux [32]byte) []byte {
+	p := publicPoint(d)
+	if p.y.Bit(0) == 1 {
+		d = new(big.Int).Sub(curveN, d)
+	}
+	px := p.x.FillBytes(make([]byte, 32))
+
+	auxHash := taggedHash("BIP0340/aux", aux[:])
+	t := d.FillBytes(make([]byte, 32))
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+	nonce := taggedHash("BIP0340/nonce", t, px, msg[:])
+	k := new(big.Int).SetBytes(nonce[:])
+	k.Mod(k, curveN)
+	r := publicPoint(k)
+	if r.y.Bit(0) == 1 {
+		k.Sub(curveN, k)
+	}
+	rx := r.x.FillBytes(make([]byte, 32))
+
+	h := taggedHash("BIP0340/challenge", rx, px, msg[:])
+	e := new(big.Int).SetBytes(h[:])
+	s := e.Mul(e, d).Add(e, k).Mod(e, curveN)
+	return append(rx, s.FillBytes(make([]byte, 32))...)
+}
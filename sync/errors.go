@@ -4,6 +4,7 @@ import "errors"
 
 var (
 	ErrNotFound                    = errors.New("not found")
+	ErrPruned                      = errors.New("block data is pruned")
 	ErrFailedToGetLastBlock        = errors.New("failed to get the last block from DB ")
 	ErrFailedToCreateMsgGetHeaders = errors.New("failed to create MSG GetHeaders ")
 	ErrFailedToSendMsgGetHeaders   = errors.New("failed to send MS GetHeaders ")
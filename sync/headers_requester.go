@@ -19,51 +19,128 @@ type HeadersRequester struct {
 	network         string
 	blockRepository BlockRepository
 
+	// headerRepository is set when the headers are stored, then the headers are requested after the
+	// best stored header and they are not downloaded again after a restart.
+	headerRepository HeaderRepository
+
+	// chainTip is set when the node is loaded from a UTXO snapshot. Then the blocks before the
+	// snapshot are not stored and the chain tip decides from which block the sync starts.
+	chainTip ChainTip
+
 	// used to queue messages that needs to be send to the peer
 	outgoingMsgs chan<- *p2p.Message
 
 	// used to notify MsgHeaders handler what to expect in Header msg. It should reject
 	// all headers messages in which the first block header's previous block hash is not
-	// the one that is send through this channel
-	expectedHashes chan<- [32]byte
+	// in the block locator that is send through this channel
+	expectedLocators chan<- [][32]byte
 }
 
-func NewHeadersRequester(n string, br BlockRepository, out chan<- *p2p.Message, h chan<- [32]byte) HeadersRequester {
+// NewHeadersRequester creates a new HeadersRequester. The header repository and the chain tip can be nil,
+// then the headers are requested from the last stored block.
+func NewHeadersRequester(n string, br BlockRepository, hr HeaderRepository, tip ChainTip, out chan<- *p2p.Message, h chan<- [][32]byte) HeadersRequester {
 	return HeadersRequester{
-		network:         n,
-		blockRepository: br,
-		outgoingMsgs:    out,
-		expectedHashes:  h,
+		network:          n,
+		blockRepository:  br,
+		headerRepository: hr,
+		chainTip:         tip,
+		outgoingMsgs:     out,
+		expectedLocators: h,
 	}
 }
 
+// RequestHeadersFromLastBlock requests the headers after the last known block. When the headers are stored
+// the getheaders message has a block locator of the best header chain, so the peer finds the fork point
+// with its chain even if the last known block is not on it.
 func (cs HeadersRequester) RequestHeadersFromLastBlock() error {
-	block, err := cs.blockRepository.GetLast()
-	var blockHash [32]byte
+	locator, err := cs.blockLocator()
 	if err != nil {
-		blockHash = GenesisBlockHash
-	} else {
-		blockHash = block.GetHash()
+		locator = [][32]byte{GenesisBlockHash}
 	}
 
-	gh, err := p2p.NewMsgGetHeader(cs.network, 1, blockHash, [32]byte{0})
+	gh, err := p2p.NewMsgGetHeader(cs.network, locator, [32]byte{0})
 	if err != nil {
 		return errors.Join(ErrFailedToCreateMsgGetHeaders, err)
 	}
 
-	log.Printf("Find the Last processed block in DB and send it to msg headers handlers: %x\n", p2p.Reverse(blockHash))
-	cs.expectedHashes <- blockHash
+	log.Printf("Find the Last processed block in DB and send it to msg headers handlers: %x\n", p2p.Reverse(locator[0]))
+	cs.expectedLocators <- locator
 	cs.outgoingMsgs <- gh
 	return nil
 }
 
 func (cs HeadersRequester) RequestHeadersFromBlockHash(hash [32]byte) error {
-	gh, err := p2p.NewMsgGetHeader(cs.network, 1, hash, [32]byte{0})
+	locator := [][32]byte{hash}
+	gh, err := p2p.NewMsgGetHeader(cs.network, locator, [32]byte{0})
 	if err != nil {
 		return errors.Join(ErrFailedToCreateMsgGetHeaders, err)
 	}
 
-	cs.expectedHashes <- hash
+	cs.expectedLocators <- locator
 	cs.outgoingMsgs <- gh
 	return nil
 }
+
+// blockLocator returns the block locator of the best stored header chain. When the headers are not stored
+// the locator has only the hash of the last block.
+func (cs HeadersRequester) blockLocator() ([][32]byte, error) {
+	if cs.headerRepository != nil {
+		return cs.headerRepository.BlockLocator()
+	}
+
+	hash, err := cs.lastBlockHash()
+	if err != nil {
+		return nil, err
+	}
+	return [][32]byte{hash}, nil
+}
+
+func (cs HeadersRequester) lastBlockHash() ([32]byte, error) {
+	if cs.chainTip != nil {
+		return cs.chainTip.SyncFrom()
+	}
+
+	block, err := cs.blockRepository.GetLast()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return block.GetHash(), nil
+}
+
+// LocatorHeights returns the heights of the blocks in the block locator of a chain with the given height:
+// the last 10 blocks, then the steps back double until the genesis block (height 0), which is always the
+// last one.
+func LocatorHeights(height int32) []int32 {
+	heights := make([]int32, 0, p2p.MaxBlockLocatorHashes)
+	step := int32(1)
+	for ; height > 0; height -= step {
+		heights = append(heights, height)
+		if len(heights) >= 10 {
+			step *= 2
+		}
+	}
+	return append(heights, 0)
+}
+
+// LastBlockTip is the ChainTip of a node that is not loaded from a UTXO snapshot, the sync continues
+// after the last stored block.
+type LastBlockTip struct {
+	blockRepository BlockRepository
+}
+
+// NewLastBlockTip creates a new LastBlockTip.
+func NewLastBlockTip(br BlockRepository) LastBlockTip {
+	return LastBlockTip{blockRepository: br}
+}
+
+// SyncFrom returns the hash of the last stored block or the genesis block hash when no blocks are stored.
+func (t LastBlockTip) SyncFrom() ([32]byte, error) {
+	block, err := t.blockRepository.GetLast()
+	if errors.Is(err, ErrNotFound) {
+		return GenesisBlockHash, nil
+	}
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return block.GetHash(), nil
+}
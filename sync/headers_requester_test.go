@@ -30,23 +30,23 @@ func TestRequestHeadersFromLastBlock_HappyPath(t *testing.T) {
 		0x61, 0x7F, 0xC8, 0x1B, 0xC3, 0x88, 0x8A, 0x51, 0x32, 0x3A, 0x9F, 0xB8, 0xAA, 0x4B, 0x1E, 0x5E, 0x4A}
 
 	lastBlock := p2p.MsgBlock{BlockHeader: testutil.NewBlockHeader(blockHash)}
-	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", 1, lastBlock.GetHash(), [32]byte{0})
+	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", [][32]byte{lastBlock.GetHash()}, [32]byte{0})
 
 	ctrl := gomock.NewController(t)
 	blockRepo := sync.NewMockBlockRepository(ctrl)
 	blockRepo.EXPECT().GetLast().Return(lastBlock, nil)
 
 	out := make(chan *p2p.Message, 1)
-	hashes := make(chan [32]byte, 1)
-	hr := sync.NewHeadersRequester("mainnet", blockRepo, out, hashes)
+	locators := make(chan [][32]byte, 1)
+	hr := sync.NewHeadersRequester("mainnet", blockRepo, nil, nil, out, locators)
 
 	err := hr.RequestHeadersFromLastBlock()
 	require.NoError(t, err)
 
 	actual := <-out
-	actualHash := <-hashes
+	actualLocator := <-locators
 	require.Equal(t, msgGetHeaders, actual)
-	require.Equal(t, lastBlock.GetHash(), actualHash)
+	require.Equal(t, [][32]byte{lastBlock.GetHash()}, actualLocator)
 }
 
 func TestRequestHeadersFromLastBlock_WhenGetMsgFromDBFail(t *testing.T) {
@@ -54,8 +54,73 @@ func TestRequestHeadersFromLastBlock_WhenGetMsgFromDBFail(t *testing.T) {
 	blockRepo := sync.NewMockBlockRepository(ctrl)
 	blockRepo.EXPECT().GetLast().Return(p2p.MsgBlock{}, errors.New("err"))
 
-	hr := sync.NewHeadersRequester("", blockRepo, nil, nil)
+	hr := sync.NewHeadersRequester("", blockRepo, nil, nil, nil, nil)
 
 	err := hr.RequestHeadersFromLastBlock()
 	require.NotNil(t, errors.Join(sync.ErrFailedToGetLastBlock, errors.New("err"), err))
 }
+
+func TestRequestHeadersFromLastBlock_FromTheChainTip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blockRepo := sync.NewMockBlockRepository(ctrl)
+	chainTip := sync.NewMockChainTip(ctrl)
+	snapshotBase := [32]byte{1, 2, 3}
+	chainTip.EXPECT().SyncFrom().Return(snapshotBase, nil)
+	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", [][32]byte{snapshotBase}, [32]byte{0})
+
+	out := make(chan *p2p.Message, 1)
+	locators := make(chan [][32]byte, 1)
+	hr := sync.NewHeadersRequester("mainnet", blockRepo, nil, chainTip, out, locators)
+
+	require.NoError(t, hr.RequestHeadersFromLastBlock())
+	require.Equal(t, msgGetHeaders, <-out)
+	require.Equal(t, [][32]byte{snapshotBase}, <-locators)
+}
+
+func TestRequestHeadersFromLastBlock_WithTheBlockLocatorOfTheStoredHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blockRepo := sync.NewMockBlockRepository(ctrl)
+	headerRepo := sync.NewMockHeaderRepository(ctrl)
+	locator := [][32]byte{{3}, {2}, {1}, sync.GenesisBlockHash}
+	headerRepo.EXPECT().BlockLocator().Return(locator, nil)
+	msgGetHeaders, _ := p2p.NewMsgGetHeader("mainnet", locator, [32]byte{0})
+
+	out := make(chan *p2p.Message, 1)
+	locators := make(chan [][32]byte, 1)
+	hr := sync.NewHeadersRequester("mainnet", blockRepo, headerRepo, nil, out, locators)
+
+	require.NoError(t, hr.RequestHeadersFromLastBlock())
+	require.Equal(t, msgGetHeaders, <-out)
+	require.Equal(t, locator, <-locators)
+}
+
+func TestLocatorHeights(t *testing.T) {
+	require.Equal(t, []int32{0}, sync.LocatorHeights(0))
+	require.Equal(t, []int32{3, 2, 1, 0}, sync.LocatorHeights(3))
+	require.Equal(t, []int32{100, 99, 98, 97, 96, 95, 94, 93, 92, 91, 89, 85, 77, 61, 29, 0}, sync.LocatorHeights(100))
+
+	// the locator of a long chain stays short
+	heights := sync.LocatorHeights(1_000_000)
+	require.Len(t, heights, 29)
+	require.Equal(t, int32(0), heights[len(heights)-1])
+}
+
+func TestLastBlockTip_SyncFrom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blockRepo := sync.NewMockBlockRepository(ctrl)
+	lastBlock := p2p.MsgBlock{BlockHeader: testutil.NewBlockHeader(sync.GenesisBlockHash)}
+	gomock.InOrder(
+		blockRepo.EXPECT().GetLast().Return(p2p.MsgBlock{}, sync.ErrNotFound),
+		blockRepo.EXPECT().GetLast().Return(lastBlock, nil),
+	)
+
+	tip := sync.NewLastBlockTip(blockRepo)
+
+	hash, err := tip.SyncFrom()
+	require.NoError(t, err)
+	require.Equal(t, sync.GenesisBlockHash, hash)
+
+	hash, err = tip.SyncFrom()
+	require.NoError(t, err)
+	require.Equal(t, lastBlock.GetHash(), hash)
+}
@@ -1,6 +1,10 @@
 package sync
 
-import "github.com/EmilGeorgiev/btc-node/network/p2p"
+import (
+	"math/big"
+
+	"github.com/EmilGeorgiev/btc-node/network/p2p"
+)
 
 type StartStop interface {
 	Start()
@@ -25,10 +29,40 @@ type HeaderRequester interface {
 	RequestHeadersFromBlockHash([32]byte) error
 }
 
+// BlockRepository stores the blocks of the chain. It is implemented by every storage backend in
+// the db package and all of them must pass the conformance tests in db/dbtest.
+// Get returns ErrNotFound when the block is not stored. GetLast returns the last saved block, or the
+// block that extends it if it was saved before it, and ErrNotFound when no block is stored.
+// Delete removes the block, it is used to roll back the tip: when the last block is deleted its
+// previous block becomes the last one.
 type BlockRepository interface {
 	Save(block p2p.MsgBlock) error
 	Get(key [32]byte) (p2p.MsgBlock, error)
 	GetLast() (p2p.MsgBlock, error)
+	Delete(key [32]byte) error
+}
+
+// ChainTip returns the hash of the block after which the headers are requested when the sync starts.
+type ChainTip interface {
+	SyncFrom() ([32]byte, error)
+}
+
+// HeaderRepository stores the validated headers before their blocks are downloaded, so the header chain
+// survives restarts. AddHeaders returns an error when the first header doesn't connect to a stored header
+// or to the genesis block. BestHeader returns ErrNotFound when no headers are stored. GetHeader returns the
+// stored header and its height, or ErrNotFound. HeadersAfter returns
+// up to max headers of the best header chain after the block with the given hash. BlockLocator returns the
+// block locator of the best header chain, it ends with the genesis block hash. FindFork returns the first
+// hash of the block locator that is on the best header chain, or the genesis block hash. ChainWork returns
+// the total work of the chain up to the stored header with the given hash, or ErrNotFound.
+type HeaderRepository interface {
+	AddHeaders(headers []p2p.BlockHeader) error
+	BestHeader() (p2p.BlockHeader, int32, error)
+	GetHeader(hash [32]byte) (p2p.BlockHeader, int32, error)
+	HeadersAfter(hash [32]byte, max int) ([]p2p.BlockHeader, error)
+	BlockLocator() ([][32]byte, error)
+	FindFork(locator [][32]byte) ([32]byte, error)
+	ChainWork(hash [32]byte) (*big.Int, error)
 }
 
 type MsgSender interface {
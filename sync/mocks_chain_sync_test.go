@@ -5,6 +5,7 @@
 package sync
 
 import (
+	big "math/big"
 	reflect "reflect"
 
 	p2p "github.com/EmilGeorgiev/btc-node/network/p2p"
@@ -251,6 +252,20 @@ func (m *MockBlockRepository) EXPECT() *MockBlockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockBlockRepository) Delete(key [32]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlockRepositoryMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlockRepository)(nil).Delete), key)
+}
+
 // Get mocks base method.
 func (m *MockBlockRepository) Get(key [32]byte) (p2p.MsgBlock, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +310,173 @@ func (mr *MockBlockRepositoryMockRecorder) Save(block interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBlockRepository)(nil).Save), block)
 }
 
+// MockChainTip is a mock of ChainTip interface.
+type MockChainTip struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainTipMockRecorder
+}
+
+// MockChainTipMockRecorder is the mock recorder for MockChainTip.
+type MockChainTipMockRecorder struct {
+	mock *MockChainTip
+}
+
+// NewMockChainTip creates a new mock instance.
+func NewMockChainTip(ctrl *gomock.Controller) *MockChainTip {
+	mock := &MockChainTip{ctrl: ctrl}
+	mock.recorder = &MockChainTipMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainTip) EXPECT() *MockChainTipMockRecorder {
+	return m.recorder
+}
+
+// SyncFrom mocks base method.
+func (m *MockChainTip) SyncFrom() ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncFrom")
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncFrom indicates an expected call of SyncFrom.
+func (mr *MockChainTipMockRecorder) SyncFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncFrom", reflect.TypeOf((*MockChainTip)(nil).SyncFrom))
+}
+
+// MockHeaderRepository is a mock of HeaderRepository interface.
+type MockHeaderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeaderRepositoryMockRecorder
+}
+
+// MockHeaderRepositoryMockRecorder is the mock recorder for MockHeaderRepository.
+type MockHeaderRepositoryMockRecorder struct {
+	mock *MockHeaderRepository
+}
+
+// NewMockHeaderRepository creates a new mock instance.
+func NewMockHeaderRepository(ctrl *gomock.Controller) *MockHeaderRepository {
+	mock := &MockHeaderRepository{ctrl: ctrl}
+	mock.recorder = &MockHeaderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeaderRepository) EXPECT() *MockHeaderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddHeaders mocks base method.
+func (m *MockHeaderRepository) AddHeaders(headers []p2p.BlockHeader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHeaders", headers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddHeaders indicates an expected call of AddHeaders.
+func (mr *MockHeaderRepositoryMockRecorder) AddHeaders(headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHeaders", reflect.TypeOf((*MockHeaderRepository)(nil).AddHeaders), headers)
+}
+
+// BestHeader mocks base method.
+func (m *MockHeaderRepository) BestHeader() (p2p.BlockHeader, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BestHeader")
+	ret0, _ := ret[0].(p2p.BlockHeader)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BestHeader indicates an expected call of BestHeader.
+func (mr *MockHeaderRepositoryMockRecorder) BestHeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestHeader", reflect.TypeOf((*MockHeaderRepository)(nil).BestHeader))
+}
+
+// BlockLocator mocks base method.
+func (m *MockHeaderRepository) BlockLocator() ([][32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockLocator")
+	ret0, _ := ret[0].([][32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockLocator indicates an expected call of BlockLocator.
+func (mr *MockHeaderRepositoryMockRecorder) BlockLocator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockLocator", reflect.TypeOf((*MockHeaderRepository)(nil).BlockLocator))
+}
+
+// ChainWork mocks base method.
+func (m *MockHeaderRepository) ChainWork(hash [32]byte) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainWork", hash)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainWork indicates an expected call of ChainWork.
+func (mr *MockHeaderRepositoryMockRecorder) ChainWork(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainWork", reflect.TypeOf((*MockHeaderRepository)(nil).ChainWork), hash)
+}
+
+// FindFork mocks base method.
+func (m *MockHeaderRepository) FindFork(locator [][32]byte) ([32]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFork", locator)
+	ret0, _ := ret[0].([32]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFork indicates an expected call of FindFork.
+func (mr *MockHeaderRepositoryMockRecorder) FindFork(locator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFork", reflect.TypeOf((*MockHeaderRepository)(nil).FindFork), locator)
+}
+
+// GetHeader mocks base method.
+func (m *MockHeaderRepository) GetHeader(hash [32]byte) (p2p.BlockHeader, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", hash)
+	ret0, _ := ret[0].(p2p.BlockHeader)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockHeaderRepositoryMockRecorder) GetHeader(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockHeaderRepository)(nil).GetHeader), hash)
+}
+
+// HeadersAfter mocks base method.
+func (m *MockHeaderRepository) HeadersAfter(hash [32]byte, max int) ([]p2p.BlockHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeadersAfter", hash, max)
+	ret0, _ := ret[0].([]p2p.BlockHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadersAfter indicates an expected call of HeadersAfter.
+func (mr *MockHeaderRepositoryMockRecorder) HeadersAfter(hash, max interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadersAfter", reflect.TypeOf((*MockHeaderRepository)(nil).HeadersAfter), hash, max)
+}
+
 // MockMsgSender is a mock of MsgSender interface.
 type MockMsgSender struct {
 	ctrl     *gomock.Controller
@@ -11,10 +11,13 @@ import (
 	"time"
 )
 
-// RequestedHeaders are the blocks headers that were requested with MsgGetheaders.
+// RequestedHeaders are the blocks headers that were requested with MsgGetheaders. CumulativePoW is the work
+// of the headers and ChainWork is the work of the stored header chain from the genesis block up to the last
+// header, it is nil when the headers are not stored.
 type RequestedHeaders struct {
 	BlockHeaders  []p2p.BlockHeader
 	CumulativePoW *big.Int
+	ChainWork     *big.Int
 	IsValid       bool
 }
 
@@ -30,6 +33,14 @@ func (hvr RequestedHeaders) GetHeadersNumber() int {
 	return len(hvr.BlockHeaders)
 }
 
+const (
+	// overviewTimeout is the time for which the peer must reply to the getheaders of the chain overview.
+	overviewTimeout = 30 * time.Second
+	// maxHeadersPerReply is the maximum number of headers in a headers message, the peer has more headers
+	// after a full reply.
+	maxHeadersPerReply = 2000
+)
+
 type PeerSync struct {
 	headerRequester  HeaderRequester
 	syncWait         time.Duration
@@ -82,62 +93,63 @@ func (cs *PeerSync) Stop() {
 	log.Println("STOP PeerSync")
 }
 
-func (cs *PeerSync) StartChainOverview(peerAddr string, ch chan common.ChainOverview) {
+// StartChainOverview starts the overview of the peer's chain. The start height is the height of the peer's
+// best block from its version message.
+func (cs *PeerSync) StartChainOverview(peerAddr string, startHeight int32, ch chan common.ChainOverview) {
 	cs.isOverviewStarted.Store(true)
 	log.Println("start get chain overview from peersync")
-	go cs.getChainOverview(peerAddr, ch)
+	go cs.getChainOverview(peerAddr, startHeight, ch)
 }
 
-func (cs *PeerSync) getChainOverview(peerAddr string, ch chan common.ChainOverview) {
+// getChainOverview requests the headers with the block locator of the local chain, so the peer replies only
+// with the headers after the fork point. When the headers are stored, the next headers are requested until
+// the peer has no more of them, and the work of the overview is the work of the peer's whole chain, so the
+// overviews of the peers are comparable even when they are taken against different local tips. Otherwise
+// the overview is done after the first reply and its work is the work of the headers. A peer that doesn't
+// reply before the timeout doesn't have a valid chain.
+func (cs *PeerSync) getChainOverview(peerAddr string, startHeight int32, ch chan common.ChainOverview) {
 	defer cs.isOverviewStarted.Store(false)
-	cho := common.ChainOverview{Peer: peerAddr, CumulativeWork: big.NewInt(0), IsValid: true}
-	timer := time.NewTimer(30 * time.Second)
-	var lastHeadersValResult RequestedHeaders
-	_ = cs.headerRequester.RequestHeadersFromLastBlock()
+	cho := common.ChainOverview{Peer: peerAddr, StartHeight: startHeight, CumulativeWork: big.NewInt(0), IsValid: true}
+	timer := time.NewTimer(overviewTimeout)
+	defer timer.Stop()
+	if err := cs.headerRequester.RequestHeadersFromLastBlock(); err != nil {
+		log.Println("failed to request the headers for the chain overview:", err)
+	}
 
-Loop:
-	for {
+	for done := false; !done; {
 		select {
 		case <-cs.stop:
 			log.Println("stop chain sync iterations")
 			cs.done <- struct{}{}
 			return
-		case headersValResult := <-cs.requestedHeaders:
-			timer.Reset(cs.syncWait)
-
-			log.Printf("Last processed block is %x\n", p2p.Reverse(headersValResult.GetLastBlockHeaderHash()))
-			log.Println("Common number of processed headers:", cho.NumberOfBlocks)
-			//fmt.Printf("Receive processe headers from handler: %#v\n", headersValResult)
-			if headersValResult.GetHeadersNumber() == 0 {
-				log.Println("Stop the loop in peer sync. get last headers.")
-				break Loop
+		case headers := <-cs.requestedHeaders:
+			done = true
+			if !headers.IsValid {
+				log.Printf("headers of peer %s are invalid\n", peerAddr)
+				cho.IsValid = false
+				break
 			}
-
-			if headersValResult.GetLastBlockHeaderHash() == lastHeadersValResult.GetLastBlockHeaderHash() {
-				log.Println("prev block hash equal to the last processes block hash. Skip this:", p2p.Reverse(lastHeadersValResult.GetLastBlockHeaderHash()))
-				continue
+			cho.NumberOfBlocks += int64(headers.GetHeadersNumber())
+			if headers.ChainWork == nil {
+				if headers.CumulativePoW != nil {
+					cho.CumulativeWork.Add(cho.CumulativeWork, headers.CumulativePoW)
+				}
+				break
 			}
-
-			if !headersValResult.IsValid {
-				log.Printf("headers are invalid. Lastheader: %x\n", p2p.Reverse(lastHeadersValResult.GetLastBlockHeaderHash()))
-				cho.IsValid = false
-				break Loop
+			cho.CumulativeWork.Set(headers.ChainWork)
+			if headers.GetHeadersNumber() < maxHeadersPerReply {
+				break
 			}
-
-			cho.CumulativeWork = cho.CumulativeWork.Add(cho.CumulativeWork, headersValResult.CumulativePoW)
-			cho.NumberOfBlocks += int64(headersValResult.GetHeadersNumber())
-			log.Println("call the RequestHeadersFromBlockHash from PeerSync.getChecinOverview case1:", time.Now().String())
-			_ = cs.headerRequester.RequestHeadersFromBlockHash(headersValResult.GetLastBlockHeaderHash())
-			lastHeadersValResult = headersValResult
-		case <-timer.C:
-			if lastHeadersValResult.GetLastBlockHeaderHash() == [32]byte{} {
-				_ = cs.headerRequester.RequestHeadersFromLastBlock()
-				continue
+			if err := cs.headerRequester.RequestHeadersFromBlockHash(headers.GetLastBlockHeaderHash()); err != nil {
+				log.Println("failed to request the next headers for the chain overview:", err)
+				break
 			}
-			log.Printf("Request headers after waiting some seconds: %x\n", p2p.Reverse(lastHeadersValResult.GetLastBlockHeaderHash()))
-			timer.Reset(cs.syncWait)
-			log.Println("call the RequestHeadersFromBlockHash from PeerSync.getChecinOverview case2:", time.Now().String())
-			_ = cs.headerRequester.RequestHeadersFromBlockHash(lastHeadersValResult.GetLastBlockHeaderHash())
+			done = false
+			timer.Reset(overviewTimeout)
+		case <-timer.C:
+			log.Printf("peer %s doesn't reply to getheaders in %s\n", peerAddr, overviewTimeout)
+			cho.IsValid = false
+			done = true
 		}
 	}
 
@@ -145,7 +157,30 @@ Loop:
 	log.Println("stop get chain overview")
 }
 
+// BlocksAnnounced requests the headers after the best stored header when the peer announces new blocks, so
+// the tip of a peer from which the headers are not synced is known from its validated headers. The headers
+// of the sync peer are requested by the sync, and the replies to the previous announcements are dropped,
+// nobody waits for them.
+func (cs *PeerSync) BlocksAnnounced() {
+	if cs.isSyncStarted.Load() || cs.isOverviewStarted.Load() {
+		return
+	}
+	for drained := false; !drained; {
+		select {
+		case <-cs.requestedHeaders:
+		default:
+			drained = true
+		}
+	}
+	cs.requestHeaders()
+}
+
 func (cs *PeerSync) start() {
+	// the headers that are received after the last sync was stopped, e.g. when the node switched to another
+	// sync peer, are stale
+	for len(cs.requestedHeaders) > 0 {
+		<-cs.requestedHeaders
+	}
 	log.Println("Call RequestHeaders from last block in PeerSync.start:", time.Now().String())
 	_ = cs.headerRequester.RequestHeadersFromLastBlock()
 	timer := time.NewTimer(30 * time.Second)
@@ -1,12 +1,14 @@
 package sync_test
 
 import (
+	"github.com/EmilGeorgiev/btc-node/common"
 	"github.com/EmilGeorgiev/btc-node/common/testutil"
 	"github.com/EmilGeorgiev/btc-node/network/p2p"
 	"github.com/EmilGeorgiev/btc-node/node"
 	"github.com/EmilGeorgiev/btc-node/sync"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"math/big"
 	"testing"
 	"time"
 )
@@ -62,3 +64,123 @@ Loop:
 
 	chs.Stop()
 }
+
+func TestPeerSync_ChainOverviewHasOnlyTheHeadersAfterTheForkPoint(t *testing.T) {
+	bh := testutil.NewBlockHeader(sync.GenesisBlockHash)
+
+	ctrl := gomock.NewController(t)
+	headerRequester := sync.NewMockHeaderRequester(ctrl)
+	headerRequester.EXPECT().RequestHeadersFromLastBlock().Return(nil).Times(1)
+
+	requestedHeaders := make(chan sync.RequestedHeaders, 1)
+	overview := make(chan common.ChainOverview, 1)
+	chs := sync.NewPeerSync(headerRequester, 10*time.Minute, requestedHeaders)
+	chs.StartChainOverview("127.0.0.1:8333", 1000, overview)
+
+	// the headers are not stored, so the overview is done after the first reply, the rest of the headers
+	// are requested during the sync
+	requestedHeaders <- sync.RequestedHeaders{BlockHeaders: []p2p.BlockHeader{bh}, CumulativePoW: big.NewInt(100), IsValid: true}
+
+	expected := common.ChainOverview{
+		Peer:           "127.0.0.1:8333",
+		StartHeight:    1000,
+		NumberOfBlocks: 1,
+		CumulativeWork: big.NewInt(100),
+		IsValid:        true,
+	}
+	require.Equal(t, expected, <-overview)
+}
+
+func TestPeerSync_ChainOverviewHasTheWorkOfThePeersWholeStoredChain(t *testing.T) {
+	full := make([]p2p.BlockHeader, 2000)
+	prev := sync.GenesisBlockHash
+	for i := range full {
+		full[i] = testutil.NewBlockHeader(prev)
+		prev = sync.Hash(full[i])
+	}
+	last := testutil.NewBlockHeader(prev)
+
+	ctrl := gomock.NewController(t)
+	headerRequester := sync.NewMockHeaderRequester(ctrl)
+	headerRequester.EXPECT().RequestHeadersFromLastBlock().Return(nil).Times(1)
+	// the reply is full, so the peer has more headers
+	headerRequester.EXPECT().RequestHeadersFromBlockHash(prev).Return(nil).Times(1)
+
+	requestedHeaders := make(chan sync.RequestedHeaders, 2)
+	overview := make(chan common.ChainOverview, 1)
+	chs := sync.NewPeerSync(headerRequester, 10*time.Minute, requestedHeaders)
+	chs.StartChainOverview("127.0.0.1:8333", 1000, overview)
+
+	requestedHeaders <- sync.RequestedHeaders{BlockHeaders: full, CumulativePoW: big.NewInt(2000), ChainWork: big.NewInt(3000), IsValid: true}
+	requestedHeaders <- sync.RequestedHeaders{BlockHeaders: []p2p.BlockHeader{last}, CumulativePoW: big.NewInt(1), ChainWork: big.NewInt(3001), IsValid: true}
+
+	// the work is the work of the chain from the genesis block, not of the headers after the local tip
+	expected := common.ChainOverview{
+		Peer:           "127.0.0.1:8333",
+		StartHeight:    1000,
+		NumberOfBlocks: 2001,
+		CumulativeWork: big.NewInt(3001),
+		IsValid:        true,
+	}
+	require.Equal(t, expected, <-overview)
+}
+
+func TestPeerSync_ChainOverviewWhenTheHeadersAreInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	headerRequester := sync.NewMockHeaderRequester(ctrl)
+	headerRequester.EXPECT().RequestHeadersFromLastBlock().Return(nil).Times(1)
+
+	requestedHeaders := make(chan sync.RequestedHeaders, 1)
+	overview := make(chan common.ChainOverview, 1)
+	chs := sync.NewPeerSync(headerRequester, 10*time.Minute, requestedHeaders)
+	chs.StartChainOverview("127.0.0.1:8333", 1000, overview)
+
+	requestedHeaders <- sync.RequestedHeaders{IsValid: false}
+
+	actual := <-overview
+	require.False(t, actual.IsValid)
+	require.Equal(t, int32(1000), actual.StartHeight)
+}
+
+func TestPeerSync_RestartDropsTheHeadersThatAreReceivedWhileItIsStopped(t *testing.T) {
+	bh := testutil.NewBlockHeader(sync.GenesisBlockHash)
+
+	ctrl := gomock.NewController(t)
+	headerRequester := sync.NewMockHeaderRequester(ctrl)
+	requested := make(chan struct{}, 2)
+	headerRequester.EXPECT().RequestHeadersFromLastBlock().Do(func() { requested <- struct{}{} }).Return(nil).Times(2)
+
+	requestedHeaders := make(chan sync.RequestedHeaders, 1)
+	chs := sync.NewPeerSync(headerRequester, 10*time.Minute, requestedHeaders)
+	chs.Start()
+	<-requested
+	chs.Stop()
+
+	// the reply to the last request is received after the sync is stopped, the sync is started again from
+	// the last stored header instead of requesting the headers after it
+	requestedHeaders <- sync.RequestedHeaders{BlockHeaders: []p2p.BlockHeader{bh}}
+	chs.Start()
+	<-requested
+	require.Equal(t, 0, len(requestedHeaders))
+	chs.Stop()
+}
+
+func TestPeerSync_BlocksAnnouncedRequestsTheHeadersWhenTheyAreNotSynced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	headerRequester := sync.NewMockHeaderRequester(ctrl)
+	// once for the announcement and once when the sync starts
+	headerRequester.EXPECT().RequestHeadersFromLastBlock().Return(nil).Times(2)
+
+	requestedHeaders := make(chan sync.RequestedHeaders, 2)
+	chs := sync.NewPeerSync(headerRequester, 10*time.Minute, requestedHeaders)
+	// the replies to the previous announcements are dropped
+	requestedHeaders <- sync.RequestedHeaders{IsValid: true}
+	requestedHeaders <- sync.RequestedHeaders{IsValid: true}
+	chs.BlocksAnnounced()
+	require.Equal(t, 0, len(requestedHeaders))
+
+	// the headers of the sync peer are requested by the sync
+	chs.Start()
+	chs.BlocksAnnounced()
+	chs.Stop()
+}